@@ -4,9 +4,13 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"os"
+	"os/signal"
 	"planning-poker/internal/config"
 	"planning-poker/internal/setup"
 	"strings"
+	"syscall"
+	"time"
 
 	"github.com/bruno303/go-toolkit/pkg/log"
 	"github.com/gorilla/handlers"
@@ -25,6 +29,10 @@ var cfg *config.Config
 // @in header
 // @name Authorization
 // @description Bearer token format. Use: Bearer <api_key>
+// @securityDefinitions.apikey ParticipantAuth
+// @in header
+// @name Authorization
+// @description Use: Bot <token> for bots, Bearer <OIDC token> for participants of the room
 func main() {
 	ctx := context.Background()
 
@@ -42,21 +50,19 @@ func main() {
 		logger.Error(ctx, "Error configuring metrics", err)
 		return
 	}
-	defer func() {
-		if err := metricsShutdown(ctx); err != nil {
-			logger.Error(ctx, "Error shutting down metrics", err)
-		}
-	}()
 
-	shutdown := setup.ConfigureTrace(ctx, cfg, logger)
-	defer func() {
-		if err := shutdown(ctx); err != nil {
-			logger.Error(ctx, "Error shutting down tracing", err)
-		}
-	}()
+	traceShutdown := setup.ConfigureTrace(ctx, cfg, logger)
 
 	container := setup.NewContainer(cfg)
 
+	watcherCtx, stopWatcher := context.WithCancel(ctx)
+	defer stopWatcher()
+	go container.Infra.VotingTimerWatcher.Run(watcherCtx)
+	if container.Infra.RoomReaper != nil {
+		go container.Infra.RoomReaper.Run(watcherCtx)
+	}
+	go container.App.Webhooks.Run(watcherCtx)
+
 	r := mux.NewRouter()
 	configureMiddlewares(ctx, r, logger)
 	setup.ConfigureAPIs(r, container)
@@ -67,13 +73,57 @@ func main() {
 		port = 8080
 	}
 
-	logger.Info(ctx, "Listening on port %d", port)
-	if err := http.ListenAndServe(
-		fmt.Sprintf(":%d", port),
-		loggingMiddleware(corsMiddleware(r, logger), logger),
-	); err != nil {
+	server := &http.Server{
+		Addr:    fmt.Sprintf(":%d", port),
+		Handler: loggingMiddleware(corsMiddleware(r, logger), logger),
+	}
+	serverErr := make(chan error, 1)
+	go func() {
+		logger.Info(ctx, "Listening on port %d", port)
+		serverErr <- server.ListenAndServe()
+	}()
+
+	signalCtx, stopSignals := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stopSignals()
+	select {
+	case <-signalCtx.Done():
+		logger.Info(ctx, "Shutting down, draining connections")
+	case err := <-serverErr:
 		logger.Error(ctx, "Error starting server", err)
 	}
+	stopWatcher()
+
+	shutdownCtx, cancel := context.WithTimeout(ctx, shutdownTimeout())
+	defer cancel()
+
+	// websockets were hijacked from the server, which only stops accepting
+	// new connections; the container drains them. Event streams are still
+	// served and keep the server from shutting down until they are drained.
+	server.RegisterOnShutdown(func() {
+		if err := container.DrainEventStreams(shutdownCtx); err != nil {
+			logger.Error(ctx, "Error draining event streams", err)
+		}
+	})
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		logger.Error(ctx, "Error shutting down server", err)
+	}
+	if err := container.Shutdown(shutdownCtx); err != nil {
+		logger.Error(ctx, "Error shutting down container", err)
+	}
+	if err := traceShutdown(shutdownCtx); err != nil {
+		logger.Error(ctx, "Error shutting down tracing", err)
+	}
+	if err := metricsShutdown(shutdownCtx); err != nil {
+		logger.Error(ctx, "Error shutting down metrics", err)
+	}
+	logger.Info(ctx, "Shutdown complete")
+}
+
+func shutdownTimeout() time.Duration {
+	if cfg.API.ShutdownTimeout <= 0 {
+		return 20 * time.Second
+	}
+	return cfg.API.ShutdownTimeout
 }
 
 func configureMiddlewares(ctx context.Context, r *mux.Router, logger log.Logger) {
@@ -86,20 +136,7 @@ func configureMiddlewares(ctx context.Context, r *mux.Router, logger log.Logger)
 }
 
 func getCORSOrigins(logger log.Logger) []string {
-	var allowedOrigins []string
-	corsOrigins := cfg.API.CorsAllowedOrigins
-
-	if corsOrigins == "" {
-		allowedOrigins = []string{
-			"*",
-			"http://localhost:3000",
-			"http://127.0.0.1:3000",
-			"http://localhost:8080",
-		}
-	} else {
-		allowedOrigins = strings.Split(corsOrigins, ",")
-	}
-
+	allowedOrigins := cfg.AllowedOrigins()
 	logger.Debug(context.Background(), "Allowed origins: %v", allowedOrigins)
 	return allowedOrigins
 }
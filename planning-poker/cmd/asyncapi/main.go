@@ -0,0 +1,26 @@
+// Command asyncapi writes the AsyncAPI document of a version of the websocket
+// protocol.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"planning-poker/internal/infra/bus"
+)
+
+func main() {
+	output := flag.String("o", "asyncapi.json", "file to write the document to")
+	version := flag.String("version", bus.LatestProtocol, "protocol version to describe")
+	flag.Parse()
+
+	document, err := bus.AsyncAPI(*version)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if err := os.WriteFile(*output, append(document, '\n'), 0o644); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
@@ -41,6 +41,7 @@ type Participant = {
   hasVoted: boolean
   isSpectator: boolean
   isOwner: boolean
+  bot: boolean
 }
 
 export default function PlanningPoker() {
@@ -578,7 +579,7 @@ export default function PlanningPoker() {
                           )}
                         </div>
                         <div style={styles.participantStatus}>
-                          {participant.isSpectator ? 'Spectator' : participant.hasVoted ? 'Voted' : 'Waiting...'}
+                          {participant.bot ? 'Bot' : participant.isSpectator ? 'Spectator' : participant.hasVoted ? 'Voted' : 'Waiting...'}
                         </div>
                       </div>
                       <div style={styles.participantRight}>
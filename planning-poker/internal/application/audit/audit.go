@@ -0,0 +1,114 @@
+package audit
+
+import (
+	"context"
+	"planning-poker/internal/application/timer"
+	"time"
+
+	"github.com/bruno303/go-toolkit/pkg/log"
+)
+
+type (
+	Action  string
+	Outcome string
+)
+
+const (
+	ActionKickClient        Action = "kick-client"
+	ActionRemoveClient      Action = "remove-client"
+	ActionToggleOwner       Action = "toggle-owner"
+	ActionRemoveStory       Action = "remove-story"
+	ActionOwnershipTransfer Action = "ownership-transfer"
+
+	OutcomeSucceeded Outcome = "succeeded"
+	OutcomeFailed    Outcome = "failed"
+)
+
+// AdminActorPrefix marks actors that are admin keys rather than clients
+const AdminActorPrefix = "admin:"
+
+type (
+	// Record is a moderation or ownership action. Actor and Target are client
+	// IDs, or admin key names prefixed with AdminActorPrefix; their names are
+	// kept as well since anonymous client IDs mean nothing later on.
+	Record struct {
+		ID         string
+		Timestamp  time.Time
+		RoomID     string
+		Action     Action
+		Actor      string
+		ActorName  string
+		Target     string
+		TargetName string
+		Outcome    Outcome
+		Error      string
+	}
+
+	// Filter selects records, empty fields match every record.
+	Filter struct {
+		RoomID string
+		Actor  string
+		From   time.Time
+		To     time.Time
+		Limit  int
+	}
+
+	// Sink stores the records. Query returns the newest records first.
+	Sink interface {
+		Write(ctx context.Context, record Record) error
+		Query(ctx context.Context, filter Filter) ([]Record, error)
+	}
+
+	// Auditor records the outcome of an audited action. Failing to record it
+	// does not fail the action.
+	Auditor interface {
+		Record(ctx context.Context, record Record, err error)
+	}
+
+	SinkAuditor struct {
+		sink   Sink
+		clock  timer.Clock
+		logger log.Logger
+	}
+
+	discard struct{}
+)
+
+// Discard drops every record.
+var Discard Auditor = discard{}
+
+var _ Auditor = (*SinkAuditor)(nil)
+
+func AdminActor(keyName string) string {
+	return AdminActorPrefix + keyName
+}
+
+func (f Filter) Matches(record Record) bool {
+	return (f.RoomID == "" || record.RoomID == f.RoomID) &&
+		(f.Actor == "" || record.Actor == f.Actor) &&
+		(f.From.IsZero() || !record.Timestamp.Before(f.From)) &&
+		(f.To.IsZero() || record.Timestamp.Before(f.To))
+}
+
+func NewSinkAuditor(sink Sink, clock timer.Clock) *SinkAuditor {
+	return &SinkAuditor{
+		sink:   sink,
+		clock:  clock,
+		logger: log.NewLogger("audit"),
+	}
+}
+
+func (a *SinkAuditor) Record(ctx context.Context, record Record, err error) {
+	record.Timestamp = a.clock.Now().UTC()
+	record.Outcome = OutcomeSucceeded
+	if err != nil {
+		record.Outcome = OutcomeFailed
+		record.Error = err.Error()
+	}
+
+	if err := a.sink.Write(ctx, record); err != nil {
+		a.logger.Error(ctx, "Failed to write audit record", err)
+	}
+}
+
+func (discard) Record(context.Context, Record, error) {}
@@ -0,0 +1,3 @@
+package audit
+
+//go:generate go tool mockgen -destination mocks.go -typed -package audit . Sink,Auditor
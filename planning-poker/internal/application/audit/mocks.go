@@ -0,0 +1,178 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: planning-poker/internal/application/audit (interfaces: Sink,Auditor)
+//
+// Generated by this command:
+//
+//	mockgen -destination mocks.go -typed -package audit . Sink,Auditor
+//
+
+// Package audit is a generated GoMock package.
+package audit
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockSink is a mock of Sink interface.
+type MockSink struct {
+	ctrl     *gomock.Controller
+	recorder *MockSinkMockRecorder
+	isgomock struct{}
+}
+
+// MockSinkMockRecorder is the mock recorder for MockSink.
+type MockSinkMockRecorder struct {
+	mock *MockSink
+}
+
+// NewMockSink creates a new mock instance.
+func NewMockSink(ctrl *gomock.Controller) *MockSink {
+	mock := &MockSink{ctrl: ctrl}
+	mock.recorder = &MockSinkMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSink) EXPECT() *MockSinkMockRecorder {
+	return m.recorder
+}
+
+// Query mocks base method.
+func (m *MockSink) Query(ctx context.Context, filter Filter) ([]Record, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Query", ctx, filter)
+	ret0, _ := ret[0].([]Record)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Query indicates an expected call of Query.
+func (mr *MockSinkMockRecorder) Query(ctx, filter any) *MockSinkQueryCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Query", reflect.TypeOf((*MockSink)(nil).Query), ctx, filter)
+	return &MockSinkQueryCall{Call: call}
+}
+
+// MockSinkQueryCall wrap *gomock.Call
+type MockSinkQueryCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockSinkQueryCall) Return(arg0 []Record, arg1 error) *MockSinkQueryCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockSinkQueryCall) Do(f func(context.Context, Filter) ([]Record, error)) *MockSinkQueryCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockSinkQueryCall) DoAndReturn(f func(context.Context, Filter) ([]Record, error)) *MockSinkQueryCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Write mocks base method.
+func (m *MockSink) Write(ctx context.Context, record Record) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Write", ctx, record)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Write indicates an expected call of Write.
+func (mr *MockSinkMockRecorder) Write(ctx, record any) *MockSinkWriteCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Write", reflect.TypeOf((*MockSink)(nil).Write), ctx, record)
+	return &MockSinkWriteCall{Call: call}
+}
+
+// MockSinkWriteCall wrap *gomock.Call
+type MockSinkWriteCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockSinkWriteCall) Return(arg0 error) *MockSinkWriteCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockSinkWriteCall) Do(f func(context.Context, Record) error) *MockSinkWriteCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockSinkWriteCall) DoAndReturn(f func(context.Context, Record) error) *MockSinkWriteCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockAuditor is a mock of Auditor interface.
+type MockAuditor struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuditorMockRecorder
+	isgomock struct{}
+}
+
+// MockAuditorMockRecorder is the mock recorder for MockAuditor.
+type MockAuditorMockRecorder struct {
+	mock *MockAuditor
+}
+
+// NewMockAuditor creates a new mock instance.
+func NewMockAuditor(ctrl *gomock.Controller) *MockAuditor {
+	mock := &MockAuditor{ctrl: ctrl}
+	mock.recorder = &MockAuditorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuditor) EXPECT() *MockAuditorMockRecorder {
+	return m.recorder
+}
+
+// Record mocks base method.
+func (m *MockAuditor) Record(ctx context.Context, record Record, err error) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Record", ctx, record, err)
+}
+
+// Record indicates an expected call of Record.
+func (mr *MockAuditorMockRecorder) Record(ctx, record, err any) *MockAuditorRecordCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Record", reflect.TypeOf((*MockAuditor)(nil).Record), ctx, record, err)
+	return &MockAuditorRecordCall{Call: call}
+}
+
+// MockAuditorRecordCall wrap *gomock.Call
+type MockAuditorRecordCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockAuditorRecordCall) Return() *MockAuditorRecordCall {
+	c.Call = c.Call.Return()
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockAuditorRecordCall) Do(f func(context.Context, Record, error)) *MockAuditorRecordCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockAuditorRecordCall) DoAndReturn(f func(context.Context, Record, error)) *MockAuditorRecordCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
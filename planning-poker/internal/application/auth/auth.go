@@ -0,0 +1,17 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"planning-poker/internal/domain/entity"
+)
+
+// ErrInvalidToken is returned for tokens that are malformed, expired, not
+// signed by the identity provider or issued for another audience.
+var ErrInvalidToken = errors.New("invalid token")
+
+// TokenVerifier checks the token presented by a participant and returns its
+// verified identity.
+type TokenVerifier interface {
+	Verify(ctx context.Context, token string) (entity.Identity, error)
+}
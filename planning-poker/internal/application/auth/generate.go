@@ -0,0 +1,3 @@
+package auth
+
+//go:generate go tool mockgen -destination mocks.go -typed -package auth . TokenVerifier
@@ -0,0 +1,81 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: planning-poker/internal/application/auth (interfaces: TokenVerifier)
+//
+// Generated by this command:
+//
+//	mockgen -destination mocks.go -typed -package auth . TokenVerifier
+//
+
+// Package auth is a generated GoMock package.
+package auth
+
+import (
+	context "context"
+	entity "planning-poker/internal/domain/entity"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockTokenVerifier is a mock of TokenVerifier interface.
+type MockTokenVerifier struct {
+	ctrl     *gomock.Controller
+	recorder *MockTokenVerifierMockRecorder
+	isgomock struct{}
+}
+
+// MockTokenVerifierMockRecorder is the mock recorder for MockTokenVerifier.
+type MockTokenVerifierMockRecorder struct {
+	mock *MockTokenVerifier
+}
+
+// NewMockTokenVerifier creates a new mock instance.
+func NewMockTokenVerifier(ctrl *gomock.Controller) *MockTokenVerifier {
+	mock := &MockTokenVerifier{ctrl: ctrl}
+	mock.recorder = &MockTokenVerifierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTokenVerifier) EXPECT() *MockTokenVerifierMockRecorder {
+	return m.recorder
+}
+
+// Verify mocks base method.
+func (m *MockTokenVerifier) Verify(ctx context.Context, token string) (entity.Identity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Verify", ctx, token)
+	ret0, _ := ret[0].(entity.Identity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Verify indicates an expected call of Verify.
+func (mr *MockTokenVerifierMockRecorder) Verify(ctx, token any) *MockTokenVerifierVerifyCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Verify", reflect.TypeOf((*MockTokenVerifier)(nil).Verify), ctx, token)
+	return &MockTokenVerifierVerifyCall{Call: call}
+}
+
+// MockTokenVerifierVerifyCall wrap *gomock.Call
+type MockTokenVerifierVerifyCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockTokenVerifierVerifyCall) Return(arg0 entity.Identity, arg1 error) *MockTokenVerifierVerifyCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockTokenVerifierVerifyCall) Do(f func(context.Context, string) (entity.Identity, error)) *MockTokenVerifierVerifyCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockTokenVerifierVerifyCall) DoAndReturn(f func(context.Context, string) (entity.Identity, error)) *MockTokenVerifierVerifyCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
@@ -1,8 +1,38 @@
 package lock
 
-import "context"
+import (
+	"context"
+	"errors"
+)
 
 type LockManager interface {
 	ExecuteWithLock(ctx context.Context, key string, fn func(context.Context) error) error
 	WithLock(ctx context.Context, key string, fn func(context.Context) (any, error)) (any, error)
 }
+
+// ErrLockExpired is returned when a lock was lost while its holder was still
+// working, either because its lease could not be extended or because a writer
+// saw a newer fencing token.
+var ErrLockExpired = errors.New("lock expired before the work finished")
+
+type fencingTokenKey struct{}
+
+type fencingToken struct {
+	key   string
+	token int64
+}
+
+// WithFencingToken records the token handed out with the lock of key, so that
+// writers can reject work from holders whose lock was taken over.
+func WithFencingToken(ctx context.Context, key string, token int64) context.Context {
+	return context.WithValue(ctx, fencingTokenKey{}, fencingToken{key: key, token: token})
+}
+
+// FencingToken returns the token of the lock of key held by the caller, if any.
+func FencingToken(ctx context.Context, key string) (int64, bool) {
+	fence, ok := ctx.Value(fencingTokenKey{}).(fencingToken)
+	if !ok || fence.key != key {
+		return 0, false
+	}
+	return fence.token, true
+}
@@ -17,6 +17,9 @@ const (
 	PlanningPokerActiveUsersMetric = "planning_poker_active_users"
 	PlanningPokerUsersTotalMetric  = "planning_poker_users_total"
 	PlanningPokerActiveRoomsMetric = "planning_poker_active_rooms"
+	PlanningPokerThrottledMetric   = "planning_poker_throttled_total"
+
+	PlanningPokerRejectedUpgradesMetric = "planning_poker_rejected_websocket_upgrades_total"
 )
 
 func NewPlanningPokerMetric() PlanningPokerMetric {
@@ -76,3 +79,15 @@ func (m PlanningPokerMetric) IncrementActiveRoomsCounter(ctx context.Context) {
 func (m PlanningPokerMetric) DecrementActiveRoomsCounter(ctx context.Context) {
 	_ = m.meter.AddCounter(ctx, PlanningPokerActiveRoomsMetric, "", "", -1)
 }
+
+// IncrementThrottled counts a websocket message or HTTP request rejected by a
+// rate limit.
+func (m PlanningPokerMetric) IncrementThrottled(ctx context.Context) {
+	_ = m.meter.AddCounter(ctx, PlanningPokerThrottledMetric, "", "", 1)
+}
+
+// IncrementRejectedUpgrades counts a websocket upgrade refused for its origin
+// or because its IP address holds too many connections.
+func (m PlanningPokerMetric) IncrementRejectedUpgrades(ctx context.Context) {
+	_ = m.meter.AddCounter(ctx, PlanningPokerRejectedUpgradesMetric, "", "", 1)
+}
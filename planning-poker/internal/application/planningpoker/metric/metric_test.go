@@ -126,6 +126,18 @@ func TestPlanningPokerMetric_CounterMethods_RecordExpectedCalls(t *testing.T) {
 			expectedName:  PlanningPokerActiveRoomsMetric,
 			expectedValue: -1,
 		},
+		{
+			name:          "increment throttled",
+			invoke:        m.IncrementThrottled,
+			expectedName:  PlanningPokerThrottledMetric,
+			expectedValue: 1,
+		},
+		{
+			name:          "increment rejected upgrades",
+			invoke:        m.IncrementRejectedUpgrades,
+			expectedName:  PlanningPokerRejectedUpgradesMetric,
+			expectedValue: 1,
+		},
 	}
 
 	for _, tt := range tests {
@@ -186,6 +198,8 @@ func TestPlanningPokerMetric_MetricNames(t *testing.T) {
 		{name: "active users", constant: PlanningPokerActiveUsersMetric, expected: "planning_poker_active_users"},
 		{name: "users total", constant: PlanningPokerUsersTotalMetric, expected: "planning_poker_users_total"},
 		{name: "active rooms", constant: PlanningPokerActiveRoomsMetric, expected: "planning_poker_active_rooms"},
+		{name: "throttled", constant: PlanningPokerThrottledMetric, expected: "planning_poker_throttled_total"},
+		{name: "rejected upgrades", constant: PlanningPokerRejectedUpgradesMetric, expected: "planning_poker_rejected_websocket_upgrades_total"},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,182 @@
+package report
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"strconv"
+	"strings"
+)
+
+type Format string
+
+const (
+	FormatJSON     Format = "json"
+	FormatCSV      Format = "csv"
+	FormatMarkdown Format = "markdown"
+
+	DefaultFormat = FormatJSON
+)
+
+var ErrUnsupportedFormat = errors.New("unsupported report format")
+
+var contentTypes = map[Format]string{
+	FormatJSON:     "application/json",
+	FormatCSV:      "text/csv",
+	FormatMarkdown: "text/markdown",
+}
+
+func ParseFormat(format string) (Format, error) {
+	switch strings.ToLower(strings.TrimSpace(format)) {
+	case "":
+		return DefaultFormat, nil
+	case "json":
+		return FormatJSON, nil
+	case "csv":
+		return FormatCSV, nil
+	case "markdown", "md":
+		return FormatMarkdown, nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrUnsupportedFormat, format)
+	}
+}
+
+// FormatFromAccept picks the first format of an Accept header that can be
+// rendered, falling back to the default one.
+func FormatFromAccept(accept string) Format {
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		for format, contentType := range contentTypes {
+			if mediaType == contentType {
+				return format
+			}
+		}
+	}
+	return DefaultFormat
+}
+
+func (f Format) ContentType() string {
+	return contentTypes[f]
+}
+
+func (f Format) Extension() string {
+	if f == FormatMarkdown {
+		return "md"
+	}
+	return string(f)
+}
+
+func Render(report Report, format Format) ([]byte, error) {
+	switch format {
+	case FormatJSON:
+		return json.MarshalIndent(report, "", "  ")
+	case FormatCSV:
+		return renderCSV(report)
+	case FormatMarkdown:
+		return renderMarkdown(report), nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedFormat, format)
+	}
+}
+
+func renderCSV(report Report) ([]byte, error) {
+	participants := report.Participants()
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+
+	header := []string{"story", "voted", "result", "most_appearing_votes"}
+	for _, participant := range participants {
+		header = append(header, csvCell(participant.Name))
+	}
+	if err := w.Write(header); err != nil {
+		return nil, err
+	}
+
+	for _, story := range report.Stories {
+		row := []string{
+			csvCell(story.Name),
+			strconv.FormatBool(story.Voted),
+			formatResult(story.Result),
+			csvCell(strings.Join(story.MostAppearingVotes, " ")),
+		}
+		for _, participant := range participants {
+			vote, _ := story.VoteOf(participant.ID)
+			row = append(row, csvCell(vote))
+		}
+		if err := w.Write(row); err != nil {
+			return nil, err
+		}
+	}
+
+	w.Flush()
+	return buf.Bytes(), w.Error()
+}
+
+// csvCell keeps spreadsheets from running a user-controlled value as a
+// formula, by prefixing the characters that start one with a quote.
+func csvCell(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+func renderMarkdown(report Report) []byte {
+	var buf bytes.Buffer
+
+	fmt.Fprintf(&buf, "# Planning poker session %s\n\n", report.RoomID)
+	fmt.Fprintf(&buf, "Deck: %s\n\n", report.Deck)
+	buf.WriteString("| Story | Result | Most voted | Votes |\n")
+	buf.WriteString("| --- | --- | --- | --- |\n")
+
+	for _, story := range report.Stories {
+		votes := make([]string, 0, len(story.Votes))
+		for _, vote := range story.Votes {
+			votes = append(votes, fmt.Sprintf("%s: %s", vote.Participant, valueOrDash(vote.Vote)))
+		}
+
+		result := formatResult(story.Result)
+		if !story.Voted {
+			result = "not voted"
+		}
+
+		fmt.Fprintf(&buf, "| %s | %s | %s | %s |\n",
+			escapeMarkdownCell(story.Name),
+			valueOrDash(result),
+			valueOrDash(strings.Join(story.MostAppearingVotes, ", ")),
+			valueOrDash(escapeMarkdownCell(strings.Join(votes, ", "))),
+		)
+	}
+
+	return buf.Bytes()
+}
+
+func formatResult(result *float32) string {
+	if result == nil {
+		return ""
+	}
+	return strconv.FormatFloat(float64(*result), 'f', -1, 32)
+}
+
+func valueOrDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
+
+func escapeMarkdownCell(value string) string {
+	value = strings.ReplaceAll(value, "|", "\\|")
+	return strings.ReplaceAll(value, "\n", " ")
+}
+
+func Filename(roomID string, format Format) string {
+	return fmt.Sprintf("planning-poker-%s.%s", roomID, format.Extension())
+}
@@ -0,0 +1,123 @@
+package report
+
+import (
+	"fmt"
+	"planning-poker/internal/domain/entity"
+	"slices"
+	"strconv"
+
+	"github.com/samber/lo"
+)
+
+type (
+	// Report is a snapshot of a room backlog meant to be copied into an
+	// issue tracker once the session is over.
+	Report struct {
+		RoomID  string  `json:"roomId"`
+		Deck    string  `json:"deck"`
+		Stories []Story `json:"stories"`
+	}
+	Story struct {
+		Name               string   `json:"name"`
+		Voted              bool     `json:"voted"`
+		Result             *float32 `json:"result,omitempty"`
+		MostAppearingVotes []string `json:"mostAppearingVotes"`
+		Votes              []Vote   `json:"votes"`
+	}
+	Vote struct {
+		ParticipantID string `json:"participantId"`
+		// Participant is the name of the participant, unique in the report
+		Participant string `json:"participant"`
+		Vote        string `json:"vote"`
+	}
+	Participant struct {
+		ID   string
+		Name string
+	}
+)
+
+// anonymousParticipant labels the participants that never set a name.
+const anonymousParticipant = "Anonymous"
+
+func New(room *entity.Room) Report {
+	deck := room.EffectiveDeck()
+	labels := participantLabels(room.Stories)
+
+	return Report{
+		RoomID: room.ID,
+		Deck:   deck.Name,
+		Stories: lo.Map(room.Stories, func(s entity.Story, _ int) Story {
+			return mapStory(s, deck, labels)
+		}),
+	}
+}
+
+// participantLabels names the participants of the stories by client ID.
+// Participants sharing a name are told apart by a number, in the order they
+// first appear.
+func participantLabels(stories []entity.Story) map[string]string {
+	labels := make(map[string]string)
+	used := make(map[string]bool)
+	for _, story := range stories {
+		for _, vote := range story.Votes {
+			if _, ok := labels[vote.ClientID]; ok {
+				continue
+			}
+			name := vote.Name
+			if name == "" {
+				name = anonymousParticipant
+			}
+			label := name
+			for n := 2; used[label]; n++ {
+				label = fmt.Sprintf("%s (%d)", name, n)
+			}
+			labels[vote.ClientID] = label
+			used[label] = true
+		}
+	}
+	return labels
+}
+
+func mapStory(story entity.Story, deck entity.Deck, labels map[string]string) Story {
+	mostAppearingVotes := slices.Sorted(slices.Values(story.MostAppearingVotes))
+
+	result := Story{
+		Name:  story.Name,
+		Voted: story.Voted,
+		MostAppearingVotes: lo.Map(mostAppearingVotes, func(score int, _ int) string {
+			if card, ok := deck.Card(score); ok {
+				return card
+			}
+			return strconv.Itoa(score)
+		}),
+		Votes: lo.Map(story.Votes, func(v entity.StoryVote, _ int) Vote {
+			return Vote{ParticipantID: v.ClientID, Participant: labels[v.ClientID], Vote: v.Vote}
+		}),
+	}
+
+	// the average of card positions means nothing outside numeric decks
+	if deck.IsNumeric() {
+		result.Result = story.Result
+	}
+
+	return result
+}
+
+// Participants returns everyone that took part in at least one story, in the
+// order they first appear.
+func (r Report) Participants() []Participant {
+	participants := []Participant{}
+	for _, story := range r.Stories {
+		for _, vote := range story.Votes {
+			if !slices.ContainsFunc(participants, func(p Participant) bool { return p.ID == vote.ParticipantID }) {
+				participants = append(participants, Participant{ID: vote.ParticipantID, Name: vote.Participant})
+			}
+		}
+	}
+	return participants
+}
+
+func (s Story) VoteOf(participantID string) (string, bool) {
+	vote, ok := lo.Find(s.Votes, func(v Vote) bool { return v.ParticipantID == participantID })
+	return vote.Vote, ok
+}
@@ -0,0 +1,239 @@
+package report
+
+import (
+	"encoding/json"
+	"errors"
+	"planning-poker/internal/domain/entity"
+	"planning-poker/internal/infra/boundaries/hub/clientcollection"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/samber/lo"
+)
+
+func newReportRoom() *entity.Room {
+	room := entity.NewRoomWithID("room1", clientcollection.New())
+	room.Stories = []entity.Story{
+		{
+			Name:               "Login page",
+			Result:             lo.ToPtr(float32(6.5)),
+			MostAppearingVotes: []int{8, 5},
+			Voted:              true,
+			Votes: []entity.StoryVote{
+				{ClientID: "c1", Name: "Alice", Vote: "5"},
+				{ClientID: "c2", Name: "Bob", Vote: "8"},
+			},
+		},
+		{
+			Name:               "Checkout | payment",
+			Result:             lo.ToPtr(float32(3)),
+			MostAppearingVotes: []int{3},
+			Voted:              true,
+			Votes: []entity.StoryVote{
+				{ClientID: "c2", Name: "Bob", Vote: "3"},
+				{ClientID: "c3", Name: "Carol", Vote: ""},
+			},
+		},
+		{Name: "Search"},
+	}
+	return room
+}
+
+func TestNew(t *testing.T) {
+	report := New(newReportRoom())
+
+	if report.RoomID != "room1" || report.Deck != entity.DeckFibonacci {
+		t.Errorf("unexpected report header %+v", report)
+	}
+	if len(report.Stories) != 3 {
+		t.Fatalf("expected 3 stories, got %d", len(report.Stories))
+	}
+	if !reflect.DeepEqual(report.Stories[0].MostAppearingVotes, []string{"5", "8"}) {
+		t.Errorf("expected sorted most appearing votes [5 8], got %v", report.Stories[0].MostAppearingVotes)
+	}
+	names := lo.Map(report.Participants(), func(p Participant, _ int) string { return p.Name })
+	if !reflect.DeepEqual(names, []string{"Alice", "Bob", "Carol"}) {
+		t.Errorf("expected participants [Alice Bob Carol], got %v", names)
+	}
+}
+
+func TestNew_ParticipantsSharingAName(t *testing.T) {
+	room := entity.NewRoomWithID("room1", clientcollection.New())
+	room.Stories = []entity.Story{
+		{
+			Name:  "Login page",
+			Voted: true,
+			Votes: []entity.StoryVote{
+				{ClientID: "c1", Name: "Alex", Vote: "5"},
+				{ClientID: "c2", Name: "Alex", Vote: "8"},
+				{ClientID: "c3", Vote: "3"},
+				{ClientID: "c4", Vote: "13"},
+			},
+		},
+	}
+	report := New(room)
+
+	want := []Participant{
+		{ID: "c1", Name: "Alex"},
+		{ID: "c2", Name: "Alex (2)"},
+		{ID: "c3", Name: "Anonymous"},
+		{ID: "c4", Name: "Anonymous (2)"},
+	}
+	if !reflect.DeepEqual(report.Participants(), want) {
+		t.Errorf("expected participants %+v, got %+v", want, report.Participants())
+	}
+
+	content, err := Render(report, FormatCSV)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := "story,voted,result,most_appearing_votes,Alex,Alex (2),Anonymous,Anonymous (2)\n" +
+		"Login page,true,,,5,8,3,13\n"
+	if string(content) != expected {
+		t.Errorf("unexpected CSV:\n%s", content)
+	}
+}
+
+func TestNew_OrdinalDeck(t *testing.T) {
+	room := entity.NewRoomWithID("room1", clientcollection.New())
+	room.Deck, _ = entity.BuiltInDeck(entity.DeckTShirt)
+	room.Stories = []entity.Story{{Name: "Story", Voted: true, Result: lo.ToPtr(float32(3.5)), MostAppearingVotes: []int{3}}}
+
+	story := New(room).Stories[0]
+
+	if story.Result != nil {
+		t.Errorf("expected no numeric result for ordinal decks, got %v", *story.Result)
+	}
+	if !reflect.DeepEqual(story.MostAppearingVotes, []string{"M"}) {
+		t.Errorf("expected most appearing votes [M], got %v", story.MostAppearingVotes)
+	}
+}
+
+func TestRender_JSON(t *testing.T) {
+	content, err := Render(New(newReportRoom()), FormatJSON)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var decoded Report
+	if err := json.Unmarshal(content, &decoded); err != nil {
+		t.Fatalf("failed to decode report: %v", err)
+	}
+	if decoded.Stories[1].Votes[0] != (Vote{ParticipantID: "c2", Participant: "Bob", Vote: "3"}) {
+		t.Errorf("unexpected vote %+v", decoded.Stories[1].Votes[0])
+	}
+}
+
+func TestRender_CSV(t *testing.T) {
+	content, err := Render(New(newReportRoom()), FormatCSV)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := strings.Join([]string{
+		"story,voted,result,most_appearing_votes,Alice,Bob,Carol",
+		"Login page,true,6.5,5 8,5,8,",
+		"Checkout | payment,true,3,3,,3,",
+		"Search,false,,,,,",
+		"",
+	}, "\n")
+	if string(content) != want {
+		t.Errorf("unexpected CSV:\n%s\nwant:\n%s", content, want)
+	}
+}
+
+func TestRender_CSV_EscapesFormulas(t *testing.T) {
+	room := entity.NewRoomWithID("room1", clientcollection.New())
+	room.Stories = []entity.Story{
+		{
+			Name:  "=HYPERLINK(\"http://evil.example.com\")",
+			Voted: true,
+			Votes: []entity.StoryVote{
+				{ClientID: "c1", Name: "+cmd", Vote: "5"},
+				{ClientID: "c2", Name: "-2+3", Vote: "8"},
+				{ClientID: "c3", Name: "@SUM(A1)", Vote: "3"},
+				{ClientID: "c4", Name: "Dana", Vote: "13"},
+			},
+		},
+	}
+
+	content, err := Render(New(room), FormatCSV)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := "story,voted,result,most_appearing_votes,'+cmd,'-2+3,'@SUM(A1),Dana\n" +
+		"\"'=HYPERLINK(\"\"http://evil.example.com\"\")\",true,,,5,8,3,13\n"
+	if string(content) != want {
+		t.Errorf("unexpected CSV:\n%s\nwant:\n%s", content, want)
+	}
+}
+
+func TestRender_Markdown(t *testing.T) {
+	content, err := Render(New(newReportRoom()), FormatMarkdown)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, line := range []string{
+		"# Planning poker session room1",
+		"| Login page | 6.5 | 5, 8 | Alice: 5, Bob: 8 |",
+		"| Checkout \\| payment | 3 | 3 | Bob: 3, Carol: - |",
+		"| Search | not voted | - | - |",
+	} {
+		if !strings.Contains(string(content), line) {
+			t.Errorf("expected markdown to contain %q, got:\n%s", line, content)
+		}
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    Format
+		wantErr bool
+	}{
+		{input: "", want: FormatJSON},
+		{input: "CSV", want: FormatCSV},
+		{input: "md", want: FormatMarkdown},
+		{input: "markdown", want: FormatMarkdown},
+		{input: "xml", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseFormat(tt.input)
+			if tt.wantErr {
+				if !errors.Is(err, ErrUnsupportedFormat) {
+					t.Errorf("expected ErrUnsupportedFormat, got %v", err)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("ParseFormat(%q) = %v, %v; want %v", tt.input, got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatFromAccept(t *testing.T) {
+	tests := []struct {
+		accept string
+		want   Format
+	}{
+		{accept: "", want: FormatJSON},
+		{accept: "*/*", want: FormatJSON},
+		{accept: "text/csv", want: FormatCSV},
+		{accept: "text/html, text/markdown;q=0.9", want: FormatMarkdown},
+		{accept: "application/json; charset=utf-8", want: FormatJSON},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.accept, func(t *testing.T) {
+			if got := FormatFromAccept(tt.accept); got != tt.want {
+				t.Errorf("FormatFromAccept(%q) = %v, want %v", tt.accept, got, tt.want)
+			}
+		})
+	}
+}
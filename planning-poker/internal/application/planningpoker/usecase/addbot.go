@@ -0,0 +1,63 @@
+package usecase
+
+import (
+	"context"
+	"planning-poker/internal/application/lock"
+	"planning-poker/internal/application/planningpoker/usecase/dto"
+	"planning-poker/internal/domain"
+)
+
+type (
+	AddBotCommand struct {
+		RoomID   string
+		SenderID string
+		Name     string
+	}
+	AddBotOutput struct {
+		ClientID string
+		// Token is only known to the caller, the room keeps its hash
+		Token string
+	}
+	AddBotUseCase struct {
+		hub         domain.Hub
+		lockManager lock.LockManager
+	}
+)
+
+var _ UseCaseR[AddBotCommand, AddBotOutput] = (*AddBotUseCase)(nil)
+
+func NewAddBotUseCase(hub domain.Hub, lockManager lock.LockManager) AddBotUseCase {
+	return AddBotUseCase{
+		hub:         hub,
+		lockManager: lockManager,
+	}
+}
+
+func (uc AddBotUseCase) Execute(ctx context.Context, cmd AddBotCommand) (AddBotOutput, error) {
+	output, err := uc.lockManager.WithLock(ctx, cmd.RoomID, func(ctx context.Context) (any, error) {
+		room, err := uc.hub.LoadRoom(ctx, cmd.RoomID)
+		if err != nil {
+			return nil, err
+		}
+
+		credentials, err := room.AddBot(ctx, cmd.SenderID, cmd.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := uc.hub.SaveRoom(ctx, room); err != nil {
+			return nil, err
+		}
+
+		if err := uc.hub.BroadcastToRoom(ctx, room.ID, dto.NewRoomStateCommand(room)); err != nil {
+			return nil, err
+		}
+
+		return AddBotOutput{ClientID: credentials.ClientID, Token: credentials.Token}, nil
+	})
+	if err != nil {
+		return AddBotOutput{}, err
+	}
+
+	return output.(AddBotOutput), nil
+}
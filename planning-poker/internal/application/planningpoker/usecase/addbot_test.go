@@ -0,0 +1,73 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"planning-poker/internal/application/lock"
+	"planning-poker/internal/domain"
+	"planning-poker/internal/domain/entity"
+	"planning-poker/internal/infra/boundaries/hub/clientcollection"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+)
+
+func TestAddBotUseCase_Execute_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockHub := domain.NewMockHub(ctrl)
+	mockLockManager := lock.NewMockLockManager(ctrl)
+
+	room := entity.NewRoomWithID("room123", clientcollection.New())
+	room.NewClient("owner")
+
+	mockLockManager.EXPECT().
+		WithLock(gomock.Any(), "room123", gomock.Any()).
+		DoAndReturn(func(ctx context.Context, key string, fn func(context.Context) (any, error)) (any, error) {
+			return fn(ctx)
+		})
+	mockHub.EXPECT().LoadRoom(ctx, "room123").Return(room, nil)
+	mockHub.EXPECT().SaveRoom(ctx, room).Return(nil)
+	mockHub.EXPECT().BroadcastToRoom(ctx, "room123", gomock.Any()).Return(nil)
+
+	uc := NewAddBotUseCase(mockHub, mockLockManager)
+	output, err := uc.Execute(ctx, AddBotCommand{RoomID: "room123", SenderID: "owner", Name: "ci"})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	bot, err := room.AuthenticateBot(output.Token)
+	if err != nil {
+		t.Fatalf("expected the token to authenticate the bot, got %v", err)
+	}
+	if bot.ID != output.ClientID || bot.Name != "ci" {
+		t.Errorf("unexpected bot %+v", bot)
+	}
+}
+
+func TestAddBotUseCase_Execute_PermissionDenied(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockHub := domain.NewMockHub(ctrl)
+	mockLockManager := lock.NewMockLockManager(ctrl)
+
+	room := entity.NewRoomWithID("room123", clientcollection.New())
+	room.NewClient("owner")
+	room.NewClient("voter")
+
+	mockLockManager.EXPECT().
+		WithLock(gomock.Any(), "room123", gomock.Any()).
+		DoAndReturn(func(ctx context.Context, key string, fn func(context.Context) (any, error)) (any, error) {
+			return fn(ctx)
+		})
+	mockHub.EXPECT().LoadRoom(ctx, "room123").Return(room, nil)
+
+	uc := NewAddBotUseCase(mockHub, mockLockManager)
+	if _, err := uc.Execute(ctx, AddBotCommand{RoomID: "room123", SenderID: "voter"}); !errors.Is(err, domain.ErrPermissionDenied) {
+		t.Fatalf("expected ErrPermissionDenied, got %v", err)
+	}
+}
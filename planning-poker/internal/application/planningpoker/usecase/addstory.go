@@ -4,6 +4,7 @@ import (
 	"context"
 	"planning-poker/internal/application/lock"
 	"planning-poker/internal/application/planningpoker/usecase/dto"
+	"planning-poker/internal/application/webhook"
 	"planning-poker/internal/domain"
 )
 
@@ -16,15 +17,17 @@ type (
 	AddStoryUseCase struct {
 		hub         domain.Hub
 		lockManager lock.LockManager
+		webhooks    webhook.Publisher
 	}
 )
 
 var _ UseCase[AddStoryCommand] = (*AddStoryUseCase)(nil)
 
-func NewAddStoryUseCase(hub domain.Hub, lockManager lock.LockManager) AddStoryUseCase {
+func NewAddStoryUseCase(hub domain.Hub, lockManager lock.LockManager, webhooks webhook.Publisher) AddStoryUseCase {
 	return AddStoryUseCase{
 		hub:         hub,
 		lockManager: lockManager,
+		webhooks:    webhooks,
 	}
 }
 
@@ -46,6 +49,7 @@ func (uc AddStoryUseCase) Execute(ctx context.Context, cmd AddStoryCommand) erro
 		if err := uc.hub.BroadcastToRoom(ctx, room.ID, dto.NewRoomStateCommand(room)); err != nil {
 			return err
 		}
+		uc.webhooks.Publish(ctx, webhook.NewStoryAddedEvent(room))
 
 		return nil
 	})
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"planning-poker/internal/application/lock"
+	"planning-poker/internal/application/webhook"
 	"planning-poker/internal/domain"
 	"planning-poker/internal/domain/entity"
 	"planning-poker/internal/infra/boundaries/hub/clientcollection"
@@ -19,7 +20,7 @@ func TestNewAddStoryUseCase(t *testing.T) {
 	mockHub := domain.NewMockHub(ctrl)
 	mockLockManager := lock.NewMockLockManager(ctrl)
 
-	uc := NewAddStoryUseCase(mockHub, mockLockManager)
+	uc := NewAddStoryUseCase(mockHub, mockLockManager, webhook.Discard)
 
 	if uc.hub != mockHub {
 		t.Error("hub not set correctly")
@@ -54,7 +55,14 @@ func TestAddStoryUseCase_Execute_Success(t *testing.T) {
 	mockHub.EXPECT().SaveRoom(ctx, room).Return(nil)
 	mockHub.EXPECT().BroadcastToRoom(ctx, roomID, gomock.Any()).Return(nil)
 
-	uc := NewAddStoryUseCase(mockHub, mockLockManager)
+	mockWebhooks := webhook.NewMockPublisher(ctrl)
+	mockWebhooks.EXPECT().Publish(ctx, gomock.Any()).Do(func(_ context.Context, event webhook.Event) {
+		if event.Type != webhook.EventStoryAdded || event.RoomID != roomID {
+			t.Errorf("unexpected event %+v", event)
+		}
+	})
+
+	uc := NewAddStoryUseCase(mockHub, mockLockManager, mockWebhooks)
 	cmd := AddStoryCommand{
 		RoomID:    roomID,
 		SenderID:  "client123",
@@ -96,7 +104,7 @@ func TestAddStoryUseCase_Execute_SaveRoomError(t *testing.T) {
 	mockHub.EXPECT().LoadRoom(ctx, roomID).Return(room, nil)
 	mockHub.EXPECT().SaveRoom(ctx, room).Return(expectedError)
 
-	uc := NewAddStoryUseCase(mockHub, mockLockManager)
+	uc := NewAddStoryUseCase(mockHub, mockLockManager, webhook.Discard)
 	cmd := AddStoryCommand{
 		RoomID:    roomID,
 		SenderID:  senderID,
@@ -131,7 +139,7 @@ func TestAddStoryUseCase_Execute_RoomNotFound(t *testing.T) {
 
 	mockHub.EXPECT().LoadRoom(ctx, roomID).Return(nil, domain.ErrRoomNotFound)
 
-	uc := NewAddStoryUseCase(mockHub, mockLockManager)
+	uc := NewAddStoryUseCase(mockHub, mockLockManager, webhook.Discard)
 	cmd := AddStoryCommand{
 		RoomID:    roomID,
 		SenderID:  "client123",
@@ -177,7 +185,7 @@ func TestAddStoryUseCase_Execute_BroadcastError(t *testing.T) {
 	mockHub.EXPECT().SaveRoom(ctx, room).Return(nil)
 	mockHub.EXPECT().BroadcastToRoom(ctx, roomID, gomock.Any()).Return(expectedError)
 
-	uc := NewAddStoryUseCase(mockHub, mockLockManager)
+	uc := NewAddStoryUseCase(mockHub, mockLockManager, webhook.Discard)
 	cmd := AddStoryCommand{
 		RoomID:    roomID,
 		SenderID:  "client123",
@@ -3,6 +3,7 @@ package usecase
 import (
 	"context"
 	"fmt"
+	"planning-poker/internal/application/audit"
 	"planning-poker/internal/application/planningpoker/usecase/dto"
 	"planning-poker/internal/domain"
 	"planning-poker/internal/domain/entity"
@@ -14,10 +15,13 @@ type (
 	AdminKickClientCommand struct {
 		RoomID   string
 		ClientID string
+		// Actor names the admin key the client is kicked with
+		Actor string
 	}
 	adminKickClientUseCase struct {
 		leaveRoom UseCase[LeaveRoomCommand]
 		hub       domain.Hub
+		auditor   audit.Auditor
 		logger    log.Logger
 	}
 )
@@ -27,22 +31,33 @@ var _ UseCase[AdminKickClientCommand] = (*adminKickClientUseCase)(nil)
 func NewAdminKickClientUseCase(
 	leaveRoom UseCase[LeaveRoomCommand],
 	hub domain.Hub,
+	auditor audit.Auditor,
 ) *adminKickClientUseCase {
 	return &adminKickClientUseCase{
 		leaveRoom: leaveRoom,
 		hub:       hub,
+		auditor:   auditor,
 		logger:    log.NewLogger("usecase.adminkickclient"),
 	}
 }
 
-func (uc *adminKickClientUseCase) Execute(ctx context.Context, cmd AdminKickClientCommand) error {
-	uc.logger.Info(ctx, "Admin kicking client %s from room %s", cmd.ClientID, cmd.RoomID)
+func (uc *adminKickClientUseCase) Execute(ctx context.Context, cmd AdminKickClientCommand) (err error) {
+	record := audit.Record{
+		RoomID: cmd.RoomID,
+		Action: audit.ActionKickClient,
+		Actor:  audit.AdminActor(cmd.Actor),
+		Target: cmd.ClientID,
+	}
+	defer func() { uc.auditor.Record(ctx, record, err) }()
+
+	uc.logger.Info(ctx, "Admin %s kicking client %s from room %s", cmd.Actor, cmd.ClientID, cmd.RoomID)
 
 	room, err := uc.hub.LoadRoom(ctx, cmd.RoomID)
 	if err != nil {
 		uc.logger.Error(ctx, "Error loading room", err)
 		return fmt.Errorf("load room: %w", err)
 	}
+	withClientNames(&record, room)
 
 	if room.Clients.Filter(func(c *entity.Client) bool { return c.ID == cmd.ClientID }).Count() == 0 {
 		uc.logger.Warn(ctx, "Client %s not found in room %s", cmd.ClientID, cmd.RoomID)
@@ -67,6 +82,6 @@ func (uc *adminKickClientUseCase) Execute(ctx context.Context, cmd AdminKickClie
 		}
 	}
 
-	uc.logger.Info(ctx, "Admin successfully kicked client %s from room %s", cmd.ClientID, cmd.RoomID)
+	uc.logger.Info(ctx, "Admin %s successfully kicked client %s from room %s", cmd.Actor, cmd.ClientID, cmd.RoomID)
 	return nil
 }
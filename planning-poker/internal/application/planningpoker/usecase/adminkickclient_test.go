@@ -3,6 +3,7 @@ package usecase
 import (
 	"context"
 	"errors"
+	"planning-poker/internal/application/audit"
 	"planning-poker/internal/application/planningpoker/usecase/dto"
 	"planning-poker/internal/domain"
 	"planning-poker/internal/domain/entity"
@@ -19,7 +20,7 @@ func TestNewAdminKickClientUseCase(t *testing.T) {
 	mockHub := domain.NewMockHub(ctrl)
 	mockLeaveRoom := NewMockUseCase[LeaveRoomCommand](ctrl)
 
-	uc := NewAdminKickClientUseCase(mockLeaveRoom, mockHub)
+	uc := NewAdminKickClientUseCase(mockLeaveRoom, mockHub, audit.Discard)
 
 	if uc.hub != mockHub {
 		t.Error("hub not set correctly")
@@ -52,7 +53,7 @@ func TestAdminKickClientUseCase_Execute_Success(t *testing.T) {
 	mockBus.EXPECT().Send(ctx, dto.NewKickNotification()).Return(nil)
 	mockBus.EXPECT().Close().Return(nil)
 
-	uc := NewAdminKickClientUseCase(mockLeaveRoom, mockHub)
+	uc := NewAdminKickClientUseCase(mockLeaveRoom, mockHub, audit.Discard)
 	err := uc.Execute(ctx, AdminKickClientCommand{RoomID: roomID, ClientID: clientID})
 
 	if err != nil {
@@ -79,7 +80,7 @@ func TestAdminKickClientUseCase_Execute_Success_NoBus(t *testing.T) {
 	mockHub.EXPECT().GetBus(clientID).Return(nil, false)
 	mockLeaveRoom.EXPECT().Execute(ctx, LeaveRoomCommand{RoomID: roomID, SenderID: clientID}).Return(nil)
 
-	uc := NewAdminKickClientUseCase(mockLeaveRoom, mockHub)
+	uc := NewAdminKickClientUseCase(mockLeaveRoom, mockHub, audit.Discard)
 	err := uc.Execute(ctx, AdminKickClientCommand{RoomID: roomID, ClientID: clientID})
 
 	if err != nil {
@@ -100,7 +101,7 @@ func TestAdminKickClientUseCase_Execute_RoomNotFound(t *testing.T) {
 
 	mockHub.EXPECT().LoadRoom(ctx, roomID).Return(nil, domain.ErrRoomNotFound)
 
-	uc := NewAdminKickClientUseCase(mockLeaveRoom, mockHub)
+	uc := NewAdminKickClientUseCase(mockLeaveRoom, mockHub, audit.Discard)
 	err := uc.Execute(ctx, AdminKickClientCommand{RoomID: roomID, ClientID: clientID})
 
 	if err == nil {
@@ -128,7 +129,7 @@ func TestAdminKickClientUseCase_Execute_ClientNotFound(t *testing.T) {
 
 	mockHub.EXPECT().LoadRoom(ctx, roomID).Return(room, nil)
 
-	uc := NewAdminKickClientUseCase(mockLeaveRoom, mockHub)
+	uc := NewAdminKickClientUseCase(mockLeaveRoom, mockHub, audit.Discard)
 	err := uc.Execute(ctx, AdminKickClientCommand{RoomID: roomID, ClientID: clientID})
 
 	if err == nil {
@@ -160,7 +161,7 @@ func TestAdminKickClientUseCase_Execute_LeaveRoomError(t *testing.T) {
 	mockHub.EXPECT().GetBus(clientID).Return(nil, false)
 	mockLeaveRoom.EXPECT().Execute(ctx, LeaveRoomCommand{RoomID: roomID, SenderID: clientID}).Return(leaveRoomErr)
 
-	uc := NewAdminKickClientUseCase(mockLeaveRoom, mockHub)
+	uc := NewAdminKickClientUseCase(mockLeaveRoom, mockHub, audit.Discard)
 	err := uc.Execute(ctx, AdminKickClientCommand{RoomID: roomID, ClientID: clientID})
 
 	if err == nil {
@@ -195,7 +196,7 @@ func TestAdminKickClientUseCase_Execute_LeaveRoomError_WithBus(t *testing.T) {
 	mockLeaveRoom.EXPECT().Execute(ctx, LeaveRoomCommand{RoomID: roomID, SenderID: clientID}).Return(leaveRoomErr)
 	// No Send or Close expectations — they must NOT be called on leaveRoom failure
 
-	uc := NewAdminKickClientUseCase(mockLeaveRoom, mockHub)
+	uc := NewAdminKickClientUseCase(mockLeaveRoom, mockHub, audit.Discard)
 	err := uc.Execute(ctx, AdminKickClientCommand{RoomID: roomID, ClientID: clientID})
 
 	if err == nil {
@@ -229,7 +230,7 @@ func TestAdminKickClientUseCase_Execute_BusCloseError(t *testing.T) {
 	mockBus.EXPECT().Send(ctx, dto.NewKickNotification()).Return(nil)
 	mockBus.EXPECT().Close().Return(errors.New("close error"))
 
-	uc := NewAdminKickClientUseCase(mockLeaveRoom, mockHub)
+	uc := NewAdminKickClientUseCase(mockLeaveRoom, mockHub, audit.Discard)
 	err := uc.Execute(ctx, AdminKickClientCommand{RoomID: roomID, ClientID: clientID})
 
 	// Bus.Close error is best effort, should still return nil
@@ -261,7 +262,7 @@ func TestAdminKickClientUseCase_Execute_SendError(t *testing.T) {
 	mockBus.EXPECT().Send(ctx, dto.NewKickNotification()).Return(errors.New("send error"))
 	mockBus.EXPECT().Close().Return(nil)
 
-	uc := NewAdminKickClientUseCase(mockLeaveRoom, mockHub)
+	uc := NewAdminKickClientUseCase(mockLeaveRoom, mockHub, audit.Discard)
 	err := uc.Execute(ctx, AdminKickClientCommand{RoomID: roomID, ClientID: clientID})
 
 	// Send error is best effort, should still return nil
@@ -3,6 +3,7 @@ package usecase
 import (
 	"context"
 	"fmt"
+	"planning-poker/internal/application/audit"
 	"planning-poker/internal/domain"
 	"planning-poker/internal/domain/entity"
 
@@ -13,10 +14,13 @@ type (
 	AdminRemoveClientCommand struct {
 		RoomID   string
 		ClientID string
+		// Actor names the admin key the client is removed with
+		Actor string
 	}
 	adminRemoveClientUseCase struct {
 		leaveRoom UseCase[LeaveRoomCommand]
 		hub       domain.Hub
+		auditor   audit.Auditor
 		logger    log.Logger
 	}
 )
@@ -26,22 +30,33 @@ var _ UseCase[AdminRemoveClientCommand] = (*adminRemoveClientUseCase)(nil)
 func NewAdminRemoveClientUseCase(
 	leaveRoom UseCase[LeaveRoomCommand],
 	hub domain.Hub,
+	auditor audit.Auditor,
 ) *adminRemoveClientUseCase {
 	return &adminRemoveClientUseCase{
 		leaveRoom: leaveRoom,
 		hub:       hub,
+		auditor:   auditor,
 		logger:    log.NewLogger("usecase.adminremoveclient"),
 	}
 }
 
-func (uc *adminRemoveClientUseCase) Execute(ctx context.Context, cmd AdminRemoveClientCommand) error {
-	uc.logger.Info(ctx, "Admin removing client %s from room %s", cmd.ClientID, cmd.RoomID)
+func (uc *adminRemoveClientUseCase) Execute(ctx context.Context, cmd AdminRemoveClientCommand) (err error) {
+	record := audit.Record{
+		RoomID: cmd.RoomID,
+		Action: audit.ActionRemoveClient,
+		Actor:  audit.AdminActor(cmd.Actor),
+		Target: cmd.ClientID,
+	}
+	defer func() { uc.auditor.Record(ctx, record, err) }()
+
+	uc.logger.Info(ctx, "Admin %s removing client %s from room %s", cmd.Actor, cmd.ClientID, cmd.RoomID)
 
 	room, err := uc.hub.LoadRoom(ctx, cmd.RoomID)
 	if err != nil {
 		uc.logger.Error(ctx, "Error loading room", err)
 		return fmt.Errorf("load room: %w", err)
 	}
+	withClientNames(&record, room)
 
 	if room.Clients.Filter(func(c *entity.Client) bool { return c.ID == cmd.ClientID }).Count() == 0 {
 		uc.logger.Warn(ctx, "Client %s not found in room %s", cmd.ClientID, cmd.RoomID)
@@ -64,6 +79,6 @@ func (uc *adminRemoveClientUseCase) Execute(ctx context.Context, cmd AdminRemove
 		}
 	}
 
-	uc.logger.Info(ctx, "Admin successfully removed client %s from room %s", cmd.ClientID, cmd.RoomID)
+	uc.logger.Info(ctx, "Admin %s successfully removed client %s from room %s", cmd.Actor, cmd.ClientID, cmd.RoomID)
 	return nil
 }
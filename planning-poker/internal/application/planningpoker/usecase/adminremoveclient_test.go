@@ -3,6 +3,7 @@ package usecase
 import (
 	"context"
 	"errors"
+	"planning-poker/internal/application/audit"
 	"planning-poker/internal/domain"
 	"planning-poker/internal/domain/entity"
 	"planning-poker/internal/infra/boundaries/hub/clientcollection"
@@ -18,7 +19,7 @@ func TestNewAdminRemoveClientUseCase(t *testing.T) {
 	mockHub := domain.NewMockHub(ctrl)
 	mockLeaveRoom := NewMockUseCase[LeaveRoomCommand](ctrl)
 
-	uc := NewAdminRemoveClientUseCase(mockLeaveRoom, mockHub)
+	uc := NewAdminRemoveClientUseCase(mockLeaveRoom, mockHub, audit.Discard)
 
 	if uc.hub != mockHub {
 		t.Error("hub not set correctly")
@@ -50,7 +51,7 @@ func TestAdminRemoveClientUseCase_Execute_Success(t *testing.T) {
 	mockLeaveRoom.EXPECT().Execute(ctx, LeaveRoomCommand{RoomID: roomID, SenderID: clientID}).Return(nil)
 	mockBus.EXPECT().Close().Return(nil)
 
-	uc := NewAdminRemoveClientUseCase(mockLeaveRoom, mockHub)
+	uc := NewAdminRemoveClientUseCase(mockLeaveRoom, mockHub, audit.Discard)
 	err := uc.Execute(ctx, AdminRemoveClientCommand{RoomID: roomID, ClientID: clientID})
 
 	if err != nil {
@@ -77,7 +78,7 @@ func TestAdminRemoveClientUseCase_Execute_Success_NoBus(t *testing.T) {
 	mockHub.EXPECT().GetBus(clientID).Return(nil, false)
 	mockLeaveRoom.EXPECT().Execute(ctx, LeaveRoomCommand{RoomID: roomID, SenderID: clientID}).Return(nil)
 
-	uc := NewAdminRemoveClientUseCase(mockLeaveRoom, mockHub)
+	uc := NewAdminRemoveClientUseCase(mockLeaveRoom, mockHub, audit.Discard)
 	err := uc.Execute(ctx, AdminRemoveClientCommand{RoomID: roomID, ClientID: clientID})
 
 	if err != nil {
@@ -98,7 +99,7 @@ func TestAdminRemoveClientUseCase_Execute_RoomNotFound(t *testing.T) {
 
 	mockHub.EXPECT().LoadRoom(ctx, roomID).Return(nil, domain.ErrRoomNotFound)
 
-	uc := NewAdminRemoveClientUseCase(mockLeaveRoom, mockHub)
+	uc := NewAdminRemoveClientUseCase(mockLeaveRoom, mockHub, audit.Discard)
 	err := uc.Execute(ctx, AdminRemoveClientCommand{RoomID: roomID, ClientID: clientID})
 
 	if err == nil {
@@ -126,7 +127,7 @@ func TestAdminRemoveClientUseCase_Execute_ClientNotFound(t *testing.T) {
 
 	mockHub.EXPECT().LoadRoom(ctx, roomID).Return(room, nil)
 
-	uc := NewAdminRemoveClientUseCase(mockLeaveRoom, mockHub)
+	uc := NewAdminRemoveClientUseCase(mockLeaveRoom, mockHub, audit.Discard)
 	err := uc.Execute(ctx, AdminRemoveClientCommand{RoomID: roomID, ClientID: clientID})
 
 	if err == nil {
@@ -158,7 +159,7 @@ func TestAdminRemoveClientUseCase_Execute_LeaveRoomError(t *testing.T) {
 	mockHub.EXPECT().GetBus(clientID).Return(nil, false)
 	mockLeaveRoom.EXPECT().Execute(ctx, LeaveRoomCommand{RoomID: roomID, SenderID: clientID}).Return(leaveRoomErr)
 
-	uc := NewAdminRemoveClientUseCase(mockLeaveRoom, mockHub)
+	uc := NewAdminRemoveClientUseCase(mockLeaveRoom, mockHub, audit.Discard)
 	err := uc.Execute(ctx, AdminRemoveClientCommand{RoomID: roomID, ClientID: clientID})
 
 	if err == nil {
@@ -191,7 +192,7 @@ func TestAdminRemoveClientUseCase_Execute_BusCloseError(t *testing.T) {
 	mockLeaveRoom.EXPECT().Execute(ctx, LeaveRoomCommand{RoomID: roomID, SenderID: clientID}).Return(nil)
 	mockBus.EXPECT().Close().Return(errors.New("close error"))
 
-	uc := NewAdminRemoveClientUseCase(mockLeaveRoom, mockHub)
+	uc := NewAdminRemoveClientUseCase(mockLeaveRoom, mockHub, audit.Discard)
 	err := uc.Execute(ctx, AdminRemoveClientCommand{RoomID: roomID, ClientID: clientID})
 
 	// Bus.Close error is best effort, should still return nil
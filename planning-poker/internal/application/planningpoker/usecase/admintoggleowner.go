@@ -3,6 +3,7 @@ package usecase
 import (
 	"context"
 	"fmt"
+	"planning-poker/internal/application/audit"
 	"planning-poker/internal/application/lock"
 	"planning-poker/internal/application/planningpoker/usecase/dto"
 	"planning-poker/internal/domain"
@@ -14,26 +15,38 @@ type (
 	AdminToggleOwnerCommand struct {
 		RoomID         string
 		TargetClientID string
+		// Actor names the admin key the owner is toggled with
+		Actor string
 	}
 	adminToggleOwnerUseCase struct {
 		hub         domain.Hub
 		lockManager lock.LockManager
+		auditor     audit.Auditor
 		logger      log.Logger
 	}
 )
 
 var _ UseCase[AdminToggleOwnerCommand] = (*adminToggleOwnerUseCase)(nil)
 
-func NewAdminToggleOwnerUseCase(hub domain.Hub, lockManager lock.LockManager) *adminToggleOwnerUseCase {
+func NewAdminToggleOwnerUseCase(hub domain.Hub, lockManager lock.LockManager, auditor audit.Auditor) *adminToggleOwnerUseCase {
 	return &adminToggleOwnerUseCase{
 		hub:         hub,
 		lockManager: lockManager,
+		auditor:     auditor,
 		logger:      log.NewLogger("usecase.admintoggleowner"),
 	}
 }
 
-func (uc *adminToggleOwnerUseCase) Execute(ctx context.Context, cmd AdminToggleOwnerCommand) error {
-	uc.logger.Info(ctx, "Admin toggling owner for client %s in room %s", cmd.TargetClientID, cmd.RoomID)
+func (uc *adminToggleOwnerUseCase) Execute(ctx context.Context, cmd AdminToggleOwnerCommand) (err error) {
+	record := audit.Record{
+		RoomID: cmd.RoomID,
+		Action: audit.ActionToggleOwner,
+		Actor:  audit.AdminActor(cmd.Actor),
+		Target: cmd.TargetClientID,
+	}
+	defer func() { uc.auditor.Record(ctx, record, err) }()
+
+	uc.logger.Info(ctx, "Admin %s toggling owner for client %s in room %s", cmd.Actor, cmd.TargetClientID, cmd.RoomID)
 
 	return uc.lockManager.ExecuteWithLock(ctx, cmd.RoomID, func(ctx context.Context) error {
 		room, err := uc.hub.LoadRoom(ctx, cmd.RoomID)
@@ -41,6 +54,7 @@ func (uc *adminToggleOwnerUseCase) Execute(ctx context.Context, cmd AdminToggleO
 			uc.logger.Error(ctx, "Error loading room", err)
 			return fmt.Errorf("load room: %w", err)
 		}
+		withClientNames(&record, room)
 
 		if err := room.AdminToggleOwner(ctx, cmd.TargetClientID); err != nil {
 			uc.logger.Warn(ctx, "Admin toggle owner failed: %v", err)
@@ -57,7 +71,7 @@ func (uc *adminToggleOwnerUseCase) Execute(ctx context.Context, cmd AdminToggleO
 			return err
 		}
 
-		uc.logger.Info(ctx, "Admin successfully toggled owner for client %s in room %s", cmd.TargetClientID, cmd.RoomID)
+		uc.logger.Info(ctx, "Admin %s successfully toggled owner for client %s in room %s", cmd.Actor, cmd.TargetClientID, cmd.RoomID)
 		return nil
 	})
 }
@@ -3,6 +3,7 @@ package usecase
 import (
 	"context"
 	"errors"
+	"planning-poker/internal/application/audit"
 	"planning-poker/internal/application/lock"
 	"planning-poker/internal/domain"
 	"planning-poker/internal/domain/entity"
@@ -42,7 +43,7 @@ func TestAdminToggleOwnerUseCase_Execute_Success(t *testing.T) {
 	mockHub.EXPECT().SaveRoom(ctx, room).Return(nil)
 	mockHub.EXPECT().BroadcastToRoom(ctx, roomID, gomock.Any()).Return(nil)
 
-	uc := NewAdminToggleOwnerUseCase(mockHub, mockLockManager)
+	uc := NewAdminToggleOwnerUseCase(mockHub, mockLockManager, audit.Discard)
 	cmd := AdminToggleOwnerCommand{
 		RoomID:         roomID,
 		TargetClientID: targetID,
@@ -73,7 +74,7 @@ func TestAdminToggleOwnerUseCase_Execute_RoomNotFound(t *testing.T) {
 
 	mockHub.EXPECT().LoadRoom(ctx, roomID).Return(nil, domain.ErrRoomNotFound)
 
-	uc := NewAdminToggleOwnerUseCase(mockHub, mockLockManager)
+	uc := NewAdminToggleOwnerUseCase(mockHub, mockLockManager, audit.Discard)
 	cmd := AdminToggleOwnerCommand{
 		RoomID:         roomID,
 		TargetClientID: "client123",
@@ -118,7 +119,7 @@ func TestAdminToggleOwnerUseCase_Execute_SaveRoomError(t *testing.T) {
 	mockHub.EXPECT().LoadRoom(ctx, roomID).Return(room, nil)
 	mockHub.EXPECT().SaveRoom(ctx, room).Return(expectedError)
 
-	uc := NewAdminToggleOwnerUseCase(mockHub, mockLockManager)
+	uc := NewAdminToggleOwnerUseCase(mockHub, mockLockManager, audit.Discard)
 	cmd := AdminToggleOwnerCommand{
 		RoomID:         roomID,
 		TargetClientID: targetID,
@@ -165,7 +166,7 @@ func TestAdminToggleOwnerUseCase_Execute_BroadcastError(t *testing.T) {
 	mockHub.EXPECT().SaveRoom(ctx, room).Return(nil)
 	mockHub.EXPECT().BroadcastToRoom(ctx, roomID, gomock.Any()).Return(expectedError)
 
-	uc := NewAdminToggleOwnerUseCase(mockHub, mockLockManager)
+	uc := NewAdminToggleOwnerUseCase(mockHub, mockLockManager, audit.Discard)
 	cmd := AdminToggleOwnerCommand{
 		RoomID:         roomID,
 		TargetClientID: targetID,
@@ -209,7 +210,7 @@ func TestAdminToggleOwnerUseCase_Execute_LastOwnerRefused(t *testing.T) {
 	mockHub.EXPECT().LoadRoom(ctx, roomID).Return(room, nil)
 	// SaveRoom and BroadcastToRoom must NOT be called (lock function returns early)
 
-	uc := NewAdminToggleOwnerUseCase(mockHub, mockLockManager)
+	uc := NewAdminToggleOwnerUseCase(mockHub, mockLockManager, audit.Discard)
 	cmd := AdminToggleOwnerCommand{
 		RoomID:         roomID,
 		TargetClientID: targetID,
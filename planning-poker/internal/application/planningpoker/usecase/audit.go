@@ -0,0 +1,16 @@
+package usecase
+
+import (
+	"planning-poker/internal/application/audit"
+	"planning-poker/internal/domain/entity"
+)
+
+// withClientNames fills in the names the actor and target go by in the room.
+func withClientNames(record *audit.Record, room *entity.Room) {
+	if client, ok := room.FindClient(record.Actor); ok {
+		record.ActorName = client.Name
+	}
+	if client, ok := room.FindClient(record.Target); ok {
+		record.TargetName = client.Name
+	}
+}
@@ -0,0 +1,58 @@
+package usecase
+
+import (
+	"context"
+	"planning-poker/internal/application/lock"
+	"planning-poker/internal/application/planningpoker/usecase/dto"
+	"planning-poker/internal/application/timer"
+	"planning-poker/internal/domain"
+)
+
+type (
+	CancelVotingTimerCommand struct {
+		RoomID   string
+		SenderID string
+	}
+	CancelVotingTimerUseCase struct {
+		hub         domain.Hub
+		lockManager lock.LockManager
+		deadlines   timer.DeadlineStore
+	}
+)
+
+var _ UseCase[CancelVotingTimerCommand] = (*CancelVotingTimerUseCase)(nil)
+
+func NewCancelVotingTimerUseCase(hub domain.Hub, lockManager lock.LockManager, deadlines timer.DeadlineStore) CancelVotingTimerUseCase {
+	return CancelVotingTimerUseCase{
+		hub:         hub,
+		lockManager: lockManager,
+		deadlines:   deadlines,
+	}
+}
+
+func (uc CancelVotingTimerUseCase) Execute(ctx context.Context, cmd CancelVotingTimerCommand) error {
+	return uc.lockManager.ExecuteWithLock(ctx, cmd.RoomID, func(ctx context.Context) error {
+		room, err := uc.hub.LoadRoom(ctx, cmd.RoomID)
+		if err != nil {
+			return err
+		}
+
+		if err := room.CancelVotingTimer(ctx, cmd.SenderID); err != nil {
+			return err
+		}
+
+		if err := uc.hub.SaveRoom(ctx, room); err != nil {
+			return err
+		}
+
+		if err := uc.deadlines.Cancel(ctx, room.ID); err != nil {
+			return err
+		}
+
+		if err := uc.hub.BroadcastToRoom(ctx, room.ID, dto.NewRoomStateCommand(room)); err != nil {
+			return err
+		}
+
+		return nil
+	})
+}
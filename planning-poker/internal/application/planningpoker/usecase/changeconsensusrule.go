@@ -0,0 +1,58 @@
+package usecase
+
+import (
+	"context"
+	"planning-poker/internal/application/lock"
+	"planning-poker/internal/application/planningpoker/usecase/dto"
+	"planning-poker/internal/domain"
+	"planning-poker/internal/domain/entity"
+)
+
+type (
+	ChangeConsensusRuleCommand struct {
+		RoomID   string
+		SenderID string
+		Rule     string
+	}
+	ChangeConsensusRuleUseCase struct {
+		hub         domain.Hub
+		lockManager lock.LockManager
+	}
+)
+
+var _ UseCase[ChangeConsensusRuleCommand] = (*ChangeConsensusRuleUseCase)(nil)
+
+func NewChangeConsensusRuleUseCase(hub domain.Hub, lockManager lock.LockManager) ChangeConsensusRuleUseCase {
+	return ChangeConsensusRuleUseCase{
+		hub:         hub,
+		lockManager: lockManager,
+	}
+}
+
+func (uc ChangeConsensusRuleUseCase) Execute(ctx context.Context, cmd ChangeConsensusRuleCommand) error {
+	rule, err := entity.ParseConsensusRule(cmd.Rule)
+	if err != nil {
+		return err
+	}
+
+	return uc.lockManager.ExecuteWithLock(ctx, cmd.RoomID, func(ctx context.Context) error {
+		room, err := uc.hub.LoadRoom(ctx, cmd.RoomID)
+		if err != nil {
+			return err
+		}
+
+		if err := room.ChangeConsensusRule(ctx, cmd.SenderID, rule); err != nil {
+			return err
+		}
+
+		if err := uc.hub.SaveRoom(ctx, room); err != nil {
+			return err
+		}
+
+		if err := uc.hub.BroadcastToRoom(ctx, room.ID, dto.NewRoomStateCommand(room)); err != nil {
+			return err
+		}
+
+		return nil
+	})
+}
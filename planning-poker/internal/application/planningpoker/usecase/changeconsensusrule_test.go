@@ -0,0 +1,79 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"planning-poker/internal/application/lock"
+	"planning-poker/internal/domain"
+	"planning-poker/internal/domain/entity"
+	"planning-poker/internal/infra/boundaries/hub/clientcollection"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+)
+
+func TestChangeConsensusRuleUseCase_Execute_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockHub := domain.NewMockHub(ctrl)
+	mockLockManager := lock.NewMockLockManager(ctrl)
+
+	roomID := "room123"
+	clientID := "client123"
+	room := &entity.Room{
+		ID:      roomID,
+		Clients: clientcollection.New(),
+	}
+
+	client := room.NewClient(clientID)
+	client.IsOwner = true
+
+	mockLockManager.EXPECT().
+		ExecuteWithLock(gomock.Any(), roomID, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, key string, fn func(context.Context) error) error {
+			return fn(ctx)
+		})
+
+	mockHub.EXPECT().LoadRoom(ctx, roomID).Return(room, nil)
+	mockHub.EXPECT().SaveRoom(ctx, room).Return(nil)
+	mockHub.EXPECT().BroadcastToRoom(ctx, roomID, gomock.Any()).Return(nil)
+
+	uc := NewChangeConsensusRuleUseCase(mockHub, mockLockManager)
+	cmd := ChangeConsensusRuleCommand{
+		RoomID:   roomID,
+		SenderID: clientID,
+		Rule:     string(entity.ConsensusAdjacent),
+	}
+
+	if err := uc.Execute(ctx, cmd); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if room.ConsensusRule != entity.ConsensusAdjacent {
+		t.Errorf("expected rule %s, got %s", entity.ConsensusAdjacent, room.ConsensusRule)
+	}
+}
+
+func TestChangeConsensusRuleUseCase_Execute_InvalidRule(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockHub := domain.NewMockHub(ctrl)
+	mockLockManager := lock.NewMockLockManager(ctrl)
+
+	uc := NewChangeConsensusRuleUseCase(mockHub, mockLockManager)
+	cmd := ChangeConsensusRuleCommand{
+		RoomID:   "room123",
+		SenderID: "client123",
+		Rule:     "majority",
+	}
+
+	err := uc.Execute(ctx, cmd)
+
+	if !errors.Is(err, domain.ErrInvalidConsensusRule) {
+		t.Errorf("expected ErrInvalidConsensusRule, got %v", err)
+	}
+}
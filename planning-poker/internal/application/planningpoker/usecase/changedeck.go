@@ -0,0 +1,59 @@
+package usecase
+
+import (
+	"context"
+	"planning-poker/internal/application/lock"
+	"planning-poker/internal/application/planningpoker/usecase/dto"
+	"planning-poker/internal/domain"
+	"planning-poker/internal/domain/entity"
+)
+
+type (
+	ChangeDeckCommand struct {
+		RoomID    string
+		SenderID  string
+		DeckName  string
+		DeckCards []string
+	}
+	ChangeDeckUseCase struct {
+		hub         domain.Hub
+		lockManager lock.LockManager
+	}
+)
+
+var _ UseCase[ChangeDeckCommand] = (*ChangeDeckUseCase)(nil)
+
+func NewChangeDeckUseCase(hub domain.Hub, lockManager lock.LockManager) ChangeDeckUseCase {
+	return ChangeDeckUseCase{
+		hub:         hub,
+		lockManager: lockManager,
+	}
+}
+
+func (uc ChangeDeckUseCase) Execute(ctx context.Context, cmd ChangeDeckCommand) error {
+	deck, err := entity.NewDeck(cmd.DeckName, cmd.DeckCards)
+	if err != nil {
+		return err
+	}
+
+	return uc.lockManager.ExecuteWithLock(ctx, cmd.RoomID, func(ctx context.Context) error {
+		room, err := uc.hub.LoadRoom(ctx, cmd.RoomID)
+		if err != nil {
+			return err
+		}
+
+		if err := room.ChangeDeck(ctx, cmd.SenderID, deck); err != nil {
+			return err
+		}
+
+		if err := uc.hub.SaveRoom(ctx, room); err != nil {
+			return err
+		}
+
+		if err := uc.hub.BroadcastToRoom(ctx, room.ID, dto.NewRoomStateCommand(room)); err != nil {
+			return err
+		}
+
+		return nil
+	})
+}
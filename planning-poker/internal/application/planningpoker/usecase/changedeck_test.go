@@ -0,0 +1,116 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"planning-poker/internal/application/lock"
+	"planning-poker/internal/domain"
+	"planning-poker/internal/domain/entity"
+	"planning-poker/internal/infra/boundaries/hub/clientcollection"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+)
+
+func TestChangeDeckUseCase_Execute_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockHub := domain.NewMockHub(ctrl)
+	mockLockManager := lock.NewMockLockManager(ctrl)
+
+	roomID := "room123"
+	clientID := "client123"
+	room := &entity.Room{
+		ID:      roomID,
+		Clients: clientcollection.New(),
+	}
+
+	client := room.NewClient(clientID)
+	client.IsOwner = true
+
+	mockLockManager.EXPECT().
+		ExecuteWithLock(gomock.Any(), roomID, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, key string, fn func(context.Context) error) error {
+			return fn(ctx)
+		})
+
+	mockHub.EXPECT().LoadRoom(ctx, roomID).Return(room, nil)
+	mockHub.EXPECT().SaveRoom(ctx, room).Return(nil)
+	mockHub.EXPECT().BroadcastToRoom(ctx, roomID, gomock.Any()).Return(nil)
+
+	uc := NewChangeDeckUseCase(mockHub, mockLockManager)
+	cmd := ChangeDeckCommand{
+		RoomID:    roomID,
+		SenderID:  clientID,
+		DeckName:  entity.DeckCustom,
+		DeckCards: []string{"small", "medium", "large"},
+	}
+
+	if err := uc.Execute(ctx, cmd); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if room.Deck.Name != entity.DeckCustom {
+		t.Errorf("expected deck %s, got %s", entity.DeckCustom, room.Deck.Name)
+	}
+}
+
+func TestChangeDeckUseCase_Execute_InvalidDeck(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockHub := domain.NewMockHub(ctrl)
+	mockLockManager := lock.NewMockLockManager(ctrl)
+
+	uc := NewChangeDeckUseCase(mockHub, mockLockManager)
+	cmd := ChangeDeckCommand{
+		RoomID:   "room123",
+		SenderID: "client123",
+		DeckName: "unknown",
+	}
+
+	err := uc.Execute(ctx, cmd)
+
+	if !errors.Is(err, domain.ErrInvalidDeck) {
+		t.Errorf("expected ErrInvalidDeck, got %v", err)
+	}
+}
+
+func TestChangeDeckUseCase_Execute_NonOwner(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockHub := domain.NewMockHub(ctrl)
+	mockLockManager := lock.NewMockLockManager(ctrl)
+
+	roomID := "room123"
+	room := &entity.Room{
+		ID:      roomID,
+		Clients: clientcollection.New(),
+	}
+	room.NewClient("owner")
+	room.NewClient("client123")
+
+	mockLockManager.EXPECT().
+		ExecuteWithLock(gomock.Any(), roomID, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, key string, fn func(context.Context) error) error {
+			return fn(ctx)
+		})
+
+	mockHub.EXPECT().LoadRoom(ctx, roomID).Return(room, nil)
+
+	uc := NewChangeDeckUseCase(mockHub, mockLockManager)
+	cmd := ChangeDeckCommand{
+		RoomID:   roomID,
+		SenderID: "client123",
+		DeckName: entity.DeckTShirt,
+	}
+
+	if err := uc.Execute(ctx, cmd); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+}
@@ -0,0 +1,68 @@
+package usecase
+
+import (
+	"context"
+	"planning-poker/internal/application/lock"
+	"planning-poker/internal/application/planningpoker/usecase/dto"
+	"planning-poker/internal/domain"
+	"planning-poker/internal/domain/entity"
+)
+
+type (
+	ChangePermissionCommand struct {
+		RoomID   string
+		SenderID string
+		Action   string
+		Roles    []string
+	}
+	ChangePermissionUseCase struct {
+		hub         domain.Hub
+		lockManager lock.LockManager
+	}
+)
+
+var _ UseCase[ChangePermissionCommand] = (*ChangePermissionUseCase)(nil)
+
+func NewChangePermissionUseCase(hub domain.Hub, lockManager lock.LockManager) ChangePermissionUseCase {
+	return ChangePermissionUseCase{
+		hub:         hub,
+		lockManager: lockManager,
+	}
+}
+
+func (uc ChangePermissionUseCase) Execute(ctx context.Context, cmd ChangePermissionCommand) error {
+	action, err := entity.ParseAction(cmd.Action)
+	if err != nil {
+		return err
+	}
+
+	roles := make([]entity.Role, 0, len(cmd.Roles))
+	for _, r := range cmd.Roles {
+		role, err := entity.ParseRole(r)
+		if err != nil {
+			return err
+		}
+		roles = append(roles, role)
+	}
+
+	return uc.lockManager.ExecuteWithLock(ctx, cmd.RoomID, func(ctx context.Context) error {
+		room, err := uc.hub.LoadRoom(ctx, cmd.RoomID)
+		if err != nil {
+			return err
+		}
+
+		if err := room.ChangePermission(ctx, cmd.SenderID, action, roles); err != nil {
+			return err
+		}
+
+		if err := uc.hub.SaveRoom(ctx, room); err != nil {
+			return err
+		}
+
+		if err := uc.hub.BroadcastToRoom(ctx, room.ID, dto.NewRoomStateCommand(room)); err != nil {
+			return err
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,88 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"planning-poker/internal/application/lock"
+	"planning-poker/internal/domain"
+	"planning-poker/internal/domain/entity"
+	"planning-poker/internal/infra/boundaries/hub/clientcollection"
+	"reflect"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+)
+
+func TestChangePermissionUseCase_Execute_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockHub := domain.NewMockHub(ctrl)
+	mockLockManager := lock.NewMockLockManager(ctrl)
+
+	roomID := "room123"
+	clientID := "client123"
+	room := &entity.Room{
+		ID:      roomID,
+		Clients: clientcollection.New(),
+	}
+	room.NewClient(clientID)
+
+	mockLockManager.EXPECT().
+		ExecuteWithLock(gomock.Any(), roomID, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, key string, fn func(context.Context) error) error {
+			return fn(ctx)
+		})
+
+	mockHub.EXPECT().LoadRoom(ctx, roomID).Return(room, nil)
+	mockHub.EXPECT().SaveRoom(ctx, room).Return(nil)
+	mockHub.EXPECT().BroadcastToRoom(ctx, roomID, gomock.Any()).Return(nil)
+
+	uc := NewChangePermissionUseCase(mockHub, mockLockManager)
+	cmd := ChangePermissionCommand{
+		RoomID:   roomID,
+		SenderID: clientID,
+		Action:   "toggle-reveal",
+		Roles:    []string{"voter"},
+	}
+
+	if err := uc.Execute(ctx, cmd); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	want := []entity.Role{entity.RoleFacilitator, entity.RoleVoter}
+	if got := room.Policy.Roles(entity.ActionToggleReveal); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected roles %v, got %v", want, got)
+	}
+}
+
+func TestChangePermissionUseCase_Execute_InvalidInput(t *testing.T) {
+	tests := []struct {
+		name   string
+		action string
+		roles  []string
+	}{
+		{name: "unknown action", action: "fly", roles: []string{"voter"}},
+		{name: "unknown role", action: "toggle-reveal", roles: []string{"admin"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			uc := NewChangePermissionUseCase(domain.NewMockHub(ctrl), lock.NewMockLockManager(ctrl))
+			cmd := ChangePermissionCommand{
+				RoomID:   "room123",
+				SenderID: "client123",
+				Action:   tt.action,
+				Roles:    tt.roles,
+			}
+
+			if err := uc.Execute(context.Background(), cmd); !errors.Is(err, domain.ErrInvalidPermission) {
+				t.Errorf("expected ErrInvalidPermission, got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"planning-poker/internal/application/lock"
+	"planning-poker/internal/application/planningpoker/metric"
+	"planning-poker/internal/application/planningpoker/usecase/dto"
+	"planning-poker/internal/application/timer"
+	"planning-poker/internal/application/webhook"
+	"planning-poker/internal/domain"
+	"planning-poker/internal/domain/entity"
+
+	"github.com/bruno303/go-toolkit/pkg/log"
+)
+
+type (
+	CloseExpiredRoomCommand struct {
+		RoomID string
+	}
+	// CloseExpiredRoomUseCase removes a room whose lifecycle is over. Every
+	// instance may try to close the same room; the room lock makes the first
+	// one close it and turns the others into no-ops. Clients are told the room
+	// was closed and disconnect, leaving the room as usual.
+	CloseExpiredRoomUseCase struct {
+		hub         domain.Hub
+		lockManager lock.LockManager
+		metric      metric.PlanningPokerMetric
+		lifecycle   entity.RoomLifecycle
+		clock       timer.Clock
+		webhooks    webhook.Publisher
+		logger      log.Logger
+	}
+)
+
+var _ UseCase[CloseExpiredRoomCommand] = (*CloseExpiredRoomUseCase)(nil)
+
+func NewCloseExpiredRoomUseCase(
+	hub domain.Hub,
+	lockManager lock.LockManager,
+	metric metric.PlanningPokerMetric,
+	lifecycle entity.RoomLifecycle,
+	clock timer.Clock,
+	webhooks webhook.Publisher,
+) CloseExpiredRoomUseCase {
+	return CloseExpiredRoomUseCase{
+		hub:         hub,
+		lockManager: lockManager,
+		metric:      metric,
+		lifecycle:   lifecycle,
+		clock:       clock,
+		webhooks:    webhooks,
+		logger:      log.NewLogger("usecase.closeexpiredroom"),
+	}
+}
+
+func (uc CloseExpiredRoomUseCase) Execute(ctx context.Context, cmd CloseExpiredRoomCommand) error {
+	return uc.lockManager.ExecuteWithLock(ctx, cmd.RoomID, func(ctx context.Context) error {
+		room, err := uc.hub.LoadRoom(ctx, cmd.RoomID)
+		if errors.Is(err, domain.ErrRoomNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		expiresAt, reason, ok := room.Expiry(uc.lifecycle)
+		if !ok || uc.clock.Now().Before(expiresAt) {
+			// the room changed after it was picked up
+			return nil
+		}
+
+		if !room.IsEmpty() {
+			if err := uc.hub.BroadcastToRoom(ctx, room.ID, dto.NewRoomClosedNotification(reason)); err != nil {
+				uc.logger.Error(ctx, "Error notifying clients that the room was closed", err)
+			}
+		}
+		uc.hub.RemoveRoom(room.ID)
+		uc.metric.DecrementActiveRoomsCounter(ctx)
+		uc.webhooks.Publish(ctx, webhook.NewSessionEndedEvent(room, reason))
+
+		uc.logger.Info(ctx, "Room %s closed, reason: %s", room.ID, reason)
+		return nil
+	})
+}
@@ -0,0 +1,130 @@
+package usecase
+
+import (
+	"context"
+	"planning-poker/internal/application/lock"
+	"planning-poker/internal/application/planningpoker/metric"
+	"planning-poker/internal/application/planningpoker/usecase/dto"
+	"planning-poker/internal/application/timer"
+	"planning-poker/internal/application/webhook"
+	"planning-poker/internal/domain"
+	"planning-poker/internal/domain/entity"
+	"planning-poker/internal/infra/boundaries/hub/clientcollection"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+)
+
+func TestCloseExpiredRoomUseCase_Execute(t *testing.T) {
+	roomID := "room123"
+	updatedAt := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	lifecycle := entity.RoomLifecycle{IdleTimeout: time.Hour, EmptyRoomTTL: 10 * time.Minute}
+
+	tests := []struct {
+		name          string
+		empty         bool
+		now           time.Time
+		roomErr       error
+		wantClose     bool
+		wantBroadcast bool
+	}{
+		{
+			name:          "closes idle room and tells its clients",
+			now:           updatedAt.Add(time.Hour),
+			wantClose:     true,
+			wantBroadcast: true,
+		},
+		{
+			name:      "closes empty room without broadcasting",
+			empty:     true,
+			now:       updatedAt.Add(10 * time.Minute),
+			wantClose: true,
+		},
+		{
+			name: "keeps room that changed after it was picked up",
+			now:  updatedAt.Add(time.Hour - time.Second),
+		},
+		{
+			name:    "ignores room that was already removed",
+			roomErr: domain.ErrRoomNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.Background()
+			mockHub := domain.NewMockHub(ctrl)
+			mockLockManager := lock.NewMockLockManager(ctrl)
+			mockClock := timer.NewMockClock(ctrl)
+			testMetric, metricMeter := newTestPlanningPokerMetric(ctrl)
+
+			room := entity.NewRoomWithID(roomID, clientcollection.New())
+			if !tt.empty {
+				room.NewClient("client123")
+			}
+			room.UpdatedAt = updatedAt
+
+			mockLockManager.EXPECT().
+				ExecuteWithLock(gomock.Any(), roomID, gomock.Any()).
+				DoAndReturn(func(ctx context.Context, key string, fn func(context.Context) error) error {
+					return fn(ctx)
+				})
+
+			if tt.roomErr != nil {
+				mockHub.EXPECT().LoadRoom(ctx, roomID).Return(nil, tt.roomErr)
+			} else {
+				mockHub.EXPECT().LoadRoom(ctx, roomID).Return(room, nil)
+				mockClock.EXPECT().Now().Return(tt.now)
+			}
+			if tt.wantBroadcast {
+				mockHub.EXPECT().BroadcastToRoom(ctx, roomID, dto.NewRoomClosedNotification(entity.ExpiryIdle)).Return(nil)
+			}
+			mockWebhooks := webhook.NewMockPublisher(ctrl)
+			if tt.wantClose {
+				mockHub.EXPECT().RemoveRoom(roomID)
+				mockWebhooks.EXPECT().Publish(ctx, gomock.Any()).Do(func(_ context.Context, event webhook.Event) {
+					if event.Type != webhook.EventSessionEnded || event.RoomID != roomID {
+						t.Errorf("unexpected event %+v", event)
+					}
+				})
+			}
+
+			uc := NewCloseExpiredRoomUseCase(mockHub, mockLockManager, testMetric, lifecycle, mockClock, mockWebhooks)
+
+			if err := uc.Execute(ctx, CloseExpiredRoomCommand{RoomID: roomID}); err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+
+			wantDecrements := 0
+			if tt.wantClose {
+				wantDecrements = 1
+			}
+			if got := countMetricCallsWithValue(metricMeter.getCalls(), metric.PlanningPokerActiveRoomsMetric, -1); got != wantDecrements {
+				t.Errorf("expected %d active room decrements, got %d", wantDecrements, got)
+			}
+		})
+	}
+}
+
+func TestWarnRoomExpiringUseCase_Execute(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockHub := domain.NewMockHub(ctrl)
+	expiresAt := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	mockHub.EXPECT().
+		BroadcastToRoom(ctx, "room123", dto.RoomExpiring{Type: "room-expiring", Reason: "max-lifetime", ExpiresAt: expiresAt}).
+		Return(nil)
+
+	uc := NewWarnRoomExpiringUseCase(mockHub)
+	err := uc.Execute(ctx, WarnRoomExpiringCommand{RoomID: "room123", Reason: entity.ExpiryMaxLifetime, ExpiresAt: expiresAt})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}
@@ -0,0 +1,63 @@
+package usecase
+
+import (
+	"context"
+	"planning-poker/internal/application/lock"
+	"planning-poker/internal/application/planningpoker/usecase/dto"
+	"planning-poker/internal/application/timer"
+	"planning-poker/internal/domain"
+	"time"
+)
+
+type (
+	CreateInviteCommand struct {
+		RoomID   string
+		SenderID string
+		TTL      time.Duration
+		// ReplyTo receives the answer instead of the bus of the sender
+		ReplyTo domain.Bus
+	}
+	CreateInviteUseCase struct {
+		hub         domain.Hub
+		lockManager lock.LockManager
+		clock       timer.Clock
+	}
+)
+
+var _ UseCase[CreateInviteCommand] = (*CreateInviteUseCase)(nil)
+
+func NewCreateInviteUseCase(hub domain.Hub, lockManager lock.LockManager, clock timer.Clock) CreateInviteUseCase {
+	return CreateInviteUseCase{
+		hub:         hub,
+		lockManager: lockManager,
+		clock:       clock,
+	}
+}
+
+// Execute sends the invite only to the owner that created it, who is then
+// responsible for sharing the link.
+func (uc CreateInviteUseCase) Execute(ctx context.Context, cmd CreateInviteCommand) error {
+	return uc.lockManager.ExecuteWithLock(ctx, cmd.RoomID, func(ctx context.Context) error {
+		room, err := uc.hub.LoadRoom(ctx, cmd.RoomID)
+		if err != nil {
+			return err
+		}
+
+		invite, err := room.CreateInvite(ctx, cmd.SenderID, uc.clock.Now(), cmd.TTL)
+		if err != nil {
+			return err
+		}
+
+		// the invite secret is generated on the first invite
+		if err := uc.hub.SaveRoom(ctx, room); err != nil {
+			return err
+		}
+
+		bus, err := replyBus(uc.hub, cmd.ReplyTo, cmd.SenderID)
+		if err != nil {
+			return err
+		}
+
+		return bus.Send(ctx, dto.NewInviteCreatedCommand(invite.Token, invite.ExpiresAt))
+	})
+}
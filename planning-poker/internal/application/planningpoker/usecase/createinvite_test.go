@@ -0,0 +1,102 @@
+package usecase
+
+import (
+	"context"
+	"planning-poker/internal/application/lock"
+	"planning-poker/internal/application/planningpoker/usecase/dto"
+	"planning-poker/internal/application/timer"
+	"planning-poker/internal/domain"
+	"planning-poker/internal/domain/entity"
+	"planning-poker/internal/infra/boundaries/hub/clientcollection"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+)
+
+func TestCreateInviteUseCase_Execute_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockHub := domain.NewMockHub(ctrl)
+	mockLockManager := lock.NewMockLockManager(ctrl)
+	mockClock := timer.NewMockClock(ctrl)
+	mockBus := domain.NewMockBus(ctrl)
+
+	roomID := "room123"
+	now := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	room := &entity.Room{
+		ID:      roomID,
+		Clients: clientcollection.New(),
+	}
+	room.NewClient("owner")
+
+	mockLockManager.EXPECT().
+		ExecuteWithLock(gomock.Any(), roomID, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, key string, fn func(context.Context) error) error {
+			return fn(ctx)
+		})
+
+	mockClock.EXPECT().Now().Return(now)
+	mockHub.EXPECT().LoadRoom(ctx, roomID).Return(room, nil)
+	mockHub.EXPECT().SaveRoom(ctx, room).Return(nil)
+	mockHub.EXPECT().GetBus("owner").Return(mockBus, true)
+
+	var sent dto.InviteCreated
+	mockBus.EXPECT().Send(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, msg any) error {
+		sent = msg.(dto.InviteCreated)
+		return nil
+	})
+
+	uc := NewCreateInviteUseCase(mockHub, mockLockManager, mockClock)
+	cmd := CreateInviteCommand{RoomID: roomID, SenderID: "owner", TTL: time.Hour}
+
+	if err := uc.Execute(ctx, cmd); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if sent.Type != "invite-created" || sent.Token == "" {
+		t.Errorf("unexpected invite message %+v", sent)
+	}
+	if !sent.ExpiresAt.Equal(now.Add(time.Hour)) {
+		t.Errorf("expected expiry %v, got %v", now.Add(time.Hour), sent.ExpiresAt)
+	}
+	if len(room.InviteSecret) == 0 {
+		t.Error("expected invite secret to be generated")
+	}
+}
+
+func TestCreateInviteUseCase_Execute_NonOwner(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockHub := domain.NewMockHub(ctrl)
+	mockLockManager := lock.NewMockLockManager(ctrl)
+	mockClock := timer.NewMockClock(ctrl)
+
+	roomID := "room123"
+	room := &entity.Room{
+		ID:      roomID,
+		Clients: clientcollection.New(),
+	}
+	room.NewClient("owner")
+	room.NewClient("client123")
+
+	mockLockManager.EXPECT().
+		ExecuteWithLock(gomock.Any(), roomID, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, key string, fn func(context.Context) error) error {
+			return fn(ctx)
+		})
+
+	mockClock.EXPECT().Now().Return(time.Now())
+	mockHub.EXPECT().LoadRoom(ctx, roomID).Return(room, nil)
+
+	uc := NewCreateInviteUseCase(mockHub, mockLockManager, mockClock)
+	cmd := CreateInviteCommand{RoomID: roomID, SenderID: "client123"}
+
+	if err := uc.Execute(ctx, cmd); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+}
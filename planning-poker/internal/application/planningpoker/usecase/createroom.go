@@ -4,13 +4,25 @@ import (
 	"context"
 	"planning-poker/internal/application/planningpoker/metric"
 	"planning-poker/internal/domain"
+	"planning-poker/internal/domain/entity"
 
 	"github.com/bruno303/go-toolkit/pkg/log"
 )
 
 type (
+	CreateRoomCommand struct {
+		DeckName  string
+		DeckCards []string
+		Passcode  string
+		// WithBot adds a bot named BotName, for tools driving the room
+		// through the API before anyone joins it
+		WithBot bool
+		BotName string
+	}
 	CreateRoomOutput struct {
 		RoomID string
+		// Bot is set when the room was created with a bot
+		Bot *AddBotOutput
 	}
 	CreateRoomUseCase struct {
 		hub    domain.Hub
@@ -19,7 +31,7 @@ type (
 	}
 )
 
-var _ UseCaseO[CreateRoomOutput] = (*CreateRoomUseCase)(nil)
+var _ UseCaseR[CreateRoomCommand, CreateRoomOutput] = (*CreateRoomUseCase)(nil)
 
 func NewCreateRoomUseCase(hub domain.Hub, metric metric.PlanningPokerMetric) CreateRoomUseCase {
 	return CreateRoomUseCase{
@@ -29,14 +41,47 @@ func NewCreateRoomUseCase(hub domain.Hub, metric metric.PlanningPokerMetric) Cre
 	}
 }
 
-func (uc CreateRoomUseCase) Execute(ctx context.Context) (CreateRoomOutput, error) {
+func (uc CreateRoomUseCase) Execute(ctx context.Context, cmd CreateRoomCommand) (CreateRoomOutput, error) {
+	deck, err := entity.NewDeck(cmd.DeckName, cmd.DeckCards)
+	if err != nil {
+		return CreateRoomOutput{}, err
+	}
+
+	passcodeHash, err := entity.NewPasscodeHash(cmd.Passcode)
+	if err != nil {
+		return CreateRoomOutput{}, err
+	}
+
+	botName, err := entity.NewBotName(cmd.BotName)
+	if err != nil {
+		return CreateRoomOutput{}, err
+	}
+
 	room, err := uc.hub.NewRoom(ctx)
 	if err != nil {
 		return CreateRoomOutput{}, err
 	}
 
-	uc.logger.Info(ctx, "Room created with ID: %s", room.ID)
+	output := CreateRoomOutput{RoomID: room.ID}
+	configured := deck.Name != room.EffectiveDeck().Name || passcodeHash != ""
+	if configured {
+		room.Configure(ctx, deck, passcodeHash)
+	}
+	if cmd.WithBot {
+		credentials, err := room.NewBot(ctx, botName)
+		if err != nil {
+			return CreateRoomOutput{}, err
+		}
+		output.Bot = &AddBotOutput{ClientID: credentials.ClientID, Token: credentials.Token}
+	}
+	if configured || cmd.WithBot {
+		if err := uc.hub.SaveRoom(ctx, room); err != nil {
+			return CreateRoomOutput{}, err
+		}
+	}
+
+	uc.logger.Info(ctx, "Room created with ID: %s and deck: %s", room.ID, deck.Name)
 	uc.metric.IncrementActiveRoomsCounter(ctx)
 
-	return CreateRoomOutput{RoomID: room.ID}, nil
+	return output, nil
 }
@@ -0,0 +1,85 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"planning-poker/internal/application/timer"
+	"planning-poker/internal/application/webhook"
+	"planning-poker/internal/domain"
+	"planning-poker/internal/domain/entity"
+)
+
+// maxRoomWebhooks bounds the deliveries a single room can cause per event.
+const maxRoomWebhooks = 10
+
+type (
+	CreateWebhookCommand struct {
+		RoomID   string
+		SenderID string
+		URL      string
+		// EventTypes is empty to subscribe to every event
+		EventTypes []string
+	}
+	CreateWebhookUseCase struct {
+		hub   domain.Hub
+		store webhook.Store
+		clock timer.Clock
+	}
+)
+
+var _ UseCaseR[CreateWebhookCommand, webhook.Subscription] = (*CreateWebhookUseCase)(nil)
+
+func NewCreateWebhookUseCase(hub domain.Hub, store webhook.Store, clock timer.Clock) CreateWebhookUseCase {
+	return CreateWebhookUseCase{
+		hub:   hub,
+		store: store,
+		clock: clock,
+	}
+}
+
+// Execute subscribes a URL to the events of the room until its session
+// ends. The subscription is returned with its secret, which is not shown
+// again.
+func (uc CreateWebhookUseCase) Execute(ctx context.Context, cmd CreateWebhookCommand) (webhook.Subscription, error) {
+	room, err := uc.hub.LoadRoom(ctx, cmd.RoomID)
+	if err != nil {
+		return webhook.Subscription{}, err
+	}
+	if _, err := room.CheckPermission(cmd.SenderID, entity.ActionManageAccess); err != nil {
+		return webhook.Subscription{}, err
+	}
+
+	subscription, err := webhook.NewSubscription(room.ID, cmd.URL, cmd.EventTypes, uc.clock)
+	if err != nil {
+		return webhook.Subscription{}, err
+	}
+
+	subscriptions, err := roomWebhooks(ctx, uc.store, room.ID)
+	if err != nil {
+		return webhook.Subscription{}, err
+	}
+	if len(subscriptions) >= maxRoomWebhooks {
+		return webhook.Subscription{}, fmt.Errorf("a room holds at most %d webhooks: %w", maxRoomWebhooks, domain.ErrInvalidWebhook)
+	}
+
+	if err := uc.store.AddSubscription(ctx, subscription); err != nil {
+		return webhook.Subscription{}, err
+	}
+	return subscription, nil
+}
+
+// roomWebhooks returns the subscriptions to the events of the room only.
+func roomWebhooks(ctx context.Context, store webhook.Store, roomID string) ([]webhook.Subscription, error) {
+	subscriptions, err := store.Subscriptions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var roomSubscriptions []webhook.Subscription
+	for _, subscription := range subscriptions {
+		if subscription.RoomID == roomID {
+			roomSubscriptions = append(roomSubscriptions, subscription)
+		}
+	}
+	return roomSubscriptions, nil
+}
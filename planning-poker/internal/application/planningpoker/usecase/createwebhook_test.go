@@ -0,0 +1,90 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"planning-poker/internal/application/timer"
+	"planning-poker/internal/application/webhook"
+	"planning-poker/internal/domain"
+	"planning-poker/internal/domain/entity"
+	"planning-poker/internal/infra/boundaries/hub/clientcollection"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+)
+
+func TestCreateWebhookUseCase_Execute_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockHub := domain.NewMockHub(ctrl)
+	mockStore := webhook.NewMockStore(ctrl)
+
+	room := entity.NewRoomWithID("room123", clientcollection.New())
+	room.NewClient("owner")
+
+	mockHub.EXPECT().LoadRoom(ctx, "room123").Return(room, nil)
+	mockStore.EXPECT().Subscriptions(ctx).Return([]webhook.Subscription{{ID: "instance"}}, nil)
+	mockStore.EXPECT().AddSubscription(ctx, gomock.Any()).Return(nil)
+
+	uc := NewCreateWebhookUseCase(mockHub, mockStore, timer.SystemClock{})
+	subscription, err := uc.Execute(ctx, CreateWebhookCommand{
+		RoomID:     "room123",
+		SenderID:   "owner",
+		URL:        "https://hooks.example.com/poker",
+		EventTypes: []string{"story.revealed"},
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if subscription.RoomID != "room123" || subscription.Secret == "" || len(subscription.EventTypes) != 1 {
+		t.Errorf("unexpected subscription %+v", subscription)
+	}
+}
+
+func TestCreateWebhookUseCase_Execute_PermissionDenied(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockHub := domain.NewMockHub(ctrl)
+	mockStore := webhook.NewMockStore(ctrl)
+
+	room := entity.NewRoomWithID("room123", clientcollection.New())
+	room.NewClient("owner")
+	room.NewClient("voter")
+
+	mockHub.EXPECT().LoadRoom(ctx, "room123").Return(room, nil)
+
+	uc := NewCreateWebhookUseCase(mockHub, mockStore, timer.SystemClock{})
+	_, err := uc.Execute(ctx, CreateWebhookCommand{RoomID: "room123", SenderID: "voter", URL: "https://hooks.example.com/poker"})
+	if !errors.Is(err, domain.ErrPermissionDenied) {
+		t.Fatalf("expected ErrPermissionDenied, got %v", err)
+	}
+}
+
+func TestCreateWebhookUseCase_Execute_TooManyWebhooks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockHub := domain.NewMockHub(ctrl)
+	mockStore := webhook.NewMockStore(ctrl)
+
+	room := entity.NewRoomWithID("room123", clientcollection.New())
+	room.NewClient("owner")
+
+	existing := make([]webhook.Subscription, maxRoomWebhooks)
+	for i := range existing {
+		existing[i].RoomID = "room123"
+	}
+	mockHub.EXPECT().LoadRoom(ctx, "room123").Return(room, nil)
+	mockStore.EXPECT().Subscriptions(ctx).Return(existing, nil)
+
+	uc := NewCreateWebhookUseCase(mockHub, mockStore, timer.SystemClock{})
+	_, err := uc.Execute(ctx, CreateWebhookCommand{RoomID: "room123", SenderID: "owner", URL: "https://hooks.example.com/poker"})
+	if !errors.Is(err, domain.ErrInvalidWebhook) {
+		t.Fatalf("expected ErrInvalidWebhook, got %v", err)
+	}
+}
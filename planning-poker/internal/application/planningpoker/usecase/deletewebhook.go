@@ -0,0 +1,54 @@
+package usecase
+
+import (
+	"context"
+	"planning-poker/internal/application/webhook"
+	"planning-poker/internal/domain"
+	"planning-poker/internal/domain/entity"
+	"slices"
+)
+
+type (
+	DeleteWebhookCommand struct {
+		RoomID         string
+		SenderID       string
+		SubscriptionID string
+	}
+	DeleteWebhookUseCase struct {
+		hub   domain.Hub
+		store webhook.Store
+	}
+)
+
+var _ UseCase[DeleteWebhookCommand] = (*DeleteWebhookUseCase)(nil)
+
+func NewDeleteWebhookUseCase(hub domain.Hub, store webhook.Store) DeleteWebhookUseCase {
+	return DeleteWebhookUseCase{
+		hub:   hub,
+		store: store,
+	}
+}
+
+// Execute removes a subscription of the room, those of other rooms and of
+// the whole instance are not found.
+func (uc DeleteWebhookUseCase) Execute(ctx context.Context, cmd DeleteWebhookCommand) error {
+	room, err := uc.hub.LoadRoom(ctx, cmd.RoomID)
+	if err != nil {
+		return err
+	}
+	if _, err := room.CheckPermission(cmd.SenderID, entity.ActionManageAccess); err != nil {
+		return err
+	}
+
+	subscriptions, err := roomWebhooks(ctx, uc.store, room.ID)
+	if err != nil {
+		return err
+	}
+	if !slices.ContainsFunc(subscriptions, func(subscription webhook.Subscription) bool {
+		return subscription.ID == cmd.SubscriptionID
+	}) {
+		return domain.ErrWebhookNotFound
+	}
+
+	return uc.store.RemoveSubscription(ctx, cmd.SubscriptionID)
+}
@@ -0,0 +1,60 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"planning-poker/internal/application/webhook"
+	"planning-poker/internal/domain"
+	"planning-poker/internal/domain/entity"
+	"planning-poker/internal/infra/boundaries/hub/clientcollection"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+)
+
+func TestDeleteWebhookUseCase_Execute_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockHub := domain.NewMockHub(ctrl)
+	mockStore := webhook.NewMockStore(ctrl)
+
+	room := entity.NewRoomWithID("room123", clientcollection.New())
+	room.NewClient("owner")
+
+	mockHub.EXPECT().LoadRoom(ctx, "room123").Return(room, nil)
+	mockStore.EXPECT().Subscriptions(ctx).Return([]webhook.Subscription{{ID: "subscription-1", RoomID: "room123"}}, nil)
+	mockStore.EXPECT().RemoveSubscription(ctx, "subscription-1").Return(nil)
+
+	uc := NewDeleteWebhookUseCase(mockHub, mockStore)
+	if err := uc.Execute(ctx, DeleteWebhookCommand{RoomID: "room123", SenderID: "owner", SubscriptionID: "subscription-1"}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}
+
+func TestDeleteWebhookUseCase_Execute_OtherRoomsAreNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockHub := domain.NewMockHub(ctrl)
+	mockStore := webhook.NewMockStore(ctrl)
+
+	room := entity.NewRoomWithID("room123", clientcollection.New())
+	room.NewClient("owner")
+
+	mockHub.EXPECT().LoadRoom(ctx, "room123").Return(room, nil).Times(2)
+	mockStore.EXPECT().Subscriptions(ctx).Return([]webhook.Subscription{
+		{ID: "instance"},
+		{ID: "other-room", RoomID: "room456"},
+	}, nil).Times(2)
+
+	uc := NewDeleteWebhookUseCase(mockHub, mockStore)
+	for _, id := range []string{"instance", "other-room"} {
+		err := uc.Execute(ctx, DeleteWebhookCommand{RoomID: "room123", SenderID: "owner", SubscriptionID: id})
+		if !errors.Is(err, domain.ErrWebhookNotFound) {
+			t.Errorf("expected ErrWebhookNotFound for %s, got %v", id, err)
+		}
+	}
+}
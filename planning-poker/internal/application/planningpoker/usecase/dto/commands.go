@@ -1,31 +1,60 @@
 package dto
 
 import (
+	"planning-poker/internal/domain/domainerror"
 	"planning-poker/internal/domain/entity"
 	"slices"
 	"strings"
+	"time"
 
 	"github.com/samber/lo"
 )
 
+// RoomClosedType is sent once a room is removed, its clients are disconnected
+// after it
+const RoomClosedType = "room-closed"
+
 type (
 	Story struct {
-		Name               string   `json:"name"`
-		Result             *float32 `json:"result,omitempty"`
-		MostAppearingVotes []int    `json:"mostAppearingVotes"`
-		Voted              bool     `json:"voted"`
+		Name               string      `json:"name"`
+		Result             *float32    `json:"result,omitempty"`
+		MostAppearingVotes []int       `json:"mostAppearingVotes"`
+		Voted              bool        `json:"voted"`
+		Statistics         *Statistics `json:"statistics,omitempty"`
+	}
+
+	Statistics struct {
+		Median    float32  `json:"median"`
+		Min       int      `json:"min"`
+		Max       int      `json:"max"`
+		StdDev    float32  `json:"stdDev"`
+		Outliers  []string `json:"outliers"`
+		Consensus bool     `json:"consensus"`
+	}
+
+	Deck struct {
+		Name    string   `json:"name"`
+		Cards   []string `json:"cards"`
+		Numeric bool     `json:"numeric"`
 	}
 
 	RoomState struct {
-		Type               string        `json:"type"`
-		CurrentStory       string        `json:"currentStory"`
-		Reveal             bool          `json:"reveal"`
-		Result             *float32      `json:"result,omitempty"`
-		MostAppearingVotes []int         `json:"mostAppearingVotes"`
-		Participants       []Participant `json:"participants"`
-		BacklogMode        bool          `json:"backlogMode"`
-		Stories            []Story       `json:"stories"`
-		CurrentStoryIndex  int           `json:"currentStoryIndex"`
+		Type               string              `json:"type"`
+		Version            int64               `json:"version"`
+		CurrentStory       string              `json:"currentStory"`
+		Reveal             bool                `json:"reveal"`
+		Result             *float32            `json:"result,omitempty"`
+		MostAppearingVotes []int               `json:"mostAppearingVotes"`
+		Participants       []Participant       `json:"participants"`
+		BacklogMode        bool                `json:"backlogMode"`
+		Stories            []Story             `json:"stories"`
+		CurrentStoryIndex  int                 `json:"currentStoryIndex"`
+		Deck               Deck                `json:"deck"`
+		Statistics         *Statistics         `json:"statistics,omitempty"`
+		ConsensusRule      string              `json:"consensusRule"`
+		VotingDeadline     *time.Time          `json:"votingDeadline,omitempty"`
+		PasscodeProtected  bool                `json:"passcodeProtected"`
+		Permissions        map[string][]string `json:"permissions"`
 	}
 	Participant struct {
 		ID          string  `json:"id"`
@@ -34,6 +63,11 @@ type (
 		HasVoted    bool    `json:"hasVoted"`
 		IsSpectator bool    `json:"isSpectator"`
 		IsOwner     bool    `json:"isOwner"`
+		Role        string  `json:"role"`
+		// Authenticated participants signed in with the identity provider
+		Authenticated bool `json:"authenticated"`
+		// Bot participants drive the room through the REST API
+		Bot bool `json:"bot"`
 	}
 
 	UpdateClientID struct {
@@ -44,11 +78,58 @@ type (
 	KickNotification struct {
 		Type string `json:"type"`
 	}
+
+	RoomExpiring struct {
+		Type      string    `json:"type"`
+		Reason    string    `json:"reason"`
+		ExpiresAt time.Time `json:"expiresAt"`
+	}
+
+	RoomClosed struct {
+		Type   string `json:"type"`
+		Reason string `json:"reason"`
+	}
+
+	// Ack tells the sender its message was handled
+	Ack struct {
+		Type      string `json:"type"`
+		RequestID string `json:"requestId"`
+	}
+
+	// Error tells the sender its message failed. RequestID is empty when the
+	// message did not carry one.
+	Error struct {
+		Type      string           `json:"type"`
+		RequestID string           `json:"requestId,omitempty"`
+		Code      domainerror.Code `json:"code"`
+		Message   string           `json:"message"`
+	}
+
+	// ServerDraining tells the client the server is shutting down. It keeps
+	// its place in the room and should reconnect with the same client ID.
+	ServerDraining struct {
+		Type string `json:"type"`
+	}
+
+	InviteCreated struct {
+		Type      string    `json:"type"`
+		Token     string    `json:"token"`
+		ExpiresAt time.Time `json:"expiresAt"`
+	}
+
+	SessionReport struct {
+		Type        string `json:"type"`
+		Format      string `json:"format"`
+		ContentType string `json:"contentType"`
+		Filename    string `json:"filename"`
+		Content     string `json:"content"`
+	}
 )
 
 func NewRoomStateCommand(room *entity.Room) RoomState {
 	return RoomState{
-		Type:               "room-state",
+		Type:               RoomStateType,
+		Version:            room.Version,
 		CurrentStory:       room.EffectiveCurrentStory(),
 		Reveal:             room.Reveal,
 		Participants:       MapToParticipants(room.Clients.Values()),
@@ -57,6 +138,12 @@ func NewRoomStateCommand(room *entity.Room) RoomState {
 		BacklogMode:        room.BacklogMode,
 		Stories:            mapStories(room.Stories),
 		CurrentStoryIndex:  room.CurrentStoryIndex,
+		Deck:               mapDeck(room.EffectiveDeck()),
+		Statistics:         mapStatistics(room.Statistics),
+		ConsensusRule:      string(room.EffectiveConsensusRule()),
+		VotingDeadline:     room.VotingDeadline,
+		PasscodeProtected:  room.HasPasscode(),
+		Permissions:        mapPermissions(room.Policy),
 	}
 }
 
@@ -73,6 +160,58 @@ func NewKickNotification() KickNotification {
 	}
 }
 
+func NewRoomExpiringNotification(reason entity.ExpiryReason, expiresAt time.Time) RoomExpiring {
+	return RoomExpiring{
+		Type:      "room-expiring",
+		Reason:    string(reason),
+		ExpiresAt: expiresAt,
+	}
+}
+
+func NewRoomClosedNotification(reason entity.ExpiryReason) RoomClosed {
+	return RoomClosed{
+		Type:   RoomClosedType,
+		Reason: string(reason),
+	}
+}
+
+func NewAckCommand(requestID string) Ack {
+	return Ack{
+		Type:      "ack",
+		RequestID: requestID,
+	}
+}
+
+// NewErrorCommand hides the message of errors that are not domain errors,
+// which may expose internals.
+func NewErrorCommand(requestID string, err error) Error {
+	code := domainerror.CodeOf(err)
+	message := err.Error()
+	if code == domainerror.CodeInternal {
+		message = "internal error"
+	}
+	return Error{
+		Type:      "error",
+		RequestID: requestID,
+		Code:      code,
+		Message:   message,
+	}
+}
+
+func NewServerDrainingNotification() ServerDraining {
+	return ServerDraining{
+		Type: "server-draining",
+	}
+}
+
+func mapDeck(deck entity.Deck) Deck {
+	return Deck{
+		Name:    deck.Name,
+		Cards:   deck.Cards,
+		Numeric: deck.IsNumeric(),
+	}
+}
+
 func mapStories(stories []entity.Story) []Story {
 	return lo.Map(stories, func(s entity.Story, _ int) Story {
 		return Story{
@@ -80,11 +219,38 @@ func mapStories(stories []entity.Story) []Story {
 			Result:             s.Result,
 			MostAppearingVotes: s.MostAppearingVotes,
 			Voted:              s.Voted,
+			Statistics:         mapStatistics(s.Statistics),
 		}
 	})
 }
 
+func mapStatistics(stats *entity.VoteStatistics) *Statistics {
+	if stats == nil {
+		return nil
+	}
+	return &Statistics{
+		Median:    stats.Median,
+		Min:       stats.Min,
+		Max:       stats.Max,
+		StdDev:    stats.StdDev,
+		Outliers:  stats.Outliers,
+		Consensus: stats.Consensus,
+	}
+}
+
+func mapPermissions(policy entity.Policy) map[string][]string {
+	permissions := make(map[string][]string)
+	for action, roles := range policy.Matrix() {
+		permissions[string(action)] = lo.Map(roles, func(role entity.Role, _ int) string {
+			return string(role)
+		})
+	}
+	return permissions
+}
+
 func MapToParticipants(clients []*entity.Client) []Participant {
+	// sort a copy, the order of the room clients decides the next owner
+	clients = slices.Clone(clients)
 	slices.SortFunc(clients, func(a, b *entity.Client) int {
 		return strings.Compare(a.Name, b.Name)
 	})
@@ -93,13 +259,34 @@ func MapToParticipants(clients []*entity.Client) []Participant {
 		clients,
 		func(client *entity.Client, _ int) Participant {
 			return Participant{
-				ID:          client.ID,
-				Name:        client.Name,
-				Vote:        client.CurrentVote,
-				HasVoted:    client.HasVoted,
-				IsSpectator: client.IsSpectator,
-				IsOwner:     client.IsOwner,
+				ID:            client.ID,
+				Name:          client.Name,
+				Vote:          client.CurrentVote,
+				HasVoted:      client.HasVoted,
+				IsSpectator:   client.IsSpectator,
+				IsOwner:       client.IsOwner,
+				Role:          string(client.Role()),
+				Authenticated: client.Subject != "",
+				Bot:           client.Bot,
 			}
 		},
 	)
 }
+
+func NewSessionReportCommand(format, contentType, filename, content string) SessionReport {
+	return SessionReport{
+		Type:        "session-report",
+		Format:      format,
+		ContentType: contentType,
+		Filename:    filename,
+		Content:     content,
+	}
+}
+
+func NewInviteCreatedCommand(token string, expiresAt time.Time) InviteCreated {
+	return InviteCreated{
+		Type:      "invite-created",
+		Token:     token,
+		ExpiresAt: expiresAt,
+	}
+}
@@ -1,6 +1,9 @@
 package dto
 
 import (
+	"errors"
+	"fmt"
+	"planning-poker/internal/domain/domainerror"
 	"planning-poker/internal/domain/entity"
 	"planning-poker/internal/infra/boundaries/hub/clientcollection"
 	"reflect"
@@ -36,20 +39,62 @@ func TestNewRoomStateCommand(t *testing.T) {
 		CurrentStory: "Story 1",
 		Reveal:       true,
 		Participants: []Participant{
-			{ID: "1", Name: "Alice", Vote: &vote, HasVoted: true, IsSpectator: false, IsOwner: true},
-			{ID: "2", Name: "Bob", Vote: nil, HasVoted: false, IsSpectator: true, IsOwner: false},
+			{ID: "1", Name: "Alice", Vote: &vote, HasVoted: true, IsSpectator: false, IsOwner: true, Role: "facilitator"},
+			{ID: "2", Name: "Bob", Vote: nil, HasVoted: false, IsSpectator: true, IsOwner: false, Role: "observer"},
 		},
 		Result:             lo.ToPtr(float32(5)),
 		MostAppearingVotes: []int{1, 2},
 		BacklogMode:        true,
 		Stories:            []Story{},
 		CurrentStoryIndex:  0,
+		Deck: Deck{
+			Name:    entity.DeckFibonacci,
+			Cards:   entity.DefaultDeck().Cards,
+			Numeric: true,
+		},
+		ConsensusRule: string(entity.DefaultConsensusRule),
+		Permissions:   NewRoomStateCommand(entity.NewRoom(clientcollection.New())).Permissions,
 	}
 	if !reflect.DeepEqual(got, want) {
 		t.Errorf("NewRoomStateCommand() = %+v, want %+v", got, want)
 	}
 }
 
+func TestNewRoomStateCommand_Deck(t *testing.T) {
+	deck, _ := entity.BuiltInDeck(entity.DeckTShirt)
+	room := entity.NewRoom(clientcollection.New())
+	room.Deck = deck
+
+	got := NewRoomStateCommand(room).Deck
+	want := Deck{
+		Name:    entity.DeckTShirt,
+		Cards:   []string{"XS", "S", "M", "L", "XL", "XXL", "?", "☕"},
+		Numeric: false,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("NewRoomStateCommand().Deck = %+v, want %+v", got, want)
+	}
+}
+
+func TestNewRoomStateCommand_Permissions(t *testing.T) {
+	room := entity.NewRoom(clientcollection.New())
+	room.Policy.Overrides = map[entity.Action][]entity.Role{
+		entity.ActionToggleReveal: {entity.RoleVoter, entity.RoleObserver},
+	}
+
+	got := NewRoomStateCommand(room).Permissions
+
+	if want := []string{"facilitator", "voter", "observer"}; !reflect.DeepEqual(got["toggle-reveal"], want) {
+		t.Errorf("toggle-reveal = %v, want %v", got["toggle-reveal"], want)
+	}
+	if want := []string{"facilitator", "voter"}; !reflect.DeepEqual(got["vote"], want) {
+		t.Errorf("vote = %v, want %v", got["vote"], want)
+	}
+	if want := []string{"facilitator"}; !reflect.DeepEqual(got["add-story"], want) {
+		t.Errorf("add-story = %v, want %v", got["add-story"], want)
+	}
+}
+
 func TestNewUpdateClientIDCommand(t *testing.T) {
 	got := NewUpdateClientIDCommand("client-123")
 	want := UpdateClientID{
@@ -70,12 +115,49 @@ func TestMapToParticipants(t *testing.T) {
 	participants := MapToParticipants(clients)
 
 	expected := []Participant{
-		{ID: "1", Name: "Alice", Vote: lo.ToPtr("5"), HasVoted: true, IsSpectator: false, IsOwner: false},
-		{ID: "2", Name: "Bob", Vote: lo.ToPtr("3"), HasVoted: true, IsSpectator: false, IsOwner: true},
-		{ID: "3", Name: "Charlie", Vote: lo.ToPtr("?"), HasVoted: true, IsSpectator: true, IsOwner: false},
+		{ID: "1", Name: "Alice", Vote: lo.ToPtr("5"), HasVoted: true, IsSpectator: false, IsOwner: false, Role: "voter"},
+		{ID: "2", Name: "Bob", Vote: lo.ToPtr("3"), HasVoted: true, IsSpectator: false, IsOwner: true, Role: "facilitator"},
+		{ID: "3", Name: "Charlie", Vote: lo.ToPtr("?"), HasVoted: true, IsSpectator: true, IsOwner: false, Role: "observer"},
 	}
 
 	if !reflect.DeepEqual(participants, expected) {
 		t.Errorf("Expected %v, got %v", expected, participants)
 	}
 }
+
+func TestNewErrorCommand(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want Error
+	}{
+		{
+			name: "domain error keeps its message",
+			err:  &domainerror.PermissionError{RoomID: "room1", ClientID: "client1", Role: "voter", Action: "reveal"},
+			want: Error{
+				Type:      "error",
+				RequestID: "req-1",
+				Code:      domainerror.CodePermissionDenied,
+				Message:   "client client1 with role voter cannot reveal in room room1",
+			},
+		},
+		{
+			name: "wrapped domain error",
+			err:  fmt.Errorf("load room: %w", domainerror.ErrRoomNotFound),
+			want: Error{Type: "error", RequestID: "req-1", Code: domainerror.CodeRoomNotFound, Message: "load room: room not found"},
+		},
+		{
+			name: "other errors are hidden",
+			err:  errors.New("redis: connection refused"),
+			want: Error{Type: "error", RequestID: "req-1", Code: domainerror.CodeInternal, Message: "internal error"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewErrorCommand("req-1", tt.err); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewErrorCommand() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
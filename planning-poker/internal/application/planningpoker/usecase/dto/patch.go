@@ -0,0 +1,151 @@
+package dto
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/samber/lo"
+)
+
+const (
+	RoomStateType = "room-state"
+	RoomPatchType = "room-patch"
+)
+
+// RoomPatch carries the changes between two versions of the room state.
+// Changes holds every top level field of the room state that changed, keyed
+// by its JSON name, with null for fields that were cleared. Participants that
+// joined or changed are sent whole; a client whose version is not BaseVersion
+// missed an update and must ask for a resync.
+type RoomPatch struct {
+	Type                string                     `json:"type"`
+	Version             int64                      `json:"version"`
+	BaseVersion         int64                      `json:"baseVersion"`
+	Changes             map[string]json.RawMessage `json:"changes,omitempty"`
+	Participants        []Participant              `json:"participants,omitempty"`
+	RemovedParticipants []string                   `json:"removedParticipants,omitempty"`
+}
+
+// fields of the room state that are not compared as a whole
+var patchSkippedFields = []string{"type", "version", "participants"}
+
+// NewRoomPatchCommand reports false when neither the version nor anything
+// visible changed, in which case there is nothing to send.
+func NewRoomPatchCommand(previous, current RoomState) (RoomPatch, bool) {
+	patch := RoomPatch{
+		Type:        RoomPatchType,
+		Version:     current.Version,
+		BaseVersion: previous.Version,
+	}
+
+	if changes := diffFields(previous, current); len(changes) > 0 {
+		patch.Changes = changes
+	}
+
+	previousParticipants := lo.KeyBy(previous.Participants, func(p Participant) string { return p.ID })
+	for _, participant := range current.Participants {
+		if old, ok := previousParticipants[participant.ID]; !ok || !reflect.DeepEqual(old, participant) {
+			patch.Participants = append(patch.Participants, participant)
+		}
+		delete(previousParticipants, participant.ID)
+	}
+	for _, participant := range previous.Participants {
+		if _, removed := previousParticipants[participant.ID]; removed {
+			patch.RemovedParticipants = append(patch.RemovedParticipants, participant.ID)
+		}
+	}
+
+	changed := len(patch.Changes) > 0 || len(patch.Participants) > 0 || len(patch.RemovedParticipants) > 0
+	return patch, changed || patch.Version != patch.BaseVersion
+}
+
+// NewRoomUpdate is what hubs publish when the state of a room changes: a patch
+// against the state they published before, or the whole state when there is
+// none. It reports false when there is nothing to publish.
+func NewRoomUpdate(previous *RoomState, current RoomState) (any, bool) {
+	if previous == nil {
+		return current, true
+	}
+	return NewRoomPatchCommand(*previous, current)
+}
+
+// Apply returns the room state the patch leads to from the state at its base
+// version.
+func (p RoomPatch) Apply(state RoomState) (RoomState, error) {
+	if state.Version != p.BaseVersion {
+		return RoomState{}, fmt.Errorf("patch from version %d cannot be applied to version %d", p.BaseVersion, state.Version)
+	}
+
+	fields := fields(state)
+	for name, value := range p.Changes {
+		fields[name] = value
+	}
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return RoomState{}, fmt.Errorf("failed to apply patch %d: %w", p.Version, err)
+	}
+	var patched RoomState
+	if err := json.Unmarshal(data, &patched); err != nil {
+		return RoomState{}, fmt.Errorf("failed to apply patch %d: %w", p.Version, err)
+	}
+
+	patched.Type = RoomStateType
+	patched.Version = p.Version
+	patched.Participants = p.applyParticipants(state.Participants)
+	return patched, nil
+}
+
+// applyParticipants keeps the participants in place, those who joined come
+// last.
+func (p RoomPatch) applyParticipants(previous []Participant) []Participant {
+	changed := lo.KeyBy(p.Participants, func(participant Participant) string { return participant.ID })
+	removed := lo.Keyify(p.RemovedParticipants)
+
+	participants := make([]Participant, 0, len(previous)+len(p.Participants))
+	for _, participant := range previous {
+		if _, ok := removed[participant.ID]; ok {
+			continue
+		}
+		if updated, ok := changed[participant.ID]; ok {
+			participant = updated
+			delete(changed, participant.ID)
+		}
+		participants = append(participants, participant)
+	}
+	for _, participant := range p.Participants {
+		if _, joined := changed[participant.ID]; joined {
+			participants = append(participants, participant)
+		}
+	}
+	return participants
+}
+
+func diffFields(previous, current RoomState) map[string]json.RawMessage {
+	before, after := fields(previous), fields(current)
+
+	changes := make(map[string]json.RawMessage)
+	for name, value := range after {
+		if !bytes.Equal(before[name], value) {
+			changes[name] = value
+		}
+	}
+	for name := range before {
+		if _, ok := after[name]; !ok {
+			changes[name] = json.RawMessage("null")
+		}
+	}
+	return changes
+}
+
+func fields(state RoomState) map[string]json.RawMessage {
+	// the room state only holds plain values, it always round trips
+	data, _ := json.Marshal(state)
+	var fields map[string]json.RawMessage
+	_ = json.Unmarshal(data, &fields)
+	for _, name := range patchSkippedFields {
+		delete(fields, name)
+	}
+	return fields
+}
@@ -0,0 +1,182 @@
+package dto
+
+import (
+	"context"
+	"encoding/json"
+	"planning-poker/internal/domain/entity"
+	"planning-poker/internal/infra/boundaries/hub/clientcollection"
+	"reflect"
+	"testing"
+)
+
+func newPatchRoom(t *testing.T) *entity.Room {
+	t.Helper()
+	room := entity.NewRoomWithID("room1", clientcollection.New())
+	room.NewClient("owner")
+	room.NewClient("voter")
+	return room
+}
+
+func TestNewRoomPatchCommand_Vote(t *testing.T) {
+	ctx := context.Background()
+	room := newPatchRoom(t)
+	previous := NewRoomStateCommand(room)
+
+	vote := "5"
+	if err := room.Vote(ctx, "voter", &vote); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	patch, ok := NewRoomPatchCommand(previous, NewRoomStateCommand(room))
+	if !ok {
+		t.Fatal("expected a patch")
+	}
+	if patch.Type != "room-patch" || patch.BaseVersion != previous.Version || patch.Version != room.Version {
+		t.Errorf("unexpected patch header %+v", patch)
+	}
+	if len(patch.Changes) != 0 {
+		t.Errorf("expected no field changes, got %v", patch.Changes)
+	}
+	if len(patch.Participants) != 1 || patch.Participants[0].ID != "voter" || !patch.Participants[0].HasVoted {
+		t.Errorf("expected only the voter to change, got %+v", patch.Participants)
+	}
+}
+
+func TestNewRoomPatchCommand_FieldChanges(t *testing.T) {
+	ctx := context.Background()
+	room := newPatchRoom(t)
+	vote := "5"
+	_ = room.Vote(ctx, "owner", &vote)
+	_ = room.Vote(ctx, "voter", &vote)
+	revealed := NewRoomStateCommand(room)
+	if revealed.Result == nil {
+		t.Fatal("expected the votes to be revealed")
+	}
+
+	if err := room.NewVoting(ctx, "owner"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	patch, _ := NewRoomPatchCommand(revealed, NewRoomStateCommand(room))
+	if string(patch.Changes["reveal"]) != "false" {
+		t.Errorf("expected reveal to change to false, got %s", patch.Changes["reveal"])
+	}
+	if string(patch.Changes["result"]) != "null" {
+		t.Errorf("expected result to be cleared, got %s", patch.Changes["result"])
+	}
+	if _, ok := patch.Changes["deck"]; ok {
+		t.Error("expected unchanged deck not to be sent")
+	}
+	if len(patch.Participants) != 2 {
+		t.Errorf("expected both votes to be cleared, got %+v", patch.Participants)
+	}
+}
+
+func TestNewRoomPatchCommand_RemovedParticipant(t *testing.T) {
+	ctx := context.Background()
+	room := newPatchRoom(t)
+	previous := NewRoomStateCommand(room)
+
+	if err := room.RemoveClient(ctx, "voter"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	patch, _ := NewRoomPatchCommand(previous, NewRoomStateCommand(room))
+	if !reflect.DeepEqual(patch.RemovedParticipants, []string{"voter"}) {
+		t.Errorf("expected voter to be removed, got %v", patch.RemovedParticipants)
+	}
+	if len(patch.Participants) != 0 {
+		t.Errorf("expected no participant changes, got %+v", patch.Participants)
+	}
+}
+
+func TestNewRoomPatchCommand_Unchanged(t *testing.T) {
+	state := NewRoomStateCommand(newPatchRoom(t))
+
+	if _, ok := NewRoomPatchCommand(state, state); ok {
+		t.Error("expected no patch for the same state")
+	}
+}
+
+func TestRoomPatch_JSON(t *testing.T) {
+	room := newPatchRoom(t)
+	previous := NewRoomStateCommand(room)
+	_ = room.ToggleBacklogMode(context.Background(), "owner")
+
+	patch, _ := NewRoomPatchCommand(previous, NewRoomStateCommand(room))
+	data, err := json.Marshal(patch)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var decoded map[string]any
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	changes := decoded["changes"].(map[string]any)
+	if changes["backlogMode"] != !previous.BacklogMode {
+		t.Errorf("expected backlogMode change, got %v", changes)
+	}
+	if _, ok := decoded["participants"]; ok {
+		t.Error("expected participants to be omitted")
+	}
+}
+
+func TestRoomPatch_Apply(t *testing.T) {
+	ctx := context.Background()
+	room := newPatchRoom(t)
+	vote := "5"
+	_ = room.Vote(ctx, "owner", &vote)
+	_ = room.Vote(ctx, "voter", &vote)
+	states := []RoomState{NewRoomStateCommand(room)}
+
+	_ = room.NewVoting(ctx, "owner")
+	states = append(states, NewRoomStateCommand(room))
+	_ = room.RemoveClient(ctx, "voter")
+	states = append(states, NewRoomStateCommand(room))
+	_ = room.ToggleBacklogMode(ctx, "owner")
+	states = append(states, NewRoomStateCommand(room))
+
+	for i := 1; i < len(states); i++ {
+		patch, ok := NewRoomPatchCommand(states[i-1], states[i])
+		if !ok {
+			t.Fatalf("expected a patch from state %d", i-1)
+		}
+		applied, err := patch.Apply(states[i-1])
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !reflect.DeepEqual(applied, states[i]) {
+			t.Errorf("expected patch %d to lead to\n%+v\ngot\n%+v", i, states[i], applied)
+		}
+	}
+}
+
+func TestRoomPatch_Apply_WrongBaseVersion(t *testing.T) {
+	room := newPatchRoom(t)
+	previous := NewRoomStateCommand(room)
+	_ = room.ToggleBacklogMode(context.Background(), "owner")
+
+	patch, _ := NewRoomPatchCommand(previous, NewRoomStateCommand(room))
+	previous.Version--
+	if _, err := patch.Apply(previous); err == nil {
+		t.Error("expected an error for a patch from another version")
+	}
+}
+
+func TestNewRoomUpdate(t *testing.T) {
+	room := newPatchRoom(t)
+	previous := NewRoomStateCommand(room)
+
+	if update, _ := NewRoomUpdate(nil, previous); !reflect.DeepEqual(update, previous) {
+		t.Errorf("expected the whole state without a previous one, got %T", update)
+	}
+	if _, ok := NewRoomUpdate(&previous, previous); ok {
+		t.Error("expected nothing to publish for the same state")
+	}
+
+	_ = room.ToggleBacklogMode(context.Background(), "owner")
+	if update, _ := NewRoomUpdate(&previous, NewRoomStateCommand(room)); reflect.TypeOf(update) != reflect.TypeOf(RoomPatch{}) {
+		t.Errorf("expected a patch, got %T", update)
+	}
+}
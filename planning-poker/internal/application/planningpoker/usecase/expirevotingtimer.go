@@ -0,0 +1,79 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"planning-poker/internal/application/lock"
+	"planning-poker/internal/application/planningpoker/usecase/dto"
+	"planning-poker/internal/application/timer"
+	"planning-poker/internal/application/webhook"
+	"planning-poker/internal/domain"
+)
+
+type (
+	ExpireVotingTimerCommand struct {
+		RoomID string
+	}
+	// ExpireVotingTimerUseCase reveals a room whose voting deadline has passed.
+	// Every instance may try to expire the same room; the room lock makes the
+	// first one reveal and turns the others into no-ops.
+	ExpireVotingTimerUseCase struct {
+		hub         domain.Hub
+		lockManager lock.LockManager
+		deadlines   timer.DeadlineStore
+		clock       timer.Clock
+		webhooks    webhook.Publisher
+	}
+)
+
+var _ UseCase[ExpireVotingTimerCommand] = (*ExpireVotingTimerUseCase)(nil)
+
+func NewExpireVotingTimerUseCase(
+	hub domain.Hub,
+	lockManager lock.LockManager,
+	deadlines timer.DeadlineStore,
+	clock timer.Clock,
+	webhooks webhook.Publisher,
+) ExpireVotingTimerUseCase {
+	return ExpireVotingTimerUseCase{
+		hub:         hub,
+		lockManager: lockManager,
+		deadlines:   deadlines,
+		clock:       clock,
+		webhooks:    webhooks,
+	}
+}
+
+func (uc ExpireVotingTimerUseCase) Execute(ctx context.Context, cmd ExpireVotingTimerCommand) error {
+	return uc.lockManager.ExecuteWithLock(ctx, cmd.RoomID, func(ctx context.Context) error {
+		room, err := uc.hub.LoadRoom(ctx, cmd.RoomID)
+		if errors.Is(err, domain.ErrRoomNotFound) {
+			return uc.deadlines.Cancel(ctx, cmd.RoomID)
+		}
+		if err != nil {
+			return err
+		}
+
+		now := uc.clock.Now()
+		if room.IsVotingTimerRunning(now) {
+			// the timer was restarted after this deadline was picked up
+			return nil
+		}
+
+		revealed := room.Reveal
+		if room.ExpireVotingTimer(now) {
+			if err := uc.hub.SaveRoom(ctx, room); err != nil {
+				return err
+			}
+
+			if err := uc.hub.BroadcastToRoom(ctx, room.ID, dto.NewRoomStateCommand(room)); err != nil {
+				return err
+			}
+			if !revealed && room.Reveal {
+				uc.webhooks.Publish(ctx, webhook.NewStoryRevealedEvent(room))
+			}
+		}
+
+		return uc.deadlines.Cancel(ctx, room.ID)
+	})
+}
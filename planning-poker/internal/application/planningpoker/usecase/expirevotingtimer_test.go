@@ -0,0 +1,107 @@
+package usecase
+
+import (
+	"context"
+	"planning-poker/internal/application/lock"
+	"planning-poker/internal/application/timer"
+	"planning-poker/internal/application/webhook"
+	"planning-poker/internal/domain"
+	"planning-poker/internal/domain/entity"
+	"planning-poker/internal/infra/boundaries/hub/clientcollection"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+)
+
+func TestExpireVotingTimerUseCase_Execute(t *testing.T) {
+	roomID := "room123"
+	deadline := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		deadline   *time.Time
+		now        time.Time
+		roomErr    error
+		wantReveal bool
+		wantCancel bool
+		wantNow    bool
+	}{
+		{
+			name:       "reveals room when deadline passed",
+			deadline:   &deadline,
+			now:        deadline.Add(time.Second),
+			wantReveal: true,
+			wantCancel: true,
+			wantNow:    true,
+		},
+		{
+			name:     "keeps deadline when timer was restarted",
+			deadline: &deadline,
+			now:      deadline.Add(-time.Second),
+			wantNow:  true,
+		},
+		{
+			name:       "drops deadline when room was already revealed",
+			now:        deadline.Add(time.Second),
+			wantCancel: true,
+			wantNow:    true,
+		},
+		{
+			name:       "drops deadline when room no longer exists",
+			roomErr:    domain.ErrRoomNotFound,
+			wantCancel: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.Background()
+			mockHub := domain.NewMockHub(ctrl)
+			mockLockManager := lock.NewMockLockManager(ctrl)
+			mockDeadlines := timer.NewMockDeadlineStore(ctrl)
+			mockClock := timer.NewMockClock(ctrl)
+
+			room := &entity.Room{
+				ID:             roomID,
+				Clients:        clientcollection.New(),
+				VotingDeadline: tt.deadline,
+			}
+			room.NewClient("client123")
+
+			mockLockManager.EXPECT().
+				ExecuteWithLock(gomock.Any(), roomID, gomock.Any()).
+				DoAndReturn(func(ctx context.Context, key string, fn func(context.Context) error) error {
+					return fn(ctx)
+				})
+
+			if tt.roomErr != nil {
+				mockHub.EXPECT().LoadRoom(ctx, roomID).Return(nil, tt.roomErr)
+			} else {
+				mockHub.EXPECT().LoadRoom(ctx, roomID).Return(room, nil)
+			}
+			if tt.wantNow {
+				mockClock.EXPECT().Now().Return(tt.now)
+			}
+			if tt.wantReveal {
+				mockHub.EXPECT().SaveRoom(ctx, room).Return(nil)
+				mockHub.EXPECT().BroadcastToRoom(ctx, roomID, gomock.Any()).Return(nil)
+			}
+			if tt.wantCancel {
+				mockDeadlines.EXPECT().Cancel(ctx, roomID).Return(nil)
+			}
+
+			uc := NewExpireVotingTimerUseCase(mockHub, mockLockManager, mockDeadlines, mockClock, webhook.Discard)
+
+			if err := uc.Execute(ctx, ExpireVotingTimerCommand{RoomID: roomID}); err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if room.Reveal != tt.wantReveal {
+				t.Errorf("expected reveal %v, got %v", tt.wantReveal, room.Reveal)
+			}
+		})
+	}
+}
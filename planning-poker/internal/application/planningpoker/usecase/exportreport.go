@@ -0,0 +1,65 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"planning-poker/internal/application/planningpoker/report"
+	"planning-poker/internal/application/planningpoker/usecase/dto"
+	"planning-poker/internal/domain"
+	"planning-poker/internal/domain/entity"
+)
+
+type (
+	ExportReportCommand struct {
+		RoomID   string
+		SenderID string
+		Format   string
+		// ReplyTo receives the answer instead of the bus of the sender
+		ReplyTo domain.Bus
+	}
+	ExportReportUseCase struct {
+		hub domain.Hub
+	}
+)
+
+var _ UseCase[ExportReportCommand] = (*ExportReportUseCase)(nil)
+
+func NewExportReportUseCase(hub domain.Hub) ExportReportUseCase {
+	return ExportReportUseCase{
+		hub: hub,
+	}
+}
+
+// Execute sends the session report only to the owner that asked for it.
+func (uc ExportReportUseCase) Execute(ctx context.Context, cmd ExportReportCommand) error {
+	format, err := report.ParseFormat(cmd.Format)
+	if err != nil {
+		return err
+	}
+
+	room, err := uc.hub.LoadRoom(ctx, cmd.RoomID)
+	if err != nil {
+		return err
+	}
+
+	if _, err := room.CheckPermission(cmd.SenderID, entity.ActionExportReport); err != nil {
+		return err
+	}
+
+	content, err := report.Render(report.New(room), format)
+	if err != nil {
+		return fmt.Errorf("render report: %w", err)
+	}
+
+	bus, err := replyBus(uc.hub, cmd.ReplyTo, cmd.SenderID)
+	if err != nil {
+		return err
+	}
+
+	return bus.Send(ctx, dto.NewSessionReportCommand(
+		string(format),
+		format.ContentType(),
+		report.Filename(room.ID, format),
+		string(content),
+	))
+}
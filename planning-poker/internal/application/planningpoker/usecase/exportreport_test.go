@@ -0,0 +1,89 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"planning-poker/internal/application/planningpoker/report"
+	"planning-poker/internal/application/planningpoker/usecase/dto"
+	"planning-poker/internal/domain"
+	"planning-poker/internal/domain/entity"
+	"planning-poker/internal/infra/boundaries/hub/clientcollection"
+	"strings"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+)
+
+func TestExportReportUseCase_Execute_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockHub := domain.NewMockHub(ctrl)
+	mockBus := domain.NewMockBus(ctrl)
+
+	roomID := "room123"
+	room := entity.NewRoomWithID(roomID, clientcollection.New())
+	room.NewClient("owner")
+	room.Stories = []entity.Story{{Name: "Story 1"}}
+
+	mockHub.EXPECT().LoadRoom(ctx, roomID).Return(room, nil)
+	mockHub.EXPECT().GetBus("owner").Return(mockBus, true)
+	mockBus.EXPECT().Send(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, msg any) error {
+		sessionReport, ok := msg.(dto.SessionReport)
+		if !ok {
+			t.Fatalf("expected dto.SessionReport, got %T", msg)
+		}
+		if sessionReport.Type != "session-report" || sessionReport.ContentType != "text/csv" {
+			t.Errorf("unexpected session report %+v", sessionReport)
+		}
+		if sessionReport.Filename != "planning-poker-room123.csv" {
+			t.Errorf("unexpected filename %s", sessionReport.Filename)
+		}
+		if !strings.HasPrefix(sessionReport.Content, "story,voted") {
+			t.Errorf("unexpected content %s", sessionReport.Content)
+		}
+		return nil
+	})
+
+	uc := NewExportReportUseCase(mockHub)
+	cmd := ExportReportCommand{RoomID: roomID, SenderID: "owner", Format: "csv"}
+
+	if err := uc.Execute(ctx, cmd); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}
+
+func TestExportReportUseCase_Execute_NonOwner(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockHub := domain.NewMockHub(ctrl)
+
+	roomID := "room123"
+	room := entity.NewRoomWithID(roomID, clientcollection.New())
+	room.NewClient("owner")
+	room.NewClient("client123")
+
+	mockHub.EXPECT().LoadRoom(ctx, roomID).Return(room, nil)
+
+	uc := NewExportReportUseCase(mockHub)
+	cmd := ExportReportCommand{RoomID: roomID, SenderID: "client123"}
+
+	if err := uc.Execute(ctx, cmd); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+}
+
+func TestExportReportUseCase_Execute_UnsupportedFormat(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uc := NewExportReportUseCase(domain.NewMockHub(ctrl))
+	cmd := ExportReportCommand{RoomID: "room123", SenderID: "owner", Format: "xml"}
+
+	if err := uc.Execute(context.Background(), cmd); !errors.Is(err, report.ErrUnsupportedFormat) {
+		t.Errorf("expected ErrUnsupportedFormat, got %v", err)
+	}
+}
@@ -1,24 +1,41 @@
 package usecase
 
+import "planning-poker/internal/application/webhook"
+
 type (
 	UseCasesFacade struct {
-		UpdateName        UseCase[UpdateNameCommand]
-		Vote              UseCase[VoteCommand]
-		Reveal            UseCase[RevealCommand]
-		Reset             UseCase[ResetCommand]
-		ToggleSpectator   UseCase[ToggleSpectatorCommand]
-		ToggleOwner       UseCase[ToggleOwnerCommand]
-		UpdateStory       UseCase[UpdateStoryCommand]
-		NewVoting         UseCase[NewVotingCommand]
-		VoteAgain         UseCase[VoteAgainCommand]
-		LeaveRoom         UseCase[LeaveRoomCommand]
-		JoinRoom          UseCaseR[JoinRoomCommand, *JoinRoomOutput]
-		CreateClient      UseCaseO[CreateClientOutput]
-		CreateRoom        UseCaseO[CreateRoomOutput]
-		ToggleBacklogMode UseCase[ToggleBacklogModeCommand]
-		AddStory          UseCase[AddStoryCommand]
-		RemoveStory       UseCase[RemoveStoryCommand]
-		AdvanceStory      UseCase[AdvanceStoryCommand]
-		PrevStory         UseCase[PrevStoryCommand]
+		UpdateName          UseCase[UpdateNameCommand]
+		Vote                UseCase[VoteCommand]
+		Reveal              UseCase[RevealCommand]
+		Reset               UseCase[ResetCommand]
+		ToggleSpectator     UseCase[ToggleSpectatorCommand]
+		ToggleOwner         UseCase[ToggleOwnerCommand]
+		UpdateStory         UseCase[UpdateStoryCommand]
+		NewVoting           UseCase[NewVotingCommand]
+		VoteAgain           UseCase[VoteAgainCommand]
+		LeaveRoom           UseCase[LeaveRoomCommand]
+		JoinRoom            UseCaseR[JoinRoomCommand, *JoinRoomOutput]
+		CreateClient        UseCaseO[CreateClientOutput]
+		CreateRoom          UseCaseR[CreateRoomCommand, CreateRoomOutput]
+		ToggleBacklogMode   UseCase[ToggleBacklogModeCommand]
+		AddStory            UseCase[AddStoryCommand]
+		RemoveStory         UseCase[RemoveStoryCommand]
+		AdvanceStory        UseCase[AdvanceStoryCommand]
+		PrevStory           UseCase[PrevStoryCommand]
+		ChangeDeck          UseCase[ChangeDeckCommand]
+		ChangeConsensusRule UseCase[ChangeConsensusRuleCommand]
+		StartVotingTimer    UseCase[StartVotingTimerCommand]
+		CancelVotingTimer   UseCase[CancelVotingTimerCommand]
+		ExportReport        UseCase[ExportReportCommand]
+		SetPasscode         UseCase[SetPasscodeCommand]
+		CreateInvite        UseCase[CreateInviteCommand]
+		RevokeInvites       UseCase[RevokeInvitesCommand]
+		ChangePermission    UseCase[ChangePermissionCommand]
+		Resync              UseCase[ResyncCommand]
+		AddBot              UseCaseR[AddBotCommand, AddBotOutput]
+		RemoveBot           UseCase[RemoveBotCommand]
+		CreateWebhook       UseCaseR[CreateWebhookCommand, webhook.Subscription]
+		ListWebhooks        UseCaseR[ListWebhooksCommand, []webhook.Subscription]
+		DeleteWebhook       UseCase[DeleteWebhookCommand]
 	}
 )
@@ -7,6 +7,7 @@ import (
 	"planning-poker/internal/application/lock"
 	"planning-poker/internal/application/planningpoker/metric"
 	"planning-poker/internal/application/planningpoker/usecase/dto"
+	"planning-poker/internal/application/timer"
 	"planning-poker/internal/domain"
 	"planning-poker/internal/domain/entity"
 	"time"
@@ -18,25 +19,39 @@ const rollbackJoinCleanupTimeout = 5 * time.Second
 
 type (
 	JoinRoomCommand struct {
-		RoomID   string
-		SenderID string
-		Bus      domain.Bus
+		RoomID      string
+		SenderID    string
+		Bus         domain.Bus
+		Credentials entity.Credentials
+		// Identity is the verified participant, the zero value for anonymous
+		// ones. Only the same identity may reconnect to a seat.
+		Identity entity.Identity
 	}
 	JoinRoomOutput struct {
 		Client *entity.Client
 		Room   *entity.Room
 	}
 	JoinRoomUseCase struct {
-		hub         domain.Hub
-		lockManager lock.LockManager
-		logger      log.Logger
-		metric      metric.PlanningPokerMetric
+		hub             domain.Hub
+		lockManager     lock.LockManager
+		logger          log.Logger
+		metric          metric.PlanningPokerMetric
+		clock           timer.Clock
+		autoCreateRooms bool
 	}
 )
 
 var _ UseCaseR[JoinRoomCommand, *JoinRoomOutput] = (*JoinRoomUseCase)(nil)
 
-func NewJoinRoomUseCase(hub domain.Hub, lockManager lock.LockManager, metric metric.PlanningPokerMetric) JoinRoomUseCase {
+// NewJoinRoomUseCase creates the use case that connects clients to rooms.
+// When autoCreateRooms is false, joining an unknown room fails instead of
+// creating it.
+func NewJoinRoomUseCase(
+	hub domain.Hub,
+	lockManager lock.LockManager,
+	metric metric.PlanningPokerMetric,
+	autoCreateRooms bool,
+) JoinRoomUseCase {
 	if hub == nil {
 		panic("hub cannot be nil")
 	}
@@ -45,10 +60,12 @@ func NewJoinRoomUseCase(hub domain.Hub, lockManager lock.LockManager, metric met
 	}
 
 	return JoinRoomUseCase{
-		hub:         hub,
-		lockManager: lockManager,
-		logger:      log.NewLogger("usecase.joinroom"),
-		metric:      metric,
+		hub:             hub,
+		lockManager:     lockManager,
+		logger:          log.NewLogger("usecase.joinroom"),
+		metric:          metric,
+		clock:           timer.SystemClock{},
+		autoCreateRooms: autoCreateRooms,
 	}
 }
 
@@ -60,6 +77,9 @@ func (uc JoinRoomUseCase) Execute(ctx context.Context, cmd JoinRoomCommand) (*Jo
 			if !errors.Is(err, domain.ErrRoomNotFound) {
 				return nil, fmt.Errorf("failed to load room %s: %w", cmd.RoomID, err)
 			}
+			if !uc.autoCreateRooms {
+				return nil, fmt.Errorf("room %s does not exist and auto-creation is disabled: %w", cmd.RoomID, err)
+			}
 
 			room, err = uc.hub.NewRoomWithID(ctx, cmd.RoomID)
 			if err != nil {
@@ -70,7 +90,17 @@ func (uc JoinRoomUseCase) Execute(ctx context.Context, cmd JoinRoomCommand) (*Jo
 			uc.logger.Info(ctx, "Room auto-created with ID: %s during join by: %s", room.ID, cmd.SenderID)
 		}
 
-		client, isReconnect, rollbackFunc := uc.joinClient(ctx, room, cmd)
+		// clients already in the room passed this check when they first joined
+		if _, isMember := room.FindClient(cmd.SenderID); !isMember {
+			if err := room.Authorize(uc.clock.Now(), cmd.Credentials); err != nil {
+				return nil, err
+			}
+		}
+
+		client, isReconnect, rollbackFunc, err := uc.joinClient(ctx, room, cmd)
+		if err != nil {
+			return nil, err
+		}
 
 		output := &JoinRoomOutput{Client: client, Room: room}
 		rollbackJoin := func(cause error) error {
@@ -113,15 +143,24 @@ func (uc JoinRoomUseCase) Execute(ctx context.Context, cmd JoinRoomCommand) (*Jo
 	return output.(*JoinRoomOutput), nil
 }
 
-func (uc JoinRoomUseCase) joinClient(ctx context.Context, room *entity.Room, cmd JoinRoomCommand) (client *entity.Client, isReconnect bool, rollbackFunc func(context.Context) error) {
+func (uc JoinRoomUseCase) joinClient(ctx context.Context, room *entity.Room, cmd JoinRoomCommand) (client *entity.Client, isReconnect bool, rollbackFunc func(context.Context) error, err error) {
 	if existingClient, ok := room.FindClient(cmd.SenderID); ok {
+		if !existingClient.BelongsTo(cmd.Identity) {
+			return nil, false, nil, fmt.Errorf("client %s cannot reconnect to room %s: %w", cmd.SenderID, room.ID, domain.ErrIdentityMismatch)
+		}
 		isReconnect = true
 		client = existingClient
 		rollbackFunc = uc.reconnectClient(ctx, cmd)
-	} else {
-		client = room.NewClient(cmd.SenderID)
-		rollbackFunc = uc.createNewClient(ctx, cmd, client)
+		return
+	}
+
+	client = room.NewClientWithIdentity(cmd.SenderID, cmd.Identity)
+	// saved before anything else happens, so a conflicting join can simply
+	// be retried
+	if err = uc.hub.SaveRoom(ctx, room); err != nil {
+		return nil, false, nil, fmt.Errorf("failed to save room %s: %w", room.ID, err)
 	}
+	rollbackFunc = uc.createNewClient(ctx, cmd, client)
 
 	return
 }
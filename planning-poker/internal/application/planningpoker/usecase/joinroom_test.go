@@ -39,7 +39,7 @@ func TestNewJoinRoomUseCase(t *testing.T) {
 	mockLockManager := lock.NewMockLockManager(ctrl)
 	mockMetric := metric.NewPlanningPokerMetric()
 
-	uc := NewJoinRoomUseCase(mockHub, mockLockManager, mockMetric)
+	uc := NewJoinRoomUseCase(mockHub, mockLockManager, mockMetric, true)
 
 	if uc.hub != mockHub {
 		t.Error("hub not set correctly")
@@ -73,12 +73,13 @@ func TestJoinRoomUseCase_Execute_Success(t *testing.T) {
 
 	mockHub.EXPECT().LoadRoom(ctx, roomID).Return(room, nil)
 	mockHub.EXPECT().AddClient(gomock.Any())
+	mockHub.EXPECT().SaveRoom(gomock.Any(), gomock.Any()).Return(nil)
 	mockHub.EXPECT().AddBus(gomock.Any(), gomock.Any(), gomock.Any())
 
 	mockBus.EXPECT().Send(gomock.Any(), gomock.Any()).Return(nil)
 	mockHub.EXPECT().BroadcastToRoom(ctx, roomID, gomock.Any()).Return(nil)
 
-	uc := NewJoinRoomUseCase(mockHub, mockLockManager, testMetric)
+	uc := NewJoinRoomUseCase(mockHub, mockLockManager, testMetric, true)
 	cmd := JoinRoomCommand{
 		RoomID:   roomID,
 		SenderID: "sender123",
@@ -107,6 +108,48 @@ func TestJoinRoomUseCase_Execute_Success(t *testing.T) {
 	)
 }
 
+func TestJoinRoomUseCase_Execute_VersionConflict_SkipsJoinInitialization(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockHub := domain.NewMockHub(ctrl)
+	mockLockManager := lock.NewMockLockManager(ctrl)
+	testMetric, metricMeter := newTestPlanningPokerMetric(ctrl)
+	mockBus := domain.NewMockBus(ctrl)
+
+	roomID := "room123"
+	room := &entity.Room{
+		ID:      roomID,
+		Clients: clientcollection.New(),
+	}
+
+	mockLockManager.EXPECT().
+		WithLock(gomock.Any(), roomID, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, key string, fn func(context.Context) (any, error)) (any, error) {
+			return fn(ctx)
+		})
+
+	mockHub.EXPECT().LoadRoom(ctx, roomID).Return(room, nil)
+	mockHub.EXPECT().SaveRoom(gomock.Any(), room).Return(domain.ErrVersionConflict)
+
+	uc := NewJoinRoomUseCase(mockHub, mockLockManager, testMetric, true)
+	cmd := JoinRoomCommand{
+		RoomID:   roomID,
+		SenderID: "sender123",
+		Bus:      mockBus,
+	}
+
+	_, err := uc.Execute(ctx, cmd)
+
+	if !errors.Is(err, domain.ErrVersionConflict) {
+		t.Fatalf("expected ErrVersionConflict, got %v", err)
+	}
+	if calls := metricMeter.getCalls(); len(calls) != 0 {
+		t.Errorf("expected no metric calls, got %v", calls)
+	}
+}
+
 func TestJoinRoomUseCase_Execute_AutoCreatesMissingRoom(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -132,11 +175,12 @@ func TestJoinRoomUseCase_Execute_AutoCreatesMissingRoom(t *testing.T) {
 	mockHub.EXPECT().LoadRoom(ctx, roomID).Return(nil, domain.ErrRoomNotFound)
 	mockHub.EXPECT().NewRoomWithID(ctx, roomID).Return(room, nil)
 	mockHub.EXPECT().AddClient(gomock.Any())
+	mockHub.EXPECT().SaveRoom(gomock.Any(), gomock.Any()).Return(nil)
 	mockHub.EXPECT().AddBus(gomock.Any(), gomock.Any(), gomock.Any())
 	mockBus.EXPECT().Send(gomock.Any(), gomock.Any()).Return(nil)
 	mockHub.EXPECT().BroadcastToRoom(ctx, roomID, gomock.Any()).Return(nil)
 
-	uc := NewJoinRoomUseCase(mockHub, mockLockManager, testMetric)
+	uc := NewJoinRoomUseCase(mockHub, mockLockManager, testMetric, true)
 	cmd := JoinRoomCommand{
 		RoomID:   roomID,
 		SenderID: "sender123",
@@ -190,7 +234,7 @@ func TestJoinRoomUseCase_Execute_LoadRoomError(t *testing.T) {
 
 	mockHub.EXPECT().LoadRoom(ctx, roomID).Return(nil, expectedError)
 
-	uc := NewJoinRoomUseCase(mockHub, mockLockManager, testMetric)
+	uc := NewJoinRoomUseCase(mockHub, mockLockManager, testMetric, true)
 	cmd := JoinRoomCommand{
 		RoomID:   roomID,
 		SenderID: "sender123",
@@ -240,7 +284,7 @@ func TestJoinRoomUseCase_Execute_AutoCreateRoomError(t *testing.T) {
 	mockHub.EXPECT().LoadRoom(ctx, roomID).Return(nil, domain.ErrRoomNotFound)
 	mockHub.EXPECT().NewRoomWithID(ctx, roomID).Return(nil, expectedError)
 
-	uc := NewJoinRoomUseCase(mockHub, mockLockManager, testMetric)
+	uc := NewJoinRoomUseCase(mockHub, mockLockManager, testMetric, true)
 	cmd := JoinRoomCommand{
 		RoomID:   roomID,
 		SenderID: "sender123",
@@ -294,11 +338,12 @@ func TestJoinRoomUseCase_Execute_SendError(t *testing.T) {
 
 	mockHub.EXPECT().LoadRoom(ctx, roomID).Return(room, nil)
 	mockHub.EXPECT().AddClient(gomock.Any())
+	mockHub.EXPECT().SaveRoom(gomock.Any(), gomock.Any()).Return(nil)
 	mockHub.EXPECT().AddBus(gomock.Any(), gomock.Any(), gomock.Any())
 	mockBus.EXPECT().Send(gomock.Any(), gomock.Any()).Return(expectedError)
 	mockHub.EXPECT().RemoveClient(gomock.Any(), gomock.Any(), roomID).Return(nil)
 
-	uc := NewJoinRoomUseCase(mockHub, mockLockManager, testMetric)
+	uc := NewJoinRoomUseCase(mockHub, mockLockManager, testMetric, true)
 	cmd := JoinRoomCommand{
 		RoomID:   roomID,
 		SenderID: "sender123",
@@ -346,11 +391,12 @@ func TestJoinRoomUseCase_Execute_SendErrorOnAutoCreatedRoom_RollsBackJoinInitial
 	mockHub.EXPECT().LoadRoom(ctx, roomID).Return(nil, domain.ErrRoomNotFound)
 	mockHub.EXPECT().NewRoomWithID(ctx, roomID).Return(room, nil)
 	mockHub.EXPECT().AddClient(gomock.Any())
+	mockHub.EXPECT().SaveRoom(gomock.Any(), gomock.Any()).Return(nil)
 	mockHub.EXPECT().AddBus(gomock.Any(), gomock.Any(), gomock.Any())
 	mockBus.EXPECT().Send(gomock.Any(), gomock.Any()).Return(expectedError)
 	mockHub.EXPECT().RemoveClient(gomock.Any(), gomock.Any(), roomID).Return(nil)
 
-	uc := NewJoinRoomUseCase(mockHub, mockLockManager, testMetric)
+	uc := NewJoinRoomUseCase(mockHub, mockLockManager, testMetric, true)
 	cmd := JoinRoomCommand{
 		RoomID:   roomID,
 		SenderID: "sender123",
@@ -402,11 +448,12 @@ func TestJoinRoomUseCase_Execute_SendErrorOnAutoCreatedRoom_DoesNotReloadRoomOrE
 	mockHub.EXPECT().LoadRoom(ctx, roomID).Return(nil, domain.ErrRoomNotFound)
 	mockHub.EXPECT().NewRoomWithID(ctx, roomID).Return(room, nil)
 	mockHub.EXPECT().AddClient(gomock.Any())
+	mockHub.EXPECT().SaveRoom(gomock.Any(), gomock.Any()).Return(nil)
 	mockHub.EXPECT().AddBus(gomock.Any(), gomock.Any(), gomock.Any())
 	mockBus.EXPECT().Send(gomock.Any(), gomock.Any()).Return(expectedError)
 	mockHub.EXPECT().RemoveClient(gomock.Any(), gomock.Any(), roomID).Return(nil)
 
-	uc := NewJoinRoomUseCase(mockHub, mockLockManager, testMetric)
+	uc := NewJoinRoomUseCase(mockHub, mockLockManager, testMetric, true)
 	cmd := JoinRoomCommand{
 		RoomID:   roomID,
 		SenderID: "sender123",
@@ -461,11 +508,12 @@ func TestJoinRoomUseCase_Execute_SendErrorWhenRollbackCleanupFails_DoesNotDecrem
 	mockHub.EXPECT().LoadRoom(ctx, roomID).Return(nil, domain.ErrRoomNotFound)
 	mockHub.EXPECT().NewRoomWithID(ctx, roomID).Return(room, nil)
 	mockHub.EXPECT().AddClient(gomock.Any())
+	mockHub.EXPECT().SaveRoom(gomock.Any(), gomock.Any()).Return(nil)
 	mockHub.EXPECT().AddBus(gomock.Any(), gomock.Any(), gomock.Any())
 	mockBus.EXPECT().Send(gomock.Any(), gomock.Any()).Return(sendErr)
 	mockHub.EXPECT().RemoveClient(gomock.Any(), gomock.Any(), roomID).Return(removeErr)
 
-	uc := NewJoinRoomUseCase(mockHub, mockLockManager, testMetric)
+	uc := NewJoinRoomUseCase(mockHub, mockLockManager, testMetric, true)
 	cmd := JoinRoomCommand{
 		RoomID:   roomID,
 		SenderID: "sender123",
@@ -518,12 +566,13 @@ func TestJoinRoomUseCase_Execute_BroadcastErrorAfterSend_RollsBackWithoutEmittin
 
 	mockHub.EXPECT().LoadRoom(ctx, roomID).Return(room, nil)
 	mockHub.EXPECT().AddClient(gomock.Any())
+	mockHub.EXPECT().SaveRoom(gomock.Any(), gomock.Any()).Return(nil)
 	mockHub.EXPECT().AddBus(gomock.Any(), gomock.Any(), gomock.Any())
 	mockBus.EXPECT().Send(gomock.Any(), gomock.Any()).Return(nil)
 	mockHub.EXPECT().BroadcastToRoom(ctx, roomID, gomock.Any()).Return(broadcastErr)
 	mockHub.EXPECT().RemoveClient(gomock.Any(), gomock.Any(), roomID).Return(nil)
 
-	uc := NewJoinRoomUseCase(mockHub, mockLockManager, testMetric)
+	uc := NewJoinRoomUseCase(mockHub, mockLockManager, testMetric, true)
 	cmd := JoinRoomCommand{
 		RoomID:   roomID,
 		SenderID: "sender123",
@@ -583,12 +632,13 @@ func TestJoinRoomUseCase_Execute_BroadcastErrorAfterSendOnAutoCreatedRoom_DoesNo
 	mockHub.EXPECT().LoadRoom(ctx, roomID).Return(nil, domain.ErrRoomNotFound)
 	mockHub.EXPECT().NewRoomWithID(ctx, roomID).Return(room, nil)
 	mockHub.EXPECT().AddClient(gomock.Any())
+	mockHub.EXPECT().SaveRoom(gomock.Any(), gomock.Any()).Return(nil)
 	mockHub.EXPECT().AddBus(gomock.Any(), gomock.Any(), gomock.Any())
 	mockBus.EXPECT().Send(gomock.Any(), gomock.Any()).Return(nil)
 	mockHub.EXPECT().BroadcastToRoom(ctx, roomID, gomock.Any()).Return(broadcastErr)
 	mockHub.EXPECT().RemoveClient(gomock.Any(), gomock.Any(), roomID).Return(nil)
 
-	uc := NewJoinRoomUseCase(mockHub, mockLockManager, testMetric)
+	uc := NewJoinRoomUseCase(mockHub, mockLockManager, testMetric, true)
 	cmd := JoinRoomCommand{
 		RoomID:   roomID,
 		SenderID: "sender123",
@@ -646,6 +696,7 @@ func TestJoinRoomUseCase_Execute_SendFailsFromCanceledContext_UsesActiveRollback
 
 	mockHub.EXPECT().LoadRoom(ctx, roomID).Return(room, nil)
 	mockHub.EXPECT().AddClient(gomock.Any())
+	mockHub.EXPECT().SaveRoom(gomock.Any(), gomock.Any()).Return(nil)
 	mockHub.EXPECT().AddBus(gomock.Any(), gomock.Any(), gomock.Any())
 	mockBus.EXPECT().Send(gomock.Any(), gomock.Any()).DoAndReturn(func(sendCtx context.Context, _ any) error {
 		cancel()
@@ -656,7 +707,7 @@ func TestJoinRoomUseCase_Execute_SendFailsFromCanceledContext_UsesActiveRollback
 		return nil
 	})
 
-	uc := NewJoinRoomUseCase(mockHub, mockLockManager, testMetric)
+	uc := NewJoinRoomUseCase(mockHub, mockLockManager, testMetric, true)
 	cmd := JoinRoomCommand{
 		RoomID:   roomID,
 		SenderID: "sender123",
@@ -705,6 +756,7 @@ func TestJoinRoomUseCase_Execute_BroadcastFailsFromCanceledContext_UsesActiveRol
 
 	mockHub.EXPECT().LoadRoom(ctx, roomID).Return(room, nil)
 	mockHub.EXPECT().AddClient(gomock.Any())
+	mockHub.EXPECT().SaveRoom(gomock.Any(), gomock.Any()).Return(nil)
 	mockHub.EXPECT().AddBus(gomock.Any(), gomock.Any(), gomock.Any())
 	mockBus.EXPECT().Send(gomock.Any(), gomock.Any()).DoAndReturn(func(context.Context, any) error {
 		cancel()
@@ -721,7 +773,7 @@ func TestJoinRoomUseCase_Execute_BroadcastFailsFromCanceledContext_UsesActiveRol
 		return nil
 	})
 
-	uc := NewJoinRoomUseCase(mockHub, mockLockManager, testMetric)
+	uc := NewJoinRoomUseCase(mockHub, mockLockManager, testMetric, true)
 	cmd := JoinRoomCommand{
 		RoomID:   roomID,
 		SenderID: "sender123",
@@ -780,7 +832,7 @@ func TestJoinRoomUseCase_Execute_ReconnectClientExists(t *testing.T) {
 	mockNewBus.EXPECT().Send(gomock.Any(), gomock.Any()).Return(nil)
 	mockHub.EXPECT().BroadcastToRoom(ctx, roomID, gomock.Any()).Return(nil)
 
-	uc := NewJoinRoomUseCase(mockHub, mockLockManager, testMetric)
+	uc := NewJoinRoomUseCase(mockHub, mockLockManager, testMetric, true)
 	cmd := JoinRoomCommand{
 		RoomID:   roomID,
 		SenderID: clientID,
@@ -842,7 +894,7 @@ func TestJoinRoomUseCase_Execute_ReconnectNoOldBus(t *testing.T) {
 	mockNewBus.EXPECT().Send(gomock.Any(), gomock.Any()).Return(nil)
 	mockHub.EXPECT().BroadcastToRoom(ctx, roomID, gomock.Any()).Return(nil)
 
-	uc := NewJoinRoomUseCase(mockHub, mockLockManager, testMetric)
+	uc := NewJoinRoomUseCase(mockHub, mockLockManager, testMetric, true)
 	cmd := JoinRoomCommand{
 		RoomID:   roomID,
 		SenderID: clientID,
@@ -902,7 +954,7 @@ func TestJoinRoomUseCase_Execute_ReconnectSendErrorRollback(t *testing.T) {
 	mockNewBus.EXPECT().Send(gomock.Any(), gomock.Any()).Return(sendErr)
 	mockHub.EXPECT().RemoveBus(gomock.Any(), clientID)
 
-	uc := NewJoinRoomUseCase(mockHub, mockLockManager, testMetric)
+	uc := NewJoinRoomUseCase(mockHub, mockLockManager, testMetric, true)
 	cmd := JoinRoomCommand{
 		RoomID:   roomID,
 		SenderID: clientID,
@@ -960,7 +1012,7 @@ func TestJoinRoomUseCase_Execute_ReconnectBroadcastErrorRollback(t *testing.T) {
 	mockHub.EXPECT().BroadcastToRoom(ctx, roomID, gomock.Any()).Return(broadcastErr)
 	mockHub.EXPECT().RemoveBus(gomock.Any(), clientID)
 
-	uc := NewJoinRoomUseCase(mockHub, mockLockManager, testMetric)
+	uc := NewJoinRoomUseCase(mockHub, mockLockManager, testMetric, true)
 	cmd := JoinRoomCommand{
 		RoomID:   roomID,
 		SenderID: clientID,
@@ -985,6 +1037,334 @@ func TestJoinRoomUseCase_Execute_ReconnectBroadcastErrorRollback(t *testing.T) {
 	}
 }
 
+func TestJoinRoomUseCase_Execute_AutoCreateDisabled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockHub := domain.NewMockHub(ctrl)
+	mockLockManager := lock.NewMockLockManager(ctrl)
+	testMetric, metricMeter := newTestPlanningPokerMetric(ctrl)
+	mockBus := domain.NewMockBus(ctrl)
+
+	roomID := "typo"
+
+	mockLockManager.EXPECT().
+		WithLock(gomock.Any(), roomID, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, key string, fn func(context.Context) (any, error)) (any, error) {
+			return fn(ctx)
+		})
+
+	mockHub.EXPECT().LoadRoom(ctx, roomID).Return(nil, domain.ErrRoomNotFound)
+
+	uc := NewJoinRoomUseCase(mockHub, mockLockManager, testMetric, false)
+	cmd := JoinRoomCommand{
+		RoomID:   roomID,
+		SenderID: "sender123",
+		Bus:      mockBus,
+	}
+
+	output, err := uc.Execute(ctx, cmd)
+
+	if !errors.Is(err, domain.ErrRoomNotFound) {
+		t.Fatalf("expected ErrRoomNotFound, got %v", err)
+	}
+	if output != nil {
+		t.Fatalf("expected nil output, got %+v", output)
+	}
+	if calls := metricMeter.getCalls(); len(calls) != 0 {
+		t.Fatalf("expected no metric changes, got %d calls", len(calls))
+	}
+}
+
+func TestJoinRoomUseCase_Execute_ProtectedRoom(t *testing.T) {
+	tests := []struct {
+		name        string
+		credentials entity.Credentials
+		wantErr     bool
+	}{
+		{name: "rejects missing credentials", wantErr: true},
+		{name: "rejects wrong passcode", credentials: entity.Credentials{Passcode: "wrong"}, wantErr: true},
+		{name: "accepts passcode", credentials: entity.Credentials{Passcode: "s3cret"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.Background()
+			mockHub := domain.NewMockHub(ctrl)
+			mockLockManager := lock.NewMockLockManager(ctrl)
+			testMetric, _ := newTestPlanningPokerMetric(ctrl)
+			mockBus := domain.NewMockBus(ctrl)
+
+			roomID := "room123"
+			room := &entity.Room{
+				ID:      roomID,
+				Clients: clientcollection.New(),
+			}
+			room.NewClient("owner")
+			_ = room.SetPasscode(ctx, "owner", "s3cret")
+
+			mockLockManager.EXPECT().
+				WithLock(gomock.Any(), roomID, gomock.Any()).
+				DoAndReturn(func(ctx context.Context, key string, fn func(context.Context) (any, error)) (any, error) {
+					return fn(ctx)
+				})
+
+			mockHub.EXPECT().LoadRoom(ctx, roomID).Return(room, nil)
+			if !tt.wantErr {
+				mockHub.EXPECT().AddClient(gomock.Any())
+				mockHub.EXPECT().SaveRoom(gomock.Any(), gomock.Any()).Return(nil)
+				mockHub.EXPECT().AddBus(gomock.Any(), gomock.Any(), gomock.Any())
+				mockBus.EXPECT().Send(gomock.Any(), gomock.Any()).Return(nil)
+				mockHub.EXPECT().BroadcastToRoom(ctx, roomID, gomock.Any()).Return(nil)
+			}
+
+			uc := NewJoinRoomUseCase(mockHub, mockLockManager, testMetric, true)
+			cmd := JoinRoomCommand{
+				RoomID:      roomID,
+				SenderID:    "sender123",
+				Bus:         mockBus,
+				Credentials: tt.credentials,
+			}
+
+			_, err := uc.Execute(ctx, cmd)
+
+			if tt.wantErr {
+				if !errors.Is(err, domain.ErrRoomAccessDenied) {
+					t.Fatalf("expected ErrRoomAccessDenied, got %v", err)
+				}
+				if _, ok := room.FindClient("sender123"); ok {
+					t.Error("expected rejected client not to be added to the room")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestJoinRoomUseCase_Execute_InviteOnlyRoom(t *testing.T) {
+	tests := []struct {
+		name       string
+		withInvite bool
+		wantErr    bool
+	}{
+		{name: "rejects missing invite", wantErr: true},
+		{name: "accepts invite", withInvite: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.Background()
+			mockHub := domain.NewMockHub(ctrl)
+			mockLockManager := lock.NewMockLockManager(ctrl)
+			testMetric, _ := newTestPlanningPokerMetric(ctrl)
+			mockBus := domain.NewMockBus(ctrl)
+
+			roomID := "room123"
+			room := &entity.Room{
+				ID:      roomID,
+				Clients: clientcollection.New(),
+			}
+			room.NewClient("owner")
+			invite, err := room.CreateInvite(ctx, "owner", time.Now(), time.Hour)
+			if err != nil {
+				t.Fatalf("unexpected error creating invite: %v", err)
+			}
+
+			credentials := entity.Credentials{}
+			if tt.withInvite {
+				credentials.InviteToken = invite.Token
+			}
+
+			mockLockManager.EXPECT().
+				WithLock(gomock.Any(), roomID, gomock.Any()).
+				DoAndReturn(func(ctx context.Context, key string, fn func(context.Context) (any, error)) (any, error) {
+					return fn(ctx)
+				})
+
+			mockHub.EXPECT().LoadRoom(ctx, roomID).Return(room, nil)
+			if !tt.wantErr {
+				mockHub.EXPECT().AddClient(gomock.Any())
+				mockHub.EXPECT().SaveRoom(gomock.Any(), gomock.Any()).Return(nil)
+				mockHub.EXPECT().AddBus(gomock.Any(), gomock.Any(), gomock.Any())
+				mockBus.EXPECT().Send(gomock.Any(), gomock.Any()).Return(nil)
+				mockHub.EXPECT().BroadcastToRoom(ctx, roomID, gomock.Any()).Return(nil)
+			}
+
+			uc := NewJoinRoomUseCase(mockHub, mockLockManager, testMetric, true)
+			cmd := JoinRoomCommand{
+				RoomID:      roomID,
+				SenderID:    "sender123",
+				Bus:         mockBus,
+				Credentials: credentials,
+			}
+
+			_, err = uc.Execute(ctx, cmd)
+
+			if tt.wantErr {
+				if !errors.Is(err, domain.ErrRoomAccessDenied) {
+					t.Fatalf("expected ErrRoomAccessDenied, got %v", err)
+				}
+				if _, ok := room.FindClient("sender123"); ok {
+					t.Error("expected rejected client not to be added to the room")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestJoinRoomUseCase_Execute_ProtectedRoomReconnectSkipsCredentials(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockHub := domain.NewMockHub(ctrl)
+	mockLockManager := lock.NewMockLockManager(ctrl)
+	testMetric, _ := newTestPlanningPokerMetric(ctrl)
+	mockBus := domain.NewMockBus(ctrl)
+
+	roomID := "room123"
+	clientID := "existing-client"
+	room := &entity.Room{
+		ID:      roomID,
+		Clients: clientcollection.New(),
+	}
+	room.NewClient(clientID)
+	_ = room.SetPasscode(ctx, clientID, "s3cret")
+
+	mockLockManager.EXPECT().
+		WithLock(gomock.Any(), roomID, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, key string, fn func(context.Context) (any, error)) (any, error) {
+			return fn(ctx)
+		})
+
+	mockHub.EXPECT().LoadRoom(ctx, roomID).Return(room, nil)
+	mockHub.EXPECT().GetBus(clientID).Return(nil, false)
+	mockHub.EXPECT().AddBus(gomock.Any(), clientID, mockBus)
+	mockBus.EXPECT().Send(gomock.Any(), gomock.Any()).Return(nil)
+	mockHub.EXPECT().BroadcastToRoom(ctx, roomID, gomock.Any()).Return(nil)
+
+	uc := NewJoinRoomUseCase(mockHub, mockLockManager, testMetric, true)
+	cmd := JoinRoomCommand{
+		RoomID:   roomID,
+		SenderID: clientID,
+		Bus:      mockBus,
+	}
+
+	if _, err := uc.Execute(ctx, cmd); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}
+
+func TestJoinRoomUseCase_Execute_ReconnectRequiresSameIdentity(t *testing.T) {
+	alice := entity.Identity{Issuer: "https://idp.example.com", Subject: "alice", Name: "Alice"}
+	mallory := entity.Identity{Issuer: "https://idp.example.com", Subject: "mallory"}
+
+	tests := []struct {
+		name     string
+		identity entity.Identity
+	}{
+		{name: "anonymous", identity: entity.Identity{}},
+		{name: "other subject", identity: mallory},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.Background()
+			mockHub := domain.NewMockHub(ctrl)
+			mockLockManager := lock.NewMockLockManager(ctrl)
+			testMetric, _ := newTestPlanningPokerMetric(ctrl)
+
+			roomID := "room123"
+			room := &entity.Room{
+				ID:      roomID,
+				Clients: clientcollection.New(),
+			}
+			room.NewClientWithIdentity(alice.ClientID(), alice)
+
+			mockLockManager.EXPECT().
+				WithLock(gomock.Any(), roomID, gomock.Any()).
+				DoAndReturn(func(ctx context.Context, key string, fn func(context.Context) (any, error)) (any, error) {
+					return fn(ctx)
+				})
+			mockHub.EXPECT().LoadRoom(ctx, roomID).Return(room, nil)
+
+			uc := NewJoinRoomUseCase(mockHub, mockLockManager, testMetric, true)
+			_, err := uc.Execute(ctx, JoinRoomCommand{
+				RoomID:   roomID,
+				SenderID: alice.ClientID(),
+				Bus:      domain.NewMockBus(ctrl),
+				Identity: tt.identity,
+			})
+
+			if !errors.Is(err, domain.ErrIdentityMismatch) {
+				t.Fatalf("expected ErrIdentityMismatch, got %v", err)
+			}
+		})
+	}
+}
+
+func TestJoinRoomUseCase_Execute_NewClientWithIdentity(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockHub := domain.NewMockHub(ctrl)
+	mockLockManager := lock.NewMockLockManager(ctrl)
+	testMetric, _ := newTestPlanningPokerMetric(ctrl)
+	mockBus := domain.NewMockBus(ctrl)
+
+	alice := entity.Identity{Issuer: "https://idp.example.com", Subject: "alice", Name: "Alice"}
+	roomID := "room123"
+	room := &entity.Room{
+		ID:      roomID,
+		Clients: clientcollection.New(),
+	}
+
+	mockLockManager.EXPECT().
+		WithLock(gomock.Any(), roomID, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, key string, fn func(context.Context) (any, error)) (any, error) {
+			return fn(ctx)
+		})
+	mockHub.EXPECT().LoadRoom(ctx, roomID).Return(room, nil)
+	mockHub.EXPECT().SaveRoom(ctx, room).Return(nil)
+	mockHub.EXPECT().AddClient(gomock.Any())
+	mockHub.EXPECT().AddBus(gomock.Any(), alice.ClientID(), mockBus)
+	mockBus.EXPECT().Send(gomock.Any(), gomock.Any()).Return(nil)
+	mockHub.EXPECT().BroadcastToRoom(ctx, roomID, gomock.Any()).Return(nil)
+
+	uc := NewJoinRoomUseCase(mockHub, mockLockManager, testMetric, true)
+	output, err := uc.Execute(ctx, JoinRoomCommand{
+		RoomID:   roomID,
+		SenderID: alice.ClientID(),
+		Bus:      mockBus,
+		Identity: alice,
+	})
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if output.Client.Subject != "alice" || output.Client.Name != "Alice" {
+		t.Errorf("expected the client to carry the identity, got %+v", output.Client)
+	}
+}
+
 func TestJoinRoomUseCase_Execute_NilDependencies(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -1008,7 +1388,7 @@ func TestJoinRoomUseCase_Execute_NilDependencies(t *testing.T) {
 				}
 			}()
 
-			_ = NewJoinRoomUseCase(tc.hub, tc.lock, mockMetric)
+			_ = NewJoinRoomUseCase(tc.hub, tc.lock, mockMetric, true)
 		})
 	}
 }
@@ -3,10 +3,13 @@ package usecase
 import (
 	"context"
 	"errors"
+	"planning-poker/internal/application/audit"
 	"planning-poker/internal/application/lock"
 	"planning-poker/internal/application/planningpoker/metric"
 	"planning-poker/internal/application/planningpoker/usecase/dto"
+	"planning-poker/internal/application/webhook"
 	"planning-poker/internal/domain"
+	"planning-poker/internal/domain/entity"
 
 	"github.com/bruno303/go-toolkit/pkg/log"
 )
@@ -15,22 +18,35 @@ type (
 	LeaveRoomCommand struct {
 		RoomID   string
 		SenderID string
+		// RoomClosed is set when the client goes because its room was closed,
+		// which was already counted out of the active rooms
+		RoomClosed bool
 	}
 	leaveRoomUseCase struct {
 		hub         domain.Hub
 		lockManager lock.LockManager
 		metric      metric.PlanningPokerMetric
+		auditor     audit.Auditor
+		webhooks    webhook.Publisher
 		logger      log.Logger
 	}
 )
 
 var _ UseCase[LeaveRoomCommand] = (*leaveRoomUseCase)(nil)
 
-func NewLeaveRoomUseCase(hub domain.Hub, lockManager lock.LockManager, metric metric.PlanningPokerMetric) *leaveRoomUseCase {
+func NewLeaveRoomUseCase(
+	hub domain.Hub,
+	lockManager lock.LockManager,
+	metric metric.PlanningPokerMetric,
+	auditor audit.Auditor,
+	webhooks webhook.Publisher,
+) *leaveRoomUseCase {
 	return &leaveRoomUseCase{
 		hub:         hub,
 		lockManager: lockManager,
 		metric:      metric,
+		auditor:     auditor,
+		webhooks:    webhooks,
 		logger:      log.NewLogger("usecase.leaveroom"),
 	}
 }
@@ -38,23 +54,46 @@ func NewLeaveRoomUseCase(hub domain.Hub, lockManager lock.LockManager, metric me
 func (uc *leaveRoomUseCase) Execute(ctx context.Context, cmd LeaveRoomCommand) error {
 	uc.logger.Info(ctx, "Client %s leaving room %s", cmd.SenderID, cmd.RoomID)
 	return uc.lockManager.ExecuteWithLock(ctx, cmd.RoomID, func(ctx context.Context) error {
+		// the room before the client leaves tells whether its ownership
+		// passes on to another client, and what its session was about when
+		// the client was the last one
+		var before *entity.Room
+		var soleOwner *entity.Client
+		if !cmd.RoomClosed {
+			if room, err := uc.hub.LoadRoom(ctx, cmd.RoomID); err == nil {
+				before = room
+				if client, ok := before.FindClient(cmd.SenderID); ok && client.IsOwner && before.CountOwners() == 1 {
+					soleOwner = client
+				}
+			}
+		}
+
 		if err := uc.hub.RemoveClient(ctx, cmd.SenderID, cmd.RoomID); err != nil {
 			uc.logger.Error(ctx, "Error removing client from room", err)
 			return err
 		}
 
 		uc.metric.DecrementActiveUsers(ctx)
+		if cmd.RoomClosed {
+			return nil
+		}
 
 		// if room still exists, broadcast the updated state
 		// otherwise, decrement active rooms metric
 		room, err := uc.hub.LoadRoom(ctx, cmd.RoomID)
 		if err == nil {
+			if soleOwner != nil {
+				uc.recordOwnershipTransfer(ctx, room, soleOwner)
+			}
 			if err := uc.hub.BroadcastToRoom(ctx, room.ID, dto.NewRoomStateCommand(room)); err != nil {
 				uc.logger.Error(ctx, "Error broadcasting room state", err)
 				return err
 			}
 		} else if errors.Is(err, domain.ErrRoomNotFound) {
 			uc.metric.DecrementActiveRoomsCounter(ctx)
+			if before != nil {
+				uc.webhooks.Publish(ctx, webhook.NewSessionEndedEvent(before, entity.ExpiryEmpty))
+			}
 		} else {
 			uc.logger.Error(ctx, "Error loading room after client removal", err)
 			return err
@@ -64,3 +103,16 @@ func (uc *leaveRoomUseCase) Execute(ctx context.Context, cmd LeaveRoomCommand) e
 		return nil
 	})
 }
+
+func (uc *leaveRoomUseCase) recordOwnershipTransfer(ctx context.Context, room *entity.Room, previous *entity.Client) {
+	for _, owner := range room.Clients.Filter(func(client *entity.Client) bool { return client.IsOwner }).Values() {
+		uc.auditor.Record(ctx, audit.Record{
+			RoomID:     room.ID,
+			Action:     audit.ActionOwnershipTransfer,
+			Actor:      previous.ID,
+			ActorName:  previous.Name,
+			Target:     owner.ID,
+			TargetName: owner.Name,
+		}, nil)
+	}
+}
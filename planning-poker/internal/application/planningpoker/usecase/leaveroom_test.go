@@ -3,8 +3,10 @@ package usecase
 import (
 	"context"
 	"errors"
+	"planning-poker/internal/application/audit"
 	"planning-poker/internal/application/lock"
 	"planning-poker/internal/application/planningpoker/metric"
+	"planning-poker/internal/application/webhook"
 	"planning-poker/internal/domain"
 	"planning-poker/internal/domain/entity"
 	"planning-poker/internal/infra/boundaries/hub/clientcollection"
@@ -21,7 +23,7 @@ func TestNewLeaveRoomUseCase(t *testing.T) {
 	mockLockManager := lock.NewMockLockManager(ctrl)
 	mockMetric := metric.NewPlanningPokerMetric()
 
-	uc := NewLeaveRoomUseCase(mockHub, mockLockManager, mockMetric)
+	uc := NewLeaveRoomUseCase(mockHub, mockLockManager, mockMetric, audit.Discard, webhook.Discard)
 
 	if uc.hub != mockHub {
 		t.Error("hub not set correctly")
@@ -53,11 +55,12 @@ func TestLeaveRoomUseCase_Execute_Success_RoomExists(t *testing.T) {
 			return fn(ctx)
 		})
 
+	mockHub.EXPECT().LoadRoom(ctx, roomID).Return(&entity.Room{ID: roomID, Clients: clientcollection.New()}, nil)
 	mockHub.EXPECT().RemoveClient(ctx, senderID, roomID).Return(nil)
 	mockHub.EXPECT().LoadRoom(ctx, roomID).Return(room, nil)
 	mockHub.EXPECT().BroadcastToRoom(ctx, roomID, gomock.Any()).Return(nil)
 
-	uc := NewLeaveRoomUseCase(mockHub, mockLockManager, testMetric)
+	uc := NewLeaveRoomUseCase(mockHub, mockLockManager, testMetric, audit.Discard, webhook.Discard)
 	cmd := LeaveRoomCommand{
 		RoomID:   roomID,
 		SenderID: senderID,
@@ -96,10 +99,18 @@ func TestLeaveRoomUseCase_Execute_WhenRoomIsMissingAfterRemove_DecrementsRoomMet
 			return fn(ctx)
 		})
 
+	mockHub.EXPECT().LoadRoom(ctx, roomID).Return(&entity.Room{ID: roomID, Clients: clientcollection.New()}, nil)
 	mockHub.EXPECT().RemoveClient(ctx, senderID, roomID).Return(nil)
 	mockHub.EXPECT().LoadRoom(ctx, roomID).Return(nil, domain.ErrRoomNotFound)
 
-	uc := NewLeaveRoomUseCase(mockHub, mockLockManager, testMetric)
+	mockWebhooks := webhook.NewMockPublisher(ctrl)
+	mockWebhooks.EXPECT().Publish(ctx, gomock.Any()).Do(func(_ context.Context, event webhook.Event) {
+		if event.Type != webhook.EventSessionEnded || event.RoomID != roomID {
+			t.Errorf("unexpected event %+v", event)
+		}
+	})
+
+	uc := NewLeaveRoomUseCase(mockHub, mockLockManager, testMetric, audit.Discard, mockWebhooks)
 	cmd := LeaveRoomCommand{
 		RoomID:   roomID,
 		SenderID: senderID,
@@ -137,9 +148,10 @@ func TestLeaveRoomUseCase_Execute_RemoveClientError(t *testing.T) {
 			return fn(ctx)
 		})
 
+	mockHub.EXPECT().LoadRoom(ctx, roomID).Return(&entity.Room{ID: roomID, Clients: clientcollection.New()}, nil)
 	mockHub.EXPECT().RemoveClient(ctx, senderID, roomID).Return(expectedError)
 
-	uc := NewLeaveRoomUseCase(mockHub, mockLockManager, mockMetric)
+	uc := NewLeaveRoomUseCase(mockHub, mockLockManager, mockMetric, audit.Discard, webhook.Discard)
 	cmd := LeaveRoomCommand{
 		RoomID:   roomID,
 		SenderID: senderID,
@@ -179,11 +191,12 @@ func TestLeaveRoomUseCase_Execute_BroadcastError(t *testing.T) {
 			return fn(ctx)
 		})
 
+	mockHub.EXPECT().LoadRoom(ctx, roomID).Return(&entity.Room{ID: roomID, Clients: clientcollection.New()}, nil)
 	mockHub.EXPECT().RemoveClient(ctx, senderID, roomID).Return(nil)
 	mockHub.EXPECT().LoadRoom(ctx, roomID).Return(room, nil)
 	mockHub.EXPECT().BroadcastToRoom(ctx, roomID, gomock.Any()).Return(expectedError)
 
-	uc := NewLeaveRoomUseCase(mockHub, mockLockManager, mockMetric)
+	uc := NewLeaveRoomUseCase(mockHub, mockLockManager, mockMetric, audit.Discard, webhook.Discard)
 	cmd := LeaveRoomCommand{
 		RoomID:   roomID,
 		SenderID: senderID,
@@ -218,10 +231,11 @@ func TestLeaveRoomUseCase_Execute_LoadRoomErrorAfterRemove_ReturnsErrorWithoutDe
 			return fn(ctx)
 		})
 
+	mockHub.EXPECT().LoadRoom(ctx, roomID).Return(&entity.Room{ID: roomID, Clients: clientcollection.New()}, nil)
 	mockHub.EXPECT().RemoveClient(ctx, senderID, roomID).Return(nil)
 	mockHub.EXPECT().LoadRoom(ctx, roomID).Return(nil, expectedError)
 
-	uc := NewLeaveRoomUseCase(mockHub, mockLockManager, testMetric)
+	uc := NewLeaveRoomUseCase(mockHub, mockLockManager, testMetric, audit.Discard, webhook.Discard)
 	cmd := LeaveRoomCommand{
 		RoomID:   roomID,
 		SenderID: senderID,
@@ -241,3 +255,80 @@ func TestLeaveRoomUseCase_Execute_LoadRoomErrorAfterRemove_ReturnsErrorWithoutDe
 		t.Fatalf("expected no active room decrements, got %d", countMetricCallsWithValue(calls, metric.PlanningPokerActiveRoomsMetric, -1))
 	}
 }
+
+func TestLeaveRoomUseCase_Execute_WhenRoomWasClosed_OnlyDecrementsUsers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockHub := domain.NewMockHub(ctrl)
+	mockLockManager := lock.NewMockLockManager(ctrl)
+	testMetric, metricMeter := newTestPlanningPokerMetric(ctrl)
+
+	roomID := "room123"
+	senderID := "client123"
+
+	mockLockManager.EXPECT().
+		ExecuteWithLock(gomock.Any(), roomID, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, key string, fn func(context.Context) error) error {
+			return fn(ctx)
+		})
+
+	mockHub.EXPECT().RemoveClient(ctx, senderID, roomID).Return(nil)
+
+	uc := NewLeaveRoomUseCase(mockHub, mockLockManager, testMetric, audit.Discard, webhook.Discard)
+	cmd := LeaveRoomCommand{
+		RoomID:     roomID,
+		SenderID:   senderID,
+		RoomClosed: true,
+	}
+
+	if err := uc.Execute(ctx, cmd); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	assertMetricCallSequence(t, metricMeter.getCalls(),
+		expectedMetricCall{name: metric.PlanningPokerActiveUsersMetric, value: -1},
+	)
+}
+
+func TestLeaveRoomUseCase_Execute_RecordsOwnershipTransfer(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockHub := domain.NewMockHub(ctrl)
+	mockLockManager := lock.NewMockLockManager(ctrl)
+	mockAuditor := audit.NewMockAuditor(ctrl)
+
+	before := &entity.Room{ID: "room123", Clients: clientcollection.New()}
+	before.NewClient("owner").Name = "Alice"
+	before.NewClient("client").Name = "Bob"
+	after := &entity.Room{ID: "room123", Clients: clientcollection.New()}
+	after.NewClient("client").Name = "Bob"
+
+	mockLockManager.EXPECT().
+		ExecuteWithLock(gomock.Any(), "room123", gomock.Any()).
+		DoAndReturn(func(ctx context.Context, key string, fn func(context.Context) error) error {
+			return fn(ctx)
+		})
+	gomock.InOrder(
+		mockHub.EXPECT().LoadRoom(ctx, "room123").Return(before, nil),
+		mockHub.EXPECT().RemoveClient(ctx, "owner", "room123").Return(nil),
+		mockHub.EXPECT().LoadRoom(ctx, "room123").Return(after, nil),
+	)
+	mockHub.EXPECT().BroadcastToRoom(ctx, "room123", gomock.Any()).Return(nil)
+	mockAuditor.EXPECT().Record(ctx, audit.Record{
+		RoomID:     "room123",
+		Action:     audit.ActionOwnershipTransfer,
+		Actor:      "owner",
+		ActorName:  "Alice",
+		Target:     "client",
+		TargetName: "Bob",
+	}, nil)
+
+	uc := NewLeaveRoomUseCase(mockHub, mockLockManager, metric.NewPlanningPokerMetric(), mockAuditor, webhook.Discard)
+	if err := uc.Execute(ctx, LeaveRoomCommand{RoomID: "room123", SenderID: "owner"}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}
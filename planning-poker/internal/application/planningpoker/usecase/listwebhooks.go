@@ -0,0 +1,40 @@
+package usecase
+
+import (
+	"context"
+	"planning-poker/internal/application/webhook"
+	"planning-poker/internal/domain"
+	"planning-poker/internal/domain/entity"
+)
+
+type (
+	ListWebhooksCommand struct {
+		RoomID   string
+		SenderID string
+	}
+	ListWebhooksUseCase struct {
+		hub   domain.Hub
+		store webhook.Store
+	}
+)
+
+var _ UseCaseR[ListWebhooksCommand, []webhook.Subscription] = (*ListWebhooksUseCase)(nil)
+
+func NewListWebhooksUseCase(hub domain.Hub, store webhook.Store) ListWebhooksUseCase {
+	return ListWebhooksUseCase{
+		hub:   hub,
+		store: store,
+	}
+}
+
+func (uc ListWebhooksUseCase) Execute(ctx context.Context, cmd ListWebhooksCommand) ([]webhook.Subscription, error) {
+	room, err := uc.hub.LoadRoom(ctx, cmd.RoomID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := room.CheckPermission(cmd.SenderID, entity.ActionManageAccess); err != nil {
+		return nil, err
+	}
+
+	return roomWebhooks(ctx, uc.store, room.ID)
+}
@@ -0,0 +1,49 @@
+package usecase
+
+import (
+	"context"
+	"planning-poker/internal/application/lock"
+	"planning-poker/internal/application/planningpoker/usecase/dto"
+	"planning-poker/internal/domain"
+)
+
+type (
+	RemoveBotCommand struct {
+		RoomID   string
+		SenderID string
+		BotID    string
+	}
+	RemoveBotUseCase struct {
+		hub         domain.Hub
+		lockManager lock.LockManager
+	}
+)
+
+var _ UseCase[RemoveBotCommand] = (*RemoveBotUseCase)(nil)
+
+func NewRemoveBotUseCase(hub domain.Hub, lockManager lock.LockManager) RemoveBotUseCase {
+	return RemoveBotUseCase{
+		hub:         hub,
+		lockManager: lockManager,
+	}
+}
+
+// Execute removes the bot from the room, which revokes its token.
+func (uc RemoveBotUseCase) Execute(ctx context.Context, cmd RemoveBotCommand) error {
+	return uc.lockManager.ExecuteWithLock(ctx, cmd.RoomID, func(ctx context.Context) error {
+		room, err := uc.hub.LoadRoom(ctx, cmd.RoomID)
+		if err != nil {
+			return err
+		}
+
+		if err := room.RemoveBot(ctx, cmd.SenderID, cmd.BotID); err != nil {
+			return err
+		}
+
+		if err := uc.hub.SaveRoom(ctx, room); err != nil {
+			return err
+		}
+
+		return uc.hub.BroadcastToRoom(ctx, room.ID, dto.NewRoomStateCommand(room))
+	})
+}
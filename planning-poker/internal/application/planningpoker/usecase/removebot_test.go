@@ -0,0 +1,69 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"planning-poker/internal/application/lock"
+	"planning-poker/internal/domain"
+	"planning-poker/internal/domain/entity"
+	"planning-poker/internal/infra/boundaries/hub/clientcollection"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+)
+
+func TestRemoveBotUseCase_Execute_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockHub := domain.NewMockHub(ctrl)
+	mockLockManager := lock.NewMockLockManager(ctrl)
+
+	room := entity.NewRoomWithID("room123", clientcollection.New())
+	room.NewClient("owner")
+	credentials, _ := room.AddBot(ctx, "owner", "ci")
+
+	mockLockManager.EXPECT().
+		ExecuteWithLock(gomock.Any(), "room123", gomock.Any()).
+		DoAndReturn(func(ctx context.Context, key string, fn func(context.Context) error) error {
+			return fn(ctx)
+		})
+	mockHub.EXPECT().LoadRoom(ctx, "room123").Return(room, nil)
+	mockHub.EXPECT().SaveRoom(ctx, room).Return(nil)
+	mockHub.EXPECT().BroadcastToRoom(ctx, "room123", gomock.Any()).Return(nil)
+
+	uc := NewRemoveBotUseCase(mockHub, mockLockManager)
+	if err := uc.Execute(ctx, RemoveBotCommand{RoomID: "room123", SenderID: "owner", BotID: credentials.ClientID}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if _, ok := room.FindClient(credentials.ClientID); ok {
+		t.Error("expected the bot to be removed")
+	}
+}
+
+func TestRemoveBotUseCase_Execute_NotABot(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockHub := domain.NewMockHub(ctrl)
+	mockLockManager := lock.NewMockLockManager(ctrl)
+
+	room := entity.NewRoomWithID("room123", clientcollection.New())
+	room.NewClient("owner")
+	room.NewClient("voter")
+
+	mockLockManager.EXPECT().
+		ExecuteWithLock(gomock.Any(), "room123", gomock.Any()).
+		DoAndReturn(func(ctx context.Context, key string, fn func(context.Context) error) error {
+			return fn(ctx)
+		})
+	mockHub.EXPECT().LoadRoom(ctx, "room123").Return(room, nil)
+
+	uc := NewRemoveBotUseCase(mockHub, mockLockManager)
+	if err := uc.Execute(ctx, RemoveBotCommand{RoomID: "room123", SenderID: "owner", BotID: "voter"}); !errors.Is(err, domain.ErrClientNotFound) {
+		t.Fatalf("expected ErrClientNotFound, got %v", err)
+	}
+}
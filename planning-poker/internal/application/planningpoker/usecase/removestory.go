@@ -2,9 +2,11 @@ package usecase
 
 import (
 	"context"
+	"planning-poker/internal/application/audit"
 	"planning-poker/internal/application/lock"
 	"planning-poker/internal/application/planningpoker/usecase/dto"
 	"planning-poker/internal/domain"
+	"strconv"
 )
 
 type (
@@ -16,24 +18,38 @@ type (
 	RemoveStoryUseCase struct {
 		hub         domain.Hub
 		lockManager lock.LockManager
+		auditor     audit.Auditor
 	}
 )
 
 var _ UseCase[RemoveStoryCommand] = (*RemoveStoryUseCase)(nil)
 
-func NewRemoveStoryUseCase(hub domain.Hub, lockManager lock.LockManager) RemoveStoryUseCase {
+func NewRemoveStoryUseCase(hub domain.Hub, lockManager lock.LockManager, auditor audit.Auditor) RemoveStoryUseCase {
 	return RemoveStoryUseCase{
 		hub:         hub,
 		lockManager: lockManager,
+		auditor:     auditor,
 	}
 }
 
-func (uc RemoveStoryUseCase) Execute(ctx context.Context, cmd RemoveStoryCommand) error {
+func (uc RemoveStoryUseCase) Execute(ctx context.Context, cmd RemoveStoryCommand) (err error) {
+	record := audit.Record{
+		RoomID: cmd.RoomID,
+		Action: audit.ActionRemoveStory,
+		Actor:  cmd.SenderID,
+		Target: strconv.Itoa(cmd.StoryIndex),
+	}
+	defer func() { uc.auditor.Record(ctx, record, err) }()
+
 	return uc.lockManager.ExecuteWithLock(ctx, cmd.RoomID, func(ctx context.Context) error {
 		room, err := uc.hub.LoadRoom(ctx, cmd.RoomID)
 		if err != nil {
 			return err
 		}
+		withClientNames(&record, room)
+		if cmd.StoryIndex >= 0 && cmd.StoryIndex < len(room.Stories) {
+			record.TargetName = room.Stories[cmd.StoryIndex].Name
+		}
 
 		if err := room.RemoveStory(ctx, cmd.SenderID, cmd.StoryIndex); err != nil {
 			return err
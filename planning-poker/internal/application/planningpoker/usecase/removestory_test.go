@@ -3,6 +3,7 @@ package usecase
 import (
 	"context"
 	"errors"
+	"planning-poker/internal/application/audit"
 	"planning-poker/internal/application/lock"
 	"planning-poker/internal/domain"
 	"planning-poker/internal/domain/entity"
@@ -19,7 +20,7 @@ func TestNewRemoveStoryUseCase(t *testing.T) {
 	mockHub := domain.NewMockHub(ctrl)
 	mockLockManager := lock.NewMockLockManager(ctrl)
 
-	uc := NewRemoveStoryUseCase(mockHub, mockLockManager)
+	uc := NewRemoveStoryUseCase(mockHub, mockLockManager, audit.Discard)
 
 	if uc.hub != mockHub {
 		t.Error("hub not set correctly")
@@ -55,7 +56,7 @@ func TestRemoveStoryUseCase_Execute_Success(t *testing.T) {
 	mockHub.EXPECT().SaveRoom(ctx, room).Return(nil)
 	mockHub.EXPECT().BroadcastToRoom(ctx, roomID, gomock.Any()).Return(nil)
 
-	uc := NewRemoveStoryUseCase(mockHub, mockLockManager)
+	uc := NewRemoveStoryUseCase(mockHub, mockLockManager, audit.Discard)
 	cmd := RemoveStoryCommand{
 		RoomID:     roomID,
 		SenderID:   "client123",
@@ -98,7 +99,7 @@ func TestRemoveStoryUseCase_Execute_SaveRoomError(t *testing.T) {
 	mockHub.EXPECT().LoadRoom(ctx, roomID).Return(room, nil)
 	mockHub.EXPECT().SaveRoom(ctx, room).Return(expectedError)
 
-	uc := NewRemoveStoryUseCase(mockHub, mockLockManager)
+	uc := NewRemoveStoryUseCase(mockHub, mockLockManager, audit.Discard)
 	cmd := RemoveStoryCommand{
 		RoomID:     roomID,
 		SenderID:   senderID,
@@ -133,7 +134,7 @@ func TestRemoveStoryUseCase_Execute_RoomNotFound(t *testing.T) {
 
 	mockHub.EXPECT().LoadRoom(ctx, roomID).Return(nil, domain.ErrRoomNotFound)
 
-	uc := NewRemoveStoryUseCase(mockHub, mockLockManager)
+	uc := NewRemoveStoryUseCase(mockHub, mockLockManager, audit.Discard)
 	cmd := RemoveStoryCommand{
 		RoomID:     roomID,
 		SenderID:   "client123",
@@ -180,7 +181,7 @@ func TestRemoveStoryUseCase_Execute_BroadcastError(t *testing.T) {
 	mockHub.EXPECT().SaveRoom(ctx, room).Return(nil)
 	mockHub.EXPECT().BroadcastToRoom(ctx, roomID, gomock.Any()).Return(expectedError)
 
-	uc := NewRemoveStoryUseCase(mockHub, mockLockManager)
+	uc := NewRemoveStoryUseCase(mockHub, mockLockManager, audit.Discard)
 	cmd := RemoveStoryCommand{
 		RoomID:     roomID,
 		SenderID:   "client123",
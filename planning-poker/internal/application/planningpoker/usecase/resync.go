@@ -0,0 +1,47 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"planning-poker/internal/application/planningpoker/usecase/dto"
+	"planning-poker/internal/domain"
+)
+
+type (
+	ResyncCommand struct {
+		RoomID   string
+		SenderID string
+		// ReplyTo receives the answer instead of the bus of the sender
+		ReplyTo domain.Bus
+	}
+	ResyncUseCase struct {
+		hub domain.Hub
+	}
+)
+
+var _ UseCase[ResyncCommand] = (*ResyncUseCase)(nil)
+
+func NewResyncUseCase(hub domain.Hub) ResyncUseCase {
+	return ResyncUseCase{
+		hub: hub,
+	}
+}
+
+// Execute sends the full room state only to the client that missed a patch.
+func (uc ResyncUseCase) Execute(ctx context.Context, cmd ResyncCommand) error {
+	room, err := uc.hub.LoadRoom(ctx, cmd.RoomID)
+	if err != nil {
+		return err
+	}
+
+	if _, ok := room.FindClient(cmd.SenderID); !ok {
+		return fmt.Errorf("client %s not found in room %s", cmd.SenderID, cmd.RoomID)
+	}
+
+	bus, err := replyBus(uc.hub, cmd.ReplyTo, cmd.SenderID)
+	if err != nil {
+		return err
+	}
+
+	return bus.Send(ctx, dto.NewRoomStateCommand(room))
+}
@@ -0,0 +1,61 @@
+package usecase
+
+import (
+	"context"
+	"planning-poker/internal/application/planningpoker/usecase/dto"
+	"planning-poker/internal/domain"
+	"planning-poker/internal/domain/entity"
+	"planning-poker/internal/infra/boundaries/hub/clientcollection"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+)
+
+func TestResyncUseCase_Execute_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockHub := domain.NewMockHub(ctrl)
+	mockBus := domain.NewMockBus(ctrl)
+
+	room := entity.NewRoomWithID("room123", clientcollection.New())
+	room.NewClient("owner")
+	room.NewClient("client123")
+
+	mockHub.EXPECT().LoadRoom(ctx, "room123").Return(room, nil)
+	mockHub.EXPECT().GetBus("client123").Return(mockBus, true)
+	mockBus.EXPECT().Send(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, msg any) error {
+		state, ok := msg.(dto.RoomState)
+		if !ok {
+			t.Fatalf("expected dto.RoomState, got %T", msg)
+		}
+		if state.Type != "room-state" || state.Version != room.Version {
+			t.Errorf("unexpected room state type %s version %d", state.Type, state.Version)
+		}
+		return nil
+	})
+
+	uc := NewResyncUseCase(mockHub)
+	if err := uc.Execute(ctx, ResyncCommand{RoomID: "room123", SenderID: "client123"}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}
+
+func TestResyncUseCase_Execute_ClientNotInRoom(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockHub := domain.NewMockHub(ctrl)
+
+	room := entity.NewRoomWithID("room123", clientcollection.New())
+	room.NewClient("owner")
+
+	mockHub.EXPECT().LoadRoom(ctx, "room123").Return(room, nil)
+
+	uc := NewResyncUseCase(mockHub)
+	if err := uc.Execute(ctx, ResyncCommand{RoomID: "room123", SenderID: "stranger"}); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"planning-poker/internal/application/lock"
 	"planning-poker/internal/application/planningpoker/usecase/dto"
+	"planning-poker/internal/application/webhook"
 	"planning-poker/internal/domain"
 )
 
@@ -15,15 +16,17 @@ type (
 	RevealUseCase struct {
 		hub         domain.Hub
 		lockManager lock.LockManager
+		webhooks    webhook.Publisher
 	}
 )
 
 var _ UseCase[RevealCommand] = (*RevealUseCase)(nil)
 
-func NewRevealUseCase(hub domain.Hub, lockManager lock.LockManager) RevealUseCase {
+func NewRevealUseCase(hub domain.Hub, lockManager lock.LockManager, webhooks webhook.Publisher) RevealUseCase {
 	return RevealUseCase{
 		hub:         hub,
 		lockManager: lockManager,
+		webhooks:    webhooks,
 	}
 }
 
@@ -45,6 +48,10 @@ func (uc RevealUseCase) Execute(ctx context.Context, cmd RevealCommand) error {
 		if err := uc.hub.BroadcastToRoom(ctx, room.ID, dto.NewRoomStateCommand(room)); err != nil {
 			return err
 		}
+		// the toggle hides the votes as well
+		if room.Reveal {
+			uc.webhooks.Publish(ctx, webhook.NewStoryRevealedEvent(room))
+		}
 
 		return nil
 	})
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"planning-poker/internal/application/lock"
+	"planning-poker/internal/application/webhook"
 	"planning-poker/internal/domain"
 	"planning-poker/internal/domain/entity"
 	"planning-poker/internal/infra/boundaries/hub/clientcollection"
@@ -19,7 +20,7 @@ func TestNewRevealUseCase(t *testing.T) {
 	mockHub := domain.NewMockHub(ctrl)
 	mockLockManager := lock.NewMockLockManager(ctrl)
 
-	uc := NewRevealUseCase(mockHub, mockLockManager)
+	uc := NewRevealUseCase(mockHub, mockLockManager, webhook.Discard)
 
 	if uc.hub != mockHub {
 		t.Error("hub not set correctly")
@@ -54,7 +55,14 @@ func TestRevealUseCase_Execute_Success(t *testing.T) {
 	mockHub.EXPECT().SaveRoom(ctx, room).Return(nil)
 	mockHub.EXPECT().BroadcastToRoom(ctx, roomID, gomock.Any()).Return(nil)
 
-	uc := NewRevealUseCase(mockHub, mockLockManager)
+	mockWebhooks := webhook.NewMockPublisher(ctrl)
+	mockWebhooks.EXPECT().Publish(ctx, gomock.Any()).Do(func(_ context.Context, event webhook.Event) {
+		if event.Type != webhook.EventStoryRevealed || event.RoomID != roomID {
+			t.Errorf("unexpected event %+v", event)
+		}
+	})
+
+	uc := NewRevealUseCase(mockHub, mockLockManager, mockWebhooks)
 	cmd := RevealCommand{
 		RoomID:   roomID,
 		SenderID: "client123",
@@ -95,7 +103,7 @@ func TestRevealUseCase_Execute_SaveRoomError(t *testing.T) {
 	mockHub.EXPECT().LoadRoom(ctx, roomID).Return(room, nil)
 	mockHub.EXPECT().SaveRoom(ctx, room).Return(expectedError)
 
-	uc := NewRevealUseCase(mockHub, mockLockManager)
+	uc := NewRevealUseCase(mockHub, mockLockManager, webhook.Discard)
 	cmd := RevealCommand{
 		RoomID:   roomID,
 		SenderID: senderID,
@@ -129,7 +137,7 @@ func TestRevealUseCase_Execute_RoomNotFound(t *testing.T) {
 
 	mockHub.EXPECT().LoadRoom(ctx, roomID).Return(nil, domain.ErrRoomNotFound)
 
-	uc := NewRevealUseCase(mockHub, mockLockManager)
+	uc := NewRevealUseCase(mockHub, mockLockManager, webhook.Discard)
 	cmd := RevealCommand{
 		RoomID:   roomID,
 		SenderID: "client123",
@@ -174,7 +182,7 @@ func TestRevealUseCase_Execute_BroadcastError(t *testing.T) {
 	mockHub.EXPECT().SaveRoom(ctx, room).Return(nil)
 	mockHub.EXPECT().BroadcastToRoom(ctx, roomID, gomock.Any()).Return(expectedError)
 
-	uc := NewRevealUseCase(mockHub, mockLockManager)
+	uc := NewRevealUseCase(mockHub, mockLockManager, webhook.Discard)
 	cmd := RevealCommand{
 		RoomID:   roomID,
 		SenderID: "client123",
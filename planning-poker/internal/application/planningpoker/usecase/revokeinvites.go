@@ -0,0 +1,51 @@
+package usecase
+
+import (
+	"context"
+	"planning-poker/internal/application/lock"
+	"planning-poker/internal/application/planningpoker/usecase/dto"
+	"planning-poker/internal/domain"
+)
+
+type (
+	RevokeInvitesCommand struct {
+		RoomID   string
+		SenderID string
+	}
+	RevokeInvitesUseCase struct {
+		hub         domain.Hub
+		lockManager lock.LockManager
+	}
+)
+
+var _ UseCase[RevokeInvitesCommand] = (*RevokeInvitesUseCase)(nil)
+
+func NewRevokeInvitesUseCase(hub domain.Hub, lockManager lock.LockManager) RevokeInvitesUseCase {
+	return RevokeInvitesUseCase{
+		hub:         hub,
+		lockManager: lockManager,
+	}
+}
+
+func (uc RevokeInvitesUseCase) Execute(ctx context.Context, cmd RevokeInvitesCommand) error {
+	return uc.lockManager.ExecuteWithLock(ctx, cmd.RoomID, func(ctx context.Context) error {
+		room, err := uc.hub.LoadRoom(ctx, cmd.RoomID)
+		if err != nil {
+			return err
+		}
+
+		if err := room.RevokeInvites(ctx, cmd.SenderID); err != nil {
+			return err
+		}
+
+		if err := uc.hub.SaveRoom(ctx, room); err != nil {
+			return err
+		}
+
+		if err := uc.hub.BroadcastToRoom(ctx, room.ID, dto.NewRoomStateCommand(room)); err != nil {
+			return err
+		}
+
+		return nil
+	})
+}
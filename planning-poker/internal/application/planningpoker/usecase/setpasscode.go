@@ -0,0 +1,52 @@
+package usecase
+
+import (
+	"context"
+	"planning-poker/internal/application/lock"
+	"planning-poker/internal/application/planningpoker/usecase/dto"
+	"planning-poker/internal/domain"
+)
+
+type (
+	SetPasscodeCommand struct {
+		RoomID   string
+		SenderID string
+		Passcode string
+	}
+	SetPasscodeUseCase struct {
+		hub         domain.Hub
+		lockManager lock.LockManager
+	}
+)
+
+var _ UseCase[SetPasscodeCommand] = (*SetPasscodeUseCase)(nil)
+
+func NewSetPasscodeUseCase(hub domain.Hub, lockManager lock.LockManager) SetPasscodeUseCase {
+	return SetPasscodeUseCase{
+		hub:         hub,
+		lockManager: lockManager,
+	}
+}
+
+func (uc SetPasscodeUseCase) Execute(ctx context.Context, cmd SetPasscodeCommand) error {
+	return uc.lockManager.ExecuteWithLock(ctx, cmd.RoomID, func(ctx context.Context) error {
+		room, err := uc.hub.LoadRoom(ctx, cmd.RoomID)
+		if err != nil {
+			return err
+		}
+
+		if err := room.SetPasscode(ctx, cmd.SenderID, cmd.Passcode); err != nil {
+			return err
+		}
+
+		if err := uc.hub.SaveRoom(ctx, room); err != nil {
+			return err
+		}
+
+		if err := uc.hub.BroadcastToRoom(ctx, room.ID, dto.NewRoomStateCommand(room)); err != nil {
+			return err
+		}
+
+		return nil
+	})
+}
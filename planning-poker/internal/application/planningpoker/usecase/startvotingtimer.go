@@ -0,0 +1,67 @@
+package usecase
+
+import (
+	"context"
+	"planning-poker/internal/application/lock"
+	"planning-poker/internal/application/planningpoker/usecase/dto"
+	"planning-poker/internal/application/timer"
+	"planning-poker/internal/domain"
+	"time"
+)
+
+type (
+	StartVotingTimerCommand struct {
+		RoomID   string
+		SenderID string
+		Duration time.Duration
+	}
+	StartVotingTimerUseCase struct {
+		hub         domain.Hub
+		lockManager lock.LockManager
+		deadlines   timer.DeadlineStore
+		clock       timer.Clock
+	}
+)
+
+var _ UseCase[StartVotingTimerCommand] = (*StartVotingTimerUseCase)(nil)
+
+func NewStartVotingTimerUseCase(
+	hub domain.Hub,
+	lockManager lock.LockManager,
+	deadlines timer.DeadlineStore,
+	clock timer.Clock,
+) StartVotingTimerUseCase {
+	return StartVotingTimerUseCase{
+		hub:         hub,
+		lockManager: lockManager,
+		deadlines:   deadlines,
+		clock:       clock,
+	}
+}
+
+func (uc StartVotingTimerUseCase) Execute(ctx context.Context, cmd StartVotingTimerCommand) error {
+	return uc.lockManager.ExecuteWithLock(ctx, cmd.RoomID, func(ctx context.Context) error {
+		room, err := uc.hub.LoadRoom(ctx, cmd.RoomID)
+		if err != nil {
+			return err
+		}
+
+		if err := room.StartVotingTimer(ctx, cmd.SenderID, uc.clock.Now(), cmd.Duration); err != nil {
+			return err
+		}
+
+		if err := uc.hub.SaveRoom(ctx, room); err != nil {
+			return err
+		}
+
+		if err := uc.deadlines.Schedule(ctx, room.ID, *room.VotingDeadline); err != nil {
+			return err
+		}
+
+		if err := uc.hub.BroadcastToRoom(ctx, room.ID, dto.NewRoomStateCommand(room)); err != nil {
+			return err
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,102 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"planning-poker/internal/application/lock"
+	"planning-poker/internal/application/timer"
+	"planning-poker/internal/domain"
+	"planning-poker/internal/domain/entity"
+	"planning-poker/internal/infra/boundaries/hub/clientcollection"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+)
+
+func TestStartVotingTimerUseCase_Execute_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockHub := domain.NewMockHub(ctrl)
+	mockLockManager := lock.NewMockLockManager(ctrl)
+	mockDeadlines := timer.NewMockDeadlineStore(ctrl)
+	mockClock := timer.NewMockClock(ctrl)
+
+	roomID := "room123"
+	clientID := "client123"
+	now := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	room := &entity.Room{
+		ID:      roomID,
+		Clients: clientcollection.New(),
+	}
+
+	client := room.NewClient(clientID)
+	client.IsOwner = true
+
+	mockLockManager.EXPECT().
+		ExecuteWithLock(gomock.Any(), roomID, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, key string, fn func(context.Context) error) error {
+			return fn(ctx)
+		})
+
+	mockClock.EXPECT().Now().Return(now)
+	mockHub.EXPECT().LoadRoom(ctx, roomID).Return(room, nil)
+	mockHub.EXPECT().SaveRoom(ctx, room).Return(nil)
+	mockDeadlines.EXPECT().Schedule(ctx, roomID, now.Add(time.Minute)).Return(nil)
+	mockHub.EXPECT().BroadcastToRoom(ctx, roomID, gomock.Any()).Return(nil)
+
+	uc := NewStartVotingTimerUseCase(mockHub, mockLockManager, mockDeadlines, mockClock)
+	cmd := StartVotingTimerCommand{
+		RoomID:   roomID,
+		SenderID: clientID,
+		Duration: time.Minute,
+	}
+
+	if err := uc.Execute(ctx, cmd); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}
+
+func TestStartVotingTimerUseCase_Execute_InvalidDuration(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockHub := domain.NewMockHub(ctrl)
+	mockLockManager := lock.NewMockLockManager(ctrl)
+	mockDeadlines := timer.NewMockDeadlineStore(ctrl)
+	mockClock := timer.NewMockClock(ctrl)
+
+	roomID := "room123"
+	clientID := "client123"
+	room := &entity.Room{
+		ID:      roomID,
+		Clients: clientcollection.New(),
+	}
+
+	client := room.NewClient(clientID)
+	client.IsOwner = true
+
+	mockLockManager.EXPECT().
+		ExecuteWithLock(gomock.Any(), roomID, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, key string, fn func(context.Context) error) error {
+			return fn(ctx)
+		})
+
+	mockClock.EXPECT().Now().Return(time.Now())
+	mockHub.EXPECT().LoadRoom(ctx, roomID).Return(room, nil)
+
+	uc := NewStartVotingTimerUseCase(mockHub, mockLockManager, mockDeadlines, mockClock)
+	cmd := StartVotingTimerCommand{
+		RoomID:   roomID,
+		SenderID: clientID,
+	}
+
+	err := uc.Execute(ctx, cmd)
+
+	if !errors.Is(err, domain.ErrInvalidTimerDuration) {
+		t.Errorf("expected ErrInvalidTimerDuration, got %v", err)
+	}
+}
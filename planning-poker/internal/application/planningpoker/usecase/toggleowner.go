@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"context"
+	"planning-poker/internal/application/audit"
 	"planning-poker/internal/application/lock"
 	"planning-poker/internal/application/planningpoker/usecase/dto"
 	"planning-poker/internal/domain"
@@ -16,24 +17,35 @@ type (
 	ToggleOwnerUseCase struct {
 		hub         domain.Hub
 		lockManager lock.LockManager
+		auditor     audit.Auditor
 	}
 )
 
 var _ UseCase[ToggleOwnerCommand] = (*ToggleOwnerUseCase)(nil)
 
-func NewToggleOwnerUseCase(hub domain.Hub, lockManager lock.LockManager) ToggleOwnerUseCase {
+func NewToggleOwnerUseCase(hub domain.Hub, lockManager lock.LockManager, auditor audit.Auditor) ToggleOwnerUseCase {
 	return ToggleOwnerUseCase{
 		hub:         hub,
 		lockManager: lockManager,
+		auditor:     auditor,
 	}
 }
 
-func (uc ToggleOwnerUseCase) Execute(ctx context.Context, cmd ToggleOwnerCommand) error {
+func (uc ToggleOwnerUseCase) Execute(ctx context.Context, cmd ToggleOwnerCommand) (err error) {
+	record := audit.Record{
+		RoomID: cmd.RoomID,
+		Action: audit.ActionToggleOwner,
+		Actor:  cmd.SenderID,
+		Target: cmd.TargetClientID,
+	}
+	defer func() { uc.auditor.Record(ctx, record, err) }()
+
 	return uc.lockManager.ExecuteWithLock(ctx, cmd.RoomID, func(ctx context.Context) error {
 		room, err := uc.hub.LoadRoom(ctx, cmd.RoomID)
 		if err != nil {
 			return err
 		}
+		withClientNames(&record, room)
 
 		if err := room.ToggleOwner(ctx, cmd.SenderID, cmd.TargetClientID); err != nil {
 			return err
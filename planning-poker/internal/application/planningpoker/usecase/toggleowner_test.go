@@ -3,6 +3,7 @@ package usecase
 import (
 	"context"
 	"errors"
+	"planning-poker/internal/application/audit"
 	"planning-poker/internal/application/lock"
 	"planning-poker/internal/domain"
 	"planning-poker/internal/domain/entity"
@@ -19,7 +20,7 @@ func TestNewToggleOwnerUseCase(t *testing.T) {
 	mockHub := domain.NewMockHub(ctrl)
 	mockLockManager := lock.NewMockLockManager(ctrl)
 
-	uc := NewToggleOwnerUseCase(mockHub, mockLockManager)
+	uc := NewToggleOwnerUseCase(mockHub, mockLockManager, audit.Discard)
 
 	if uc.hub != mockHub {
 		t.Error("hub not set correctly")
@@ -57,7 +58,7 @@ func TestToggleOwnerUseCase_Execute_Success(t *testing.T) {
 	mockHub.EXPECT().SaveRoom(ctx, room).Return(nil)
 	mockHub.EXPECT().BroadcastToRoom(ctx, roomID, gomock.Any()).Return(nil)
 
-	uc := NewToggleOwnerUseCase(mockHub, mockLockManager)
+	uc := NewToggleOwnerUseCase(mockHub, mockLockManager, audit.Discard)
 	cmd := ToggleOwnerCommand{
 		RoomID:         roomID,
 		TargetClientID: targetID,
@@ -101,7 +102,7 @@ func TestToggleOwnerUseCase_Execute_SaveRoomError(t *testing.T) {
 	mockHub.EXPECT().LoadRoom(ctx, roomID).Return(room, nil)
 	mockHub.EXPECT().SaveRoom(ctx, room).Return(expectedError)
 
-	uc := NewToggleOwnerUseCase(mockHub, mockLockManager)
+	uc := NewToggleOwnerUseCase(mockHub, mockLockManager, audit.Discard)
 	cmd := ToggleOwnerCommand{
 		RoomID:         roomID,
 		SenderID:       senderID,
@@ -136,7 +137,7 @@ func TestToggleOwnerUseCase_Execute_RoomNotFound(t *testing.T) {
 
 	mockHub.EXPECT().LoadRoom(ctx, roomID).Return(nil, domain.ErrRoomNotFound)
 
-	uc := NewToggleOwnerUseCase(mockHub, mockLockManager)
+	uc := NewToggleOwnerUseCase(mockHub, mockLockManager, audit.Discard)
 	cmd := ToggleOwnerCommand{
 		RoomID:         roomID,
 		TargetClientID: "client123",
@@ -185,7 +186,7 @@ func TestToggleOwnerUseCase_Execute_BroadcastError(t *testing.T) {
 	mockHub.EXPECT().SaveRoom(ctx, room).Return(nil)
 	mockHub.EXPECT().BroadcastToRoom(ctx, roomID, gomock.Any()).Return(expectedError)
 
-	uc := NewToggleOwnerUseCase(mockHub, mockLockManager)
+	uc := NewToggleOwnerUseCase(mockHub, mockLockManager, audit.Discard)
 	cmd := ToggleOwnerCommand{
 		RoomID:         roomID,
 		TargetClientID: targetID,
@@ -201,3 +202,54 @@ func TestToggleOwnerUseCase_Execute_BroadcastError(t *testing.T) {
 		t.Errorf("expected error %v, got %v", expectedError, err)
 	}
 }
+
+func TestToggleOwnerUseCase_Execute_RecordsAudit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockHub := domain.NewMockHub(ctrl)
+	mockLockManager := lock.NewMockLockManager(ctrl)
+	mockAuditor := audit.NewMockAuditor(ctrl)
+
+	room := &entity.Room{ID: "room123", Clients: clientcollection.New()}
+	room.NewClient("sender123").Name = "Alice"
+	room.NewClient("client123").Name = "Bob"
+
+	mockLockManager.EXPECT().
+		ExecuteWithLock(gomock.Any(), "room123", gomock.Any()).
+		DoAndReturn(func(ctx context.Context, key string, fn func(context.Context) error) error {
+			return fn(ctx)
+		}).
+		Times(2)
+	mockHub.EXPECT().LoadRoom(ctx, "room123").Return(room, nil).Times(2)
+	mockHub.EXPECT().SaveRoom(ctx, room).Return(nil).Times(1)
+	mockHub.EXPECT().BroadcastToRoom(ctx, "room123", gomock.Any()).Return(nil)
+
+	expected := audit.Record{
+		RoomID:     "room123",
+		Action:     audit.ActionToggleOwner,
+		Actor:      "sender123",
+		ActorName:  "Alice",
+		Target:     "client123",
+		TargetName: "Bob",
+	}
+	gomock.InOrder(
+		mockAuditor.EXPECT().Record(ctx, expected, nil),
+		mockAuditor.EXPECT().Record(ctx, audit.Record{
+			RoomID:    "room123",
+			Action:    audit.ActionToggleOwner,
+			Actor:     "sender123",
+			ActorName: "Alice",
+			Target:    "unknown",
+		}, gomock.Not(nil)),
+	)
+
+	uc := NewToggleOwnerUseCase(mockHub, mockLockManager, mockAuditor)
+	if err := uc.Execute(ctx, ToggleOwnerCommand{RoomID: "room123", SenderID: "sender123", TargetClientID: "client123"}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if err := uc.Execute(ctx, ToggleOwnerCommand{RoomID: "room123", SenderID: "sender123", TargetClientID: "unknown"}); err == nil {
+		t.Fatal("expected an error")
+	}
+}
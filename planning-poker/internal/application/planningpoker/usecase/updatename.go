@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"context"
+	"planning-poker/internal/application/lock"
 	"planning-poker/internal/application/planningpoker/usecase/dto"
 	"planning-poker/internal/domain"
 )
@@ -13,35 +14,39 @@ type (
 		Username string
 	}
 	UpdateNameUseCase struct {
-		hub domain.Hub
+		hub         domain.Hub
+		lockManager lock.LockManager
 	}
 )
 
 var _ UseCase[UpdateNameCommand] = (*UpdateNameUseCase)(nil)
 
-func NewUpdateNameUseCase(hub domain.Hub) UpdateNameUseCase {
+func NewUpdateNameUseCase(hub domain.Hub, lockManager lock.LockManager) UpdateNameUseCase {
 	return UpdateNameUseCase{
-		hub: hub,
+		hub:         hub,
+		lockManager: lockManager,
 	}
 }
 
 func (uc UpdateNameUseCase) Execute(ctx context.Context, cmd UpdateNameCommand) error {
-	room, err := uc.hub.LoadRoom(ctx, cmd.RoomID)
-	if err != nil {
-		return err
-	}
-
-	if err := room.UpdateClientName(ctx, cmd.SenderID, cmd.Username); err != nil {
-		return err
-	}
-
-	if err := uc.hub.SaveRoom(ctx, room); err != nil {
-		return err
-	}
-
-	if err := uc.hub.BroadcastToRoom(ctx, room.ID, dto.NewRoomStateCommand(room)); err != nil {
-		return err
-	}
-
-	return nil
+	return uc.lockManager.ExecuteWithLock(ctx, cmd.RoomID, func(ctx context.Context) error {
+		room, err := uc.hub.LoadRoom(ctx, cmd.RoomID)
+		if err != nil {
+			return err
+		}
+
+		if err := room.UpdateClientName(ctx, cmd.SenderID, cmd.Username); err != nil {
+			return err
+		}
+
+		if err := uc.hub.SaveRoom(ctx, room); err != nil {
+			return err
+		}
+
+		if err := uc.hub.BroadcastToRoom(ctx, room.ID, dto.NewRoomStateCommand(room)); err != nil {
+			return err
+		}
+
+		return nil
+	})
 }
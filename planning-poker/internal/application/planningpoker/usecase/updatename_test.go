@@ -3,6 +3,7 @@ package usecase
 import (
 	"context"
 	"errors"
+	"planning-poker/internal/application/lock"
 	"planning-poker/internal/domain"
 	"planning-poker/internal/domain/entity"
 	"planning-poker/internal/infra/boundaries/hub/clientcollection"
@@ -16,12 +17,16 @@ func TestNewUpdateNameUseCase(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockHub := domain.NewMockHub(ctrl)
+	mockLockManager := lock.NewMockLockManager(ctrl)
 
-	uc := NewUpdateNameUseCase(mockHub)
+	uc := NewUpdateNameUseCase(mockHub, mockLockManager)
 
 	if uc.hub != mockHub {
 		t.Error("hub not set correctly")
 	}
+	if uc.lockManager != mockLockManager {
+		t.Error("lockManager not set correctly")
+	}
 }
 
 func TestUpdateNameUseCase_Execute_Success(t *testing.T) {
@@ -45,7 +50,7 @@ func TestUpdateNameUseCase_Execute_Success(t *testing.T) {
 	mockHub.EXPECT().SaveRoom(ctx, room).Return(nil)
 	mockHub.EXPECT().BroadcastToRoom(ctx, roomID, gomock.Any()).Return(nil)
 
-	uc := NewUpdateNameUseCase(mockHub)
+	uc := NewUpdateNameUseCase(mockHub, newUpdateNameLockManager(ctrl, roomID))
 	cmd := UpdateNameCommand{
 		RoomID:   roomID,
 		SenderID: senderID,
@@ -80,7 +85,7 @@ func TestUpdateNameUseCase_Execute_SaveRoomError(t *testing.T) {
 	mockHub.EXPECT().LoadRoom(ctx, roomID).Return(room, nil)
 	mockHub.EXPECT().SaveRoom(ctx, room).Return(expectedError)
 
-	uc := NewUpdateNameUseCase(mockHub)
+	uc := NewUpdateNameUseCase(mockHub, newUpdateNameLockManager(ctrl, roomID))
 	cmd := UpdateNameCommand{
 		RoomID:   roomID,
 		SenderID: senderID,
@@ -108,7 +113,7 @@ func TestUpdateNameUseCase_Execute_RoomNotFound(t *testing.T) {
 
 	mockHub.EXPECT().LoadRoom(ctx, roomID).Return(nil, domain.ErrRoomNotFound)
 
-	uc := NewUpdateNameUseCase(mockHub)
+	uc := NewUpdateNameUseCase(mockHub, newUpdateNameLockManager(ctrl, roomID))
 	cmd := UpdateNameCommand{
 		RoomID:   roomID,
 		SenderID: "client123",
@@ -146,7 +151,7 @@ func TestUpdateNameUseCase_Execute_BroadcastError(t *testing.T) {
 	mockHub.EXPECT().SaveRoom(ctx, room).Return(nil)
 	mockHub.EXPECT().BroadcastToRoom(ctx, roomID, gomock.Any()).Return(expectedError)
 
-	uc := NewUpdateNameUseCase(mockHub)
+	uc := NewUpdateNameUseCase(mockHub, newUpdateNameLockManager(ctrl, roomID))
 	cmd := UpdateNameCommand{
 		RoomID:   roomID,
 		SenderID: senderID,
@@ -162,3 +167,13 @@ func TestUpdateNameUseCase_Execute_BroadcastError(t *testing.T) {
 		t.Errorf("expected error %v, got %v", expectedError, err)
 	}
 }
+
+func newUpdateNameLockManager(ctrl *gomock.Controller, roomID string) *lock.MockLockManager {
+	mockLockManager := lock.NewMockLockManager(ctrl)
+	mockLockManager.EXPECT().
+		ExecuteWithLock(gomock.Any(), roomID, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, key string, fn func(context.Context) error) error {
+			return fn(ctx)
+		})
+	return mockLockManager
+}
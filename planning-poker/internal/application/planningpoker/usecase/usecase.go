@@ -1,6 +1,10 @@
 package usecase
 
-import "context"
+import (
+	"context"
+	"fmt"
+	"planning-poker/internal/domain"
+)
 
 type (
 	// Represents a Use Case that does not return a result.
@@ -18,3 +22,16 @@ type (
 		Execute(ctx context.Context) (Out, error)
 	}
 )
+
+// replyBus returns the bus answering the sender of a command: the one the
+// command names, otherwise the bus of the sender's connection.
+func replyBus(hub domain.Hub, replyTo domain.Bus, senderID string) (domain.Bus, error) {
+	if replyTo != nil {
+		return replyTo, nil
+	}
+	bus, ok := hub.GetBus(senderID)
+	if !ok {
+		return nil, fmt.Errorf("bus not found for client %s", senderID)
+	}
+	return bus, nil
+}
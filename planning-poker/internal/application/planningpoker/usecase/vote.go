@@ -4,6 +4,7 @@ import (
 	"context"
 	"planning-poker/internal/application/lock"
 	"planning-poker/internal/application/planningpoker/usecase/dto"
+	"planning-poker/internal/application/webhook"
 	"planning-poker/internal/domain"
 )
 
@@ -16,15 +17,17 @@ type (
 	voteUseCase struct {
 		hub         domain.Hub
 		lockManager lock.LockManager
+		webhooks    webhook.Publisher
 	}
 )
 
 var _ UseCase[VoteCommand] = (*voteUseCase)(nil)
 
-func NewVoteUseCase(hub domain.Hub, lockManager lock.LockManager) *voteUseCase {
+func NewVoteUseCase(hub domain.Hub, lockManager lock.LockManager, webhooks webhook.Publisher) *voteUseCase {
 	return &voteUseCase{
 		hub:         hub,
 		lockManager: lockManager,
+		webhooks:    webhooks,
 	}
 }
 
@@ -35,6 +38,7 @@ func (uc *voteUseCase) Execute(ctx context.Context, cmd VoteCommand) error {
 			return err
 		}
 
+		revealed := room.Reveal
 		if err := room.Vote(ctx, cmd.SenderID, cmd.Vote); err != nil {
 			return err
 		}
@@ -46,6 +50,10 @@ func (uc *voteUseCase) Execute(ctx context.Context, cmd VoteCommand) error {
 		if err := uc.hub.BroadcastToRoom(ctx, room.ID, dto.NewRoomStateCommand(room)); err != nil {
 			return err
 		}
+		// the last vote reveals the votes
+		if !revealed && room.Reveal {
+			uc.webhooks.Publish(ctx, webhook.NewStoryRevealedEvent(room))
+		}
 
 		return nil
 	})
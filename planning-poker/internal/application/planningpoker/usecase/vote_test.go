@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"planning-poker/internal/application/lock"
+	"planning-poker/internal/application/webhook"
 	"planning-poker/internal/domain"
 	"planning-poker/internal/domain/entity"
 	"planning-poker/internal/infra/boundaries/hub/clientcollection"
@@ -19,7 +20,7 @@ func TestNewVoteUseCase(t *testing.T) {
 	mockHub := domain.NewMockHub(ctrl)
 	mockLockManager := lock.NewMockLockManager(ctrl)
 
-	uc := NewVoteUseCase(mockHub, mockLockManager)
+	uc := NewVoteUseCase(mockHub, mockLockManager, webhook.Discard)
 
 	if uc.hub != mockHub {
 		t.Error("hub not set correctly")
@@ -54,7 +55,15 @@ func TestVoteUseCase_Execute_Success(t *testing.T) {
 	mockHub.EXPECT().SaveRoom(ctx, room).Return(nil)
 	mockHub.EXPECT().BroadcastToRoom(ctx, roomID, gomock.Any()).Return(nil)
 
-	uc := NewVoteUseCase(mockHub, mockLockManager)
+	// the only voter reveals the votes
+	mockWebhooks := webhook.NewMockPublisher(ctrl)
+	mockWebhooks.EXPECT().Publish(ctx, gomock.Any()).Do(func(_ context.Context, event webhook.Event) {
+		if event.Type != webhook.EventStoryRevealed || event.RoomID != roomID {
+			t.Errorf("unexpected event %+v", event)
+		}
+	})
+
+	uc := NewVoteUseCase(mockHub, mockLockManager, mockWebhooks)
 	cmd := VoteCommand{
 		RoomID:   roomID,
 		SenderID: senderID,
@@ -96,7 +105,7 @@ func TestVoteUseCase_Execute_SaveRoomError(t *testing.T) {
 	mockHub.EXPECT().LoadRoom(ctx, roomID).Return(room, nil)
 	mockHub.EXPECT().SaveRoom(ctx, room).Return(expectedError)
 
-	uc := NewVoteUseCase(mockHub, mockLockManager)
+	uc := NewVoteUseCase(mockHub, mockLockManager, webhook.Discard)
 	cmd := VoteCommand{
 		RoomID:   roomID,
 		SenderID: senderID,
@@ -131,7 +140,7 @@ func TestVoteUseCase_Execute_RoomNotFound(t *testing.T) {
 
 	mockHub.EXPECT().LoadRoom(ctx, roomID).Return(nil, domain.ErrRoomNotFound)
 
-	uc := NewVoteUseCase(mockHub, mockLockManager)
+	uc := NewVoteUseCase(mockHub, mockLockManager, webhook.Discard)
 	cmd := VoteCommand{
 		RoomID:   roomID,
 		SenderID: "client123",
@@ -177,7 +186,7 @@ func TestVoteUseCase_Execute_BroadcastError(t *testing.T) {
 	mockHub.EXPECT().SaveRoom(ctx, room).Return(nil)
 	mockHub.EXPECT().BroadcastToRoom(ctx, roomID, gomock.Any()).Return(expectedError)
 
-	uc := NewVoteUseCase(mockHub, mockLockManager)
+	uc := NewVoteUseCase(mockHub, mockLockManager, webhook.Discard)
 	cmd := VoteCommand{
 		RoomID:   roomID,
 		SenderID: senderID,
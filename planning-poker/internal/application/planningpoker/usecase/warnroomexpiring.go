@@ -0,0 +1,32 @@
+package usecase
+
+import (
+	"context"
+	"planning-poker/internal/application/planningpoker/usecase/dto"
+	"planning-poker/internal/domain"
+	"planning-poker/internal/domain/entity"
+	"time"
+)
+
+type (
+	WarnRoomExpiringCommand struct {
+		RoomID    string
+		Reason    entity.ExpiryReason
+		ExpiresAt time.Time
+	}
+	// WarnRoomExpiringUseCase tells the clients of a room when it is going to
+	// be closed, so they can keep it alive or wrap up.
+	WarnRoomExpiringUseCase struct {
+		hub domain.Hub
+	}
+)
+
+var _ UseCase[WarnRoomExpiringCommand] = (*WarnRoomExpiringUseCase)(nil)
+
+func NewWarnRoomExpiringUseCase(hub domain.Hub) WarnRoomExpiringUseCase {
+	return WarnRoomExpiringUseCase{hub: hub}
+}
+
+func (uc WarnRoomExpiringUseCase) Execute(ctx context.Context, cmd WarnRoomExpiringCommand) error {
+	return uc.hub.BroadcastToRoom(ctx, cmd.RoomID, dto.NewRoomExpiringNotification(cmd.Reason, cmd.ExpiresAt))
+}
@@ -0,0 +1,3 @@
+package ratelimit
+
+//go:generate go tool mockgen -destination mocks.go -typed -package ratelimit . Limiter
@@ -0,0 +1,80 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: planning-poker/internal/application/ratelimit (interfaces: Limiter)
+//
+// Generated by this command:
+//
+//	mockgen -destination mocks.go -typed -package ratelimit . Limiter
+//
+
+// Package ratelimit is a generated GoMock package.
+package ratelimit
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockLimiter is a mock of Limiter interface.
+type MockLimiter struct {
+	ctrl     *gomock.Controller
+	recorder *MockLimiterMockRecorder
+	isgomock struct{}
+}
+
+// MockLimiterMockRecorder is the mock recorder for MockLimiter.
+type MockLimiterMockRecorder struct {
+	mock *MockLimiter
+}
+
+// NewMockLimiter creates a new mock instance.
+func NewMockLimiter(ctrl *gomock.Controller) *MockLimiter {
+	mock := &MockLimiter{ctrl: ctrl}
+	mock.recorder = &MockLimiterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLimiter) EXPECT() *MockLimiterMockRecorder {
+	return m.recorder
+}
+
+// Allow mocks base method.
+func (m *MockLimiter) Allow(ctx context.Context, key string, limit Limit) (Decision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Allow", ctx, key, limit)
+	ret0, _ := ret[0].(Decision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Allow indicates an expected call of Allow.
+func (mr *MockLimiterMockRecorder) Allow(ctx, key, limit any) *MockLimiterAllowCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Allow", reflect.TypeOf((*MockLimiter)(nil).Allow), ctx, key, limit)
+	return &MockLimiterAllowCall{Call: call}
+}
+
+// MockLimiterAllowCall wrap *gomock.Call
+type MockLimiterAllowCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockLimiterAllowCall) Return(arg0 Decision, arg1 error) *MockLimiterAllowCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockLimiterAllowCall) Do(f func(context.Context, string, Limit) (Decision, error)) *MockLimiterAllowCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockLimiterAllowCall) DoAndReturn(f func(context.Context, string, Limit) (Decision, error)) *MockLimiterAllowCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
@@ -0,0 +1,37 @@
+package ratelimit
+
+import (
+	"context"
+	"time"
+)
+
+// Limit is a token bucket holding Burst tokens and refilled with Rate tokens
+// per minute. Every event takes a token.
+type Limit struct {
+	Rate  int
+	Burst int
+}
+
+// Enabled tells whether the limit lets anything through at all. A zero rate
+// or burst disables it.
+func (l Limit) Enabled() bool {
+	return l.Rate > 0 && l.Burst > 0
+}
+
+// Interval is the time it takes to refill one token.
+func (l Limit) Interval() time.Duration {
+	return time.Minute / time.Duration(l.Rate)
+}
+
+// Decision is the outcome of taking a token. RetryAfter is when the next
+// token is available, when the event was rejected.
+type Decision struct {
+	Allowed    bool
+	RetryAfter time.Duration
+}
+
+// Limiter takes tokens from the bucket of key. Buckets are created full and
+// forgotten once full again.
+type Limiter interface {
+	Allow(ctx context.Context, key string, limit Limit) (Decision, error)
+}
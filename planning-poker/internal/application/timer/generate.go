@@ -0,0 +1,3 @@
+package timer
+
+//go:generate go tool mockgen -destination mocks.go -typed -package timer . Clock,DeadlineStore
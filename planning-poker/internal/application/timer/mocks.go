@@ -0,0 +1,219 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: planning-poker/internal/application/timer (interfaces: Clock,DeadlineStore)
+//
+// Generated by this command:
+//
+//	mockgen -destination mocks.go -typed -package timer . Clock,DeadlineStore
+//
+
+// Package timer is a generated GoMock package.
+package timer
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockClock is a mock of Clock interface.
+type MockClock struct {
+	ctrl     *gomock.Controller
+	recorder *MockClockMockRecorder
+	isgomock struct{}
+}
+
+// MockClockMockRecorder is the mock recorder for MockClock.
+type MockClockMockRecorder struct {
+	mock *MockClock
+}
+
+// NewMockClock creates a new mock instance.
+func NewMockClock(ctrl *gomock.Controller) *MockClock {
+	mock := &MockClock{ctrl: ctrl}
+	mock.recorder = &MockClockMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockClock) EXPECT() *MockClockMockRecorder {
+	return m.recorder
+}
+
+// Now mocks base method.
+func (m *MockClock) Now() time.Time {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Now")
+	ret0, _ := ret[0].(time.Time)
+	return ret0
+}
+
+// Now indicates an expected call of Now.
+func (mr *MockClockMockRecorder) Now() *MockClockNowCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Now", reflect.TypeOf((*MockClock)(nil).Now))
+	return &MockClockNowCall{Call: call}
+}
+
+// MockClockNowCall wrap *gomock.Call
+type MockClockNowCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockClockNowCall) Return(arg0 time.Time) *MockClockNowCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockClockNowCall) Do(f func() time.Time) *MockClockNowCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockClockNowCall) DoAndReturn(f func() time.Time) *MockClockNowCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockDeadlineStore is a mock of DeadlineStore interface.
+type MockDeadlineStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockDeadlineStoreMockRecorder
+	isgomock struct{}
+}
+
+// MockDeadlineStoreMockRecorder is the mock recorder for MockDeadlineStore.
+type MockDeadlineStoreMockRecorder struct {
+	mock *MockDeadlineStore
+}
+
+// NewMockDeadlineStore creates a new mock instance.
+func NewMockDeadlineStore(ctrl *gomock.Controller) *MockDeadlineStore {
+	mock := &MockDeadlineStore{ctrl: ctrl}
+	mock.recorder = &MockDeadlineStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDeadlineStore) EXPECT() *MockDeadlineStoreMockRecorder {
+	return m.recorder
+}
+
+// Cancel mocks base method.
+func (m *MockDeadlineStore) Cancel(ctx context.Context, roomID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Cancel", ctx, roomID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Cancel indicates an expected call of Cancel.
+func (mr *MockDeadlineStoreMockRecorder) Cancel(ctx, roomID any) *MockDeadlineStoreCancelCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Cancel", reflect.TypeOf((*MockDeadlineStore)(nil).Cancel), ctx, roomID)
+	return &MockDeadlineStoreCancelCall{Call: call}
+}
+
+// MockDeadlineStoreCancelCall wrap *gomock.Call
+type MockDeadlineStoreCancelCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockDeadlineStoreCancelCall) Return(arg0 error) *MockDeadlineStoreCancelCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockDeadlineStoreCancelCall) Do(f func(context.Context, string) error) *MockDeadlineStoreCancelCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockDeadlineStoreCancelCall) DoAndReturn(f func(context.Context, string) error) *MockDeadlineStoreCancelCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Due mocks base method.
+func (m *MockDeadlineStore) Due(ctx context.Context, now time.Time) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Due", ctx, now)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Due indicates an expected call of Due.
+func (mr *MockDeadlineStoreMockRecorder) Due(ctx, now any) *MockDeadlineStoreDueCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Due", reflect.TypeOf((*MockDeadlineStore)(nil).Due), ctx, now)
+	return &MockDeadlineStoreDueCall{Call: call}
+}
+
+// MockDeadlineStoreDueCall wrap *gomock.Call
+type MockDeadlineStoreDueCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockDeadlineStoreDueCall) Return(arg0 []string, arg1 error) *MockDeadlineStoreDueCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockDeadlineStoreDueCall) Do(f func(context.Context, time.Time) ([]string, error)) *MockDeadlineStoreDueCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockDeadlineStoreDueCall) DoAndReturn(f func(context.Context, time.Time) ([]string, error)) *MockDeadlineStoreDueCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Schedule mocks base method.
+func (m *MockDeadlineStore) Schedule(ctx context.Context, roomID string, deadline time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Schedule", ctx, roomID, deadline)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Schedule indicates an expected call of Schedule.
+func (mr *MockDeadlineStoreMockRecorder) Schedule(ctx, roomID, deadline any) *MockDeadlineStoreScheduleCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Schedule", reflect.TypeOf((*MockDeadlineStore)(nil).Schedule), ctx, roomID, deadline)
+	return &MockDeadlineStoreScheduleCall{Call: call}
+}
+
+// MockDeadlineStoreScheduleCall wrap *gomock.Call
+type MockDeadlineStoreScheduleCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockDeadlineStoreScheduleCall) Return(arg0 error) *MockDeadlineStoreScheduleCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockDeadlineStoreScheduleCall) Do(f func(context.Context, string, time.Time) error) *MockDeadlineStoreScheduleCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockDeadlineStoreScheduleCall) DoAndReturn(f func(context.Context, string, time.Time) error) *MockDeadlineStoreScheduleCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
@@ -0,0 +1,28 @@
+package timer
+
+import (
+	"context"
+	"time"
+)
+
+type (
+	Clock interface {
+		Now() time.Time
+	}
+
+	// DeadlineStore keeps the voting deadlines of every room, so that any
+	// instance can find the rooms whose timer has expired.
+	DeadlineStore interface {
+		Schedule(ctx context.Context, roomID string, deadline time.Time) error
+		Cancel(ctx context.Context, roomID string) error
+		Due(ctx context.Context, now time.Time) ([]string, error)
+	}
+
+	SystemClock struct{}
+)
+
+var _ Clock = SystemClock{}
+
+func (SystemClock) Now() time.Time {
+	return time.Now()
+}
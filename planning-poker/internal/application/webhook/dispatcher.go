@@ -0,0 +1,222 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"planning-poker/internal/application/timer"
+	"sync"
+	"time"
+
+	"github.com/bruno303/go-toolkit/pkg/log"
+	"github.com/google/uuid"
+)
+
+const (
+	defaultWorkers        = 4
+	defaultQueueSize      = 1000
+	defaultMaxAttempts    = 5
+	defaultInitialBackoff = time.Second
+	defaultMaxBackoff     = 5 * time.Minute
+)
+
+var errQueueFull = errors.New("delivery queue is full")
+
+type (
+	DispatcherConfig struct {
+		Workers   int
+		QueueSize int
+		// MaxAttempts counts the first attempt, a delivery failing all of them
+		// becomes a dead letter
+		MaxAttempts int
+		// InitialBackoff is the delay before the first retry, doubling for
+		// every retry after it up to MaxBackoff
+		InitialBackoff time.Duration
+		MaxBackoff     time.Duration
+	}
+
+	// Dispatcher delivers the events published by the instance to the
+	// subscriptions matching them. Retries wait off the workers, so that a
+	// failing endpoint does not hold up the others. Deliveries still queued
+	// or waiting for a retry when the instance stops are lost.
+	Dispatcher struct {
+		store  Store
+		sender Sender
+		clock  timer.Clock
+		cfg    DispatcherConfig
+		queue  chan delivery
+		logger log.Logger
+
+		// retries are the timers of the deliveries waiting for a retry,
+		// stopped when the dispatcher stops
+		mu      sync.Mutex
+		retries map[*time.Timer]struct{}
+		stopped bool
+	}
+
+	delivery struct {
+		subscription Subscription
+		event        Event
+		attempts     int
+	}
+)
+
+var _ Publisher = (*Dispatcher)(nil)
+
+func NewDispatcher(store Store, sender Sender, clock timer.Clock, cfg DispatcherConfig) *Dispatcher {
+	if cfg.Workers <= 0 {
+		cfg.Workers = defaultWorkers
+	}
+	if cfg.QueueSize <= 0 {
+		cfg.QueueSize = defaultQueueSize
+	}
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = defaultMaxAttempts
+	}
+	if cfg.InitialBackoff <= 0 {
+		cfg.InitialBackoff = defaultInitialBackoff
+	}
+	if cfg.MaxBackoff <= 0 {
+		cfg.MaxBackoff = defaultMaxBackoff
+	}
+
+	return &Dispatcher{
+		store:   store,
+		sender:  sender,
+		clock:   clock,
+		cfg:     cfg,
+		queue:   make(chan delivery, cfg.QueueSize),
+		logger:  log.NewLogger("webhook.dispatcher"),
+		retries: make(map[*time.Timer]struct{}),
+	}
+}
+
+// Publish queues a delivery of the event for every subscription matching it.
+// The subscriptions of a room end with its session, once the session.ended
+// event is queued.
+func (d *Dispatcher) Publish(ctx context.Context, event Event) {
+	event.ID = uuid.NewString()
+	event.OccurredAt = d.clock.Now().UTC()
+
+	subscriptions, err := d.store.Subscriptions(ctx)
+	if err != nil {
+		d.logger.Error(ctx, "Failed to load webhook subscriptions for event "+string(event.Type), err)
+		return
+	}
+
+	for _, subscription := range subscriptions {
+		if subscription.Matches(event) {
+			d.enqueue(ctx, delivery{subscription: subscription, event: event})
+		}
+		if event.Type == EventSessionEnded && subscription.RoomID != "" && subscription.RoomID == event.RoomID {
+			if err := d.store.RemoveSubscription(ctx, subscription.ID); err != nil {
+				d.logger.Error(ctx, "Failed to remove webhook subscription "+subscription.ID, err)
+			}
+		}
+	}
+}
+
+// Run delivers the queued events until the context is cancelled.
+func (d *Dispatcher) Run(ctx context.Context) {
+	d.logger.Info(ctx, "Webhook dispatcher started with %d workers", d.cfg.Workers)
+
+	var wg sync.WaitGroup
+	for range d.cfg.Workers {
+		wg.Go(func() {
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case delivery := <-d.queue:
+					d.deliver(ctx, delivery)
+				}
+			}
+		})
+	}
+	wg.Wait()
+
+	d.mu.Lock()
+	d.stopped = true
+	for retry := range d.retries {
+		retry.Stop()
+	}
+	d.mu.Unlock()
+
+	d.logger.Info(ctx, "Webhook dispatcher stopped")
+}
+
+func (d *Dispatcher) deliver(ctx context.Context, delivery delivery) {
+	delivery.attempts++
+	err := d.sender.Send(ctx, delivery.subscription, delivery.event)
+	if err == nil {
+		return
+	}
+	if ctx.Err() != nil {
+		return
+	}
+
+	if delivery.attempts >= d.cfg.MaxAttempts {
+		d.deadLetter(ctx, delivery, err)
+		return
+	}
+
+	backoff := d.backoff(delivery.attempts)
+	d.logger.Warn(ctx, "Delivery of %s to webhook %s failed on attempt %d, retrying in %s: %v",
+		delivery.event.ID, delivery.subscription.ID, delivery.attempts, backoff, err)
+	d.retryAfter(ctx, backoff, delivery)
+}
+
+// backoff is the delay before the retry following the given attempt.
+func (d *Dispatcher) backoff(attempts int) time.Duration {
+	backoff := d.cfg.InitialBackoff
+	for range attempts - 1 {
+		backoff *= 2
+		if backoff >= d.cfg.MaxBackoff {
+			return d.cfg.MaxBackoff
+		}
+	}
+	return backoff
+}
+
+func (d *Dispatcher) retryAfter(ctx context.Context, backoff time.Duration, delivery delivery) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.stopped {
+		return
+	}
+
+	var retry *time.Timer
+	retry = time.AfterFunc(backoff, func() {
+		d.mu.Lock()
+		delete(d.retries, retry)
+		d.mu.Unlock()
+
+		d.enqueue(context.WithoutCancel(ctx), delivery)
+	})
+	d.retries[retry] = struct{}{}
+}
+
+// enqueue never blocks, deliveries that do not fit become dead letters.
+func (d *Dispatcher) enqueue(ctx context.Context, delivery delivery) {
+	select {
+	case d.queue <- delivery:
+	default:
+		d.deadLetter(ctx, delivery, errQueueFull)
+	}
+}
+
+func (d *Dispatcher) deadLetter(ctx context.Context, delivery delivery, err error) {
+	d.logger.Warn(ctx, "Giving up delivery of %s to webhook %s after %d attempts: %v",
+		delivery.event.ID, delivery.subscription.ID, delivery.attempts, err)
+
+	deadLetter := DeadLetter{
+		SubscriptionID: delivery.subscription.ID,
+		URL:            delivery.subscription.URL,
+		Event:          delivery.event,
+		Attempts:       delivery.attempts,
+		Error:          err.Error(),
+		FailedAt:       d.clock.Now().UTC(),
+	}
+	if err := d.store.AddDeadLetter(context.WithoutCancel(ctx), deadLetter); err != nil {
+		d.logger.Error(ctx, "Failed to store webhook dead letter", err)
+	}
+}
@@ -0,0 +1,80 @@
+package webhook
+
+import (
+	"planning-poker/internal/application/planningpoker/report"
+	"planning-poker/internal/domain/entity"
+	"slices"
+	"strconv"
+
+	"github.com/samber/lo"
+)
+
+type (
+	StoryRevealedData struct {
+		Story string `json:"story"`
+		// Result is the average of the votes, only on numeric decks
+		Result             *float32 `json:"result,omitempty"`
+		MostAppearingVotes []string `json:"mostAppearingVotes"`
+		Votes              []Vote   `json:"votes"`
+	}
+	Vote struct {
+		Participant string `json:"participant"`
+		Vote        string `json:"vote"`
+	}
+	StoryAddedData struct {
+		Story string `json:"story"`
+		Index int    `json:"index"`
+	}
+	SessionEndedData struct {
+		Reason string `json:"reason"`
+		// Report holds the backlog of the session with the result of each
+		// story, as exported by the participants
+		Report report.Report `json:"report"`
+	}
+)
+
+// NewStoryRevealedEvent describes the votes of the current story of a room
+// that was just revealed.
+func NewStoryRevealedEvent(room *entity.Room) Event {
+	deck := room.EffectiveDeck()
+
+	data := StoryRevealedData{
+		Story: room.EffectiveCurrentStory(),
+		MostAppearingVotes: lo.Map(slices.Sorted(slices.Values(room.MostAppearingVotes)), func(score int, _ int) string {
+			if card, ok := deck.Card(score); ok {
+				return card
+			}
+			return strconv.Itoa(score)
+		}),
+		Votes: []Vote{},
+	}
+	if deck.IsNumeric() {
+		data.Result = room.Result
+	}
+	for _, client := range room.Clients.Values() {
+		if client.CurrentVote != nil && *client.CurrentVote != "" {
+			data.Votes = append(data.Votes, Vote{Participant: client.Name, Vote: *client.CurrentVote})
+		}
+	}
+
+	return Event{Type: EventStoryRevealed, RoomID: room.ID, Data: data}
+}
+
+// NewStoryAddedEvent describes the last story of the backlog of a room.
+func NewStoryAddedEvent(room *entity.Room) Event {
+	index := len(room.Stories) - 1
+	return Event{
+		Type:   EventStoryAdded,
+		RoomID: room.ID,
+		Data:   StoryAddedData{Story: room.Stories[index].Name, Index: index},
+	}
+}
+
+// NewSessionEndedEvent describes a room that was closed, with its report.
+func NewSessionEndedEvent(room *entity.Room, reason entity.ExpiryReason) Event {
+	return Event{
+		Type:   EventSessionEnded,
+		RoomID: room.ID,
+		Data:   SessionEndedData{Reason: string(reason), Report: report.New(room)},
+	}
+}
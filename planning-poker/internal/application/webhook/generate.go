@@ -0,0 +1,3 @@
+package webhook
+
+//go:generate go tool mockgen -destination mocks.go -typed -package webhook . Store,Sender,Publisher
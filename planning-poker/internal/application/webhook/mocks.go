@@ -0,0 +1,355 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: planning-poker/internal/application/webhook (interfaces: Store,Sender,Publisher)
+//
+// Generated by this command:
+//
+//	mockgen -destination mocks.go -typed -package webhook . Store,Sender,Publisher
+//
+
+// Package webhook is a generated GoMock package.
+package webhook
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockStore is a mock of Store interface.
+type MockStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockStoreMockRecorder
+	isgomock struct{}
+}
+
+// MockStoreMockRecorder is the mock recorder for MockStore.
+type MockStoreMockRecorder struct {
+	mock *MockStore
+}
+
+// NewMockStore creates a new mock instance.
+func NewMockStore(ctrl *gomock.Controller) *MockStore {
+	mock := &MockStore{ctrl: ctrl}
+	mock.recorder = &MockStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStore) EXPECT() *MockStoreMockRecorder {
+	return m.recorder
+}
+
+// AddDeadLetter mocks base method.
+func (m *MockStore) AddDeadLetter(ctx context.Context, deadLetter DeadLetter) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddDeadLetter", ctx, deadLetter)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddDeadLetter indicates an expected call of AddDeadLetter.
+func (mr *MockStoreMockRecorder) AddDeadLetter(ctx, deadLetter any) *MockStoreAddDeadLetterCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddDeadLetter", reflect.TypeOf((*MockStore)(nil).AddDeadLetter), ctx, deadLetter)
+	return &MockStoreAddDeadLetterCall{Call: call}
+}
+
+// MockStoreAddDeadLetterCall wrap *gomock.Call
+type MockStoreAddDeadLetterCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockStoreAddDeadLetterCall) Return(arg0 error) *MockStoreAddDeadLetterCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockStoreAddDeadLetterCall) Do(f func(context.Context, DeadLetter) error) *MockStoreAddDeadLetterCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockStoreAddDeadLetterCall) DoAndReturn(f func(context.Context, DeadLetter) error) *MockStoreAddDeadLetterCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// AddSubscription mocks base method.
+func (m *MockStore) AddSubscription(ctx context.Context, subscription Subscription) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddSubscription", ctx, subscription)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddSubscription indicates an expected call of AddSubscription.
+func (mr *MockStoreMockRecorder) AddSubscription(ctx, subscription any) *MockStoreAddSubscriptionCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddSubscription", reflect.TypeOf((*MockStore)(nil).AddSubscription), ctx, subscription)
+	return &MockStoreAddSubscriptionCall{Call: call}
+}
+
+// MockStoreAddSubscriptionCall wrap *gomock.Call
+type MockStoreAddSubscriptionCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockStoreAddSubscriptionCall) Return(arg0 error) *MockStoreAddSubscriptionCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockStoreAddSubscriptionCall) Do(f func(context.Context, Subscription) error) *MockStoreAddSubscriptionCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockStoreAddSubscriptionCall) DoAndReturn(f func(context.Context, Subscription) error) *MockStoreAddSubscriptionCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// DeadLetters mocks base method.
+func (m *MockStore) DeadLetters(ctx context.Context, limit int) ([]DeadLetter, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeadLetters", ctx, limit)
+	ret0, _ := ret[0].([]DeadLetter)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeadLetters indicates an expected call of DeadLetters.
+func (mr *MockStoreMockRecorder) DeadLetters(ctx, limit any) *MockStoreDeadLettersCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeadLetters", reflect.TypeOf((*MockStore)(nil).DeadLetters), ctx, limit)
+	return &MockStoreDeadLettersCall{Call: call}
+}
+
+// MockStoreDeadLettersCall wrap *gomock.Call
+type MockStoreDeadLettersCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockStoreDeadLettersCall) Return(arg0 []DeadLetter, arg1 error) *MockStoreDeadLettersCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockStoreDeadLettersCall) Do(f func(context.Context, int) ([]DeadLetter, error)) *MockStoreDeadLettersCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockStoreDeadLettersCall) DoAndReturn(f func(context.Context, int) ([]DeadLetter, error)) *MockStoreDeadLettersCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// RemoveSubscription mocks base method.
+func (m *MockStore) RemoveSubscription(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveSubscription", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveSubscription indicates an expected call of RemoveSubscription.
+func (mr *MockStoreMockRecorder) RemoveSubscription(ctx, id any) *MockStoreRemoveSubscriptionCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveSubscription", reflect.TypeOf((*MockStore)(nil).RemoveSubscription), ctx, id)
+	return &MockStoreRemoveSubscriptionCall{Call: call}
+}
+
+// MockStoreRemoveSubscriptionCall wrap *gomock.Call
+type MockStoreRemoveSubscriptionCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockStoreRemoveSubscriptionCall) Return(arg0 error) *MockStoreRemoveSubscriptionCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockStoreRemoveSubscriptionCall) Do(f func(context.Context, string) error) *MockStoreRemoveSubscriptionCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockStoreRemoveSubscriptionCall) DoAndReturn(f func(context.Context, string) error) *MockStoreRemoveSubscriptionCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Subscriptions mocks base method.
+func (m *MockStore) Subscriptions(ctx context.Context) ([]Subscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Subscriptions", ctx)
+	ret0, _ := ret[0].([]Subscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Subscriptions indicates an expected call of Subscriptions.
+func (mr *MockStoreMockRecorder) Subscriptions(ctx any) *MockStoreSubscriptionsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscriptions", reflect.TypeOf((*MockStore)(nil).Subscriptions), ctx)
+	return &MockStoreSubscriptionsCall{Call: call}
+}
+
+// MockStoreSubscriptionsCall wrap *gomock.Call
+type MockStoreSubscriptionsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockStoreSubscriptionsCall) Return(arg0 []Subscription, arg1 error) *MockStoreSubscriptionsCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockStoreSubscriptionsCall) Do(f func(context.Context) ([]Subscription, error)) *MockStoreSubscriptionsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockStoreSubscriptionsCall) DoAndReturn(f func(context.Context) ([]Subscription, error)) *MockStoreSubscriptionsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockSender is a mock of Sender interface.
+type MockSender struct {
+	ctrl     *gomock.Controller
+	recorder *MockSenderMockRecorder
+	isgomock struct{}
+}
+
+// MockSenderMockRecorder is the mock recorder for MockSender.
+type MockSenderMockRecorder struct {
+	mock *MockSender
+}
+
+// NewMockSender creates a new mock instance.
+func NewMockSender(ctrl *gomock.Controller) *MockSender {
+	mock := &MockSender{ctrl: ctrl}
+	mock.recorder = &MockSenderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSender) EXPECT() *MockSenderMockRecorder {
+	return m.recorder
+}
+
+// Send mocks base method.
+func (m *MockSender) Send(ctx context.Context, subscription Subscription, event Event) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", ctx, subscription, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockSenderMockRecorder) Send(ctx, subscription, event any) *MockSenderSendCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockSender)(nil).Send), ctx, subscription, event)
+	return &MockSenderSendCall{Call: call}
+}
+
+// MockSenderSendCall wrap *gomock.Call
+type MockSenderSendCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockSenderSendCall) Return(arg0 error) *MockSenderSendCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockSenderSendCall) Do(f func(context.Context, Subscription, Event) error) *MockSenderSendCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockSenderSendCall) DoAndReturn(f func(context.Context, Subscription, Event) error) *MockSenderSendCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockPublisher is a mock of Publisher interface.
+type MockPublisher struct {
+	ctrl     *gomock.Controller
+	recorder *MockPublisherMockRecorder
+	isgomock struct{}
+}
+
+// MockPublisherMockRecorder is the mock recorder for MockPublisher.
+type MockPublisherMockRecorder struct {
+	mock *MockPublisher
+}
+
+// NewMockPublisher creates a new mock instance.
+func NewMockPublisher(ctrl *gomock.Controller) *MockPublisher {
+	mock := &MockPublisher{ctrl: ctrl}
+	mock.recorder = &MockPublisherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPublisher) EXPECT() *MockPublisherMockRecorder {
+	return m.recorder
+}
+
+// Publish mocks base method.
+func (m *MockPublisher) Publish(ctx context.Context, event Event) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Publish", ctx, event)
+}
+
+// Publish indicates an expected call of Publish.
+func (mr *MockPublisherMockRecorder) Publish(ctx, event any) *MockPublisherPublishCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockPublisher)(nil).Publish), ctx, event)
+	return &MockPublisherPublishCall{Call: call}
+}
+
+// MockPublisherPublishCall wrap *gomock.Call
+type MockPublisherPublishCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockPublisherPublishCall) Return() *MockPublisherPublishCall {
+	c.Call = c.Call.Return()
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockPublisherPublishCall) Do(f func(context.Context, Event)) *MockPublisherPublishCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockPublisherPublishCall) DoAndReturn(f func(context.Context, Event)) *MockPublisherPublishCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
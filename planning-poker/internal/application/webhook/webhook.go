@@ -0,0 +1,135 @@
+package webhook
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"net/url"
+	"planning-poker/internal/application/timer"
+	"planning-poker/internal/domain"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type EventType string
+
+const (
+	EventStoryRevealed EventType = "story.revealed"
+	EventStoryAdded    EventType = "story.added"
+	EventSessionEnded  EventType = "session.ended"
+)
+
+// EventTypes lists every event a subscription may filter on.
+var EventTypes = []EventType{EventStoryRevealed, EventStoryAdded, EventSessionEnded}
+
+const maxURLLength = 2048
+
+type (
+	// Event is something that happened in a room, sent as the body of every
+	// delivery. Data depends on the type of the event.
+	Event struct {
+		ID         string    `json:"id"`
+		Type       EventType `json:"type"`
+		RoomID     string    `json:"roomId"`
+		OccurredAt time.Time `json:"occurredAt"`
+		Data       any       `json:"data"`
+	}
+
+	// Subscription sends the events of a room, or of every room when RoomID
+	// is empty, to URL. Deliveries are signed with Secret.
+	Subscription struct {
+		ID     string
+		RoomID string
+		URL    string
+		Secret string
+		// EventTypes is empty for subscriptions to every event
+		EventTypes []EventType
+		CreatedAt  time.Time
+	}
+
+	// DeadLetter is a delivery given up after its last attempt.
+	DeadLetter struct {
+		SubscriptionID string
+		URL            string
+		Event          Event
+		Attempts       int
+		Error          string
+		FailedAt       time.Time
+	}
+
+	// Store keeps the subscriptions and the dead letters, shared by every
+	// instance. DeadLetters returns the newest first.
+	Store interface {
+		AddSubscription(ctx context.Context, subscription Subscription) error
+		RemoveSubscription(ctx context.Context, id string) error
+		Subscriptions(ctx context.Context) ([]Subscription, error)
+		AddDeadLetter(ctx context.Context, deadLetter DeadLetter) error
+		DeadLetters(ctx context.Context, limit int) ([]DeadLetter, error)
+	}
+
+	// Sender makes one attempt to deliver an event to a subscription.
+	Sender interface {
+		Send(ctx context.Context, subscription Subscription, event Event) error
+	}
+
+	// Publisher notifies the subscriptions of an event. Deliveries happen in
+	// the background, publishing never fails the action that caused it.
+	Publisher interface {
+		Publish(ctx context.Context, event Event)
+	}
+
+	discard struct{}
+)
+
+var Discard Publisher = discard{}
+
+// NewSubscription validates a subscription and generates its secret.
+func NewSubscription(roomID, rawURL string, eventTypes []string, clock timer.Clock) (Subscription, error) {
+	target, err := url.Parse(rawURL)
+	if err != nil || target.Scheme != "http" && target.Scheme != "https" || target.Host == "" || len(rawURL) > maxURLLength {
+		return Subscription{}, fmt.Errorf("url must be an absolute http or https URL: %w", domain.ErrInvalidWebhook)
+	}
+	if target.User != nil {
+		return Subscription{}, fmt.Errorf("url must not hold credentials, deliveries are signed: %w", domain.ErrInvalidWebhook)
+	}
+
+	types, err := ParseEventTypes(eventTypes)
+	if err != nil {
+		return Subscription{}, err
+	}
+
+	return Subscription{
+		ID:         uuid.NewString(),
+		RoomID:     roomID,
+		URL:        target.String(),
+		Secret:     "whsec_" + rand.Text(),
+		EventTypes: types,
+		CreatedAt:  clock.Now().UTC(),
+	}, nil
+}
+
+// ParseEventTypes validates event type names, dropping duplicates.
+func ParseEventTypes(names []string) ([]EventType, error) {
+	var types []EventType
+	for _, name := range names {
+		eventType := EventType(strings.TrimSpace(name))
+		if !slices.Contains(EventTypes, eventType) {
+			return nil, fmt.Errorf("unknown event type %q: %w", name, domain.ErrInvalidWebhook)
+		}
+		if !slices.Contains(types, eventType) {
+			types = append(types, eventType)
+		}
+	}
+	return types, nil
+}
+
+// Matches tells whether the event is sent to the subscription.
+func (s Subscription) Matches(event Event) bool {
+	return (s.RoomID == "" || s.RoomID == event.RoomID) &&
+		(len(s.EventTypes) == 0 || slices.Contains(s.EventTypes, event.Type))
+}
+
+func (discard) Publish(context.Context, Event) {}
@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"planning-poker/config"
+	"strings"
 	"time"
 
 	toolkitconfig "github.com/bruno303/go-toolkit/pkg/config"
@@ -15,16 +16,66 @@ type Config struct {
 		Tracing struct {
 			Enabled bool `env:"API_TRACING_ENABLED" yaml:"enabled"`
 		} `yaml:"tracing"`
-		BackendPort        int    `env:"API_BACKEND_PORT" yaml:"backend_port"`
-		CorsAllowedOrigins string `env:"API_CORS_ALLOWED_ORIGINS" yaml:"cors_allowed_origins"`
+		BackendPort        int           `env:"API_BACKEND_PORT" yaml:"backend_port"`
+		CorsAllowedOrigins string        `env:"API_CORS_ALLOWED_ORIGINS" yaml:"cors_allowed_origins"`
+		ShutdownTimeout    time.Duration `env:"API_SHUTDOWN_TIMEOUT" yaml:"shutdown_timeout"`
+		TrustForwardedFor  bool          `env:"API_TRUST_FORWARDED_FOR" yaml:"trust_forwarded_for"`
 		PlanningPoker      struct {
-			WebsocketWriteTimeout time.Duration `env:"API_PLANNING_POKER_WEBSOCKET_WRITE_TIMEOUT" yaml:"websocket_write_timeout"`
-			WebsocketReadTimeout  time.Duration `env:"API_PLANNING_POKER_WEBSOCKET_READ_TIMEOUT" yaml:"websocket_read_timeout"`
-			WebsocketPingInterval time.Duration `env:"API_PLANNING_POKER_WEBSOCKET_PING_INTERVAL" yaml:"websocket_ping_interval"`
+			WebsocketWriteTimeout   time.Duration `env:"API_PLANNING_POKER_WEBSOCKET_WRITE_TIMEOUT" yaml:"websocket_write_timeout"`
+			WebsocketReadTimeout    time.Duration `env:"API_PLANNING_POKER_WEBSOCKET_READ_TIMEOUT" yaml:"websocket_read_timeout"`
+			WebsocketPingInterval   time.Duration `env:"API_PLANNING_POKER_WEBSOCKET_PING_INTERVAL" yaml:"websocket_ping_interval"`
+			WebsocketMaxMessageSize int64         `env:"API_PLANNING_POKER_WEBSOCKET_MAX_MESSAGE_SIZE" yaml:"websocket_max_message_size"`
+			WebsocketMaxConnsPerIP  int           `env:"API_PLANNING_POKER_WEBSOCKET_MAX_CONNECTIONS_PER_IP" yaml:"websocket_max_connections_per_ip"`
+			VotingTimerPollInterval time.Duration `env:"API_PLANNING_POKER_VOTING_TIMER_POLL_INTERVAL" yaml:"voting_timer_poll_interval"`
+			AutoCreateRoomsOnJoin   bool          `env:"API_PLANNING_POKER_AUTO_CREATE_ROOMS_ON_JOIN" yaml:"auto_create_rooms_on_join"`
+			ConcurrencyStrategy     string        `env:"API_PLANNING_POKER_CONCURRENCY_STRATEGY" yaml:"concurrency_strategy"`
+			OptimisticMaxAttempts   int           `env:"API_PLANNING_POKER_OPTIMISTIC_MAX_ATTEMPTS" yaml:"optimistic_max_attempts"`
+			OptimisticRetryDelay    time.Duration `env:"API_PLANNING_POKER_OPTIMISTIC_RETRY_DELAY" yaml:"optimistic_retry_delay"`
+			HubBackend              string        `env:"API_PLANNING_POKER_HUB_BACKEND" yaml:"hub_backend"`
+			RoomIdleTimeout         time.Duration `env:"API_PLANNING_POKER_ROOM_IDLE_TIMEOUT" yaml:"room_idle_timeout"`
+			RoomMaxLifetime         time.Duration `env:"API_PLANNING_POKER_ROOM_MAX_LIFETIME" yaml:"room_max_lifetime"`
+			EmptyRoomTTL            time.Duration `env:"API_PLANNING_POKER_EMPTY_ROOM_TTL" yaml:"empty_room_ttl"`
+			RoomExpiryWarning       time.Duration `env:"API_PLANNING_POKER_ROOM_EXPIRY_WARNING" yaml:"room_expiry_warning"`
+			RoomReapInterval        time.Duration `env:"API_PLANNING_POKER_ROOM_REAP_INTERVAL" yaml:"room_reap_interval"`
 		} `yaml:"planning_poker"`
+		RateLimit struct {
+			ClientRate  int `env:"API_RATE_LIMIT_CLIENT_RATE" yaml:"client_rate"`
+			ClientBurst int `env:"API_RATE_LIMIT_CLIENT_BURST" yaml:"client_burst"`
+			RoomRate    int `env:"API_RATE_LIMIT_ROOM_RATE" yaml:"room_rate"`
+			RoomBurst   int `env:"API_RATE_LIMIT_ROOM_BURST" yaml:"room_burst"`
+			IPRate      int `env:"API_RATE_LIMIT_IP_RATE" yaml:"ip_rate"`
+			IPBurst     int `env:"API_RATE_LIMIT_IP_BURST" yaml:"ip_burst"`
+		} `yaml:"rate_limit"`
 		Admin struct {
 			APIKey string `env:"ADMIN_API_KEY" yaml:"api_key"`
+			// Keys is a JSON array of named and scoped keys, like KeysFile
+			Keys     string `env:"ADMIN_API_KEYS" yaml:"keys"`
+			KeysFile string `env:"ADMIN_API_KEYS_FILE" yaml:"keys_file"`
 		} `yaml:"admin"`
+		Audit struct {
+			MaxRecords int `env:"API_AUDIT_MAX_RECORDS" yaml:"max_records"`
+		} `yaml:"audit"`
+		Webhooks struct {
+			Workers        int           `env:"API_WEBHOOKS_WORKERS" yaml:"workers"`
+			QueueSize      int           `env:"API_WEBHOOKS_QUEUE_SIZE" yaml:"queue_size"`
+			MaxAttempts    int           `env:"API_WEBHOOKS_MAX_ATTEMPTS" yaml:"max_attempts"`
+			InitialBackoff time.Duration `env:"API_WEBHOOKS_INITIAL_BACKOFF" yaml:"initial_backoff"`
+			MaxBackoff     time.Duration `env:"API_WEBHOOKS_MAX_BACKOFF" yaml:"max_backoff"`
+			Timeout        time.Duration `env:"API_WEBHOOKS_TIMEOUT" yaml:"timeout"`
+			MaxDeadLetters int           `env:"API_WEBHOOKS_MAX_DEAD_LETTERS" yaml:"max_dead_letters"`
+			// AllowPrivateNetworks lets subscriptions reach loopback and
+			// private addresses, for local development only
+			AllowPrivateNetworks bool `env:"API_WEBHOOKS_ALLOW_PRIVATE_NETWORKS" yaml:"allow_private_networks"`
+		} `yaml:"webhooks"`
+		Auth struct {
+			Issuer              string        `env:"API_AUTH_ISSUER" yaml:"issuer"`
+			Audience            string        `env:"API_AUTH_AUDIENCE" yaml:"audience"`
+			JWKSFile            string        `env:"API_AUTH_JWKS_FILE" yaml:"jwks_file"`
+			JWKSURL             string        `env:"API_AUTH_JWKS_URL" yaml:"jwks_url"`
+			JWKSRefreshInterval time.Duration `env:"API_AUTH_JWKS_REFRESH_INTERVAL" yaml:"jwks_refresh_interval"`
+			NameClaim           string        `env:"API_AUTH_NAME_CLAIM" yaml:"name_claim"`
+			Required            bool          `env:"API_AUTH_REQUIRED" yaml:"required"`
+		} `yaml:"auth"`
 	} `yaml:"api"`
 	Trace struct {
 		Enabled      bool   `env:"TRACE_ENABLED" yaml:"enabled"`
@@ -42,6 +93,35 @@ type Config struct {
 		Password string `env:"REDIS_PASSWORD" yaml:"password"`
 		DB       int    `env:"REDIS_DB" yaml:"db"`
 	} `yaml:"redis"`
+	Postgres struct {
+		Host     string `env:"POSTGRES_HOST" yaml:"host"`
+		Port     int    `env:"POSTGRES_PORT" yaml:"port"`
+		User     string `env:"POSTGRES_USER" yaml:"user"`
+		Password string `env:"POSTGRES_PASSWORD" yaml:"password"`
+		Database string `env:"POSTGRES_DATABASE" yaml:"database"`
+		SSLMode  string `env:"POSTGRES_SSL_MODE" yaml:"ssl_mode"`
+		MaxConns int32  `env:"POSTGRES_MAX_CONNS" yaml:"max_conns"`
+	} `yaml:"postgres"`
+}
+
+// AllowedOrigins lists the origins allowed by CORS and by the websocket
+// upgrade. "*" allows every origin.
+func (c *Config) AllowedOrigins() []string {
+	if c.API.CorsAllowedOrigins == "" {
+		return []string{
+			"http://localhost:3000",
+			"http://127.0.0.1:3000",
+			"http://localhost:8080",
+		}
+	}
+
+	var origins []string
+	for origin := range strings.SplitSeq(c.API.CorsAllowedOrigins, ",") {
+		if origin = strings.TrimSpace(origin); origin != "" {
+			origins = append(origins, origin)
+		}
+	}
+	return origins
 }
 
 func LoadConfig() (*Config, error) {
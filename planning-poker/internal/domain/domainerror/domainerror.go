@@ -1,9 +1,122 @@
 package domainerror
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 var (
 	ErrRoomNotFound   = errors.New("room not found")
 	ErrClientNotFound = errors.New("client not found")
 	ErrLastOwner      = errors.New("cannot remove the last owner")
+	ErrInvalidDeck    = errors.New("invalid deck")
+	ErrInvalidVote    = errors.New("vote is not part of the room deck")
+
+	ErrInvalidConsensusRule = errors.New("invalid consensus rule")
+	ErrInvalidTimerDuration = errors.New("invalid voting timer duration")
+	ErrInvalidPasscode      = errors.New("invalid passcode")
+	ErrInvalidInvite        = errors.New("invalid invite")
+	ErrRoomAccessDenied     = errors.New("invalid room credentials")
+	ErrPermissionDenied     = errors.New("permission denied")
+	ErrInvalidPermission    = errors.New("invalid permission")
+	ErrVersionConflict      = errors.New("room was modified concurrently")
+	ErrInvalidCursor        = errors.New("invalid cursor")
+	ErrInvalidMessage       = errors.New("invalid message")
+	ErrUnknownMessageType   = errors.New("unknown message type")
+	ErrInvalidPayload       = errors.New("invalid payload")
+	ErrRateLimited          = errors.New("too many requests, slow down")
+	ErrIdentityMismatch     = errors.New("client belongs to another identity")
+	ErrInvalidBotName       = errors.New("invalid bot name")
+	ErrInvalidBotToken      = errors.New("invalid bot token")
+	ErrInvalidWebhook       = errors.New("invalid webhook")
+	ErrWebhookNotFound      = errors.New("webhook not found")
+)
+
+// Code identifies an error for clients, which must not depend on error
+// messages. Codes never change once published.
+type Code string
+
+const (
+	CodeRoomNotFound         Code = "ROOM_NOT_FOUND"
+	CodeClientNotFound       Code = "CLIENT_NOT_FOUND"
+	CodeLastOwner            Code = "LAST_OWNER"
+	CodeInvalidDeck          Code = "INVALID_DECK"
+	CodeInvalidVote          Code = "INVALID_VOTE"
+	CodeInvalidConsensusRule Code = "INVALID_CONSENSUS_RULE"
+	CodeInvalidTimerDuration Code = "INVALID_TIMER_DURATION"
+	CodeInvalidPasscode      Code = "INVALID_PASSCODE"
+	CodeInvalidInvite        Code = "INVALID_INVITE"
+	CodeRoomAccessDenied     Code = "ROOM_ACCESS_DENIED"
+	CodePermissionDenied     Code = "PERMISSION_DENIED"
+	CodeInvalidPermission    Code = "INVALID_PERMISSION"
+	CodeVersionConflict      Code = "VERSION_CONFLICT"
+	CodeInvalidCursor        Code = "INVALID_CURSOR"
+	CodeInvalidMessage       Code = "INVALID_MESSAGE"
+	CodeUnknownMessageType   Code = "UNKNOWN_MESSAGE_TYPE"
+	CodeInvalidPayload       Code = "INVALID_PAYLOAD"
+	CodeRateLimited          Code = "RATE_LIMITED"
+	CodeIdentityMismatch     Code = "IDENTITY_MISMATCH"
+	CodeInvalidBotName       Code = "INVALID_BOT_NAME"
+	CodeInvalidBotToken      Code = "INVALID_BOT_TOKEN"
+	CodeInvalidWebhook       Code = "INVALID_WEBHOOK"
+	CodeWebhookNotFound      Code = "WEBHOOK_NOT_FOUND"
+	// CodeInternal covers every error that is not a domain error
+	CodeInternal Code = "INTERNAL"
 )
+
+var codes = []struct {
+	err  error
+	code Code
+}{
+	{ErrRoomNotFound, CodeRoomNotFound},
+	{ErrClientNotFound, CodeClientNotFound},
+	{ErrLastOwner, CodeLastOwner},
+	{ErrInvalidDeck, CodeInvalidDeck},
+	{ErrInvalidVote, CodeInvalidVote},
+	{ErrInvalidConsensusRule, CodeInvalidConsensusRule},
+	{ErrInvalidTimerDuration, CodeInvalidTimerDuration},
+	{ErrInvalidPasscode, CodeInvalidPasscode},
+	{ErrInvalidInvite, CodeInvalidInvite},
+	{ErrRoomAccessDenied, CodeRoomAccessDenied},
+	{ErrPermissionDenied, CodePermissionDenied},
+	{ErrInvalidPermission, CodeInvalidPermission},
+	{ErrVersionConflict, CodeVersionConflict},
+	{ErrInvalidCursor, CodeInvalidCursor},
+	{ErrInvalidMessage, CodeInvalidMessage},
+	{ErrUnknownMessageType, CodeUnknownMessageType},
+	{ErrInvalidPayload, CodeInvalidPayload},
+	{ErrRateLimited, CodeRateLimited},
+	{ErrIdentityMismatch, CodeIdentityMismatch},
+	{ErrInvalidBotName, CodeInvalidBotName},
+	{ErrInvalidBotToken, CodeInvalidBotToken},
+	{ErrInvalidWebhook, CodeInvalidWebhook},
+	{ErrWebhookNotFound, CodeWebhookNotFound},
+}
+
+// CodeOf returns the code of the domain error wrapped by err, or CodeInternal
+// when it wraps none.
+func CodeOf(err error) Code {
+	for _, c := range codes {
+		if errors.Is(err, c.err) {
+			return c.code
+		}
+	}
+	return CodeInternal
+}
+
+// PermissionError is returned when a participant's role does not allow an
+// action in a room. It matches ErrPermissionDenied with errors.Is.
+type PermissionError struct {
+	RoomID   string
+	ClientID string
+	Role     string
+	Action   string
+}
+
+func (e *PermissionError) Error() string {
+	return fmt.Sprintf("client %s with role %s cannot %s in room %s", e.ClientID, e.Role, e.Action, e.RoomID)
+}
+
+func (e *PermissionError) Unwrap() error {
+	return ErrPermissionDenied
+}
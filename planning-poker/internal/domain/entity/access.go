@@ -0,0 +1,219 @@
+package entity
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"planning-poker/internal/domain/domainerror"
+)
+
+const (
+	minPasscodeLength = 4
+	maxPasscodeLength = 64
+
+	DefaultInviteTTL = 24 * time.Hour
+	MaxInviteTTL     = 7 * 24 * time.Hour
+
+	passcodeHashScheme     = "pbkdf2-sha256"
+	passcodeHashIterations = 100_000
+	passcodeSaltLength     = 16
+	inviteSecretLength     = 32
+)
+
+type Invite struct {
+	Token     string
+	ExpiresAt time.Time
+}
+
+// Credentials are presented by clients joining a room that is protected by a
+// passcode or invite only. A valid invite token is accepted in place of the
+// passcode.
+type Credentials struct {
+	Passcode    string
+	InviteToken string
+}
+
+func (r *Room) HasPasscode() bool {
+	return r.PasscodeHash != ""
+}
+
+// SetPasscode protects the room with a passcode. An empty passcode removes
+// the protection.
+func (r *Room) SetPasscode(ctx context.Context, clientID string, passcode string) error {
+	if _, err := r.CheckPermission(clientID, ActionManageAccess); err != nil {
+		return err
+	}
+
+	hash, err := NewPasscodeHash(passcode)
+	if err != nil {
+		return err
+	}
+
+	r.record(ctx, RoomEvent{Type: EventPasscodeChanged, ClientID: clientID, PasscodeHash: hash})
+
+	return nil
+}
+
+// NewPasscodeHash validates and hashes a passcode. The hash of an empty
+// passcode is empty, meaning the room is not protected.
+func NewPasscodeHash(passcode string) (string, error) {
+	if passcode == "" {
+		return "", nil
+	}
+
+	length := utf8.RuneCountInString(passcode)
+	if length < minPasscodeLength || length > maxPasscodeLength {
+		return "", fmt.Errorf("passcode must have between %d and %d characters: %w", minPasscodeLength, maxPasscodeLength, domainerror.ErrInvalidPasscode)
+	}
+
+	return hashPasscode(passcode)
+}
+
+// CreateInvite signs a token that lets its holder join the room until it
+// expires, without knowing the passcode. Once an invite is created the room is
+// invite only, even if it has no passcode.
+func (r *Room) CreateInvite(ctx context.Context, clientID string, now time.Time, ttl time.Duration) (Invite, error) {
+	if _, err := r.CheckPermission(clientID, ActionManageAccess); err != nil {
+		return Invite{}, err
+	}
+	if ttl == 0 {
+		ttl = DefaultInviteTTL
+	}
+	if ttl < 0 || ttl > MaxInviteTTL {
+		return Invite{}, fmt.Errorf("invite must expire within %s: %w", MaxInviteTTL, domainerror.ErrInvalidInvite)
+	}
+
+	if len(r.InviteSecret) == 0 {
+		if err := r.rotateInviteSecret(ctx, clientID); err != nil {
+			return Invite{}, err
+		}
+	}
+
+	expiresAt := now.Add(ttl).Truncate(time.Second)
+	r.record(ctx, RoomEvent{Type: EventInviteCreated, ClientID: clientID, Deadline: &expiresAt})
+
+	expiry := strconv.FormatInt(expiresAt.Unix(), 10)
+
+	return Invite{
+		Token:     expiry + "." + base64.RawURLEncoding.EncodeToString(r.signInvite(expiry)),
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+// RevokeInvites invalidates every invite created so far.
+func (r *Room) RevokeInvites(ctx context.Context, clientID string) error {
+	if _, err := r.CheckPermission(clientID, ActionManageAccess); err != nil {
+		return err
+	}
+
+	return r.rotateInviteSecret(ctx, clientID)
+}
+
+// Authorize checks the credentials of a client joining the room. Rooms
+// without a passcode that never issued an invite are open to anyone.
+func (r *Room) Authorize(now time.Time, credentials Credentials) error {
+	if !r.HasPasscode() && !r.InviteOnly {
+		return nil
+	}
+
+	if credentials.InviteToken != "" && r.validInvite(now, credentials.InviteToken) {
+		return nil
+	}
+	if credentials.Passcode != "" && verifyPasscode(r.PasscodeHash, credentials.Passcode) {
+		return nil
+	}
+
+	return fmt.Errorf("cannot join room %s: %w", r.ID, domainerror.ErrRoomAccessDenied)
+}
+
+func (r *Room) validInvite(now time.Time, token string) bool {
+	if len(r.InviteSecret) == 0 {
+		return false
+	}
+
+	expiry, signature, ok := strings.Cut(token, ".")
+	if !ok {
+		return false
+	}
+	expiresAt, err := strconv.ParseInt(expiry, 10, 64)
+	if err != nil || !now.Before(time.Unix(expiresAt, 0)) {
+		return false
+	}
+	decoded, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+
+	return hmac.Equal(decoded, r.signInvite(expiry))
+}
+
+func (r *Room) signInvite(expiry string) []byte {
+	mac := hmac.New(sha256.New, r.InviteSecret)
+	mac.Write([]byte(r.ID + ":" + expiry))
+	return mac.Sum(nil)
+}
+
+func (r *Room) rotateInviteSecret(ctx context.Context, clientID string) error {
+	secret := make([]byte, inviteSecretLength)
+	if _, err := rand.Read(secret); err != nil {
+		return fmt.Errorf("failed to generate invite secret: %w", err)
+	}
+	r.record(ctx, RoomEvent{Type: EventInviteSecretRotated, ClientID: clientID, InviteSecret: secret})
+	return nil
+}
+
+func hashPasscode(passcode string) (string, error) {
+	salt := make([]byte, passcodeSaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("failed to generate passcode salt: %w", err)
+	}
+
+	key, err := pbkdf2.Key(sha256.New, passcode, salt, passcodeHashIterations, sha256.Size)
+	if err != nil {
+		return "", fmt.Errorf("failed to hash passcode: %w", err)
+	}
+
+	return strings.Join([]string{
+		passcodeHashScheme,
+		strconv.Itoa(passcodeHashIterations),
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	}, "$"), nil
+}
+
+func verifyPasscode(hash string, passcode string) bool {
+	parts := strings.Split(hash, "$")
+	if len(parts) != 4 || parts[0] != passcodeHashScheme {
+		return false
+	}
+
+	iterations, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return false
+	}
+	salt, err := base64.RawStdEncoding.DecodeString(parts[2])
+	if err != nil {
+		return false
+	}
+	expected, err := base64.RawStdEncoding.DecodeString(parts[3])
+	if err != nil {
+		return false
+	}
+
+	key, err := pbkdf2.Key(sha256.New, passcode, salt, iterations, len(expected))
+	if err != nil {
+		return false
+	}
+
+	return subtle.ConstantTimeCompare(key, expected) == 1
+}
@@ -0,0 +1,190 @@
+package entity_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"planning-poker/internal/domain/domainerror"
+	"planning-poker/internal/domain/entity"
+	"planning-poker/internal/infra/boundaries/hub/clientcollection"
+)
+
+func newProtectedRoom(t *testing.T, passcode string) *entity.Room {
+	t.Helper()
+
+	room := entity.NewRoom(clientcollection.New())
+	room.NewClient("owner")
+	room.NewClient("client1")
+	if err := room.SetPasscode(context.Background(), "owner", passcode); err != nil {
+		t.Fatalf("unexpected error setting passcode: %v", err)
+	}
+	return room
+}
+
+func TestRoom_SetPasscode(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("stores a hash instead of the passcode", func(t *testing.T) {
+		room := newProtectedRoom(t, "s3cret")
+
+		if !room.HasPasscode() {
+			t.Fatal("expected room to be protected")
+		}
+		if strings.Contains(room.PasscodeHash, "s3cret") {
+			t.Error("expected passcode not to be stored in clear text")
+		}
+	})
+
+	t.Run("empty passcode removes protection", func(t *testing.T) {
+		room := newProtectedRoom(t, "s3cret")
+
+		if err := room.SetPasscode(ctx, "owner", ""); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if room.HasPasscode() {
+			t.Error("expected room to be open")
+		}
+	})
+
+	t.Run("short passcode is rejected", func(t *testing.T) {
+		room := newProtectedRoom(t, "s3cret")
+
+		if err := room.SetPasscode(ctx, "owner", "abc"); !errors.Is(err, domainerror.ErrInvalidPasscode) {
+			t.Errorf("expected ErrInvalidPasscode, got %v", err)
+		}
+	})
+
+	t.Run("non-owner cannot set passcode", func(t *testing.T) {
+		room := entity.NewRoom(clientcollection.New())
+		room.NewClient("owner")
+		room.NewClient("client1")
+
+		if err := room.SetPasscode(ctx, "client1", "s3cret"); err == nil {
+			t.Error("expected error for non-owner")
+		}
+		if room.HasPasscode() {
+			t.Error("expected room to stay open")
+		}
+	})
+}
+
+func TestRoom_Authorize(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	room := newProtectedRoom(t, "s3cret")
+	invite, err := room.CreateInvite(ctx, "owner", now, time.Hour)
+	if err != nil {
+		t.Fatalf("unexpected error creating invite: %v", err)
+	}
+
+	otherRoom := newProtectedRoom(t, "s3cret")
+	otherInvite, _ := otherRoom.CreateInvite(ctx, "owner", now, time.Hour)
+
+	tests := []struct {
+		name        string
+		at          time.Time
+		credentials entity.Credentials
+		wantErr     bool
+	}{
+		{name: "valid passcode", at: now, credentials: entity.Credentials{Passcode: "s3cret"}},
+		{name: "wrong passcode", at: now, credentials: entity.Credentials{Passcode: "wrong"}, wantErr: true},
+		{name: "no credentials", at: now, wantErr: true},
+		{name: "valid invite", at: now.Add(59 * time.Minute), credentials: entity.Credentials{InviteToken: invite.Token}},
+		{name: "expired invite", at: now.Add(time.Hour), credentials: entity.Credentials{InviteToken: invite.Token}, wantErr: true},
+		{name: "tampered invite", at: now, credentials: entity.Credentials{InviteToken: "9999999999" + invite.Token[strings.Index(invite.Token, "."):]}, wantErr: true},
+		{name: "invite of another room", at: now, credentials: entity.Credentials{InviteToken: otherInvite.Token}, wantErr: true},
+		{name: "invalid invite falls back to passcode", at: now, credentials: entity.Credentials{Passcode: "s3cret", InviteToken: "garbage"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := room.Authorize(tt.at, tt.credentials)
+			if tt.wantErr {
+				if !errors.Is(err, domainerror.ErrRoomAccessDenied) {
+					t.Errorf("expected ErrRoomAccessDenied, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestRoom_Authorize_OpenRoom(t *testing.T) {
+	room := entity.NewRoom(clientcollection.New())
+
+	if err := room.Authorize(time.Now(), entity.Credentials{}); err != nil {
+		t.Errorf("expected open room to accept anyone, got %v", err)
+	}
+}
+
+func TestRoom_Authorize_InviteOnlyRoom(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	room := entity.NewRoom(clientcollection.New())
+	room.NewClient("owner")
+
+	invite, err := room.CreateInvite(ctx, "owner", now, time.Hour)
+	if err != nil {
+		t.Fatalf("unexpected error creating invite: %v", err)
+	}
+
+	if !room.InviteOnly {
+		t.Error("expected room to become invite only")
+	}
+	if err := room.Authorize(now, entity.Credentials{}); !errors.Is(err, domainerror.ErrRoomAccessDenied) {
+		t.Errorf("expected join without invite to be rejected, got %v", err)
+	}
+	if err := room.Authorize(now, entity.Credentials{InviteToken: invite.Token}); err != nil {
+		t.Errorf("expected invite to be accepted, got %v", err)
+	}
+
+	if err := room.RevokeInvites(ctx, "owner"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := room.Authorize(now, entity.Credentials{InviteToken: invite.Token}); !errors.Is(err, domainerror.ErrRoomAccessDenied) {
+		t.Errorf("expected revoked invite to be rejected, got %v", err)
+	}
+}
+
+func TestRoom_RevokeInvites(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	room := newProtectedRoom(t, "s3cret")
+	invite, _ := room.CreateInvite(ctx, "owner", now, time.Hour)
+
+	if err := room.RevokeInvites(ctx, "owner"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := room.Authorize(now, entity.Credentials{InviteToken: invite.Token}); !errors.Is(err, domainerror.ErrRoomAccessDenied) {
+		t.Errorf("expected revoked invite to be rejected, got %v", err)
+	}
+}
+
+func TestRoom_CreateInvite(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	room := newProtectedRoom(t, "s3cret")
+
+	invite, err := room.CreateInvite(ctx, "owner", now, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !invite.ExpiresAt.Equal(now.Add(entity.DefaultInviteTTL)) {
+		t.Errorf("expected default expiry %v, got %v", now.Add(entity.DefaultInviteTTL), invite.ExpiresAt)
+	}
+
+	if _, err := room.CreateInvite(ctx, "owner", now, entity.MaxInviteTTL+time.Second); !errors.Is(err, domainerror.ErrInvalidInvite) {
+		t.Errorf("expected ErrInvalidInvite, got %v", err)
+	}
+	if _, err := room.CreateInvite(ctx, "client1", now, time.Hour); err == nil {
+		t.Error("expected error for non-owner")
+	}
+}
@@ -0,0 +1,130 @@
+package entity
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+
+	"planning-poker/internal/domain/domainerror"
+)
+
+const (
+	DefaultBotName = "Bot"
+
+	maxBotNameLength = 64
+	botIDPrefix      = "bot-"
+)
+
+// BotCredentials are returned once, when the bot is added. Only the hash of
+// the token is kept.
+type BotCredentials struct {
+	ClientID string
+	Token    string
+}
+
+// NewBot adds a bot to a room that was just created, before anyone could
+// join it.
+func (r *Room) NewBot(ctx context.Context, name string) (BotCredentials, error) {
+	return r.newBot(ctx, "", name)
+}
+
+// AddBot adds a bot that acts on the room through the API with the returned
+// token. Bots facilitate the session without voting, until they are removed.
+func (r *Room) AddBot(ctx context.Context, clientID string, name string) (BotCredentials, error) {
+	if _, err := r.CheckPermission(clientID, ActionManageAccess); err != nil {
+		return BotCredentials{}, err
+	}
+
+	return r.newBot(ctx, clientID, name)
+}
+
+// RemoveBot removes a bot, its token is no longer accepted.
+func (r *Room) RemoveBot(ctx context.Context, clientID string, botID string) error {
+	if _, err := r.CheckPermission(clientID, ActionManageAccess); err != nil {
+		return err
+	}
+
+	bot, ok := r.FindClient(botID)
+	if !ok || !bot.Bot {
+		return fmt.Errorf("bot %s not found in room %s: %w", botID, r.ID, domainerror.ErrClientNotFound)
+	}
+
+	r.record(ctx, RoomEvent{Type: EventClientLeft, ClientID: clientID, TargetID: botID})
+
+	return nil
+}
+
+// AuthenticateBot returns the bot the token was issued to.
+func (r *Room) AuthenticateBot(token string) (*Client, error) {
+	botID, _, ok := strings.Cut(token, ".")
+	if !ok || !strings.HasPrefix(botID, botIDPrefix) {
+		return nil, fmt.Errorf("malformed bot token for room %s: %w", r.ID, domainerror.ErrInvalidBotToken)
+	}
+
+	bot, ok := r.FindClient(botID)
+	if !ok || !bot.Bot || subtle.ConstantTimeCompare([]byte(hashBotToken(token)), []byte(bot.TokenHash)) != 1 {
+		return nil, fmt.Errorf("bot token rejected by room %s: %w", r.ID, domainerror.ErrInvalidBotToken)
+	}
+
+	return bot, nil
+}
+
+func (r *Room) newBot(ctx context.Context, clientID string, name string) (BotCredentials, error) {
+	name, err := NewBotName(name)
+	if err != nil {
+		return BotCredentials{}, err
+	}
+
+	botID := botIDPrefix + uuid.NewString()
+	token := botID + "." + rand.Text()
+
+	r.record(ctx, RoomEvent{Type: EventBotAdded, ClientID: clientID, TargetID: botID, Name: name, TokenHash: hashBotToken(token)})
+
+	return BotCredentials{ClientID: botID, Token: token}, nil
+}
+
+// NewBotName validates the name of a bot, bots without a name are named
+// after DefaultBotName.
+func NewBotName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return DefaultBotName, nil
+	}
+	if utf8.RuneCountInString(name) > maxBotNameLength {
+		return "", fmt.Errorf("bot name must have at most %d characters: %w", maxBotNameLength, domainerror.ErrInvalidBotName)
+	}
+	return name, nil
+}
+
+// addBot seats a bot as a spectator, so the votes never wait for it.
+func (r *Room) addBot(id string, name string, tokenHash string) *Client {
+	client := newClient(id)
+	client.Name = name
+	client.Bot = true
+	client.IsSpectator = true
+	client.TokenHash = tokenHash
+	r.Clients.Add(client)
+	client.room = r
+	return client
+}
+
+// participants are the clients taking part in person, bots left out.
+func (r *Room) participants() ClientCollection {
+	return r.Clients.Filter(func(client *Client) bool {
+		return !client.Bot
+	})
+}
+
+// tokens are random, a fast hash is enough to keep them from being usable
+// when the room is read
+func hashBotToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return base64.RawStdEncoding.EncodeToString(sum[:])
+}
@@ -0,0 +1,149 @@
+package entity_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"planning-poker/internal/domain/domainerror"
+	"planning-poker/internal/domain/entity"
+	"planning-poker/internal/infra/boundaries/hub/clientcollection"
+)
+
+func TestRoom_AddBot(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("bot authenticates with its token and facilitates without voting", func(t *testing.T) {
+		room := entity.NewRoom(clientcollection.New())
+		room.NewClient("owner")
+		room.NewClient("voter")
+
+		credentials, err := room.AddBot(ctx, "owner", "release-bot")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		bot, err := room.AuthenticateBot(credentials.Token)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if bot.ID != credentials.ClientID || bot.Name != "release-bot" || !bot.IsSpectator || bot.IsOwner {
+			t.Errorf("unexpected bot %+v", bot)
+		}
+		if strings.Contains(bot.TokenHash, credentials.Token) {
+			t.Error("expected the token not to be stored in clear text")
+		}
+
+		if err := room.AddStory(ctx, bot.ID, "Login"); err != nil {
+			t.Errorf("expected the bot to add stories, got %v", err)
+		}
+		if _, err := room.AddBot(ctx, bot.ID, "another"); !errors.Is(err, domainerror.ErrPermissionDenied) {
+			t.Errorf("expected the bot not to manage access, got %v", err)
+		}
+
+		// the votes do not wait for the bot
+		vote := "5"
+		_ = room.Vote(ctx, "owner", &vote)
+		_ = room.Vote(ctx, "voter", &vote)
+		if !room.Reveal {
+			t.Error("expected the votes to be revealed")
+		}
+	})
+
+	t.Run("voters cannot add bots", func(t *testing.T) {
+		room := entity.NewRoom(clientcollection.New())
+		room.NewClient("owner")
+		room.NewClient("voter")
+
+		if _, err := room.AddBot(ctx, "voter", ""); !errors.Is(err, domainerror.ErrPermissionDenied) {
+			t.Errorf("expected ErrPermissionDenied, got %v", err)
+		}
+	})
+
+	t.Run("long names are rejected", func(t *testing.T) {
+		room := entity.NewRoom(clientcollection.New())
+		room.NewClient("owner")
+
+		if _, err := room.AddBot(ctx, "owner", strings.Repeat("a", 65)); !errors.Is(err, domainerror.ErrInvalidBotName) {
+			t.Errorf("expected ErrInvalidBotName, got %v", err)
+		}
+	})
+}
+
+func TestRoom_NewBot_KeepsOwnershipForParticipants(t *testing.T) {
+	ctx := context.Background()
+	room := entity.NewRoom(clientcollection.New())
+
+	credentials, err := room.NewBot(ctx, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	first := room.NewClient("first")
+	if !first.IsOwner {
+		t.Error("expected the first participant to own the room")
+	}
+	room.NewClient("second")
+
+	if err := room.RemoveClient(ctx, "first"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if second, _ := room.FindClient("second"); !second.IsOwner {
+		t.Error("expected the ownership to pass on to a participant")
+	}
+	if bot, _ := room.FindClient(credentials.ClientID); bot.IsOwner || bot.Name != entity.DefaultBotName {
+		t.Errorf("unexpected bot %+v", bot)
+	}
+	if bot, _ := room.FindClient(credentials.ClientID); bot.BelongsTo(entity.Identity{}) {
+		t.Error("expected the seat of the bot not to be taken by a connection")
+	}
+}
+
+func TestRoom_IsEmpty_WithOnlyBotsLeft(t *testing.T) {
+	ctx := context.Background()
+	room := entity.NewRoom(clientcollection.New())
+
+	if _, err := room.NewBot(ctx, ""); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !room.IsEmpty() {
+		t.Error("expected a room with only a bot to be empty")
+	}
+
+	room.NewClient("client1")
+	if room.IsEmpty() {
+		t.Error("expected a room with a participant not to be empty")
+	}
+
+	if err := room.RemoveClient(ctx, "client1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !room.IsEmpty() {
+		t.Error("expected the room to be empty once the last participant left")
+	}
+}
+
+func TestRoom_AuthenticateBot(t *testing.T) {
+	ctx := context.Background()
+	room := entity.NewRoom(clientcollection.New())
+	room.NewClient("owner")
+	credentials, _ := room.AddBot(ctx, "owner", "")
+
+	botID, _, _ := strings.Cut(credentials.Token, ".")
+	for _, token := range []string{"", "owner.secret", botID, botID + ".wrong"} {
+		if _, err := room.AuthenticateBot(token); !errors.Is(err, domainerror.ErrInvalidBotToken) {
+			t.Errorf("expected ErrInvalidBotToken for %q, got %v", token, err)
+		}
+	}
+
+	if err := room.RemoveBot(ctx, "owner", "owner"); !errors.Is(err, domainerror.ErrClientNotFound) {
+		t.Errorf("expected only bots to be removed, got %v", err)
+	}
+	if err := room.RemoveBot(ctx, "owner", credentials.ClientID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := room.AuthenticateBot(credentials.Token); !errors.Is(err, domainerror.ErrInvalidBotToken) {
+		t.Errorf("expected the token of a removed bot to be rejected, got %v", err)
+	}
+}
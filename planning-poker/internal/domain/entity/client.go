@@ -10,6 +10,14 @@ type (
 	Client struct {
 		ID   string
 		Name string
+		// Subject is the verified identity of the participant, empty for
+		// anonymous ones
+		Subject string
+		// Bot is set for the clients that drive the room through the API with
+		// a token instead of a connection
+		Bot bool
+		// TokenHash is the hash of the token of a bot
+		TokenHash string
 
 		room *Room
 
@@ -53,6 +61,28 @@ func (c *Client) Vote(ctx context.Context, vote *string) {
 	}
 }
 
+// Role derives the permission role of the client: bots have their own,
+// owners facilitate the session and spectators observe it.
+func (c *Client) Role() Role {
+	switch {
+	case c.Bot:
+		return RoleBot
+	case c.IsOwner:
+		return RoleFacilitator
+	case c.IsSpectator:
+		return RoleObserver
+	default:
+		return RoleVoter
+	}
+}
+
+// BelongsTo tells whether the identity may take the seat of the client:
+// anonymous seats are only for anonymous participants and authenticated ones
+// for their subject. The seat of a bot is only taken with its token.
+func (c *Client) BelongsTo(identity Identity) bool {
+	return !c.Bot && c.Subject == identity.Subject
+}
+
 func (c *Client) Room() *Room {
 	return c.room
 }
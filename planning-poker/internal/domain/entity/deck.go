@@ -0,0 +1,158 @@
+package entity
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/samber/lo"
+
+	"planning-poker/internal/domain/domainerror"
+)
+
+const (
+	DeckFibonacci   = "fibonacci"
+	DeckTShirt      = "tshirt"
+	DeckPowersOfTwo = "powers-of-two"
+	DeckCustom      = "custom"
+
+	maxCustomDeckCards  = 30
+	maxCustomCardLength = 8
+)
+
+// Cards that can be played on any deck but do not take part in the result.
+var nonEstimateCards = []string{"?", "☕"}
+
+type Deck struct {
+	Name  string
+	Cards []string
+}
+
+var builtInDecks = map[string][]string{
+	DeckFibonacci:   {"0", "1", "2", "3", "5", "8", "13", "21", "34", "55", "89", "?", "☕"},
+	DeckTShirt:      {"XS", "S", "M", "L", "XL", "XXL", "?", "☕"},
+	DeckPowersOfTwo: {"0", "1", "2", "4", "8", "16", "32", "64", "?", "☕"},
+}
+
+func DefaultDeck() Deck {
+	deck, _ := BuiltInDeck(DeckFibonacci)
+	return deck
+}
+
+func BuiltInDeck(name string) (Deck, bool) {
+	cards, ok := builtInDecks[name]
+	if !ok {
+		return Deck{}, false
+	}
+
+	return Deck{Name: name, Cards: slices.Clone(cards)}, true
+}
+
+func BuiltInDeckNames() []string {
+	return []string{DeckFibonacci, DeckTShirt, DeckPowersOfTwo}
+}
+
+// NewDeck resolves a deck by name. Cards are only used, and required,
+// for the custom deck.
+func NewDeck(name string, cards []string) (Deck, error) {
+	if name == "" {
+		return DefaultDeck(), nil
+	}
+
+	if name != DeckCustom {
+		deck, ok := BuiltInDeck(name)
+		if !ok {
+			return Deck{}, fmt.Errorf("unknown deck %q: %w", name, domainerror.ErrInvalidDeck)
+		}
+		return deck, nil
+	}
+
+	return newCustomDeck(cards)
+}
+
+func newCustomDeck(cards []string) (Deck, error) {
+	normalized := make([]string, 0, len(cards))
+	for _, card := range cards {
+		card = strings.TrimSpace(card)
+		if card == "" {
+			return Deck{}, fmt.Errorf("custom deck contains an empty card: %w", domainerror.ErrInvalidDeck)
+		}
+		if len([]rune(card)) > maxCustomCardLength {
+			return Deck{}, fmt.Errorf("card %q is longer than %d characters: %w", card, maxCustomCardLength, domainerror.ErrInvalidDeck)
+		}
+		if slices.Contains(normalized, card) {
+			return Deck{}, fmt.Errorf("card %q appears more than once: %w", card, domainerror.ErrInvalidDeck)
+		}
+		normalized = append(normalized, card)
+	}
+
+	deck := Deck{Name: DeckCustom, Cards: normalized}
+	if len(deck.estimateCards()) == 0 {
+		return Deck{}, fmt.Errorf("custom deck needs at least one estimate card: %w", domainerror.ErrInvalidDeck)
+	}
+	if len(normalized) > maxCustomDeckCards {
+		return Deck{}, fmt.Errorf("custom deck has more than %d cards: %w", maxCustomDeckCards, domainerror.ErrInvalidDeck)
+	}
+
+	return deck, nil
+}
+
+func (d Deck) IsEmpty() bool {
+	return len(d.Cards) == 0
+}
+
+func (d Deck) Contains(card string) bool {
+	return slices.Contains(d.Cards, card)
+}
+
+// IsNumeric reports whether every estimate card of the deck is an integer.
+// Numeric decks are scored by card value, the others by card position.
+func (d Deck) IsNumeric() bool {
+	for _, card := range d.estimateCards() {
+		if _, err := strconv.Atoi(card); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// Score returns the value used to compute the result of a card. Non-estimate
+// cards and cards outside the deck have no score.
+func (d Deck) Score(card string) (int, bool) {
+	estimateCards := d.estimateCards()
+	position := slices.Index(estimateCards, card)
+	if position < 0 {
+		return 0, false
+	}
+
+	if d.IsNumeric() {
+		value, _ := strconv.Atoi(card)
+		return value, true
+	}
+
+	return position + 1, true
+}
+
+// Step returns the position of an estimate card in the deck, so that
+// neighbouring cards are one step apart regardless of their values.
+func (d Deck) Step(card string) (int, bool) {
+	position := slices.Index(d.estimateCards(), card)
+	return position, position >= 0
+}
+
+// Card is the inverse of Score.
+func (d Deck) Card(score int) (string, bool) {
+	for _, card := range d.estimateCards() {
+		if cardScore, _ := d.Score(card); cardScore == score {
+			return card, true
+		}
+	}
+	return "", false
+}
+
+func (d Deck) estimateCards() []string {
+	return lo.Reject(d.Cards, func(card string, _ int) bool {
+		return slices.Contains(nonEstimateCards, card)
+	})
+}
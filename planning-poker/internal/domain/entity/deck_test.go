@@ -0,0 +1,228 @@
+package entity_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"planning-poker/internal/domain/domainerror"
+	"planning-poker/internal/domain/entity"
+	"planning-poker/internal/infra/boundaries/hub/clientcollection"
+
+	"github.com/samber/lo"
+)
+
+func TestNewDeck(t *testing.T) {
+	tests := []struct {
+		name      string
+		deckName  string
+		cards     []string
+		wantName  string
+		wantCards []string
+		wantErr   bool
+	}{
+		{
+			name:      "empty name falls back to fibonacci",
+			wantName:  entity.DeckFibonacci,
+			wantCards: entity.DefaultDeck().Cards,
+		},
+		{
+			name:      "built-in deck ignores cards",
+			deckName:  entity.DeckPowersOfTwo,
+			cards:     []string{"a"},
+			wantName:  entity.DeckPowersOfTwo,
+			wantCards: []string{"0", "1", "2", "4", "8", "16", "32", "64", "?", "☕"},
+		},
+		{
+			name:      "custom deck trims cards",
+			deckName:  entity.DeckCustom,
+			cards:     []string{" small ", "big", "?"},
+			wantName:  entity.DeckCustom,
+			wantCards: []string{"small", "big", "?"},
+		},
+		{
+			name:     "unknown deck",
+			deckName: "unknown",
+			wantErr:  true,
+		},
+		{
+			name:     "custom deck with duplicated cards",
+			deckName: entity.DeckCustom,
+			cards:    []string{"1", "1"},
+			wantErr:  true,
+		},
+		{
+			name:     "custom deck with empty card",
+			deckName: entity.DeckCustom,
+			cards:    []string{"1", " "},
+			wantErr:  true,
+		},
+		{
+			name:     "custom deck without estimate cards",
+			deckName: entity.DeckCustom,
+			cards:    []string{"?", "☕"},
+			wantErr:  true,
+		},
+		{
+			name:     "custom deck with long card",
+			deckName: entity.DeckCustom,
+			cards:    []string{"way too long"},
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deck, err := entity.NewDeck(tt.deckName, tt.cards)
+			if tt.wantErr {
+				if !errors.Is(err, domainerror.ErrInvalidDeck) {
+					t.Fatalf("expected ErrInvalidDeck, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if deck.Name != tt.wantName {
+				t.Errorf("expected name %s, got %s", tt.wantName, deck.Name)
+			}
+			if !reflect.DeepEqual(deck.Cards, tt.wantCards) {
+				t.Errorf("expected cards %v, got %v", tt.wantCards, deck.Cards)
+			}
+		})
+	}
+}
+
+func TestDeck_Score(t *testing.T) {
+	fibonacci := entity.DefaultDeck()
+	tshirt, _ := entity.BuiltInDeck(entity.DeckTShirt)
+
+	tests := []struct {
+		name      string
+		deck      entity.Deck
+		card      string
+		wantScore int
+		wantOk    bool
+	}{
+		{name: "numeric card scores by value", deck: fibonacci, card: "13", wantScore: 13, wantOk: true},
+		{name: "ordinal card scores by position", deck: tshirt, card: "M", wantScore: 3, wantOk: true},
+		{name: "non-estimate card has no score", deck: tshirt, card: "?", wantOk: false},
+		{name: "card outside deck has no score", deck: fibonacci, card: "4", wantOk: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			score, ok := tt.deck.Score(tt.card)
+			if ok != tt.wantOk {
+				t.Fatalf("expected ok %v, got %v", tt.wantOk, ok)
+			}
+			if score != tt.wantScore {
+				t.Errorf("expected score %d, got %d", tt.wantScore, score)
+			}
+			if ok {
+				if card, _ := tt.deck.Card(score); card != tt.card {
+					t.Errorf("expected Card(%d) to be %s, got %s", score, tt.card, card)
+				}
+			}
+		})
+	}
+}
+
+func TestRoom_VoteRejectsCardsOutsideDeck(t *testing.T) {
+	ctx := context.Background()
+	room := entity.NewRoom(clientcollection.New())
+	room.NewClient("client1")
+	room.NewClient("client2")
+
+	err := room.Vote(ctx, "client1", lo.ToPtr("4"))
+	if !errors.Is(err, domainerror.ErrInvalidVote) {
+		t.Fatalf("expected ErrInvalidVote, got %v", err)
+	}
+
+	if err := room.Vote(ctx, "client1", lo.ToPtr("☕")); err != nil {
+		t.Fatalf("expected special card to be accepted, got %v", err)
+	}
+	if err := room.Vote(ctx, "client1", lo.ToPtr("")); err != nil {
+		t.Fatalf("expected vote to be cleared, got %v", err)
+	}
+}
+
+func TestRoom_RevealWithOrdinalDeck(t *testing.T) {
+	ctx := context.Background()
+	room := entity.NewRoom(clientcollection.New())
+	room.Deck, _ = entity.BuiltInDeck(entity.DeckTShirt)
+	room.NewClient("client1")
+	room.NewClient("client2")
+	room.NewClient("client3")
+
+	for clientID, vote := range map[string]string{"client1": "S", "client2": "L", "client3": "L"} {
+		if err := room.Vote(ctx, clientID, lo.ToPtr(vote)); err != nil {
+			t.Fatalf("unexpected error voting %s: %v", vote, err)
+		}
+	}
+
+	if !room.Reveal {
+		t.Fatal("expected room to be revealed after everyone voted")
+	}
+	// S=2, L=4
+	if room.Result == nil || *room.Result != float32(10)/3 {
+		t.Errorf("expected result %v, got %v", float32(10)/3, lo.FromPtr(room.Result))
+	}
+	if !reflect.DeepEqual(room.MostAppearingVotes, []int{4}) {
+		t.Errorf("expected most appearing votes [4], got %v", room.MostAppearingVotes)
+	}
+}
+
+func TestRoom_ChangeDeck(t *testing.T) {
+	ctx := context.Background()
+	tshirt, _ := entity.BuiltInDeck(entity.DeckTShirt)
+
+	t.Run("owner changes deck and votes are cleared", func(t *testing.T) {
+		room := entity.NewRoom(clientcollection.New())
+		owner := room.NewClient("owner")
+		room.NewClient("client1")
+		_ = room.Vote(ctx, "owner", lo.ToPtr("5"))
+
+		if err := room.ChangeDeck(ctx, "owner", tshirt); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if room.EffectiveDeck().Name != entity.DeckTShirt {
+			t.Errorf("expected deck %s, got %s", entity.DeckTShirt, room.EffectiveDeck().Name)
+		}
+		if owner.HasVoted || owner.CurrentVote != nil {
+			t.Error("expected votes to be cleared after changing the deck")
+		}
+	})
+
+	t.Run("non-owner cannot change deck", func(t *testing.T) {
+		room := entity.NewRoom(clientcollection.New())
+		room.NewClient("owner")
+		room.NewClient("client1")
+
+		if err := room.ChangeDeck(ctx, "client1", tshirt); err == nil {
+			t.Error("expected error for non-owner")
+		}
+		if room.EffectiveDeck().Name != entity.DeckFibonacci {
+			t.Errorf("expected deck to stay %s, got %s", entity.DeckFibonacci, room.EffectiveDeck().Name)
+		}
+	})
+
+	t.Run("empty deck is rejected", func(t *testing.T) {
+		room := entity.NewRoom(clientcollection.New())
+		room.NewClient("owner")
+
+		if err := room.ChangeDeck(ctx, "owner", entity.Deck{}); !errors.Is(err, domainerror.ErrInvalidDeck) {
+			t.Errorf("expected ErrInvalidDeck, got %v", err)
+		}
+	})
+}
+
+func TestRoom_EffectiveDeck_DefaultsWhenUnset(t *testing.T) {
+	room := &entity.Room{ID: "room1", Clients: clientcollection.New()}
+
+	if room.EffectiveDeck().Name != entity.DeckFibonacci {
+		t.Errorf("expected default deck %s, got %s", entity.DeckFibonacci, room.EffectiveDeck().Name)
+	}
+}
@@ -0,0 +1,241 @@
+package entity
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+)
+
+type RoomEventType string
+
+const (
+	EventRoomCreated           RoomEventType = "room-created"
+	EventRoomConfigured        RoomEventType = "room-configured"
+	EventClientJoined          RoomEventType = "client-joined"
+	EventClientLeft            RoomEventType = "client-left"
+	EventBotAdded              RoomEventType = "bot-added"
+	EventClientRenamed         RoomEventType = "client-renamed"
+	EventVoteCast              RoomEventType = "vote-cast"
+	EventVotingStarted         RoomEventType = "voting-started"
+	EventVotingReset           RoomEventType = "voting-reset"
+	EventRevealToggled         RoomEventType = "reveal-toggled"
+	EventBacklogModeToggled    RoomEventType = "backlog-mode-toggled"
+	EventStoryAdded            RoomEventType = "story-added"
+	EventStoryRemoved          RoomEventType = "story-removed"
+	EventNextStorySelected     RoomEventType = "next-story-selected"
+	EventPreviousStorySelected RoomEventType = "previous-story-selected"
+	EventCurrentStorySet       RoomEventType = "current-story-set"
+	EventDeckChanged           RoomEventType = "deck-changed"
+	EventConsensusRuleChanged  RoomEventType = "consensus-rule-changed"
+	EventSpectatorToggled      RoomEventType = "spectator-toggled"
+	EventOwnerToggled          RoomEventType = "owner-toggled"
+	EventVotingTimerStarted    RoomEventType = "voting-timer-started"
+	EventVotingTimerCancelled  RoomEventType = "voting-timer-cancelled"
+	EventVotingTimerExpired    RoomEventType = "voting-timer-expired"
+	EventPasscodeChanged       RoomEventType = "passcode-changed"
+	EventInviteCreated         RoomEventType = "invite-created"
+	EventInviteSecretRotated   RoomEventType = "invite-secret-rotated"
+	EventPermissionChanged     RoomEventType = "permission-changed"
+	EventPermissionReset       RoomEventType = "permission-reset"
+)
+
+// RoomEvent is a change to the state of a room. Only the fields relevant to
+// the event type are set. Events carry every value needed to apply them again,
+// including generated ones such as hashes and secrets, so replaying the log of
+// a room always rebuilds the same state.
+type RoomEvent struct {
+	Sequence int64
+	Type     RoomEventType
+	// participant that caused the change, empty for admin and system changes
+	ClientID string
+	// participant affected by the change
+	TargetID string
+	// verified identity of the participant that joined
+	Subject      string
+	Name         string
+	Index        int
+	Vote         *string
+	Deck         *Deck
+	Rule         ConsensusRule
+	Action       Action
+	Roles        []Role
+	Deadline     *time.Time
+	PasscodeHash string
+	InviteSecret []byte
+	TokenHash    string
+	OccurredAt   time.Time
+}
+
+// RebuildRoom replays the whole log of a room.
+func RebuildRoom(ctx context.Context, id string, clients ClientCollection, events []RoomEvent) (*Room, error) {
+	room := newRoom(id, clients)
+	for _, event := range events {
+		if err := room.Apply(ctx, event); err != nil {
+			return nil, err
+		}
+	}
+	return room, nil
+}
+
+// PendingEvents returns the events recorded since the room was loaded that
+// were not persisted yet.
+func (r *Room) PendingEvents() []RoomEvent {
+	return slices.Clone(r.pendingEvents)
+}
+
+// BaseVersion is the version the room was loaded at. Saving the room only
+// succeeds while it is still the stored version.
+func (r *Room) BaseVersion() int64 {
+	return r.Version - int64(len(r.pendingEvents))
+}
+
+func (r *Room) ClearPendingEvents() {
+	r.pendingEvents = nil
+}
+
+// Touch records when the pending events of the room were stored. Hubs call it
+// as they store them; the first time also dates the creation of the room.
+func (r *Room) Touch(at time.Time) {
+	if len(r.pendingEvents) == 0 {
+		return
+	}
+	r.UpdatedAt = at
+	if r.CreatedAt.IsZero() {
+		r.CreatedAt = at
+	}
+}
+
+// Apply changes the room according to an event that was already accepted,
+// for example when replaying its log. Events must be applied in order.
+func (r *Room) Apply(ctx context.Context, event RoomEvent) error {
+	if event.Sequence != r.Version+1 {
+		return fmt.Errorf("event %d cannot be applied to room %s at version %d", event.Sequence, r.ID, r.Version)
+	}
+	if !r.apply(ctx, event) {
+		return fmt.Errorf("unknown event type %q for room %s", event.Type, r.ID)
+	}
+	r.Version = event.Sequence
+	if !event.OccurredAt.IsZero() {
+		r.UpdatedAt = event.OccurredAt
+		if r.CreatedAt.IsZero() {
+			r.CreatedAt = event.OccurredAt
+		}
+	}
+	return nil
+}
+
+// record applies an event produced by a command and keeps it until the hub
+// persists it. Callers check permissions and arguments beforehand.
+func (r *Room) record(ctx context.Context, event RoomEvent) {
+	r.apply(ctx, event)
+	r.commit(event)
+}
+
+// commit keeps an event whose change the command already made itself, for
+// commands that hold the clients involved and should not look them up again.
+func (r *Room) commit(event RoomEvent) {
+	r.Version++
+	event.Sequence = r.Version
+	r.pendingEvents = append(r.pendingEvents, event)
+}
+
+// apply reports false for event types it does not know.
+func (r *Room) apply(ctx context.Context, event RoomEvent) bool {
+	switch event.Type {
+	case EventRoomCreated:
+		// nothing changes, kept for the timeline
+	case EventInviteCreated:
+		r.InviteOnly = true
+	case EventRoomConfigured:
+		if event.Deck != nil {
+			r.Deck = *event.Deck
+		}
+		r.PasscodeHash = event.PasscodeHash
+	case EventClientJoined:
+		client := r.addClient(event.TargetID)
+		client.Subject = event.Subject
+		client.Name = event.Name
+	case EventClientLeft:
+		r.removeClient(event.TargetID)
+	case EventBotAdded:
+		r.addBot(event.TargetID, event.Name, event.TokenHash)
+	case EventClientRenamed:
+		if client, ok := r.FindClient(event.TargetID); ok {
+			client.UpdateName(ctx, event.Name)
+		}
+	case EventVoteCast:
+		if client, ok := r.FindClient(event.ClientID); ok {
+			r.castVote(ctx, client, event.Vote)
+		}
+	case EventVotingStarted:
+		if !r.BacklogMode {
+			r.CurrentStory = ""
+		}
+		r.clearVotes(ctx)
+	case EventVotingReset:
+		r.clearVotes(ctx)
+	case EventDeckChanged:
+		r.Deck = *event.Deck
+		// votes cast with the previous deck may not exist on the new one
+		r.clearVotes(ctx)
+	case EventRevealToggled:
+		r.reveal(!r.Reveal)
+		if r.Reveal {
+			r.storeCurrentStoryResult()
+		}
+	case EventBacklogModeToggled:
+		r.toggleBacklogMode()
+	case EventStoryAdded:
+		r.addStory(event.Name)
+	case EventStoryRemoved:
+		r.removeStory(ctx, event.Index)
+	case EventNextStorySelected:
+		if r.CurrentStoryIndex < len(r.Stories)-1 {
+			r.CurrentStoryIndex++
+			r.clearVotes(ctx)
+		}
+	case EventPreviousStorySelected:
+		if r.CurrentStoryIndex > 0 {
+			r.CurrentStoryIndex--
+			r.clearVotes(ctx)
+		}
+	case EventCurrentStorySet:
+		r.setCurrentStory(event.Name)
+	case EventConsensusRuleChanged:
+		r.ConsensusRule = event.Rule
+		if r.Reveal {
+			r.reveal(true)
+			r.storeCurrentStoryResult()
+		}
+	case EventSpectatorToggled:
+		if target, ok := r.FindClient(event.TargetID); ok {
+			r.toggleSpectator(ctx, target)
+		}
+	case EventOwnerToggled:
+		if target, ok := r.FindClient(event.TargetID); ok {
+			target.IsOwner = !target.IsOwner
+		}
+	case EventVotingTimerStarted:
+		r.VotingDeadline = event.Deadline
+	case EventVotingTimerCancelled:
+		r.VotingDeadline = nil
+	case EventVotingTimerExpired:
+		r.reveal(true)
+		r.storeCurrentStoryResult()
+	case EventPasscodeChanged:
+		r.PasscodeHash = event.PasscodeHash
+	case EventInviteSecretRotated:
+		r.InviteSecret = event.InviteSecret
+	case EventPermissionChanged:
+		if r.Policy.Overrides == nil {
+			r.Policy.Overrides = make(map[Action][]Role)
+		}
+		r.Policy.Overrides[event.Action] = event.Roles
+	case EventPermissionReset:
+		delete(r.Policy.Overrides, event.Action)
+	default:
+		return false
+	}
+	return true
+}
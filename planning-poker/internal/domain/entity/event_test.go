@@ -0,0 +1,119 @@
+package entity_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"planning-poker/internal/domain/entity"
+	"planning-poker/internal/infra/boundaries/hub/clientcollection"
+)
+
+func TestRebuildRoom_ReproducesRecordedState(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	vote := "5"
+
+	room := entity.NewRoomWithID("room1", clientcollection.New())
+	room.NewClient("owner")
+	room.NewClient("voter")
+	commands := []func() error{
+		func() error { return room.UpdateClientName(ctx, "voter", "Alice") },
+		func() error { return room.ToggleBacklogMode(ctx, "owner") },
+		func() error { return room.AddStory(ctx, "owner", "Login") },
+		func() error { return room.AddStory(ctx, "owner", "Logout") },
+		func() error { return room.SetPasscode(ctx, "owner", "s3cret") },
+		func() error {
+			return room.ChangePermission(ctx, "owner", entity.ActionAddStory, []entity.Role{entity.RoleVoter})
+		},
+		func() error { return room.StartVotingTimer(ctx, "owner", now, time.Minute) },
+		func() error { return room.Vote(ctx, "voter", &vote) },
+		func() error { return room.ToggleReveal(ctx, "owner") },
+		func() error { return room.RemoveClient(ctx, "owner") },
+	}
+	for i, command := range commands {
+		if err := command(); err != nil {
+			t.Fatalf("command %d failed: %v", i, err)
+		}
+	}
+
+	events := room.PendingEvents()
+	if int64(len(events)) != room.Version {
+		t.Fatalf("expected %d events, got %d", room.Version, len(events))
+	}
+
+	rebuilt, err := entity.RebuildRoom(ctx, "room1", clientcollection.New(), events)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if rebuilt.Version != room.Version {
+		t.Errorf("expected version %d, got %d", room.Version, rebuilt.Version)
+	}
+	if !reflect.DeepEqual(rebuilt.Stories, room.Stories) {
+		t.Errorf("expected stories %v, got %v", room.Stories, rebuilt.Stories)
+	}
+	if rebuilt.Reveal != room.Reveal {
+		t.Errorf("expected reveal %v, got %v", room.Reveal, rebuilt.Reveal)
+	}
+	if rebuilt.PasscodeHash != room.PasscodeHash {
+		t.Error("expected passcode hash to be replayed")
+	}
+	if !reflect.DeepEqual(rebuilt.Policy, room.Policy) {
+		t.Errorf("expected policy %v, got %v", room.Policy, rebuilt.Policy)
+	}
+	if !reflect.DeepEqual(rebuilt.VotingDeadline, room.VotingDeadline) {
+		t.Errorf("expected deadline %v, got %v", room.VotingDeadline, rebuilt.VotingDeadline)
+	}
+	if len(rebuilt.PendingEvents()) != 0 {
+		t.Error("expected replayed events not to be pending")
+	}
+
+	client, ok := rebuilt.FindClient("voter")
+	if !ok {
+		t.Fatal("expected voter to be in the rebuilt room")
+	}
+	if client.Name != "Alice" {
+		t.Errorf("expected name Alice, got %s", client.Name)
+	}
+	if client.CurrentVote == nil || *client.CurrentVote != vote {
+		t.Errorf("expected vote %s, got %v", vote, client.CurrentVote)
+	}
+	if !client.IsOwner {
+		t.Error("expected voter to become owner after the owner left")
+	}
+	if _, ok := rebuilt.FindClient("owner"); ok {
+		t.Error("expected owner to have left the rebuilt room")
+	}
+}
+
+func TestRoom_Apply_RejectsOutOfOrderEvents(t *testing.T) {
+	ctx := context.Background()
+	room := entity.NewRoomWithID("room1", clientcollection.New())
+	room.ClearPendingEvents()
+
+	if err := room.Apply(ctx, entity.RoomEvent{Sequence: 3, Type: entity.EventStoryAdded, Name: "Login"}); err == nil {
+		t.Error("expected error for an event applied out of order")
+	}
+	if err := room.Apply(ctx, entity.RoomEvent{Sequence: 2, Type: "unknown"}); err == nil {
+		t.Error("expected error for an unknown event type")
+	}
+
+	if err := room.Apply(ctx, entity.RoomEvent{Sequence: 2, Type: entity.EventBacklogModeToggled}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := room.Apply(ctx, entity.RoomEvent{Sequence: 3, Type: entity.EventStoryAdded, Name: "Login"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if room.Version != 3 {
+		t.Errorf("expected version 3, got %d", room.Version)
+	}
+	if !reflect.DeepEqual(room.Stories, []entity.Story{{Name: "Login"}}) {
+		t.Errorf("unexpected stories %v", room.Stories)
+	}
+	if len(room.PendingEvents()) != 0 {
+		t.Error("expected applied events not to be pending")
+	}
+}
@@ -0,0 +1,26 @@
+package entity
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+)
+
+// Identity is a participant verified by the identity provider. The zero
+// value is an anonymous participant.
+type Identity struct {
+	Issuer  string
+	Subject string
+	// Name is the display name taken from the claims of the participant
+	Name string
+}
+
+func (i Identity) Authenticated() bool {
+	return i.Subject != ""
+}
+
+// ClientID derives the ID of an authenticated participant from its subject,
+// so that it keeps its seat across reconnects and devices.
+func (i Identity) ClientID() string {
+	sum := sha256.Sum256([]byte(i.Issuer + "\x00" + i.Subject))
+	return "user-" + base64.RawURLEncoding.EncodeToString(sum[:16])
+}
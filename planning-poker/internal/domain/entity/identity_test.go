@@ -0,0 +1,51 @@
+package entity_test
+
+import (
+	"context"
+	"planning-poker/internal/domain/entity"
+	"planning-poker/internal/infra/boundaries/hub/clientcollection"
+	"testing"
+)
+
+func TestIdentity_ClientID(t *testing.T) {
+	alice := entity.Identity{Issuer: "https://idp.example.com", Subject: "alice"}
+
+	if alice.ClientID() != alice.ClientID() {
+		t.Error("expected the client ID to be stable")
+	}
+	if alice.ClientID() == (entity.Identity{Issuer: "https://idp.example.com", Subject: "bob"}).ClientID() {
+		t.Error("expected subjects to have different client IDs")
+	}
+	if alice.ClientID() == (entity.Identity{Issuer: "https://other.example.com", Subject: "alice"}).ClientID() {
+		t.Error("expected issuers to have different client IDs")
+	}
+}
+
+func TestRoom_NewClientWithIdentity_SurvivesReplay(t *testing.T) {
+	alice := entity.Identity{Issuer: "https://idp.example.com", Subject: "alice", Name: "Alice"}
+	room := entity.NewRoomWithID("room1", clientcollection.New())
+
+	client := room.NewClientWithIdentity(alice.ClientID(), alice)
+	room.NewClient("anonymous")
+	if client.Subject != "alice" || client.Name != "Alice" {
+		t.Fatalf("expected the client to carry the identity, got %+v", client)
+	}
+
+	rebuilt, err := entity.RebuildRoom(context.Background(), "room1", clientcollection.New(), room.PendingEvents())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	replayed, ok := rebuilt.FindClient(alice.ClientID())
+	if !ok || replayed.Subject != "alice" || replayed.Name != "Alice" {
+		t.Fatalf("expected the identity to be replayed, got %+v", replayed)
+	}
+	if !replayed.BelongsTo(alice) || replayed.BelongsTo(entity.Identity{}) {
+		t.Error("expected the seat to belong to its subject only")
+	}
+
+	anonymous, _ := rebuilt.FindClient("anonymous")
+	if !anonymous.BelongsTo(entity.Identity{}) || anonymous.BelongsTo(alice) {
+		t.Error("expected the anonymous seat to belong to anonymous participants only")
+	}
+}
@@ -0,0 +1,61 @@
+package entity
+
+import "time"
+
+type (
+	// RoomLifecycle tells when rooms expire. Zero durations never expire.
+	RoomLifecycle struct {
+		// IdleTimeout is how long a room lives without changes
+		IdleTimeout time.Duration
+		// MaxLifetime is how long a room lives since it was created, whatever
+		// happens in it
+		MaxLifetime time.Duration
+		// EmptyRoomTTL is how long a room without clients is kept, so that its
+		// participants can come back. Without it, empty rooms are removed as
+		// soon as the last client leaves.
+		EmptyRoomTTL time.Duration
+	}
+
+	ExpiryReason string
+)
+
+const (
+	ExpiryIdle        ExpiryReason = "idle"
+	ExpiryMaxLifetime ExpiryReason = "max-lifetime"
+	ExpiryEmpty       ExpiryReason = "empty"
+)
+
+func (l RoomLifecycle) Enabled() bool {
+	return l.IdleTimeout > 0 || l.MaxLifetime > 0 || l.EmptyRoomTTL > 0
+}
+
+// KeepsEmptyRooms tells whether rooms outlive their last client.
+func (l RoomLifecycle) KeepsEmptyRooms() bool {
+	return l.EmptyRoomTTL > 0
+}
+
+// Expiry returns when the room expires under the lifecycle and why. Rooms
+// stored before their timestamps were tracked do not expire until they
+// change again.
+func (r *Room) Expiry(lifecycle RoomLifecycle) (time.Time, ExpiryReason, bool) {
+	var expiresAt time.Time
+	var reason ExpiryReason
+	candidate := func(at time.Time, why ExpiryReason) {
+		if reason == "" || at.Before(expiresAt) {
+			expiresAt, reason = at, why
+		}
+	}
+
+	if !r.UpdatedAt.IsZero() {
+		if r.IsEmpty() && lifecycle.EmptyRoomTTL > 0 {
+			candidate(r.UpdatedAt.Add(lifecycle.EmptyRoomTTL), ExpiryEmpty)
+		} else if lifecycle.IdleTimeout > 0 {
+			candidate(r.UpdatedAt.Add(lifecycle.IdleTimeout), ExpiryIdle)
+		}
+	}
+	if !r.CreatedAt.IsZero() && lifecycle.MaxLifetime > 0 {
+		candidate(r.CreatedAt.Add(lifecycle.MaxLifetime), ExpiryMaxLifetime)
+	}
+
+	return expiresAt, reason, reason != ""
+}
@@ -0,0 +1,119 @@
+package entity_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"planning-poker/internal/domain/entity"
+	"planning-poker/internal/infra/boundaries/hub/clientcollection"
+)
+
+func TestRoom_Expiry(t *testing.T) {
+	created := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	updated := created.Add(time.Hour)
+
+	tests := []struct {
+		name       string
+		lifecycle  entity.RoomLifecycle
+		empty      bool
+		onlyBots   bool
+		untracked  bool
+		wantOK     bool
+		wantAt     time.Time
+		wantReason entity.ExpiryReason
+	}{
+		{name: "no policy", lifecycle: entity.RoomLifecycle{}},
+		{
+			name:       "idle",
+			lifecycle:  entity.RoomLifecycle{IdleTimeout: 2 * time.Hour},
+			wantOK:     true,
+			wantAt:     updated.Add(2 * time.Hour),
+			wantReason: entity.ExpiryIdle,
+		},
+		{
+			name:       "max lifetime comes first",
+			lifecycle:  entity.RoomLifecycle{IdleTimeout: 2 * time.Hour, MaxLifetime: 2 * time.Hour},
+			wantOK:     true,
+			wantAt:     created.Add(2 * time.Hour),
+			wantReason: entity.ExpiryMaxLifetime,
+		},
+		{
+			name:       "empty room kept for its ttl",
+			lifecycle:  entity.RoomLifecycle{IdleTimeout: 2 * time.Hour, EmptyRoomTTL: 30 * time.Minute},
+			empty:      true,
+			wantOK:     true,
+			wantAt:     updated.Add(30 * time.Minute),
+			wantReason: entity.ExpiryEmpty,
+		},
+		{
+			name:       "room with only bots left is empty",
+			lifecycle:  entity.RoomLifecycle{IdleTimeout: 2 * time.Hour, EmptyRoomTTL: 30 * time.Minute},
+			empty:      true,
+			onlyBots:   true,
+			wantOK:     true,
+			wantAt:     updated.Add(30 * time.Minute),
+			wantReason: entity.ExpiryEmpty,
+		},
+		{
+			name:       "empty room without ttl goes idle",
+			lifecycle:  entity.RoomLifecycle{IdleTimeout: 2 * time.Hour},
+			empty:      true,
+			wantOK:     true,
+			wantAt:     updated.Add(2 * time.Hour),
+			wantReason: entity.ExpiryIdle,
+		},
+		{
+			name:      "room stored before timestamps were tracked",
+			lifecycle: entity.RoomLifecycle{IdleTimeout: time.Hour, MaxLifetime: time.Hour},
+			untracked: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			room := entity.NewRoomWithID("room1", clientcollection.New())
+			if tt.onlyBots {
+				if _, err := room.NewBot(context.Background(), ""); err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+			}
+			if !tt.empty {
+				room.NewClient("client1")
+			}
+			if !tt.untracked {
+				room.CreatedAt = created
+				room.UpdatedAt = updated
+			}
+
+			at, reason, ok := room.Expiry(tt.lifecycle)
+
+			if ok != tt.wantOK {
+				t.Fatalf("expected ok %v, got %v", tt.wantOK, ok)
+			}
+			if !at.Equal(tt.wantAt) || reason != tt.wantReason {
+				t.Errorf("expected expiry at %v for %q, got %v for %q", tt.wantAt, tt.wantReason, at, reason)
+			}
+		})
+	}
+}
+
+func TestRoom_Touch(t *testing.T) {
+	first := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	second := first.Add(time.Minute)
+
+	room := entity.NewRoomWithID("room1", clientcollection.New())
+	room.Touch(first)
+	room.ClearPendingEvents()
+
+	room.Touch(second)
+	if !room.UpdatedAt.Equal(first) {
+		t.Errorf("expected a room without pending events to keep its update time, got %v", room.UpdatedAt)
+	}
+
+	room.NewClient("client1")
+	room.Touch(second)
+	if !room.CreatedAt.Equal(first) || !room.UpdatedAt.Equal(second) {
+		t.Errorf("expected created at %v and updated at %v, got %v and %v", first, second, room.CreatedAt, room.UpdatedAt)
+	}
+}
@@ -0,0 +1,185 @@
+package entity
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"planning-poker/internal/domain/domainerror"
+)
+
+type (
+	Role   string
+	Action string
+)
+
+const (
+	// facilitators run the session and may perform every action
+	RoleFacilitator Role = "facilitator"
+	RoleVoter       Role = "voter"
+	// observers follow the session without estimating
+	RoleObserver Role = "observer"
+	// bots drive the session through the API like facilitators, but cannot
+	// manage who runs it
+	RoleBot Role = "bot"
+)
+
+const (
+	ActionVote                Action = "vote"
+	ActionToggleReveal        Action = "toggle-reveal"
+	ActionNewVoting           Action = "new-voting"
+	ActionToggleBacklogMode   Action = "toggle-backlog-mode"
+	ActionAddStory            Action = "add-story"
+	ActionRemoveStory         Action = "remove-story"
+	ActionSetCurrentStory     Action = "set-current-story"
+	ActionNavigateStories     Action = "navigate-stories"
+	ActionChangeDeck          Action = "change-deck"
+	ActionChangeConsensusRule Action = "change-consensus-rule"
+	ActionManageTimer         Action = "manage-timer"
+	ActionToggleSpectator     Action = "toggle-spectator"
+	ActionExportReport        Action = "export-report"
+	ActionToggleOwner         Action = "toggle-owner"
+	ActionManageAccess        Action = "manage-access"
+	ActionManagePermissions   Action = "manage-permissions"
+)
+
+// Roles other than facilitator allowed to perform each action when the room
+// does not override it. Actions missing from the map are facilitator only.
+var defaultPermissions = map[Action][]Role{
+	ActionVote: {RoleVoter},
+}
+
+// Actions that cannot be delegated, otherwise anyone could promote
+// themselves or open the room.
+var lockedActions = []Action{
+	ActionToggleOwner,
+	ActionManageAccess,
+	ActionManagePermissions,
+}
+
+var actions = []Action{
+	ActionVote,
+	ActionToggleReveal,
+	ActionNewVoting,
+	ActionToggleBacklogMode,
+	ActionAddStory,
+	ActionRemoveStory,
+	ActionSetCurrentStory,
+	ActionNavigateStories,
+	ActionChangeDeck,
+	ActionChangeConsensusRule,
+	ActionManageTimer,
+	ActionToggleSpectator,
+	ActionExportReport,
+	ActionToggleOwner,
+	ActionManageAccess,
+	ActionManagePermissions,
+}
+
+// Policy is the permission matrix of a room. Overrides replace the default
+// roles of an action; facilitators are always allowed.
+type Policy struct {
+	Overrides map[Action][]Role
+}
+
+func Actions() []Action {
+	return slices.Clone(actions)
+}
+
+func ParseAction(action string) (Action, error) {
+	if !slices.Contains(actions, Action(action)) {
+		return "", fmt.Errorf("unknown action %q: %w", action, domainerror.ErrInvalidPermission)
+	}
+	return Action(action), nil
+}
+
+func ParseRole(role string) (Role, error) {
+	switch Role(role) {
+	case RoleFacilitator, RoleVoter, RoleObserver:
+		return Role(role), nil
+	default:
+		return "", fmt.Errorf("unknown role %q: %w", role, domainerror.ErrInvalidPermission)
+	}
+}
+
+// Roles returns every role allowed to perform the action.
+func (p Policy) Roles(action Action) []Role {
+	roles, ok := p.Overrides[action]
+	if !ok {
+		roles = defaultPermissions[action]
+	}
+	return append([]Role{RoleFacilitator}, roles...)
+}
+
+func (p Policy) Allows(role Role, action Action) bool {
+	if role == RoleBot {
+		return !slices.Contains(lockedActions, action)
+	}
+	return slices.Contains(p.Roles(action), role)
+}
+
+// Matrix returns the effective roles of every action.
+func (p Policy) Matrix() map[Action][]Role {
+	matrix := make(map[Action][]Role, len(actions))
+	for _, action := range actions {
+		matrix[action] = p.Roles(action)
+	}
+	return matrix
+}
+
+// CheckPermission returns the client when its role allows the action.
+func (r *Room) CheckPermission(clientID string, action Action) (*Client, error) {
+	client, ok := r.FindClient(clientID)
+	if !ok {
+		return nil, fmt.Errorf("client %s not found in room %s", clientID, r.ID)
+	}
+
+	role := client.Role()
+	if !r.Policy.Allows(role, action) {
+		return nil, &domainerror.PermissionError{
+			RoomID:   r.ID,
+			ClientID: clientID,
+			Role:     string(role),
+			Action:   string(action),
+		}
+	}
+
+	return client, nil
+}
+
+// ChangePermission sets which roles besides facilitators may perform the
+// action. Setting the default roles removes the override.
+func (r *Room) ChangePermission(ctx context.Context, clientID string, action Action, roles []Role) error {
+	if _, err := r.CheckPermission(clientID, ActionManagePermissions); err != nil {
+		return err
+	}
+	if !slices.Contains(actions, action) {
+		return fmt.Errorf("unknown action %q: %w", action, domainerror.ErrInvalidPermission)
+	}
+	if slices.Contains(lockedActions, action) {
+		return fmt.Errorf("action %s is restricted to facilitators: %w", action, domainerror.ErrInvalidPermission)
+	}
+
+	normalized := normalizeRoles(roles)
+	if slices.Equal(normalized, normalizeRoles(defaultPermissions[action])) {
+		r.record(ctx, RoomEvent{Type: EventPermissionReset, ClientID: clientID, Action: action})
+		return nil
+	}
+
+	r.record(ctx, RoomEvent{Type: EventPermissionChanged, ClientID: clientID, Action: action, Roles: normalized})
+
+	return nil
+}
+
+// normalizeRoles drops facilitators, which are implicit, and duplicates so
+// that equal sets of roles compare equal.
+func normalizeRoles(roles []Role) []Role {
+	normalized := []Role{}
+	for _, role := range roles {
+		if role != RoleFacilitator && !slices.Contains(normalized, role) {
+			normalized = append(normalized, role)
+		}
+	}
+	slices.Sort(normalized)
+	return normalized
+}
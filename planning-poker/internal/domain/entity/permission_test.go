@@ -0,0 +1,187 @@
+package entity_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"planning-poker/internal/domain/domainerror"
+	"planning-poker/internal/domain/entity"
+	"planning-poker/internal/infra/boundaries/hub/clientcollection"
+)
+
+func newRoleRoom() *entity.Room {
+	room := entity.NewRoom(clientcollection.New())
+	room.NewClient("owner")
+	room.NewClient("voter")
+	room.NewClient("observer").IsSpectator = true
+	return room
+}
+
+func TestClient_Role(t *testing.T) {
+	room := newRoleRoom()
+
+	want := map[string]entity.Role{
+		"owner":    entity.RoleFacilitator,
+		"voter":    entity.RoleVoter,
+		"observer": entity.RoleObserver,
+	}
+	for id, role := range want {
+		client, _ := room.FindClient(id)
+		if got := client.Role(); got != role {
+			t.Errorf("Role() of %s = %s, want %s", id, got, role)
+		}
+	}
+}
+
+func TestRoom_CheckPermission(t *testing.T) {
+	tests := []struct {
+		name     string
+		clientID string
+		action   entity.Action
+		allowed  bool
+	}{
+		{name: "facilitator may reveal", clientID: "owner", action: entity.ActionToggleReveal, allowed: true},
+		{name: "voter may not reveal", clientID: "voter", action: entity.ActionToggleReveal},
+		{name: "voter may vote", clientID: "voter", action: entity.ActionVote, allowed: true},
+		{name: "observer may not vote", clientID: "observer", action: entity.ActionVote},
+		{name: "facilitator may manage permissions", clientID: "owner", action: entity.ActionManagePermissions, allowed: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			room := newRoleRoom()
+
+			_, err := room.CheckPermission(tt.clientID, tt.action)
+
+			if tt.allowed {
+				if err != nil {
+					t.Errorf("expected no error, got %v", err)
+				}
+				return
+			}
+
+			var permErr *domainerror.PermissionError
+			if !errors.As(err, &permErr) {
+				t.Fatalf("expected PermissionError, got %v", err)
+			}
+			if !errors.Is(err, domainerror.ErrPermissionDenied) {
+				t.Error("expected error to match ErrPermissionDenied")
+			}
+			if permErr.Action != string(tt.action) || permErr.ClientID != tt.clientID {
+				t.Errorf("unexpected error details %+v", permErr)
+			}
+		})
+	}
+
+	t.Run("unknown client", func(t *testing.T) {
+		room := newRoleRoom()
+
+		_, err := room.CheckPermission("ghost", entity.ActionVote)
+
+		if err == nil || errors.Is(err, domainerror.ErrPermissionDenied) {
+			t.Errorf("expected client not found error, got %v", err)
+		}
+	})
+}
+
+func TestRoom_CheckPermission_Bot(t *testing.T) {
+	room := newRoleRoom()
+	credentials, err := room.NewBot(context.Background(), "")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	bot, _ := room.FindClient(credentials.ClientID)
+	if got := bot.Role(); got != entity.RoleBot {
+		t.Fatalf("Role() of bot = %s, want %s", got, entity.RoleBot)
+	}
+
+	for _, action := range []entity.Action{entity.ActionToggleReveal, entity.ActionNewVoting, entity.ActionAddStory, entity.ActionExportReport} {
+		if _, err := room.CheckPermission(bot.ID, action); err != nil {
+			t.Errorf("expected bot to be allowed %s, got %v", action, err)
+		}
+	}
+
+	// owning the room does not let a bot manage who runs it
+	bot.IsOwner = true
+	for _, action := range []entity.Action{entity.ActionToggleOwner, entity.ActionManageAccess, entity.ActionManagePermissions} {
+		var permErr *domainerror.PermissionError
+		if _, err := room.CheckPermission(bot.ID, action); !errors.As(err, &permErr) || permErr.Role != string(entity.RoleBot) {
+			t.Errorf("expected bot to be denied %s, got %v", action, err)
+		}
+	}
+}
+
+func TestRoom_ChangePermission(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("anyone may reveal", func(t *testing.T) {
+		room := newRoleRoom()
+
+		err := room.ChangePermission(ctx, "owner", entity.ActionToggleReveal, []entity.Role{entity.RoleVoter, entity.RoleObserver})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if err := room.ToggleReveal(ctx, "observer"); err != nil {
+			t.Errorf("expected observer to reveal, got %v", err)
+		}
+		if !room.Reveal {
+			t.Error("expected room to be revealed")
+		}
+	})
+
+	t.Run("voters may add stories", func(t *testing.T) {
+		room := newRoleRoom()
+
+		if err := room.AddStory(ctx, "voter", "story"); !errors.Is(err, domainerror.ErrPermissionDenied) {
+			t.Fatalf("expected ErrPermissionDenied before the change, got %v", err)
+		}
+
+		if err := room.ChangePermission(ctx, "owner", entity.ActionAddStory, []entity.Role{entity.RoleVoter}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if err := room.AddStory(ctx, "voter", "story"); err != nil {
+			t.Errorf("expected voter to add story, got %v", err)
+		}
+		if err := room.AddStory(ctx, "observer", "story"); !errors.Is(err, domainerror.ErrPermissionDenied) {
+			t.Errorf("expected observer to be denied, got %v", err)
+		}
+	})
+
+	t.Run("restoring the default removes the override", func(t *testing.T) {
+		room := newRoleRoom()
+
+		_ = room.ChangePermission(ctx, "owner", entity.ActionVote, nil)
+		if len(room.Policy.Overrides) != 1 {
+			t.Fatalf("expected one override, got %v", room.Policy.Overrides)
+		}
+
+		_ = room.ChangePermission(ctx, "owner", entity.ActionVote, []entity.Role{entity.RoleVoter, entity.RoleFacilitator})
+		if len(room.Policy.Overrides) != 0 {
+			t.Errorf("expected no overrides, got %v", room.Policy.Overrides)
+		}
+	})
+
+	t.Run("locked actions cannot be delegated", func(t *testing.T) {
+		room := newRoleRoom()
+
+		err := room.ChangePermission(ctx, "owner", entity.ActionToggleOwner, []entity.Role{entity.RoleVoter})
+		if !errors.Is(err, domainerror.ErrInvalidPermission) {
+			t.Errorf("expected ErrInvalidPermission, got %v", err)
+		}
+	})
+
+	t.Run("voter cannot change permissions", func(t *testing.T) {
+		room := newRoleRoom()
+
+		err := room.ChangePermission(ctx, "voter", entity.ActionToggleReveal, []entity.Role{entity.RoleVoter})
+		if !errors.Is(err, domainerror.ErrPermissionDenied) {
+			t.Errorf("expected ErrPermissionDenied, got %v", err)
+		}
+		if len(room.Policy.Overrides) != 0 {
+			t.Errorf("expected no overrides, got %v", room.Policy.Overrides)
+		}
+	})
+}
@@ -5,7 +5,7 @@ package entity
 import (
 	"context"
 	"fmt"
-	"strconv"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/samber/lo"
@@ -34,6 +34,24 @@ type (
 		BacklogMode        bool
 		Stories            []Story
 		CurrentStoryIndex  int
+		Deck               Deck
+		Statistics         *VoteStatistics
+		ConsensusRule      ConsensusRule
+		VotingDeadline     *time.Time
+		PasscodeHash       string
+		InviteSecret       []byte
+		// set once an invite is created, from then on joining requires an
+		// invite or the passcode
+		InviteOnly bool
+		Policy     Policy
+		// number of events applied to the room
+		Version int64
+		// when the first and the last event were stored, zero for rooms stored
+		// before they were tracked
+		CreatedAt time.Time
+		UpdatedAt time.Time
+
+		pendingEvents []RoomEvent
 	}
 )
 
@@ -42,22 +60,50 @@ func NewRoom(clients ClientCollection) *Room {
 }
 
 func NewRoomWithID(id string, clients ClientCollection) *Room {
+	room := newRoom(id, clients)
+	room.record(context.Background(), RoomEvent{Type: EventRoomCreated})
+	return room
+}
+
+func newRoom(id string, clients ClientCollection) *Room {
 	return &Room{
-		ID:           id,
-		Clients:      clients,
-		CurrentStory: "",
-		Reveal:       false,
-		Result:       nil,
-		BacklogMode:  true,
+		ID:            id,
+		Clients:       clients,
+		CurrentStory:  "",
+		Reveal:        false,
+		Result:        nil,
+		BacklogMode:   true,
+		Deck:          DefaultDeck(),
+		ConsensusRule: DefaultConsensusRule,
 	}
 }
 
+// Configure sets up a room that was just created.
+func (r *Room) Configure(ctx context.Context, deck Deck, passcodeHash string) {
+	r.record(ctx, RoomEvent{Type: EventRoomConfigured, Deck: &deck, PasscodeHash: passcodeHash})
+}
+
 func (r *Room) NewClient(id string) *Client {
+	return r.NewClientWithIdentity(id, Identity{})
+}
+
+// NewClientWithIdentity adds a participant verified by the identity provider,
+// named after its claims.
+func (r *Room) NewClientWithIdentity(id string, identity Identity) *Client {
+	client := r.addClient(id)
+	client.Subject = identity.Subject
+	client.Name = identity.Name
+	r.commit(RoomEvent{Type: EventClientJoined, ClientID: id, TargetID: id, Subject: identity.Subject, Name: identity.Name})
+	return client
+}
+
+func (r *Room) addClient(id string) *Client {
 	client := newClient(id)
 	r.Clients.Add(client)
 	client.room = r
 
-	if r.Clients.Count() == 1 {
+	// the first participant owns the room, also when bots were added first
+	if r.Clients.Count() == 1 || r.CountOwners() == 0 {
 		client.IsOwner = true
 	}
 
@@ -65,48 +111,51 @@ func (r *Room) NewClient(id string) *Client {
 }
 
 func (r *Room) RemoveClient(ctx context.Context, clientID string) error {
+	r.record(ctx, RoomEvent{Type: EventClientLeft, ClientID: clientID, TargetID: clientID})
+	return nil
+}
+
+func (r *Room) removeClient(clientID string) {
 	r.Clients.Remove(clientID)
 
+	// bots never own the room
 	if r.CountOwners() == 0 && r.Clients.Count() > 0 {
-		if client, ok := r.Clients.First(); ok {
+		if client, ok := r.participants().First(); ok {
 			client.IsOwner = true
 		}
 	}
 
 	r.checkReveal()
-
-	return nil
 }
 
 func (r *Room) NewVoting(ctx context.Context, clientID string) error {
-	client, ok := r.FindClient(clientID)
-	if !ok {
-		return fmt.Errorf("client %s not found in room %s", clientID, r.ID)
-	}
-	if !client.IsOwner {
-		return fmt.Errorf("only the room owner can start a new voting")
+	if _, err := r.CheckPermission(clientID, ActionNewVoting); err != nil {
+		return err
 	}
 
-	if !r.BacklogMode {
-		r.CurrentStory = ""
-	}
+	r.record(ctx, RoomEvent{Type: EventVotingStarted, ClientID: clientID})
+
+	return nil
+}
+
+func (r *Room) clearVotes(ctx context.Context) {
 	r.reveal(false)
 	r.Clients.ForEach(func(c *Client) {
 		c.Vote(ctx, nil)
 	})
-
-	return nil
 }
 
 func (r *Room) ToggleBacklogMode(ctx context.Context, clientID string) error {
-	client, ok := r.FindClient(clientID)
-	if !ok {
-		return fmt.Errorf("client %s not found in room %s", clientID, r.ID)
-	}
-	if !client.IsOwner {
-		return fmt.Errorf("only the room owner can toggle backlog mode")
+	if _, err := r.CheckPermission(clientID, ActionToggleBacklogMode); err != nil {
+		return err
 	}
 
+	r.record(ctx, RoomEvent{Type: EventBacklogModeToggled, ClientID: clientID})
+
+	return nil
+}
+
+func (r *Room) toggleBacklogMode() {
 	if !r.BacklogMode {
 		r.BacklogMode = true
 		if r.CurrentStory != "" {
@@ -121,19 +170,19 @@ func (r *Room) ToggleBacklogMode(ctx context.Context, clientID string) error {
 		r.Stories = nil
 		r.CurrentStoryIndex = 0
 	}
-
-	return nil
 }
 
 func (r *Room) AddStory(ctx context.Context, clientID string, name string) error {
-	client, ok := r.FindClient(clientID)
-	if !ok {
-		return fmt.Errorf("client %s not found in room %s", clientID, r.ID)
-	}
-	if !client.IsOwner {
-		return fmt.Errorf("only the room owner can add a story")
+	if _, err := r.CheckPermission(clientID, ActionAddStory); err != nil {
+		return err
 	}
 
+	r.record(ctx, RoomEvent{Type: EventStoryAdded, ClientID: clientID, Name: name})
+
+	return nil
+}
+
+func (r *Room) addStory(name string) {
 	if !r.BacklogMode {
 		r.BacklogMode = true
 	}
@@ -142,79 +191,58 @@ func (r *Room) AddStory(ctx context.Context, clientID string, name string) error
 	if len(r.Stories) == 1 {
 		r.CurrentStoryIndex = 0
 	}
-
-	return nil
 }
 
 func (r *Room) RemoveStory(ctx context.Context, clientID string, index int) error {
-	client, ok := r.FindClient(clientID)
-	if !ok {
-		return fmt.Errorf("client %s not found in room %s", clientID, r.ID)
-	}
-	if !client.IsOwner {
-		return fmt.Errorf("only the room owner can remove a story")
+	if _, err := r.CheckPermission(clientID, ActionRemoveStory); err != nil {
+		return err
 	}
 
 	if index < 0 || index >= len(r.Stories) {
-		return fmt.Errorf("story index %d out of range", index)
+		return fmt.Errorf("story index %d out of range: %w", index, domainerror.ErrInvalidPayload)
 	}
 
+	r.record(ctx, RoomEvent{Type: EventStoryRemoved, ClientID: clientID, Index: index})
+
+	return nil
+}
+
+func (r *Room) removeStory(ctx context.Context, index int) {
 	if index == r.CurrentStoryIndex {
 		if len(r.Stories) == 1 {
 			r.CurrentStoryIndex = 0
 			r.Stories = nil
-			return nil
+			return
 		} else if index == len(r.Stories)-1 {
 			r.CurrentStoryIndex--
 		}
-		r.reveal(false)
-		r.Clients.ForEach(func(c *Client) {
-			c.Vote(ctx, nil)
-		})
+		r.clearVotes(ctx)
 	} else if index < r.CurrentStoryIndex {
 		r.CurrentStoryIndex--
 	}
 
 	r.Stories = append(r.Stories[:index], r.Stories[index+1:]...)
-
-	return nil
 }
 
 func (r *Room) AdvanceToNextStory(ctx context.Context, clientID string) error {
-	client, ok := r.FindClient(clientID)
-	if !ok {
-		return fmt.Errorf("client %s not found in room %s", clientID, r.ID)
-	}
-	if !client.IsOwner {
-		return fmt.Errorf("only the room owner can advance to the next story")
+	if _, err := r.CheckPermission(clientID, ActionNavigateStories); err != nil {
+		return err
 	}
 
 	if r.CurrentStoryIndex < len(r.Stories)-1 {
-		r.CurrentStoryIndex++
-		r.reveal(false)
-		r.Clients.ForEach(func(c *Client) {
-			c.Vote(ctx, nil)
-		})
+		r.record(ctx, RoomEvent{Type: EventNextStorySelected, ClientID: clientID})
 	}
 
 	return nil
 }
 
 func (r *Room) PrevStory(ctx context.Context, clientID string) error {
-	client, ok := r.FindClient(clientID)
-	if !ok {
-		return fmt.Errorf("client %s not found in room %s", clientID, r.ID)
-	}
-	if !client.IsOwner {
-		return fmt.Errorf("only the room owner can go to the previous story")
+	if _, err := r.CheckPermission(clientID, ActionNavigateStories); err != nil {
+		return err
 	}
 
 	if r.CurrentStoryIndex > 0 {
-		r.CurrentStoryIndex--
-		r.reveal(false)
-		r.Clients.ForEach(func(c *Client) {
-			c.Vote(ctx, nil)
-		})
+		r.record(ctx, RoomEvent{Type: EventPreviousStorySelected, ClientID: clientID})
 	}
 
 	return nil
@@ -227,6 +255,45 @@ func (r *Room) EffectiveCurrentStory() string {
 	return r.CurrentStory
 }
 
+// EffectiveDeck returns the deck of the room, falling back to the default
+// deck for rooms created before decks were configurable.
+func (r *Room) EffectiveDeck() Deck {
+	if r.Deck.IsEmpty() {
+		return DefaultDeck()
+	}
+	return r.Deck
+}
+
+func (r *Room) ChangeDeck(ctx context.Context, clientID string, deck Deck) error {
+	if _, err := r.CheckPermission(clientID, ActionChangeDeck); err != nil {
+		return err
+	}
+	if deck.IsEmpty() {
+		return fmt.Errorf("deck has no cards: %w", domainerror.ErrInvalidDeck)
+	}
+
+	r.record(ctx, RoomEvent{Type: EventDeckChanged, ClientID: clientID, Deck: &deck})
+
+	return nil
+}
+
+func (r *Room) EffectiveConsensusRule() ConsensusRule {
+	if r.ConsensusRule == "" {
+		return DefaultConsensusRule
+	}
+	return r.ConsensusRule
+}
+
+func (r *Room) ChangeConsensusRule(ctx context.Context, clientID string, rule ConsensusRule) error {
+	if _, err := r.CheckPermission(clientID, ActionChangeConsensusRule); err != nil {
+		return err
+	}
+
+	r.record(ctx, RoomEvent{Type: EventConsensusRuleChanged, ClientID: clientID, Rule: rule})
+
+	return nil
+}
+
 func (r *Room) getCurrentStoryName() string {
 	if len(r.Stories) > 0 && r.CurrentStoryIndex < len(r.Stories) {
 		return r.Stories[r.CurrentStoryIndex].Name
@@ -235,19 +302,11 @@ func (r *Room) getCurrentStoryName() string {
 }
 
 func (r *Room) ResetVoting(ctx context.Context, clientID string) error {
-	client, ok := r.FindClient(clientID)
-	if !ok {
-		return fmt.Errorf("client %s not found in room %s", clientID, r.ID)
-	}
-	if !client.IsOwner {
-		return fmt.Errorf("only the room owner can start a new voting")
+	if _, err := r.CheckPermission(clientID, ActionNewVoting); err != nil {
+		return err
 	}
 
-	r.reveal(false)
-
-	r.Clients.ForEach(func(c *Client) {
-		c.Vote(ctx, nil)
-	})
+	r.record(ctx, RoomEvent{Type: EventVotingReset, ClientID: clientID})
 
 	return nil
 }
@@ -271,32 +330,30 @@ func (r *Room) CountOwners() int {
 }
 
 func (r *Room) ToggleSpectator(ctx context.Context, clientID string, targetClientID string) error {
-	client, ok := r.FindClient(clientID)
-	if !ok {
-		return fmt.Errorf("client %s not found in room %s", clientID, r.ID)
-	}
-	if !client.IsOwner {
-		return fmt.Errorf("only the room owner can toggle ownership")
+	if _, err := r.CheckPermission(clientID, ActionToggleSpectator); err != nil {
+		return err
 	}
 
-	if targetClient, ok := r.FindClient(targetClientID); ok {
-		targetClient.IsSpectator = !targetClient.IsSpectator
-		targetClient.Vote(ctx, nil)
-		r.checkReveal()
-	} else {
+	targetClient, ok := r.FindClient(targetClientID)
+	if !ok {
 		return fmt.Errorf("target client %s not found in room %s", targetClientID, r.ID)
 	}
 
+	r.toggleSpectator(ctx, targetClient)
+	r.commit(RoomEvent{Type: EventSpectatorToggled, ClientID: clientID, TargetID: targetClientID})
+
 	return nil
 }
 
+func (r *Room) toggleSpectator(ctx context.Context, target *Client) {
+	target.IsSpectator = !target.IsSpectator
+	target.Vote(ctx, nil)
+	r.checkReveal()
+}
+
 func (r *Room) ToggleOwner(ctx context.Context, clientID string, targetClientID string) error {
-	client, ok := r.FindClient(clientID)
-	if !ok {
-		return fmt.Errorf("client %s not found in room %s", clientID, r.ID)
-	}
-	if !client.IsOwner {
-		return fmt.Errorf("only the room owner can toggle ownership")
+	if _, err := r.CheckPermission(clientID, ActionToggleOwner); err != nil {
+		return err
 	}
 
 	owners := r.Clients.Filter(func(client *Client) bool {
@@ -312,12 +369,14 @@ func (r *Room) ToggleOwner(ctx context.Context, clientID string, targetClientID
 		}
 	}
 
-	if targetClient, ok := r.FindClient(targetClientID); ok {
-		targetClient.IsOwner = !targetClient.IsOwner
-	} else {
+	targetClient, ok := r.FindClient(targetClientID)
+	if !ok {
 		return fmt.Errorf("target client %s not found in room %s", targetClientID, r.ID)
 	}
 
+	targetClient.IsOwner = !targetClient.IsOwner
+	r.commit(RoomEvent{Type: EventOwnerToggled, ClientID: clientID, TargetID: targetClientID})
+
 	return nil
 }
 
@@ -337,76 +396,104 @@ func (r *Room) AdminToggleOwner(ctx context.Context, targetClientID string) erro
 		}
 	}
 
-	if targetClient, ok := r.FindClient(targetClientID); ok {
-		targetClient.IsOwner = !targetClient.IsOwner
-	} else {
+	targetClient, ok := r.FindClient(targetClientID)
+	if !ok {
 		return fmt.Errorf("target client %s not found in room %s: %w", targetClientID, r.ID, domainerror.ErrClientNotFound)
 	}
 
+	targetClient.IsOwner = !targetClient.IsOwner
+	r.commit(RoomEvent{Type: EventOwnerToggled, TargetID: targetClientID})
+
 	return nil
 }
 
 func (r *Room) SetCurrentStory(ctx context.Context, clientID string, story string) error {
-	client, ok := r.FindClient(clientID)
-	if !ok {
-		return fmt.Errorf("client %s not found in room %s", clientID, r.ID)
-	}
-	if !client.IsOwner {
-		return fmt.Errorf("only the room owner can set the current story")
+	if _, err := r.CheckPermission(clientID, ActionSetCurrentStory); err != nil {
+		return err
 	}
 
+	r.record(ctx, RoomEvent{Type: EventCurrentStorySet, ClientID: clientID, Name: story})
+
+	return nil
+}
+
+func (r *Room) setCurrentStory(story string) {
 	if r.BacklogMode && r.CurrentStoryIndex >= 0 && r.CurrentStoryIndex < len(r.Stories) {
 		r.Stories[r.CurrentStoryIndex].Name = story
 	} else {
 		r.CurrentStory = story
 	}
-	return nil
 }
 
 func (r *Room) ToggleReveal(ctx context.Context, clientID string) error {
-	client, ok := r.FindClient(clientID)
-	if !ok {
-		return fmt.Errorf("client %s not found in room %s", clientID, r.ID)
-	}
-	if !client.IsOwner {
-		return fmt.Errorf("only the room owner can toggle reveal")
+	if _, err := r.CheckPermission(clientID, ActionToggleReveal); err != nil {
+		return err
 	}
 
-	r.reveal(!r.Reveal)
+	r.record(ctx, RoomEvent{Type: EventRevealToggled, ClientID: clientID})
 
-	if r.Reveal && r.BacklogMode && r.CurrentStoryIndex >= 0 && r.CurrentStoryIndex < len(r.Stories) {
+	return nil
+}
+
+func (r *Room) storeCurrentStoryResult() {
+	if r.BacklogMode && r.CurrentStoryIndex >= 0 && r.CurrentStoryIndex < len(r.Stories) {
 		r.Stories[r.CurrentStoryIndex].Result = r.Result
 		r.Stories[r.CurrentStoryIndex].MostAppearingVotes = r.MostAppearingVotes
 		r.Stories[r.CurrentStoryIndex].Voted = true
+		r.Stories[r.CurrentStoryIndex].Statistics = r.Statistics
+		r.Stories[r.CurrentStoryIndex].Votes = r.storyVotes()
 	}
+}
 
-	return nil
+func (r *Room) storyVotes() []StoryVote {
+	votes := []StoryVote{}
+	for _, client := range r.Clients.Values() {
+		if client.IsSpectator {
+			continue
+		}
+		votes = append(votes, StoryVote{
+			ClientID: client.ID,
+			Name:     client.Name,
+			Vote:     lo.FromPtr(client.CurrentVote),
+		})
+	}
+	return votes
 }
 
 func (r *Room) reveal(reveal bool) {
 	r.Reveal = reveal
+	// either the votes are shown or a new round starts, the countdown is over
+	r.VotingDeadline = nil
 
 	if !reveal {
 		r.Result = nil
+		r.Statistics = nil
 		return
 	}
 
 	var voteSum float32 = 0
 	var voteCount float32 = 0
 	var votesCountMap = make(map[int]int)
+	var estimates []estimate
 
+	deck := r.EffectiveDeck()
 	for _, client := range r.Clients.Values() {
 		if !client.IsSpectator {
 			if client.CurrentVote != nil {
-				if vote, err := strconv.Atoi(*client.CurrentVote); err == nil {
+				if vote, ok := deck.Score(*client.CurrentVote); ok {
 					voteSum += float32(vote)
 					voteCount++
 					votesCountMap[vote]++
+
+					step, _ := deck.Step(*client.CurrentVote)
+					estimates = append(estimates, estimate{clientID: client.ID, score: vote, step: step})
 				}
 			}
 		}
 	}
 
+	r.Statistics = newVoteStatistics(estimates, r.EffectiveConsensusRule())
+
 	r.MostAppearingVotes = []int{}
 
 	mostVoteCount := r.getMostVoteCount(votesCountMap)
@@ -434,8 +521,10 @@ func (r *Room) getMostVoteCount(voteMap map[int]int) int {
 	return mostVoteCount
 }
 
+// IsEmpty tells whether no participant is left, bots alone do not keep the
+// room going.
 func (r *Room) IsEmpty() bool {
-	return r.Clients.Count() == 0
+	return r.participants().Count() == 0
 }
 
 func (r *Room) FindClient(clientID string) (*Client, bool) {
@@ -445,17 +534,26 @@ func (r *Room) FindClient(clientID string) (*Client, bool) {
 }
 
 func (r *Room) Vote(ctx context.Context, clientID string, vote *string) error {
-	client, ok := r.FindClient(clientID)
-	if !ok {
-		return fmt.Errorf("client %s not found in room %s", clientID, r.ID)
+	client, err := r.CheckPermission(clientID, ActionVote)
+	if err != nil {
+		return err
 	}
 
-	client.Vote(ctx, vote)
-	r.checkReveal()
+	if vote != nil && *vote != "" && !r.EffectiveDeck().Contains(*vote) {
+		return fmt.Errorf("vote %q rejected in room %s: %w", *vote, r.ID, domainerror.ErrInvalidVote)
+	}
+
+	r.castVote(ctx, client, vote)
+	r.commit(RoomEvent{Type: EventVoteCast, ClientID: clientID, Vote: vote})
 
 	return nil
 }
 
+func (r *Room) castVote(ctx context.Context, client *Client, vote *string) {
+	client.Vote(ctx, vote)
+	r.checkReveal()
+}
+
 func (r *Room) UpdateClientName(ctx context.Context, clientID string, name string) error {
 	client, ok := r.FindClient(clientID)
 	if !ok {
@@ -463,6 +561,7 @@ func (r *Room) UpdateClientName(ctx context.Context, clientID string, name strin
 	}
 
 	client.UpdateName(ctx, name)
+	r.commit(RoomEvent{Type: EventClientRenamed, ClientID: clientID, TargetID: clientID, Name: name})
 
 	return nil
 }
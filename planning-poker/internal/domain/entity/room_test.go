@@ -258,6 +258,9 @@ func TestRoom_NewClient(t *testing.T) {
 	mockCC.EXPECT().Count().Return(1).Times(1) // First client
 	mockCC.EXPECT().Count().Return(2).Times(1) // Second client
 	mockCC.EXPECT().Count().Return(3).Times(1) // Third client
+	owners := NewMockClientCollection(ctrl)
+	owners.EXPECT().Count().Return(1).Times(2) // the first client owns the room
+	mockCC.EXPECT().Filter(gomock.Any()).Return(owners).Times(2)
 
 	room := NewRoom(mockCC)
 
@@ -797,7 +800,9 @@ func TestRoom_IsEmpty(t *testing.T) {
 	mockCC := NewMockClientCollection(ctrl)
 
 	t.Run("should return true when room is empty", func(t *testing.T) {
-		mockCC.EXPECT().Count().Return(0)
+		participants := NewMockClientCollection(ctrl)
+		mockCC.EXPECT().Filter(gomock.Any()).Return(participants)
+		participants.EXPECT().Count().Return(0)
 		room := NewRoom(mockCC)
 
 		if !room.IsEmpty() {
@@ -806,7 +811,9 @@ func TestRoom_IsEmpty(t *testing.T) {
 	})
 
 	t.Run("should return false when room has clients", func(t *testing.T) {
-		mockCC.EXPECT().Count().Return(3)
+		participants := NewMockClientCollection(ctrl)
+		mockCC.EXPECT().Filter(gomock.Any()).Return(participants)
+		participants.EXPECT().Count().Return(3)
 		room := NewRoom(mockCC)
 
 		if room.IsEmpty() {
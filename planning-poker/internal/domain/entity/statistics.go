@@ -0,0 +1,110 @@
+package entity
+
+import (
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/samber/lo"
+
+	"planning-poker/internal/domain/domainerror"
+)
+
+type ConsensusRule string
+
+const (
+	// every estimate is the same card
+	ConsensusUnanimous ConsensusRule = "unanimous"
+	// every estimate is at most one card away from the others
+	ConsensusAdjacent ConsensusRule = "adjacent"
+
+	DefaultConsensusRule = ConsensusUnanimous
+
+	// minimum number of estimates before someone can be considered an outlier
+	minVotesForOutliers = 3
+)
+
+type (
+	VoteStatistics struct {
+		Median    float32  `json:"median"`
+		Min       int      `json:"min"`
+		Max       int      `json:"max"`
+		StdDev    float32  `json:"stdDev"`
+		Outliers  []string `json:"outliers"`
+		Consensus bool     `json:"consensus"`
+	}
+
+	estimate struct {
+		clientID string
+		score    int
+		step     int
+	}
+)
+
+func ParseConsensusRule(rule string) (ConsensusRule, error) {
+	switch ConsensusRule(rule) {
+	case "":
+		return DefaultConsensusRule, nil
+	case ConsensusUnanimous, ConsensusAdjacent:
+		return ConsensusRule(rule), nil
+	default:
+		return "", fmt.Errorf("unknown consensus rule %q: %w", rule, domainerror.ErrInvalidConsensusRule)
+	}
+}
+
+// newVoteStatistics summarizes the estimates of a reveal. It returns nil
+// when nobody played an estimate card.
+func newVoteStatistics(estimates []estimate, rule ConsensusRule) *VoteStatistics {
+	if len(estimates) == 0 {
+		return nil
+	}
+
+	scores := lo.Map(estimates, func(e estimate, _ int) float64 { return float64(e.score) })
+	steps := lo.Map(estimates, func(e estimate, _ int) float64 { return float64(e.step) })
+
+	minStep, maxStep := slices.Min(steps), slices.Max(steps)
+	medianStep := median(steps)
+
+	stats := &VoteStatistics{
+		Median:   float32(median(scores)),
+		Min:      int(slices.Min(scores)),
+		Max:      int(slices.Max(scores)),
+		StdDev:   float32(stdDev(scores)),
+		Outliers: []string{},
+	}
+
+	switch rule {
+	case ConsensusAdjacent:
+		stats.Consensus = maxStep-minStep <= 1
+	default:
+		stats.Consensus = minStep == maxStep
+	}
+
+	if len(estimates) >= minVotesForOutliers {
+		for _, e := range estimates {
+			if math.Abs(float64(e.step)-medianStep) > 1 {
+				stats.Outliers = append(stats.Outliers, e.clientID)
+			}
+		}
+	}
+
+	return stats
+}
+
+func median(values []float64) float64 {
+	sorted := slices.Sorted(slices.Values(values))
+	middle := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[middle-1] + sorted[middle]) / 2
+	}
+	return sorted[middle]
+}
+
+func stdDev(values []float64) float64 {
+	mean := lo.Sum(values) / float64(len(values))
+	var variance float64
+	for _, v := range values {
+		variance += (v - mean) * (v - mean)
+	}
+	return math.Sqrt(variance / float64(len(values)))
+}
@@ -0,0 +1,266 @@
+package entity_test
+
+import (
+	"context"
+	"errors"
+	"math"
+	"reflect"
+	"slices"
+	"testing"
+
+	"planning-poker/internal/domain/domainerror"
+	"planning-poker/internal/domain/entity"
+	"planning-poker/internal/infra/boundaries/hub/clientcollection"
+
+	"github.com/samber/lo"
+)
+
+func newRoomWithVotes(t *testing.T, rule entity.ConsensusRule, votes map[string]string) *entity.Room {
+	t.Helper()
+	ctx := context.Background()
+
+	room := entity.NewRoom(clientcollection.New())
+	room.ConsensusRule = rule
+	owner := room.NewClient("owner")
+	owner.IsSpectator = true
+	for clientID := range votes {
+		room.NewClient(clientID)
+	}
+	for clientID, vote := range votes {
+		if err := room.Vote(ctx, clientID, lo.ToPtr(vote)); err != nil {
+			t.Fatalf("unexpected error voting %s: %v", vote, err)
+		}
+	}
+	if !room.Reveal {
+		t.Fatal("expected room to be revealed after everyone voted")
+	}
+
+	return room
+}
+
+func TestRoom_RevealStatistics(t *testing.T) {
+	room := newRoomWithVotes(t, entity.ConsensusUnanimous, map[string]string{
+		"client1": "3",
+		"client2": "5",
+		"client3": "5",
+		"client4": "21",
+		"client5": "?",
+	})
+
+	stats := room.Statistics
+	if stats == nil {
+		t.Fatal("expected statistics to be computed")
+	}
+	if stats.Median != 5 {
+		t.Errorf("expected median 5, got %v", stats.Median)
+	}
+	if stats.Min != 3 || stats.Max != 21 {
+		t.Errorf("expected min 3 and max 21, got %d and %d", stats.Min, stats.Max)
+	}
+	// mean 8.5, variance (30.25 + 12.25 + 12.25 + 156.25) / 4
+	if want := float32(math.Sqrt(52.75)); stats.StdDev != want {
+		t.Errorf("expected std dev %v, got %v", want, stats.StdDev)
+	}
+	if !slices.Equal(stats.Outliers, []string{"client4"}) {
+		t.Errorf("expected outliers [client4], got %v", stats.Outliers)
+	}
+	if stats.Consensus {
+		t.Error("expected no consensus")
+	}
+}
+
+func TestRoom_RevealStatistics_Consensus(t *testing.T) {
+	tests := []struct {
+		name  string
+		rule  entity.ConsensusRule
+		votes map[string]string
+		want  bool
+	}{
+		{
+			name:  "unanimous with equal votes",
+			rule:  entity.ConsensusUnanimous,
+			votes: map[string]string{"client1": "8", "client2": "8"},
+			want:  true,
+		},
+		{
+			name:  "unanimous with adjacent votes",
+			rule:  entity.ConsensusUnanimous,
+			votes: map[string]string{"client1": "5", "client2": "8"},
+			want:  false,
+		},
+		{
+			name:  "adjacent with adjacent votes",
+			rule:  entity.ConsensusAdjacent,
+			votes: map[string]string{"client1": "5", "client2": "8"},
+			want:  true,
+		},
+		{
+			name:  "adjacent with distant votes",
+			rule:  entity.ConsensusAdjacent,
+			votes: map[string]string{"client1": "3", "client2": "8"},
+			want:  false,
+		},
+		{
+			name:  "non-estimate cards are ignored",
+			rule:  entity.ConsensusUnanimous,
+			votes: map[string]string{"client1": "13", "client2": "☕"},
+			want:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			room := newRoomWithVotes(t, tt.rule, tt.votes)
+
+			if room.Statistics == nil {
+				t.Fatal("expected statistics to be computed")
+			}
+			if room.Statistics.Consensus != tt.want {
+				t.Errorf("expected consensus %v, got %v", tt.want, room.Statistics.Consensus)
+			}
+		})
+	}
+}
+
+func TestRoom_RevealStatistics_NoOutliersWithFewVotes(t *testing.T) {
+	room := newRoomWithVotes(t, entity.ConsensusUnanimous, map[string]string{"client1": "1", "client2": "89"})
+
+	if len(room.Statistics.Outliers) != 0 {
+		t.Errorf("expected no outliers, got %v", room.Statistics.Outliers)
+	}
+}
+
+func TestRoom_RevealStatistics_OnlyNonEstimateVotes(t *testing.T) {
+	room := newRoomWithVotes(t, entity.ConsensusUnanimous, map[string]string{"client1": "?", "client2": "☕"})
+
+	if room.Statistics != nil {
+		t.Errorf("expected no statistics, got %+v", room.Statistics)
+	}
+}
+
+func TestRoom_ToggleRevealStoresStatisticsOnStory(t *testing.T) {
+	ctx := context.Background()
+	room := entity.NewRoom(clientcollection.New())
+	room.NewClient("owner")
+	room.NewClient("client1")
+	_ = room.AddStory(ctx, "owner", "Story 1")
+	_ = room.Vote(ctx, "owner", lo.ToPtr("5"))
+
+	if err := room.ToggleReveal(ctx, "owner"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	stats := room.Stories[0].Statistics
+	if stats == nil {
+		t.Fatal("expected statistics to be stored on the story")
+	}
+	if stats.Median != 5 || !stats.Consensus {
+		t.Errorf("unexpected story statistics %+v", stats)
+	}
+
+	if err := room.ToggleReveal(ctx, "owner"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if room.Statistics != nil {
+		t.Error("expected statistics to be cleared when hiding votes")
+	}
+	if room.Stories[0].Statistics == nil {
+		t.Error("expected story statistics to be kept when hiding votes")
+	}
+}
+
+func TestRoom_ToggleRevealStoresVotesOnStory(t *testing.T) {
+	ctx := context.Background()
+	room := entity.NewRoom(clientcollection.New())
+	room.NewClient("owner").Name = "Alice"
+	room.NewClient("client1").Name = "Bob"
+	room.NewClient("client2").IsSpectator = true
+	_ = room.AddStory(ctx, "owner", "Story 1")
+	_ = room.Vote(ctx, "owner", lo.ToPtr("5"))
+
+	if err := room.ToggleReveal(ctx, "owner"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []entity.StoryVote{
+		{ClientID: "owner", Name: "Alice", Vote: "5"},
+		{ClientID: "client1", Name: "Bob", Vote: ""},
+	}
+	if !reflect.DeepEqual(room.Stories[0].Votes, want) {
+		t.Errorf("expected story votes %+v, got %+v", want, room.Stories[0].Votes)
+	}
+}
+
+func TestRoom_ChangeConsensusRule(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("owner changes rule and statistics are recomputed", func(t *testing.T) {
+		room := newRoomWithVotes(t, entity.ConsensusUnanimous, map[string]string{"client1": "5", "client2": "8"})
+		if err := room.ChangeConsensusRule(ctx, "owner", entity.ConsensusAdjacent); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if room.EffectiveConsensusRule() != entity.ConsensusAdjacent {
+			t.Errorf("expected rule %s, got %s", entity.ConsensusAdjacent, room.EffectiveConsensusRule())
+		}
+		if !room.Statistics.Consensus {
+			t.Error("expected consensus after switching to the adjacent rule")
+		}
+	})
+
+	t.Run("revealed story keeps the statistics of the new rule", func(t *testing.T) {
+		room := entity.NewRoom(clientcollection.New())
+		owner := room.NewClient("owner")
+		owner.IsSpectator = true
+		room.NewClient("client1")
+		room.NewClient("client2")
+		room.NewClient("client3")
+		if err := room.AddStory(ctx, "owner", "Login page"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		for clientID, vote := range map[string]string{"client1": "5", "client2": "8"} {
+			if err := room.Vote(ctx, clientID, lo.ToPtr(vote)); err != nil {
+				t.Fatalf("unexpected error voting %s: %v", vote, err)
+			}
+		}
+		if err := room.ToggleReveal(ctx, "owner"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if room.Stories[0].Statistics == nil || room.Stories[0].Statistics.Consensus {
+			t.Fatalf("expected no consensus under the unanimous rule, got %+v", room.Stories[0].Statistics)
+		}
+
+		if err := room.ChangeConsensusRule(ctx, "owner", entity.ConsensusAdjacent); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if !room.Stories[0].Statistics.Consensus {
+			t.Error("expected the story to keep the consensus of the adjacent rule")
+		}
+	})
+
+	t.Run("non-owner cannot change rule", func(t *testing.T) {
+		room := entity.NewRoom(clientcollection.New())
+		room.NewClient("owner")
+		room.NewClient("client1")
+
+		if err := room.ChangeConsensusRule(ctx, "client1", entity.ConsensusAdjacent); err == nil {
+			t.Error("expected error for non-owner")
+		}
+		if room.EffectiveConsensusRule() != entity.DefaultConsensusRule {
+			t.Errorf("expected rule to stay %s, got %s", entity.DefaultConsensusRule, room.EffectiveConsensusRule())
+		}
+	})
+}
+
+func TestParseConsensusRule(t *testing.T) {
+	if rule, err := entity.ParseConsensusRule(""); err != nil || rule != entity.DefaultConsensusRule {
+		t.Errorf("expected default rule, got %s (%v)", rule, err)
+	}
+	if rule, err := entity.ParseConsensusRule("adjacent"); err != nil || rule != entity.ConsensusAdjacent {
+		t.Errorf("expected adjacent rule, got %s (%v)", rule, err)
+	}
+	if _, err := entity.ParseConsensusRule("majority"); !errors.Is(err, domainerror.ErrInvalidConsensusRule) {
+		t.Errorf("expected ErrInvalidConsensusRule, got %v", err)
+	}
+}
@@ -1,8 +1,19 @@
 package entity
 
-type Story struct {
-	Name               string   `json:"name"`
-	Result             *float32 `json:"result,omitempty"`
-	MostAppearingVotes []int    `json:"mostAppearingVotes"`
-	Voted              bool     `json:"voted"`
-}
+type (
+	Story struct {
+		Name               string          `json:"name"`
+		Result             *float32        `json:"result,omitempty"`
+		MostAppearingVotes []int           `json:"mostAppearingVotes"`
+		Voted              bool            `json:"voted"`
+		Statistics         *VoteStatistics `json:"statistics,omitempty"`
+		Votes              []StoryVote     `json:"votes,omitempty"`
+	}
+	// StoryVote is the card a participant played when the story was revealed.
+	// Vote is empty when the participant did not vote.
+	StoryVote struct {
+		ClientID string `json:"clientId"`
+		Name     string `json:"name"`
+		Vote     string `json:"vote"`
+	}
+)
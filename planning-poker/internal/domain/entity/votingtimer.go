@@ -0,0 +1,62 @@
+package entity
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"planning-poker/internal/domain/domainerror"
+)
+
+const (
+	MinVotingTimerDuration = 5 * time.Second
+	MaxVotingTimerDuration = time.Hour
+)
+
+// StartVotingTimer starts a countdown for the current round. When the deadline
+// passes the room is revealed even if some participants have not voted yet.
+// Starting a timer while one is running replaces its deadline.
+func (r *Room) StartVotingTimer(ctx context.Context, clientID string, now time.Time, duration time.Duration) error {
+	if _, err := r.CheckPermission(clientID, ActionManageTimer); err != nil {
+		return err
+	}
+	if duration < MinVotingTimerDuration || duration > MaxVotingTimerDuration {
+		return fmt.Errorf("timer must last between %s and %s: %w", MinVotingTimerDuration, MaxVotingTimerDuration, domainerror.ErrInvalidTimerDuration)
+	}
+	if r.Reveal {
+		return fmt.Errorf("cannot start the voting timer while votes are revealed")
+	}
+
+	deadline := now.Add(duration)
+	r.record(ctx, RoomEvent{Type: EventVotingTimerStarted, ClientID: clientID, Deadline: &deadline})
+
+	return nil
+}
+
+func (r *Room) CancelVotingTimer(ctx context.Context, clientID string) error {
+	if _, err := r.CheckPermission(clientID, ActionManageTimer); err != nil {
+		return err
+	}
+
+	r.record(ctx, RoomEvent{Type: EventVotingTimerCancelled, ClientID: clientID})
+
+	return nil
+}
+
+// IsVotingTimerRunning reports whether the room has a deadline that has not
+// been reached at the given time.
+func (r *Room) IsVotingTimerRunning(now time.Time) bool {
+	return r.VotingDeadline != nil && now.Before(*r.VotingDeadline)
+}
+
+// ExpireVotingTimer reveals the room when its deadline has passed. It returns
+// false when there is nothing to reveal, so calling it again is harmless.
+func (r *Room) ExpireVotingTimer(now time.Time) bool {
+	if r.VotingDeadline == nil || r.IsVotingTimerRunning(now) {
+		return false
+	}
+
+	r.record(context.Background(), RoomEvent{Type: EventVotingTimerExpired})
+
+	return true
+}
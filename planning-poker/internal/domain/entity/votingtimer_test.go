@@ -0,0 +1,128 @@
+package entity_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"planning-poker/internal/domain/domainerror"
+	"planning-poker/internal/domain/entity"
+	"planning-poker/internal/infra/boundaries/hub/clientcollection"
+
+	"github.com/samber/lo"
+)
+
+func TestRoom_StartVotingTimer(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		senderID string
+		duration time.Duration
+		revealed bool
+		wantErr  bool
+		errIs    error
+	}{
+		{name: "owner starts timer", senderID: "owner", duration: time.Minute},
+		{name: "non-owner cannot start timer", senderID: "client1", duration: time.Minute, wantErr: true},
+		{name: "too short", senderID: "owner", duration: time.Second, wantErr: true, errIs: domainerror.ErrInvalidTimerDuration},
+		{name: "too long", senderID: "owner", duration: 2 * time.Hour, wantErr: true, errIs: domainerror.ErrInvalidTimerDuration},
+		{name: "votes already revealed", senderID: "owner", duration: time.Minute, revealed: true, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			room := entity.NewRoom(clientcollection.New())
+			room.NewClient("owner")
+			room.NewClient("client1")
+			room.Reveal = tt.revealed
+
+			err := room.StartVotingTimer(ctx, tt.senderID, now, tt.duration)
+
+			if !tt.wantErr {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if room.VotingDeadline == nil || !room.VotingDeadline.Equal(now.Add(tt.duration)) {
+					t.Errorf("expected deadline %v, got %v", now.Add(tt.duration), room.VotingDeadline)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("expected error, got nil")
+			}
+			if tt.errIs != nil && !errors.Is(err, tt.errIs) {
+				t.Errorf("expected %v, got %v", tt.errIs, err)
+			}
+			if room.VotingDeadline != nil {
+				t.Error("expected no deadline to be set")
+			}
+		})
+	}
+}
+
+func TestRoom_ExpireVotingTimer(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	room := entity.NewRoom(clientcollection.New())
+	room.NewClient("owner")
+	room.NewClient("client1")
+	_ = room.AddStory(ctx, "owner", "Story 1")
+	_ = room.Vote(ctx, "owner", lo.ToPtr("8"))
+
+	if err := room.StartVotingTimer(ctx, "owner", now, 30*time.Second); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if room.ExpireVotingTimer(now.Add(29 * time.Second)) {
+		t.Fatal("expected timer not to expire before the deadline")
+	}
+	if room.Reveal {
+		t.Fatal("expected room to stay hidden before the deadline")
+	}
+
+	if !room.ExpireVotingTimer(now.Add(30 * time.Second)) {
+		t.Fatal("expected timer to expire at the deadline")
+	}
+	if !room.Reveal {
+		t.Error("expected room to be revealed even though client1 did not vote")
+	}
+	if room.VotingDeadline != nil {
+		t.Error("expected deadline to be cleared")
+	}
+	if room.Stories[0].Result == nil || *room.Stories[0].Result != 8 {
+		t.Errorf("expected story result 8, got %v", lo.FromPtr(room.Stories[0].Result))
+	}
+
+	if room.ExpireVotingTimer(now.Add(time.Minute)) {
+		t.Error("expected expiring twice to be a no-op")
+	}
+}
+
+func TestRoom_RevealClearsVotingTimer(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	room := entity.NewRoom(clientcollection.New())
+	room.NewClient("owner")
+	_ = room.StartVotingTimer(ctx, "owner", now, time.Minute)
+
+	if err := room.ToggleReveal(ctx, "owner"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if room.VotingDeadline != nil {
+		t.Error("expected reveal to clear the deadline")
+	}
+
+	_ = room.ToggleReveal(ctx, "owner")
+	_ = room.StartVotingTimer(ctx, "owner", now, time.Minute)
+	if err := room.CancelVotingTimer(ctx, "owner"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if room.VotingDeadline != nil {
+		t.Error("expected cancel to clear the deadline")
+	}
+}
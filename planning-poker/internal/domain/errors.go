@@ -6,4 +6,27 @@ var (
 	ErrRoomNotFound   = domainerror.ErrRoomNotFound
 	ErrClientNotFound = domainerror.ErrClientNotFound
 	ErrLastOwner      = domainerror.ErrLastOwner
+	ErrInvalidDeck    = domainerror.ErrInvalidDeck
+	ErrInvalidVote    = domainerror.ErrInvalidVote
+
+	ErrInvalidConsensusRule = domainerror.ErrInvalidConsensusRule
+	ErrInvalidTimerDuration = domainerror.ErrInvalidTimerDuration
+	ErrInvalidPasscode      = domainerror.ErrInvalidPasscode
+	ErrInvalidInvite        = domainerror.ErrInvalidInvite
+	ErrRoomAccessDenied     = domainerror.ErrRoomAccessDenied
+	ErrPermissionDenied     = domainerror.ErrPermissionDenied
+	ErrInvalidPermission    = domainerror.ErrInvalidPermission
+	ErrVersionConflict      = domainerror.ErrVersionConflict
+	ErrInvalidCursor        = domainerror.ErrInvalidCursor
+	ErrInvalidMessage       = domainerror.ErrInvalidMessage
+	ErrUnknownMessageType   = domainerror.ErrUnknownMessageType
+	ErrInvalidPayload       = domainerror.ErrInvalidPayload
+	ErrRateLimited          = domainerror.ErrRateLimited
+	ErrIdentityMismatch     = domainerror.ErrIdentityMismatch
+	ErrInvalidBotName       = domainerror.ErrInvalidBotName
+	ErrInvalidBotToken      = domainerror.ErrInvalidBotToken
+	ErrInvalidWebhook       = domainerror.ErrInvalidWebhook
+	ErrWebhookNotFound      = domainerror.ErrWebhookNotFound
 )
+
+type PermissionError = domainerror.PermissionError
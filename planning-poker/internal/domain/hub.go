@@ -23,6 +23,9 @@ type (
 		RemoveBus(ctx context.Context, clientID string)
 	}
 	AdminHub interface {
-		GetRooms() []*entity.Room
+		// ListRooms returns a page of the rooms matching the query. It fails
+		// with ErrInvalidCursor when the cursor was not issued by the hub.
+		ListRooms(ctx context.Context, query RoomQuery) (RoomPage, error)
+		RoomEvents(ctx context.Context, roomID string) ([]entity.RoomEvent, error)
 	}
 )
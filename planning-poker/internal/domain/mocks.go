@@ -518,40 +518,80 @@ func (m *MockAdminHub) EXPECT() *MockAdminHubMockRecorder {
 	return m.recorder
 }
 
-// GetRooms mocks base method.
-func (m *MockAdminHub) GetRooms() []*entity.Room {
+// ListRooms mocks base method.
+func (m *MockAdminHub) ListRooms(ctx context.Context, query RoomQuery) (RoomPage, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetRooms")
-	ret0, _ := ret[0].([]*entity.Room)
-	return ret0
+	ret := m.ctrl.Call(m, "ListRooms", ctx, query)
+	ret0, _ := ret[0].(RoomPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// GetRooms indicates an expected call of GetRooms.
-func (mr *MockAdminHubMockRecorder) GetRooms() *MockAdminHubGetRoomsCall {
+// ListRooms indicates an expected call of ListRooms.
+func (mr *MockAdminHubMockRecorder) ListRooms(ctx, query any) *MockAdminHubListRoomsCall {
 	mr.mock.ctrl.T.Helper()
-	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRooms", reflect.TypeOf((*MockAdminHub)(nil).GetRooms))
-	return &MockAdminHubGetRoomsCall{Call: call}
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRooms", reflect.TypeOf((*MockAdminHub)(nil).ListRooms), ctx, query)
+	return &MockAdminHubListRoomsCall{Call: call}
 }
 
-// MockAdminHubGetRoomsCall wrap *gomock.Call
-type MockAdminHubGetRoomsCall struct {
+// MockAdminHubListRoomsCall wrap *gomock.Call
+type MockAdminHubListRoomsCall struct {
 	*gomock.Call
 }
 
 // Return rewrite *gomock.Call.Return
-func (c *MockAdminHubGetRoomsCall) Return(arg0 []*entity.Room) *MockAdminHubGetRoomsCall {
-	c.Call = c.Call.Return(arg0)
+func (c *MockAdminHubListRoomsCall) Return(arg0 RoomPage, arg1 error) *MockAdminHubListRoomsCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockAdminHubListRoomsCall) Do(f func(context.Context, RoomQuery) (RoomPage, error)) *MockAdminHubListRoomsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockAdminHubListRoomsCall) DoAndReturn(f func(context.Context, RoomQuery) (RoomPage, error)) *MockAdminHubListRoomsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// RoomEvents mocks base method.
+func (m *MockAdminHub) RoomEvents(ctx context.Context, roomID string) ([]entity.RoomEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RoomEvents", ctx, roomID)
+	ret0, _ := ret[0].([]entity.RoomEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RoomEvents indicates an expected call of RoomEvents.
+func (mr *MockAdminHubMockRecorder) RoomEvents(ctx, roomID any) *MockAdminHubRoomEventsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RoomEvents", reflect.TypeOf((*MockAdminHub)(nil).RoomEvents), ctx, roomID)
+	return &MockAdminHubRoomEventsCall{Call: call}
+}
+
+// MockAdminHubRoomEventsCall wrap *gomock.Call
+type MockAdminHubRoomEventsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockAdminHubRoomEventsCall) Return(arg0 []entity.RoomEvent, arg1 error) *MockAdminHubRoomEventsCall {
+	c.Call = c.Call.Return(arg0, arg1)
 	return c
 }
 
 // Do rewrite *gomock.Call.Do
-func (c *MockAdminHubGetRoomsCall) Do(f func() []*entity.Room) *MockAdminHubGetRoomsCall {
+func (c *MockAdminHubRoomEventsCall) Do(f func(context.Context, string) ([]entity.RoomEvent, error)) *MockAdminHubRoomEventsCall {
 	c.Call = c.Call.Do(f)
 	return c
 }
 
 // DoAndReturn rewrite *gomock.Call.DoAndReturn
-func (c *MockAdminHubGetRoomsCall) DoAndReturn(f func() []*entity.Room) *MockAdminHubGetRoomsCall {
+func (c *MockAdminHubRoomEventsCall) DoAndReturn(f func(context.Context, string) ([]entity.RoomEvent, error)) *MockAdminHubRoomEventsCall {
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
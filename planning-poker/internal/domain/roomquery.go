@@ -0,0 +1,182 @@
+package domain
+
+import (
+	"cmp"
+	"fmt"
+	"planning-poker/internal/domain/entity"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type RoomSort string
+
+const (
+	// RoomSortNone keeps the order of the storage, which hubs can page through
+	// without loading every room
+	RoomSortNone         RoomSort = ""
+	RoomSortID           RoomSort = "id"
+	RoomSortParticipants RoomSort = "participants"
+	RoomSortIdle         RoomSort = "idle"
+	RoomSortBacklogMode  RoomSort = "backlog_mode"
+	RoomSortOwner        RoomSort = "owner"
+
+	DefaultRoomPageSize = 50
+)
+
+type (
+	// RoomQuery selects a page of rooms. Zero values do not filter.
+	RoomQuery struct {
+		// Cursor is the NextCursor of the previous page, empty for the first one
+		Cursor          string
+		Limit           int
+		MinParticipants int
+		MaxParticipants int
+		// MinIdle keeps the rooms that were not updated for at least that long
+		MinIdle     time.Duration
+		BacklogMode *bool
+		// OwnerName keeps the rooms with an owner whose name contains it,
+		// ignoring case
+		OwnerName  string
+		SortBy     RoomSort
+		Descending bool
+	}
+	RoomPage struct {
+		Rooms []*entity.Room
+		// NextCursor is empty on the last page
+		NextCursor string
+	}
+)
+
+func (s RoomSort) Valid() bool {
+	switch s {
+	case RoomSortNone, RoomSortID, RoomSortParticipants, RoomSortIdle, RoomSortBacklogMode, RoomSortOwner:
+		return true
+	}
+	return false
+}
+
+func (q RoomQuery) PageSize() int {
+	if q.Limit <= 0 {
+		return DefaultRoomPageSize
+	}
+	return q.Limit
+}
+
+// Matches tells whether the room passes the filters of the query.
+func (q RoomQuery) Matches(room *entity.Room, now time.Time) bool {
+	participants := room.Clients.Count()
+	if q.MinParticipants > 0 && participants < q.MinParticipants {
+		return false
+	}
+	if q.MaxParticipants > 0 && participants > q.MaxParticipants {
+		return false
+	}
+	if q.MinIdle > 0 && now.Sub(room.UpdatedAt) < q.MinIdle {
+		return false
+	}
+	if q.BacklogMode != nil && room.BacklogMode != *q.BacklogMode {
+		return false
+	}
+	if q.OwnerName != "" {
+		name := strings.ToLower(q.OwnerName)
+		owners := room.Clients.Filter(func(c *entity.Client) bool {
+			return c.IsOwner && strings.Contains(strings.ToLower(c.Name), name)
+		})
+		if owners.Count() == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// PageRooms filters, sorts and pages rooms that are all in memory. Its cursor
+// is the offset of the next page, so pages may shift while rooms come and go.
+func PageRooms(rooms []*entity.Room, query RoomQuery, now time.Time) (RoomPage, error) {
+	offset, err := ParseOffsetCursor(query.Cursor)
+	if err != nil {
+		return RoomPage{}, err
+	}
+
+	matching := make([]*entity.Room, 0, len(rooms))
+	for _, room := range rooms {
+		if query.Matches(room, now) {
+			matching = append(matching, room)
+		}
+	}
+	if query.SortBy != RoomSortNone {
+		SortRooms(matching, query.SortBy, query.Descending)
+	}
+
+	if offset >= len(matching) {
+		return RoomPage{Rooms: []*entity.Room{}}, nil
+	}
+	end := min(offset+query.PageSize(), len(matching))
+	page := RoomPage{Rooms: matching[offset:end]}
+	if end < len(matching) {
+		page.NextCursor = strconv.Itoa(end)
+	}
+	return page, nil
+}
+
+// ParseOffsetCursor reads the cursor of hubs that page by offset.
+func ParseOffsetCursor(cursor string) (int, error) {
+	if cursor == "" {
+		return 0, nil
+	}
+	offset, err := strconv.Atoi(cursor)
+	if err != nil || offset < 0 {
+		return 0, fmt.Errorf("cursor %q: %w", cursor, ErrInvalidCursor)
+	}
+	return offset, nil
+}
+
+// SortRooms sorts rooms by the given field, then by ID. Idle sorts the most
+// recently updated rooms first.
+func SortRooms(rooms []*entity.Room, by RoomSort, descending bool) {
+	slices.SortStableFunc(rooms, func(a, b *entity.Room) int {
+		var c int
+		switch by {
+		case RoomSortParticipants:
+			c = cmp.Compare(a.Clients.Count(), b.Clients.Count())
+		case RoomSortIdle:
+			c = b.UpdatedAt.Compare(a.UpdatedAt)
+		case RoomSortBacklogMode:
+			c = compareBool(a.BacklogMode, b.BacklogMode)
+		case RoomSortOwner:
+			c = strings.Compare(ownerName(a), ownerName(b))
+		}
+		if c == 0 {
+			c = strings.Compare(a.ID, b.ID)
+		}
+		if descending {
+			return -c
+		}
+		return c
+	})
+}
+
+// ownerName is the first owner name of the room in alphabetical order.
+func ownerName(room *entity.Room) string {
+	var name string
+	found := false
+	room.Clients.ForEach(func(c *entity.Client) {
+		lower := strings.ToLower(c.Name)
+		if c.IsOwner && (!found || lower < name) {
+			name, found = lower, true
+		}
+	})
+	return name
+}
+
+func compareBool(a, b bool) int {
+	switch {
+	case a == b:
+		return 0
+	case a:
+		return 1
+	default:
+		return -1
+	}
+}
@@ -0,0 +1,4 @@
+package audit
+
+//go:generate go tool mockgen -destination mocks.go -typed -package audit . RedisSinkClient,PostgresSinkClient
+//go:generate go tool mockgen -destination pgxmocks.go -typed -package audit github.com/jackc/pgx/v5 Row
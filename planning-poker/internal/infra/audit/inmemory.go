@@ -0,0 +1,51 @@
+package audit
+
+import (
+	"context"
+	"planning-poker/internal/application/audit"
+	"strconv"
+	"sync"
+)
+
+// InMemorySink keeps the latest records of a single instance.
+type InMemorySink struct {
+	maxRecords int
+	mu         sync.RWMutex
+	records    []audit.Record
+	sequence   int64
+}
+
+var _ audit.Sink = (*InMemorySink)(nil)
+
+func NewInMemorySink(maxRecords int) *InMemorySink {
+	return &InMemorySink{maxRecords: maxRecords}
+}
+
+func (s *InMemorySink) Write(_ context.Context, record audit.Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sequence++
+	record.ID = strconv.FormatInt(s.sequence, 10)
+	s.records = append(s.records, record)
+	if s.maxRecords > 0 && len(s.records) > s.maxRecords {
+		s.records = s.records[len(s.records)-s.maxRecords:]
+	}
+	return nil
+}
+
+func (s *InMemorySink) Query(_ context.Context, filter audit.Filter) ([]audit.Record, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var records []audit.Record
+	for i := len(s.records) - 1; i >= 0; i-- {
+		if filter.Limit > 0 && len(records) == filter.Limit {
+			break
+		}
+		if filter.Matches(s.records[i]) {
+			records = append(records, s.records[i])
+		}
+	}
+	return records, nil
+}
@@ -0,0 +1,75 @@
+package audit
+
+import (
+	"context"
+	"planning-poker/internal/application/audit"
+	"testing"
+	"time"
+)
+
+func TestInMemorySink_Query(t *testing.T) {
+	sink := NewInMemorySink(0)
+	ctx := context.Background()
+	start := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	records := []audit.Record{
+		{RoomID: "room-1", Actor: "alice", Action: audit.ActionKickClient, Timestamp: start},
+		{RoomID: "room-1", Actor: "bob", Action: audit.ActionToggleOwner, Timestamp: start.Add(time.Minute)},
+		{RoomID: "room-2", Actor: "alice", Action: audit.ActionRemoveStory, Timestamp: start.Add(2 * time.Minute)},
+		{RoomID: "room-1", Actor: "alice", Action: audit.ActionToggleOwner, Timestamp: start.Add(3 * time.Minute)},
+	}
+	for _, record := range records {
+		if err := sink.Write(ctx, record); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name     string
+		filter   audit.Filter
+		expected []string
+	}{
+		{name: "everything, newest first", filter: audit.Filter{}, expected: []string{"4", "3", "2", "1"}},
+		{name: "by room", filter: audit.Filter{RoomID: "room-1"}, expected: []string{"4", "2", "1"}},
+		{name: "by actor", filter: audit.Filter{Actor: "alice"}, expected: []string{"4", "3", "1"}},
+		{name: "by time range", filter: audit.Filter{From: start.Add(time.Minute), To: start.Add(3 * time.Minute)}, expected: []string{"3", "2"}},
+		{name: "limited", filter: audit.Filter{RoomID: "room-1", Actor: "alice", Limit: 1}, expected: []string{"4"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			found, err := sink.Query(ctx, tt.filter)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var ids []string
+			for _, record := range found {
+				ids = append(ids, record.ID)
+			}
+			if len(ids) != len(tt.expected) {
+				t.Fatalf("expected records %v, got %v", tt.expected, ids)
+			}
+			for i := range ids {
+				if ids[i] != tt.expected[i] {
+					t.Fatalf("expected records %v, got %v", tt.expected, ids)
+				}
+			}
+		})
+	}
+}
+
+func TestInMemorySink_KeepsTheLatestRecords(t *testing.T) {
+	sink := NewInMemorySink(2)
+	ctx := context.Background()
+
+	for range 3 {
+		if err := sink.Write(ctx, audit.Record{RoomID: "room-1"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	found, _ := sink.Query(ctx, audit.Filter{})
+	if len(found) != 2 || found[0].ID != "3" || found[1].ID != "2" {
+		t.Errorf("expected the 2 latest records, got %+v", found)
+	}
+}
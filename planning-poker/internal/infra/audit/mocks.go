@@ -0,0 +1,231 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: planning-poker/internal/infra/audit (interfaces: RedisSinkClient,PostgresSinkClient)
+//
+// Generated by this command:
+//
+//	mockgen -destination mocks.go -typed -package audit . RedisSinkClient,PostgresSinkClient
+//
+
+// Package audit is a generated GoMock package.
+package audit
+
+import (
+	context "context"
+	reflect "reflect"
+
+	pgx "github.com/jackc/pgx/v5"
+	pgconn "github.com/jackc/pgx/v5/pgconn"
+	redis "github.com/redis/go-redis/v9"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRedisSinkClient is a mock of RedisSinkClient interface.
+type MockRedisSinkClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockRedisSinkClientMockRecorder
+	isgomock struct{}
+}
+
+// MockRedisSinkClientMockRecorder is the mock recorder for MockRedisSinkClient.
+type MockRedisSinkClientMockRecorder struct {
+	mock *MockRedisSinkClient
+}
+
+// NewMockRedisSinkClient creates a new mock instance.
+func NewMockRedisSinkClient(ctrl *gomock.Controller) *MockRedisSinkClient {
+	mock := &MockRedisSinkClient{ctrl: ctrl}
+	mock.recorder = &MockRedisSinkClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRedisSinkClient) EXPECT() *MockRedisSinkClientMockRecorder {
+	return m.recorder
+}
+
+// XAdd mocks base method.
+func (m *MockRedisSinkClient) XAdd(ctx context.Context, a *redis.XAddArgs) *redis.StringCmd {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "XAdd", ctx, a)
+	ret0, _ := ret[0].(*redis.StringCmd)
+	return ret0
+}
+
+// XAdd indicates an expected call of XAdd.
+func (mr *MockRedisSinkClientMockRecorder) XAdd(ctx, a any) *MockRedisSinkClientXAddCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "XAdd", reflect.TypeOf((*MockRedisSinkClient)(nil).XAdd), ctx, a)
+	return &MockRedisSinkClientXAddCall{Call: call}
+}
+
+// MockRedisSinkClientXAddCall wrap *gomock.Call
+type MockRedisSinkClientXAddCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockRedisSinkClientXAddCall) Return(arg0 *redis.StringCmd) *MockRedisSinkClientXAddCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockRedisSinkClientXAddCall) Do(f func(context.Context, *redis.XAddArgs) *redis.StringCmd) *MockRedisSinkClientXAddCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockRedisSinkClientXAddCall) DoAndReturn(f func(context.Context, *redis.XAddArgs) *redis.StringCmd) *MockRedisSinkClientXAddCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// XRevRangeN mocks base method.
+func (m *MockRedisSinkClient) XRevRangeN(ctx context.Context, stream, start, stop string, count int64) *redis.XMessageSliceCmd {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "XRevRangeN", ctx, stream, start, stop, count)
+	ret0, _ := ret[0].(*redis.XMessageSliceCmd)
+	return ret0
+}
+
+// XRevRangeN indicates an expected call of XRevRangeN.
+func (mr *MockRedisSinkClientMockRecorder) XRevRangeN(ctx, stream, start, stop, count any) *MockRedisSinkClientXRevRangeNCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "XRevRangeN", reflect.TypeOf((*MockRedisSinkClient)(nil).XRevRangeN), ctx, stream, start, stop, count)
+	return &MockRedisSinkClientXRevRangeNCall{Call: call}
+}
+
+// MockRedisSinkClientXRevRangeNCall wrap *gomock.Call
+type MockRedisSinkClientXRevRangeNCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockRedisSinkClientXRevRangeNCall) Return(arg0 *redis.XMessageSliceCmd) *MockRedisSinkClientXRevRangeNCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockRedisSinkClientXRevRangeNCall) Do(f func(context.Context, string, string, string, int64) *redis.XMessageSliceCmd) *MockRedisSinkClientXRevRangeNCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockRedisSinkClientXRevRangeNCall) DoAndReturn(f func(context.Context, string, string, string, int64) *redis.XMessageSliceCmd) *MockRedisSinkClientXRevRangeNCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockPostgresSinkClient is a mock of PostgresSinkClient interface.
+type MockPostgresSinkClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockPostgresSinkClientMockRecorder
+	isgomock struct{}
+}
+
+// MockPostgresSinkClientMockRecorder is the mock recorder for MockPostgresSinkClient.
+type MockPostgresSinkClientMockRecorder struct {
+	mock *MockPostgresSinkClient
+}
+
+// NewMockPostgresSinkClient creates a new mock instance.
+func NewMockPostgresSinkClient(ctrl *gomock.Controller) *MockPostgresSinkClient {
+	mock := &MockPostgresSinkClient{ctrl: ctrl}
+	mock.recorder = &MockPostgresSinkClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPostgresSinkClient) EXPECT() *MockPostgresSinkClientMockRecorder {
+	return m.recorder
+}
+
+// Exec mocks base method.
+func (m *MockPostgresSinkClient) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, sql}
+	for _, a := range args {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Exec", varargs...)
+	ret0, _ := ret[0].(pgconn.CommandTag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Exec indicates an expected call of Exec.
+func (mr *MockPostgresSinkClientMockRecorder) Exec(ctx, sql any, args ...any) *MockPostgresSinkClientExecCall {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, sql}, args...)
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Exec", reflect.TypeOf((*MockPostgresSinkClient)(nil).Exec), varargs...)
+	return &MockPostgresSinkClientExecCall{Call: call}
+}
+
+// MockPostgresSinkClientExecCall wrap *gomock.Call
+type MockPostgresSinkClientExecCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockPostgresSinkClientExecCall) Return(arg0 pgconn.CommandTag, arg1 error) *MockPostgresSinkClientExecCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockPostgresSinkClientExecCall) Do(f func(context.Context, string, ...any) (pgconn.CommandTag, error)) *MockPostgresSinkClientExecCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockPostgresSinkClientExecCall) DoAndReturn(f func(context.Context, string, ...any) (pgconn.CommandTag, error)) *MockPostgresSinkClientExecCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// QueryRow mocks base method.
+func (m *MockPostgresSinkClient) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, sql}
+	for _, a := range args {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "QueryRow", varargs...)
+	ret0, _ := ret[0].(pgx.Row)
+	return ret0
+}
+
+// QueryRow indicates an expected call of QueryRow.
+func (mr *MockPostgresSinkClientMockRecorder) QueryRow(ctx, sql any, args ...any) *MockPostgresSinkClientQueryRowCall {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, sql}, args...)
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryRow", reflect.TypeOf((*MockPostgresSinkClient)(nil).QueryRow), varargs...)
+	return &MockPostgresSinkClientQueryRowCall{Call: call}
+}
+
+// MockPostgresSinkClientQueryRowCall wrap *gomock.Call
+type MockPostgresSinkClientQueryRowCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockPostgresSinkClientQueryRowCall) Return(arg0 pgx.Row) *MockPostgresSinkClientQueryRowCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockPostgresSinkClientQueryRowCall) Do(f func(context.Context, string, ...any) pgx.Row) *MockPostgresSinkClientQueryRowCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockPostgresSinkClientQueryRowCall) DoAndReturn(f func(context.Context, string, ...any) pgx.Row) *MockPostgresSinkClientQueryRowCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
@@ -0,0 +1,106 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"planning-poker/internal/application/audit"
+	"strconv"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+type PostgresSinkClient interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+const (
+	writeRecordSQL = `
+INSERT INTO audit_records (recorded_at, room_id, actor, record) VALUES ($1, $2, $3, $4)`
+
+	// writeTrimmedRecordSQL also deletes the records beyond the latest $5,
+	// counting the one written
+	writeTrimmedRecordSQL = `
+WITH trimmed AS (
+    DELETE FROM audit_records
+    WHERE id <= (SELECT id FROM audit_records ORDER BY id DESC OFFSET $5::BIGINT - 1 LIMIT 1)
+)
+INSERT INTO audit_records (recorded_at, room_id, actor, record) VALUES ($1, $2, $3, $4)`
+
+	queryRecordsSQL = `
+SELECT COALESCE(json_agg(json_build_object('id', a.id, 'record', a.record) ORDER BY a.id DESC), '[]')
+FROM (
+    SELECT id, record FROM audit_records
+    WHERE ($1::TEXT = '' OR room_id = $1)
+      AND ($2::TEXT = '' OR actor = $2)
+      AND ($3::TIMESTAMPTZ IS NULL OR recorded_at >= $3)
+      AND ($4::TIMESTAMPTZ IS NULL OR recorded_at < $4)
+    ORDER BY id DESC
+    LIMIT $5
+) a`
+)
+
+// PostgresSink keeps the records in a table shared by every instance, for
+// deployments that store rooms in Postgres, trimmed to the latest maxRecords.
+type PostgresSink struct {
+	client     PostgresSinkClient
+	maxRecords int64
+}
+
+var _ audit.Sink = (*PostgresSink)(nil)
+
+func NewPostgresSink(client PostgresSinkClient, maxRecords int64) *PostgresSink {
+	return &PostgresSink{client: client, maxRecords: maxRecords}
+}
+
+func (s *PostgresSink) Write(ctx context.Context, record audit.Record) error {
+	data, err := json.Marshal(serializeRecord(record))
+	if err != nil {
+		return fmt.Errorf("failed to serialize audit record: %w", err)
+	}
+
+	args := []any{record.Timestamp, record.RoomID, record.Actor, data}
+	sql := writeRecordSQL
+	if s.maxRecords > 0 {
+		sql = writeTrimmedRecordSQL
+		args = append(args, s.maxRecords)
+	}
+	if _, err := s.client.Exec(ctx, sql, args...); err != nil {
+		return fmt.Errorf("failed to write audit record: %w", err)
+	}
+	return nil
+}
+
+func (s *PostgresSink) Query(ctx context.Context, filter audit.Filter) ([]audit.Record, error) {
+	var from, to, limit any
+	if !filter.From.IsZero() {
+		from = filter.From
+	}
+	if !filter.To.IsZero() {
+		to = filter.To
+	}
+	if filter.Limit > 0 {
+		limit = filter.Limit
+	}
+
+	var data []byte
+	err := s.client.QueryRow(ctx, queryRecordsSQL, filter.RoomID, filter.Actor, from, to, limit).Scan(&data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read audit records: %w", err)
+	}
+
+	var rows []struct {
+		ID     int64            `json:"id"`
+		Record serializedRecord `json:"record"`
+	}
+	if err := json.Unmarshal(data, &rows); err != nil {
+		return nil, fmt.Errorf("failed to deserialize audit records: %w", err)
+	}
+	records := make([]audit.Record, 0, len(rows))
+	for _, row := range rows {
+		records = append(records, row.Record.record(strconv.FormatInt(row.ID, 10)))
+	}
+	return records, nil
+}
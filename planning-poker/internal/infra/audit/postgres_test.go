@@ -0,0 +1,83 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"planning-poker/internal/application/audit"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"go.uber.org/mock/gomock"
+)
+
+func TestPostgresSink_Write_TrimsTheTable(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := NewMockPostgresSinkClient(ctrl)
+	sink := NewPostgresSink(client, 1000)
+	record := audit.Record{
+		Timestamp: time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC),
+		RoomID:    "room-1",
+		Action:    audit.ActionKickClient,
+		Actor:     audit.AdminActor("alice"),
+		Target:    "client-1",
+		Outcome:   audit.OutcomeSucceeded,
+	}
+
+	client.EXPECT().
+		Exec(gomock.Any(), writeTrimmedRecordSQL, record.Timestamp, "room-1", "admin:alice", gomock.Any(), int64(1000)).
+		DoAndReturn(func(_ context.Context, _ string, args ...any) (pgconn.CommandTag, error) {
+			var serialized serializedRecord
+			if err := json.Unmarshal(args[3].([]byte), &serialized); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := serialized.record(""); got != record {
+				t.Errorf("expected %+v, got %+v", record, got)
+			}
+			return pgconn.NewCommandTag("INSERT 0 1"), nil
+		})
+
+	if err := sink.Write(context.Background(), record); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestPostgresSink_Query(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := NewMockPostgresSinkClient(ctrl)
+	sink := NewPostgresSink(client, 0)
+	from := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	rows, _ := json.Marshal([]map[string]any{
+		{"id": 7, "record": serializedRecord{RoomID: "room-1", Actor: "alice", Timestamp: from.Add(time.Minute)}},
+	})
+	row := NewMockRow(ctrl)
+	row.EXPECT().Scan(gomock.Any()).DoAndReturn(func(dest ...any) error {
+		*dest[0].(*[]byte) = rows
+		return nil
+	})
+	client.EXPECT().QueryRow(gomock.Any(), queryRecordsSQL, "room-1", "", from, nil, 10).Return(row)
+
+	records, err := sink.Query(context.Background(), audit.Filter{RoomID: "room-1", From: from, Limit: 10})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(records) != 1 || records[0].ID != "7" || records[0].Actor != "alice" {
+		t.Errorf("expected the record of alice, got %+v", records)
+	}
+}
+
+func TestPostgresSink_Query_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := NewMockPostgresSinkClient(ctrl)
+	sink := NewPostgresSink(client, 0)
+
+	row := NewMockRow(ctrl)
+	row.EXPECT().Scan(gomock.Any()).Return(errors.New("connection refused"))
+	client.EXPECT().QueryRow(gomock.Any(), queryRecordsSQL, "", "", nil, nil, nil).Return(row)
+
+	if _, err := sink.Query(context.Background(), audit.Filter{}); err == nil {
+		t.Fatal("expected an error")
+	}
+}
@@ -0,0 +1,150 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"planning-poker/internal/application/audit"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+type RedisSinkClient interface {
+	XAdd(ctx context.Context, a *redis.XAddArgs) *redis.StringCmd
+	XRevRangeN(ctx context.Context, stream, start, stop string, count int64) *redis.XMessageSliceCmd
+}
+
+const (
+	auditStreamKey = "planning-poker:audit"
+	recordField    = "record"
+	// queryPageSize is how many entries are read at once while filtering
+	queryPageSize = 500
+	// streamClockSkew tolerates clock skew between Redis and the instances
+	streamClockSkew = time.Minute
+)
+
+type serializedRecord struct {
+	Timestamp  time.Time `json:"timestamp"`
+	RoomID     string    `json:"roomId"`
+	Action     string    `json:"action"`
+	Actor      string    `json:"actor"`
+	ActorName  string    `json:"actorName,omitempty"`
+	Target     string    `json:"target,omitempty"`
+	TargetName string    `json:"targetName,omitempty"`
+	Outcome    string    `json:"outcome"`
+	Error      string    `json:"error,omitempty"`
+}
+
+// RedisSink appends the records to a stream shared by every instance,
+// trimmed to about maxRecords entries. Stream IDs start with the time they
+// were added at, so time ranges are read straight from the stream.
+type RedisSink struct {
+	client     RedisSinkClient
+	maxRecords int64
+}
+
+var _ audit.Sink = (*RedisSink)(nil)
+
+func NewRedisSink(client RedisSinkClient, maxRecords int64) *RedisSink {
+	return &RedisSink{client: client, maxRecords: maxRecords}
+}
+
+func (s *RedisSink) Write(ctx context.Context, record audit.Record) error {
+	data, err := json.Marshal(serializeRecord(record))
+	if err != nil {
+		return fmt.Errorf("failed to serialize audit record: %w", err)
+	}
+
+	args := &redis.XAddArgs{
+		Stream: auditStreamKey,
+		Values: map[string]any{recordField: data},
+	}
+	if s.maxRecords > 0 {
+		args.MaxLen = s.maxRecords
+		args.Approx = true
+	}
+	if err := s.client.XAdd(ctx, args).Err(); err != nil {
+		return fmt.Errorf("failed to write audit record: %w", err)
+	}
+	return nil
+}
+
+func (s *RedisSink) Query(ctx context.Context, filter audit.Filter) ([]audit.Record, error) {
+	// stream IDs come from the clock of Redis and timestamps from the one of
+	// the instance, the range is widened and the timestamps filtered exactly
+	end, start := "+", "-"
+	if !filter.To.IsZero() {
+		end = strconv.FormatInt(filter.To.Add(streamClockSkew).UnixMilli(), 10)
+	}
+	if !filter.From.IsZero() {
+		start = strconv.FormatInt(filter.From.Add(-streamClockSkew).UnixMilli(), 10)
+	}
+
+	var records []audit.Record
+	for {
+		messages, err := s.client.XRevRangeN(ctx, auditStreamKey, end, start, queryPageSize).Result()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read audit records: %w", err)
+		}
+
+		for _, message := range messages {
+			record, err := deserializeRecord(message)
+			if err != nil {
+				return nil, err
+			}
+			if !filter.Matches(record) {
+				continue
+			}
+			records = append(records, record)
+			if filter.Limit > 0 && len(records) == filter.Limit {
+				return records, nil
+			}
+		}
+
+		if len(messages) < queryPageSize {
+			return records, nil
+		}
+		end = "(" + messages[len(messages)-1].ID
+	}
+}
+
+func deserializeRecord(message redis.XMessage) (audit.Record, error) {
+	data, _ := message.Values[recordField].(string)
+
+	var serialized serializedRecord
+	if err := json.Unmarshal([]byte(data), &serialized); err != nil {
+		return audit.Record{}, fmt.Errorf("failed to deserialize audit record %s: %w", message.ID, err)
+	}
+	return serialized.record(message.ID), nil
+}
+
+func serializeRecord(record audit.Record) serializedRecord {
+	return serializedRecord{
+		Timestamp:  record.Timestamp,
+		RoomID:     record.RoomID,
+		Action:     string(record.Action),
+		Actor:      record.Actor,
+		ActorName:  record.ActorName,
+		Target:     record.Target,
+		TargetName: record.TargetName,
+		Outcome:    string(record.Outcome),
+		Error:      record.Error,
+	}
+}
+
+func (s serializedRecord) record(id string) audit.Record {
+	return audit.Record{
+		ID:         id,
+		Timestamp:  s.Timestamp,
+		RoomID:     s.RoomID,
+		Action:     audit.Action(s.Action),
+		Actor:      s.Actor,
+		ActorName:  s.ActorName,
+		Target:     s.Target,
+		TargetName: s.TargetName,
+		Outcome:    audit.Outcome(s.Outcome),
+		Error:      s.Error,
+	}
+}
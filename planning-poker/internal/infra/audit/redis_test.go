@@ -0,0 +1,105 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"planning-poker/internal/application/audit"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/mock/gomock"
+)
+
+func streamMessage(t *testing.T, id string, record serializedRecord) redis.XMessage {
+	t.Helper()
+	data, err := json.Marshal(record)
+	if err != nil {
+		t.Fatalf("failed to marshal record: %v", err)
+	}
+	return redis.XMessage{ID: id, Values: map[string]any{recordField: string(data)}}
+}
+
+func TestRedisSink_Write(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := NewMockRedisSinkClient(ctrl)
+	sink := NewRedisSink(client, 1000)
+	record := audit.Record{
+		Timestamp: time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC),
+		RoomID:    "room-1",
+		Action:    audit.ActionKickClient,
+		Actor:     audit.AdminActor("alice"),
+		Target:    "client-1",
+		Outcome:   audit.OutcomeSucceeded,
+	}
+
+	client.EXPECT().XAdd(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, args *redis.XAddArgs) *redis.StringCmd {
+		if args.Stream != auditStreamKey || args.MaxLen != 1000 || !args.Approx {
+			t.Errorf("unexpected stream arguments %+v", args)
+		}
+		values := args.Values.(map[string]any)
+		got, err := deserializeRecord(redis.XMessage{ID: "1-0", Values: map[string]any{recordField: string(values[recordField].([]byte))}})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		record.ID = "1-0"
+		if got != record {
+			t.Errorf("expected %+v, got %+v", record, got)
+		}
+		return redis.NewStringResult("1-0", nil)
+	})
+
+	if err := sink.Write(context.Background(), record); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestRedisSink_Query_PagesUntilTheLimit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := NewMockRedisSinkClient(ctrl)
+	sink := NewRedisSink(client, 0)
+	from := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	// the first page only holds records of another room
+	firstPage := make([]redis.XMessage, queryPageSize)
+	for i := range firstPage {
+		firstPage[i] = streamMessage(t, fmt.Sprintf("%d-0", 2000-i), serializedRecord{RoomID: "room-2", Timestamp: from.Add(time.Hour)})
+	}
+	secondPage := []redis.XMessage{
+		streamMessage(t, "1400-0", serializedRecord{RoomID: "room-1", Actor: "alice", Timestamp: from.Add(time.Minute)}),
+		streamMessage(t, "1300-0", serializedRecord{RoomID: "room-1", Actor: "bob", Timestamp: from.Add(-time.Second)}),
+	}
+
+	gomock.InOrder(
+		client.EXPECT().
+			XRevRangeN(gomock.Any(), auditStreamKey, "+", fmt.Sprint(from.Add(-streamClockSkew).UnixMilli()), int64(queryPageSize)).
+			Return(redis.NewXMessageSliceCmdResult(firstPage, nil)),
+		client.EXPECT().
+			XRevRangeN(gomock.Any(), auditStreamKey, "(1501-0", fmt.Sprint(from.Add(-streamClockSkew).UnixMilli()), int64(queryPageSize)).
+			Return(redis.NewXMessageSliceCmdResult(secondPage, nil)),
+	)
+
+	records, err := sink.Query(context.Background(), audit.Filter{RoomID: "room-1", From: from, Limit: 10})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(records) != 1 || records[0].ID != "1400-0" || records[0].Actor != "alice" {
+		t.Errorf("expected only the record of alice, got %+v", records)
+	}
+}
+
+func TestRedisSink_Query_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := NewMockRedisSinkClient(ctrl)
+	sink := NewRedisSink(client, 0)
+
+	client.EXPECT().
+		XRevRangeN(gomock.Any(), auditStreamKey, "+", "-", int64(queryPageSize)).
+		Return(redis.NewXMessageSliceCmdResult(nil, errors.New("connection refused")))
+
+	if _, err := sink.Query(context.Background(), audit.Filter{}); err == nil {
+		t.Fatal("expected an error")
+	}
+}
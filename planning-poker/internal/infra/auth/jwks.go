@@ -0,0 +1,261 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"os"
+	"planning-poker/internal/application/timer"
+	"sync"
+	"time"
+)
+
+// minRefreshInterval bounds how often an unknown kid may trigger a fetch, so
+// tokens with made up kids cannot hammer the identity provider
+const minRefreshInterval = time.Minute
+
+var errUnknownKey = errors.New("unknown signing key")
+
+// KeySource resolves the public key a token was signed with.
+type KeySource interface {
+	Key(ctx context.Context, kid string) (crypto.PublicKey, error)
+}
+
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// KeySet is a parsed JSON Web Key Set. Keys that are not meant for
+// signatures or use an unsupported type are skipped.
+type KeySet map[string]crypto.PublicKey
+
+var _ KeySource = KeySet(nil)
+
+func ParseJWKS(data []byte) (KeySet, error) {
+	var doc struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse JWKS: %w", err)
+	}
+
+	keys := make(KeySet, len(doc.Keys))
+	for _, k := range doc.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		key, err := k.publicKey()
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse key %q: %w", k.Kid, err)
+		}
+		if key != nil {
+			keys[k.Kid] = key
+		}
+	}
+	return keys, nil
+}
+
+func LoadJWKSFile(path string) (KeySet, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read JWKS file: %w", err)
+	}
+	return ParseJWKS(data)
+}
+
+func (s KeySet) Key(_ context.Context, kid string) (crypto.PublicKey, error) {
+	if key, ok := s[kid]; ok {
+		return key, nil
+	}
+	// providers with a single key often leave the kid out of their tokens
+	if kid == "" && len(s) == 1 {
+		for _, key := range s {
+			return key, nil
+		}
+	}
+	return nil, errUnknownKey
+}
+
+func (k jwk) publicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeBigInt(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeBigInt(k.E)
+		if err != nil {
+			return nil, err
+		}
+		if !e.IsInt64() || e.Int64() < 3 || e.Int64() > 1<<31-1 {
+			return nil, errors.New("invalid RSA exponent")
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := decodeBigInt(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeBigInt(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		if !curve.IsOnCurve(x, y) {
+			return nil, errors.New("point is not on the curve")
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	default:
+		return nil, nil
+	}
+}
+
+func decodeBigInt(value string) (*big.Int, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil || len(data) == 0 {
+		return nil, errors.New("invalid key parameter")
+	}
+	return new(big.Int).SetBytes(data), nil
+}
+
+// RemoteKeySet fetches the JWKS of the identity provider, refreshing it every
+// interval and whenever a token names a kid it does not know yet, which is
+// how providers roll their keys. A single fetch runs at a time, without
+// holding up lookups of known keys.
+type RemoteKeySet struct {
+	url      string
+	client   *http.Client
+	clock    timer.Clock
+	interval time.Duration
+
+	mu   sync.Mutex
+	keys KeySet
+	// error of the last fetch, returned until keys are fetched at last
+	err error
+	// when the last fetch started, failed ones included
+	fetchedAt time.Time
+	// closed when the fetch in flight ends, nil when there is none
+	refreshing chan struct{}
+}
+
+var _ KeySource = (*RemoteKeySet)(nil)
+
+func NewRemoteKeySet(url string, client *http.Client, clock timer.Clock, interval time.Duration) *RemoteKeySet {
+	return &RemoteKeySet{
+		url:      url,
+		client:   client,
+		clock:    clock,
+		interval: interval,
+	}
+}
+
+func (s *RemoteKeySet) Key(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	s.mu.Lock()
+	if s.refreshing == nil && s.refreshDue(ctx, kid) {
+		s.refreshing = make(chan struct{})
+		s.fetchedAt = s.clock.Now()
+		// the fetch is shared, a caller giving up must not fail it for the others
+		go s.refresh(context.WithoutCancel(ctx), s.refreshing)
+	}
+
+	if done := s.refreshing; done != nil {
+		if key, err := s.keys.Key(ctx, kid); err == nil {
+			s.mu.Unlock()
+			return key, nil
+		}
+		s.mu.Unlock()
+		select {
+		case <-done:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		s.mu.Lock()
+	}
+	defer s.mu.Unlock()
+
+	if s.keys == nil {
+		return nil, s.err
+	}
+	// keep serving the last known keys while the provider is unreachable
+	return s.keys.Key(ctx, kid)
+}
+
+// refreshDue tells whether the keys must be fetched: they never were, they
+// are older than the interval or kid is unknown. Fetches start at least
+// minRefreshInterval apart. The caller holds mu.
+func (s *RemoteKeySet) refreshDue(ctx context.Context, kid string) bool {
+	if s.fetchedAt.IsZero() {
+		return true
+	}
+	elapsed := s.clock.Now().Sub(s.fetchedAt)
+	if elapsed < minRefreshInterval {
+		return false
+	}
+	if s.keys == nil || (s.interval > 0 && elapsed >= s.interval) {
+		return true
+	}
+	_, err := s.keys.Key(ctx, kid)
+	return err != nil
+}
+
+func (s *RemoteKeySet) refresh(ctx context.Context, done chan struct{}) {
+	keys, err := s.fetch(ctx)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err == nil {
+		s.keys = keys
+	}
+	s.err = err
+	s.refreshing = nil
+	close(done)
+}
+
+func (s *RemoteKeySet) fetch(ctx context.Context) (KeySet, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create JWKS request: %w", err)
+	}
+
+	res, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch JWKS: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch JWKS: status %d", res.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(res.Body, 1<<20))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read JWKS: %w", err)
+	}
+	return ParseJWKS(data)
+}
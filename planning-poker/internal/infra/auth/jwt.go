@@ -0,0 +1,225 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"math/big"
+	"planning-poker/internal/application/auth"
+	"planning-poker/internal/application/timer"
+	"planning-poker/internal/domain/entity"
+	"slices"
+	"strings"
+	"time"
+)
+
+// leeway tolerates clock skew between this server and the identity provider
+const leeway = time.Minute
+
+const fallbackNameClaim = "preferred_username"
+
+// minRSAKeyBits rejects RSA keys too short to be trusted
+const minRSAKeyBits = 2048
+
+type JWTConfig struct {
+	Issuer string
+	// Audience is checked against the aud claim when set
+	Audience string
+	// NameClaim holds the display name, falling back to preferred_username
+	NameClaim string
+}
+
+// JWTVerifier verifies signed JWTs (RS*, PS* and ES* algorithms) issued by a
+// single OIDC provider.
+type JWTVerifier struct {
+	cfg   JWTConfig
+	keys  KeySource
+	clock timer.Clock
+}
+
+var _ auth.TokenVerifier = (*JWTVerifier)(nil)
+
+func NewJWTVerifier(cfg JWTConfig, keys KeySource, clock timer.Clock) *JWTVerifier {
+	if cfg.NameClaim == "" {
+		cfg.NameClaim = "name"
+	}
+	return &JWTVerifier{cfg: cfg, keys: keys, clock: clock}
+}
+
+type jwtHeader struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+}
+
+func (v *JWTVerifier) Verify(ctx context.Context, token string) (entity.Identity, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return entity.Identity{}, invalid("malformed token")
+	}
+
+	var header jwtHeader
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return entity.Identity{}, invalid("malformed header")
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return entity.Identity{}, invalid("malformed signature")
+	}
+
+	key, err := v.keys.Key(ctx, header.Kid)
+	if err != nil {
+		return entity.Identity{}, fmt.Errorf("%w: %w", auth.ErrInvalidToken, err)
+	}
+	if err := verifySignature(header.Alg, key, parts[0]+"."+parts[1], signature); err != nil {
+		return entity.Identity{}, fmt.Errorf("%w: %w", auth.ErrInvalidToken, err)
+	}
+
+	var claims map[string]any
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return entity.Identity{}, invalid("malformed claims")
+	}
+	return v.identity(claims)
+}
+
+func (v *JWTVerifier) identity(claims map[string]any) (entity.Identity, error) {
+	now := v.clock.Now()
+
+	if iss, _ := claims["iss"].(string); iss != v.cfg.Issuer {
+		return entity.Identity{}, invalid("unexpected issuer")
+	}
+	if v.cfg.Audience != "" && !hasAudience(claims["aud"], v.cfg.Audience) {
+		return entity.Identity{}, invalid("unexpected audience")
+	}
+
+	exp, ok := numericDate(claims["exp"])
+	if !ok {
+		return entity.Identity{}, invalid("missing expiration")
+	}
+	if !now.Before(exp.Add(leeway)) {
+		return entity.Identity{}, invalid("token expired")
+	}
+	if nbf, ok := numericDate(claims["nbf"]); ok && now.Add(leeway).Before(nbf) {
+		return entity.Identity{}, invalid("token not valid yet")
+	}
+	if iat, ok := numericDate(claims["iat"]); ok && now.Add(leeway).Before(iat) {
+		return entity.Identity{}, invalid("token issued in the future")
+	}
+
+	sub, _ := claims["sub"].(string)
+	if sub == "" {
+		return entity.Identity{}, invalid("missing subject")
+	}
+
+	name, _ := claims[v.cfg.NameClaim].(string)
+	if name == "" {
+		name, _ = claims[fallbackNameClaim].(string)
+	}
+
+	return entity.Identity{Issuer: v.cfg.Issuer, Subject: sub, Name: name}, nil
+}
+
+func invalid(reason string) error {
+	return fmt.Errorf("%w: %s", auth.ErrInvalidToken, reason)
+}
+
+func decodeSegment(segment string, v any) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+func hasAudience(aud any, expected string) bool {
+	switch aud := aud.(type) {
+	case string:
+		return aud == expected
+	case []any:
+		return slices.Contains(aud, any(expected))
+	default:
+		return false
+	}
+}
+
+func numericDate(value any) (time.Time, bool) {
+	seconds, ok := value.(float64)
+	if !ok {
+		return time.Time{}, false
+	}
+	return time.Unix(int64(seconds), 0), true
+}
+
+func verifySignature(alg string, key crypto.PublicKey, signed string, signature []byte) error {
+	hashFunc, h := hashFor(alg)
+	if h == nil {
+		return fmt.Errorf("unsupported algorithm %q", alg)
+	}
+	h.Write([]byte(signed))
+	digest := h.Sum(nil)
+
+	switch alg[:2] {
+	case "RS", "PS":
+		rsaKey, ok := key.(*rsa.PublicKey)
+		if !ok {
+			return errors.New("key does not match algorithm")
+		}
+		if rsaKey.N.BitLen() < minRSAKeyBits {
+			return fmt.Errorf("RSA key must have at least %d bits", minRSAKeyBits)
+		}
+		if alg[:2] == "PS" {
+			return rsa.VerifyPSS(rsaKey, hashFunc, digest, signature, &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash})
+		}
+		return rsa.VerifyPKCS1v15(rsaKey, hashFunc, digest, signature)
+	default:
+		ecKey, ok := key.(*ecdsa.PublicKey)
+		if !ok || ecKey.Curve != curveFor(alg) {
+			return errors.New("key does not match algorithm")
+		}
+		size := (ecKey.Curve.Params().BitSize + 7) / 8
+		if len(signature) != 2*size {
+			return errors.New("invalid signature")
+		}
+		r := new(big.Int).SetBytes(signature[:size])
+		s := new(big.Int).SetBytes(signature[size:])
+		if !ecdsa.Verify(ecKey, digest, r, s) {
+			return errors.New("invalid signature")
+		}
+		return nil
+	}
+}
+
+// curveFor returns the curve ES algorithms are bound to, as RFC 7518 requires.
+func curveFor(alg string) elliptic.Curve {
+	switch alg {
+	case "ES256":
+		return elliptic.P256()
+	case "ES384":
+		return elliptic.P384()
+	case "ES512":
+		return elliptic.P521()
+	default:
+		return nil
+	}
+}
+
+func hashFor(alg string) (crypto.Hash, hash.Hash) {
+	switch alg {
+	case "RS256", "PS256", "ES256":
+		return crypto.SHA256, sha256.New()
+	case "RS384", "PS384", "ES384":
+		return crypto.SHA384, sha512.New384()
+	case "RS512", "PS512", "ES512":
+		return crypto.SHA512, sha512.New()
+	default:
+		return 0, nil
+	}
+}
@@ -0,0 +1,389 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"planning-poker/internal/application/auth"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+const testIssuer = "https://id.example.com"
+
+func encodeSegment(t *testing.T, v any) string {
+	t.Helper()
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("failed to marshal segment: %v", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func signRS256(t *testing.T, key *rsa.PrivateKey, kid string, claims map[string]any) string {
+	t.Helper()
+	signed := encodeSegment(t, map[string]string{"alg": "RS256", "kid": kid}) + "." + encodeSegment(t, claims)
+	digest := crypto.SHA256.New()
+	digest.Write([]byte(signed))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest.Sum(nil))
+	if err != nil {
+		t.Fatalf("failed to sign token: %v", err)
+	}
+	return signed + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func signES256(t *testing.T, key *ecdsa.PrivateKey, kid string, claims map[string]any) string {
+	t.Helper()
+	signed := encodeSegment(t, map[string]string{"alg": "ES256", "kid": kid}) + "." + encodeSegment(t, claims)
+	digest := crypto.SHA256.New()
+	digest.Write([]byte(signed))
+	r, s, err := ecdsa.Sign(rand.Reader, key, digest.Sum(nil))
+	if err != nil {
+		t.Fatalf("failed to sign token: %v", err)
+	}
+	signature := make([]byte, 64)
+	r.FillBytes(signature[:32])
+	s.FillBytes(signature[32:])
+	return signed + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func rsaJWK(kid string, key *rsa.PublicKey) map[string]string {
+	return map[string]string{
+		"kty": "RSA",
+		"kid": kid,
+		"use": "sig",
+		"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+		"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+	}
+}
+
+func ecJWK(kid string, key *ecdsa.PublicKey) map[string]string {
+	return map[string]string{
+		"kty": "EC",
+		"kid": kid,
+		"crv": "P-256",
+		"x":   base64.RawURLEncoding.EncodeToString(key.X.FillBytes(make([]byte, 32))),
+		"y":   base64.RawURLEncoding.EncodeToString(key.Y.FillBytes(make([]byte, 32))),
+	}
+}
+
+func jwks(t *testing.T, keys ...map[string]string) []byte {
+	t.Helper()
+	data, err := json.Marshal(map[string]any{"keys": keys})
+	if err != nil {
+		t.Fatalf("failed to marshal JWKS: %v", err)
+	}
+	return data
+}
+
+func TestJWTVerifier_Verify(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate RSA key: %v", err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate EC key: %v", err)
+	}
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate RSA key: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	if err := os.WriteFile(path, jwks(t, rsaJWK("rsa-1", &rsaKey.PublicKey), ecJWK("ec-1", &ecKey.PublicKey)), 0o600); err != nil {
+		t.Fatalf("failed to write JWKS: %v", err)
+	}
+	keys, err := LoadJWKSFile(path)
+	if err != nil {
+		t.Fatalf("failed to load JWKS: %v", err)
+	}
+
+	clock := &fakeClock{now: time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)}
+	verifier := NewJWTVerifier(JWTConfig{Issuer: testIssuer, Audience: "planning-poker"}, keys, clock)
+
+	claims := func(overrides map[string]any) map[string]any {
+		c := map[string]any{
+			"iss":  testIssuer,
+			"aud":  []string{"planning-poker", "other"},
+			"sub":  "user-42",
+			"name": "Alice",
+			"iat":  clock.now.Unix(),
+			"exp":  clock.now.Add(time.Hour).Unix(),
+		}
+		for k, v := range overrides {
+			if v == nil {
+				delete(c, k)
+				continue
+			}
+			c[k] = v
+		}
+		return c
+	}
+
+	t.Run("accepts a valid RS256 token", func(t *testing.T) {
+		identity, err := verifier.Verify(context.Background(), signRS256(t, rsaKey, "rsa-1", claims(nil)))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if identity.Issuer != testIssuer || identity.Subject != "user-42" || identity.Name != "Alice" {
+			t.Errorf("unexpected identity %+v", identity)
+		}
+	})
+
+	t.Run("accepts a valid ES256 token", func(t *testing.T) {
+		identity, err := verifier.Verify(context.Background(), signES256(t, ecKey, "ec-1", claims(map[string]any{"aud": "planning-poker"})))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if identity.Subject != "user-42" {
+			t.Errorf("unexpected identity %+v", identity)
+		}
+	})
+
+	t.Run("falls back to preferred_username", func(t *testing.T) {
+		identity, err := verifier.Verify(context.Background(), signRS256(t, rsaKey, "rsa-1", claims(map[string]any{"name": nil, "preferred_username": "alice"})))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if identity.Name != "alice" {
+			t.Errorf("expected name alice, got %q", identity.Name)
+		}
+	})
+
+	t.Run("tolerates small clock skew", func(t *testing.T) {
+		token := signRS256(t, rsaKey, "rsa-1", claims(map[string]any{
+			"iat": clock.now.Add(30 * time.Second).Unix(),
+			"exp": clock.now.Add(-30 * time.Second).Unix(),
+		}))
+		if _, err := verifier.Verify(context.Background(), token); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	invalid := map[string]string{
+		"wrong issuer":      signRS256(t, rsaKey, "rsa-1", claims(map[string]any{"iss": "https://evil.example.com"})),
+		"wrong audience":    signRS256(t, rsaKey, "rsa-1", claims(map[string]any{"aud": "other"})),
+		"expired":           signRS256(t, rsaKey, "rsa-1", claims(map[string]any{"exp": clock.now.Add(-2 * time.Minute).Unix()})),
+		"no expiration":     signRS256(t, rsaKey, "rsa-1", claims(map[string]any{"exp": nil})),
+		"not valid yet":     signRS256(t, rsaKey, "rsa-1", claims(map[string]any{"nbf": clock.now.Add(5 * time.Minute).Unix()})),
+		"no subject":        signRS256(t, rsaKey, "rsa-1", claims(map[string]any{"sub": nil})),
+		"unknown kid":       signRS256(t, rsaKey, "rsa-2", claims(nil)),
+		"foreign key":       signRS256(t, otherKey, "rsa-1", claims(nil)),
+		"key type mismatch": signRS256(t, rsaKey, "ec-1", claims(nil)),
+		"unsigned": encodeSegment(t, map[string]string{"alg": "none", "kid": "rsa-1"}) + "." +
+			encodeSegment(t, claims(nil)) + ".",
+		"malformed": "not-a-token",
+	}
+	for name, token := range invalid {
+		t.Run("rejects "+name, func(t *testing.T) {
+			_, err := verifier.Verify(context.Background(), token)
+			if !errors.Is(err, auth.ErrInvalidToken) {
+				t.Errorf("expected ErrInvalidToken, got %v", err)
+			}
+		})
+	}
+}
+
+func TestRemoteKeySet_RefetchesOnUnknownKid(t *testing.T) {
+	first, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate RSA key: %v", err)
+	}
+	second, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate RSA key: %v", err)
+	}
+
+	var fetches atomic.Int32
+	var body atomic.Value
+	body.Store(jwks(t, rsaJWK("key-1", &first.PublicKey)))
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		fetches.Add(1)
+		_, _ = w.Write(body.Load().([]byte))
+	}))
+	defer server.Close()
+
+	clock := &fakeClock{now: time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)}
+	keys := NewRemoteKeySet(server.URL, server.Client(), clock, time.Hour)
+	ctx := context.Background()
+
+	if _, err := keys.Key(ctx, "key-1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := keys.Key(ctx, "key-1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := fetches.Load(); got != 1 {
+		t.Fatalf("expected the keys to be cached, got %d fetches", got)
+	}
+
+	// the provider rolls its keys
+	body.Store(jwks(t, rsaJWK("key-1", &first.PublicKey), rsaJWK("key-2", &second.PublicKey)))
+
+	if _, err := keys.Key(ctx, "key-2"); err == nil {
+		t.Fatal("expected unknown kids not to trigger a fetch right after the last one")
+	}
+
+	clock.now = clock.now.Add(minRefreshInterval)
+	if _, err := keys.Key(ctx, "key-2"); err != nil {
+		t.Fatalf("expected the new key to be fetched, got %v", err)
+	}
+	if got := fetches.Load(); got != 2 {
+		t.Errorf("expected 2 fetches, got %d", got)
+	}
+}
+
+func TestVerifySignature_RejectsKeysNotFitForTheAlgorithm(t *testing.T) {
+	signed := "header.payload"
+	digest := crypto.SHA256.New()
+	digest.Write([]byte(signed))
+
+	weakKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatalf("failed to generate RSA key: %v", err)
+	}
+	rsaSignature, err := rsa.SignPKCS1v15(rand.Reader, weakKey, crypto.SHA256, digest.Sum(nil))
+	if err != nil {
+		t.Fatalf("failed to sign: %v", err)
+	}
+	if err := verifySignature("RS256", &weakKey.PublicKey, signed, rsaSignature); err == nil {
+		t.Error("expected a 1024 bits RSA key to be rejected")
+	}
+
+	// a valid signature, but ES256 is bound to P-256
+	p384Key, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate EC key: %v", err)
+	}
+	r, s, err := ecdsa.Sign(rand.Reader, p384Key, digest.Sum(nil))
+	if err != nil {
+		t.Fatalf("failed to sign: %v", err)
+	}
+	ecSignature := make([]byte, 96)
+	r.FillBytes(ecSignature[:48])
+	s.FillBytes(ecSignature[48:])
+	if err := verifySignature("ES256", &p384Key.PublicKey, signed, ecSignature); err == nil {
+		t.Error("expected a P-384 key to be rejected for ES256")
+	}
+}
+
+func TestRemoteKeySet_WaitsBeforeRetryingAFailedFetch(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate RSA key: %v", err)
+	}
+
+	var fetches atomic.Int32
+	var available atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		fetches.Add(1)
+		if !available.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write(jwks(t, rsaJWK("key-1", &key.PublicKey)))
+	}))
+	defer server.Close()
+
+	clock := &fakeClock{now: time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)}
+	keys := NewRemoteKeySet(server.URL, server.Client(), clock, time.Hour)
+	ctx := context.Background()
+
+	for range 3 {
+		if _, err := keys.Key(ctx, "key-1"); err == nil {
+			t.Fatal("expected an error while the provider is unavailable")
+		}
+	}
+	if got := fetches.Load(); got != 1 {
+		t.Fatalf("expected a single fetch right after a failed one, got %d", got)
+	}
+
+	available.Store(true)
+	clock.now = clock.now.Add(minRefreshInterval)
+	if _, err := keys.Key(ctx, "key-1"); err != nil {
+		t.Fatalf("expected the keys to be fetched again, got %v", err)
+	}
+	if got := fetches.Load(); got != 2 {
+		t.Errorf("expected 2 fetches, got %d", got)
+	}
+}
+
+func TestRemoteKeySet_SharesASingleFetch(t *testing.T) {
+	first, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate RSA key: %v", err)
+	}
+	second, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate RSA key: %v", err)
+	}
+
+	var fetches atomic.Int32
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if fetches.Add(1) > 1 {
+			<-release
+		}
+		_, _ = w.Write(jwks(t, rsaJWK("key-1", &first.PublicKey), rsaJWK("key-2", &second.PublicKey)))
+	}))
+	defer server.Close()
+
+	clock := &fakeClock{now: time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)}
+	keys := NewRemoteKeySet(server.URL, server.Client(), clock, time.Hour)
+	ctx := context.Background()
+
+	if _, err := keys.Key(ctx, "key-1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// unknown kids wait for the same fetch, which the provider holds up
+	clock.now = clock.now.Add(minRefreshInterval)
+	var wg sync.WaitGroup
+	for range 5 {
+		wg.Go(func() {
+			if _, err := keys.Key(ctx, "key-3"); !errors.Is(err, errUnknownKey) {
+				t.Errorf("expected errUnknownKey, got %v", err)
+			}
+		})
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for fetches.Load() < 2 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+
+	// known keys are served meanwhile
+	lookup, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+	if _, err := keys.Key(lookup, "key-1"); err != nil {
+		t.Errorf("expected a known key to be served during the fetch, got %v", err)
+	}
+
+	close(release)
+	wg.Wait()
+	if got := fetches.Load(); got != 2 {
+		t.Errorf("expected 2 fetches, got %d", got)
+	}
+}
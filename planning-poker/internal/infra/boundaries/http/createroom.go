@@ -1,18 +1,31 @@
 package http
 
 import (
+	"encoding/json"
+	"errors"
+	"io"
 	"net/http"
 	"planning-poker/internal/application/planningpoker/usecase"
+	"planning-poker/internal/domain"
 
 	"github.com/bruno303/go-toolkit/pkg/log"
 )
 
 type (
+	CreateRoomRequest struct {
+		Deck     string   `json:"deck,omitempty"`
+		Cards    []string `json:"cards,omitempty"`
+		Passcode string   `json:"passcode,omitempty"`
+		// Bot adds a bot to the room, whose token drives it through the
+		// room command API
+		Bot *AddBotRequest `json:"bot,omitempty"`
+	}
 	CreateRoomResponse struct {
-		RoomID string `json:"roomId"`
+		RoomID string          `json:"roomId"`
+		Bot    *AddBotResponse `json:"bot,omitempty"`
 	}
 	CreateRoomAPI struct {
-		createRoom usecase.UseCaseO[usecase.CreateRoomOutput]
+		createRoom usecase.UseCaseR[usecase.CreateRoomCommand, usecase.CreateRoomOutput]
 		logger     log.Logger
 	}
 )
@@ -20,14 +33,17 @@ type (
 var _ API = (*CreateRoomAPI)(nil)
 
 // @Summary Create a new room
-// @Description Creates a new planning poker room and returns its ID
+// @Description Creates a new planning poker room and returns its ID. The body is optional and selects the vote deck: one of fibonacci (default), tshirt, powers-of-two or custom (with cards). A passcode can be set so that only clients presenting it, or an invite, can join. With bot, a bot is added to the room and its token, returned only here, drives the room through the room command API.
 // @Tags rooms
 // @Accept json
 // @Produce json
-// @Success 200 {object} CreateRoomResponse
+// @Param request body CreateRoomRequest false "Room configuration"
+// @Success 201 {object} CreateRoomResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 429 {string} string "Too many requests"
 // @Failure 500 {object} ErrorResponse
 // @Router /planning/rooms [post]
-func NewCreateRoomAPI(createRoom usecase.UseCaseO[usecase.CreateRoomOutput]) CreateRoomAPI {
+func NewCreateRoomAPI(createRoom usecase.UseCaseR[usecase.CreateRoomCommand, usecase.CreateRoomOutput]) CreateRoomAPI {
 	return CreateRoomAPI{
 		createRoom: createRoom,
 		logger:     log.NewLogger("createroomapi"),
@@ -44,13 +60,37 @@ func (c CreateRoomAPI) Methods() []string {
 
 func (c CreateRoomAPI) Handle() http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		output, err := c.createRoom.Execute(r.Context())
+		var request CreateRoomRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil && !errors.Is(err, io.EOF) {
+			SendJsonErrorMsg(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+
+		cmd := usecase.CreateRoomCommand{
+			DeckName:  request.Deck,
+			DeckCards: request.Cards,
+			Passcode:  request.Passcode,
+		}
+		if request.Bot != nil {
+			cmd.WithBot = true
+			cmd.BotName = request.Bot.Name
+		}
+
+		output, err := c.createRoom.Execute(r.Context(), cmd)
+		if errors.Is(err, domain.ErrInvalidDeck) || errors.Is(err, domain.ErrInvalidPasscode) || errors.Is(err, domain.ErrInvalidBotName) {
+			SendJsonError(w, http.StatusBadRequest, err)
+			return
+		}
 		if err != nil {
 			c.logger.Error(r.Context(), "Failed to create room", err)
 			SendJsonErrorMsg(w, http.StatusInternalServerError, "Failed to create room")
 			return
 		}
 
-		SendJsonResponse(w, http.StatusCreated, CreateRoomResponse{RoomID: output.RoomID})
+		response := CreateRoomResponse{RoomID: output.RoomID}
+		if output.Bot != nil {
+			response.Bot = &AddBotResponse{ClientID: output.Bot.ClientID, Token: output.Bot.Token}
+		}
+		SendJsonResponse(w, http.StatusCreated, response)
 	})
 }
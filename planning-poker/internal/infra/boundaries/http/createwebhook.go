@@ -0,0 +1,97 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"planning-poker/internal/application/timer"
+	"planning-poker/internal/application/webhook"
+	"planning-poker/internal/domain"
+	"planning-poker/internal/infra/boundaries/http/middleware"
+
+	"github.com/bruno303/go-toolkit/pkg/log"
+)
+
+type (
+	CreateWebhookAPI struct {
+		store               webhook.Store
+		clock               timer.Clock
+		adminAuthMiddleware middleware.AdminMiddleware
+		logger              log.Logger
+	}
+
+	AdminWebhookRequest struct {
+		URL string `json:"url"`
+		// Events filters the events sent, every event when empty
+		Events []string `json:"events,omitempty"`
+		// RoomID limits the subscription to a room until its session ends,
+		// every room when empty
+		RoomID string `json:"room_id,omitempty"`
+	}
+)
+
+var _ API = (*CreateWebhookAPI)(nil)
+
+// @Summary Subscribe a webhook
+// @Description Subscribes a URL to the events of every room, or of one room until its session ends (admin only). The secret signing the deliveries is only returned here.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param payload body AdminWebhookRequest true "URL, events and room of the subscription"
+// @Success 201 {object} AdminWebhookResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {string} string "Key lacks the scope of the endpoint"
+// @Failure 429 {string} string "Too many requests"
+// @Failure 500 {object} ErrorResponse
+// @Security ApiKeyAuth
+// @Router /admin/webhooks [post]
+func NewCreateWebhookAPI(store webhook.Store, clock timer.Clock, adminAuthMiddleware middleware.AdminMiddleware) CreateWebhookAPI {
+	return CreateWebhookAPI{
+		store:               store,
+		clock:               clock,
+		adminAuthMiddleware: adminAuthMiddleware.WithScope(middleware.ScopeWebhooks),
+		logger:              log.NewLogger("createwebhookapi"),
+	}
+}
+
+func (api CreateWebhookAPI) Endpoint() string {
+	return "/admin/webhooks"
+}
+
+func (api CreateWebhookAPI) Methods() []string {
+	return []string{"POST"}
+}
+
+func (api CreateWebhookAPI) Handle() http.Handler {
+	return api.adminAuthMiddleware.Handle(api.execute())
+}
+
+func (api CreateWebhookAPI) execute() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		var req AdminWebhookRequest
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRoomCommandBodySize)).Decode(&req); err != nil {
+			SendJsonErrorMsg(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+
+		subscription, err := webhook.NewSubscription(req.RoomID, req.URL, req.Events, api.clock)
+		if errors.Is(err, domain.ErrInvalidWebhook) {
+			SendJsonError(w, http.StatusBadRequest, err)
+			return
+		}
+		if err == nil {
+			err = api.store.AddSubscription(ctx, subscription)
+		}
+		if err != nil {
+			api.logger.Error(ctx, "Failed to create webhook subscription", err)
+			SendJsonErrorMsg(w, http.StatusInternalServerError, "Failed to create webhook subscription")
+			return
+		}
+
+		api.logger.Info(ctx, "Webhook %s to %s subscribed by admin key %s", subscription.ID, subscription.URL, middleware.AdminKeyName(ctx))
+		SendJsonResponse(w, http.StatusCreated, newAdminWebhookResponse(subscription))
+	})
+}
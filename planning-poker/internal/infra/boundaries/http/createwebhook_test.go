@@ -0,0 +1,55 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"planning-poker/internal/application/timer"
+	"planning-poker/internal/application/webhook"
+	"planning-poker/internal/infra/boundaries/http/middleware"
+	"strings"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+)
+
+func TestCreateWebhookAPI_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := webhook.NewMockStore(ctrl)
+	mockStore.EXPECT().AddSubscription(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, subscription webhook.Subscription) error {
+		if subscription.RoomID != "" || subscription.URL != "https://hooks.example.com/poker" {
+			t.Errorf("unexpected subscription %+v", subscription)
+		}
+		return nil
+	})
+
+	api := NewCreateWebhookAPI(mockStore, timer.SystemClock{}, middleware.NewAdminMiddleware("valid-api-key"))
+	rec := serveAdminAPI(api, http.MethodPost, "/admin/webhooks", `{"url":"https://hooks.example.com/poker"}`)
+
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("status code = %v, want %v", rec.Code, http.StatusCreated)
+	}
+	var response AdminWebhookResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	if response.ID == "" || !strings.HasPrefix(response.Secret, "whsec_") {
+		t.Errorf("unexpected response %+v", response)
+	}
+}
+
+func TestCreateWebhookAPI_Handle_InvalidWebhook(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	api := NewCreateWebhookAPI(webhook.NewMockStore(ctrl), timer.SystemClock{}, middleware.NewAdminMiddleware("valid-api-key"))
+	for _, body := range []string{`{"url":"ftp://example.com"}`, `{"url":"https://example.com","events":["story.voted"]}`, `{`} {
+		rec := serveAdminAPI(api, http.MethodPost, "/admin/webhooks", body)
+
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("status code for %s = %v, want %v", body, rec.Code, http.StatusBadRequest)
+		}
+	}
+}
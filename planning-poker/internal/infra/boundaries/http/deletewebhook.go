@@ -0,0 +1,76 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"planning-poker/internal/application/webhook"
+	"planning-poker/internal/domain"
+	"planning-poker/internal/infra/boundaries/http/middleware"
+
+	"github.com/bruno303/go-toolkit/pkg/log"
+	"github.com/gorilla/mux"
+)
+
+type (
+	DeleteWebhookAPI struct {
+		store               webhook.Store
+		adminAuthMiddleware middleware.AdminMiddleware
+		logger              log.Logger
+	}
+)
+
+var _ API = (*DeleteWebhookAPI)(nil)
+
+// @Summary Unsubscribe a webhook
+// @Description Removes a webhook subscription of any room or of the whole instance (admin only)
+// @Tags admin
+// @Produce json
+// @Param subscriptionID path string true "Subscription ID"
+// @Success 204 "Done"
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {string} string "Key lacks the scope of the endpoint"
+// @Failure 404 {object} ErrorResponse
+// @Failure 429 {string} string "Too many requests"
+// @Failure 500 {object} ErrorResponse
+// @Security ApiKeyAuth
+// @Router /admin/webhooks/{subscriptionID} [delete]
+func NewDeleteWebhookAPI(store webhook.Store, adminAuthMiddleware middleware.AdminMiddleware) DeleteWebhookAPI {
+	return DeleteWebhookAPI{
+		store:               store,
+		adminAuthMiddleware: adminAuthMiddleware.WithScope(middleware.ScopeWebhooks),
+		logger:              log.NewLogger("deletewebhookapi"),
+	}
+}
+
+func (api DeleteWebhookAPI) Endpoint() string {
+	return "/admin/webhooks/{subscriptionID}"
+}
+
+func (api DeleteWebhookAPI) Methods() []string {
+	return []string{"DELETE"}
+}
+
+func (api DeleteWebhookAPI) Handle() http.Handler {
+	return api.adminAuthMiddleware.Handle(api.execute())
+}
+
+func (api DeleteWebhookAPI) execute() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		subscriptionID := mux.Vars(r)["subscriptionID"]
+
+		err := api.store.RemoveSubscription(ctx, subscriptionID)
+		if errors.Is(err, domain.ErrWebhookNotFound) {
+			SendJsonErrorMsg(w, http.StatusNotFound, "Webhook not found")
+			return
+		}
+		if err != nil {
+			api.logger.Error(ctx, "Failed to remove webhook subscription", err)
+			SendJsonErrorMsg(w, http.StatusInternalServerError, "Failed to remove webhook subscription")
+			return
+		}
+
+		api.logger.Info(ctx, "Webhook %s unsubscribed by admin key %s", subscriptionID, middleware.AdminKeyName(ctx))
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
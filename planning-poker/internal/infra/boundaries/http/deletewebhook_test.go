@@ -0,0 +1,42 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"planning-poker/internal/application/webhook"
+	"planning-poker/internal/domain"
+	"planning-poker/internal/infra/boundaries/http/middleware"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+)
+
+func TestDeleteWebhookAPI_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := webhook.NewMockStore(ctrl)
+	mockStore.EXPECT().RemoveSubscription(gomock.Any(), "webhook-1").Return(nil)
+	mockStore.EXPECT().RemoveSubscription(gomock.Any(), "missing").Return(domain.ErrWebhookNotFound)
+	api := NewDeleteWebhookAPI(mockStore, middleware.NewAdminMiddleware("valid-api-key"))
+
+	if rec := serveAdminAPI(api, http.MethodDelete, "/admin/webhooks/webhook-1", ""); rec.Code != http.StatusNoContent {
+		t.Errorf("status code = %v, want %v", rec.Code, http.StatusNoContent)
+	}
+	if rec := serveAdminAPI(api, http.MethodDelete, "/admin/webhooks/missing", ""); rec.Code != http.StatusNotFound {
+		t.Errorf("status code = %v, want %v", rec.Code, http.StatusNotFound)
+	}
+}
+
+func TestDeleteWebhookAPI_Handle_StoreError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := webhook.NewMockStore(ctrl)
+	mockStore.EXPECT().RemoveSubscription(gomock.Any(), "webhook-1").Return(errors.New("connection refused"))
+	api := NewDeleteWebhookAPI(mockStore, middleware.NewAdminMiddleware("valid-api-key"))
+
+	if rec := serveAdminAPI(api, http.MethodDelete, "/admin/webhooks/webhook-1", ""); rec.Code != http.StatusInternalServerError {
+		t.Errorf("status code = %v, want %v", rec.Code, http.StatusInternalServerError)
+	}
+}
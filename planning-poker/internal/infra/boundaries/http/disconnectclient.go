@@ -29,6 +29,8 @@ var _ API = (*DisconnectClientAPI)(nil)
 // @Param clientID path string true "Client ID"
 // @Success 200 {object} map[string]string
 // @Failure 401 {object} ErrorResponse
+// @Failure 403 {string} string "Key lacks the scope of the endpoint"
+// @Failure 429 {string} string "Too many requests"
 // @Failure 404 {object} ErrorResponse
 // @Security ApiKeyAuth
 // @Router /admin/rooms/{roomID}/client/{clientID} [delete]
@@ -38,7 +40,7 @@ func NewDisconnectClientAPI(
 ) DisconnectClientAPI {
 	return DisconnectClientAPI{
 		adminRemoveClient:   adminRemoveClient,
-		adminAuthMiddleware: adminAuthMiddleware,
+		adminAuthMiddleware: adminAuthMiddleware.WithScope(middleware.ScopeClientsKick),
 		logger:              log.NewLogger("disconnectclientapi"),
 	}
 }
@@ -73,6 +75,7 @@ func (api DisconnectClientAPI) execute() http.Handler {
 		err := api.adminRemoveClient.Execute(ctx, usecase.AdminRemoveClientCommand{
 			RoomID:   roomID,
 			ClientID: clientID,
+			Actor:    middleware.AdminKeyName(ctx),
 		})
 		if errors.Is(err, domain.ErrRoomNotFound) {
 			SendJsonErrorMsg(w, http.StatusNotFound, "Room not found")
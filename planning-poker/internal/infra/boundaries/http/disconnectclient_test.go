@@ -59,7 +59,7 @@ func TestDisconnectClientAPI_Handle_Success(t *testing.T) {
 	api := NewDisconnectClientAPI(mockUseCase, middleware.NewAdminMiddleware(apiKey))
 
 	mockUseCase.EXPECT().
-		Execute(gomock.Any(), usecase.AdminRemoveClientCommand{RoomID: roomID, ClientID: clientID}).
+		Execute(gomock.Any(), usecase.AdminRemoveClientCommand{RoomID: roomID, ClientID: clientID, Actor: middleware.DefaultAdminKeyName}).
 		Return(nil)
 
 	handler := api.Handle()
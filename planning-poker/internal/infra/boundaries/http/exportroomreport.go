@@ -0,0 +1,102 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"planning-poker/internal/application/planningpoker/report"
+	"planning-poker/internal/domain"
+	"planning-poker/internal/infra/boundaries/http/middleware"
+
+	"github.com/bruno303/go-toolkit/pkg/log"
+	"github.com/gorilla/mux"
+)
+
+type ExportRoomReportAPI struct {
+	hub                 domain.Hub
+	adminAuthMiddleware middleware.AdminMiddleware
+	logger              log.Logger
+}
+
+var _ API = (*ExportRoomReportAPI)(nil)
+
+// @Summary Export room session report
+// @Description Exports the backlog of a room with each story result, most voted cards and participant votes (admin only). The format is taken from the format query parameter or, when absent, from the Accept header.
+// @Tags admin
+// @Produce json
+// @Produce text/csv
+// @Produce text/markdown
+// @Param roomID path string true "Room ID"
+// @Param format query string false "Report format" Enums(json, csv, markdown)
+// @Success 200 {string} string "Report in the requested format"
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {string} string "Key lacks the scope of the endpoint"
+// @Failure 429 {string} string "Too many requests"
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Security ApiKeyAuth
+// @Router /admin/rooms/{roomID}/report [get]
+func NewExportRoomReportAPI(hub domain.Hub, adminAuthMiddleware middleware.AdminMiddleware) ExportRoomReportAPI {
+	return ExportRoomReportAPI{
+		hub:                 hub,
+		adminAuthMiddleware: adminAuthMiddleware.WithScope(middleware.ScopeRoomsRead),
+		logger:              log.NewLogger("exportroomreportapi"),
+	}
+}
+
+func (api ExportRoomReportAPI) Endpoint() string {
+	return "/admin/rooms/{roomID}/report"
+}
+
+func (api ExportRoomReportAPI) Methods() []string {
+	return []string{"GET"}
+}
+
+func (api ExportRoomReportAPI) Handle() http.Handler {
+	return api.adminAuthMiddleware.Handle(api.execute())
+}
+
+func (api ExportRoomReportAPI) execute() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		roomID := mux.Vars(r)["roomID"]
+		if roomID == "" {
+			SendJsonErrorMsg(w, http.StatusBadRequest, "Room ID is required")
+			return
+		}
+
+		format := report.FormatFromAccept(r.Header.Get("Accept"))
+		if r.URL.Query().Has("format") {
+			var err error
+			format, err = report.ParseFormat(r.URL.Query().Get("format"))
+			if err != nil {
+				SendJsonError(w, http.StatusBadRequest, err)
+				return
+			}
+		}
+
+		room, err := api.hub.LoadRoom(ctx, roomID)
+		if errors.Is(err, domain.ErrRoomNotFound) {
+			SendJsonErrorMsg(w, http.StatusNotFound, "Room not found")
+			return
+		}
+		if err != nil {
+			api.logger.Error(ctx, "Failed to load room", err)
+			SendJsonErrorMsg(w, http.StatusInternalServerError, "Failed to load room")
+			return
+		}
+
+		content, err := report.Render(report.New(room), format)
+		if err != nil {
+			api.logger.Error(ctx, "Failed to render report", err)
+			SendJsonErrorMsg(w, http.StatusInternalServerError, "Failed to render report")
+			return
+		}
+
+		w.Header().Set("Content-Type", format.ContentType())
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", report.Filename(room.ID, format)))
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(content)
+	})
+}
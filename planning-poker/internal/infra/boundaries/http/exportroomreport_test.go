@@ -0,0 +1,140 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"planning-poker/internal/domain"
+	"planning-poker/internal/domain/entity"
+	"planning-poker/internal/infra/boundaries/http/middleware"
+	"planning-poker/internal/infra/boundaries/hub/clientcollection"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/samber/lo"
+	"go.uber.org/mock/gomock"
+)
+
+func TestExportRoomReportAPI_Endpoint(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	api := NewExportRoomReportAPI(domain.NewMockHub(ctrl), middleware.NewAdminMiddleware("test-api-key"))
+
+	if api.Endpoint() != "/admin/rooms/{roomID}/report" {
+		t.Errorf("Endpoint() = %v, want %v", api.Endpoint(), "/admin/rooms/{roomID}/report")
+	}
+}
+
+func TestExportRoomReportAPI_Handle(t *testing.T) {
+	tests := []struct {
+		name            string
+		query           string
+		accept          string
+		wantContentType string
+		wantBody        string
+	}{
+		{
+			name:            "defaults to json",
+			wantContentType: "application/json",
+			wantBody:        `"name": "Story 1"`,
+		},
+		{
+			name:            "format from accept header",
+			accept:          "text/csv",
+			wantContentType: "text/csv",
+			wantBody:        "Story 1,true,5,5,5",
+		},
+		{
+			name:            "format query parameter wins over accept header",
+			query:           "?format=markdown",
+			accept:          "text/csv",
+			wantContentType: "text/markdown",
+			wantBody:        "| Story 1 | 5 | 5 | Alice: 5 |",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockHub := domain.NewMockHub(ctrl)
+			api := NewExportRoomReportAPI(mockHub, middleware.NewAdminMiddleware("valid-api-key"))
+
+			room := entity.NewRoomWithID("room1", clientcollection.New())
+			room.Stories = []entity.Story{{
+				Name:               "Story 1",
+				Result:             lo.ToPtr(float32(5)),
+				MostAppearingVotes: []int{5},
+				Voted:              true,
+				Votes:              []entity.StoryVote{{ClientID: "client1", Name: "Alice", Vote: "5"}},
+			}}
+			mockHub.EXPECT().LoadRoom(gomock.Any(), "room1").Return(room, nil)
+
+			router := mux.NewRouter()
+			router.Handle(api.Endpoint(), api.Handle()).Methods("GET")
+
+			req := httptest.NewRequest(http.MethodGet, "/admin/rooms/room1/report"+tt.query, nil)
+			req.Header.Set("Authorization", "Bearer valid-api-key")
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+			rec := httptest.NewRecorder()
+
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != http.StatusOK {
+				t.Fatalf("status code = %v, want %v", rec.Code, http.StatusOK)
+			}
+			if got := rec.Header().Get("Content-Type"); got != tt.wantContentType {
+				t.Errorf("Content-Type = %v, want %v", got, tt.wantContentType)
+			}
+			if !strings.Contains(rec.Body.String(), tt.wantBody) {
+				t.Errorf("body = %v, want it to contain %v", rec.Body.String(), tt.wantBody)
+			}
+		})
+	}
+}
+
+func TestExportRoomReportAPI_Handle_UnsupportedFormat(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	api := NewExportRoomReportAPI(domain.NewMockHub(ctrl), middleware.NewAdminMiddleware("valid-api-key"))
+
+	router := mux.NewRouter()
+	router.Handle(api.Endpoint(), api.Handle()).Methods("GET")
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/rooms/room1/report?format=xml", nil)
+	req.Header.Set("Authorization", "Bearer valid-api-key")
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("status code = %v, want %v", rec.Code, http.StatusBadRequest)
+	}
+}
+
+func TestExportRoomReportAPI_Handle_RoomNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockHub := domain.NewMockHub(ctrl)
+	api := NewExportRoomReportAPI(mockHub, middleware.NewAdminMiddleware("valid-api-key"))
+	mockHub.EXPECT().LoadRoom(gomock.Any(), "nonexistent").Return(nil, domain.ErrRoomNotFound)
+
+	router := mux.NewRouter()
+	router.Handle(api.Endpoint(), api.Handle()).Methods("GET")
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/rooms/nonexistent/report", nil)
+	req.Header.Set("Authorization", "Bearer valid-api-key")
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("status code = %v, want %v", rec.Code, http.StatusNotFound)
+	}
+}
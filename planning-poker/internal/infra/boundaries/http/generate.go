@@ -1,4 +1,5 @@
 package http
 
 //go:generate go tool mockgen -destination mocks.go -typed -package http . API
-//go:generate go tool swag init -g ../../../../cmd/api/main.go -o swagger --outputTypes json,yaml --parseInternal
+//go:generate go tool swag init -d ./,../../bus,../../../application/planningpoker/usecase/dto -g ../../../../cmd/api/main.go -o swagger --outputTypes json,yaml --parseInternal
+//go:generate go run ../../../../cmd/asyncapi -o swagger/asyncapi.json
@@ -1,18 +1,33 @@
 package http
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
+	"net/url"
 	"planning-poker/internal/domain"
 	"planning-poker/internal/domain/entity"
 	"planning-poker/internal/infra/boundaries/http/middleware"
+	"strconv"
+	"time"
 
 	"github.com/bruno303/go-toolkit/pkg/log"
 )
 
+const (
+	// NextCursorHeader carries the cursor of the next page of rooms, it is
+	// absent on the last page
+	NextCursorHeader = "X-Next-Cursor"
+	maxRoomPageSize  = 200
+)
+
 type (
 	GetRoomStateResponse struct {
-		ID      string                   `json:"id"`
-		Clients []GetAllRoomsStateClient `json:"clients"`
+		ID           string                   `json:"id"`
+		Clients      []GetAllRoomsStateClient `json:"clients"`
+		Participants int                      `json:"participants"`
+		BacklogMode  bool                     `json:"backlog_mode"`
+		UpdatedAt    *time.Time               `json:"updated_at,omitempty"`
 	}
 	GetAllRoomsStateClient struct {
 		ID          string `json:"id"`
@@ -30,17 +45,31 @@ type (
 var _ API = (*GetAllRoomsStateAPI)(nil)
 
 // @Summary Get all rooms state
-// @Description Returns the state of all rooms (admin only)
+// @Description Returns a page of the rooms state (admin only). The cursor of the next page is returned in the X-Next-Cursor header, which is absent on the last page. A full page may be followed by an empty last page.
 // @Tags admin
 // @Produce json
+// @Param cursor query string false "Cursor of the page, from the X-Next-Cursor header of the previous one"
+// @Param limit query int false "Rooms per page, 50 by default" minimum(1) maximum(200)
+// @Param min_participants query int false "Minimum number of participants"
+// @Param max_participants query int false "Maximum number of participants"
+// @Param min_idle query string false "Minimum time since the last change of the room, as a duration like 30m"
+// @Param backlog_mode query bool false "Whether the room is in backlog mode"
+// @Param owner query string false "Part of the name of an owner of the room, ignoring case"
+// @Param sort query string false "Field to sort by" Enums(id, participants, idle, backlog_mode, owner)
+// @Param order query string false "Sort order" Enums(asc, desc)
 // @Success 200 {array} GetRoomStateResponse
+// @Header 200 {string} X-Next-Cursor "Cursor of the next page"
+// @Failure 400 {object} ErrorResponse
 // @Failure 401 {object} ErrorResponse
+// @Failure 403 {string} string "Key lacks the scope of the endpoint"
+// @Failure 429 {string} string "Too many requests"
+// @Failure 500 {object} ErrorResponse
 // @Security ApiKeyAuth
 // @Router /admin/rooms [get]
 func NewGetAllRoomsStateAPI(hub domain.AdminHub, adminAuthMiddleware middleware.AdminMiddleware) GetAllRoomsStateAPI {
 	return GetAllRoomsStateAPI{
 		hub:                 hub,
-		adminAuthMiddleware: adminAuthMiddleware,
+		adminAuthMiddleware: adminAuthMiddleware.WithScope(middleware.ScopeRoomsRead),
 		logger:              log.NewLogger("getallroomsstateapi"),
 	}
 }
@@ -59,19 +88,100 @@ func (api GetAllRoomsStateAPI) Handle() http.Handler {
 
 func (api GetAllRoomsStateAPI) execute() http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
 
-		rooms := api.hub.GetRooms()
+		query, err := parseRoomQuery(r.URL.Query())
+		if err != nil {
+			SendJsonError(w, http.StatusBadRequest, err)
+			return
+		}
 
-		SendJsonResponse(w, http.StatusOK, mapRooms(rooms))
+		page, err := api.hub.ListRooms(ctx, query)
+		if errors.Is(err, domain.ErrInvalidCursor) {
+			SendJsonError(w, http.StatusBadRequest, err)
+			return
+		}
+		if err != nil {
+			api.logger.Error(ctx, "Failed to list rooms", err)
+			SendJsonErrorMsg(w, http.StatusInternalServerError, "Failed to list rooms")
+			return
+		}
+
+		if page.NextCursor != "" {
+			w.Header().Set(NextCursorHeader, page.NextCursor)
+		}
+		SendJsonResponse(w, http.StatusOK, mapRooms(page.Rooms))
 	})
 }
 
+func parseRoomQuery(values url.Values) (domain.RoomQuery, error) {
+	query := domain.RoomQuery{
+		Cursor:    values.Get("cursor"),
+		OwnerName: values.Get("owner"),
+		SortBy:    domain.RoomSort(values.Get("sort")),
+	}
+
+	var err error
+	if query.Limit, err = intParam(values, "limit"); err != nil {
+		return query, err
+	}
+	if values.Has("limit") && (query.Limit < 1 || query.Limit > maxRoomPageSize) {
+		return query, fmt.Errorf("limit must be between 1 and %d", maxRoomPageSize)
+	}
+	if query.MinParticipants, err = intParam(values, "min_participants"); err != nil {
+		return query, err
+	}
+	if query.MaxParticipants, err = intParam(values, "max_participants"); err != nil {
+		return query, err
+	}
+	if values.Has("min_idle") {
+		if query.MinIdle, err = time.ParseDuration(values.Get("min_idle")); err != nil || query.MinIdle < 0 {
+			return query, fmt.Errorf("min_idle must be a duration like 30m")
+		}
+	}
+	if values.Has("backlog_mode") {
+		backlogMode, err := strconv.ParseBool(values.Get("backlog_mode"))
+		if err != nil {
+			return query, fmt.Errorf("backlog_mode must be true or false")
+		}
+		query.BacklogMode = &backlogMode
+	}
+	if !query.SortBy.Valid() {
+		return query, fmt.Errorf("unknown sort %q", query.SortBy)
+	}
+	switch values.Get("order") {
+	case "", "asc":
+	case "desc":
+		query.Descending = true
+	default:
+		return query, fmt.Errorf("order must be asc or desc")
+	}
+
+	return query, nil
+}
+
+func intParam(values url.Values, name string) (int, error) {
+	if !values.Has(name) {
+		return 0, nil
+	}
+	value, err := strconv.Atoi(values.Get(name))
+	if err != nil || value < 0 {
+		return 0, fmt.Errorf("%s must be a non-negative integer", name)
+	}
+	return value, nil
+}
+
 func mapRooms(rooms []*entity.Room) []GetRoomStateResponse {
 	res := make([]GetRoomStateResponse, len(rooms))
 	for i, room := range rooms {
 		r := GetRoomStateResponse{
-			ID:      room.ID,
-			Clients: mapClients(room.Clients),
+			ID:           room.ID,
+			Clients:      mapClients(room.Clients),
+			Participants: room.Clients.Count(),
+			BacklogMode:  room.BacklogMode,
+		}
+		if !room.UpdatedAt.IsZero() {
+			r.UpdatedAt = &room.UpdatedAt
 		}
 		res[i] = r
 	}
@@ -9,6 +9,7 @@ import (
 	"planning-poker/internal/infra/boundaries/http/middleware"
 	"planning-poker/internal/infra/boundaries/hub/clientcollection"
 	"testing"
+	"time"
 
 	"go.uber.org/mock/gomock"
 )
@@ -74,8 +75,8 @@ func TestGetAllRoomsStateAPI_Handle_Success(t *testing.T) {
 	}
 
 	mockHub.EXPECT().
-		GetRooms().
-		Return(rooms)
+		ListRooms(gomock.Any(), domain.RoomQuery{}).
+		Return(domain.RoomPage{Rooms: rooms}, nil)
 
 	handler := api.Handle()
 	req := httptest.NewRequest(http.MethodGet, "/admin/rooms", nil)
@@ -134,8 +135,8 @@ func TestGetAllRoomsStateAPI_Handle_EmptyRooms(t *testing.T) {
 	api := NewGetAllRoomsStateAPI(mockHub, middleware.NewAdminMiddleware(apiKey))
 
 	mockHub.EXPECT().
-		GetRooms().
-		Return([]*entity.Room{})
+		ListRooms(gomock.Any(), gomock.Any()).
+		Return(domain.RoomPage{Rooms: []*entity.Room{}}, nil)
 
 	handler := api.Handle()
 	req := httptest.NewRequest(http.MethodGet, "/admin/rooms", nil)
@@ -176,3 +177,93 @@ func TestGetAllRoomsStateAPI_Handle_MissingAuthHeader(t *testing.T) {
 		t.Errorf("status code = %v, want %v", rec.Code, http.StatusUnauthorized)
 	}
 }
+
+func TestGetAllRoomsStateAPI_Handle_QueryAndCursor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockHub := domain.NewMockAdminHub(ctrl)
+	api := NewGetAllRoomsStateAPI(mockHub, middleware.NewAdminMiddleware("valid-api-key"))
+
+	backlogMode := false
+	mockHub.EXPECT().
+		ListRooms(gomock.Any(), domain.RoomQuery{
+			Cursor:          "42",
+			Limit:           10,
+			MinParticipants: 2,
+			MaxParticipants: 8,
+			MinIdle:         30 * time.Minute,
+			BacklogMode:     &backlogMode,
+			OwnerName:       "ali",
+			SortBy:          domain.RoomSortParticipants,
+			Descending:      true,
+		}).
+		Return(domain.RoomPage{Rooms: []*entity.Room{{ID: "room1", Clients: clientcollection.New()}}, NextCursor: "52"}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/rooms?cursor=42&limit=10&min_participants=2&max_participants=8&min_idle=30m&backlog_mode=false&owner=ali&sort=participants&order=desc", nil)
+	req.Header.Set("Authorization", "Bearer valid-api-key")
+	rec := httptest.NewRecorder()
+
+	api.Handle().ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status code = %v, want %v", rec.Code, http.StatusOK)
+	}
+	if got := rec.Header().Get(NextCursorHeader); got != "52" {
+		t.Errorf("%s = %q, want %q", NextCursorHeader, got, "52")
+	}
+}
+
+func TestGetAllRoomsStateAPI_Handle_InvalidQuery(t *testing.T) {
+	queries := []string{
+		"limit=0",
+		"limit=500",
+		"min_participants=many",
+		"min_idle=yesterday",
+		"backlog_mode=maybe",
+		"sort=name",
+		"order=up",
+	}
+
+	for _, query := range queries {
+		t.Run(query, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockHub := domain.NewMockAdminHub(ctrl)
+			api := NewGetAllRoomsStateAPI(mockHub, middleware.NewAdminMiddleware("valid-api-key"))
+
+			req := httptest.NewRequest(http.MethodGet, "/admin/rooms?"+query, nil)
+			req.Header.Set("Authorization", "Bearer valid-api-key")
+			rec := httptest.NewRecorder()
+
+			api.Handle().ServeHTTP(rec, req)
+
+			if rec.Code != http.StatusBadRequest {
+				t.Errorf("status code = %v, want %v", rec.Code, http.StatusBadRequest)
+			}
+		})
+	}
+}
+
+func TestGetAllRoomsStateAPI_Handle_InvalidCursor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockHub := domain.NewMockAdminHub(ctrl)
+	api := NewGetAllRoomsStateAPI(mockHub, middleware.NewAdminMiddleware("valid-api-key"))
+
+	mockHub.EXPECT().
+		ListRooms(gomock.Any(), gomock.Any()).
+		Return(domain.RoomPage{}, domain.ErrInvalidCursor)
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/rooms?cursor=bogus", nil)
+	req.Header.Set("Authorization", "Bearer valid-api-key")
+	rec := httptest.NewRecorder()
+
+	api.Handle().ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("status code = %v, want %v", rec.Code, http.StatusBadRequest)
+	}
+}
@@ -0,0 +1,140 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"planning-poker/internal/application/audit"
+	"planning-poker/internal/infra/boundaries/http/middleware"
+	"time"
+
+	"github.com/bruno303/go-toolkit/pkg/log"
+	"github.com/samber/lo"
+)
+
+const (
+	defaultAuditPageSize = 100
+	maxAuditPageSize     = 1000
+)
+
+type (
+	GetAuditRecordsAPI struct {
+		sink                audit.Sink
+		adminAuthMiddleware middleware.AdminMiddleware
+		logger              log.Logger
+	}
+
+	AuditRecordResponse struct {
+		ID         string    `json:"id"`
+		Timestamp  time.Time `json:"timestamp"`
+		RoomID     string    `json:"room_id"`
+		Action     string    `json:"action"`
+		Actor      string    `json:"actor"`
+		ActorName  string    `json:"actor_name,omitempty"`
+		Target     string    `json:"target,omitempty"`
+		TargetName string    `json:"target_name,omitempty"`
+		Outcome    string    `json:"outcome"`
+		Error      string    `json:"error,omitempty"`
+	}
+)
+
+var _ API = (*GetAuditRecordsAPI)(nil)
+
+// @Summary Query the audit log
+// @Description Returns the moderation and ownership actions, newest first (admin only). Actors are client IDs, or admin key names prefixed with "admin:".
+// @Tags admin
+// @Produce json
+// @Param room query string false "Room ID"
+// @Param actor query string false "Client ID or admin:<key name> of the actor"
+// @Param from query string false "Oldest timestamp, inclusive, in RFC 3339"
+// @Param to query string false "Newest timestamp, exclusive, in RFC 3339"
+// @Param limit query int false "Records to return, 100 by default" minimum(1) maximum(1000)
+// @Success 200 {array} AuditRecordResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {string} string "Key lacks the scope of the endpoint"
+// @Failure 429 {string} string "Too many requests"
+// @Failure 500 {object} ErrorResponse
+// @Security ApiKeyAuth
+// @Router /admin/audit [get]
+func NewGetAuditRecordsAPI(sink audit.Sink, adminAuthMiddleware middleware.AdminMiddleware) GetAuditRecordsAPI {
+	return GetAuditRecordsAPI{
+		sink:                sink,
+		adminAuthMiddleware: adminAuthMiddleware.WithScope(middleware.ScopeAuditRead),
+		logger:              log.NewLogger("getauditrecordsapi"),
+	}
+}
+
+func (api GetAuditRecordsAPI) Endpoint() string {
+	return "/admin/audit"
+}
+
+func (api GetAuditRecordsAPI) Methods() []string {
+	return []string{"GET"}
+}
+
+func (api GetAuditRecordsAPI) Handle() http.Handler {
+	return api.adminAuthMiddleware.Handle(api.execute())
+}
+
+func (api GetAuditRecordsAPI) execute() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		filter, err := parseAuditFilter(r.URL.Query())
+		if err != nil {
+			SendJsonError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		records, err := api.sink.Query(ctx, filter)
+		if err != nil {
+			api.logger.Error(ctx, "Failed to query audit records", err)
+			SendJsonErrorMsg(w, http.StatusInternalServerError, "Failed to query audit records")
+			return
+		}
+
+		SendJsonResponse(w, http.StatusOK, lo.Map(records, func(record audit.Record, _ int) AuditRecordResponse {
+			return AuditRecordResponse{
+				ID:         record.ID,
+				Timestamp:  record.Timestamp,
+				RoomID:     record.RoomID,
+				Action:     string(record.Action),
+				Actor:      record.Actor,
+				ActorName:  record.ActorName,
+				Target:     record.Target,
+				TargetName: record.TargetName,
+				Outcome:    string(record.Outcome),
+				Error:      record.Error,
+			}
+		}))
+	})
+}
+
+func parseAuditFilter(values url.Values) (audit.Filter, error) {
+	filter := audit.Filter{
+		RoomID: values.Get("room"),
+		Actor:  values.Get("actor"),
+		Limit:  defaultAuditPageSize,
+	}
+
+	var err error
+	if values.Has("limit") {
+		if filter.Limit, err = intParam(values, "limit"); err != nil {
+			return filter, err
+		}
+		if filter.Limit < 1 || filter.Limit > maxAuditPageSize {
+			return filter, fmt.Errorf("limit must be between 1 and %d", maxAuditPageSize)
+		}
+	}
+	for name, target := range map[string]*time.Time{"from": &filter.From, "to": &filter.To} {
+		if !values.Has(name) {
+			continue
+		}
+		if *target, err = time.Parse(time.RFC3339, values.Get(name)); err != nil {
+			return filter, fmt.Errorf("%s must be a timestamp like 2024-01-31T10:00:00Z", name)
+		}
+	}
+
+	return filter, nil
+}
@@ -0,0 +1,99 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"planning-poker/internal/application/audit"
+	"planning-poker/internal/application/timer"
+	"planning-poker/internal/infra/boundaries/http/middleware"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetAuditRecordsAPI_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	from := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	mockSink := audit.NewMockSink(ctrl)
+	mockSink.EXPECT().
+		Query(gomock.Any(), audit.Filter{RoomID: "room1", Actor: "admin:alice", From: from, Limit: 10}).
+		Return([]audit.Record{{
+			ID:         "1",
+			Timestamp:  from.Add(time.Minute),
+			RoomID:     "room1",
+			Action:     audit.ActionKickClient,
+			Actor:      "admin:alice",
+			Target:     "client1",
+			TargetName: "Bob",
+			Outcome:    audit.OutcomeSucceeded,
+		}}, nil)
+	api := NewGetAuditRecordsAPI(mockSink, middleware.NewAdminMiddleware("valid-api-key"))
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/audit?room=room1&actor=admin:alice&from=2024-01-01T10:00:00Z&limit=10", nil)
+	req.Header.Set("Authorization", "Bearer valid-api-key")
+	rec := httptest.NewRecorder()
+
+	api.Handle().ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status code = %v, want %v", rec.Code, http.StatusOK)
+	}
+	var response []AuditRecordResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	if len(response) != 1 {
+		t.Fatalf("expected 1 record, got %d", len(response))
+	}
+	if response[0].Action != "kick-client" || response[0].Outcome != "succeeded" || response[0].TargetName != "Bob" {
+		t.Errorf("unexpected record %+v", response[0])
+	}
+}
+
+func TestGetAuditRecordsAPI_Handle_InvalidFilter(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	api := NewGetAuditRecordsAPI(audit.NewMockSink(ctrl), middleware.NewAdminMiddleware("valid-api-key"))
+
+	for _, query := range []string{"from=yesterday", "to=2024-01-01", "limit=0", "limit=1001", "limit=ten"} {
+		t.Run(query, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/admin/audit?"+query, nil)
+			req.Header.Set("Authorization", "Bearer valid-api-key")
+			rec := httptest.NewRecorder()
+
+			api.Handle().ServeHTTP(rec, req)
+
+			if rec.Code != http.StatusBadRequest {
+				t.Errorf("status code = %v, want %v", rec.Code, http.StatusBadRequest)
+			}
+		})
+	}
+}
+
+func TestGetAuditRecordsAPI_Handle_RequiresScope(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	keyring, err := middleware.NewAdminKeyring([]middleware.AdminKey{
+		{Name: "support", Key: "support-key", Scopes: []string{middleware.ScopeRoomsRead}},
+	}, "", timer.SystemClock{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	api := NewGetAuditRecordsAPI(audit.NewMockSink(ctrl), middleware.NewAdminKeyringMiddleware(keyring))
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/audit", nil)
+	req.Header.Set("Authorization", "Bearer support-key")
+	rec := httptest.NewRecorder()
+
+	api.Handle().ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("status code = %v, want %v", rec.Code, http.StatusForbidden)
+	}
+}
@@ -0,0 +1,135 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"planning-poker/internal/domain"
+	"planning-poker/internal/domain/entity"
+	"planning-poker/internal/infra/boundaries/http/middleware"
+	"time"
+
+	"github.com/bruno303/go-toolkit/pkg/log"
+	"github.com/gorilla/mux"
+	"github.com/samber/lo"
+)
+
+type (
+	GetRoomEventsAPI struct {
+		hub                 domain.AdminHub
+		adminAuthMiddleware middleware.AdminMiddleware
+		logger              log.Logger
+	}
+
+	RoomEventResponse struct {
+		Sequence   int64      `json:"sequence"`
+		Type       string     `json:"type"`
+		ClientID   string     `json:"client_id,omitempty"`
+		TargetID   string     `json:"target_id,omitempty"`
+		Name       string     `json:"name,omitempty"`
+		Index      *int       `json:"index,omitempty"`
+		Vote       *string    `json:"vote,omitempty"`
+		Deck       []string   `json:"deck,omitempty"`
+		Rule       string     `json:"rule,omitempty"`
+		Action     string     `json:"action,omitempty"`
+		Roles      []string   `json:"roles,omitempty"`
+		Deadline   *time.Time `json:"deadline,omitempty"`
+		OccurredAt time.Time  `json:"occurred_at"`
+	}
+
+	GetRoomEventsResponse struct {
+		RoomID string              `json:"room_id"`
+		Events []RoomEventResponse `json:"events"`
+	}
+)
+
+var _ API = (*GetRoomEventsAPI)(nil)
+
+// @Summary Get room event timeline
+// @Description Returns every change recorded for a room, oldest first (admin only). Passcode hashes and invite secrets are never returned.
+// @Tags admin
+// @Produce json
+// @Param roomID path string true "Room ID"
+// @Success 200 {object} GetRoomEventsResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {string} string "Key lacks the scope of the endpoint"
+// @Failure 429 {string} string "Too many requests"
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Security ApiKeyAuth
+// @Router /admin/rooms/{roomID}/events [get]
+func NewGetRoomEventsAPI(hub domain.AdminHub, adminAuthMiddleware middleware.AdminMiddleware) GetRoomEventsAPI {
+	return GetRoomEventsAPI{
+		hub:                 hub,
+		adminAuthMiddleware: adminAuthMiddleware.WithScope(middleware.ScopeRoomsRead),
+		logger:              log.NewLogger("getroomeventsapi"),
+	}
+}
+
+func (api GetRoomEventsAPI) Endpoint() string {
+	return "/admin/rooms/{roomID}/events"
+}
+
+func (api GetRoomEventsAPI) Methods() []string {
+	return []string{"GET"}
+}
+
+func (api GetRoomEventsAPI) Handle() http.Handler {
+	return api.adminAuthMiddleware.Handle(api.execute())
+}
+
+func (api GetRoomEventsAPI) execute() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		roomID := mux.Vars(r)["roomID"]
+		if roomID == "" {
+			SendJsonErrorMsg(w, http.StatusBadRequest, "Room ID is required")
+			return
+		}
+
+		events, err := api.hub.RoomEvents(ctx, roomID)
+		if errors.Is(err, domain.ErrRoomNotFound) {
+			SendJsonErrorMsg(w, http.StatusNotFound, "Room not found")
+			return
+		}
+		if err != nil {
+			api.logger.Error(ctx, "Failed to load room events", err)
+			SendJsonErrorMsg(w, http.StatusInternalServerError, "Failed to load room events")
+			return
+		}
+
+		SendJsonResponse(w, http.StatusOK, GetRoomEventsResponse{
+			RoomID: roomID,
+			Events: lo.Map(events, func(event entity.RoomEvent, _ int) RoomEventResponse {
+				return mapRoomEvent(event)
+			}),
+		})
+	})
+}
+
+func mapRoomEvent(event entity.RoomEvent) RoomEventResponse {
+	response := RoomEventResponse{
+		Sequence:   event.Sequence,
+		Type:       string(event.Type),
+		ClientID:   event.ClientID,
+		TargetID:   event.TargetID,
+		Name:       event.Name,
+		Vote:       event.Vote,
+		Rule:       string(event.Rule),
+		Action:     string(event.Action),
+		Deadline:   event.Deadline,
+		OccurredAt: event.OccurredAt,
+	}
+	if event.Type == entity.EventStoryRemoved {
+		response.Index = lo.ToPtr(event.Index)
+	}
+	if event.Deck != nil {
+		response.Deck = event.Deck.Cards
+	}
+	if event.Type == entity.EventPermissionChanged {
+		response.Roles = lo.Map(event.Roles, func(role entity.Role, _ int) string {
+			return string(role)
+		})
+	}
+	return response
+}
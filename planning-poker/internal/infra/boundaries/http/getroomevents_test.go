@@ -0,0 +1,98 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"planning-poker/internal/domain"
+	"planning-poker/internal/domain/entity"
+	"planning-poker/internal/infra/boundaries/http/middleware"
+	"planning-poker/internal/infra/boundaries/hub/clientcollection"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetRoomEventsAPI_Endpoint(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	api := NewGetRoomEventsAPI(domain.NewMockAdminHub(ctrl), middleware.NewAdminMiddleware("test-api-key"))
+
+	if api.Endpoint() != "/admin/rooms/{roomID}/events" {
+		t.Errorf("Endpoint() = %v, want %v", api.Endpoint(), "/admin/rooms/{roomID}/events")
+	}
+}
+
+func TestGetRoomEventsAPI_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	room := entity.NewRoomWithID("room1", clientcollection.New())
+	room.NewClient("client1")
+	_ = room.AddStory(ctx, "client1", "Story 1")
+	_ = room.SetPasscode(ctx, "client1", "s3cret")
+
+	mockHub := domain.NewMockAdminHub(ctrl)
+	mockHub.EXPECT().RoomEvents(gomock.Any(), "room1").Return(room.PendingEvents(), nil)
+	api := NewGetRoomEventsAPI(mockHub, middleware.NewAdminMiddleware("valid-api-key"))
+
+	router := mux.NewRouter()
+	router.Handle(api.Endpoint(), api.Handle()).Methods("GET")
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/rooms/room1/events", nil)
+	req.Header.Set("Authorization", "Bearer valid-api-key")
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status code = %v, want %v", rec.Code, http.StatusOK)
+	}
+	if strings.Contains(rec.Body.String(), room.PasscodeHash) {
+		t.Error("response must not contain the passcode hash")
+	}
+
+	var response GetRoomEventsResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+
+	types := make([]string, len(response.Events))
+	for i, event := range response.Events {
+		types[i] = event.Type
+	}
+	want := "room-created,client-joined,story-added,passcode-changed"
+	if got := strings.Join(types, ","); got != want {
+		t.Errorf("event types = %v, want %v", got, want)
+	}
+	if response.Events[2].Name != "Story 1" || response.Events[2].ClientID != "client1" {
+		t.Errorf("unexpected story event %+v", response.Events[2])
+	}
+}
+
+func TestGetRoomEventsAPI_Handle_RoomNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockHub := domain.NewMockAdminHub(ctrl)
+	mockHub.EXPECT().RoomEvents(gomock.Any(), "nonexistent").Return(nil, domain.ErrRoomNotFound)
+	api := NewGetRoomEventsAPI(mockHub, middleware.NewAdminMiddleware("valid-api-key"))
+
+	router := mux.NewRouter()
+	router.Handle(api.Endpoint(), api.Handle()).Methods("GET")
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/rooms/nonexistent/events", nil)
+	req.Header.Set("Authorization", "Bearer valid-api-key")
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("status code = %v, want %v", rec.Code, http.StatusNotFound)
+	}
+}
@@ -27,6 +27,8 @@ var _ API = (*GetRoomStateAPI)(nil)
 // @Success 200 {object} GetRoomStateResponse
 // @Failure 400 {object} ErrorResponse
 // @Failure 401 {object} ErrorResponse
+// @Failure 403 {string} string "Key lacks the scope of the endpoint"
+// @Failure 429 {string} string "Too many requests"
 // @Failure 404 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Security ApiKeyAuth
@@ -34,7 +36,7 @@ var _ API = (*GetRoomStateAPI)(nil)
 func NewGetRoomStateAPI(hub domain.Hub, adminAuthMiddleware middleware.AdminMiddleware) GetRoomStateAPI {
 	return GetRoomStateAPI{
 		hub:                 hub,
-		adminAuthMiddleware: adminAuthMiddleware,
+		adminAuthMiddleware: adminAuthMiddleware.WithScope(middleware.ScopeRoomsRead),
 		logger:              log.NewLogger("getroomstateapi"),
 	}
 }
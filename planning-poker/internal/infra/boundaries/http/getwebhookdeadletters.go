@@ -0,0 +1,107 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"planning-poker/internal/application/webhook"
+	"planning-poker/internal/infra/boundaries/http/middleware"
+	"time"
+
+	"github.com/bruno303/go-toolkit/pkg/log"
+	"github.com/samber/lo"
+)
+
+const (
+	defaultDeadLettersPageSize = 100
+	maxDeadLettersPageSize     = 1000
+)
+
+type (
+	GetWebhookDeadLettersAPI struct {
+		store               webhook.Store
+		adminAuthMiddleware middleware.AdminMiddleware
+		logger              log.Logger
+	}
+
+	WebhookDeadLetterResponse struct {
+		SubscriptionID string `json:"subscription_id"`
+		URL            string `json:"url"`
+		// Event is the body of the delivery as it was sent
+		Event    webhook.Event `json:"event" swaggertype:"object"`
+		Attempts int           `json:"attempts"`
+		Error    string        `json:"error"`
+		FailedAt time.Time     `json:"failed_at"`
+	}
+)
+
+var _ API = (*GetWebhookDeadLettersAPI)(nil)
+
+// @Summary List undelivered webhook events
+// @Description Returns the deliveries given up after their last attempt, newest first (admin only)
+// @Tags admin
+// @Produce json
+// @Param limit query int false "Dead letters to return, 100 by default" minimum(1) maximum(1000)
+// @Success 200 {array} WebhookDeadLetterResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {string} string "Key lacks the scope of the endpoint"
+// @Failure 429 {string} string "Too many requests"
+// @Failure 500 {object} ErrorResponse
+// @Security ApiKeyAuth
+// @Router /admin/webhooks/dead-letters [get]
+func NewGetWebhookDeadLettersAPI(store webhook.Store, adminAuthMiddleware middleware.AdminMiddleware) GetWebhookDeadLettersAPI {
+	return GetWebhookDeadLettersAPI{
+		store:               store,
+		adminAuthMiddleware: adminAuthMiddleware.WithScope(middleware.ScopeWebhooks),
+		logger:              log.NewLogger("getwebhookdeadlettersapi"),
+	}
+}
+
+func (api GetWebhookDeadLettersAPI) Endpoint() string {
+	return "/admin/webhooks/dead-letters"
+}
+
+func (api GetWebhookDeadLettersAPI) Methods() []string {
+	return []string{"GET"}
+}
+
+func (api GetWebhookDeadLettersAPI) Handle() http.Handler {
+	return api.adminAuthMiddleware.Handle(api.execute())
+}
+
+func (api GetWebhookDeadLettersAPI) execute() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		limit := defaultDeadLettersPageSize
+		if values := r.URL.Query(); values.Has("limit") {
+			var err error
+			if limit, err = intParam(values, "limit"); err != nil {
+				SendJsonError(w, http.StatusBadRequest, err)
+				return
+			}
+			if limit < 1 || limit > maxDeadLettersPageSize {
+				SendJsonError(w, http.StatusBadRequest, fmt.Errorf("limit must be between 1 and %d", maxDeadLettersPageSize))
+				return
+			}
+		}
+
+		deadLetters, err := api.store.DeadLetters(ctx, limit)
+		if err != nil {
+			api.logger.Error(ctx, "Failed to list webhook dead letters", err)
+			SendJsonErrorMsg(w, http.StatusInternalServerError, "Failed to list webhook dead letters")
+			return
+		}
+
+		SendJsonResponse(w, http.StatusOK, lo.Map(deadLetters, func(deadLetter webhook.DeadLetter, _ int) WebhookDeadLetterResponse {
+			return WebhookDeadLetterResponse{
+				SubscriptionID: deadLetter.SubscriptionID,
+				URL:            deadLetter.URL,
+				Event:          deadLetter.Event,
+				Attempts:       deadLetter.Attempts,
+				Error:          deadLetter.Error,
+				FailedAt:       deadLetter.FailedAt,
+			}
+		}))
+	})
+}
@@ -0,0 +1,61 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"planning-poker/internal/application/webhook"
+	"planning-poker/internal/infra/boundaries/http/middleware"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetWebhookDeadLettersAPI_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	failedAt := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	mockStore := webhook.NewMockStore(ctrl)
+	mockStore.EXPECT().DeadLetters(gomock.Any(), 10).Return([]webhook.DeadLetter{{
+		SubscriptionID: "webhook-1",
+		URL:            "https://hooks.example.com/poker",
+		Event:          webhook.Event{ID: "event-1", Type: webhook.EventStoryAdded, RoomID: "room123"},
+		Attempts:       5,
+		Error:          "webhook responded with 503 Service Unavailable",
+		FailedAt:       failedAt,
+	}}, nil)
+	api := NewGetWebhookDeadLettersAPI(mockStore, middleware.NewAdminMiddleware("valid-api-key"))
+
+	rec := serveAdminAPI(api, http.MethodGet, "/admin/webhooks/dead-letters?limit=10", "")
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status code = %v, want %v", rec.Code, http.StatusOK)
+	}
+	var response []struct {
+		SubscriptionID string        `json:"subscription_id"`
+		Event          webhook.Event `json:"event"`
+		Attempts       int           `json:"attempts"`
+		FailedAt       time.Time     `json:"failed_at"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	if len(response) != 1 || response[0].Event.ID != "event-1" || response[0].Attempts != 5 || !response[0].FailedAt.Equal(failedAt) {
+		t.Errorf("unexpected response %+v", response)
+	}
+}
+
+func TestGetWebhookDeadLettersAPI_Handle_InvalidLimit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	api := NewGetWebhookDeadLettersAPI(webhook.NewMockStore(ctrl), middleware.NewAdminMiddleware("valid-api-key"))
+	for _, limit := range []string{"0", "1001", "ten"} {
+		rec := serveAdminAPI(api, http.MethodGet, "/admin/webhooks/dead-letters?limit="+limit, "")
+
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("status code for limit %s = %v, want %v", limit, rec.Code, http.StatusBadRequest)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package http
+
+import (
+	"net/http"
+	"planning-poker/internal/application/webhook"
+	"planning-poker/internal/infra/boundaries/http/middleware"
+	"time"
+
+	"github.com/bruno303/go-toolkit/pkg/log"
+	"github.com/samber/lo"
+)
+
+type (
+	GetWebhooksAPI struct {
+		store               webhook.Store
+		adminAuthMiddleware middleware.AdminMiddleware
+		logger              log.Logger
+	}
+
+	AdminWebhookResponse struct {
+		ID     string `json:"id"`
+		RoomID string `json:"room_id,omitempty"`
+		URL    string `json:"url"`
+		// Events is empty for subscriptions to every event
+		Events    []string  `json:"events"`
+		CreatedAt time.Time `json:"created_at"`
+		// Secret is only returned when the subscription is created
+		Secret string `json:"secret,omitempty"`
+	}
+)
+
+var _ API = (*GetWebhooksAPI)(nil)
+
+// @Summary List webhook subscriptions
+// @Description Returns the webhook subscriptions of every room and of the whole instance, without their secrets (admin only)
+// @Tags admin
+// @Produce json
+// @Success 200 {array} AdminWebhookResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {string} string "Key lacks the scope of the endpoint"
+// @Failure 429 {string} string "Too many requests"
+// @Failure 500 {object} ErrorResponse
+// @Security ApiKeyAuth
+// @Router /admin/webhooks [get]
+func NewGetWebhooksAPI(store webhook.Store, adminAuthMiddleware middleware.AdminMiddleware) GetWebhooksAPI {
+	return GetWebhooksAPI{
+		store:               store,
+		adminAuthMiddleware: adminAuthMiddleware.WithScope(middleware.ScopeWebhooks),
+		logger:              log.NewLogger("getwebhooksapi"),
+	}
+}
+
+func (api GetWebhooksAPI) Endpoint() string {
+	return "/admin/webhooks"
+}
+
+func (api GetWebhooksAPI) Methods() []string {
+	return []string{"GET"}
+}
+
+func (api GetWebhooksAPI) Handle() http.Handler {
+	return api.adminAuthMiddleware.Handle(api.execute())
+}
+
+func (api GetWebhooksAPI) execute() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		subscriptions, err := api.store.Subscriptions(ctx)
+		if err != nil {
+			api.logger.Error(ctx, "Failed to list webhook subscriptions", err)
+			SendJsonErrorMsg(w, http.StatusInternalServerError, "Failed to list webhook subscriptions")
+			return
+		}
+
+		SendJsonResponse(w, http.StatusOK, lo.Map(subscriptions, func(subscription webhook.Subscription, _ int) AdminWebhookResponse {
+			response := newAdminWebhookResponse(subscription)
+			response.Secret = ""
+			return response
+		}))
+	})
+}
+
+func newAdminWebhookResponse(subscription webhook.Subscription) AdminWebhookResponse {
+	return AdminWebhookResponse{
+		ID:        subscription.ID,
+		RoomID:    subscription.RoomID,
+		URL:       subscription.URL,
+		Events:    eventTypeNames(subscription.EventTypes),
+		CreatedAt: subscription.CreatedAt,
+		Secret:    subscription.Secret,
+	}
+}
+
+func eventTypeNames(eventTypes []webhook.EventType) []string {
+	names := make([]string, 0, len(eventTypes))
+	for _, eventType := range eventTypes {
+		names = append(names, string(eventType))
+	}
+	return names
+}
@@ -0,0 +1,51 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"planning-poker/internal/application/webhook"
+	"planning-poker/internal/infra/boundaries/http/middleware"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"go.uber.org/mock/gomock"
+)
+
+func serveAdminAPI(api API, method, target, body string) *httptest.ResponseRecorder {
+	router := mux.NewRouter()
+	router.Handle(api.Endpoint(), api.Handle()).Methods(api.Methods()...)
+
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	req.Header.Set("Authorization", "Bearer valid-api-key")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestGetWebhooksAPI_Handle_HidesSecrets(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := webhook.NewMockStore(ctrl)
+	mockStore.EXPECT().Subscriptions(gomock.Any()).Return([]webhook.Subscription{{
+		ID:         "webhook-1",
+		URL:        "https://hooks.example.com/poker",
+		Secret:     "whsec_secret",
+		EventTypes: []webhook.EventType{webhook.EventSessionEnded},
+	}}, nil)
+
+	rec := serveAdminAPI(NewGetWebhooksAPI(mockStore, middleware.NewAdminMiddleware("valid-api-key")), http.MethodGet, "/admin/webhooks", "")
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status code = %v, want %v", rec.Code, http.StatusOK)
+	}
+	var response []AdminWebhookResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	if len(response) != 1 || response[0].ID != "webhook-1" || response[0].Secret != "" || response[0].Events[0] != "session.ended" {
+		t.Errorf("unexpected response %+v", response)
+	}
+}
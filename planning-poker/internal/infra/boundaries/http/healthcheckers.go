@@ -17,6 +17,15 @@ type (
 		client RedisClient
 		name   string
 	}
+
+	PostgresClient interface {
+		Ping(ctx context.Context) error
+	}
+
+	PostgresHealthChecker struct {
+		client PostgresClient
+		name   string
+	}
 )
 
 func NewRedisHealthChecker(client RedisClient, name string) *RedisHealthChecker {
@@ -63,3 +72,48 @@ func (c *RedisHealthChecker) Check(ctx context.Context) HealthStatus {
 		},
 	}
 }
+
+func NewPostgresHealthChecker(client PostgresClient, name string) *PostgresHealthChecker {
+	return &PostgresHealthChecker{
+		client: client,
+		name:   name,
+	}
+}
+
+func (c *PostgresHealthChecker) Name() string {
+	return c.name
+}
+
+func (c *PostgresHealthChecker) Check(ctx context.Context) HealthStatus {
+	start := time.Now()
+
+	err := c.client.Ping(ctx)
+	duration := time.Since(start)
+
+	if err != nil {
+		return HealthStatus{
+			Status:  HealthStatusFail,
+			Message: fmt.Sprintf("Postgres connection failed: %v", err),
+			Details: map[string]any{
+				"response_time_ms": duration.Milliseconds(),
+				"error":            err.Error(),
+			},
+		}
+	}
+
+	status := HealthStatusPass
+	message := "Postgres connection successful"
+
+	if duration > 100*time.Millisecond {
+		status = HealthStatusWarn
+		message = fmt.Sprintf("Postgres connection successful but slow (%v)", duration)
+	}
+
+	return HealthStatus{
+		Status:  status,
+		Message: message,
+		Details: map[string]any{
+			"response_time_ms": duration.Milliseconds(),
+		},
+	}
+}
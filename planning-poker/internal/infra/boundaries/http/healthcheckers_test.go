@@ -40,6 +40,38 @@ func TestRedisHealthChecker_Check_Failure(t *testing.T) {
 	assert.Contains(t, status.Details["error"], assert.AnError.Error())
 }
 
+func TestPostgresHealthChecker_Check_Success(t *testing.T) {
+	client := &MockPostgresClient{
+		pingResult: nil,
+	}
+
+	checker := NewPostgresHealthChecker(client, "postgres")
+	ctx := context.Background()
+
+	status := checker.Check(ctx)
+
+	assert.Equal(t, "postgres", checker.Name())
+	assert.Equal(t, HealthStatusPass, status.Status)
+	assert.Contains(t, status.Message, "Postgres connection successful")
+	assert.Contains(t, status.Details, "response_time_ms")
+}
+
+func TestPostgresHealthChecker_Check_Failure(t *testing.T) {
+	client := &MockPostgresClient{
+		pingResult: assert.AnError,
+	}
+
+	checker := NewPostgresHealthChecker(client, "postgres")
+	ctx := context.Background()
+
+	status := checker.Check(ctx)
+
+	assert.Equal(t, "postgres", checker.Name())
+	assert.Equal(t, HealthStatusFail, status.Status)
+	assert.Contains(t, status.Message, "Postgres connection failed")
+	assert.Contains(t, status.Details["error"], assert.AnError.Error())
+}
+
 // Mock Redis client for testing
 type MockRedisClient struct {
 	pingResult error
@@ -54,3 +86,12 @@ func (m *MockRedisClient) Ping(ctx context.Context) *redis.StatusCmd {
 	}
 	return cmd
 }
+
+// Mock Postgres client for testing
+type MockPostgresClient struct {
+	pingResult error
+}
+
+func (m *MockPostgresClient) Ping(ctx context.Context) error {
+	return m.pingResult
+}
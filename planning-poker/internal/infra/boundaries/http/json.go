@@ -36,7 +36,11 @@ func SendJsonErrorMsg(w http.ResponseWriter, statusCode int, msg string) {
 }
 
 func SendErrorWebsocket(ws *websocket.Conn, msg string) {
-	closeMsg := websocket.FormatCloseMessage(websocket.CloseInternalServerErr, msg)
+	SendCloseWebsocket(ws, websocket.CloseInternalServerErr, msg)
+}
+
+func SendCloseWebsocket(ws *websocket.Conn, closeCode int, msg string) {
+	closeMsg := websocket.FormatCloseMessage(closeCode, msg)
 	_ = ws.WriteControl(websocket.CloseMessage, closeMsg, time.Now().Add(time.Second))
 	_ = ws.Close()
 }
@@ -59,7 +59,7 @@ func TestKickClientAPI_Handle_Success(t *testing.T) {
 	api := NewKickClientAPI(mockUseCase, middleware.NewAdminMiddleware(apiKey))
 
 	mockUseCase.EXPECT().
-		Execute(gomock.Any(), usecase.AdminKickClientCommand{RoomID: roomID, ClientID: clientID}).
+		Execute(gomock.Any(), usecase.AdminKickClientCommand{RoomID: roomID, ClientID: clientID, Actor: middleware.DefaultAdminKeyName}).
 		Return(nil)
 
 	handler := api.Handle()
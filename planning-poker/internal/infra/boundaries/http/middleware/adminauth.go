@@ -1,31 +1,90 @@
 package middleware
 
 import (
-	"crypto/subtle"
+	"context"
 	"net/http"
+	"planning-poker/internal/application/timer"
 	"strings"
+
+	"github.com/bruno303/go-toolkit/pkg/log"
 )
 
+// DefaultAdminKeyName names the single key given by ADMIN_API_KEY
+const DefaultAdminKeyName = "default"
+
+type adminKeyNameKey struct{}
+
+// AdminMiddleware authenticates admin calls with a key of the keyring holding
+// the scope of the endpoint, and records every call with the key's name.
 type AdminMiddleware struct {
-	apiKey string
+	keyring *AdminKeyring
+	scope   string
+	audit   log.Logger
 }
 
+// NewAdminMiddleware accepts a single key holding every scope.
 func NewAdminMiddleware(apiKey string) AdminMiddleware {
+	var keys []AdminKey
+	if apiKey != "" {
+		keys = append(keys, AdminKey{Name: DefaultAdminKeyName, Key: apiKey, Scopes: []string{ScopeAll}})
+	}
+	keyring, _ := NewAdminKeyring(keys, "", timer.SystemClock{})
+	return NewAdminKeyringMiddleware(keyring)
+}
+
+func NewAdminKeyringMiddleware(keyring *AdminKeyring) AdminMiddleware {
 	return AdminMiddleware{
-		apiKey: apiKey,
+		keyring: keyring,
+		audit:   log.NewLogger("admin.audit"),
 	}
 }
 
+// WithScope returns a copy of the middleware only accepting keys that hold
+// the scope.
+func (m AdminMiddleware) WithScope(scope string) AdminMiddleware {
+	m.scope = scope
+	return m
+}
+
+// AdminKeyName returns the name of the key that authenticated the admin call.
+func AdminKeyName(ctx context.Context) string {
+	name, _ := ctx.Value(adminKeyNameKey{}).(string)
+	return name
+}
+
 func (m *AdminMiddleware) Handle(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		authorization := r.Header.Get("Authorization")
 
 		apiKey := strings.TrimPrefix(authorization, "Bearer ")
 
-		if subtle.ConstantTimeCompare([]byte(apiKey), []byte(m.apiKey)) != 1 {
+		key, ok := m.keyring.Authenticate(r.Context(), apiKey)
+		if !ok {
 			http.Error(w, "Unauthorized", http.StatusUnauthorized)
 			return
 		}
-		next.ServeHTTP(w, r)
+		if m.scope != "" && !key.HasScope(m.scope) {
+			m.audit.Warn(r.Context(), "Admin key %s denied %s %s: missing scope %s", key.Name, r.Method, r.URL.Path, m.scope)
+			http.Error(w, "Forbidden", http.StatusForbidden)
+			return
+		}
+
+		recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(recorder, r.WithContext(context.WithValue(r.Context(), adminKeyNameKey{}, key.Name)))
+		m.audit.Info(r.Context(), "Admin key %s called %s %s: %d", key.Name, r.Method, r.URL.Path, recorder.status)
 	})
 }
+
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *statusRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	"context"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -11,8 +12,56 @@ func TestNewAdminMiddleware(t *testing.T) {
 
 	middleware := NewAdminMiddleware(apiKey)
 
-	if middleware.apiKey != apiKey {
-		t.Errorf("apiKey = %v, want %v", middleware.apiKey, apiKey)
+	key, ok := middleware.keyring.Authenticate(context.Background(), apiKey)
+	if !ok {
+		t.Fatal("expected the key to be accepted")
+	}
+	if key.Name != DefaultAdminKeyName || !key.HasScope(ScopeClientsOwner) {
+		t.Errorf("key = %+v, want the default key holding every scope", key)
+	}
+}
+
+func TestAdminMiddleware_Handle_Scopes(t *testing.T) {
+	keyring, err := NewAdminKeyring([]AdminKey{
+		{Name: "alice", Key: "alice-key", Scopes: []string{ScopeRoomsRead, ScopeClientsKick}},
+	}, "", &fakeClock{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	middleware := NewAdminKeyringMiddleware(keyring)
+
+	var actor string
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		actor = AdminKeyName(r.Context())
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	tests := []struct {
+		scope          string
+		expectedStatus int
+	}{
+		{scope: ScopeRoomsRead, expectedStatus: http.StatusNoContent},
+		{scope: ScopeClientsKick, expectedStatus: http.StatusNoContent},
+		{scope: ScopeClientsOwner, expectedStatus: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.scope, func(t *testing.T) {
+			actor = ""
+			scoped := middleware.WithScope(tt.scope)
+			req := httptest.NewRequest(http.MethodPost, "/admin", nil)
+			req.Header.Set("Authorization", "Bearer alice-key")
+			rec := httptest.NewRecorder()
+
+			scoped.Handle(next).ServeHTTP(rec, req)
+
+			if rec.Code != tt.expectedStatus {
+				t.Errorf("status code = %v, want %v", rec.Code, tt.expectedStatus)
+			}
+			if tt.expectedStatus == http.StatusNoContent && actor != "alice" {
+				t.Errorf("actor = %q, want alice", actor)
+			}
+		})
 	}
 }
 
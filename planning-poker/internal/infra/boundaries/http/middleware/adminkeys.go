@@ -0,0 +1,202 @@
+package middleware
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"planning-poker/internal/application/timer"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/bruno303/go-toolkit/pkg/log"
+)
+
+const (
+	ScopeRoomsRead    = "rooms:read"
+	ScopeClientsKick  = "clients:kick"
+	ScopeClientsOwner = "clients:owner"
+	ScopeAuditRead    = "audit:read"
+	ScopeWebhooks     = "webhooks:manage"
+	// ScopeAll grants every scope
+	ScopeAll = "*"
+)
+
+// keysReloadInterval is how often the keys file is checked for changes
+const keysReloadInterval = 10 * time.Second
+
+// AdminKey is a named admin API key. A key is rotated by adding the new
+// secret under the same name with a NotBefore before the ExpiresAt of the
+// old one, both are accepted in between.
+type AdminKey struct {
+	Name string `json:"name"`
+	// Key is the secret itself, or SHA256 its hex encoded SHA-256 digest so
+	// the configuration does not hold the secret
+	Key       string    `json:"key"`
+	SHA256    string    `json:"sha256"`
+	Scopes    []string  `json:"scopes"`
+	NotBefore time.Time `json:"not_before"`
+	ExpiresAt time.Time `json:"expires_at"`
+
+	digest []byte
+}
+
+func (k AdminKey) HasScope(scope string) bool {
+	return slices.Contains(k.Scopes, ScopeAll) || slices.Contains(k.Scopes, scope)
+}
+
+func (k AdminKey) activeAt(now time.Time) bool {
+	return (k.NotBefore.IsZero() || !now.Before(k.NotBefore)) &&
+		(k.ExpiresAt.IsZero() || now.Before(k.ExpiresAt))
+}
+
+// ParseAdminKeys reads a JSON array of keys, the format of both
+// ADMIN_API_KEYS and the keys file.
+func ParseAdminKeys(data []byte) ([]AdminKey, error) {
+	var keys []AdminKey
+	if err := json.Unmarshal(data, &keys); err != nil {
+		return nil, fmt.Errorf("failed to parse admin keys: %w", err)
+	}
+	for i := range keys {
+		if err := keys[i].prepare(); err != nil {
+			return nil, err
+		}
+	}
+	return keys, nil
+}
+
+func (k *AdminKey) prepare() error {
+	if k.Name == "" {
+		return errors.New("admin key without a name")
+	}
+	switch {
+	case k.Key != "":
+		digest := sha256.Sum256([]byte(k.Key))
+		k.digest = digest[:]
+	case k.SHA256 != "":
+		digest, err := hex.DecodeString(k.SHA256)
+		if err != nil || len(digest) != sha256.Size {
+			return fmt.Errorf("admin key %s has an invalid sha256", k.Name)
+		}
+		k.digest = digest
+	default:
+		return fmt.Errorf("admin key %s has no key or sha256", k.Name)
+	}
+	if len(k.Scopes) == 0 {
+		return fmt.Errorf("admin key %s has no scopes", k.Name)
+	}
+	return nil
+}
+
+// AdminKeyring holds the admin keys from the configuration and, when a path
+// is given, from a file that is reloaded when it changes.
+type AdminKeyring struct {
+	static []AdminKey
+	path   string
+	clock  timer.Clock
+	logger log.Logger
+
+	mu        sync.RWMutex
+	keys      []AdminKey
+	modTime   time.Time
+	checkedAt time.Time
+}
+
+func NewAdminKeyring(static []AdminKey, path string, clock timer.Clock) (*AdminKeyring, error) {
+	for i := range static {
+		if err := static[i].prepare(); err != nil {
+			return nil, err
+		}
+	}
+
+	keyring := &AdminKeyring{
+		static: static,
+		path:   path,
+		clock:  clock,
+		logger: log.NewLogger("adminkeyring"),
+		keys:   static,
+	}
+	if path != "" {
+		if err := keyring.reload(); err != nil {
+			return nil, err
+		}
+		keyring.checkedAt = clock.Now()
+	}
+	return keyring, nil
+}
+
+// Authenticate returns the active key holding the secret. Every key is
+// compared, so the time taken does not depend on which one matched.
+func (k *AdminKeyring) Authenticate(ctx context.Context, secret string) (AdminKey, bool) {
+	if secret == "" {
+		return AdminKey{}, false
+	}
+
+	now := k.clock.Now()
+	k.reloadIfChanged(ctx, now)
+
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	digest := sha256.Sum256([]byte(secret))
+	var found AdminKey
+	var ok bool
+	for _, key := range k.keys {
+		if subtle.ConstantTimeCompare(digest[:], key.digest) == 1 && key.activeAt(now) && !ok {
+			found, ok = key, true
+		}
+	}
+	return found, ok
+}
+
+func (k *AdminKeyring) reloadIfChanged(ctx context.Context, now time.Time) {
+	if k.path == "" {
+		return
+	}
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if now.Sub(k.checkedAt) < keysReloadInterval {
+		return
+	}
+	k.checkedAt = now
+
+	if err := k.reloadLocked(); err != nil {
+		// keep the keys loaded last, a bad edit must not lock admins out
+		k.logger.Warn(ctx, "Failed to reload admin keys from %s: %v", k.path, err)
+	}
+}
+
+func (k *AdminKeyring) reload() error {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	return k.reloadLocked()
+}
+
+func (k *AdminKeyring) reloadLocked() error {
+	info, err := os.Stat(k.path)
+	if err != nil {
+		return fmt.Errorf("failed to stat admin keys file: %w", err)
+	}
+	if info.ModTime().Equal(k.modTime) {
+		return nil
+	}
+
+	data, err := os.ReadFile(k.path)
+	if err != nil {
+		return fmt.Errorf("failed to read admin keys file: %w", err)
+	}
+	fileKeys, err := ParseAdminKeys(data)
+	if err != nil {
+		return err
+	}
+
+	k.keys = append(slices.Clone(k.static), fileKeys...)
+	k.modTime = info.ModTime()
+	return nil
+}
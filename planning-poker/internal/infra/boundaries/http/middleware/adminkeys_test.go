@@ -0,0 +1,133 @@
+package middleware
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func TestAdminKeyring_Authenticate_RotationWindow(t *testing.T) {
+	rotation := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	newDigest := sha256.Sum256([]byte("new-secret"))
+	clock := &fakeClock{now: rotation.Add(-time.Hour)}
+
+	keyring, err := NewAdminKeyring([]AdminKey{
+		{Name: "alice", Key: "old-secret", Scopes: []string{ScopeRoomsRead}, ExpiresAt: rotation.Add(time.Hour)},
+		{Name: "alice", SHA256: hex.EncodeToString(newDigest[:]), Scopes: []string{ScopeRoomsRead}, NotBefore: rotation},
+	}, "", clock)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	ctx := context.Background()
+
+	tests := []struct {
+		name      string
+		now       time.Time
+		oldActive bool
+		newActive bool
+	}{
+		{name: "before the rotation", now: rotation.Add(-time.Minute), oldActive: true, newActive: false},
+		{name: "during the overlap", now: rotation.Add(time.Minute), oldActive: true, newActive: true},
+		{name: "after the overlap", now: rotation.Add(time.Hour), oldActive: false, newActive: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock.now = tt.now
+			if _, ok := keyring.Authenticate(ctx, "old-secret"); ok != tt.oldActive {
+				t.Errorf("old secret accepted = %v, want %v", ok, tt.oldActive)
+			}
+			key, ok := keyring.Authenticate(ctx, "new-secret")
+			if ok != tt.newActive {
+				t.Errorf("new secret accepted = %v, want %v", ok, tt.newActive)
+			}
+			if ok && key.Name != "alice" {
+				t.Errorf("key name = %q, want alice", key.Name)
+			}
+		})
+	}
+
+	if _, ok := keyring.Authenticate(ctx, ""); ok {
+		t.Error("expected an empty secret to be rejected")
+	}
+}
+
+func TestAdminKeyring_ReloadsFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "admin-keys.json")
+	write := func(content string, modTime time.Time) {
+		t.Helper()
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatalf("failed to write keys: %v", err)
+		}
+		if err := os.Chtimes(path, modTime, modTime); err != nil {
+			t.Fatalf("failed to touch keys: %v", err)
+		}
+	}
+	modTime := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	write(`[{"name": "alice", "key": "alice-key", "scopes": ["rooms:read"]}]`, modTime)
+
+	clock := &fakeClock{now: time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)}
+	keyring, err := NewAdminKeyring([]AdminKey{
+		{Name: DefaultAdminKeyName, Key: "default-key", Scopes: []string{ScopeAll}},
+	}, path, clock)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	ctx := context.Background()
+
+	if _, ok := keyring.Authenticate(ctx, "alice-key"); !ok {
+		t.Fatal("expected the key from the file to be accepted")
+	}
+
+	write(`[{"name": "bob", "key": "bob-key", "scopes": ["clients:kick"]}]`, modTime.Add(time.Minute))
+	if _, ok := keyring.Authenticate(ctx, "bob-key"); ok {
+		t.Error("expected the file not to be checked again before the reload interval")
+	}
+
+	clock.now = clock.now.Add(keysReloadInterval)
+	if _, ok := keyring.Authenticate(ctx, "bob-key"); !ok {
+		t.Error("expected the changed file to be reloaded")
+	}
+	if _, ok := keyring.Authenticate(ctx, "alice-key"); ok {
+		t.Error("expected keys removed from the file to be rejected")
+	}
+	if _, ok := keyring.Authenticate(ctx, "default-key"); !ok {
+		t.Error("expected the configured keys to be kept")
+	}
+
+	write(`not json`, modTime.Add(2*time.Minute))
+	clock.now = clock.now.Add(keysReloadInterval)
+	if _, ok := keyring.Authenticate(ctx, "bob-key"); !ok {
+		t.Error("expected an invalid file to keep the keys loaded last")
+	}
+}
+
+func TestParseAdminKeys_Invalid(t *testing.T) {
+	tests := map[string]string{
+		"no name":        `[{"key": "secret", "scopes": ["*"]}]`,
+		"no secret":      `[{"name": "alice", "scopes": ["*"]}]`,
+		"invalid sha256": `[{"name": "alice", "sha256": "abc", "scopes": ["*"]}]`,
+		"no scopes":      `[{"name": "alice", "key": "secret"}]`,
+		"not an array":   `{"name": "alice"}`,
+	}
+
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := ParseAdminKeys([]byte(data)); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
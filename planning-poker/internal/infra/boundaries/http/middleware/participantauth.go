@@ -0,0 +1,90 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"planning-poker/internal/application/auth"
+	"planning-poker/internal/domain"
+	"strings"
+
+	"github.com/bruno303/go-toolkit/pkg/log"
+	"github.com/gorilla/mux"
+)
+
+type participantIDKey struct{}
+
+// ParticipantMiddleware authenticates the client acting on the room of the
+// request: a bot with the token it was added with, sent as
+// "Authorization: Bot <token>", or a participant of the room with its OIDC
+// token, sent as a bearer token.
+type ParticipantMiddleware struct {
+	hub domain.Hub
+	// verifier checks the OIDC tokens, only bots are accepted when nil
+	verifier auth.TokenVerifier
+	logger   log.Logger
+}
+
+func NewParticipantMiddleware(hub domain.Hub, verifier auth.TokenVerifier) ParticipantMiddleware {
+	return ParticipantMiddleware{
+		hub:      hub,
+		verifier: verifier,
+		logger:   log.NewLogger("participantmiddleware"),
+	}
+}
+
+// ParticipantID returns the client the request was authenticated as.
+func ParticipantID(ctx context.Context) string {
+	id, _ := ctx.Value(participantIDKey{}).(string)
+	return id
+}
+
+func (m ParticipantMiddleware) Handle(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		roomID := mux.Vars(r)["roomID"]
+		scheme, token, _ := strings.Cut(r.Header.Get("Authorization"), " ")
+		token = strings.TrimSpace(token)
+		if token == "" || scheme != "Bot" && (scheme != "Bearer" || m.verifier == nil) {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		room, err := m.hub.LoadRoom(r.Context(), roomID)
+		if errors.Is(err, domain.ErrRoomNotFound) {
+			http.Error(w, "Room not found", http.StatusNotFound)
+			return
+		}
+		if err != nil {
+			m.logger.Error(r.Context(), "Failed to load room "+roomID, err)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+
+		var clientID string
+		if scheme == "Bot" {
+			bot, err := room.AuthenticateBot(token)
+			if err != nil {
+				m.logger.Warn(r.Context(), "Bot token rejected: %v", err)
+				http.Error(w, "Unauthorized", http.StatusUnauthorized)
+				return
+			}
+			clientID = bot.ID
+		} else {
+			identity, err := m.verifier.Verify(r.Context(), token)
+			if err != nil {
+				m.logger.Warn(r.Context(), "Participant token rejected: %v", err)
+				http.Error(w, "Unauthorized", http.StatusUnauthorized)
+				return
+			}
+			// participants join with a connection first, the API acts on
+			// their seat
+			clientID = identity.ClientID()
+			if _, ok := room.FindClient(clientID); !ok {
+				http.Error(w, "Not a participant of the room", http.StatusForbidden)
+				return
+			}
+		}
+
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), participantIDKey{}, clientID)))
+	})
+}
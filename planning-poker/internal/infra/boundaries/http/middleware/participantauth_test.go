@@ -0,0 +1,160 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"planning-poker/internal/application/auth"
+	"planning-poker/internal/domain"
+	"planning-poker/internal/domain/entity"
+	"planning-poker/internal/infra/boundaries/hub/clientcollection"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"go.uber.org/mock/gomock"
+)
+
+func TestParticipantMiddleware_Handle(t *testing.T) {
+	ctx := context.Background()
+	member := entity.Identity{Issuer: "https://issuer", Subject: "alice"}
+	stranger := entity.Identity{Issuer: "https://issuer", Subject: "mallory"}
+
+	room := entity.NewRoomWithID("room123", clientcollection.New())
+	room.NewClient(member.ClientID())
+	credentials, err := room.AddBot(ctx, member.ClientID(), "ci")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name          string
+		roomID        string
+		authorization string
+		setup         func(hub *domain.MockHub, verifier *auth.MockTokenVerifier)
+		wantStatus    int
+		wantClientID  string
+	}{
+		{
+			name:          "bot token",
+			roomID:        "room123",
+			authorization: "Bot " + credentials.Token,
+			setup: func(hub *domain.MockHub, _ *auth.MockTokenVerifier) {
+				hub.EXPECT().LoadRoom(gomock.Any(), "room123").Return(room, nil)
+			},
+			wantStatus:   http.StatusOK,
+			wantClientID: credentials.ClientID,
+		},
+		{
+			name:          "participant token",
+			roomID:        "room123",
+			authorization: "Bearer alice-token",
+			setup: func(hub *domain.MockHub, verifier *auth.MockTokenVerifier) {
+				hub.EXPECT().LoadRoom(gomock.Any(), "room123").Return(room, nil)
+				verifier.EXPECT().Verify(gomock.Any(), "alice-token").Return(member, nil)
+			},
+			wantStatus:   http.StatusOK,
+			wantClientID: member.ClientID(),
+		},
+		{
+			name:          "token of someone outside the room",
+			roomID:        "room123",
+			authorization: "Bearer mallory-token",
+			setup: func(hub *domain.MockHub, verifier *auth.MockTokenVerifier) {
+				hub.EXPECT().LoadRoom(gomock.Any(), "room123").Return(room, nil)
+				verifier.EXPECT().Verify(gomock.Any(), "mallory-token").Return(stranger, nil)
+			},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:          "invalid participant token",
+			roomID:        "room123",
+			authorization: "Bearer expired",
+			setup: func(hub *domain.MockHub, verifier *auth.MockTokenVerifier) {
+				hub.EXPECT().LoadRoom(gomock.Any(), "room123").Return(room, nil)
+				verifier.EXPECT().Verify(gomock.Any(), "expired").Return(entity.Identity{}, auth.ErrInvalidToken)
+			},
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:          "wrong bot token",
+			roomID:        "room123",
+			authorization: "Bot " + credentials.ClientID + ".wrong",
+			setup: func(hub *domain.MockHub, _ *auth.MockTokenVerifier) {
+				hub.EXPECT().LoadRoom(gomock.Any(), "room123").Return(room, nil)
+			},
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:          "bot token of another room",
+			roomID:        "other",
+			authorization: "Bot " + credentials.Token,
+			setup: func(hub *domain.MockHub, _ *auth.MockTokenVerifier) {
+				hub.EXPECT().LoadRoom(gomock.Any(), "other").Return(nil, domain.ErrRoomNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "missing token",
+			roomID:     "room123",
+			setup:      func(*domain.MockHub, *auth.MockTokenVerifier) {},
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:          "unknown scheme",
+			roomID:        "room123",
+			authorization: "Basic dXNlcjpwYXNz",
+			setup:         func(*domain.MockHub, *auth.MockTokenVerifier) {},
+			wantStatus:    http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			hub := domain.NewMockHub(ctrl)
+			verifier := auth.NewMockTokenVerifier(ctrl)
+			tt.setup(hub, verifier)
+
+			var clientID string
+			router := mux.NewRouter()
+			router.Handle("/planning/{roomID}/reveal", NewParticipantMiddleware(hub, verifier).Handle(
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					clientID = ParticipantID(r.Context())
+					w.WriteHeader(http.StatusOK)
+				}),
+			))
+
+			req := httptest.NewRequest(http.MethodPost, "/planning/"+tt.roomID+"/reveal", nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status code = %v, want %v", rec.Code, tt.wantStatus)
+			}
+			if clientID != tt.wantClientID {
+				t.Errorf("client ID = %q, want %q", clientID, tt.wantClientID)
+			}
+		})
+	}
+}
+
+func TestParticipantMiddleware_Handle_OnlyBotsWithoutVerifier(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	hub := domain.NewMockHub(ctrl)
+
+	handler := NewParticipantMiddleware(hub, nil).Handle(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		t.Error("expected the request to be rejected")
+	}))
+
+	req := httptest.NewRequest(http.MethodPost, "/planning/room123/reveal", nil)
+	req.Header.Set("Authorization", "Bearer token")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("status code = %v, want %v", rec.Code, http.StatusUnauthorized)
+	}
+}
@@ -0,0 +1,85 @@
+package middleware
+
+import (
+	"math"
+	"net"
+	"net/http"
+	"planning-poker/internal/application/planningpoker/metric"
+	"planning-poker/internal/application/ratelimit"
+	"strconv"
+	"strings"
+
+	"github.com/bruno303/go-toolkit/pkg/log"
+)
+
+// RateLimitMiddleware throttles the requests of each IP address. Its name
+// keeps the buckets of the endpoints it guards apart from other ones.
+type RateLimitMiddleware struct {
+	name    string
+	limiter ratelimit.Limiter
+	limit   ratelimit.Limit
+	metric  metric.PlanningPokerMetric
+	// trust the address appended to X-Forwarded-For by a reverse proxy
+	trustForwardedFor bool
+	logger            log.Logger
+}
+
+func NewRateLimitMiddleware(
+	name string,
+	limiter ratelimit.Limiter,
+	limit ratelimit.Limit,
+	metric metric.PlanningPokerMetric,
+	trustForwardedFor bool,
+) RateLimitMiddleware {
+	return RateLimitMiddleware{
+		name:              name,
+		limiter:           limiter,
+		limit:             limit,
+		metric:            metric,
+		trustForwardedFor: trustForwardedFor,
+		logger:            log.NewLogger("ratelimitmiddleware"),
+	}
+}
+
+func (m *RateLimitMiddleware) Handle(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// preflight requests are answered without reaching the endpoint
+		if r.Method == http.MethodOptions {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		decision, err := m.limiter.Allow(r.Context(), "ip:"+m.name+":"+ClientIP(r, m.trustForwardedFor), m.limit)
+		if err != nil {
+			m.logger.Warn(r.Context(), "Failed to rate limit request: %v", err)
+			next.ServeHTTP(w, r)
+			return
+		}
+		if !decision.Allowed {
+			m.metric.IncrementThrottled(r.Context())
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(decision.RetryAfter.Seconds()))))
+			http.Error(w, "Too Many Requests", http.StatusTooManyRequests)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// ClientIP returns the address a request came from. With trustForwardedFor
+// it is the one appended to X-Forwarded-For by a reverse proxy.
+func ClientIP(r *http.Request, trustForwardedFor bool) string {
+	if trustForwardedFor {
+		if forwarded := r.Header.Get("X-Forwarded-For"); forwarded != "" {
+			// the last address is the one the proxy saw, earlier ones are
+			// sent by the client and can be forged
+			addresses := strings.Split(forwarded, ",")
+			return strings.TrimSpace(addresses[len(addresses)-1])
+		}
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
@@ -0,0 +1,109 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"planning-poker/internal/application/planningpoker/metric"
+	"planning-poker/internal/application/ratelimit"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+)
+
+func TestRateLimitMiddleware_Handle(t *testing.T) {
+	limit := ratelimit.Limit{Rate: 30, Burst: 10}
+
+	tests := []struct {
+		name              string
+		trustForwardedFor bool
+		forwardedFor      string
+		expectedKey       string
+		decision          ratelimit.Decision
+		err               error
+		expectedStatus    int
+		expectedRetry     string
+	}{
+		{
+			name:           "allowed",
+			expectedKey:    "ip:create-room:192.0.2.1",
+			decision:       ratelimit.Decision{Allowed: true},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "throttled",
+			expectedKey:    "ip:create-room:192.0.2.1",
+			decision:       ratelimit.Decision{RetryAfter: 1500 * time.Millisecond},
+			expectedStatus: http.StatusTooManyRequests,
+			expectedRetry:  "2",
+		},
+		{
+			name:           "limiter failure lets the request through",
+			expectedKey:    "ip:create-room:192.0.2.1",
+			err:            errors.New("redis down"),
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "forwarded for is ignored by default",
+			forwardedFor:   "203.0.113.7",
+			expectedKey:    "ip:create-room:192.0.2.1",
+			decision:       ratelimit.Decision{Allowed: true},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:              "forwarded for of a trusted proxy",
+			trustForwardedFor: true,
+			forwardedFor:      "198.51.100.1, 203.0.113.7",
+			expectedKey:       "ip:create-room:203.0.113.7",
+			decision:          ratelimit.Decision{Allowed: true},
+			expectedStatus:    http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			limiter := ratelimit.NewMockLimiter(ctrl)
+			limiter.EXPECT().Allow(gomock.Any(), tt.expectedKey, limit).Return(tt.decision, tt.err)
+
+			middleware := NewRateLimitMiddleware("create-room", limiter, limit, metric.NewPlanningPokerMetric(), tt.trustForwardedFor)
+			handler := middleware.Handle(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			req := httptest.NewRequest(http.MethodPost, "/planning/rooms", nil)
+			req.RemoteAddr = "192.0.2.1:51234"
+			if tt.forwardedFor != "" {
+				req.Header.Set("X-Forwarded-For", tt.forwardedFor)
+			}
+			rec := httptest.NewRecorder()
+
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.expectedStatus {
+				t.Errorf("status = %v, want %v", rec.Code, tt.expectedStatus)
+			}
+			if got := rec.Header().Get("Retry-After"); got != tt.expectedRetry {
+				t.Errorf("Retry-After = %q, want %q", got, tt.expectedRetry)
+			}
+		})
+	}
+}
+
+func TestRateLimitMiddleware_Handle_SkipsPreflight(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	limiter := ratelimit.NewMockLimiter(ctrl)
+	middleware := NewRateLimitMiddleware("admin", limiter, ratelimit.Limit{Rate: 1, Burst: 1}, metric.NewPlanningPokerMetric(), false)
+
+	handler := middleware.Handle(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodOptions, "/admin/rooms", nil).WithContext(context.Background()))
+
+	if rec.Code != http.StatusNoContent {
+		t.Errorf("status = %v, want %v", rec.Code, http.StatusNoContent)
+	}
+}
@@ -0,0 +1,21 @@
+package http
+
+import (
+	"net/http"
+	"planning-poker/internal/infra/boundaries/http/middleware"
+)
+
+// rateLimitedAPI throttles the requests of each IP address to an API before
+// they reach it, authentication included.
+type rateLimitedAPI struct {
+	API
+	rateLimitMiddleware middleware.RateLimitMiddleware
+}
+
+func WithRateLimit(api API, rateLimitMiddleware middleware.RateLimitMiddleware) API {
+	return rateLimitedAPI{API: api, rateLimitMiddleware: rateLimitMiddleware}
+}
+
+func (api rateLimitedAPI) Handle() http.Handler {
+	return api.rateLimitMiddleware.Handle(api.API.Handle())
+}
@@ -0,0 +1,174 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"planning-poker/internal/application/planningpoker/report"
+	"planning-poker/internal/domain"
+	"planning-poker/internal/domain/domainerror"
+	"planning-poker/internal/infra/boundaries/http/middleware"
+	"planning-poker/internal/infra/bus"
+	"sync"
+
+	"github.com/bruno303/go-toolkit/pkg/log"
+	"github.com/gorilla/mux"
+)
+
+// maxRoomCommandBodySize bounds the payloads, which are as small as the
+// messages of the websocket
+const maxRoomCommandBodySize = 64 << 10
+
+type (
+	// RoomCommandAPI runs a use case of a room as the client authenticated by
+	// the participant middleware, for bots and tools that do not keep a
+	// connection open. The body is the payload of the websocket message of
+	// the use case, decoded with the same rules.
+	RoomCommandAPI[P any] struct {
+		endpoint    string
+		method      string
+		participant middleware.ParticipantMiddleware
+		execute     func(w http.ResponseWriter, r *http.Request, cmd roomCommand[P]) error
+		logger      log.Logger
+	}
+	roomCommand[P any] struct {
+		RoomID   string
+		SenderID string
+		Payload  P
+	}
+
+	// RoomCommandError carries the code of the error, the same as in the
+	// error messages of the websocket.
+	RoomCommandError struct {
+		Error string `json:"error"`
+		Code  string `json:"code"`
+	}
+
+	// replyRecorder is the bus of a request, keeping the message the use
+	// cases answering the sender alone send to it.
+	replyRecorder struct {
+		roomID  string
+		mu      sync.Mutex
+		message any
+	}
+)
+
+var (
+	_ API        = (*RoomCommandAPI[bus.NoPayload])(nil)
+	_ domain.Bus = (*replyRecorder)(nil)
+)
+
+func newRoomCommandAPI[P any](
+	endpoint, method string,
+	participant middleware.ParticipantMiddleware,
+	execute func(w http.ResponseWriter, r *http.Request, cmd roomCommand[P]) error,
+) *RoomCommandAPI[P] {
+	return &RoomCommandAPI[P]{
+		endpoint:    endpoint,
+		method:      method,
+		participant: participant,
+		execute:     execute,
+		logger:      log.NewLogger("planningpoker.api.roomcommand"),
+	}
+}
+
+func (api *RoomCommandAPI[P]) Endpoint() string {
+	return api.endpoint
+}
+
+func (api *RoomCommandAPI[P]) Methods() []string {
+	return []string{api.method}
+}
+
+func (api *RoomCommandAPI[P]) Handle() http.Handler {
+	return api.participant.Handle(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		cmd := roomCommand[P]{
+			RoomID:   mux.Vars(r)["roomID"],
+			SenderID: middleware.ParticipantID(r.Context()),
+		}
+
+		data, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxRoomCommandBodySize))
+		if err != nil {
+			SendJsonError(w, http.StatusRequestEntityTooLarge, err)
+			return
+		}
+		if err := bus.DecodePayload(data, &cmd.Payload); err != nil {
+			sendRoomCommandError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		if err := api.execute(w, r, cmd); err != nil {
+			status := roomCommandStatus(err)
+			if status == http.StatusInternalServerError {
+				api.logger.Error(r.Context(), fmt.Sprintf("Failed to run %s %s for client %s", r.Method, r.URL.Path, cmd.SenderID), err)
+			}
+			sendRoomCommandError(w, status, err)
+		}
+	}))
+}
+
+// roomCommandStatus answers the errors of the use cases with the status code
+// of their cause, the message of unexpected errors is not disclosed.
+func roomCommandStatus(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrRoomNotFound), errors.Is(err, domain.ErrClientNotFound), errors.Is(err, domain.ErrWebhookNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, domain.ErrPermissionDenied):
+		return http.StatusForbidden
+	case errors.Is(err, domain.ErrVersionConflict):
+		return http.StatusConflict
+	case errors.Is(err, report.ErrUnsupportedFormat), domainerror.CodeOf(err) != domainerror.CodeInternal:
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func sendRoomCommandError(w http.ResponseWriter, status int, err error) {
+	message := err.Error()
+	if status == http.StatusInternalServerError {
+		message = "Failed to run the command"
+	}
+	SendJsonResponse(w, status, RoomCommandError{Error: message, Code: string(domainerror.CodeOf(err))})
+}
+
+// noContent answers a use case that has no result.
+func noContent(w http.ResponseWriter, err error) error {
+	if err == nil {
+		w.WriteHeader(http.StatusNoContent)
+	}
+	return err
+}
+
+func newReplyRecorder(roomID string) *replyRecorder {
+	return &replyRecorder{roomID: roomID}
+}
+
+// reply returns the message sent to the recorder, when it is a T.
+func reply[T any](recorder *replyRecorder) (T, bool) {
+	recorder.mu.Lock()
+	defer recorder.mu.Unlock()
+	message, ok := recorder.message.(T)
+	return message, ok
+}
+
+func (b *replyRecorder) Send(_ context.Context, message any) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.message = message
+	return nil
+}
+
+func (b *replyRecorder) Close() error {
+	return nil
+}
+
+func (b *replyRecorder) Detach() {}
+
+func (b *replyRecorder) Listen(context.Context) {}
+
+func (b *replyRecorder) RoomID() string {
+	return b.roomID
+}
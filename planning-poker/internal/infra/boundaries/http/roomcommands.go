@@ -0,0 +1,891 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"planning-poker/internal/application/planningpoker/usecase"
+	"planning-poker/internal/application/planningpoker/usecase/dto"
+	"planning-poker/internal/application/webhook"
+	"planning-poker/internal/domain"
+	"planning-poker/internal/infra/boundaries/http/middleware"
+	"planning-poker/internal/infra/bus"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/samber/lo"
+)
+
+type (
+	AddBotRequest struct {
+		Name string `json:"name"`
+	}
+	AddBotResponse struct {
+		ClientID string `json:"clientId"`
+		// Token is only returned once, send it as "Authorization: Bot <token>"
+		Token string `json:"token"`
+	}
+	CreateInviteResponse struct {
+		Token     string    `json:"token"`
+		ExpiresAt time.Time `json:"expiresAt"`
+	}
+	WebhookRequest struct {
+		URL string `json:"url"`
+		// Events filters the events sent, every event when empty
+		Events []string `json:"events,omitempty"`
+	}
+	WebhookResponse struct {
+		ID  string `json:"id"`
+		URL string `json:"url"`
+		// Events is empty for subscriptions to every event
+		Events    []string  `json:"events"`
+		CreatedAt time.Time `json:"createdAt"`
+		// Secret is only returned when the webhook is created
+		Secret string `json:"secret,omitempty"`
+	}
+)
+
+// NewRoomCommandAPIs returns the endpoints of the use cases a participant runs
+// on a room. Joining and leaving stay with the connections.
+func NewRoomCommandAPIs(usecases usecase.UseCasesFacade, participant middleware.ParticipantMiddleware) []API {
+	return []API{
+		NewUpdateNameAPI(usecases.UpdateName, participant),
+		NewVoteAPI(usecases.Vote, participant),
+		NewRevealAPI(usecases.Reveal, participant),
+		NewResetAPI(usecases.Reset, participant),
+		NewToggleSpectatorAPI(usecases.ToggleSpectator, participant),
+		NewToggleParticipantOwnerAPI(usecases.ToggleOwner, participant),
+		NewUpdateStoryAPI(usecases.UpdateStory, participant),
+		NewNewVotingAPI(usecases.NewVoting, participant),
+		NewVoteAgainAPI(usecases.VoteAgain, participant),
+		NewToggleBacklogModeAPI(usecases.ToggleBacklogMode, participant),
+		NewAddStoryAPI(usecases.AddStory, participant),
+		NewRemoveStoryAPI(usecases.RemoveStory, participant),
+		NewAdvanceStoryAPI(usecases.AdvanceStory, participant),
+		NewPrevStoryAPI(usecases.PrevStory, participant),
+		NewChangeDeckAPI(usecases.ChangeDeck, participant),
+		NewChangeConsensusRuleAPI(usecases.ChangeConsensusRule, participant),
+		NewStartVotingTimerAPI(usecases.StartVotingTimer, participant),
+		NewCancelVotingTimerAPI(usecases.CancelVotingTimer, participant),
+		NewExportReportAPI(usecases.ExportReport, participant),
+		NewSetPasscodeAPI(usecases.SetPasscode, participant),
+		NewCreateInviteAPI(usecases.CreateInvite, participant),
+		NewRevokeInvitesAPI(usecases.RevokeInvites, participant),
+		NewChangePermissionAPI(usecases.ChangePermission, participant),
+		NewGetParticipantRoomStateAPI(usecases.Resync, participant),
+		NewAddBotAPI(usecases.AddBot, participant),
+		NewRemoveBotAPI(usecases.RemoveBot, participant),
+		NewCreateRoomWebhookAPI(usecases.CreateWebhook, participant),
+		NewListRoomWebhooksAPI(usecases.ListWebhooks, participant),
+		NewDeleteRoomWebhookAPI(usecases.DeleteWebhook, participant),
+	}
+}
+
+// @Summary Rename
+// @Description Renames the authenticated participant.
+// @Tags room commands
+// @Accept json
+// @Produce json
+// @Param roomID path string true "Room ID"
+// @Param payload body bus.UpdateNamePayload true "New name"
+// @Success 204 "Done"
+// @Failure 400 {object} RoomCommandError
+// @Failure 401 {string} string "Missing or invalid token"
+// @Failure 403 {object} RoomCommandError "Not a participant of the room or not allowed by its role"
+// @Failure 404 {object} RoomCommandError "Room or participant not found"
+// @Failure 409 {object} RoomCommandError "The room changed concurrently, retry"
+// @Failure 429 {string} string "Too many requests"
+// @Failure 500 {object} RoomCommandError
+// @Security ParticipantAuth
+// @Router /planning/{roomID}/name [put]
+func NewUpdateNameAPI(uc usecase.UseCase[usecase.UpdateNameCommand], participant middleware.ParticipantMiddleware) *RoomCommandAPI[bus.UpdateNamePayload] {
+	return newRoomCommandAPI("/planning/{roomID}/name", http.MethodPut, participant,
+		func(w http.ResponseWriter, r *http.Request, cmd roomCommand[bus.UpdateNamePayload]) error {
+			return noContent(w, uc.Execute(r.Context(), usecase.UpdateNameCommand{
+				RoomID:   cmd.RoomID,
+				SenderID: cmd.SenderID,
+				Username: cmd.Payload.Username,
+			}))
+		})
+}
+
+// @Summary Vote
+// @Description Casts the vote of the authenticated participant, an empty vote clears it. Bots do not vote.
+// @Tags room commands
+// @Accept json
+// @Produce json
+// @Param roomID path string true "Room ID"
+// @Param payload body bus.VotePayload true "Vote, one of the cards of the deck"
+// @Success 204 "Done"
+// @Failure 400 {object} RoomCommandError
+// @Failure 401 {string} string "Missing or invalid token"
+// @Failure 403 {object} RoomCommandError "Not a participant of the room or not allowed by its role"
+// @Failure 404 {object} RoomCommandError "Room or participant not found"
+// @Failure 409 {object} RoomCommandError "The room changed concurrently, retry"
+// @Failure 429 {string} string "Too many requests"
+// @Failure 500 {object} RoomCommandError
+// @Security ParticipantAuth
+// @Router /planning/{roomID}/vote [put]
+func NewVoteAPI(uc usecase.UseCase[usecase.VoteCommand], participant middleware.ParticipantMiddleware) *RoomCommandAPI[bus.VotePayload] {
+	return newRoomCommandAPI("/planning/{roomID}/vote", http.MethodPut, participant,
+		func(w http.ResponseWriter, r *http.Request, cmd roomCommand[bus.VotePayload]) error {
+			return noContent(w, uc.Execute(r.Context(), usecase.VoteCommand{
+				RoomID:   cmd.RoomID,
+				SenderID: cmd.SenderID,
+				Vote:     lo.ToPtr(cmd.Payload.Vote),
+			}))
+		})
+}
+
+// @Summary Reveal or hide the votes
+// @Description Reveals the votes, or hides them when they are revealed.
+// @Tags room commands
+// @Produce json
+// @Param roomID path string true "Room ID"
+// @Success 204 "Done"
+// @Failure 400 {object} RoomCommandError
+// @Failure 401 {string} string "Missing or invalid token"
+// @Failure 403 {object} RoomCommandError "Not a participant of the room or not allowed by its role"
+// @Failure 404 {object} RoomCommandError "Room or participant not found"
+// @Failure 409 {object} RoomCommandError "The room changed concurrently, retry"
+// @Failure 429 {string} string "Too many requests"
+// @Failure 500 {object} RoomCommandError
+// @Security ParticipantAuth
+// @Router /planning/{roomID}/reveal [post]
+func NewRevealAPI(uc usecase.UseCase[usecase.RevealCommand], participant middleware.ParticipantMiddleware) *RoomCommandAPI[bus.NoPayload] {
+	return newRoomCommandAPI("/planning/{roomID}/reveal", http.MethodPost, participant,
+		func(w http.ResponseWriter, r *http.Request, cmd roomCommand[bus.NoPayload]) error {
+			return noContent(w, uc.Execute(r.Context(), usecase.RevealCommand{RoomID: cmd.RoomID, SenderID: cmd.SenderID}))
+		})
+}
+
+// @Summary Clear the votes
+// @Description Clears the votes of the current round.
+// @Tags room commands
+// @Produce json
+// @Param roomID path string true "Room ID"
+// @Success 204 "Done"
+// @Failure 400 {object} RoomCommandError
+// @Failure 401 {string} string "Missing or invalid token"
+// @Failure 403 {object} RoomCommandError "Not a participant of the room or not allowed by its role"
+// @Failure 404 {object} RoomCommandError "Room or participant not found"
+// @Failure 409 {object} RoomCommandError "The room changed concurrently, retry"
+// @Failure 429 {string} string "Too many requests"
+// @Failure 500 {object} RoomCommandError
+// @Security ParticipantAuth
+// @Router /planning/{roomID}/reset [post]
+func NewResetAPI(uc usecase.UseCase[usecase.ResetCommand], participant middleware.ParticipantMiddleware) *RoomCommandAPI[bus.NoPayload] {
+	return newRoomCommandAPI("/planning/{roomID}/reset", http.MethodPost, participant,
+		func(w http.ResponseWriter, r *http.Request, cmd roomCommand[bus.NoPayload]) error {
+			return noContent(w, uc.Execute(r.Context(), usecase.ResetCommand{RoomID: cmd.RoomID, SenderID: cmd.SenderID}))
+		})
+}
+
+// @Summary Toggle spectator
+// @Description Turns a participant into a spectator or back.
+// @Tags room commands
+// @Produce json
+// @Param roomID path string true "Room ID"
+// @Param clientID path string true "Client ID of the participant"
+// @Success 204 "Done"
+// @Failure 400 {object} RoomCommandError
+// @Failure 401 {string} string "Missing or invalid token"
+// @Failure 403 {object} RoomCommandError "Not a participant of the room or not allowed by its role"
+// @Failure 404 {object} RoomCommandError "Room or participant not found"
+// @Failure 409 {object} RoomCommandError "The room changed concurrently, retry"
+// @Failure 429 {string} string "Too many requests"
+// @Failure 500 {object} RoomCommandError
+// @Security ParticipantAuth
+// @Router /planning/{roomID}/participants/{clientID}/spectator [post]
+func NewToggleSpectatorAPI(uc usecase.UseCase[usecase.ToggleSpectatorCommand], participant middleware.ParticipantMiddleware) *RoomCommandAPI[bus.NoPayload] {
+	return newRoomCommandAPI("/planning/{roomID}/participants/{clientID}/spectator", http.MethodPost, participant,
+		func(w http.ResponseWriter, r *http.Request, cmd roomCommand[bus.NoPayload]) error {
+			return noContent(w, uc.Execute(r.Context(), usecase.ToggleSpectatorCommand{
+				RoomID:         cmd.RoomID,
+				SenderID:       cmd.SenderID,
+				TargetClientID: mux.Vars(r)["clientID"],
+			}))
+		})
+}
+
+// @Summary Toggle owner
+// @Description Grants or revokes the ownership of the room. Bots cannot.
+// @Tags room commands
+// @Produce json
+// @Param roomID path string true "Room ID"
+// @Param clientID path string true "Client ID of the participant"
+// @Success 204 "Done"
+// @Failure 400 {object} RoomCommandError
+// @Failure 401 {string} string "Missing or invalid token"
+// @Failure 403 {object} RoomCommandError "Not a participant of the room or not allowed by its role"
+// @Failure 404 {object} RoomCommandError "Room or participant not found"
+// @Failure 409 {object} RoomCommandError "The room changed concurrently, retry"
+// @Failure 429 {string} string "Too many requests"
+// @Failure 500 {object} RoomCommandError
+// @Security ParticipantAuth
+// @Router /planning/{roomID}/participants/{clientID}/owner [post]
+func NewToggleParticipantOwnerAPI(uc usecase.UseCase[usecase.ToggleOwnerCommand], participant middleware.ParticipantMiddleware) *RoomCommandAPI[bus.NoPayload] {
+	return newRoomCommandAPI("/planning/{roomID}/participants/{clientID}/owner", http.MethodPost, participant,
+		func(w http.ResponseWriter, r *http.Request, cmd roomCommand[bus.NoPayload]) error {
+			return noContent(w, uc.Execute(r.Context(), usecase.ToggleOwnerCommand{
+				RoomID:         cmd.RoomID,
+				SenderID:       cmd.SenderID,
+				TargetClientID: mux.Vars(r)["clientID"],
+			}))
+		})
+}
+
+// @Summary Rename the current story
+// @Description Renames the current story.
+// @Tags room commands
+// @Accept json
+// @Produce json
+// @Param roomID path string true "Room ID"
+// @Param payload body bus.UpdateStoryPayload true "Name of the story"
+// @Success 204 "Done"
+// @Failure 400 {object} RoomCommandError
+// @Failure 401 {string} string "Missing or invalid token"
+// @Failure 403 {object} RoomCommandError "Not a participant of the room or not allowed by its role"
+// @Failure 404 {object} RoomCommandError "Room or participant not found"
+// @Failure 409 {object} RoomCommandError "The room changed concurrently, retry"
+// @Failure 429 {string} string "Too many requests"
+// @Failure 500 {object} RoomCommandError
+// @Security ParticipantAuth
+// @Router /planning/{roomID}/story [put]
+func NewUpdateStoryAPI(uc usecase.UseCase[usecase.UpdateStoryCommand], participant middleware.ParticipantMiddleware) *RoomCommandAPI[bus.UpdateStoryPayload] {
+	return newRoomCommandAPI("/planning/{roomID}/story", http.MethodPut, participant,
+		func(w http.ResponseWriter, r *http.Request, cmd roomCommand[bus.UpdateStoryPayload]) error {
+			return noContent(w, uc.Execute(r.Context(), usecase.UpdateStoryCommand{
+				RoomID:   cmd.RoomID,
+				SenderID: cmd.SenderID,
+				Story:    cmd.Payload.Story,
+			}))
+		})
+}
+
+// @Summary Start a new voting round
+// @Description Starts a new voting round, clearing the votes and the story.
+// @Tags room commands
+// @Produce json
+// @Param roomID path string true "Room ID"
+// @Success 204 "Done"
+// @Failure 400 {object} RoomCommandError
+// @Failure 401 {string} string "Missing or invalid token"
+// @Failure 403 {object} RoomCommandError "Not a participant of the room or not allowed by its role"
+// @Failure 404 {object} RoomCommandError "Room or participant not found"
+// @Failure 409 {object} RoomCommandError "The room changed concurrently, retry"
+// @Failure 429 {string} string "Too many requests"
+// @Failure 500 {object} RoomCommandError
+// @Security ParticipantAuth
+// @Router /planning/{roomID}/new-voting [post]
+func NewNewVotingAPI(uc usecase.UseCase[usecase.NewVotingCommand], participant middleware.ParticipantMiddleware) *RoomCommandAPI[bus.NoPayload] {
+	return newRoomCommandAPI("/planning/{roomID}/new-voting", http.MethodPost, participant,
+		func(w http.ResponseWriter, r *http.Request, cmd roomCommand[bus.NoPayload]) error {
+			return noContent(w, uc.Execute(r.Context(), usecase.NewVotingCommand{RoomID: cmd.RoomID, SenderID: cmd.SenderID}))
+		})
+}
+
+// @Summary Vote again
+// @Description Votes the current story again.
+// @Tags room commands
+// @Produce json
+// @Param roomID path string true "Room ID"
+// @Success 204 "Done"
+// @Failure 400 {object} RoomCommandError
+// @Failure 401 {string} string "Missing or invalid token"
+// @Failure 403 {object} RoomCommandError "Not a participant of the room or not allowed by its role"
+// @Failure 404 {object} RoomCommandError "Room or participant not found"
+// @Failure 409 {object} RoomCommandError "The room changed concurrently, retry"
+// @Failure 429 {string} string "Too many requests"
+// @Failure 500 {object} RoomCommandError
+// @Security ParticipantAuth
+// @Router /planning/{roomID}/vote-again [post]
+func NewVoteAgainAPI(uc usecase.UseCase[usecase.VoteAgainCommand], participant middleware.ParticipantMiddleware) *RoomCommandAPI[bus.NoPayload] {
+	return newRoomCommandAPI("/planning/{roomID}/vote-again", http.MethodPost, participant,
+		func(w http.ResponseWriter, r *http.Request, cmd roomCommand[bus.NoPayload]) error {
+			return noContent(w, uc.Execute(r.Context(), usecase.VoteAgainCommand{RoomID: cmd.RoomID, SenderID: cmd.SenderID}))
+		})
+}
+
+// @Summary Toggle backlog mode
+// @Description Switches the backlog mode on or off.
+// @Tags room commands
+// @Produce json
+// @Param roomID path string true "Room ID"
+// @Success 204 "Done"
+// @Failure 400 {object} RoomCommandError
+// @Failure 401 {string} string "Missing or invalid token"
+// @Failure 403 {object} RoomCommandError "Not a participant of the room or not allowed by its role"
+// @Failure 404 {object} RoomCommandError "Room or participant not found"
+// @Failure 409 {object} RoomCommandError "The room changed concurrently, retry"
+// @Failure 429 {string} string "Too many requests"
+// @Failure 500 {object} RoomCommandError
+// @Security ParticipantAuth
+// @Router /planning/{roomID}/backlog-mode [post]
+func NewToggleBacklogModeAPI(uc usecase.UseCase[usecase.ToggleBacklogModeCommand], participant middleware.ParticipantMiddleware) *RoomCommandAPI[bus.NoPayload] {
+	return newRoomCommandAPI("/planning/{roomID}/backlog-mode", http.MethodPost, participant,
+		func(w http.ResponseWriter, r *http.Request, cmd roomCommand[bus.NoPayload]) error {
+			return noContent(w, uc.Execute(r.Context(), usecase.ToggleBacklogModeCommand{RoomID: cmd.RoomID, SenderID: cmd.SenderID}))
+		})
+}
+
+// @Summary Add a story
+// @Description Adds a story to the end of the backlog.
+// @Tags room commands
+// @Accept json
+// @Produce json
+// @Param roomID path string true "Room ID"
+// @Param payload body bus.AddStoryPayload true "Name of the story"
+// @Success 204 "Done"
+// @Failure 400 {object} RoomCommandError
+// @Failure 401 {string} string "Missing or invalid token"
+// @Failure 403 {object} RoomCommandError "Not a participant of the room or not allowed by its role"
+// @Failure 404 {object} RoomCommandError "Room or participant not found"
+// @Failure 409 {object} RoomCommandError "The room changed concurrently, retry"
+// @Failure 429 {string} string "Too many requests"
+// @Failure 500 {object} RoomCommandError
+// @Security ParticipantAuth
+// @Router /planning/{roomID}/stories [post]
+func NewAddStoryAPI(uc usecase.UseCase[usecase.AddStoryCommand], participant middleware.ParticipantMiddleware) *RoomCommandAPI[bus.AddStoryPayload] {
+	return newRoomCommandAPI("/planning/{roomID}/stories", http.MethodPost, participant,
+		func(w http.ResponseWriter, r *http.Request, cmd roomCommand[bus.AddStoryPayload]) error {
+			return noContent(w, uc.Execute(r.Context(), usecase.AddStoryCommand{
+				RoomID:    cmd.RoomID,
+				SenderID:  cmd.SenderID,
+				StoryName: cmd.Payload.Story,
+			}))
+		})
+}
+
+// @Summary Remove a story
+// @Description Removes a story from the backlog.
+// @Tags room commands
+// @Produce json
+// @Param roomID path string true "Room ID"
+// @Param index path int true "Index of the story in the backlog"
+// @Success 204 "Done"
+// @Failure 400 {object} RoomCommandError
+// @Failure 401 {string} string "Missing or invalid token"
+// @Failure 403 {object} RoomCommandError "Not a participant of the room or not allowed by its role"
+// @Failure 404 {object} RoomCommandError "Room or participant not found"
+// @Failure 409 {object} RoomCommandError "The room changed concurrently, retry"
+// @Failure 429 {string} string "Too many requests"
+// @Failure 500 {object} RoomCommandError
+// @Security ParticipantAuth
+// @Router /planning/{roomID}/stories/{index} [delete]
+func NewRemoveStoryAPI(uc usecase.UseCase[usecase.RemoveStoryCommand], participant middleware.ParticipantMiddleware) *RoomCommandAPI[bus.NoPayload] {
+	return newRoomCommandAPI("/planning/{roomID}/stories/{index}", http.MethodDelete, participant,
+		func(w http.ResponseWriter, r *http.Request, cmd roomCommand[bus.NoPayload]) error {
+			index, err := strconv.Atoi(mux.Vars(r)["index"])
+			if err != nil {
+				return fmt.Errorf("story index must be a number: %w", domain.ErrInvalidPayload)
+			}
+			return noContent(w, uc.Execute(r.Context(), usecase.RemoveStoryCommand{
+				RoomID:     cmd.RoomID,
+				SenderID:   cmd.SenderID,
+				StoryIndex: index,
+			}))
+		})
+}
+
+// @Summary Next story
+// @Description Moves to the next story of the backlog.
+// @Tags room commands
+// @Produce json
+// @Param roomID path string true "Room ID"
+// @Success 204 "Done"
+// @Failure 400 {object} RoomCommandError
+// @Failure 401 {string} string "Missing or invalid token"
+// @Failure 403 {object} RoomCommandError "Not a participant of the room or not allowed by its role"
+// @Failure 404 {object} RoomCommandError "Room or participant not found"
+// @Failure 409 {object} RoomCommandError "The room changed concurrently, retry"
+// @Failure 429 {string} string "Too many requests"
+// @Failure 500 {object} RoomCommandError
+// @Security ParticipantAuth
+// @Router /planning/{roomID}/stories/next [post]
+func NewAdvanceStoryAPI(uc usecase.UseCase[usecase.AdvanceStoryCommand], participant middleware.ParticipantMiddleware) *RoomCommandAPI[bus.NoPayload] {
+	return newRoomCommandAPI("/planning/{roomID}/stories/next", http.MethodPost, participant,
+		func(w http.ResponseWriter, r *http.Request, cmd roomCommand[bus.NoPayload]) error {
+			return noContent(w, uc.Execute(r.Context(), usecase.AdvanceStoryCommand{RoomID: cmd.RoomID, SenderID: cmd.SenderID}))
+		})
+}
+
+// @Summary Previous story
+// @Description Moves back to the previous story of the backlog.
+// @Tags room commands
+// @Produce json
+// @Param roomID path string true "Room ID"
+// @Success 204 "Done"
+// @Failure 400 {object} RoomCommandError
+// @Failure 401 {string} string "Missing or invalid token"
+// @Failure 403 {object} RoomCommandError "Not a participant of the room or not allowed by its role"
+// @Failure 404 {object} RoomCommandError "Room or participant not found"
+// @Failure 409 {object} RoomCommandError "The room changed concurrently, retry"
+// @Failure 429 {string} string "Too many requests"
+// @Failure 500 {object} RoomCommandError
+// @Security ParticipantAuth
+// @Router /planning/{roomID}/stories/previous [post]
+func NewPrevStoryAPI(uc usecase.UseCase[usecase.PrevStoryCommand], participant middleware.ParticipantMiddleware) *RoomCommandAPI[bus.NoPayload] {
+	return newRoomCommandAPI("/planning/{roomID}/stories/previous", http.MethodPost, participant,
+		func(w http.ResponseWriter, r *http.Request, cmd roomCommand[bus.NoPayload]) error {
+			return noContent(w, uc.Execute(r.Context(), usecase.PrevStoryCommand{RoomID: cmd.RoomID, SenderID: cmd.SenderID}))
+		})
+}
+
+// @Summary Change the deck
+// @Description Changes the cards of the room: a built-in deck by name, the default one without a name, or the cards of a custom deck.
+// @Tags room commands
+// @Accept json
+// @Produce json
+// @Param roomID path string true "Room ID"
+// @Param payload body bus.ChangeDeckPayload true "Deck"
+// @Success 204 "Done"
+// @Failure 400 {object} RoomCommandError
+// @Failure 401 {string} string "Missing or invalid token"
+// @Failure 403 {object} RoomCommandError "Not a participant of the room or not allowed by its role"
+// @Failure 404 {object} RoomCommandError "Room or participant not found"
+// @Failure 409 {object} RoomCommandError "The room changed concurrently, retry"
+// @Failure 429 {string} string "Too many requests"
+// @Failure 500 {object} RoomCommandError
+// @Security ParticipantAuth
+// @Router /planning/{roomID}/deck [put]
+func NewChangeDeckAPI(uc usecase.UseCase[usecase.ChangeDeckCommand], participant middleware.ParticipantMiddleware) *RoomCommandAPI[bus.ChangeDeckPayload] {
+	return newRoomCommandAPI("/planning/{roomID}/deck", http.MethodPut, participant,
+		func(w http.ResponseWriter, r *http.Request, cmd roomCommand[bus.ChangeDeckPayload]) error {
+			return noContent(w, uc.Execute(r.Context(), usecase.ChangeDeckCommand{
+				RoomID:    cmd.RoomID,
+				SenderID:  cmd.SenderID,
+				DeckName:  cmd.Payload.Deck,
+				DeckCards: cmd.Payload.Cards,
+			}))
+		})
+}
+
+// @Summary Change the consensus rule
+// @Description Changes how the votes reach a consensus.
+// @Tags room commands
+// @Accept json
+// @Produce json
+// @Param roomID path string true "Room ID"
+// @Param payload body bus.ChangeConsensusRulePayload true "Consensus rule"
+// @Success 204 "Done"
+// @Failure 400 {object} RoomCommandError
+// @Failure 401 {string} string "Missing or invalid token"
+// @Failure 403 {object} RoomCommandError "Not a participant of the room or not allowed by its role"
+// @Failure 404 {object} RoomCommandError "Room or participant not found"
+// @Failure 409 {object} RoomCommandError "The room changed concurrently, retry"
+// @Failure 429 {string} string "Too many requests"
+// @Failure 500 {object} RoomCommandError
+// @Security ParticipantAuth
+// @Router /planning/{roomID}/consensus-rule [put]
+func NewChangeConsensusRuleAPI(uc usecase.UseCase[usecase.ChangeConsensusRuleCommand], participant middleware.ParticipantMiddleware) *RoomCommandAPI[bus.ChangeConsensusRulePayload] {
+	return newRoomCommandAPI("/planning/{roomID}/consensus-rule", http.MethodPut, participant,
+		func(w http.ResponseWriter, r *http.Request, cmd roomCommand[bus.ChangeConsensusRulePayload]) error {
+			return noContent(w, uc.Execute(r.Context(), usecase.ChangeConsensusRuleCommand{
+				RoomID:   cmd.RoomID,
+				SenderID: cmd.SenderID,
+				Rule:     cmd.Payload.Rule,
+			}))
+		})
+}
+
+// @Summary Start the voting timer
+// @Description Reveals the votes after the given number of seconds.
+// @Tags room commands
+// @Accept json
+// @Produce json
+// @Param roomID path string true "Room ID"
+// @Param payload body bus.StartTimerPayload true "Duration of the timer"
+// @Success 204 "Done"
+// @Failure 400 {object} RoomCommandError
+// @Failure 401 {string} string "Missing or invalid token"
+// @Failure 403 {object} RoomCommandError "Not a participant of the room or not allowed by its role"
+// @Failure 404 {object} RoomCommandError "Room or participant not found"
+// @Failure 409 {object} RoomCommandError "The room changed concurrently, retry"
+// @Failure 429 {string} string "Too many requests"
+// @Failure 500 {object} RoomCommandError
+// @Security ParticipantAuth
+// @Router /planning/{roomID}/timer [post]
+func NewStartVotingTimerAPI(uc usecase.UseCase[usecase.StartVotingTimerCommand], participant middleware.ParticipantMiddleware) *RoomCommandAPI[bus.StartTimerPayload] {
+	return newRoomCommandAPI("/planning/{roomID}/timer", http.MethodPost, participant,
+		func(w http.ResponseWriter, r *http.Request, cmd roomCommand[bus.StartTimerPayload]) error {
+			return noContent(w, uc.Execute(r.Context(), usecase.StartVotingTimerCommand{
+				RoomID:   cmd.RoomID,
+				SenderID: cmd.SenderID,
+				Duration: time.Duration(cmd.Payload.Seconds) * time.Second,
+			}))
+		})
+}
+
+// @Summary Cancel the voting timer
+// @Description Cancels the voting timer.
+// @Tags room commands
+// @Produce json
+// @Param roomID path string true "Room ID"
+// @Success 204 "Done"
+// @Failure 400 {object} RoomCommandError
+// @Failure 401 {string} string "Missing or invalid token"
+// @Failure 403 {object} RoomCommandError "Not a participant of the room or not allowed by its role"
+// @Failure 404 {object} RoomCommandError "Room or participant not found"
+// @Failure 409 {object} RoomCommandError "The room changed concurrently, retry"
+// @Failure 429 {string} string "Too many requests"
+// @Failure 500 {object} RoomCommandError
+// @Security ParticipantAuth
+// @Router /planning/{roomID}/timer [delete]
+func NewCancelVotingTimerAPI(uc usecase.UseCase[usecase.CancelVotingTimerCommand], participant middleware.ParticipantMiddleware) *RoomCommandAPI[bus.NoPayload] {
+	return newRoomCommandAPI("/planning/{roomID}/timer", http.MethodDelete, participant,
+		func(w http.ResponseWriter, r *http.Request, cmd roomCommand[bus.NoPayload]) error {
+			return noContent(w, uc.Execute(r.Context(), usecase.CancelVotingTimerCommand{RoomID: cmd.RoomID, SenderID: cmd.SenderID}))
+		})
+}
+
+// @Summary Export the session report
+// @Description Returns the backlog of the room with the result of each story, to the participants allowed to export it.
+// @Tags room commands
+// @Produce json
+// @Produce text/csv
+// @Produce text/markdown
+// @Param roomID path string true "Room ID"
+// @Param format query string false "Report format" Enums(json, csv, markdown)
+// @Success 200 {string} string "Report in the requested format"
+// @Failure 400 {object} RoomCommandError
+// @Failure 401 {string} string "Missing or invalid token"
+// @Failure 403 {object} RoomCommandError "Not a participant of the room or not allowed by its role"
+// @Failure 404 {object} RoomCommandError "Room or participant not found"
+// @Failure 409 {object} RoomCommandError "The room changed concurrently, retry"
+// @Failure 429 {string} string "Too many requests"
+// @Failure 500 {object} RoomCommandError
+// @Security ParticipantAuth
+// @Router /planning/{roomID}/report [get]
+func NewExportReportAPI(uc usecase.UseCase[usecase.ExportReportCommand], participant middleware.ParticipantMiddleware) *RoomCommandAPI[bus.NoPayload] {
+	return newRoomCommandAPI("/planning/{roomID}/report", http.MethodGet, participant,
+		func(w http.ResponseWriter, r *http.Request, cmd roomCommand[bus.NoPayload]) error {
+			replies := newReplyRecorder(cmd.RoomID)
+			err := uc.Execute(r.Context(), usecase.ExportReportCommand{
+				RoomID:   cmd.RoomID,
+				SenderID: cmd.SenderID,
+				Format:   r.URL.Query().Get("format"),
+				ReplyTo:  replies,
+			})
+			if err != nil {
+				return err
+			}
+			sessionReport, ok := reply[dto.SessionReport](replies)
+			if !ok {
+				return errors.New("the report was not sent")
+			}
+			w.Header().Set("Content-Type", sessionReport.ContentType)
+			w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", sessionReport.Filename))
+			w.WriteHeader(http.StatusOK)
+			_, _ = io.WriteString(w, sessionReport.Content)
+			return nil
+		})
+}
+
+// @Summary Set the passcode
+// @Description Protects the room with a passcode, an empty one removes the protection.
+// @Tags room commands
+// @Accept json
+// @Produce json
+// @Param roomID path string true "Room ID"
+// @Param payload body bus.SetPasscodePayload true "Passcode"
+// @Success 204 "Done"
+// @Failure 400 {object} RoomCommandError
+// @Failure 401 {string} string "Missing or invalid token"
+// @Failure 403 {object} RoomCommandError "Not a participant of the room or not allowed by its role"
+// @Failure 404 {object} RoomCommandError "Room or participant not found"
+// @Failure 409 {object} RoomCommandError "The room changed concurrently, retry"
+// @Failure 429 {string} string "Too many requests"
+// @Failure 500 {object} RoomCommandError
+// @Security ParticipantAuth
+// @Router /planning/{roomID}/passcode [put]
+func NewSetPasscodeAPI(uc usecase.UseCase[usecase.SetPasscodeCommand], participant middleware.ParticipantMiddleware) *RoomCommandAPI[bus.SetPasscodePayload] {
+	return newRoomCommandAPI("/planning/{roomID}/passcode", http.MethodPut, participant,
+		func(w http.ResponseWriter, r *http.Request, cmd roomCommand[bus.SetPasscodePayload]) error {
+			return noContent(w, uc.Execute(r.Context(), usecase.SetPasscodeCommand{
+				RoomID:   cmd.RoomID,
+				SenderID: cmd.SenderID,
+				Passcode: cmd.Payload.Passcode,
+			}))
+		})
+}
+
+// @Summary Create an invite
+// @Description Creates a token that lets its holder join the room without the passcode. From then on the room is invite only: clients need an invite or the passcode to join. Bots cannot.
+// @Tags room commands
+// @Accept json
+// @Produce json
+// @Param roomID path string true "Room ID"
+// @Param payload body bus.CreateInvitePayload true "Lifetime of the invite, the default one without a TTL"
+// @Success 201 {object} CreateInviteResponse
+// @Failure 400 {object} RoomCommandError
+// @Failure 401 {string} string "Missing or invalid token"
+// @Failure 403 {object} RoomCommandError "Not a participant of the room or not allowed by its role"
+// @Failure 404 {object} RoomCommandError "Room or participant not found"
+// @Failure 409 {object} RoomCommandError "The room changed concurrently, retry"
+// @Failure 429 {string} string "Too many requests"
+// @Failure 500 {object} RoomCommandError
+// @Security ParticipantAuth
+// @Router /planning/{roomID}/invites [post]
+func NewCreateInviteAPI(uc usecase.UseCase[usecase.CreateInviteCommand], participant middleware.ParticipantMiddleware) *RoomCommandAPI[bus.CreateInvitePayload] {
+	return newRoomCommandAPI("/planning/{roomID}/invites", http.MethodPost, participant,
+		func(w http.ResponseWriter, r *http.Request, cmd roomCommand[bus.CreateInvitePayload]) error {
+			replies := newReplyRecorder(cmd.RoomID)
+			err := uc.Execute(r.Context(), usecase.CreateInviteCommand{
+				RoomID:   cmd.RoomID,
+				SenderID: cmd.SenderID,
+				TTL:      time.Duration(cmd.Payload.TTLSeconds) * time.Second,
+				ReplyTo:  replies,
+			})
+			if err != nil {
+				return err
+			}
+			invite, ok := reply[dto.InviteCreated](replies)
+			if !ok {
+				return errors.New("the invite was not sent")
+			}
+			SendJsonResponse(w, http.StatusCreated, CreateInviteResponse{Token: invite.Token, ExpiresAt: invite.ExpiresAt})
+			return nil
+		})
+}
+
+// @Summary Revoke the invites
+// @Description Revokes every invite of the room. Bots cannot.
+// @Tags room commands
+// @Produce json
+// @Param roomID path string true "Room ID"
+// @Success 204 "Done"
+// @Failure 400 {object} RoomCommandError
+// @Failure 401 {string} string "Missing or invalid token"
+// @Failure 403 {object} RoomCommandError "Not a participant of the room or not allowed by its role"
+// @Failure 404 {object} RoomCommandError "Room or participant not found"
+// @Failure 409 {object} RoomCommandError "The room changed concurrently, retry"
+// @Failure 429 {string} string "Too many requests"
+// @Failure 500 {object} RoomCommandError
+// @Security ParticipantAuth
+// @Router /planning/{roomID}/invites [delete]
+func NewRevokeInvitesAPI(uc usecase.UseCase[usecase.RevokeInvitesCommand], participant middleware.ParticipantMiddleware) *RoomCommandAPI[bus.NoPayload] {
+	return newRoomCommandAPI("/planning/{roomID}/invites", http.MethodDelete, participant,
+		func(w http.ResponseWriter, r *http.Request, cmd roomCommand[bus.NoPayload]) error {
+			return noContent(w, uc.Execute(r.Context(), usecase.RevokeInvitesCommand{RoomID: cmd.RoomID, SenderID: cmd.SenderID}))
+		})
+}
+
+// @Summary Change a permission
+// @Description Changes the roles allowed to take an action. Bots cannot.
+// @Tags room commands
+// @Accept json
+// @Produce json
+// @Param roomID path string true "Room ID"
+// @Param payload body bus.ChangePermissionPayload true "Action and the roles allowed to take it"
+// @Success 204 "Done"
+// @Failure 400 {object} RoomCommandError
+// @Failure 401 {string} string "Missing or invalid token"
+// @Failure 403 {object} RoomCommandError "Not a participant of the room or not allowed by its role"
+// @Failure 404 {object} RoomCommandError "Room or participant not found"
+// @Failure 409 {object} RoomCommandError "The room changed concurrently, retry"
+// @Failure 429 {string} string "Too many requests"
+// @Failure 500 {object} RoomCommandError
+// @Security ParticipantAuth
+// @Router /planning/{roomID}/permissions [put]
+func NewChangePermissionAPI(uc usecase.UseCase[usecase.ChangePermissionCommand], participant middleware.ParticipantMiddleware) *RoomCommandAPI[bus.ChangePermissionPayload] {
+	return newRoomCommandAPI("/planning/{roomID}/permissions", http.MethodPut, participant,
+		func(w http.ResponseWriter, r *http.Request, cmd roomCommand[bus.ChangePermissionPayload]) error {
+			return noContent(w, uc.Execute(r.Context(), usecase.ChangePermissionCommand{
+				RoomID:   cmd.RoomID,
+				SenderID: cmd.SenderID,
+				Action:   cmd.Payload.Action,
+				Roles:    cmd.Payload.Roles,
+			}))
+		})
+}
+
+// @Summary Get the room state
+// @Description Returns the room state the participants receive on every change, with the results of the stories voted so far.
+// @Tags room commands
+// @Produce json
+// @Param roomID path string true "Room ID"
+// @Success 200 {object} dto.RoomState
+// @Failure 400 {object} RoomCommandError
+// @Failure 401 {string} string "Missing or invalid token"
+// @Failure 403 {object} RoomCommandError "Not a participant of the room or not allowed by its role"
+// @Failure 404 {object} RoomCommandError "Room or participant not found"
+// @Failure 409 {object} RoomCommandError "The room changed concurrently, retry"
+// @Failure 429 {string} string "Too many requests"
+// @Failure 500 {object} RoomCommandError
+// @Security ParticipantAuth
+// @Router /planning/{roomID}/state [get]
+func NewGetParticipantRoomStateAPI(uc usecase.UseCase[usecase.ResyncCommand], participant middleware.ParticipantMiddleware) *RoomCommandAPI[bus.NoPayload] {
+	return newRoomCommandAPI("/planning/{roomID}/state", http.MethodGet, participant,
+		func(w http.ResponseWriter, r *http.Request, cmd roomCommand[bus.NoPayload]) error {
+			replies := newReplyRecorder(cmd.RoomID)
+			if err := uc.Execute(r.Context(), usecase.ResyncCommand{RoomID: cmd.RoomID, SenderID: cmd.SenderID, ReplyTo: replies}); err != nil {
+				return err
+			}
+			state, ok := reply[dto.RoomState](replies)
+			if !ok {
+				return errors.New("the room state was not sent")
+			}
+			SendJsonResponse(w, http.StatusOK, state)
+			return nil
+		})
+}
+
+// @Summary Add a bot
+// @Description Adds a bot to the room and returns its token, which is not stored. Bots facilitate the session without voting or managing who runs it, so bots cannot add bots.
+// @Tags room commands
+// @Accept json
+// @Produce json
+// @Param roomID path string true "Room ID"
+// @Param payload body AddBotRequest true "Name of the bot, Bot by default"
+// @Success 201 {object} AddBotResponse
+// @Failure 400 {object} RoomCommandError
+// @Failure 401 {string} string "Missing or invalid token"
+// @Failure 403 {object} RoomCommandError "Not a participant of the room or not allowed by its role"
+// @Failure 404 {object} RoomCommandError "Room or participant not found"
+// @Failure 409 {object} RoomCommandError "The room changed concurrently, retry"
+// @Failure 429 {string} string "Too many requests"
+// @Failure 500 {object} RoomCommandError
+// @Security ParticipantAuth
+// @Router /planning/{roomID}/bots [post]
+func NewAddBotAPI(uc usecase.UseCaseR[usecase.AddBotCommand, usecase.AddBotOutput], participant middleware.ParticipantMiddleware) *RoomCommandAPI[AddBotRequest] {
+	return newRoomCommandAPI("/planning/{roomID}/bots", http.MethodPost, participant,
+		func(w http.ResponseWriter, r *http.Request, cmd roomCommand[AddBotRequest]) error {
+			output, err := uc.Execute(r.Context(), usecase.AddBotCommand{
+				RoomID:   cmd.RoomID,
+				SenderID: cmd.SenderID,
+				Name:     cmd.Payload.Name,
+			})
+			if err != nil {
+				return err
+			}
+			SendJsonResponse(w, http.StatusCreated, AddBotResponse{ClientID: output.ClientID, Token: output.Token})
+			return nil
+		})
+}
+
+// @Summary Remove a bot
+// @Description Removes a bot from the room, its token is no longer accepted. Bots cannot.
+// @Tags room commands
+// @Produce json
+// @Param roomID path string true "Room ID"
+// @Param botID path string true "Client ID of the bot"
+// @Success 204 "Done"
+// @Failure 400 {object} RoomCommandError
+// @Failure 401 {string} string "Missing or invalid token"
+// @Failure 403 {object} RoomCommandError "Not a participant of the room or not allowed by its role"
+// @Failure 404 {object} RoomCommandError "Room or participant not found"
+// @Failure 409 {object} RoomCommandError "The room changed concurrently, retry"
+// @Failure 429 {string} string "Too many requests"
+// @Failure 500 {object} RoomCommandError
+// @Security ParticipantAuth
+// @Router /planning/{roomID}/bots/{botID} [delete]
+func NewRemoveBotAPI(uc usecase.UseCase[usecase.RemoveBotCommand], participant middleware.ParticipantMiddleware) *RoomCommandAPI[bus.NoPayload] {
+	return newRoomCommandAPI("/planning/{roomID}/bots/{botID}", http.MethodDelete, participant,
+		func(w http.ResponseWriter, r *http.Request, cmd roomCommand[bus.NoPayload]) error {
+			return noContent(w, uc.Execute(r.Context(), usecase.RemoveBotCommand{
+				RoomID:   cmd.RoomID,
+				SenderID: cmd.SenderID,
+				BotID:    mux.Vars(r)["botID"],
+			}))
+		})
+}
+
+// @Summary Add a webhook
+// @Description Sends the events of the room to a URL until the session ends. Deliveries are signed with the returned secret, which is not shown again.
+// @Tags room commands
+// @Accept json
+// @Produce json
+// @Param roomID path string true "Room ID"
+// @Param payload body WebhookRequest true "URL and events of the webhook: story.revealed, story.added and session.ended"
+// @Success 201 {object} WebhookResponse
+// @Failure 400 {object} RoomCommandError
+// @Failure 401 {string} string "Missing or invalid token"
+// @Failure 403 {object} RoomCommandError "Not a participant of the room or not allowed by its role"
+// @Failure 404 {object} RoomCommandError "Room or participant not found"
+// @Failure 429 {string} string "Too many requests"
+// @Failure 500 {object} RoomCommandError
+// @Security ParticipantAuth
+// @Router /planning/{roomID}/webhooks [post]
+func NewCreateRoomWebhookAPI(uc usecase.UseCaseR[usecase.CreateWebhookCommand, webhook.Subscription], participant middleware.ParticipantMiddleware) *RoomCommandAPI[WebhookRequest] {
+	return newRoomCommandAPI("/planning/{roomID}/webhooks", http.MethodPost, participant,
+		func(w http.ResponseWriter, r *http.Request, cmd roomCommand[WebhookRequest]) error {
+			subscription, err := uc.Execute(r.Context(), usecase.CreateWebhookCommand{
+				RoomID:     cmd.RoomID,
+				SenderID:   cmd.SenderID,
+				URL:        cmd.Payload.URL,
+				EventTypes: cmd.Payload.Events,
+			})
+			if err != nil {
+				return err
+			}
+			SendJsonResponse(w, http.StatusCreated, newWebhookResponse(subscription))
+			return nil
+		})
+}
+
+// @Summary List the webhooks
+// @Description Returns the webhooks of the room, without their secrets.
+// @Tags room commands
+// @Produce json
+// @Param roomID path string true "Room ID"
+// @Success 200 {array} WebhookResponse
+// @Failure 401 {string} string "Missing or invalid token"
+// @Failure 403 {object} RoomCommandError "Not a participant of the room or not allowed by its role"
+// @Failure 404 {object} RoomCommandError "Room or participant not found"
+// @Failure 429 {string} string "Too many requests"
+// @Failure 500 {object} RoomCommandError
+// @Security ParticipantAuth
+// @Router /planning/{roomID}/webhooks [get]
+func NewListRoomWebhooksAPI(uc usecase.UseCaseR[usecase.ListWebhooksCommand, []webhook.Subscription], participant middleware.ParticipantMiddleware) *RoomCommandAPI[bus.NoPayload] {
+	return newRoomCommandAPI("/planning/{roomID}/webhooks", http.MethodGet, participant,
+		func(w http.ResponseWriter, r *http.Request, cmd roomCommand[bus.NoPayload]) error {
+			subscriptions, err := uc.Execute(r.Context(), usecase.ListWebhooksCommand{RoomID: cmd.RoomID, SenderID: cmd.SenderID})
+			if err != nil {
+				return err
+			}
+			SendJsonResponse(w, http.StatusOK, lo.Map(subscriptions, func(subscription webhook.Subscription, _ int) WebhookResponse {
+				response := newWebhookResponse(subscription)
+				response.Secret = ""
+				return response
+			}))
+			return nil
+		})
+}
+
+// @Summary Remove a webhook
+// @Description Stops sending the events of the room to a webhook.
+// @Tags room commands
+// @Produce json
+// @Param roomID path string true "Room ID"
+// @Param webhookID path string true "ID of the webhook"
+// @Success 204 "Done"
+// @Failure 401 {string} string "Missing or invalid token"
+// @Failure 403 {object} RoomCommandError "Not a participant of the room or not allowed by its role"
+// @Failure 404 {object} RoomCommandError "Room, participant or webhook not found"
+// @Failure 429 {string} string "Too many requests"
+// @Failure 500 {object} RoomCommandError
+// @Security ParticipantAuth
+// @Router /planning/{roomID}/webhooks/{webhookID} [delete]
+func NewDeleteRoomWebhookAPI(uc usecase.UseCase[usecase.DeleteWebhookCommand], participant middleware.ParticipantMiddleware) *RoomCommandAPI[bus.NoPayload] {
+	return newRoomCommandAPI("/planning/{roomID}/webhooks/{webhookID}", http.MethodDelete, participant,
+		func(w http.ResponseWriter, r *http.Request, cmd roomCommand[bus.NoPayload]) error {
+			return noContent(w, uc.Execute(r.Context(), usecase.DeleteWebhookCommand{
+				RoomID:         cmd.RoomID,
+				SenderID:       cmd.SenderID,
+				SubscriptionID: mux.Vars(r)["webhookID"],
+			}))
+		})
+}
+
+func newWebhookResponse(subscription webhook.Subscription) WebhookResponse {
+	return WebhookResponse{
+		ID:        subscription.ID,
+		URL:       subscription.URL,
+		Events:    eventTypeNames(subscription.EventTypes),
+		CreatedAt: subscription.CreatedAt,
+		Secret:    subscription.Secret,
+	}
+}
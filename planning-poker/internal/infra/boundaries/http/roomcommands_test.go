@@ -0,0 +1,350 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"planning-poker/internal/application/planningpoker/usecase"
+	"planning-poker/internal/application/planningpoker/usecase/dto"
+	"planning-poker/internal/application/webhook"
+	"planning-poker/internal/domain"
+	"planning-poker/internal/domain/entity"
+	"planning-poker/internal/infra/boundaries/http/middleware"
+	"planning-poker/internal/infra/boundaries/hub/clientcollection"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"go.uber.org/mock/gomock"
+)
+
+// newBotRoom returns a room with an owner and a bot, and the token of the bot.
+func newBotRoom(t *testing.T) (*entity.Room, entity.BotCredentials) {
+	t.Helper()
+	room := entity.NewRoomWithID("room123", clientcollection.New())
+	room.NewClient("owner")
+	credentials, err := room.AddBot(context.Background(), "owner", "ci")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return room, credentials
+}
+
+func serveRoomCommand(t *testing.T, api API, method, target, token, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	router := mux.NewRouter()
+	router.Handle(api.Endpoint(), api.Handle()).Methods(api.Methods()...)
+
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	req.Header.Set("Authorization", "Bot "+token)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestAddStoryAPI_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	room, credentials := newBotRoom(t)
+
+	mockHub := domain.NewMockHub(ctrl)
+	mockHub.EXPECT().LoadRoom(gomock.Any(), "room123").Return(room, nil)
+	mockUseCase := usecase.NewMockUseCase[usecase.AddStoryCommand](ctrl)
+	mockUseCase.EXPECT().
+		Execute(gomock.Any(), usecase.AddStoryCommand{RoomID: "room123", SenderID: credentials.ClientID, StoryName: "Login"}).
+		Return(nil)
+
+	api := NewAddStoryAPI(mockUseCase, middleware.NewParticipantMiddleware(mockHub, nil))
+	rec := serveRoomCommand(t, api, http.MethodPost, "/planning/room123/stories", credentials.Token, `{"story":"Login"}`)
+
+	if rec.Code != http.StatusNoContent {
+		t.Errorf("status code = %v, want %v", rec.Code, http.StatusNoContent)
+	}
+}
+
+func TestAddStoryAPI_Handle_InvalidPayload(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	room, credentials := newBotRoom(t)
+
+	mockHub := domain.NewMockHub(ctrl)
+	mockHub.EXPECT().LoadRoom(gomock.Any(), "room123").Return(room, nil).Times(2)
+	mockUseCase := usecase.NewMockUseCase[usecase.AddStoryCommand](ctrl)
+
+	api := NewAddStoryAPI(mockUseCase, middleware.NewParticipantMiddleware(mockHub, nil))
+	for _, body := range []string{`{}`, `{"story":"Login","points":3}`} {
+		rec := serveRoomCommand(t, api, http.MethodPost, "/planning/room123/stories", credentials.Token, body)
+
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("status code for %s = %v, want %v", body, rec.Code, http.StatusBadRequest)
+		}
+		var response RoomCommandError
+		if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if response.Code != "INVALID_PAYLOAD" {
+			t.Errorf("code for %s = %v, want INVALID_PAYLOAD", body, response.Code)
+		}
+	}
+}
+
+func TestRoomCommandAPI_Handle_UseCaseErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantCode   string
+	}{
+		{"permission denied", fmt.Errorf("bots cannot: %w", domain.ErrPermissionDenied), http.StatusForbidden, "PERMISSION_DENIED"},
+		{"client not found", domain.ErrClientNotFound, http.StatusNotFound, "CLIENT_NOT_FOUND"},
+		{"version conflict", domain.ErrVersionConflict, http.StatusConflict, "VERSION_CONFLICT"},
+		{"webhook not found", domain.ErrWebhookNotFound, http.StatusNotFound, "WEBHOOK_NOT_FOUND"},
+		{"domain error", domain.ErrLastOwner, http.StatusBadRequest, "LAST_OWNER"},
+		{"unexpected error", fmt.Errorf("connection refused"), http.StatusInternalServerError, "INTERNAL"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			room, credentials := newBotRoom(t)
+
+			mockHub := domain.NewMockHub(ctrl)
+			mockHub.EXPECT().LoadRoom(gomock.Any(), "room123").Return(room, nil)
+			mockUseCase := usecase.NewMockUseCase[usecase.ToggleOwnerCommand](ctrl)
+			mockUseCase.EXPECT().
+				Execute(gomock.Any(), usecase.ToggleOwnerCommand{RoomID: "room123", SenderID: credentials.ClientID, TargetClientID: "owner"}).
+				Return(tt.err)
+
+			api := NewToggleParticipantOwnerAPI(mockUseCase, middleware.NewParticipantMiddleware(mockHub, nil))
+			rec := serveRoomCommand(t, api, http.MethodPost, "/planning/room123/participants/owner/owner", credentials.Token, "")
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status code = %v, want %v", rec.Code, tt.wantStatus)
+			}
+			var response RoomCommandError
+			if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if response.Code != tt.wantCode {
+				t.Errorf("code = %v, want %v", response.Code, tt.wantCode)
+			}
+			if tt.wantStatus == http.StatusInternalServerError && strings.Contains(response.Error, "connection refused") {
+				t.Error("expected the cause of unexpected errors not to be disclosed")
+			}
+		})
+	}
+}
+
+func TestRemoveStoryAPI_Handle_InvalidIndex(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	room, credentials := newBotRoom(t)
+
+	mockHub := domain.NewMockHub(ctrl)
+	mockHub.EXPECT().LoadRoom(gomock.Any(), "room123").Return(room, nil)
+	mockUseCase := usecase.NewMockUseCase[usecase.RemoveStoryCommand](ctrl)
+
+	api := NewRemoveStoryAPI(mockUseCase, middleware.NewParticipantMiddleware(mockHub, nil))
+	rec := serveRoomCommand(t, api, http.MethodDelete, "/planning/room123/stories/first", credentials.Token, "")
+
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("status code = %v, want %v", rec.Code, http.StatusBadRequest)
+	}
+}
+
+func TestGetParticipantRoomStateAPI_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	room, credentials := newBotRoom(t)
+
+	mockHub := domain.NewMockHub(ctrl)
+	mockHub.EXPECT().LoadRoom(gomock.Any(), "room123").Return(room, nil)
+	mockUseCase := usecase.NewMockUseCase[usecase.ResyncCommand](ctrl)
+	mockUseCase.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, cmd usecase.ResyncCommand) error {
+		if cmd.SenderID != credentials.ClientID || cmd.ReplyTo == nil {
+			t.Errorf("unexpected command %+v", cmd)
+		}
+		return cmd.ReplyTo.Send(ctx, dto.RoomState{Type: "room-state", Version: 7, CurrentStory: "Login"})
+	})
+
+	api := NewGetParticipantRoomStateAPI(mockUseCase, middleware.NewParticipantMiddleware(mockHub, nil))
+	rec := serveRoomCommand(t, api, http.MethodGet, "/planning/room123/state", credentials.Token, "")
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status code = %v, want %v", rec.Code, http.StatusOK)
+	}
+	var state dto.RoomState
+	if err := json.NewDecoder(rec.Body).Decode(&state); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if state.Version != 7 || state.CurrentStory != "Login" {
+		t.Errorf("unexpected state %+v", state)
+	}
+}
+
+func TestExportReportAPI_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	room, credentials := newBotRoom(t)
+
+	mockHub := domain.NewMockHub(ctrl)
+	mockHub.EXPECT().LoadRoom(gomock.Any(), "room123").Return(room, nil)
+	mockUseCase := usecase.NewMockUseCase[usecase.ExportReportCommand](ctrl)
+	mockUseCase.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, cmd usecase.ExportReportCommand) error {
+		if cmd.Format != "csv" {
+			t.Errorf("format = %v, want csv", cmd.Format)
+		}
+		return cmd.ReplyTo.Send(ctx, dto.SessionReport{
+			Type:        "session-report",
+			Format:      "csv",
+			ContentType: "text/csv",
+			Filename:    "report.csv",
+			Content:     "story,result\nLogin,5\n",
+		})
+	})
+
+	api := NewExportReportAPI(mockUseCase, middleware.NewParticipantMiddleware(mockHub, nil))
+	rec := serveRoomCommand(t, api, http.MethodGet, "/planning/room123/report?format=csv", credentials.Token, "")
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status code = %v, want %v", rec.Code, http.StatusOK)
+	}
+	if got := rec.Header().Get("Content-Type"); got != "text/csv" {
+		t.Errorf("Content-Type = %v, want text/csv", got)
+	}
+	if got := rec.Header().Get("Content-Disposition"); got != `attachment; filename="report.csv"` {
+		t.Errorf("Content-Disposition = %v", got)
+	}
+	if rec.Body.String() != "story,result\nLogin,5\n" {
+		t.Errorf("unexpected body %q", rec.Body.String())
+	}
+}
+
+func TestCreateInviteAPI_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	room, credentials := newBotRoom(t)
+	expiresAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	mockHub := domain.NewMockHub(ctrl)
+	mockHub.EXPECT().LoadRoom(gomock.Any(), "room123").Return(room, nil)
+	mockUseCase := usecase.NewMockUseCase[usecase.CreateInviteCommand](ctrl)
+	mockUseCase.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, cmd usecase.CreateInviteCommand) error {
+		if cmd.TTL != time.Hour {
+			t.Errorf("TTL = %v, want %v", cmd.TTL, time.Hour)
+		}
+		return cmd.ReplyTo.Send(ctx, dto.InviteCreated{Type: "invite-created", Token: "invite-token", ExpiresAt: expiresAt})
+	})
+
+	api := NewCreateInviteAPI(mockUseCase, middleware.NewParticipantMiddleware(mockHub, nil))
+	rec := serveRoomCommand(t, api, http.MethodPost, "/planning/room123/invites", credentials.Token, `{"ttlSeconds":3600}`)
+
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("status code = %v, want %v", rec.Code, http.StatusCreated)
+	}
+	var response CreateInviteResponse
+	if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if response.Token != "invite-token" || !response.ExpiresAt.Equal(expiresAt) {
+		t.Errorf("unexpected response %+v", response)
+	}
+}
+
+func TestAddBotAPI_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	room, credentials := newBotRoom(t)
+
+	mockHub := domain.NewMockHub(ctrl)
+	mockHub.EXPECT().LoadRoom(gomock.Any(), "room123").Return(room, nil)
+	mockUseCase := usecase.NewMockUseCaseR[usecase.AddBotCommand, usecase.AddBotOutput](ctrl)
+	mockUseCase.EXPECT().
+		Execute(gomock.Any(), usecase.AddBotCommand{RoomID: "room123", SenderID: credentials.ClientID, Name: "release"}).
+		Return(usecase.AddBotOutput{ClientID: "bot-2", Token: "bot-2.secret"}, nil)
+
+	api := NewAddBotAPI(mockUseCase, middleware.NewParticipantMiddleware(mockHub, nil))
+	rec := serveRoomCommand(t, api, http.MethodPost, "/planning/room123/bots", credentials.Token, `{"name":"release"}`)
+
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("status code = %v, want %v", rec.Code, http.StatusCreated)
+	}
+	var response AddBotResponse
+	if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if response.ClientID != "bot-2" || response.Token != "bot-2.secret" {
+		t.Errorf("unexpected response %+v", response)
+	}
+}
+
+func TestCreateRoomWebhookAPI_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	room, credentials := newBotRoom(t)
+
+	mockHub := domain.NewMockHub(ctrl)
+	mockHub.EXPECT().LoadRoom(gomock.Any(), "room123").Return(room, nil)
+	mockUseCase := usecase.NewMockUseCaseR[usecase.CreateWebhookCommand, webhook.Subscription](ctrl)
+	mockUseCase.EXPECT().
+		Execute(gomock.Any(), usecase.CreateWebhookCommand{
+			RoomID:     "room123",
+			SenderID:   credentials.ClientID,
+			URL:        "https://hooks.example.com/poker",
+			EventTypes: []string{"story.revealed"},
+		}).
+		Return(webhook.Subscription{
+			ID:         "webhook-1",
+			RoomID:     "room123",
+			URL:        "https://hooks.example.com/poker",
+			Secret:     "whsec_secret",
+			EventTypes: []webhook.EventType{webhook.EventStoryRevealed},
+		}, nil)
+
+	api := NewCreateRoomWebhookAPI(mockUseCase, middleware.NewParticipantMiddleware(mockHub, nil))
+	rec := serveRoomCommand(t, api, http.MethodPost, "/planning/room123/webhooks", credentials.Token,
+		`{"url":"https://hooks.example.com/poker","events":["story.revealed"]}`)
+
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("status code = %v, want %v", rec.Code, http.StatusCreated)
+	}
+	var response WebhookResponse
+	if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if response.ID != "webhook-1" || response.Secret != "whsec_secret" || len(response.Events) != 1 {
+		t.Errorf("unexpected response %+v", response)
+	}
+}
+
+func TestListRoomWebhooksAPI_Handle_HidesSecrets(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	room, credentials := newBotRoom(t)
+
+	mockHub := domain.NewMockHub(ctrl)
+	mockHub.EXPECT().LoadRoom(gomock.Any(), "room123").Return(room, nil)
+	mockUseCase := usecase.NewMockUseCaseR[usecase.ListWebhooksCommand, []webhook.Subscription](ctrl)
+	mockUseCase.EXPECT().
+		Execute(gomock.Any(), usecase.ListWebhooksCommand{RoomID: "room123", SenderID: credentials.ClientID}).
+		Return([]webhook.Subscription{{ID: "webhook-1", URL: "https://hooks.example.com/poker", Secret: "whsec_secret"}}, nil)
+
+	api := NewListRoomWebhooksAPI(mockUseCase, middleware.NewParticipantMiddleware(mockHub, nil))
+	rec := serveRoomCommand(t, api, http.MethodGet, "/planning/room123/webhooks", credentials.Token, "")
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status code = %v, want %v", rec.Code, http.StatusOK)
+	}
+	if strings.Contains(rec.Body.String(), "whsec_secret") {
+		t.Errorf("expected the secret not to be listed, got %s", rec.Body.String())
+	}
+}
+
+func TestNewRoomCommandAPIs_UniqueRoutes(t *testing.T) {
+	apis := NewRoomCommandAPIs(usecase.UseCasesFacade{}, middleware.NewParticipantMiddleware(nil, nil))
+
+	routes := make(map[string]bool)
+	for _, api := range apis {
+		for _, method := range api.Methods() {
+			route := method + " " + api.Endpoint()
+			if routes[route] {
+				t.Errorf("duplicate route %s", route)
+			}
+			routes[route] = true
+		}
+	}
+}
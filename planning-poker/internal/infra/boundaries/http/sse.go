@@ -0,0 +1,140 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"planning-poker/internal/application/planningpoker/usecase"
+	"planning-poker/internal/domain"
+	"planning-poker/internal/domain/entity"
+	"planning-poker/internal/infra/bus"
+
+	"github.com/bruno303/go-toolkit/pkg/log"
+	"github.com/gorilla/mux"
+)
+
+// SSEAPI streams the messages of a room as Server-Sent Events, for clients
+// behind proxies that block websockets. It shares the origin, token and
+// connection checks of the websocket, connections counting against the same
+// limit per IP address.
+type SSEAPI struct {
+	websocket  *WebsocketAPI
+	busFactory *bus.SSEBusFactory
+	logger     log.Logger
+}
+
+var _ API = (*SSEAPI)(nil)
+
+// @Summary Server-Sent Events stream
+// @Description Streams the messages of the websocket protocol as Server-Sent Events, for clients that cannot open websockets. The first event, named session, carries the session ID to post messages to. A keepalive comment is sent every ping interval.
+// @Tags rooms
+// @Produce text/event-stream
+// @Param roomID path string true "Room ID"
+// @Param clientId query string false "Client ID to reconnect with"
+// @Param passcode query string false "Room passcode"
+// @Param invite query string false "Invite token created by the room owner"
+// @Param patches query bool false "Receive room-patch messages instead of the full room-state"
+// @Param protocol query string false "Protocol version, the latest by default" Enums(v1)
+// @Param token query string false "OIDC token of the participant, also accepted as an Authorization Bearer header"
+// @Success 200 {string} string "Event stream"
+// @Failure 400 {object} ErrorResponse "Unsupported protocol version"
+// @Failure 401 {object} ErrorResponse "Missing or invalid token"
+// @Failure 403 {object} ErrorResponse "Origin not allowed or invalid room credentials"
+// @Failure 404 {object} ErrorResponse "Room not found"
+// @Failure 429 {object} ErrorResponse "Too many connections from the IP address"
+// @Failure 500 {object} ErrorResponse
+// @Router /planning/{roomID}/sse [get]
+func NewSSEAPI(websocketAPI *WebsocketAPI, busFactory *bus.SSEBusFactory) *SSEAPI {
+	return &SSEAPI{
+		websocket:  websocketAPI,
+		busFactory: busFactory,
+		logger:     log.NewLogger("planningpoker.api.sse"),
+	}
+}
+
+func (api *SSEAPI) Endpoint() string {
+	return "/planning/{roomID}/sse"
+}
+
+func (api *SSEAPI) Methods() []string {
+	return []string{"GET"}
+}
+
+func (api *SSEAPI) Handle() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		roomID := mux.Vars(r)["roomID"]
+		if roomID == "" {
+			SendJsonErrorMsg(w, http.StatusBadRequest, "Room ID is required")
+			return
+		}
+
+		identity, ip, ok := api.websocket.admit(w, r, "Event stream")
+		if !ok {
+			return
+		}
+		defer api.websocket.connections.release(ip)
+
+		protocol, err := bus.NegotiateProtocol(r.URL.Query().Get("protocol"), nil)
+		if err != nil {
+			SendJsonError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		clientID, err := api.websocket.clientID(r, identity)
+		if err != nil {
+			api.logger.Error(r.Context(), "Error creating client", err)
+			SendJsonErrorMsg(w, http.StatusInternalServerError, "Error creating client")
+			return
+		}
+
+		sseBus := api.busFactory.NewBus(bus.SSEBusFactoryInput{
+			ClientID: clientID,
+			RoomID:   roomID,
+			Writer:   w,
+			Patches:  r.URL.Query().Get("patches") == "true",
+			Protocol: protocol,
+		})
+
+		output, err := api.websocket.usecases.JoinRoom.Execute(r.Context(), usecase.JoinRoomCommand{
+			RoomID:   roomID,
+			SenderID: clientID,
+			Bus:      sseBus,
+			Identity: identity,
+			Credentials: entity.Credentials{
+				Passcode:    r.URL.Query().Get("passcode"),
+				InviteToken: r.URL.Query().Get("invite"),
+			},
+		})
+		if err != nil {
+			// the join was rolled back, the bus only has to be forgotten
+			sseBus.Detach()
+			_ = sseBus.Close()
+			api.rejectJoin(w, r, sseBus, clientID, roomID, err)
+			return
+		}
+
+		api.logger.Info(r.Context(), "New client streaming: %v on room: %v", output.Client.ID, output.Room.ID)
+
+		sseBus.Listen(r.Context())
+	})
+}
+
+// rejectJoin answers a failed join with its status code, unless the stream
+// already began.
+func (api *SSEAPI) rejectJoin(w http.ResponseWriter, r *http.Request, sseBus *bus.SSEBus, clientID, roomID string, err error) {
+	switch {
+	case sseBus.Started():
+		api.logger.Error(r.Context(), fmt.Sprintf("Error joining room %s after the stream began", roomID), err)
+	case errors.Is(err, domain.ErrRoomAccessDenied):
+		api.logger.Info(r.Context(), "Client %s denied access to room %s", clientID, roomID)
+		SendJsonErrorMsg(w, http.StatusForbidden, "Invalid room credentials")
+	case errors.Is(err, domain.ErrIdentityMismatch):
+		api.logger.Info(r.Context(), "Client %s denied access to room %s: identity mismatch", clientID, roomID)
+		SendJsonErrorMsg(w, http.StatusForbidden, "Client belongs to another identity")
+	case errors.Is(err, domain.ErrRoomNotFound):
+		SendJsonErrorMsg(w, http.StatusNotFound, "Room not found")
+	default:
+		api.logger.Error(r.Context(), fmt.Sprintf("Error joining room %s", roomID), err)
+		SendJsonErrorMsg(w, http.StatusInternalServerError, fmt.Sprintf("Error joining room %s", roomID))
+	}
+}
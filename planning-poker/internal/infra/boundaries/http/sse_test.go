@@ -0,0 +1,136 @@
+package http
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"planning-poker/internal/application/planningpoker/metric"
+	"planning-poker/internal/application/planningpoker/usecase"
+	"planning-poker/internal/domain"
+	"planning-poker/internal/domain/entity"
+	"planning-poker/internal/infra/bus"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSSEAPI_Handle_RejectsDeniedJoins(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockJoinRoom := usecase.NewMockUseCaseR[usecase.JoinRoomCommand, *usecase.JoinRoomOutput](ctrl)
+	mockJoinRoom.EXPECT().Execute(gomock.Any(), gomock.Any()).
+		Return(nil, fmt.Errorf("joining: %w", domain.ErrRoomAccessDenied))
+	mockJoinRoom.EXPECT().Execute(gomock.Any(), gomock.Any()).
+		Return(nil, fmt.Errorf("joining: %w", domain.ErrRoomNotFound))
+	usecases := usecase.UseCasesFacade{JoinRoom: mockJoinRoom}
+
+	factory := bus.NewSSEBusFactory(usecases, bus.WebSocketConfig{PingInterval: time.Minute})
+	api := NewSSEAPI(NewWebsocketAPI(usecases, nil, metric.NewPlanningPokerMetric(), WebsocketAPIConfig{}), factory)
+
+	for _, expectedStatus := range []int{http.StatusForbidden, http.StatusNotFound} {
+		req := httptest.NewRequest(http.MethodGet, "/planning/room-1/sse?clientId=client-1&passcode=wrong", nil)
+		req = mux.SetURLVars(req, map[string]string{"roomID": "room-1"})
+		rec := httptest.NewRecorder()
+
+		api.Handle().ServeHTTP(rec, req)
+
+		if rec.Code != expectedStatus {
+			t.Errorf("status = %v, want %v", rec.Code, expectedStatus)
+		}
+		if contentType := rec.Header().Get("Content-Type"); contentType == "text/event-stream" {
+			t.Error("expected the stream not to begin")
+		}
+	}
+}
+
+func TestSSEAPI_Handle_StreamsAndAcceptsMessages(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockJoinRoom := usecase.NewMockUseCaseR[usecase.JoinRoomCommand, *usecase.JoinRoomOutput](ctrl)
+	mockJoinRoom.EXPECT().Execute(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, cmd usecase.JoinRoomCommand) (*usecase.JoinRoomOutput, error) {
+			if cmd.SenderID != "client-1" || cmd.Credentials.Passcode != "s3cret" {
+				t.Errorf("unexpected command %+v", cmd)
+			}
+			return &usecase.JoinRoomOutput{Client: &entity.Client{ID: "client-1"}, Room: &entity.Room{ID: "room-1"}},
+				cmd.Bus.Send(ctx, map[string]any{"type": "update-client-id", "clientId": "client-1"})
+		})
+	mockReset := usecase.NewMockUseCase[usecase.ResetCommand](ctrl)
+	mockReset.EXPECT().Execute(gomock.Any(), usecase.ResetCommand{RoomID: "room-1", SenderID: "client-1"}).Return(nil)
+	mockLeaveRoom := usecase.NewMockUseCase[usecase.LeaveRoomCommand](ctrl)
+	mockLeaveRoom.EXPECT().Execute(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	usecases := usecase.UseCasesFacade{JoinRoom: mockJoinRoom, Reset: mockReset, LeaveRoom: mockLeaveRoom}
+
+	factory := bus.NewSSEBusFactory(usecases, bus.WebSocketConfig{WriteTimeout: time.Second, PingInterval: time.Minute})
+	streamAPI := NewSSEAPI(NewWebsocketAPI(usecases, nil, metric.NewPlanningPokerMetric(), WebsocketAPIConfig{}), factory)
+	messageAPI := NewSSEMessageAPI(factory)
+
+	router := mux.NewRouter()
+	router.Handle(streamAPI.Endpoint(), streamAPI.Handle()).Methods(streamAPI.Methods()...)
+	router.Handle(messageAPI.Endpoint(), messageAPI.Handle()).Methods(messageAPI.Methods()...)
+	srv := httptest.NewServer(router)
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + "/planning/room-1/sse?clientId=client-1&passcode=s3cret")
+	if err != nil {
+		t.Fatalf("failed to open stream: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %v, want %v", resp.StatusCode, http.StatusOK)
+	}
+
+	reader := bufio.NewReader(resp.Body)
+	readData := func() map[string]any {
+		t.Helper()
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				t.Fatalf("failed to read event: %v", err)
+			}
+			if data, ok := strings.CutPrefix(strings.TrimSpace(line), "data: "); ok {
+				var decoded map[string]any
+				if err := json.Unmarshal([]byte(data), &decoded); err != nil {
+					t.Fatalf("failed to decode event: %v", err)
+				}
+				return decoded
+			}
+		}
+	}
+
+	sessionID, _ := readData()["sessionId"].(string)
+	if sessionID == "" {
+		t.Fatal("expected the session ID first")
+	}
+	if msg := readData(); msg["type"] != "update-client-id" {
+		t.Fatalf("expected the client ID, got %v", msg)
+	}
+
+	post := func(sessionID string) int {
+		t.Helper()
+		resp, err := http.Post(srv.URL+"/planning/room-1/sse/"+sessionID, "application/json", strings.NewReader(`{"type": "reset", "requestId": "req-1"}`))
+		if err != nil {
+			t.Fatalf("failed to post message: %v", err)
+		}
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	if status := post(sessionID); status != http.StatusAccepted {
+		t.Fatalf("status = %v, want %v", status, http.StatusAccepted)
+	}
+	if msg := readData(); msg["type"] != "ack" || msg["requestId"] != "req-1" {
+		t.Fatalf("expected an ack on the stream, got %v", msg)
+	}
+	if status := post("unknown"); status != http.StatusNotFound {
+		t.Errorf("status = %v, want %v", status, http.StatusNotFound)
+	}
+}
@@ -0,0 +1,63 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"planning-poker/internal/infra/bus"
+
+	"github.com/bruno303/go-toolkit/pkg/log"
+	"github.com/gorilla/mux"
+)
+
+type SSEMessageAPI struct {
+	busFactory *bus.SSEBusFactory
+	logger     log.Logger
+}
+
+var _ API = (*SSEMessageAPI)(nil)
+
+// @Summary Send a message on an event stream
+// @Description Sends a message of the websocket protocol as the client of an event stream. The ack or error reply arrives on the stream. Sessions are only known to the instance serving their stream.
+// @Tags rooms
+// @Accept json
+// @Param roomID path string true "Room ID"
+// @Param sessionID path string true "Session ID sent in the session event of the stream"
+// @Param message body object true "Message of the websocket protocol"
+// @Success 202 {string} string "Message handled, the reply is sent on the stream"
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse "Unknown session"
+// @Failure 413 {object} ErrorResponse "Message too large"
+// @Router /planning/{roomID}/sse/{sessionID} [post]
+func NewSSEMessageAPI(busFactory *bus.SSEBusFactory) *SSEMessageAPI {
+	return &SSEMessageAPI{
+		busFactory: busFactory,
+		logger:     log.NewLogger("planningpoker.api.ssemessage"),
+	}
+}
+
+func (api *SSEMessageAPI) Endpoint() string {
+	return "/planning/{roomID}/sse/{sessionID}"
+}
+
+func (api *SSEMessageAPI) Methods() []string {
+	return []string{"POST"}
+}
+
+func (api *SSEMessageAPI) Handle() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+
+		err := api.busFactory.Dispatch(r.Context(), vars["roomID"], vars["sessionID"], r.Body)
+		switch {
+		case errors.Is(err, bus.ErrSessionNotFound):
+			SendJsonErrorMsg(w, http.StatusNotFound, "Session not found")
+		case errors.Is(err, bus.ErrMessageTooLarge):
+			SendJsonError(w, http.StatusRequestEntityTooLarge, err)
+		case err != nil:
+			api.logger.Warn(r.Context(), "Failed to read message for session of room %s: %v", vars["roomID"], err)
+			SendJsonError(w, http.StatusBadRequest, err)
+		default:
+			w.WriteHeader(http.StatusAccepted)
+		}
+	})
+}
@@ -7,7 +7,7 @@ import (
 	"github.com/gorilla/mux"
 )
 
-//go:embed swagger/swagger.yaml swagger/swagger.json
+//go:embed swagger/swagger.yaml swagger/swagger.json swagger/asyncapi.json
 var swaggerFS embed.FS
 
 type SwaggerAPI struct{}
@@ -50,6 +50,15 @@ func (s SwaggerAPI) Handle() http.Handler {
 			w.Header().Set("Content-Type", "application/json")
 			w.WriteHeader(http.StatusOK)
 			_, _ = w.Write(data)
+		case "asyncapi.json":
+			data, err := swaggerFS.ReadFile("swagger/asyncapi.json")
+			if err != nil {
+				http.Error(w, "Failed to load AsyncAPI spec", http.StatusInternalServerError)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write(data)
 		default:
 			http.NotFound(w, r)
 		}
@@ -0,0 +1,43 @@
+package http
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"planning-poker/internal/infra/bus"
+	"testing"
+
+	"github.com/gorilla/mux"
+)
+
+func TestSwaggerAPI_ServesAsyncAPI(t *testing.T) {
+	router := mux.NewRouter()
+	api := NewSwaggerAPI()
+	router.Handle(api.Endpoint(), api.Handle()).Methods(api.Methods()...)
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/swagger/asyncapi.json", nil))
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rr.Code)
+	}
+	if ct := rr.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("expected application/json, got %s", ct)
+	}
+}
+
+func TestAsyncAPISpec_IsUpToDate(t *testing.T) {
+	embedded, err := swaggerFS.ReadFile("swagger/asyncapi.json")
+	if err != nil {
+		t.Fatalf("failed to read embedded spec: %v", err)
+	}
+
+	document, err := bus.AsyncAPI(bus.LatestProtocol)
+	if err != nil {
+		t.Fatalf("failed to build spec: %v", err)
+	}
+
+	if !bytes.Equal(embedded, append(document, '\n')) {
+		t.Error("swagger/asyncapi.json is stale, run go generate ./...")
+	}
+}
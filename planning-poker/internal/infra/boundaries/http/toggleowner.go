@@ -30,6 +30,8 @@ var _ API = (*ToggleOwnerAPI)(nil)
 // @Success 200 {object} map[string]string
 // @Failure 400 {object} ErrorResponse
 // @Failure 401 {object} ErrorResponse
+// @Failure 403 {string} string "Key lacks the scope of the endpoint"
+// @Failure 429 {string} string "Too many requests"
 // @Failure 404 {object} ErrorResponse
 // @Security ApiKeyAuth
 // @Router /admin/rooms/{roomID}/client/{clientID}/owner [post]
@@ -39,7 +41,7 @@ func NewToggleOwnerAPI(
 ) ToggleOwnerAPI {
 	return ToggleOwnerAPI{
 		adminToggleOwner:    adminToggleOwner,
-		adminAuthMiddleware: adminAuthMiddleware,
+		adminAuthMiddleware: adminAuthMiddleware.WithScope(middleware.ScopeClientsOwner),
 		logger:              log.NewLogger("toggleownerapi"),
 	}
 }
@@ -74,6 +76,7 @@ func (api ToggleOwnerAPI) execute() http.Handler {
 		err := api.adminToggleOwner.Execute(ctx, usecase.AdminToggleOwnerCommand{
 			RoomID:         roomID,
 			TargetClientID: clientID,
+			Actor:          middleware.AdminKeyName(ctx),
 		})
 		if errors.Is(err, domain.ErrLastOwner) {
 			SendJsonErrorMsg(w, http.StatusBadRequest, "Cannot remove the last owner")
@@ -59,7 +59,7 @@ func TestToggleOwnerAPI_Handle_Success(t *testing.T) {
 	api := NewToggleOwnerAPI(mockUseCase, middleware.NewAdminMiddleware(apiKey))
 
 	mockUseCase.EXPECT().
-		Execute(gomock.Any(), usecase.AdminToggleOwnerCommand{RoomID: roomID, TargetClientID: clientID}).
+		Execute(gomock.Any(), usecase.AdminToggleOwnerCommand{RoomID: roomID, TargetClientID: clientID, Actor: middleware.DefaultAdminKeyName}).
 		Return(nil)
 
 	handler := api.Handle()
@@ -2,10 +2,20 @@ package http
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
+	"planning-poker/internal/application/auth"
+	"planning-poker/internal/application/planningpoker/metric"
 	"planning-poker/internal/application/planningpoker/usecase"
+	"planning-poker/internal/domain"
+	"planning-poker/internal/domain/entity"
+	"planning-poker/internal/infra/boundaries/http/middleware"
 	"planning-poker/internal/infra/bus"
+	"slices"
+	"strings"
+	"sync"
 
 	"github.com/bruno303/go-toolkit/pkg/log"
 	"github.com/gorilla/mux"
@@ -16,10 +26,33 @@ type (
 	contextKey string
 
 	WebsocketAPI struct {
-		upgrader   websocket.Upgrader
-		usecases   usecase.UseCasesFacade
-		busFactory *bus.WebSocketBusFactory
-		logger     log.Logger
+		upgrader    websocket.Upgrader
+		usecases    usecase.UseCasesFacade
+		busFactory  *bus.WebSocketBusFactory
+		metric      metric.PlanningPokerMetric
+		cfg         WebsocketAPIConfig
+		connections *connectionLimiter
+		logger      log.Logger
+	}
+
+	WebsocketAPIConfig struct {
+		// AllowedOrigins may upgrade from a browser besides the API's own
+		// origin, "*" allows every origin
+		AllowedOrigins []string
+		// MaxConnectionsPerIP is counted per instance, no limit when zero
+		MaxConnectionsPerIP int
+		TrustForwardedFor   bool
+		// TokenVerifier authenticates participants, tokens are ignored when nil
+		TokenVerifier auth.TokenVerifier
+		// RequireAuth rejects upgrades without a token
+		RequireAuth bool
+	}
+
+	// connectionLimiter counts the open websockets of each IP address.
+	connectionLimiter struct {
+		max    int
+		mu     sync.Mutex
+		counts map[string]int
 	}
 )
 
@@ -29,21 +62,38 @@ var _ API = (*WebsocketAPI)(nil)
 // @Description Upgrades the HTTP connection to a WebSocket for real-time communication
 // @Tags rooms
 // @Param roomID path string true "Room ID"
+// @Param clientId query string false "Client ID to reconnect with"
+// @Param passcode query string false "Room passcode"
+// @Param invite query string false "Invite token created by the room owner"
+// @Param patches query bool false "Receive room-patch messages instead of the full room-state"
+// @Param protocol query string false "Protocol version, also negotiable with the planning-poker.<version> subprotocol; the latest by default" Enums(v1)
+// @Param token query string false "OIDC token of the participant, also accepted as an Authorization Bearer header"
 // @Success 101 {string} string "WebSocket upgrade successful"
+// @Failure 400 {object} ErrorResponse "Unsupported protocol version"
+// @Failure 401 {object} ErrorResponse "Missing or invalid token"
+// @Failure 403 {object} ErrorResponse "Origin not allowed"
+// @Failure 429 {object} ErrorResponse "Too many connections from the IP address"
 // @Router /planning/{roomID}/ws [get]
-func NewWebsocketAPI(usecases usecase.UseCasesFacade, websocketBusFactory *bus.WebSocketBusFactory) *WebsocketAPI {
-	return &WebsocketAPI{
-		upgrader: websocket.Upgrader{
-			ReadBufferSize:  1024,
-			WriteBufferSize: 1024,
-			CheckOrigin: func(r *http.Request) bool {
-				return true
-			},
-		},
-		usecases:   usecases,
-		busFactory: websocketBusFactory,
-		logger:     log.NewLogger("planningpoker.api.websocket"),
+func NewWebsocketAPI(
+	usecases usecase.UseCasesFacade,
+	websocketBusFactory *bus.WebSocketBusFactory,
+	metric metric.PlanningPokerMetric,
+	cfg WebsocketAPIConfig,
+) *WebsocketAPI {
+	api := &WebsocketAPI{
+		usecases:    usecases,
+		busFactory:  websocketBusFactory,
+		metric:      metric,
+		cfg:         cfg,
+		connections: newConnectionLimiter(cfg.MaxConnectionsPerIP),
+		logger:      log.NewLogger("planningpoker.api.websocket"),
+	}
+	api.upgrader = websocket.Upgrader{
+		ReadBufferSize:  1024,
+		WriteBufferSize: 1024,
+		CheckOrigin:     api.originAllowed,
 	}
+	return api
 }
 
 func (api *WebsocketAPI) Endpoint() string {
@@ -62,35 +112,71 @@ func (api *WebsocketAPI) Handle() http.Handler {
 			return
 		}
 
+		identity, ip, ok := api.admit(w, r, "Websocket upgrade")
+		if !ok {
+			return
+		}
+		defer api.connections.release(ip)
+
+		offered := websocket.Subprotocols(r)
+		protocol, err := bus.NegotiateProtocol(r.URL.Query().Get("protocol"), offered)
+		if err != nil {
+			SendJsonError(w, http.StatusBadRequest, err)
+			return
+		}
+		// browsers fail the handshake when the server picks a subprotocol
+		// they did not offer
+		var responseHeader http.Header
+		if subprotocol := bus.SubprotocolPrefix + protocol; slices.Contains(offered, subprotocol) {
+			responseHeader = http.Header{"Sec-Websocket-Protocol": {subprotocol}}
+		}
+
 		r = r.WithContext(context.WithValue(r.Context(), contextKey("roomID"), roomID))
-		ws, err := api.upgrader.Upgrade(w, r, nil)
+		ws, err := api.upgrader.Upgrade(w, r, responseHeader)
 		if err != nil {
 			api.logger.Error(r.Context(), "Error upgrading to WebSocket", err)
 			return
 		}
 
-		clientID := r.URL.Query().Get("clientId")
-		if clientID == "" {
-			createClientOutput, err := api.usecases.CreateClient.Execute(r.Context())
-			if err != nil {
-				api.logger.Error(r.Context(), "Error creating client", err)
-				SendErrorWebsocket(ws, "Error creating client")
-				return
-			}
-			clientID = createClientOutput.ClientID
+		clientID, err := api.clientID(r, identity)
+		if err != nil {
+			api.logger.Error(r.Context(), "Error creating client", err)
+			SendErrorWebsocket(ws, "Error creating client")
+			return
 		}
 
 		wsBus := api.busFactory.NewBus(bus.WebSocketBusFactoryInput{
 			ClientID: clientID,
 			RoomID:   roomID,
 			Socket:   ws,
+			Patches:  r.URL.Query().Get("patches") == "true",
+			Protocol: protocol,
 		})
 
 		output, err := api.usecases.JoinRoom.Execute(r.Context(), usecase.JoinRoomCommand{
 			RoomID:   roomID,
 			SenderID: clientID,
 			Bus:      wsBus,
+			Identity: identity,
+			Credentials: entity.Credentials{
+				Passcode:    r.URL.Query().Get("passcode"),
+				InviteToken: r.URL.Query().Get("invite"),
+			},
 		})
+		if errors.Is(err, domain.ErrRoomAccessDenied) {
+			api.logger.Info(r.Context(), "Client %s denied access to room %s", clientID, roomID)
+			SendCloseWebsocket(ws, websocket.ClosePolicyViolation, "Invalid room credentials")
+			return
+		}
+		if errors.Is(err, domain.ErrIdentityMismatch) {
+			api.logger.Info(r.Context(), "Client %s denied access to room %s: identity mismatch", clientID, roomID)
+			SendCloseWebsocket(ws, websocket.ClosePolicyViolation, "Client belongs to another identity")
+			return
+		}
+		if errors.Is(err, domain.ErrRoomNotFound) {
+			SendCloseWebsocket(ws, websocket.ClosePolicyViolation, "Room not found")
+			return
+		}
 		if err != nil {
 			api.logger.Error(r.Context(), fmt.Sprintf("Error joining room %s", roomID), err)
 			SendErrorWebsocket(ws, fmt.Sprintf("Error joining room %s", roomID))
@@ -102,3 +188,119 @@ func (api *WebsocketAPI) Handle() http.Handler {
 		wsBus.Listen(r.Context())
 	})
 }
+
+// admit checks the origin, the token and the connections of the IP address
+// of a new connection, answering the request when it is rejected. The
+// caller releases the connection of the IP address once done.
+func (api *WebsocketAPI) admit(w http.ResponseWriter, r *http.Request, kind string) (entity.Identity, string, bool) {
+	if !api.originAllowed(r) {
+		api.logger.Warn(r.Context(), "%s rejected: origin %s is not allowed", kind, r.Header.Get("Origin"))
+		api.metric.IncrementRejectedUpgrades(r.Context())
+		SendJsonErrorMsg(w, http.StatusForbidden, "Origin not allowed")
+		return entity.Identity{}, "", false
+	}
+
+	identity, err := api.authenticate(r)
+	if err != nil {
+		api.logger.Warn(r.Context(), "%s rejected: %v", kind, err)
+		api.metric.IncrementRejectedUpgrades(r.Context())
+		SendJsonErrorMsg(w, http.StatusUnauthorized, "Missing or invalid token")
+		return entity.Identity{}, "", false
+	}
+
+	ip := middleware.ClientIP(r, api.cfg.TrustForwardedFor)
+	if !api.connections.acquire(ip) {
+		api.logger.Warn(r.Context(), "%s rejected: %s already holds %d connections", kind, ip, api.cfg.MaxConnectionsPerIP)
+		api.metric.IncrementRejectedUpgrades(r.Context())
+		SendJsonErrorMsg(w, http.StatusTooManyRequests, "Too many connections")
+		return entity.Identity{}, "", false
+	}
+	return identity, ip, true
+}
+
+// clientID returns the client to connect as. Authenticated participants
+// always get the client of their identity, so they reconnect to it from any
+// device; anonymous ones without a client ID get a new client.
+func (api *WebsocketAPI) clientID(r *http.Request, identity entity.Identity) (string, error) {
+	if identity.Authenticated() {
+		return identity.ClientID(), nil
+	}
+	if clientID := r.URL.Query().Get("clientId"); clientID != "" {
+		return clientID, nil
+	}
+
+	output, err := api.usecases.CreateClient.Execute(r.Context())
+	if err != nil {
+		return "", err
+	}
+	return output.ClientID, nil
+}
+
+// authenticate verifies the token sent as the token query parameter or as a
+// bearer token, browsers cannot set headers on websocket upgrades. Without a
+// verifier every participant is anonymous.
+func (api *WebsocketAPI) authenticate(r *http.Request) (entity.Identity, error) {
+	if api.cfg.TokenVerifier == nil {
+		return entity.Identity{}, nil
+	}
+
+	token := r.URL.Query().Get("token")
+	if bearer, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok {
+		token = strings.TrimSpace(bearer)
+	}
+	if token == "" {
+		if api.cfg.RequireAuth {
+			return entity.Identity{}, errors.New("token required")
+		}
+		return entity.Identity{}, nil
+	}
+	return api.cfg.TokenVerifier.Verify(r.Context(), token)
+}
+
+// originAllowed lets through requests without an origin, which do not come
+// from browsers, and those from the API's own origin.
+func (api *WebsocketAPI) originAllowed(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	if u, err := url.Parse(origin); err == nil && strings.EqualFold(u.Host, r.Host) {
+		return true
+	}
+	return slices.ContainsFunc(api.cfg.AllowedOrigins, func(allowed string) bool {
+		return allowed == "*" || strings.EqualFold(strings.TrimSuffix(allowed, "/"), origin)
+	})
+}
+
+func newConnectionLimiter(maxPerIP int) *connectionLimiter {
+	return &connectionLimiter{
+		max:    maxPerIP,
+		counts: make(map[string]int),
+	}
+}
+
+func (l *connectionLimiter) acquire(ip string) bool {
+	if l.max <= 0 {
+		return true
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.counts[ip] >= l.max {
+		return false
+	}
+	l.counts[ip]++
+	return true
+}
+
+func (l *connectionLimiter) release(ip string) {
+	if l.max <= 0 {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.counts[ip]--; l.counts[ip] <= 0 {
+		delete(l.counts, ip)
+	}
+}
@@ -0,0 +1,200 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"planning-poker/internal/application/auth"
+	"planning-poker/internal/application/planningpoker/metric"
+	"planning-poker/internal/application/planningpoker/usecase"
+	"planning-poker/internal/domain/entity"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"go.uber.org/mock/gomock"
+)
+
+func newTestWebsocketAPI(cfg WebsocketAPIConfig) *WebsocketAPI {
+	return NewWebsocketAPI(usecase.UseCasesFacade{}, nil, metric.NewPlanningPokerMetric(), cfg)
+}
+
+func TestWebsocketAPI_OriginAllowed(t *testing.T) {
+	api := newTestWebsocketAPI(WebsocketAPIConfig{
+		AllowedOrigins: []string{"https://poker.example.com/", "http://localhost:3000"},
+	})
+
+	tests := []struct {
+		name     string
+		origin   string
+		expected bool
+	}{
+		{name: "no origin", origin: "", expected: true},
+		{name: "same origin", origin: "http://api.example.com", expected: true},
+		{name: "allowed origin", origin: "https://poker.example.com", expected: true},
+		{name: "allowed origin is case insensitive", origin: "http://LOCALHOST:3000", expected: true},
+		{name: "other origin", origin: "https://evil.example.com", expected: false},
+		{name: "other port", origin: "http://localhost:3001", expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "http://api.example.com/planning/room-1/ws", nil)
+			if tt.origin != "" {
+				req.Header.Set("Origin", tt.origin)
+			}
+			if got := api.originAllowed(req); got != tt.expected {
+				t.Errorf("originAllowed() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestWebsocketAPI_OriginAllowed_Wildcard(t *testing.T) {
+	api := newTestWebsocketAPI(WebsocketAPIConfig{AllowedOrigins: []string{"*"}})
+
+	req := httptest.NewRequest(http.MethodGet, "/planning/room-1/ws", nil)
+	req.Header.Set("Origin", "https://anywhere.example.com")
+	if !api.originAllowed(req) {
+		t.Error("expected every origin to be allowed")
+	}
+}
+
+func TestWebsocketAPI_Handle_RejectsUpgrades(t *testing.T) {
+	api := newTestWebsocketAPI(WebsocketAPIConfig{
+		AllowedOrigins:      []string{"http://localhost:3000"},
+		MaxConnectionsPerIP: 1,
+	})
+	// the IP address already holds its only connection
+	api.connections.acquire("192.0.2.1")
+
+	tests := []struct {
+		name           string
+		origin         string
+		expectedStatus int
+	}{
+		{name: "origin not allowed", origin: "https://evil.example.com", expectedStatus: http.StatusForbidden},
+		{name: "too many connections", origin: "http://localhost:3000", expectedStatus: http.StatusTooManyRequests},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/planning/room-1/ws", nil)
+			req.RemoteAddr = "192.0.2.1:51234"
+			req.Header.Set("Origin", tt.origin)
+			req = mux.SetURLVars(req, map[string]string{"roomID": "room-1"})
+			rec := httptest.NewRecorder()
+
+			api.Handle().ServeHTTP(rec, req)
+
+			if rec.Code != tt.expectedStatus {
+				t.Errorf("status = %v, want %v", rec.Code, tt.expectedStatus)
+			}
+		})
+	}
+}
+
+func TestWebsocketAPI_Authenticate(t *testing.T) {
+	alice := entity.Identity{Issuer: "https://id.example.com", Subject: "alice", Name: "Alice"}
+
+	t.Run("tokens are ignored without a verifier", func(t *testing.T) {
+		api := newTestWebsocketAPI(WebsocketAPIConfig{RequireAuth: true})
+		req := httptest.NewRequest(http.MethodGet, "/planning/room-1/ws?token=abc", nil)
+
+		identity, err := api.authenticate(req)
+		if err != nil || identity.Authenticated() {
+			t.Errorf("expected an anonymous participant, got %+v, %v", identity, err)
+		}
+	})
+
+	t.Run("reads the token from the query", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		verifier := auth.NewMockTokenVerifier(ctrl)
+		verifier.EXPECT().Verify(gomock.Any(), "abc").Return(alice, nil)
+		api := newTestWebsocketAPI(WebsocketAPIConfig{TokenVerifier: verifier})
+		req := httptest.NewRequest(http.MethodGet, "/planning/room-1/ws?token=abc", nil)
+
+		identity, err := api.authenticate(req)
+		if err != nil || identity != alice {
+			t.Errorf("expected %+v, got %+v, %v", alice, identity, err)
+		}
+	})
+
+	t.Run("reads the token from the authorization header", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		verifier := auth.NewMockTokenVerifier(ctrl)
+		verifier.EXPECT().Verify(gomock.Any(), "abc").Return(alice, nil)
+		api := newTestWebsocketAPI(WebsocketAPIConfig{TokenVerifier: verifier})
+		req := httptest.NewRequest(http.MethodGet, "/planning/room-1/ws", nil)
+		req.Header.Set("Authorization", "Bearer abc")
+
+		identity, err := api.authenticate(req)
+		if err != nil || identity != alice {
+			t.Errorf("expected %+v, got %+v, %v", alice, identity, err)
+		}
+	})
+
+	t.Run("anonymous participants are allowed unless required", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		verifier := auth.NewMockTokenVerifier(ctrl)
+		req := httptest.NewRequest(http.MethodGet, "/planning/room-1/ws", nil)
+
+		identity, err := newTestWebsocketAPI(WebsocketAPIConfig{TokenVerifier: verifier}).authenticate(req)
+		if err != nil || identity.Authenticated() {
+			t.Errorf("expected an anonymous participant, got %+v, %v", identity, err)
+		}
+		if _, err := newTestWebsocketAPI(WebsocketAPIConfig{TokenVerifier: verifier, RequireAuth: true}).authenticate(req); err == nil {
+			t.Error("expected a missing token to be rejected")
+		}
+	})
+}
+
+func TestWebsocketAPI_Handle_RejectsInvalidTokens(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	verifier := auth.NewMockTokenVerifier(ctrl)
+	verifier.EXPECT().Verify(gomock.Any(), "expired").Return(entity.Identity{}, errors.Join(auth.ErrInvalidToken, errors.New("token expired")))
+	api := newTestWebsocketAPI(WebsocketAPIConfig{TokenVerifier: verifier})
+
+	req := httptest.NewRequest(http.MethodGet, "/planning/room-1/ws?token=expired", nil)
+	req = mux.SetURLVars(req, map[string]string{"roomID": "room-1"})
+	rec := httptest.NewRecorder()
+
+	api.Handle().ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("status = %v, want %v", rec.Code, http.StatusUnauthorized)
+	}
+}
+
+func TestConnectionLimiter(t *testing.T) {
+	limiter := newConnectionLimiter(2)
+
+	if !limiter.acquire("192.0.2.1") || !limiter.acquire("192.0.2.1") {
+		t.Fatal("expected two connections to be allowed")
+	}
+	if limiter.acquire("192.0.2.1") {
+		t.Error("expected a third connection to be rejected")
+	}
+	if !limiter.acquire("192.0.2.2") {
+		t.Error("expected other addresses to be counted apart")
+	}
+
+	limiter.release("192.0.2.1")
+	if !limiter.acquire("192.0.2.1") {
+		t.Error("expected a released connection to free a slot")
+	}
+
+	limiter.release("192.0.2.2")
+	if _, ok := limiter.counts["192.0.2.2"]; ok {
+		t.Error("expected addresses without connections to be forgotten")
+	}
+}
+
+func TestConnectionLimiter_Unlimited(t *testing.T) {
+	limiter := newConnectionLimiter(0)
+
+	for range 100 {
+		if !limiter.acquire("192.0.2.1") {
+			t.Fatal("expected no limit")
+		}
+	}
+}
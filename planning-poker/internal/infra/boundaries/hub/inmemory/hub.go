@@ -4,93 +4,182 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"planning-poker/internal/application/timer"
 	"planning-poker/internal/domain"
 	"planning-poker/internal/domain/entity"
 	"planning-poker/internal/infra/boundaries/hub/clientcollection"
+	"slices"
+	"sync"
 
 	"github.com/bruno303/go-toolkit/pkg/log"
 	"github.com/bruno303/go-toolkit/pkg/trace"
 	"github.com/samber/lo"
 )
 
+// InMemoryHub keeps everything in the process, for single node deployments.
+// It is safe for concurrent use. Every load rebuilds the room from its log,
+// so callers never share a room and a failed change leaves nothing behind;
+// saving swaps in a new snapshot of the room, which is never changed again
+// and can be read without holding the hub.
 type InMemoryHub struct {
+	// latest snapshot of every room, rebuilt whenever its log grows
 	Rooms   map[string]*entity.Room
 	Clients map[string]*entity.Client
 	Buses   map[string]domain.Bus
+	Events  map[string][]entity.RoomEvent
+	mu      sync.RWMutex
 	logger  log.Logger
+	clock   timer.Clock
+
+	keepEmptyRooms bool
 }
 
-var _ domain.Hub = (*InMemoryHub)(nil)
+var (
+	_ domain.Hub      = (*InMemoryHub)(nil)
+	_ domain.AdminHub = (*InMemoryHub)(nil)
+)
 
 func NewHub() *InMemoryHub {
 	return &InMemoryHub{
 		Rooms:   make(map[string]*entity.Room),
 		Clients: make(map[string]*entity.Client),
 		Buses:   make(map[string]domain.Bus),
+		Events:  make(map[string][]entity.RoomEvent),
 		logger:  log.NewLogger("inmemory.hub"),
+		clock:   timer.SystemClock{},
 	}
 }
 
+// SetClock changes the clock that dates the events of the rooms.
+func (h *InMemoryHub) SetClock(clock timer.Clock) {
+	h.clock = clock
+}
+
+// KeepEmptyRooms leaves rooms in place when their last client leaves, so that
+// participants can come back until the reaper removes them.
+func (h *InMemoryHub) KeepEmptyRooms() {
+	h.keepEmptyRooms = true
+}
+
 func (h *InMemoryHub) NewRoom(ctx context.Context) (*entity.Room, error) {
-	room, _ := trace.Trace(ctx, trace.NameConfig("InMemoryHub", "NewRoom"), func(ctx context.Context) (any, error) {
+	room, err := trace.Trace(ctx, trace.NameConfig("InMemoryHub", "NewRoom"), func(ctx context.Context) (any, error) {
 		room := entity.NewRoom(clientcollection.New())
-		h.Rooms[room.ID] = room
+		if err := h.storeRoom(room); err != nil {
+			return nil, err
+		}
 		return room, nil
 	})
+	if err != nil {
+		return nil, err
+	}
 
 	return room.(*entity.Room), nil
 }
 
 func (h *InMemoryHub) NewRoomWithID(ctx context.Context, roomID string) (*entity.Room, error) {
-	room, _ := trace.Trace(ctx, trace.NameConfig("InMemoryHub", "NewRoomWithID"), func(ctx context.Context) (any, error) {
+	room, err := trace.Trace(ctx, trace.NameConfig("InMemoryHub", "NewRoomWithID"), func(ctx context.Context) (any, error) {
 		room := entity.NewRoomWithID(roomID, clientcollection.New())
-		h.Rooms[room.ID] = room
+		if err := h.storeRoom(room); err != nil {
+			return nil, err
+		}
 		return room, nil
 	})
+	if err != nil {
+		return nil, err
+	}
 
 	return room.(*entity.Room), nil
 }
 
-func (h *InMemoryHub) LoadRoom(_ context.Context, roomID string) (*entity.Room, error) {
-	room, ok := h.Rooms[roomID]
+func (h *InMemoryHub) storeRoom(room *entity.Room) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return h.appendEvents(room)
+}
+
+// LoadRoom rebuilds the room from its log, the caller gets a copy of its own.
+func (h *InMemoryHub) LoadRoom(ctx context.Context, roomID string) (*entity.Room, error) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	events, ok := h.Events[roomID]
 	if !ok {
 		return nil, domain.ErrRoomNotFound
 	}
 
-	return room, nil
+	return entity.RebuildRoom(ctx, roomID, clientcollection.New(), events)
+}
+
+// snapshot returns the latest snapshot of a room. It must only be read.
+func (h *InMemoryHub) snapshot(roomID string) (*entity.Room, bool) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	room, ok := h.Rooms[roomID]
+	return room, ok
 }
 
 func (h *InMemoryHub) RemoveRoom(roomID string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.removeRoom(roomID)
+}
+
+func (h *InMemoryHub) removeRoom(roomID string) {
 	delete(h.Rooms, roomID)
+	delete(h.Events, roomID)
 }
 
 func (h *InMemoryHub) FindClientByID(clientID string) (*entity.Client, bool) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
 	client, ok := h.Clients[clientID]
 	return client, ok
 }
 
 func (h *InMemoryHub) AddClient(c *entity.Client) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
 	h.Clients[c.ID] = c
+	if room := c.Room(); room != nil {
+		if err := h.appendEvents(room); err != nil {
+			h.logger.Error(context.Background(), fmt.Sprintf("Failed to save room %s after adding client %s", room.ID, c.ID), err)
+		}
+	}
 }
 
 func (h *InMemoryHub) AddBus(_ context.Context, clientID string, bus domain.Bus) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
 	h.Buses[clientID] = bus
 }
 
 func (h *InMemoryHub) GetBus(clientID string) (domain.Bus, bool) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
 	bus, ok := h.Buses[clientID]
 	return bus, ok
 }
 
 func (h *InMemoryHub) RemoveBus(_ context.Context, clientID string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
 	delete(h.Buses, clientID)
 }
 
 func (h *InMemoryHub) RemoveClient(ctx context.Context, clientID string, roomID string) error {
 	_, err := trace.Trace(ctx, trace.NameConfig("InMemoryHub", "RemoveClient"), func(ctx context.Context) (any, error) {
-
+		h.mu.Lock()
 		delete(h.Clients, clientID)
-		h.RemoveBus(ctx, clientID)
+		delete(h.Buses, clientID)
+		h.mu.Unlock()
 
 		room, err := h.LoadRoom(ctx, roomID)
 		if err != nil {
@@ -105,8 +194,14 @@ func (h *InMemoryHub) RemoveClient(ctx context.Context, clientID string, roomID
 		if err != nil {
 			return nil, err
 		}
-		if room.IsEmpty() {
-			h.RemoveRoom(room.ID)
+
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		if err := h.appendEvents(room); err != nil {
+			return nil, err
+		}
+		if room.IsEmpty() && !h.keepEmptyRooms {
+			h.removeRoom(room.ID)
 		}
 		return nil, nil
 	})
@@ -115,19 +210,71 @@ func (h *InMemoryHub) RemoveClient(ctx context.Context, clientID string, roomID
 }
 
 func (h *InMemoryHub) SaveRoom(_ context.Context, room *entity.Room) error {
-	// In-memory hub doesn't need to persist
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return h.appendEvents(room)
+}
+
+// RoomEvents returns the log of a room, oldest event first.
+func (h *InMemoryHub) RoomEvents(_ context.Context, roomID string) ([]entity.RoomEvent, error) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	events, ok := h.Events[roomID]
+	if !ok {
+		return nil, domain.ErrRoomNotFound
+	}
+	return slices.Clone(events), nil
+}
+
+// appendEvents fails with ErrVersionConflict when the log moved past the
+// version the room was loaded at. Otherwise it replaces the snapshot of the
+// room, the caller keeps its copy. The caller holds the write lock.
+func (h *InMemoryHub) appendEvents(room *entity.Room) error {
+	events := h.Events[room.ID]
+	if stored := int64(len(events)); stored != room.BaseVersion() {
+		return fmt.Errorf("save room %s at version %d, stored version is %d: %w", room.ID, room.BaseVersion(), stored, domain.ErrVersionConflict)
+	}
+
+	pending := room.PendingEvents()
+	if len(pending) == 0 {
+		return nil
+	}
+
+	now := h.clock.Now()
+	for _, event := range pending {
+		event.OccurredAt = now
+		events = append(events, event)
+	}
+
+	snapshot, err := entity.RebuildRoom(context.Background(), room.ID, clientcollection.New(), events)
+	if err != nil {
+		return fmt.Errorf("failed to rebuild room %s: %w", room.ID, err)
+	}
+
+	h.Events[room.ID] = events
+	h.Rooms[room.ID] = snapshot
+	room.Touch(now)
+	room.ClearPendingEvents()
 	return nil
 }
 
 func (h *InMemoryHub) BroadcastToRoom(ctx context.Context, roomID string, message any) error {
 	_, err := trace.Trace(ctx, trace.NameConfig("InMemoryHub", "BroadcastToRoom"), func(ctx context.Context) (any, error) {
 
-		room, err := h.LoadRoom(ctx, roomID)
-		if err != nil {
-			return nil, err
+		room, ok := h.snapshot(roomID)
+		if !ok {
+			return nil, domain.ErrRoomNotFound
 		}
 
+		// messages are sent without holding the hub, a slow client must not
+		// block the others
 		for _, client := range room.Clients.Values() {
+			if client.Bot {
+				// bots read the room through the API
+				continue
+			}
 			bus, ok := h.GetBus(client.ID)
 			if !ok {
 				h.logger.Warn(ctx, "bus not found for client %s", client.ID)
@@ -143,8 +290,17 @@ func (h *InMemoryHub) BroadcastToRoom(ctx context.Context, roomID string, messag
 	return err
 }
 
-func (h *InMemoryHub) GetRooms() []*entity.Room {
-	return lo.MapToSlice(h.Rooms, func(key string, room *entity.Room) *entity.Room {
+// ListRooms pages the rooms by ID unless the query sorts them, the map has no
+// stable order to page through.
+func (h *InMemoryHub) ListRooms(ctx context.Context, query domain.RoomQuery) (domain.RoomPage, error) {
+	h.mu.RLock()
+	rooms := lo.MapToSlice(h.Rooms, func(key string, room *entity.Room) *entity.Room {
 		return room
 	})
+	h.mu.RUnlock()
+
+	if query.SortBy == domain.RoomSortNone {
+		domain.SortRooms(rooms, domain.RoomSortID, false)
+	}
+	return domain.PageRooms(rooms, query, h.clock.Now())
 }
@@ -3,8 +3,12 @@ package inmemory
 import (
 	"context"
 	"errors"
+	"fmt"
 	"planning-poker/internal/domain"
 	"planning-poker/internal/domain/entity"
+	"planning-poker/internal/infra/boundaries/hub/clientcollection"
+	"slices"
+	"sync"
 	"testing"
 
 	"go.uber.org/mock/gomock"
@@ -24,8 +28,8 @@ func TestNewRoom(t *testing.T) {
 	if len(hub.Rooms) != 1 {
 		t.Errorf("expected hub.Rooms to have 1 room, got %d", len(hub.Rooms))
 	}
-	if hub.Rooms[room.ID] != room {
-		t.Errorf("expected hub.Rooms[0] to be the created room")
+	if stored, ok := hub.Rooms[room.ID]; !ok || stored == room {
+		t.Errorf("expected hub.Rooms to keep its own copy of the created room")
 	}
 	if room.Clients == nil {
 		t.Error("expected room.Clients to be initialized")
@@ -47,7 +51,7 @@ func TestNewRoomWithID(t *testing.T) {
 	if room.ID != "room-123" {
 		t.Fatalf("expected room ID room-123, got %s", room.ID)
 	}
-	if hub.Rooms[room.ID] != room {
+	if _, ok := hub.Rooms[room.ID]; !ok {
 		t.Fatal("expected hub to store room under explicit ID")
 	}
 }
@@ -64,8 +68,11 @@ func TestLoadRoom(t *testing.T) {
 	if err != nil {
 		t.Fatalf("expected to find room but got error: %v", err)
 	}
-	if got != room {
-		t.Errorf("expected to get the created room, got different room")
+	if got.ID != room.ID || got.Version != room.Version {
+		t.Errorf("expected to get the created room, got %s at version %d", got.ID, got.Version)
+	}
+	if got == room || got == hub.Rooms[room.ID] {
+		t.Error("expected to get a copy of the room")
 	}
 
 	_, err = hub.LoadRoom(ctx, "non-existent-id")
@@ -94,7 +101,7 @@ func TestRemoveRoom(t *testing.T) {
 	if len(hub.Rooms) != 1 {
 		t.Errorf("expected 1 room after removal, got %d", len(hub.Rooms))
 	}
-	if hub.Rooms[room2.ID] != room2 {
+	if _, ok := hub.Rooms[room2.ID]; !ok {
 		t.Errorf("expected remaining room to be room2")
 	}
 
@@ -198,7 +205,7 @@ func TestRemoveClient_Success(t *testing.T) {
 	if err != nil {
 		t.Fatalf("expected no error, got %v", err)
 	}
-	client := &entity.Client{ID: "client1", Name: "Alice"}
+	client := room.NewClient("client1")
 	hub.AddClient(client)
 
 	ctrl := gomock.NewController(t)
@@ -206,9 +213,6 @@ func TestRemoveClient_Success(t *testing.T) {
 	mockBus := domain.NewMockBus(ctrl)
 	hub.AddBus(ctx, client.ID, mockBus)
 
-	// Add client to room
-	room.Clients.Add(client)
-
 	err = hub.RemoveClient(ctx, client.ID, room.ID)
 	if err != nil {
 		t.Fatalf("expected no error, got %v", err)
@@ -261,7 +265,7 @@ func TestRemoveClient_EmptyRoomRemoval(t *testing.T) {
 	if err != nil {
 		t.Fatalf("expected no error, got %v", err)
 	}
-	client := &entity.Client{ID: "client1", Name: "Alice"}
+	client := room.NewClient("client1")
 	hub.AddClient(client)
 
 	ctrl := gomock.NewController(t)
@@ -269,8 +273,6 @@ func TestRemoveClient_EmptyRoomRemoval(t *testing.T) {
 	mockBus := domain.NewMockBus(ctrl)
 	hub.AddBus(ctx, client.ID, mockBus)
 
-	room.Clients.Add(client)
-
 	if len(hub.Rooms) != 1 {
 		t.Fatalf("expected 1 room, got %d", len(hub.Rooms))
 	}
@@ -286,6 +288,29 @@ func TestRemoveClient_EmptyRoomRemoval(t *testing.T) {
 	}
 }
 
+func TestRemoveClient_RoomWithOnlyBotsLeftIsRemoved(t *testing.T) {
+	ctx := context.Background()
+	hub := NewHub()
+
+	room, err := hub.NewRoom(ctx)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if _, err := room.NewBot(ctx, ""); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	client := room.NewClient("client1")
+	hub.AddClient(client)
+
+	if err := hub.RemoveClient(ctx, client.ID, room.ID); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if _, err := hub.LoadRoom(ctx, room.ID); !errors.Is(err, domain.ErrRoomNotFound) {
+		t.Errorf("expected the room of a bot alone to be removed, got %v", err)
+	}
+}
+
 func TestBroadcastToRoom_Success(t *testing.T) {
 	ctx := context.Background()
 	ctrl := gomock.NewController(t)
@@ -297,13 +322,11 @@ func TestBroadcastToRoom_Success(t *testing.T) {
 		t.Fatalf("expected no error, got %v", err)
 	}
 
-	client1 := &entity.Client{ID: "client1", Name: "Alice"}
-	client2 := &entity.Client{ID: "client2", Name: "Bob"}
+	client1 := room.NewClient("client1")
+	client2 := room.NewClient("client2")
 
 	hub.AddClient(client1)
 	hub.AddClient(client2)
-	room.Clients.Add(client1)
-	room.Clients.Add(client2)
 
 	mockBus1 := domain.NewMockBus(ctrl)
 	mockBus2 := domain.NewMockBus(ctrl)
@@ -344,9 +367,8 @@ func TestBroadcastToRoom_BusNotFound(t *testing.T) {
 	if err != nil {
 		t.Fatalf("expected no error, got %v", err)
 	}
-	client := &entity.Client{ID: "client1", Name: "Alice"}
+	client := room.NewClient("client1")
 	hub.AddClient(client)
-	room.Clients.Add(client)
 
 	// No bus added for client
 	message := map[string]string{"type": "test"}
@@ -369,9 +391,8 @@ func TestBroadcastToRoom_SendError(t *testing.T) {
 		t.Fatalf("expected no error, got %v", err)
 	}
 
-	client := &entity.Client{ID: "client1", Name: "Alice"}
+	client := room.NewClient("client1")
 	hub.AddClient(client)
-	room.Clients.Add(client)
 
 	mockBus := domain.NewMockBus(ctrl)
 	hub.AddBus(ctx, client.ID, mockBus)
@@ -390,49 +411,83 @@ func TestBroadcastToRoom_SendError(t *testing.T) {
 	}
 }
 
-func TestGetRooms(t *testing.T) {
+func TestListRooms(t *testing.T) {
 	ctx := context.Background()
 	hub := NewHub()
 
 	// Empty hub
-	rooms := hub.GetRooms()
-	if len(rooms) != 0 {
-		t.Errorf("expected 0 rooms for empty hub, got %d", len(rooms))
+	page, err := hub.ListRooms(ctx, domain.RoomQuery{})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(page.Rooms) != 0 || page.NextCursor != "" {
+		t.Errorf("expected an empty last page for empty hub, got %d rooms and cursor %q", len(page.Rooms), page.NextCursor)
 	}
 
 	// Add rooms
-	room1, err := hub.NewRoom(ctx)
-	if err != nil {
-		t.Fatalf("expected no error, got %v", err)
+	for _, id := range []string{"room-c", "room-a", "room-b"} {
+		if _, err := hub.NewRoomWithID(ctx, id); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+	}
+
+	var ids []string
+	query := domain.RoomQuery{Limit: 2}
+	for {
+		page, err = hub.ListRooms(ctx, query)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		for _, room := range page.Rooms {
+			ids = append(ids, room.ID)
+		}
+		if page.NextCursor == "" {
+			break
+		}
+		query.Cursor = page.NextCursor
+	}
+
+	if !slices.Equal(ids, []string{"room-a", "room-b", "room-c"}) {
+		t.Errorf("expected rooms paged by ID, got %v", ids)
 	}
-	room2, err := hub.NewRoom(ctx)
-	if err != nil {
+}
+
+func TestListRoomsFiltersAndSorts(t *testing.T) {
+	ctx := context.Background()
+	hub := NewHub()
+
+	small, _ := hub.NewRoomWithID(ctx, "small")
+	small.NewClientWithIdentity("client-1", entity.Identity{Name: "Alice"})
+	if err := hub.SaveRoom(ctx, small); err != nil {
 		t.Fatalf("expected no error, got %v", err)
 	}
-	room3, err := hub.NewRoom(ctx)
-	if err != nil {
+
+	large, _ := hub.NewRoomWithID(ctx, "large")
+	for i, name := range []string{"Bob", "Carol", "Dave"} {
+		large.NewClientWithIdentity(fmt.Sprintf("client-%d", i+2), entity.Identity{Name: name})
+	}
+	if err := hub.SaveRoom(ctx, large); err != nil {
 		t.Fatalf("expected no error, got %v", err)
 	}
 
-	rooms = hub.GetRooms()
-	if len(rooms) != 3 {
-		t.Fatalf("expected 3 rooms, got %d", len(rooms))
+	page, err := hub.ListRooms(ctx, domain.RoomQuery{MinParticipants: 1, SortBy: domain.RoomSortParticipants, Descending: true})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
 	}
-
-	// Check all rooms are in the slice
-	roomIDs := make(map[string]bool)
-	for _, room := range rooms {
-		roomIDs[room.ID] = true
+	if len(page.Rooms) != 2 || page.Rooms[0].ID != large.ID || page.Rooms[1].ID != small.ID {
+		t.Errorf("expected large then small, got %v", page.Rooms)
 	}
 
-	if !roomIDs[room1.ID] {
-		t.Error("room1 not found in GetRooms result")
+	page, err = hub.ListRooms(ctx, domain.RoomQuery{OwnerName: "ali"})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
 	}
-	if !roomIDs[room2.ID] {
-		t.Error("room2 not found in GetRooms result")
+	if len(page.Rooms) != 1 || page.Rooms[0].ID != small.ID {
+		t.Errorf("expected only the room owned by Alice, got %v", page.Rooms)
 	}
-	if !roomIDs[room3.ID] {
-		t.Error("room3 not found in GetRooms result")
+
+	if _, err := hub.ListRooms(ctx, domain.RoomQuery{Cursor: "not-a-cursor"}); !errors.Is(err, domain.ErrInvalidCursor) {
+		t.Errorf("expected ErrInvalidCursor, got %v", err)
 	}
 }
 
@@ -450,7 +505,10 @@ func TestSaveRoom(t *testing.T) {
 	}
 
 	// Change some state in the room
-	room.Reveal = true
+	room.NewClient("client1")
+	if err := room.ToggleReveal(ctx, "client1"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
 	err = hub.SaveRoom(ctx, room)
 	if err != nil {
 		t.Fatalf("expected no error from SaveRoom, got %v", err)
@@ -464,4 +522,165 @@ func TestSaveRoom(t *testing.T) {
 	if !got.Reveal {
 		t.Errorf("expected room.Reveal to be true, got false")
 	}
+	if !hub.Rooms[room.ID].Reveal {
+		t.Errorf("expected the snapshot of the room to be revealed")
+	}
+}
+
+func TestSaveRoom_VersionConflict(t *testing.T) {
+	ctx := context.Background()
+	hub := NewHub()
+	room, err := hub.NewRoomWithID(ctx, "room1")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	// a room rebuilt from the same log is a second copy of it
+	stale, err := entity.RebuildRoom(ctx, room.ID, clientcollection.New(), hub.Events[room.ID])
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	room.NewClient("client1")
+	if err := hub.SaveRoom(ctx, room); err != nil {
+		t.Fatalf("expected no error from SaveRoom, got %v", err)
+	}
+
+	stale.NewClient("client2")
+	if err := hub.SaveRoom(ctx, stale); !errors.Is(err, domain.ErrVersionConflict) {
+		t.Fatalf("expected ErrVersionConflict, got %v", err)
+	}
+	if len(hub.Events[room.ID]) != 2 {
+		t.Errorf("expected 2 events to be stored, got %d", len(hub.Events[room.ID]))
+	}
+}
+
+func TestConcurrentAccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+	hub := NewHub()
+
+	var wg sync.WaitGroup
+	for i := range 20 {
+		wg.Go(func() {
+			// every goroutine changes its own room, as holding the room lock would
+			room, err := hub.NewRoomWithID(ctx, fmt.Sprintf("room-%d", i))
+			if err != nil {
+				t.Errorf("expected no error, got %v", err)
+				return
+			}
+			client := room.NewClient(fmt.Sprintf("client-%d", i))
+			hub.AddClient(client)
+
+			bus := domain.NewMockBus(ctrl)
+			bus.EXPECT().Send(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+			hub.AddBus(ctx, client.ID, bus)
+
+			if err := hub.BroadcastToRoom(ctx, room.ID, "hello"); err != nil {
+				t.Errorf("expected no error, got %v", err)
+			}
+			_, _ = hub.ListRooms(ctx, domain.RoomQuery{})
+			if _, err := hub.RoomEvents(ctx, room.ID); err != nil {
+				t.Errorf("expected no error, got %v", err)
+			}
+			if err := hub.RemoveClient(ctx, client.ID, room.ID); err != nil {
+				t.Errorf("expected no error, got %v", err)
+			}
+		})
+	}
+	wg.Wait()
+
+	if len(hub.Rooms) != 0 || len(hub.Clients) != 0 || len(hub.Buses) != 0 {
+		t.Errorf("expected hub to be empty, got %d rooms, %d clients and %d buses", len(hub.Rooms), len(hub.Clients), len(hub.Buses))
+	}
+}
+
+func TestLoadRoom_FailedChangeLeavesNothingBehind(t *testing.T) {
+	ctx := context.Background()
+	hub := NewHub()
+	room, err := hub.NewRoomWithID(ctx, "room1")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	// a change that is never saved, as when a use case fails halfway
+	loaded, err := hub.LoadRoom(ctx, room.ID)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	loaded.NewClient("client1")
+
+	got, err := hub.LoadRoom(ctx, room.ID)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !got.IsEmpty() || len(got.PendingEvents()) != 0 {
+		t.Errorf("expected the unsaved change not to show, got %d clients and %d pending events", got.Clients.Count(), len(got.PendingEvents()))
+	}
+	if !hub.Rooms[room.ID].IsEmpty() {
+		t.Error("expected the snapshot not to show the unsaved change")
+	}
+}
+
+func TestConcurrentAccess_SameRoom(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+	hub := NewHub()
+
+	room, err := hub.NewRoomWithID(ctx, "room1")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	owner := room.NewClient("owner")
+	hub.AddClient(owner)
+	bus := domain.NewMockBus(ctrl)
+	bus.EXPECT().Send(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	hub.AddBus(ctx, owner.ID, bus)
+
+	// writers take turns as holding the room lock would, readers never wait
+	var roomLock sync.Mutex
+	var wg sync.WaitGroup
+	for i := range 20 {
+		wg.Go(func() {
+			roomLock.Lock()
+			defer roomLock.Unlock()
+
+			room, err := hub.LoadRoom(ctx, "room1")
+			if err != nil {
+				t.Errorf("expected no error, got %v", err)
+				return
+			}
+			client := room.NewClient(fmt.Sprintf("client-%d", i))
+			hub.AddClient(client)
+			if err := room.UpdateClientName(ctx, client.ID, fmt.Sprintf("Client %d", i)); err != nil {
+				t.Errorf("expected no error, got %v", err)
+			}
+			if err := hub.SaveRoom(ctx, room); err != nil {
+				t.Errorf("expected no error, got %v", err)
+			}
+		})
+		wg.Go(func() {
+			page, err := hub.ListRooms(ctx, domain.RoomQuery{MinParticipants: 1})
+			if err != nil {
+				t.Errorf("expected no error, got %v", err)
+				return
+			}
+			for _, room := range page.Rooms {
+				_ = room.IsEmpty()
+				room.Clients.ForEach(func(client *entity.Client) { _ = client.Name })
+			}
+			if err := hub.BroadcastToRoom(ctx, "room1", "hello"); err != nil {
+				t.Errorf("expected no error, got %v", err)
+			}
+		})
+	}
+	wg.Wait()
+
+	got, err := hub.LoadRoom(ctx, "room1")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if got.Clients.Count() != 21 {
+		t.Errorf("expected 21 clients, got %d", got.Clients.Count())
+	}
 }
@@ -0,0 +1,4 @@
+package postgres
+
+//go:generate go tool mockgen -destination mocks.go -typed -package postgres . Database,Listener
+//go:generate go tool mockgen -destination pgxmocks.go -typed -package postgres github.com/jackc/pgx/v5 Tx,Row
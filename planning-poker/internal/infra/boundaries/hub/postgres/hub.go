@@ -0,0 +1,683 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"planning-poker/internal/application/planningpoker/usecase/dto"
+	"planning-poker/internal/domain"
+	"planning-poker/internal/domain/entity"
+	"planning-poker/internal/infra/boundaries/hub/clientcollection"
+	"planning-poker/internal/infra/boundaries/hub/serialization"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bruno303/go-toolkit/pkg/log"
+	"github.com/bruno303/go-toolkit/pkg/trace"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+type Database interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+	Begin(ctx context.Context) (pgx.Tx, error)
+}
+
+const (
+	notifyChannel = "planning_poker_updates"
+	// PostgreSQL rejects notification payloads of 8000 bytes or more
+	maxNotifyPayload = 7999
+)
+
+// saveRoomSQL is the compare-and-set of the room: it writes the snapshot only
+// while the stored version is the one the room was loaded at. Only rooms that
+// were never saved are inserted, so a late writer cannot bring back a room
+// that was removed.
+const saveRoomSQL = `
+INSERT INTO rooms (id, version, state)
+SELECT $1::TEXT, $3::BIGINT, $4::JSONB
+WHERE $2::BIGINT = 0
+ON CONFLICT (id) DO UPDATE
+SET version = EXCLUDED.version, state = EXCLUDED.state, updated_at = now()
+WHERE rooms.version = $2`
+
+const appendEventsSQL = `
+INSERT INTO room_events (room_id, sequence, event, occurred_at)
+SELECT $1::TEXT, e.sequence, e.event::JSONB, $4::TIMESTAMPTZ
+FROM unnest($2::BIGINT[], $3::TEXT[]) AS e(sequence, event)`
+
+var refreshProjectionsSQL = []string{
+	`DELETE FROM room_clients WHERE room_id = $1`,
+	`INSERT INTO room_clients (room_id, client_id, position, name, has_voted, is_spectator, is_owner)
+SELECT r.id, c.client->>'id', c.position - 1, c.client->>'name',
+       (c.client->>'hasVoted')::BOOLEAN, (c.client->>'isSpectator')::BOOLEAN, (c.client->>'isOwner')::BOOLEAN
+FROM rooms r, jsonb_array_elements(COALESCE(r.state->'clients', '[]')) WITH ORDINALITY AS c(client, position)
+WHERE r.id = $1`,
+	`DELETE FROM room_stories WHERE room_id = $1`,
+	`INSERT INTO room_stories (room_id, position, name, voted, result)
+SELECT r.id, s.position - 1, s.story->>'name', (s.story->>'voted')::BOOLEAN, (s.story->>'result')::REAL
+FROM rooms r, jsonb_array_elements(COALESCE(r.state->'stories', '[]')) WITH ORDINALITY AS s(story, position)
+WHERE r.id = $1`,
+}
+
+const storeBroadcastSQL = `
+WITH expired AS (
+    DELETE FROM room_broadcasts WHERE created_at < now() - INTERVAL '1 minute'
+)
+INSERT INTO room_broadcasts (room_id, payload) VALUES ($1, $2) RETURNING id`
+
+// lockBroadcastStateSQL reads the last room state broadcast and holds the room
+// until the next one is stored, so that broadcasts of a room are notified in
+// the order of their states.
+const lockBroadcastStateSQL = `SELECT broadcast_state FROM rooms WHERE id = $1 FOR UPDATE`
+
+const storeBroadcastStateSQL = `UPDATE rooms SET broadcast_state = $2 WHERE id = $1`
+
+// queryer is what Database and pgx.Tx have in common.
+type queryer interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+type (
+	PostgresHub struct {
+		db               Database
+		logger           log.Logger
+		buses            map[string]domain.Bus
+		busMux           sync.RWMutex
+		roomClientCounts map[string]int
+		wg               sync.WaitGroup
+		cancel           context.CancelFunc
+		keepEmptyRooms   bool
+	}
+	// BroadcastMessage is the payload of a notification. Payloads that do not
+	// fit in a notification are stored and passed by Ref instead.
+	BroadcastMessage struct {
+		RoomID  string `json:"roomId"`
+		Payload any    `json:"payload,omitempty"`
+		Ref     int64  `json:"ref,omitempty"`
+	}
+)
+
+var (
+	_ domain.Hub      = (*PostgresHub)(nil)
+	_ domain.AdminHub = (*PostgresHub)(nil)
+)
+
+// NewPostgresHub keeps rooms without expiration. Broadcasts go through
+// LISTEN/NOTIFY, so every instance listening on the database forwards them to
+// its own clients. The schema must be migrated beforehand, see Migrate.
+func NewPostgresHub(ctx context.Context, db Database, listener Listener) (*PostgresHub, error) {
+	hctx, cancel := context.WithCancel(context.Background())
+	hub := &PostgresHub{
+		db:               db,
+		logger:           log.NewLogger("postgres.hub"),
+		buses:            make(map[string]domain.Bus),
+		roomClientCounts: make(map[string]int),
+		cancel:           cancel,
+	}
+	hub.wg.Go(func() {
+		if err := listener.Listen(hctx, notifyChannel, hub.handleNotification); err != nil {
+			hub.logger.Error(hctx, "Stopped listening for room updates", err)
+		}
+	})
+	hub.logger.Info(ctx, "PostgresHub initialized")
+	return hub, nil
+}
+
+func (h *PostgresHub) Close() error {
+	h.cancel()
+	h.wg.Wait()
+	return nil
+}
+
+// KeepEmptyRooms leaves rooms in place when their last client leaves, so that
+// participants can come back until the reaper removes them.
+func (h *PostgresHub) KeepEmptyRooms() {
+	h.keepEmptyRooms = true
+}
+
+func (h *PostgresHub) NewRoom(ctx context.Context) (*entity.Room, error) {
+	room, err := trace.Trace(ctx, trace.NameConfig("PostgresHub", "NewRoom"), func(ctx context.Context) (any, error) {
+		room := entity.NewRoom(clientcollection.New())
+		if err := h.saveRoom(ctx, room); err != nil {
+			h.logger.Error(ctx, "Failed to save new room to Postgres", err)
+			return nil, err
+		}
+
+		return room, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return room.(*entity.Room), nil
+}
+
+func (h *PostgresHub) NewRoomWithID(ctx context.Context, roomID string) (*entity.Room, error) {
+	room, err := trace.Trace(ctx, trace.NameConfig("PostgresHub", "NewRoomWithID"), func(ctx context.Context) (any, error) {
+		room := entity.NewRoomWithID(roomID, clientcollection.New())
+		if err := h.saveRoom(ctx, room); err != nil {
+			h.logger.Error(ctx, "Failed to save new room to Postgres", err)
+			return nil, err
+		}
+
+		return room, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return room.(*entity.Room), nil
+}
+
+func (h *PostgresHub) LoadRoom(ctx context.Context, roomID string) (*entity.Room, error) {
+	var data []byte
+	err := h.db.QueryRow(ctx, "SELECT state FROM rooms WHERE id = $1", roomID).Scan(&data)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrRoomNotFound
+		}
+
+		h.logger.Error(ctx, fmt.Sprintf("Failed to load room %s from Postgres", roomID), err)
+		return nil, fmt.Errorf("load room %s: %w", roomID, err)
+	}
+
+	room, err := serialization.DeserializeRoom(data, clientcollection.New())
+	if err != nil {
+		return nil, fmt.Errorf("failed to deserialize room %s: %w", roomID, err)
+	}
+
+	return room, nil
+}
+
+func (h *PostgresHub) RemoveRoom(roomID string) {
+	ctx := context.Background()
+	// events, clients and stories of the room are deleted in cascade
+	if _, err := h.db.Exec(ctx, "DELETE FROM rooms WHERE id = $1", roomID); err != nil {
+		h.logger.Error(ctx, fmt.Sprintf("Failed to delete room %s from Postgres", roomID), err)
+	}
+}
+
+func (h *PostgresHub) FindClientByID(clientID string) (*entity.Client, bool) {
+	ctx := context.Background()
+
+	var roomID string
+	err := h.db.QueryRow(ctx, "SELECT room_id FROM room_clients WHERE client_id = $1 LIMIT 1", clientID).Scan(&roomID)
+	if err != nil {
+		if !errors.Is(err, pgx.ErrNoRows) {
+			h.logger.Error(ctx, fmt.Sprintf("Failed to find client %s in Postgres", clientID), err)
+		}
+		return nil, false
+	}
+
+	room, err := h.LoadRoom(ctx, roomID)
+	if err != nil {
+		return nil, false
+	}
+
+	return room.Clients.Filter(func(c *entity.Client) bool {
+		return c.ID == clientID
+	}).First()
+}
+
+func (h *PostgresHub) AddClient(c *entity.Client) {
+	ctx := context.Background()
+
+	// the client is stored along with its room
+	room := c.Room()
+	if err := h.saveRoom(ctx, room); err != nil {
+		h.logger.Error(ctx, fmt.Sprintf("Failed to save room %s after adding client %s", room.ID, c.ID), err)
+	}
+}
+
+func (h *PostgresHub) AddBus(ctx context.Context, clientID string, bus domain.Bus) {
+	h.busMux.Lock()
+	defer h.busMux.Unlock()
+
+	h.buses[clientID] = bus
+	roomID := bus.RoomID()
+	if roomID == "" {
+		h.logger.Warn(ctx, "Bus for client %s has empty RoomID", clientID)
+		return
+	}
+	h.roomClientCounts[roomID]++
+}
+
+func (h *PostgresHub) GetBus(clientID string) (domain.Bus, bool) {
+	h.busMux.RLock()
+	bus, ok := h.buses[clientID]
+	h.busMux.RUnlock()
+	return bus, ok
+}
+
+func (h *PostgresHub) RemoveBus(ctx context.Context, clientID string) {
+	h.logger.Debug(ctx, "Removing bus for client %s", clientID)
+	h.busMux.Lock()
+	defer h.busMux.Unlock()
+
+	bus, ok := h.buses[clientID]
+	if !ok {
+		return
+	}
+	delete(h.buses, clientID)
+
+	roomID := bus.RoomID()
+	if h.roomClientCounts[roomID] > 1 {
+		h.roomClientCounts[roomID]--
+	} else {
+		delete(h.roomClientCounts, roomID)
+	}
+}
+
+func (h *PostgresHub) RemoveClient(ctx context.Context, clientID string, roomID string) error {
+	h.logger.Debug(ctx, "Removing client %s from room %s", clientID, roomID)
+	_, err := trace.Trace(ctx, trace.NameConfig("PostgresHub", "RemoveClient"), func(ctx context.Context) (any, error) {
+		h.RemoveBus(ctx, clientID)
+
+		room, err := h.LoadRoom(ctx, roomID)
+		if err != nil {
+			if errors.Is(err, domain.ErrRoomNotFound) {
+				return nil, nil
+			}
+
+			return nil, err
+		}
+
+		if err := room.RemoveClient(ctx, clientID); err != nil {
+			return nil, err
+		}
+
+		if room.IsEmpty() && !h.keepEmptyRooms {
+			h.RemoveRoom(room.ID)
+			return nil, nil
+		}
+
+		return nil, h.saveRoom(ctx, room)
+	})
+
+	return err
+}
+
+// BroadcastToRoom notifies room states as patches against the state
+// broadcast before, see broadcastRoomState. Other messages are notified as
+// they are.
+func (h *PostgresHub) BroadcastToRoom(ctx context.Context, roomID string, message any) error {
+	_, err := trace.Trace(ctx, trace.NameConfig("PostgresHub", "BroadcastToRoom"), func(ctx context.Context) (any, error) {
+		if state, ok := message.(dto.RoomState); ok {
+			return nil, h.broadcastRoomState(ctx, roomID, state)
+		}
+		return nil, h.notify(ctx, h.db, roomID, message)
+	})
+
+	return err
+}
+
+// broadcastRoomState notifies the patch from the last state broadcast for the
+// room, versioned by the room, and the whole state only when there is none.
+// The buses turn patches back into states for the clients that want them.
+// States older than the last one broadcast are not notified.
+func (h *PostgresHub) broadcastRoomState(ctx context.Context, roomID string, state dto.RoomState) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("failed to marshal room state: %w", err)
+	}
+
+	tx, err := h.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to broadcast state of room %s: %w", roomID, err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	var current []byte
+	err = tx.QueryRow(ctx, lockBroadcastStateSQL, roomID).Scan(&current)
+	if errors.Is(err, pgx.ErrNoRows) {
+		// a removed room has no state to patch against
+		return h.notify(ctx, h.db, roomID, state)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read the last state broadcast for room %s: %w", roomID, err)
+	}
+
+	var previous *dto.RoomState
+	if current != nil {
+		previous = &dto.RoomState{}
+		if err := json.Unmarshal(current, previous); err != nil {
+			return fmt.Errorf("failed to unmarshal the last state broadcast for room %s: %w", roomID, err)
+		}
+		if previous.Version > state.Version {
+			h.logger.Debug(ctx, "Not broadcasting room state %d of room %s older than %d", state.Version, roomID, previous.Version)
+			return nil
+		}
+	}
+	update, ok := dto.NewRoomUpdate(previous, state)
+	if !ok {
+		return nil
+	}
+
+	if _, err := tx.Exec(ctx, storeBroadcastStateSQL, roomID, data); err != nil {
+		return fmt.Errorf("failed to store the state broadcast for room %s: %w", roomID, err)
+	}
+	// notifications go out when the transaction commits
+	if err := h.notify(ctx, tx, roomID, update); err != nil {
+		return err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to broadcast state of room %s: %w", roomID, err)
+	}
+
+	return nil
+}
+
+// notify passes the message to every instance listening on the database.
+func (h *PostgresHub) notify(ctx context.Context, db queryer, roomID string, message any) error {
+	data, err := json.Marshal(BroadcastMessage{RoomID: roomID, Payload: message})
+	if err != nil {
+		return fmt.Errorf("failed to marshal broadcast message: %w", err)
+	}
+
+	if len(data) > maxNotifyPayload {
+		payload, err := json.Marshal(message)
+		if err != nil {
+			return fmt.Errorf("failed to marshal broadcast message: %w", err)
+		}
+
+		var ref int64
+		if err := db.QueryRow(ctx, storeBroadcastSQL, roomID, payload).Scan(&ref); err != nil {
+			return fmt.Errorf("failed to store broadcast message: %w", err)
+		}
+		if data, err = json.Marshal(BroadcastMessage{RoomID: roomID, Ref: ref}); err != nil {
+			return fmt.Errorf("failed to marshal broadcast message: %w", err)
+		}
+	}
+
+	if _, err := db.Exec(ctx, "SELECT pg_notify($1, $2)", notifyChannel, string(data)); err != nil {
+		return fmt.Errorf("failed to notify room update to Postgres: %w", err)
+	}
+
+	return nil
+}
+
+// ListRooms filters, sorts and pages the rooms in the database. Its cursor is
+// the offset of the next page.
+func (h *PostgresHub) ListRooms(ctx context.Context, query domain.RoomQuery) (domain.RoomPage, error) {
+	offset, err := domain.ParseOffsetCursor(query.Cursor)
+	if err != nil {
+		return domain.RoomPage{}, err
+	}
+	sql, args, err := listRoomsSQL(query, offset, time.Now())
+	if err != nil {
+		return domain.RoomPage{}, err
+	}
+
+	var data []byte
+	if err := h.db.QueryRow(ctx, sql, args...).Scan(&data); err != nil {
+		return domain.RoomPage{}, fmt.Errorf("failed to list rooms: %w", err)
+	}
+
+	var states []json.RawMessage
+	if err := json.Unmarshal(data, &states); err != nil {
+		return domain.RoomPage{}, fmt.Errorf("failed to decode rooms: %w", err)
+	}
+
+	page := domain.RoomPage{Rooms: make([]*entity.Room, 0, len(states))}
+	// one room more than the page is read to know whether another page follows
+	if len(states) > query.PageSize() {
+		states = states[:query.PageSize()]
+		page.NextCursor = strconv.Itoa(offset + query.PageSize())
+	}
+	for _, state := range states {
+		room, err := serialization.DeserializeRoom(state, clientcollection.New())
+		if err != nil {
+			h.logger.Error(ctx, "Failed to deserialize room", err)
+			continue
+		}
+		page.Rooms = append(page.Rooms, room)
+	}
+
+	return page, nil
+}
+
+const (
+	participantsSQL = `jsonb_array_length(COALESCE(r.state->'clients', '[]'))`
+	backlogModeSQL  = `COALESCE((r.state->>'backlogMode')::BOOLEAN, FALSE)`
+	ownerNameSQL    = `COALESCE((SELECT MIN(lower(c.name)) FROM room_clients c WHERE c.room_id = r.id AND c.is_owner), '')`
+)
+
+// roomOrderSQL holds the only expressions rooms are ordered by, the sort of
+// the query is never written to the statement. The storage order is the
+// creation order.
+var roomOrderSQL = map[domain.RoomSort]string{
+	domain.RoomSortNone:         "r.created_at",
+	domain.RoomSortID:           "r.id",
+	domain.RoomSortParticipants: participantsSQL,
+	domain.RoomSortIdle:         "r.updated_at DESC",
+	domain.RoomSortBacklogMode:  backlogModeSQL,
+	domain.RoomSortOwner:        ownerNameSQL,
+}
+
+func listRoomsSQL(query domain.RoomQuery, offset int, now time.Time) (string, []any, error) {
+	order, ok := roomOrderSQL[query.SortBy]
+	if !ok {
+		return "", nil, fmt.Errorf("unknown room sort %q", query.SortBy)
+	}
+	tiebreak := "r.id"
+	if query.Descending {
+		order = reverseOrder(order)
+		tiebreak = "r.id DESC"
+	}
+
+	var conditions []string
+	var args []any
+	where := func(condition string, arg any) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+	if query.MinParticipants > 0 {
+		where(participantsSQL+" >= $%d", query.MinParticipants)
+	}
+	if query.MaxParticipants > 0 {
+		where(participantsSQL+" <= $%d", query.MaxParticipants)
+	}
+	if query.MinIdle > 0 {
+		where("r.updated_at <= $%d::TIMESTAMPTZ", now.Add(-query.MinIdle))
+	}
+	if query.BacklogMode != nil {
+		where(backlogModeSQL+" = $%d::BOOLEAN", *query.BacklogMode)
+	}
+	if query.OwnerName != "" {
+		where("EXISTS (SELECT 1 FROM room_clients c WHERE c.room_id = r.id AND c.is_owner AND c.name ILIKE $%d::TEXT)",
+			"%"+likeEscaper.Replace(query.OwnerName)+"%")
+	}
+
+	whereSQL := ""
+	if len(conditions) > 0 {
+		whereSQL = "WHERE " + strings.Join(conditions, " AND ")
+	}
+	args = append(args, offset, query.PageSize()+1)
+
+	sql := fmt.Sprintf(`
+SELECT COALESCE(jsonb_agg(page.state ORDER BY page.position), '[]') FROM (
+    SELECT r.state, row_number() OVER (ORDER BY %[1]s, %[2]s) AS position
+    FROM rooms r
+    %[3]s
+    ORDER BY %[1]s, %[2]s
+    OFFSET $%[4]d LIMIT $%[5]d
+) page`, order, tiebreak, whereSQL, len(args)-1, len(args))
+
+	return sql, args, nil
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+func reverseOrder(order string) string {
+	if expr, ok := strings.CutSuffix(order, " DESC"); ok {
+		return expr
+	}
+	return order + " DESC"
+}
+
+func (h *PostgresHub) SaveRoom(ctx context.Context, room *entity.Room) error {
+	return h.saveRoom(ctx, room)
+}
+
+// RoomEvents returns the log of a room, oldest event first.
+func (h *PostgresHub) RoomEvents(ctx context.Context, roomID string) ([]entity.RoomEvent, error) {
+	var data []byte
+	err := h.db.QueryRow(ctx,
+		"SELECT COALESCE(jsonb_agg(event ORDER BY sequence), '[]') FROM room_events WHERE room_id = $1",
+		roomID,
+	).Scan(&data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read events of room %s: %w", roomID, err)
+	}
+
+	var payloads []json.RawMessage
+	if err := json.Unmarshal(data, &payloads); err != nil {
+		return nil, fmt.Errorf("failed to decode events of room %s: %w", roomID, err)
+	}
+	if len(payloads) == 0 {
+		return nil, domain.ErrRoomNotFound
+	}
+
+	events := make([]entity.RoomEvent, 0, len(payloads))
+	for _, payload := range payloads {
+		event, err := serialization.DeserializeRoomEvent(payload)
+		if err != nil {
+			return nil, fmt.Errorf("failed to deserialize event of room %s: %w", roomID, err)
+		}
+		events = append(events, event)
+	}
+
+	return events, nil
+}
+
+// saveRoom writes the snapshot, the pending events and the client and story
+// projections of the room in one transaction. It fails with
+// ErrVersionConflict when someone else saved the room since it was loaded;
+// the stale writers a fencing token would stop are caught by the same check.
+func (h *PostgresHub) saveRoom(ctx context.Context, room *entity.Room) error {
+	events := room.PendingEvents()
+	sequences := make([]int64, 0, len(events))
+	payloads := make([]string, 0, len(events))
+	now := time.Now()
+	for _, event := range events {
+		event.OccurredAt = now
+		data, err := serialization.SerializeRoomEvent(event)
+		if err != nil {
+			return fmt.Errorf("failed to serialize room event: %w", err)
+		}
+		sequences = append(sequences, event.Sequence)
+		payloads = append(payloads, string(data))
+	}
+	room.Touch(now)
+
+	state, err := serialization.SerializeRoom(room)
+	if err != nil {
+		return fmt.Errorf("failed to serialize room: %w", err)
+	}
+
+	tx, err := h.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to save room %s: %w", room.ID, err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	tag, err := tx.Exec(ctx, saveRoomSQL, room.ID, room.BaseVersion(), room.Version, state)
+	if err != nil {
+		return fmt.Errorf("failed to save room %s: %w", room.ID, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("save room %s at version %d: %w", room.ID, room.BaseVersion(), domain.ErrVersionConflict)
+	}
+
+	if len(events) > 0 {
+		if _, err := tx.Exec(ctx, appendEventsSQL, room.ID, sequences, payloads, now); err != nil {
+			return fmt.Errorf("failed to append events of room %s: %w", room.ID, err)
+		}
+	}
+
+	for _, sql := range refreshProjectionsSQL {
+		if _, err := tx.Exec(ctx, sql, room.ID); err != nil {
+			return fmt.Errorf("failed to save clients and stories of room %s: %w", room.ID, err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to save room %s: %w", room.ID, err)
+	}
+	room.ClearPendingEvents()
+
+	return nil
+}
+
+func (h *PostgresHub) handleNotification(ctx context.Context, payload string) {
+	var msg BroadcastMessage
+	if err := json.Unmarshal([]byte(payload), &msg); err != nil {
+		h.logger.Error(ctx, "Failed to unmarshal broadcast message", err)
+		return
+	}
+
+	h.busMux.RLock()
+	local := h.roomClientCounts[msg.RoomID] > 0
+	h.busMux.RUnlock()
+	if !local {
+		return
+	}
+
+	opCtx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	defer cancel()
+
+	if msg.Ref != 0 {
+		var data []byte
+		if err := h.db.QueryRow(opCtx, "SELECT payload FROM room_broadcasts WHERE id = $1", msg.Ref).Scan(&data); err != nil {
+			h.logger.Error(ctx, fmt.Sprintf("Failed to read broadcast %d of room %s", msg.Ref, msg.RoomID), err)
+			return
+		}
+		if err := json.Unmarshal(data, &msg.Payload); err != nil {
+			h.logger.Error(ctx, "Failed to unmarshal broadcast message", err)
+			return
+		}
+	}
+
+	h.forwardToLocalClients(opCtx, msg.RoomID, msg.Payload)
+}
+
+func (h *PostgresHub) forwardToLocalClients(ctx context.Context, roomID string, message any) {
+	room, err := h.LoadRoom(ctx, roomID)
+	if errors.Is(err, domain.ErrRoomNotFound) {
+		// a room that was just closed cannot be looked up anymore, its last
+		// message goes to the buses still attached to it
+		h.busMux.RLock()
+		defer h.busMux.RUnlock()
+		for clientID, bus := range h.buses {
+			if bus.RoomID() == roomID {
+				h.send(ctx, clientID, bus, message)
+			}
+		}
+		return
+	}
+	if err != nil {
+		return
+	}
+
+	h.busMux.RLock()
+	defer h.busMux.RUnlock()
+	for _, client := range room.Clients.Values() {
+		bus, ok := h.buses[client.ID]
+		if !ok {
+			continue
+		}
+		h.send(ctx, client.ID, bus, message)
+	}
+}
+
+func (h *PostgresHub) send(ctx context.Context, clientID string, bus domain.Bus, message any) {
+	if err := bus.Send(ctx, message); err != nil {
+		h.logger.Warn(ctx, "Failed to send message to client %s: %v", clientID, err)
+	}
+}
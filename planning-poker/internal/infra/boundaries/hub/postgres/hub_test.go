@@ -0,0 +1,447 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"planning-poker/internal/application/planningpoker/usecase/dto"
+	"planning-poker/internal/domain"
+	"planning-poker/internal/domain/entity"
+	"planning-poker/internal/infra/boundaries/hub/clientcollection"
+	"planning-poker/internal/infra/boundaries/hub/serialization"
+
+	"github.com/bruno303/go-toolkit/pkg/log"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestPostgresHub_SaveRoom_LoadRoom(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockDB := NewMockDatabase(ctrl)
+	hub := newTestHub(mockDB)
+
+	room := entity.NewRoomWithID("room1", clientcollection.New())
+	room.NewClient("client1")
+
+	tx := expectTx(ctrl, mockDB)
+	tx.EXPECT().
+		Exec(gomock.Any(), saveRoomSQL, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, args ...any) (pgconn.CommandTag, error) {
+			assert.Equal(t, "room1", args[0])
+			assert.Equal(t, int64(0), args[1], "expected version")
+			assert.Equal(t, room.Version, args[2])
+			return pgconn.NewCommandTag("INSERT 0 1"), nil
+		})
+	tx.EXPECT().
+		Exec(gomock.Any(), appendEventsSQL, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, args ...any) (pgconn.CommandTag, error) {
+			assert.Equal(t, []int64{1, 2}, args[1], "sequences of the pending events")
+			assert.Len(t, args[2], 2)
+			return pgconn.NewCommandTag("INSERT 0 2"), nil
+		})
+	for _, sql := range refreshProjectionsSQL {
+		tx.EXPECT().Exec(gomock.Any(), sql, "room1").Return(pgconn.NewCommandTag(""), nil)
+	}
+	tx.EXPECT().Commit(gomock.Any()).Return(nil)
+
+	err := hub.SaveRoom(context.Background(), room)
+	assert.NoError(t, err)
+	assert.Empty(t, room.PendingEvents())
+
+	state, _ := serialization.SerializeRoom(room)
+	mockDB.EXPECT().QueryRow(gomock.Any(), "SELECT state FROM rooms WHERE id = $1", "room1").Return(scanRow(ctrl, state))
+
+	gotRoom, err := hub.LoadRoom(context.Background(), "room1")
+	assert.NoError(t, err)
+	assert.Equal(t, room.ID, gotRoom.ID)
+	assert.Equal(t, room.Version, gotRoom.Version)
+	assert.Equal(t, 1, gotRoom.Clients.Count())
+}
+
+func TestPostgresHub_SaveRoom_VersionConflict(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockDB := NewMockDatabase(ctrl)
+	hub := newTestHub(mockDB)
+
+	room := entity.NewRoomWithID("room1", clientcollection.New())
+	room.ClearPendingEvents()
+	room.NewClient("client1")
+
+	tx := expectTx(ctrl, mockDB)
+	tx.EXPECT().
+		Exec(gomock.Any(), saveRoomSQL, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, args ...any) (pgconn.CommandTag, error) {
+			assert.Equal(t, int64(1), args[1], "expected version")
+			return pgconn.NewCommandTag("INSERT 0 0"), nil
+		})
+
+	err := hub.SaveRoom(context.Background(), room)
+	assert.ErrorIs(t, err, domain.ErrVersionConflict)
+	assert.Len(t, room.PendingEvents(), 1)
+}
+
+func TestPostgresHub_LoadRoom_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockDB := NewMockDatabase(ctrl)
+	hub := newTestHub(mockDB)
+
+	row := NewMockRow(ctrl)
+	row.EXPECT().Scan(gomock.Any()).Return(pgx.ErrNoRows)
+	mockDB.EXPECT().QueryRow(gomock.Any(), gomock.Any(), "missing").Return(row)
+
+	_, err := hub.LoadRoom(context.Background(), "missing")
+	assert.ErrorIs(t, err, domain.ErrRoomNotFound)
+}
+
+func TestPostgresHub_FindClientByID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockDB := NewMockDatabase(ctrl)
+	hub := newTestHub(mockDB)
+
+	room := entity.NewRoomWithID("room1", clientcollection.New())
+	room.NewClient("client1")
+	state, _ := serialization.SerializeRoom(room)
+
+	row := NewMockRow(ctrl)
+	row.EXPECT().Scan(gomock.Any()).DoAndReturn(func(dest ...any) error {
+		*dest[0].(*string) = "room1"
+		return nil
+	})
+	mockDB.EXPECT().QueryRow(gomock.Any(), gomock.Any(), "client1").Return(row)
+	mockDB.EXPECT().QueryRow(gomock.Any(), gomock.Any(), "room1").Return(scanRow(ctrl, state))
+
+	client, ok := hub.FindClientByID("client1")
+	assert.True(t, ok)
+	assert.Equal(t, "client1", client.ID)
+	assert.Equal(t, "room1", client.Room().ID)
+}
+
+func TestPostgresHub_RemoveRoom(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockDB := NewMockDatabase(ctrl)
+	hub := newTestHub(mockDB)
+
+	mockDB.EXPECT().Exec(gomock.Any(), "DELETE FROM rooms WHERE id = $1", "room1").Return(pgconn.NewCommandTag("DELETE 1"), nil)
+
+	hub.RemoveRoom("room1")
+}
+
+func TestPostgresHub_BroadcastToRoom(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockDB := NewMockDatabase(ctrl)
+	hub := newTestHub(mockDB)
+
+	mockDB.EXPECT().
+		Exec(gomock.Any(), "SELECT pg_notify($1, $2)", notifyChannel, `{"roomId":"room1","payload":{"type":"room-state"}}`).
+		Return(pgconn.NewCommandTag("SELECT 1"), nil)
+
+	err := hub.BroadcastToRoom(context.Background(), "room1", map[string]string{"type": "room-state"})
+	assert.NoError(t, err)
+}
+
+func TestPostgresHub_BroadcastToRoom_LargePayloadIsPassedByReference(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockDB := NewMockDatabase(ctrl)
+	hub := newTestHub(mockDB)
+
+	message := map[string]string{"story": strings.Repeat("x", maxNotifyPayload)}
+
+	row := NewMockRow(ctrl)
+	row.EXPECT().Scan(gomock.Any()).DoAndReturn(func(dest ...any) error {
+		*dest[0].(*int64) = 42
+		return nil
+	})
+	mockDB.EXPECT().QueryRow(gomock.Any(), storeBroadcastSQL, "room1", gomock.Any()).Return(row)
+	mockDB.EXPECT().
+		Exec(gomock.Any(), "SELECT pg_notify($1, $2)", notifyChannel, `{"roomId":"room1","ref":42}`).
+		Return(pgconn.NewCommandTag("SELECT 1"), nil)
+
+	err := hub.BroadcastToRoom(context.Background(), "room1", message)
+	assert.NoError(t, err)
+}
+
+func TestPostgresHub_BroadcastToRoom_NotifiesPatches(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockDB := NewMockDatabase(ctrl)
+	hub := newTestHub(mockDB)
+
+	first := dto.RoomState{Type: dto.RoomStateType, Version: 1}
+	second := dto.RoomState{Type: dto.RoomStateType, Version: 2, Reveal: true}
+	expectBroadcast := func(current []byte, state dto.RoomState) *string {
+		notified := new(string)
+		stored, _ := json.Marshal(state)
+		tx := expectTx(ctrl, mockDB)
+		tx.EXPECT().QueryRow(gomock.Any(), lockBroadcastStateSQL, "room1").Return(scanRow(ctrl, current))
+		tx.EXPECT().Exec(gomock.Any(), storeBroadcastStateSQL, "room1", stored).Return(pgconn.NewCommandTag("UPDATE 1"), nil)
+		tx.EXPECT().
+			Exec(gomock.Any(), "SELECT pg_notify($1, $2)", notifyChannel, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ string, args ...any) (pgconn.CommandTag, error) {
+				*notified = args[1].(string)
+				return pgconn.NewCommandTag("SELECT 1"), nil
+			})
+		tx.EXPECT().Commit(gomock.Any()).Return(nil)
+		return notified
+	}
+
+	notified := expectBroadcast(nil, first)
+	assert.NoError(t, hub.BroadcastToRoom(context.Background(), "room1", first))
+	assert.Contains(t, *notified, `"type":"room-state"`, "the first state is notified whole")
+
+	previous, _ := json.Marshal(first)
+	notified = expectBroadcast(previous, second)
+	assert.NoError(t, hub.BroadcastToRoom(context.Background(), "room1", second))
+	assert.Contains(t, *notified, `"type":"room-patch","version":2,"baseVersion":1`)
+}
+
+func TestPostgresHub_BroadcastToRoom_StaleRoomStateIsNotNotified(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockDB := NewMockDatabase(ctrl)
+	hub := newTestHub(mockDB)
+
+	current, _ := json.Marshal(dto.RoomState{Type: dto.RoomStateType, Version: 2})
+	tx := expectTx(ctrl, mockDB)
+	tx.EXPECT().QueryRow(gomock.Any(), lockBroadcastStateSQL, "room1").Return(scanRow(ctrl, current))
+
+	err := hub.BroadcastToRoom(context.Background(), "room1", dto.RoomState{Type: dto.RoomStateType, Version: 1})
+	assert.NoError(t, err)
+}
+
+func TestPostgresHub_HandleNotification_ForwardsToLocalClients(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockDB := NewMockDatabase(ctrl)
+	hub := newTestHub(mockDB)
+
+	room := entity.NewRoomWithID("room1", clientcollection.New())
+	room.NewClient("client1")
+	state, _ := serialization.SerializeRoom(room)
+
+	bus := domain.NewMockBus(ctrl)
+	bus.EXPECT().RoomID().Return("room1").AnyTimes()
+	bus.EXPECT().Send(gomock.Any(), map[string]any{"type": "room-state"}).Return(nil)
+	hub.AddBus(context.Background(), "client1", bus)
+
+	mockDB.EXPECT().QueryRow(gomock.Any(), gomock.Any(), "room1").Return(scanRow(ctrl, state))
+
+	hub.handleNotification(context.Background(), `{"roomId":"room1","payload":{"type":"room-state"}}`)
+}
+
+func TestPostgresHub_HandleNotification_ClosedRoomReachesAttachedBuses(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockDB := NewMockDatabase(ctrl)
+	hub := newTestHub(mockDB)
+
+	bus := domain.NewMockBus(ctrl)
+	bus.EXPECT().RoomID().Return("room1").AnyTimes()
+	bus.EXPECT().Send(gomock.Any(), map[string]any{"type": "room-closed"}).Return(nil)
+	hub.AddBus(context.Background(), "client1", bus)
+
+	row := NewMockRow(ctrl)
+	row.EXPECT().Scan(gomock.Any()).Return(pgx.ErrNoRows)
+	mockDB.EXPECT().QueryRow(gomock.Any(), gomock.Any(), "room1").Return(row)
+
+	hub.handleNotification(context.Background(), `{"roomId":"room1","payload":{"type":"room-closed"}}`)
+}
+
+func TestPostgresHub_HandleNotification_ReadsReferencedPayload(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockDB := NewMockDatabase(ctrl)
+	hub := newTestHub(mockDB)
+
+	room := entity.NewRoomWithID("room1", clientcollection.New())
+	room.NewClient("client1")
+	state, _ := serialization.SerializeRoom(room)
+
+	bus := domain.NewMockBus(ctrl)
+	bus.EXPECT().RoomID().Return("room1").AnyTimes()
+	bus.EXPECT().Send(gomock.Any(), map[string]any{"type": "room-state"}).Return(nil)
+	hub.AddBus(context.Background(), "client1", bus)
+
+	mockDB.EXPECT().QueryRow(gomock.Any(), "SELECT payload FROM room_broadcasts WHERE id = $1", int64(42)).
+		Return(scanRow(ctrl, []byte(`{"type":"room-state"}`)))
+	mockDB.EXPECT().QueryRow(gomock.Any(), gomock.Any(), "room1").Return(scanRow(ctrl, state))
+
+	hub.handleNotification(context.Background(), `{"roomId":"room1","ref":42}`)
+}
+
+func TestPostgresHub_HandleNotification_IgnoresRoomsWithoutLocalClients(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockDB := NewMockDatabase(ctrl)
+	hub := newTestHub(mockDB)
+
+	// no database calls expected
+	hub.handleNotification(context.Background(), `{"roomId":"room1","payload":{"type":"room-state"}}`)
+}
+
+func TestPostgresHub_RemoveBus_ForgetsRoomAfterLastClient(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	hub := newTestHub(NewMockDatabase(ctrl))
+
+	bus1 := domain.NewMockBus(ctrl)
+	bus1.EXPECT().RoomID().Return("room1").AnyTimes()
+	bus2 := domain.NewMockBus(ctrl)
+	bus2.EXPECT().RoomID().Return("room1").AnyTimes()
+
+	hub.AddBus(context.Background(), "client1", bus1)
+	hub.AddBus(context.Background(), "client2", bus2)
+	assert.Equal(t, 2, hub.roomClientCounts["room1"])
+
+	hub.RemoveBus(context.Background(), "client1")
+	assert.Equal(t, 1, hub.roomClientCounts["room1"])
+
+	hub.RemoveBus(context.Background(), "client2")
+	_, ok := hub.roomClientCounts["room1"]
+	assert.False(t, ok)
+	_, ok = hub.GetBus("client2")
+	assert.False(t, ok)
+}
+
+func TestPostgresHub_RoomEvents(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockDB := NewMockDatabase(ctrl)
+	hub := newTestHub(mockDB)
+
+	room := entity.NewRoomWithID("room1", clientcollection.New())
+	room.NewClient("client1")
+	payloads := make([]json.RawMessage, 0)
+	for _, event := range room.PendingEvents() {
+		data, _ := serialization.SerializeRoomEvent(event)
+		payloads = append(payloads, data)
+	}
+	data, _ := json.Marshal(payloads)
+
+	mockDB.EXPECT().QueryRow(gomock.Any(), gomock.Any(), "room1").Return(scanRow(ctrl, data))
+
+	events, err := hub.RoomEvents(context.Background(), "room1")
+	assert.NoError(t, err)
+	assert.Len(t, events, 2)
+	assert.Equal(t, entity.EventRoomCreated, events[0].Type)
+}
+
+func TestPostgresHub_RoomEvents_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockDB := NewMockDatabase(ctrl)
+	hub := newTestHub(mockDB)
+
+	mockDB.EXPECT().QueryRow(gomock.Any(), gomock.Any(), "missing").Return(scanRow(ctrl, []byte("[]")))
+
+	_, err := hub.RoomEvents(context.Background(), "missing")
+	assert.ErrorIs(t, err, domain.ErrRoomNotFound)
+}
+
+func TestPostgresHub_ListRooms(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockDB := NewMockDatabase(ctrl)
+	hub := newTestHub(mockDB)
+
+	room1, _ := serialization.SerializeRoom(entity.NewRoomWithID("room1", clientcollection.New()))
+	room2, _ := serialization.SerializeRoom(entity.NewRoomWithID("room2", clientcollection.New()))
+	room3, _ := serialization.SerializeRoom(entity.NewRoomWithID("room3", clientcollection.New()))
+	data, _ := json.Marshal([]json.RawMessage{room1, room2, room3})
+
+	mockDB.EXPECT().
+		QueryRow(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, sql string, args ...any) pgx.Row {
+			assert.Contains(t, sql, "ORDER BY r.created_at, r.id")
+			assert.Equal(t, []any{2, 3}, args, "offset and one room more than the page")
+			return scanRow(ctrl, data)
+		})
+
+	page, err := hub.ListRooms(context.Background(), domain.RoomQuery{Cursor: "2", Limit: 2})
+	assert.NoError(t, err)
+	assert.Len(t, page.Rooms, 2)
+	assert.Equal(t, "room1", page.Rooms[0].ID)
+	assert.Equal(t, "room2", page.Rooms[1].ID)
+	assert.Equal(t, "4", page.NextCursor)
+}
+
+func TestPostgresHub_ListRooms_InvalidCursor(t *testing.T) {
+	hub := newTestHub(NewMockDatabase(gomock.NewController(t)))
+
+	_, err := hub.ListRooms(context.Background(), domain.RoomQuery{Cursor: "abc"})
+	assert.ErrorIs(t, err, domain.ErrInvalidCursor)
+}
+
+func TestListRoomsSQL(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	backlog := false
+
+	sql, args, err := listRoomsSQL(domain.RoomQuery{
+		MinParticipants: 2,
+		MaxParticipants: 5,
+		MinIdle:         time.Hour,
+		BacklogMode:     &backlog,
+		OwnerName:       "50%_off",
+		SortBy:          domain.RoomSortIdle,
+		Descending:      true,
+	}, 10, now)
+
+	assert.NoError(t, err)
+	assert.Contains(t, sql, participantsSQL+" >= $1 AND "+participantsSQL+" <= $2")
+	assert.Contains(t, sql, "r.updated_at <= $3::TIMESTAMPTZ")
+	assert.Contains(t, sql, backlogModeSQL+" = $4::BOOLEAN")
+	assert.Contains(t, sql, "c.name ILIKE $5::TEXT")
+	assert.Contains(t, sql, "ORDER BY r.updated_at, r.id DESC")
+	assert.Contains(t, sql, "OFFSET $6 LIMIT $7")
+	assert.Equal(t, []any{2, 5, now.Add(-time.Hour), false, `%50\%\_off%`, 10, domain.DefaultRoomPageSize + 1}, args)
+
+	_, _, err = listRoomsSQL(domain.RoomQuery{SortBy: "state; DROP TABLE rooms"}, 0, now)
+	assert.Error(t, err)
+}
+
+func TestNewPostgresHub_ListensUntilClosed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockDB := NewMockDatabase(ctrl)
+	listener := NewMockListener(ctrl)
+
+	listening := make(chan struct{})
+	listener.EXPECT().
+		Listen(gomock.Any(), notifyChannel, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, _ string, _ func(context.Context, string)) error {
+			close(listening)
+			<-ctx.Done()
+			return nil
+		})
+
+	hub, err := NewPostgresHub(context.Background(), mockDB, listener)
+	assert.NoError(t, err)
+
+	select {
+	case <-listening:
+	case <-time.After(time.Second):
+		t.Fatal("hub did not start listening")
+	}
+	assert.NoError(t, hub.Close())
+}
+
+func newTestHub(db Database) *PostgresHub {
+	return &PostgresHub{
+		db:               db,
+		logger:           log.NewLogger("test"),
+		buses:            make(map[string]domain.Bus),
+		roomClientCounts: make(map[string]int),
+		cancel:           func() {},
+	}
+}
+
+// expectTx expects a transaction to begin, which is rolled back unless it is
+// committed.
+func expectTx(ctrl *gomock.Controller, mockDB *MockDatabase) *MockTx {
+	tx := NewMockTx(ctrl)
+	mockDB.EXPECT().Begin(gomock.Any()).Return(tx, nil)
+	tx.EXPECT().Rollback(gomock.Any()).Return(nil).AnyTimes()
+	return tx
+}
+
+func scanRow(ctrl *gomock.Controller, data []byte) *MockRow {
+	row := NewMockRow(ctrl)
+	row.EXPECT().Scan(gomock.Any()).DoAndReturn(func(dest ...any) error {
+		*dest[0].(*[]byte) = data
+		return nil
+	})
+	return row
+}
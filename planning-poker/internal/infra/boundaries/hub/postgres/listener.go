@@ -0,0 +1,81 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/bruno303/go-toolkit/pkg/log"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type (
+	Listener interface {
+		// Listen delivers the notifications of the channel to handle until the
+		// context is done.
+		Listen(ctx context.Context, channel string, handle func(ctx context.Context, payload string)) error
+	}
+
+	PoolListener struct {
+		pool       *pgxpool.Pool
+		retryDelay time.Duration
+		logger     log.Logger
+	}
+)
+
+var _ Listener = (*PoolListener)(nil)
+
+const defaultListenRetryDelay = time.Second
+
+func NewPoolListener(pool *pgxpool.Pool) *PoolListener {
+	return &PoolListener{
+		pool:       pool,
+		retryDelay: defaultListenRetryDelay,
+		logger:     log.NewLogger("postgres.listener"),
+	}
+}
+
+// Listen holds a connection of the pool for as long as it listens and takes
+// another one when it breaks. Notifications sent while reconnecting are lost;
+// clients catch up with a resync.
+func (l *PoolListener) Listen(ctx context.Context, channel string, handle func(ctx context.Context, payload string)) error {
+	for {
+		err := l.listen(ctx, channel, handle)
+		if ctx.Err() != nil {
+			return nil
+		}
+		l.logger.Error(ctx, fmt.Sprintf("Listening on channel %s failed, reconnecting", channel), err)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(l.retryDelay):
+		}
+	}
+}
+
+func (l *PoolListener) listen(ctx context.Context, channel string, handle func(ctx context.Context, payload string)) error {
+	conn, err := l.pool.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to acquire connection: %w", err)
+	}
+	defer func() {
+		// a listening connection must not go back to the pool
+		_ = conn.Conn().Close(context.Background())
+		conn.Release()
+	}()
+
+	if _, err := conn.Exec(ctx, "LISTEN "+pgx.Identifier{channel}.Sanitize()); err != nil {
+		return fmt.Errorf("failed to listen: %w", err)
+	}
+	l.logger.Info(ctx, "Listening on channel %s", channel)
+
+	for {
+		notification, err := conn.Conn().WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+		handle(ctx, notification.Payload)
+	}
+}
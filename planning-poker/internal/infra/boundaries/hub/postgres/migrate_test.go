@@ -0,0 +1,112 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"testing/fstest"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestLoadMigrations_SortsByVersion(t *testing.T) {
+	fsys := fstest.MapFS{
+		"migrations/0010_add_index.sql":    {Data: []byte("CREATE INDEX")},
+		"migrations/0002_add_column.sql":   {Data: []byte("ALTER TABLE")},
+		"migrations/0001_create_rooms.sql": {Data: []byte("CREATE TABLE")},
+	}
+
+	migrations, err := loadMigrations(fsys)
+	assert.NoError(t, err)
+	assert.Equal(t, []migration{
+		{Version: 1, Name: "create_rooms", SQL: "CREATE TABLE"},
+		{Version: 2, Name: "add_column", SQL: "ALTER TABLE"},
+		{Version: 10, Name: "add_index", SQL: "CREATE INDEX"},
+	}, migrations)
+}
+
+func TestLoadMigrations_RejectsInvalidNames(t *testing.T) {
+	tests := map[string]fstest.MapFS{
+		"without version": {"migrations/create_rooms.sql": {}},
+		"zero version":    {"migrations/0000_create_rooms.sql": {}},
+		"duplicate version": {
+			"migrations/0001_create_rooms.sql":  {},
+			"migrations/001_create_clients.sql": {},
+		},
+	}
+
+	for name, fsys := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := loadMigrations(fsys)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestLoadMigrations_Embedded(t *testing.T) {
+	migrations, err := loadMigrations(migrationsFS)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, migrations)
+	assert.Equal(t, 1, migrations[0].Version)
+}
+
+func TestMigrate_AppliesPendingMigrations(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockDB := NewMockDatabase(ctrl)
+	migrations, _ := loadMigrations(migrationsFS)
+
+	tx := expectTx(ctrl, mockDB)
+	expectMigrationLock(tx)
+	tx.EXPECT().QueryRow(gomock.Any(), gomock.Any()).Return(scanVersion(ctrl, 0))
+	for _, m := range migrations {
+		tx.EXPECT().Exec(gomock.Any(), m.SQL).Return(pgconn.NewCommandTag(""), nil)
+		tx.EXPECT().Exec(gomock.Any(), gomock.Any(), m.Version, m.Name).Return(pgconn.NewCommandTag("INSERT 0 1"), nil)
+	}
+	tx.EXPECT().Commit(gomock.Any()).Return(nil)
+
+	err := Migrate(context.Background(), mockDB)
+	assert.NoError(t, err)
+}
+
+func TestMigrate_SkipsAppliedMigrations(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockDB := NewMockDatabase(ctrl)
+	migrations, _ := loadMigrations(migrationsFS)
+
+	tx := expectTx(ctrl, mockDB)
+	expectMigrationLock(tx)
+	tx.EXPECT().QueryRow(gomock.Any(), gomock.Any()).Return(scanVersion(ctrl, migrations[len(migrations)-1].Version))
+	tx.EXPECT().Commit(gomock.Any()).Return(nil)
+
+	err := Migrate(context.Background(), mockDB)
+	assert.NoError(t, err)
+}
+
+func TestMigrate_FailedMigrationIsNotCommitted(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockDB := NewMockDatabase(ctrl)
+	migrations, _ := loadMigrations(migrationsFS)
+
+	tx := expectTx(ctrl, mockDB)
+	expectMigrationLock(tx)
+	tx.EXPECT().QueryRow(gomock.Any(), gomock.Any()).Return(scanVersion(ctrl, 0))
+	tx.EXPECT().Exec(gomock.Any(), migrations[0].SQL).Return(pgconn.CommandTag{}, assert.AnError)
+
+	err := Migrate(context.Background(), mockDB)
+	assert.ErrorIs(t, err, assert.AnError)
+}
+
+func expectMigrationLock(tx *MockTx) {
+	tx.EXPECT().Exec(gomock.Any(), "SELECT pg_advisory_xact_lock($1)", migrationLockID).Return(pgconn.NewCommandTag("SELECT 1"), nil)
+	tx.EXPECT().Exec(gomock.Any(), createMigrationsTableSQL).Return(pgconn.NewCommandTag("CREATE TABLE"), nil)
+}
+
+func scanVersion(ctrl *gomock.Controller, version int) *MockRow {
+	row := NewMockRow(ctrl)
+	row.EXPECT().Scan(gomock.Any()).DoAndReturn(func(dest ...any) error {
+		*dest[0].(*int) = version
+		return nil
+	})
+	return row
+}
@@ -0,0 +1,49 @@
+CREATE TABLE rooms (
+    id         TEXT PRIMARY KEY,
+    version    BIGINT      NOT NULL,
+    state      JSONB       NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE TABLE room_events (
+    room_id     TEXT        NOT NULL REFERENCES rooms (id) ON DELETE CASCADE,
+    sequence    BIGINT      NOT NULL,
+    event       JSONB       NOT NULL,
+    occurred_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (room_id, sequence)
+);
+
+-- room_clients and room_stories are projections of rooms.state, rewritten
+-- in the transaction that saves the room
+CREATE TABLE room_clients (
+    room_id      TEXT    NOT NULL REFERENCES rooms (id) ON DELETE CASCADE,
+    client_id    TEXT    NOT NULL,
+    position     INT     NOT NULL,
+    name         TEXT    NOT NULL,
+    has_voted    BOOLEAN NOT NULL,
+    is_spectator BOOLEAN NOT NULL,
+    is_owner     BOOLEAN NOT NULL,
+    PRIMARY KEY (room_id, client_id)
+);
+
+CREATE INDEX room_clients_client_id_idx ON room_clients (client_id);
+
+CREATE TABLE room_stories (
+    room_id  TEXT    NOT NULL REFERENCES rooms (id) ON DELETE CASCADE,
+    position INT     NOT NULL,
+    name     TEXT    NOT NULL,
+    voted    BOOLEAN NOT NULL,
+    result   REAL,
+    PRIMARY KEY (room_id, position)
+);
+
+-- payloads too large for a notification are passed by reference
+CREATE TABLE room_broadcasts (
+    id         BIGSERIAL PRIMARY KEY,
+    room_id    TEXT        NOT NULL,
+    payload    JSONB       NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX room_broadcasts_created_at_idx ON room_broadcasts (created_at);
@@ -0,0 +1,2 @@
+-- the last room state broadcast, which the next one is patched against
+ALTER TABLE rooms ADD COLUMN broadcast_state JSONB;
@@ -0,0 +1,29 @@
+-- voting deadlines, audit records and webhooks live next to the rooms, so
+-- that instances storing rooms in Postgres need nothing else
+CREATE TABLE voting_deadlines (
+    room_id  TEXT PRIMARY KEY,
+    deadline TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX voting_deadlines_deadline_idx ON voting_deadlines (deadline);
+
+CREATE TABLE audit_records (
+    id          BIGSERIAL PRIMARY KEY,
+    recorded_at TIMESTAMPTZ NOT NULL,
+    room_id     TEXT        NOT NULL,
+    actor       TEXT        NOT NULL,
+    record      JSONB       NOT NULL
+);
+
+CREATE INDEX audit_records_recorded_at_idx ON audit_records (recorded_at);
+
+CREATE TABLE webhook_subscriptions (
+    id           TEXT PRIMARY KEY,
+    subscription JSONB       NOT NULL,
+    created_at   TIMESTAMPTZ NOT NULL
+);
+
+CREATE TABLE webhook_dead_letters (
+    id          BIGSERIAL PRIMARY KEY,
+    dead_letter JSONB NOT NULL
+);
@@ -0,0 +1,231 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: planning-poker/internal/infra/boundaries/hub/postgres (interfaces: Database,Listener)
+//
+// Generated by this command:
+//
+//	mockgen -destination mocks.go -typed -package postgres . Database,Listener
+//
+
+// Package postgres is a generated GoMock package.
+package postgres
+
+import (
+	context "context"
+	reflect "reflect"
+
+	pgx "github.com/jackc/pgx/v5"
+	pgconn "github.com/jackc/pgx/v5/pgconn"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockDatabase is a mock of Database interface.
+type MockDatabase struct {
+	ctrl     *gomock.Controller
+	recorder *MockDatabaseMockRecorder
+	isgomock struct{}
+}
+
+// MockDatabaseMockRecorder is the mock recorder for MockDatabase.
+type MockDatabaseMockRecorder struct {
+	mock *MockDatabase
+}
+
+// NewMockDatabase creates a new mock instance.
+func NewMockDatabase(ctrl *gomock.Controller) *MockDatabase {
+	mock := &MockDatabase{ctrl: ctrl}
+	mock.recorder = &MockDatabaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDatabase) EXPECT() *MockDatabaseMockRecorder {
+	return m.recorder
+}
+
+// Begin mocks base method.
+func (m *MockDatabase) Begin(ctx context.Context) (pgx.Tx, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Begin", ctx)
+	ret0, _ := ret[0].(pgx.Tx)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Begin indicates an expected call of Begin.
+func (mr *MockDatabaseMockRecorder) Begin(ctx any) *MockDatabaseBeginCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Begin", reflect.TypeOf((*MockDatabase)(nil).Begin), ctx)
+	return &MockDatabaseBeginCall{Call: call}
+}
+
+// MockDatabaseBeginCall wrap *gomock.Call
+type MockDatabaseBeginCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockDatabaseBeginCall) Return(arg0 pgx.Tx, arg1 error) *MockDatabaseBeginCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockDatabaseBeginCall) Do(f func(context.Context) (pgx.Tx, error)) *MockDatabaseBeginCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockDatabaseBeginCall) DoAndReturn(f func(context.Context) (pgx.Tx, error)) *MockDatabaseBeginCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Exec mocks base method.
+func (m *MockDatabase) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, sql}
+	for _, a := range args {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Exec", varargs...)
+	ret0, _ := ret[0].(pgconn.CommandTag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Exec indicates an expected call of Exec.
+func (mr *MockDatabaseMockRecorder) Exec(ctx, sql any, args ...any) *MockDatabaseExecCall {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, sql}, args...)
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Exec", reflect.TypeOf((*MockDatabase)(nil).Exec), varargs...)
+	return &MockDatabaseExecCall{Call: call}
+}
+
+// MockDatabaseExecCall wrap *gomock.Call
+type MockDatabaseExecCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockDatabaseExecCall) Return(arg0 pgconn.CommandTag, arg1 error) *MockDatabaseExecCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockDatabaseExecCall) Do(f func(context.Context, string, ...any) (pgconn.CommandTag, error)) *MockDatabaseExecCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockDatabaseExecCall) DoAndReturn(f func(context.Context, string, ...any) (pgconn.CommandTag, error)) *MockDatabaseExecCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// QueryRow mocks base method.
+func (m *MockDatabase) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, sql}
+	for _, a := range args {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "QueryRow", varargs...)
+	ret0, _ := ret[0].(pgx.Row)
+	return ret0
+}
+
+// QueryRow indicates an expected call of QueryRow.
+func (mr *MockDatabaseMockRecorder) QueryRow(ctx, sql any, args ...any) *MockDatabaseQueryRowCall {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, sql}, args...)
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryRow", reflect.TypeOf((*MockDatabase)(nil).QueryRow), varargs...)
+	return &MockDatabaseQueryRowCall{Call: call}
+}
+
+// MockDatabaseQueryRowCall wrap *gomock.Call
+type MockDatabaseQueryRowCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockDatabaseQueryRowCall) Return(arg0 pgx.Row) *MockDatabaseQueryRowCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockDatabaseQueryRowCall) Do(f func(context.Context, string, ...any) pgx.Row) *MockDatabaseQueryRowCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockDatabaseQueryRowCall) DoAndReturn(f func(context.Context, string, ...any) pgx.Row) *MockDatabaseQueryRowCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockListener is a mock of Listener interface.
+type MockListener struct {
+	ctrl     *gomock.Controller
+	recorder *MockListenerMockRecorder
+	isgomock struct{}
+}
+
+// MockListenerMockRecorder is the mock recorder for MockListener.
+type MockListenerMockRecorder struct {
+	mock *MockListener
+}
+
+// NewMockListener creates a new mock instance.
+func NewMockListener(ctrl *gomock.Controller) *MockListener {
+	mock := &MockListener{ctrl: ctrl}
+	mock.recorder = &MockListenerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockListener) EXPECT() *MockListenerMockRecorder {
+	return m.recorder
+}
+
+// Listen mocks base method.
+func (m *MockListener) Listen(ctx context.Context, channel string, handle func(context.Context, string)) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Listen", ctx, channel, handle)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Listen indicates an expected call of Listen.
+func (mr *MockListenerMockRecorder) Listen(ctx, channel, handle any) *MockListenerListenCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Listen", reflect.TypeOf((*MockListener)(nil).Listen), ctx, channel, handle)
+	return &MockListenerListenCall{Call: call}
+}
+
+// MockListenerListenCall wrap *gomock.Call
+type MockListenerListenCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockListenerListenCall) Return(arg0 error) *MockListenerListenCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockListenerListenCall) Do(f func(context.Context, string, func(context.Context, string)) error) *MockListenerListenCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockListenerListenCall) DoAndReturn(f func(context.Context, string, func(context.Context, string)) error) *MockListenerListenCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
@@ -0,0 +1,547 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/jackc/pgx/v5 (interfaces: Tx,Row)
+//
+// Generated by this command:
+//
+//	mockgen -destination pgxmocks.go -typed -package postgres github.com/jackc/pgx/v5 Tx,Row
+//
+
+// Package postgres is a generated GoMock package.
+package postgres
+
+import (
+	context "context"
+	reflect "reflect"
+
+	pgx "github.com/jackc/pgx/v5"
+	pgconn "github.com/jackc/pgx/v5/pgconn"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockTx is a mock of Tx interface.
+type MockTx struct {
+	ctrl     *gomock.Controller
+	recorder *MockTxMockRecorder
+	isgomock struct{}
+}
+
+// MockTxMockRecorder is the mock recorder for MockTx.
+type MockTxMockRecorder struct {
+	mock *MockTx
+}
+
+// NewMockTx creates a new mock instance.
+func NewMockTx(ctrl *gomock.Controller) *MockTx {
+	mock := &MockTx{ctrl: ctrl}
+	mock.recorder = &MockTxMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTx) EXPECT() *MockTxMockRecorder {
+	return m.recorder
+}
+
+// Begin mocks base method.
+func (m *MockTx) Begin(ctx context.Context) (pgx.Tx, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Begin", ctx)
+	ret0, _ := ret[0].(pgx.Tx)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Begin indicates an expected call of Begin.
+func (mr *MockTxMockRecorder) Begin(ctx any) *MockTxBeginCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Begin", reflect.TypeOf((*MockTx)(nil).Begin), ctx)
+	return &MockTxBeginCall{Call: call}
+}
+
+// MockTxBeginCall wrap *gomock.Call
+type MockTxBeginCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockTxBeginCall) Return(arg0 pgx.Tx, arg1 error) *MockTxBeginCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockTxBeginCall) Do(f func(context.Context) (pgx.Tx, error)) *MockTxBeginCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockTxBeginCall) DoAndReturn(f func(context.Context) (pgx.Tx, error)) *MockTxBeginCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Commit mocks base method.
+func (m *MockTx) Commit(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Commit", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Commit indicates an expected call of Commit.
+func (mr *MockTxMockRecorder) Commit(ctx any) *MockTxCommitCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Commit", reflect.TypeOf((*MockTx)(nil).Commit), ctx)
+	return &MockTxCommitCall{Call: call}
+}
+
+// MockTxCommitCall wrap *gomock.Call
+type MockTxCommitCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockTxCommitCall) Return(arg0 error) *MockTxCommitCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockTxCommitCall) Do(f func(context.Context) error) *MockTxCommitCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockTxCommitCall) DoAndReturn(f func(context.Context) error) *MockTxCommitCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Conn mocks base method.
+func (m *MockTx) Conn() *pgx.Conn {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Conn")
+	ret0, _ := ret[0].(*pgx.Conn)
+	return ret0
+}
+
+// Conn indicates an expected call of Conn.
+func (mr *MockTxMockRecorder) Conn() *MockTxConnCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Conn", reflect.TypeOf((*MockTx)(nil).Conn))
+	return &MockTxConnCall{Call: call}
+}
+
+// MockTxConnCall wrap *gomock.Call
+type MockTxConnCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockTxConnCall) Return(arg0 *pgx.Conn) *MockTxConnCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockTxConnCall) Do(f func() *pgx.Conn) *MockTxConnCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockTxConnCall) DoAndReturn(f func() *pgx.Conn) *MockTxConnCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// CopyFrom mocks base method.
+func (m *MockTx) CopyFrom(ctx context.Context, tableName pgx.Identifier, columnNames []string, rowSrc pgx.CopyFromSource) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CopyFrom", ctx, tableName, columnNames, rowSrc)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CopyFrom indicates an expected call of CopyFrom.
+func (mr *MockTxMockRecorder) CopyFrom(ctx, tableName, columnNames, rowSrc any) *MockTxCopyFromCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CopyFrom", reflect.TypeOf((*MockTx)(nil).CopyFrom), ctx, tableName, columnNames, rowSrc)
+	return &MockTxCopyFromCall{Call: call}
+}
+
+// MockTxCopyFromCall wrap *gomock.Call
+type MockTxCopyFromCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockTxCopyFromCall) Return(arg0 int64, arg1 error) *MockTxCopyFromCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockTxCopyFromCall) Do(f func(context.Context, pgx.Identifier, []string, pgx.CopyFromSource) (int64, error)) *MockTxCopyFromCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockTxCopyFromCall) DoAndReturn(f func(context.Context, pgx.Identifier, []string, pgx.CopyFromSource) (int64, error)) *MockTxCopyFromCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Exec mocks base method.
+func (m *MockTx) Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, sql}
+	for _, a := range arguments {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Exec", varargs...)
+	ret0, _ := ret[0].(pgconn.CommandTag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Exec indicates an expected call of Exec.
+func (mr *MockTxMockRecorder) Exec(ctx, sql any, arguments ...any) *MockTxExecCall {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, sql}, arguments...)
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Exec", reflect.TypeOf((*MockTx)(nil).Exec), varargs...)
+	return &MockTxExecCall{Call: call}
+}
+
+// MockTxExecCall wrap *gomock.Call
+type MockTxExecCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockTxExecCall) Return(commandTag pgconn.CommandTag, err error) *MockTxExecCall {
+	c.Call = c.Call.Return(commandTag, err)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockTxExecCall) Do(f func(context.Context, string, ...any) (pgconn.CommandTag, error)) *MockTxExecCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockTxExecCall) DoAndReturn(f func(context.Context, string, ...any) (pgconn.CommandTag, error)) *MockTxExecCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// LargeObjects mocks base method.
+func (m *MockTx) LargeObjects() pgx.LargeObjects {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LargeObjects")
+	ret0, _ := ret[0].(pgx.LargeObjects)
+	return ret0
+}
+
+// LargeObjects indicates an expected call of LargeObjects.
+func (mr *MockTxMockRecorder) LargeObjects() *MockTxLargeObjectsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LargeObjects", reflect.TypeOf((*MockTx)(nil).LargeObjects))
+	return &MockTxLargeObjectsCall{Call: call}
+}
+
+// MockTxLargeObjectsCall wrap *gomock.Call
+type MockTxLargeObjectsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockTxLargeObjectsCall) Return(arg0 pgx.LargeObjects) *MockTxLargeObjectsCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockTxLargeObjectsCall) Do(f func() pgx.LargeObjects) *MockTxLargeObjectsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockTxLargeObjectsCall) DoAndReturn(f func() pgx.LargeObjects) *MockTxLargeObjectsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Prepare mocks base method.
+func (m *MockTx) Prepare(ctx context.Context, name, sql string) (*pgconn.StatementDescription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Prepare", ctx, name, sql)
+	ret0, _ := ret[0].(*pgconn.StatementDescription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Prepare indicates an expected call of Prepare.
+func (mr *MockTxMockRecorder) Prepare(ctx, name, sql any) *MockTxPrepareCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Prepare", reflect.TypeOf((*MockTx)(nil).Prepare), ctx, name, sql)
+	return &MockTxPrepareCall{Call: call}
+}
+
+// MockTxPrepareCall wrap *gomock.Call
+type MockTxPrepareCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockTxPrepareCall) Return(arg0 *pgconn.StatementDescription, arg1 error) *MockTxPrepareCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockTxPrepareCall) Do(f func(context.Context, string, string) (*pgconn.StatementDescription, error)) *MockTxPrepareCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockTxPrepareCall) DoAndReturn(f func(context.Context, string, string) (*pgconn.StatementDescription, error)) *MockTxPrepareCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Query mocks base method.
+func (m *MockTx) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, sql}
+	for _, a := range args {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Query", varargs...)
+	ret0, _ := ret[0].(pgx.Rows)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Query indicates an expected call of Query.
+func (mr *MockTxMockRecorder) Query(ctx, sql any, args ...any) *MockTxQueryCall {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, sql}, args...)
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Query", reflect.TypeOf((*MockTx)(nil).Query), varargs...)
+	return &MockTxQueryCall{Call: call}
+}
+
+// MockTxQueryCall wrap *gomock.Call
+type MockTxQueryCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockTxQueryCall) Return(arg0 pgx.Rows, arg1 error) *MockTxQueryCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockTxQueryCall) Do(f func(context.Context, string, ...any) (pgx.Rows, error)) *MockTxQueryCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockTxQueryCall) DoAndReturn(f func(context.Context, string, ...any) (pgx.Rows, error)) *MockTxQueryCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// QueryRow mocks base method.
+func (m *MockTx) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, sql}
+	for _, a := range args {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "QueryRow", varargs...)
+	ret0, _ := ret[0].(pgx.Row)
+	return ret0
+}
+
+// QueryRow indicates an expected call of QueryRow.
+func (mr *MockTxMockRecorder) QueryRow(ctx, sql any, args ...any) *MockTxQueryRowCall {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, sql}, args...)
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryRow", reflect.TypeOf((*MockTx)(nil).QueryRow), varargs...)
+	return &MockTxQueryRowCall{Call: call}
+}
+
+// MockTxQueryRowCall wrap *gomock.Call
+type MockTxQueryRowCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockTxQueryRowCall) Return(arg0 pgx.Row) *MockTxQueryRowCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockTxQueryRowCall) Do(f func(context.Context, string, ...any) pgx.Row) *MockTxQueryRowCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockTxQueryRowCall) DoAndReturn(f func(context.Context, string, ...any) pgx.Row) *MockTxQueryRowCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Rollback mocks base method.
+func (m *MockTx) Rollback(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rollback", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Rollback indicates an expected call of Rollback.
+func (mr *MockTxMockRecorder) Rollback(ctx any) *MockTxRollbackCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rollback", reflect.TypeOf((*MockTx)(nil).Rollback), ctx)
+	return &MockTxRollbackCall{Call: call}
+}
+
+// MockTxRollbackCall wrap *gomock.Call
+type MockTxRollbackCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockTxRollbackCall) Return(arg0 error) *MockTxRollbackCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockTxRollbackCall) Do(f func(context.Context) error) *MockTxRollbackCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockTxRollbackCall) DoAndReturn(f func(context.Context) error) *MockTxRollbackCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// SendBatch mocks base method.
+func (m *MockTx) SendBatch(ctx context.Context, b *pgx.Batch) pgx.BatchResults {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendBatch", ctx, b)
+	ret0, _ := ret[0].(pgx.BatchResults)
+	return ret0
+}
+
+// SendBatch indicates an expected call of SendBatch.
+func (mr *MockTxMockRecorder) SendBatch(ctx, b any) *MockTxSendBatchCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendBatch", reflect.TypeOf((*MockTx)(nil).SendBatch), ctx, b)
+	return &MockTxSendBatchCall{Call: call}
+}
+
+// MockTxSendBatchCall wrap *gomock.Call
+type MockTxSendBatchCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockTxSendBatchCall) Return(arg0 pgx.BatchResults) *MockTxSendBatchCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockTxSendBatchCall) Do(f func(context.Context, *pgx.Batch) pgx.BatchResults) *MockTxSendBatchCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockTxSendBatchCall) DoAndReturn(f func(context.Context, *pgx.Batch) pgx.BatchResults) *MockTxSendBatchCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockRow is a mock of Row interface.
+type MockRow struct {
+	ctrl     *gomock.Controller
+	recorder *MockRowMockRecorder
+	isgomock struct{}
+}
+
+// MockRowMockRecorder is the mock recorder for MockRow.
+type MockRowMockRecorder struct {
+	mock *MockRow
+}
+
+// NewMockRow creates a new mock instance.
+func NewMockRow(ctrl *gomock.Controller) *MockRow {
+	mock := &MockRow{ctrl: ctrl}
+	mock.recorder = &MockRowMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRow) EXPECT() *MockRowMockRecorder {
+	return m.recorder
+}
+
+// Scan mocks base method.
+func (m *MockRow) Scan(dest ...any) error {
+	m.ctrl.T.Helper()
+	varargs := []any{}
+	for _, a := range dest {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Scan", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Scan indicates an expected call of Scan.
+func (mr *MockRowMockRecorder) Scan(dest ...any) *MockRowScanCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Scan", reflect.TypeOf((*MockRow)(nil).Scan), dest...)
+	return &MockRowScanCall{Call: call}
+}
+
+// MockRowScanCall wrap *gomock.Call
+type MockRowScanCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockRowScanCall) Return(arg0 error) *MockRowScanCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockRowScanCall) Do(f func(...any) error) *MockRowScanCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockRowScanCall) DoAndReturn(f func(...any) error) *MockRowScanCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
@@ -5,9 +5,15 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"planning-poker/internal/application/lock"
+	"planning-poker/internal/application/planningpoker/usecase/dto"
 	"planning-poker/internal/domain"
 	"planning-poker/internal/domain/entity"
 	"planning-poker/internal/infra/boundaries/hub/clientcollection"
+	"planning-poker/internal/infra/boundaries/hub/serialization"
+	"slices"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -22,17 +28,31 @@ type RedisClient interface {
 	Del(ctx context.Context, keys ...string) *redis.IntCmd
 	Publish(ctx context.Context, channel string, message any) *redis.IntCmd
 	Subscribe(ctx context.Context, channels ...string) *redis.PubSub
-	Scan(ctx context.Context, count uint64, match string, cursor int64) *redis.ScanCmd
-	Keys(ctx context.Context, pattern string) *redis.StringSliceCmd
+	Scan(ctx context.Context, cursor uint64, match string, count int64) *redis.ScanCmd
+	XRange(ctx context.Context, stream, start, stop string) *redis.XMessageSliceCmd
+	Eval(ctx context.Context, script string, keys []string, args ...any) *redis.Cmd
+	Pipelined(ctx context.Context, fn func(redis.Pipeliner) error) ([]redis.Cmder, error)
 }
 
 const (
 	roomKeyPrefix   = "planning-poker:room:"
+	eventsKeyPrefix = "planning-poker:room-events:"
+	fenceKeyPrefix  = "planning-poker:room-fence:"
 	clientKeyPrefix = "planning-poker:client:"
 	pubsubChannel   = "planning-poker:updates:"
-	twentyFourHours = 24 * time.Hour
+	// rooms expire a day after their last change without a room lifecycle
+	defaultRoomTTL = 24 * time.Hour
+	// how long the keys of a room outlive the moment the reaper closes it
+	lifecycleTTLMargin = time.Hour
 
 	subscribeTimeout = 2 * time.Second
+	// keys asked per SCAN call when every room must be read
+	scanBatchSize = 200
+
+	eventField = "event"
+
+	// the last room state published, which the next one is patched against
+	broadcastKeyPrefix = "planning-poker:room-broadcast:"
 )
 
 type (
@@ -47,6 +67,8 @@ type (
 		roomClientCounts map[string]int
 		ctx              context.Context
 		cancel           context.CancelFunc
+		keepEmptyRooms   bool
+		lifecycle        entity.RoomLifecycle
 	}
 	BroadcastMessage struct {
 		RoomID  string `json:"roomId"`
@@ -96,6 +118,39 @@ func (h *RedisHub) Close() error {
 	return nil
 }
 
+// KeepEmptyRooms leaves rooms in place when their last client leaves, so that
+// participants can come back until the reaper removes them.
+func (h *RedisHub) KeepEmptyRooms() {
+	h.keepEmptyRooms = true
+}
+
+// SetLifecycle hands the expiry of rooms over to the reaper, which must find
+// them before Redis drops their keys, see keyTTL.
+func (h *RedisHub) SetLifecycle(lifecycle entity.RoomLifecycle) {
+	h.lifecycle = lifecycle
+}
+
+// keyTTL is how long the keys of a room outlive its last change, zero for
+// keys that never expire. Under a lifecycle they outlive the latest moment
+// the room can be closed by lifecycleTTLMargin, and never expire when only
+// empty rooms do.
+func (h *RedisHub) keyTTL() time.Duration {
+	if !h.lifecycle.Enabled() {
+		return defaultRoomTTL
+	}
+
+	var ttl time.Duration
+	for _, expiry := range []time.Duration{h.lifecycle.IdleTimeout, h.lifecycle.MaxLifetime} {
+		if expiry > 0 && (ttl == 0 || expiry < ttl) {
+			ttl = expiry
+		}
+	}
+	if ttl == 0 {
+		return 0
+	}
+	return ttl + lifecycleTTLMargin
+}
+
 func (h *RedisHub) NewRoom(ctx context.Context) (*entity.Room, error) {
 	room, err := trace.Trace(ctx, trace.NameConfig("RedisHub", "NewRoom"), func(ctx context.Context) (any, error) {
 		room := entity.NewRoom(clientcollection.New())
@@ -152,8 +207,8 @@ func (h *RedisHub) LoadRoom(ctx context.Context, roomID string) (*entity.Room, e
 
 func (h *RedisHub) RemoveRoom(roomID string) {
 	ctx := context.Background()
-	key := roomKeyPrefix + roomID
-	if err := h.client.Del(ctx, key).Err(); err != nil {
+	// the log goes with the snapshot, otherwise loading the room would replay it
+	if err := h.client.Del(ctx, roomKeyPrefix+roomID, eventsKeyPrefix+roomID, fenceKeyPrefix+roomID, broadcastKeyPrefix+roomID).Err(); err != nil {
 		h.logger.Error(ctx, fmt.Sprintf("Failed to delete room %s from Redis", roomID), err)
 	}
 }
@@ -186,7 +241,7 @@ func (h *RedisHub) AddClient(c *entity.Client) {
 	ctx := context.Background()
 	key := clientKeyPrefix + c.ID
 
-	if err := h.client.Set(ctx, key, c.Room().ID, twentyFourHours).Err(); err != nil {
+	if err := h.client.Set(ctx, key, c.Room().ID, h.keyTTL()).Err(); err != nil {
 		h.logger.Error(ctx, fmt.Sprintf("Failed to save client %s to Redis", c.ID), err)
 		return
 	}
@@ -315,7 +370,7 @@ func (h *RedisHub) RemoveClient(ctx context.Context, clientID string, roomID str
 			return nil, err
 		}
 
-		if room.IsEmpty() {
+		if room.IsEmpty() && !h.keepEmptyRooms {
 			h.RemoveRoom(room.ID)
 		} else {
 			if err := h.saveRoom(ctx, room); err != nil {
@@ -329,8 +384,14 @@ func (h *RedisHub) RemoveClient(ctx context.Context, clientID string, roomID str
 	return err
 }
 
+// BroadcastToRoom publishes room states as patches against the state published
+// before, see publishRoomState. Other messages are published as they are.
 func (h *RedisHub) BroadcastToRoom(ctx context.Context, roomID string, message any) error {
 	_, err := trace.Trace(ctx, trace.NameConfig("RedisHub", "BroadcastToRoom"), func(ctx context.Context) (any, error) {
+		if state, ok := message.(dto.RoomState); ok {
+			return nil, h.publishRoomState(ctx, roomID, state)
+		}
+
 		broadcastMsg := BroadcastMessage{
 			RoomID:  roomID,
 			Payload: message,
@@ -352,66 +413,452 @@ func (h *RedisHub) BroadcastToRoom(ctx context.Context, roomID string, message a
 	return err
 }
 
-func (h *RedisHub) GetRooms() []*entity.Room {
-	ctx := context.Background()
-	pattern := roomKeyPrefix + "*"
+// publishRoomStateScript stores the room state and publishes its update only
+// while the stored state is the one the update was computed against, so
+// every patch follows the one published before it. States older than the
+// stored one are not published.
+// KEYS[1] is the last state published. ARGV[1] is the state the update was
+// computed against or an empty string, ARGV[2] the version of the new state,
+// ARGV[3] the new state, ARGV[4] the expiration in seconds or 0, ARGV[5] the
+// channel and ARGV[6] the update or an empty string when nothing changed.
+const publishRoomStateScript = `
+local current = redis.call("GET", KEYS[1]) or ""
+if current ~= "" and cjson.decode(current).version > tonumber(ARGV[2]) then
+	return -1
+end
+if current ~= ARGV[1] then
+	return 0
+end
+if tonumber(ARGV[4]) > 0 then
+	redis.call("SET", KEYS[1], ARGV[3], "EX", ARGV[4])
+else
+	redis.call("SET", KEYS[1], ARGV[3])
+end
+if ARGV[6] ~= "" then
+	redis.call("PUBLISH", ARGV[5], ARGV[6])
+end
+return 1
+`
+
+// publishRoomState publishes the patch from the last state published for the
+// room, versioned by the room, and the whole state only when there is none.
+// The buses turn patches back into states for the clients that want them.
+func (h *RedisHub) publishRoomState(ctx context.Context, roomID string, state dto.RoomState) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("failed to marshal room state: %w", err)
+	}
+
+	key := broadcastKeyPrefix + roomID
+	for {
+		current, err := h.client.Get(ctx, key).Result()
+		if err != nil && !errors.Is(err, redis.Nil) {
+			return fmt.Errorf("failed to read the last room state published: %w", err)
+		}
+		var previous *dto.RoomState
+		if current != "" {
+			previous = &dto.RoomState{}
+			if err := json.Unmarshal([]byte(current), previous); err != nil {
+				return fmt.Errorf("failed to unmarshal the last room state published: %w", err)
+			}
+			if previous.Version > state.Version {
+				h.logger.Debug(ctx, "Not publishing room state %d of room %s older than %d", state.Version, roomID, previous.Version)
+				return nil
+			}
+		}
+
+		message := ""
+		if update, ok := dto.NewRoomUpdate(previous, state); ok {
+			payload, err := json.Marshal(BroadcastMessage{RoomID: roomID, Payload: update})
+			if err != nil {
+				return fmt.Errorf("failed to marshal broadcast message: %w", err)
+			}
+			message = string(payload)
+		}
+
+		published, err := h.client.Eval(ctx, publishRoomStateScript, []string{key},
+			current, state.Version, data, int64(h.keyTTL()/time.Second), pubsubChannel+roomID, message).Int()
+		if err != nil {
+			return fmt.Errorf("failed to publish message to Redis: %w", err)
+		}
+		if published != 0 {
+			return nil
+		}
+		// another state was published meanwhile, patch against that one
+	}
+}
+
+// ListRooms walks the room keys with SCAN, so listing never blocks Redis.
+// Without a sort, pages follow the SCAN cursor and hold at most the page size;
+// a page that fills up within a SCAN batch resumes there, its cursor being
+// the SCAN cursor of the batch and the number of keys of it already listed.
+// The last page may be empty, and rooms created or removed meanwhile may be
+// missed or listed twice. Sorting needs every room, so sorted listings scan
+// all the keys and page by offset.
+func (h *RedisHub) ListRooms(ctx context.Context, query domain.RoomQuery) (domain.RoomPage, error) {
+	if query.SortBy != domain.RoomSortNone {
+		return h.listSortedRooms(ctx, query)
+	}
 
-	keys, err := h.client.Keys(ctx, pattern).Result()
+	cursor, skip, err := parseScanCursor(query.Cursor)
 	if err != nil {
-		h.logger.Error(ctx, "Failed to get room keys from Redis", err)
-		return nil
+		return domain.RoomPage{}, err
 	}
 
+	now := time.Now()
+	page := domain.RoomPage{Rooms: []*entity.Room{}}
+	for {
+		keys, next, err := h.client.Scan(ctx, cursor, roomKeyPrefix+"*", int64(query.PageSize())).Result()
+		if err != nil {
+			return domain.RoomPage{}, fmt.Errorf("failed to scan rooms: %w", err)
+		}
+		keys = keys[min(skip, len(keys)):]
+
+		for _, room := range h.loadRooms(ctx, keys) {
+			if !query.Matches(room, now) {
+				continue
+			}
+			if len(page.Rooms) == query.PageSize() {
+				page.NextCursor = formatScanCursor(cursor, skip+slices.Index(keys, roomKeyPrefix+room.ID))
+				return page, nil
+			}
+			page.Rooms = append(page.Rooms, room)
+		}
+
+		if next == 0 {
+			return page, nil
+		}
+		cursor, skip = next, 0
+		if len(page.Rooms) == query.PageSize() {
+			page.NextCursor = formatScanCursor(cursor, skip)
+			return page, nil
+		}
+	}
+}
+
+// parseScanCursor reads a cursor of ListRooms: the SCAN cursor, followed by
+// the number of keys of its batch to skip when a page ended within it.
+func parseScanCursor(value string) (uint64, int, error) {
+	if value == "" {
+		return 0, 0, nil
+	}
+
+	cursorValue, skipValue, hasSkip := strings.Cut(value, ":")
+	cursor, err := strconv.ParseUint(cursorValue, 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("cursor %q: %w", value, domain.ErrInvalidCursor)
+	}
+	if !hasSkip {
+		return cursor, 0, nil
+	}
+	skip, err := strconv.Atoi(skipValue)
+	if err != nil || skip < 0 {
+		return 0, 0, fmt.Errorf("cursor %q: %w", value, domain.ErrInvalidCursor)
+	}
+	return cursor, skip, nil
+}
+
+func formatScanCursor(cursor uint64, skip int) string {
+	if skip == 0 {
+		return strconv.FormatUint(cursor, 10)
+	}
+	return fmt.Sprintf("%d:%d", cursor, skip)
+}
+
+func (h *RedisHub) listSortedRooms(ctx context.Context, query domain.RoomQuery) (domain.RoomPage, error) {
 	var rooms []*entity.Room
-	for _, roomKey := range keys {
-		roomID := roomKey[len(roomKeyPrefix):]
+	var cursor uint64
+	for {
+		keys, next, err := h.client.Scan(ctx, cursor, roomKeyPrefix+"*", scanBatchSize).Result()
+		if err != nil {
+			return domain.RoomPage{}, fmt.Errorf("failed to scan rooms: %w", err)
+		}
 
-		room, err := h.LoadRoom(ctx, roomID)
-		if err == nil {
-			rooms = append(rooms, room)
+		rooms = append(rooms, h.loadRooms(ctx, keys)...)
+
+		if cursor = next; cursor == 0 {
+			break
+		}
+	}
+
+	return domain.PageRooms(rooms, query, time.Now())
+}
+
+// loadRooms loads the rooms of the given keys in two round trips, one for the
+// snapshots and one for the events appended after them. Rooms that expired
+// since they were scanned or cannot be read are left out.
+func (h *RedisHub) loadRooms(ctx context.Context, keys []string) []*entity.Room {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	snapshots := make([]*redis.StringCmd, len(keys))
+	// every command carries its own error, checked below
+	_, _ = h.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for i, key := range keys {
+			snapshots[i] = pipe.Get(ctx, key)
+		}
+		return nil
+	})
+
+	rooms := make([]*entity.Room, 0, len(keys))
+	for i, cmd := range snapshots {
+		data, err := cmd.Bytes()
+		if err != nil {
+			if !errors.Is(err, redis.Nil) {
+				h.logger.Error(ctx, fmt.Sprintf("Failed to load room %s from Redis", keys[i]), err)
+			}
+			continue
+		}
+		room, err := serialization.DeserializeRoom(data, clientcollection.New())
+		if err != nil {
+			h.logger.Error(ctx, fmt.Sprintf("Failed to deserialize room %s", keys[i]), err)
+			continue
+		}
+		rooms = append(rooms, room)
+	}
+	if len(rooms) == 0 {
+		return rooms
+	}
+
+	tails := make([]*redis.XMessageSliceCmd, len(rooms))
+	_, _ = h.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for i, room := range rooms {
+			tails[i] = pipe.XRange(ctx, eventsKeyPrefix+room.ID, strconv.FormatInt(room.Version+1, 10), "+")
 		}
+		return nil
+	})
+
+	loaded := make([]*entity.Room, 0, len(rooms))
+	for i, room := range rooms {
+		if err := replayTail(ctx, room, tails[i]); err != nil {
+			h.logger.Error(ctx, fmt.Sprintf("Failed to load events of room %s", room.ID), err)
+			continue
+		}
+		loaded = append(loaded, room)
 	}
 
-	return rooms
+	return loaded
+}
+
+// replayTail applies the events appended after the snapshot of the room.
+func replayTail(ctx context.Context, room *entity.Room, tail *redis.XMessageSliceCmd) error {
+	messages, err := tail.Result()
+	if err != nil {
+		return err
+	}
+	events, err := decodeEvents(room.ID, messages)
+	if err != nil {
+		return err
+	}
+	for _, event := range events {
+		if err := room.Apply(ctx, event); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func (h *RedisHub) SaveRoom(ctx context.Context, room *entity.Room) error {
 	return h.saveRoom(ctx, room)
 }
 
+// RoomEvents returns the log of a room, oldest event first.
+func (h *RedisHub) RoomEvents(ctx context.Context, roomID string) ([]entity.RoomEvent, error) {
+	events, err := h.readEvents(ctx, roomID, "-")
+	if err != nil {
+		return nil, err
+	}
+	if len(events) == 0 {
+		return nil, domain.ErrRoomNotFound
+	}
+	return events, nil
+}
+
+// saveRoom appends the pending events of the room to its log before writing
+// the snapshot, so a failed snapshot is caught up by replaying the log. The
+// snapshot itself is not versioned: a late writer may store an older one,
+// which loading also catches up from the log.
 func (h *RedisHub) saveRoom(ctx context.Context, room *entity.Room) error {
-	data, err := SerializeRoom(room)
+	if err := h.appendEvents(ctx, room); err != nil {
+		return err
+	}
+
+	data, err := serialization.SerializeRoom(room)
 	if err != nil {
 		return fmt.Errorf("failed to serialize room: %w", err)
 	}
 
 	key := roomKeyPrefix + room.ID
-	if err := h.client.Set(ctx, key, data, twentyFourHours).Err(); err != nil {
+	if err := h.client.Set(ctx, key, data, h.keyTTL()).Err(); err != nil {
 		return fmt.Errorf("failed to save room to Redis: %w", err)
 	}
 
 	return nil
 }
 
+// appendEventsScript appends the events only while the last entry of the log
+// is the version the room was loaded at. Entry IDs are the event sequences.
+// Writers holding the lock of the room pass its fencing token, which must not
+// be older than the last one seen, so a holder whose lock was taken over
+// cannot write anymore.
+// KEYS[1] is the log and KEYS[2] the fence. ARGV[1] is the expected version,
+// ARGV[2] the expiration in seconds or 0, ARGV[3] the fencing token or 0 and the
+// remaining arguments the serialized events, stored under the event field.
+const appendEventsScript = `
+local token = tonumber(ARGV[3])
+if token > 0 then
+	local fence = tonumber(redis.call("GET", KEYS[2]) or "0")
+	if token < fence then
+		return -1
+	end
+end
+local last = redis.call("XREVRANGE", KEYS[1], "+", "-", "COUNT", 1)
+local version = 0
+if #last > 0 then
+	version = tonumber(string.match(last[1][1], "^%d+"))
+end
+if version ~= tonumber(ARGV[1]) then
+	return 0
+end
+for i = 4, #ARGV do
+	redis.call("XADD", KEYS[1], string.format("%d-0", version + i - 3), "event", ARGV[i])
+end
+local ttl = tonumber(ARGV[2])
+if ttl > 0 then
+	redis.call("EXPIRE", KEYS[1], ttl)
+else
+	redis.call("PERSIST", KEYS[1])
+end
+if token > 0 then
+	if ttl > 0 then
+		redis.call("SET", KEYS[2], token, "EX", ttl)
+	else
+		redis.call("SET", KEYS[2], token)
+	end
+end
+return 1
+`
+
+// appendEvents is the compare-and-set of the room: it fails with
+// ErrVersionConflict when someone else saved the room since it was loaded and
+// with ErrLockExpired when the lock of the caller was taken over.
+func (h *RedisHub) appendEvents(ctx context.Context, room *entity.Room) error {
+	token, _ := lock.FencingToken(ctx, room.ID)
+	events := room.PendingEvents()
+	args := make([]any, 0, len(events)+3)
+	args = append(args, room.BaseVersion(), int64(h.keyTTL()/time.Second), token)
+
+	now := time.Now()
+	for _, event := range events {
+		event.OccurredAt = now
+		data, err := serialization.SerializeRoomEvent(event)
+		if err != nil {
+			return fmt.Errorf("failed to serialize room event: %w", err)
+		}
+		args = append(args, data)
+	}
+
+	keys := []string{eventsKeyPrefix + room.ID, fenceKeyPrefix + room.ID}
+	appended, err := h.client.Eval(ctx, appendEventsScript, keys, args...).Int()
+	if err != nil {
+		return fmt.Errorf("failed to append events of room %s: %w", room.ID, err)
+	}
+	switch appended {
+	case -1:
+		return fmt.Errorf("save room %s with fencing token %d: %w", room.ID, token, lock.ErrLockExpired)
+	case 0:
+		return fmt.Errorf("save room %s at version %d: %w", room.ID, room.BaseVersion(), domain.ErrVersionConflict)
+	}
+	room.Touch(now)
+	room.ClearPendingEvents()
+
+	return nil
+}
+
+// readEvents reads the log of a room starting at the given entry ID.
+func (h *RedisHub) readEvents(ctx context.Context, roomID string, start string) ([]entity.RoomEvent, error) {
+	messages, err := h.client.XRange(ctx, eventsKeyPrefix+roomID, start, "+").Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read events of room %s: %w", roomID, err)
+	}
+
+	return decodeEvents(roomID, messages)
+}
+
+func decodeEvents(roomID string, messages []redis.XMessage) ([]entity.RoomEvent, error) {
+	events := make([]entity.RoomEvent, 0, len(messages))
+	for _, msg := range messages {
+		data, ok := msg.Values[eventField].(string)
+		if !ok {
+			return nil, fmt.Errorf("event %s of room %s has no payload", msg.ID, roomID)
+		}
+		event, err := serialization.DeserializeRoomEvent([]byte(data))
+		if err != nil {
+			return nil, fmt.Errorf("failed to deserialize event %s of room %s: %w", msg.ID, roomID, err)
+		}
+		events = append(events, event)
+	}
+
+	return events, nil
+}
+
+// loadRoom reads the snapshot of the room and replays the events appended
+// after it. Without a snapshot the room is rebuilt from its whole log.
 func (h *RedisHub) loadRoom(ctx context.Context, roomID string) (*entity.Room, error) {
 	key := roomKeyPrefix + roomID
 	data, err := h.client.Get(ctx, key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return h.rebuildRoom(ctx, roomID)
+	}
 	if err != nil {
 		return nil, err
 	}
 
-	room, err := DeserializeRoom(data, clientcollection.New())
+	room, err := serialization.DeserializeRoom(data, clientcollection.New())
 	if err != nil {
 		return nil, fmt.Errorf("failed to deserialize room: %w", err)
 	}
 
+	events, err := h.readEvents(ctx, roomID, strconv.FormatInt(room.Version+1, 10))
+	if err != nil {
+		return nil, err
+	}
+	for _, event := range events {
+		if err := room.Apply(ctx, event); err != nil {
+			return nil, err
+		}
+	}
+
 	return room, nil
 }
 
+func (h *RedisHub) rebuildRoom(ctx context.Context, roomID string) (*entity.Room, error) {
+	events, err := h.readEvents(ctx, roomID, "-")
+	if err != nil {
+		return nil, err
+	}
+	if len(events) == 0 {
+		return nil, redis.Nil
+	}
+
+	return entity.RebuildRoom(ctx, roomID, clientcollection.New(), events)
+}
+
 func (h *RedisHub) forwardToLocalClients(ctx context.Context, roomID string, message any) {
 	h.busMux.RLock()
 	defer h.busMux.RUnlock()
 	room, err := h.LoadRoom(ctx, roomID)
+	if errors.Is(err, domain.ErrRoomNotFound) {
+		// a room that was just closed cannot be looked up anymore, its last
+		// message goes to the buses still attached to it
+		for clientID, bus := range h.buses {
+			if bus.RoomID() == roomID {
+				h.send(ctx, clientID, bus, message)
+			}
+		}
+		return
+	}
 	if err != nil {
 		return
 	}
@@ -421,8 +868,12 @@ func (h *RedisHub) forwardToLocalClients(ctx context.Context, roomID string, mes
 		if !ok {
 			continue
 		}
-		if err := bus.Send(ctx, message); err != nil {
-			h.logger.Warn(ctx, "Failed to send message to client %s: %v", client.ID, err)
-		}
+		h.send(ctx, client.ID, bus, message)
+	}
+}
+
+func (h *RedisHub) send(ctx context.Context, clientID string, bus domain.Bus, message any) {
+	if err := bus.Send(ctx, message); err != nil {
+		h.logger.Warn(ctx, "Failed to send message to client %s: %v", clientID, err)
 	}
 }
@@ -2,13 +2,19 @@ package redis
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"maps"
+	"strings"
 	"testing"
 	"time"
 
+	"planning-poker/internal/application/lock"
+	"planning-poker/internal/application/planningpoker/usecase/dto"
 	"planning-poker/internal/domain"
 	"planning-poker/internal/domain/entity"
 	"planning-poker/internal/infra/boundaries/hub/clientcollection"
+	"planning-poker/internal/infra/boundaries/hub/serialization"
 
 	"github.com/bruno303/go-toolkit/pkg/log"
 	"github.com/redis/go-redis/v9"
@@ -25,7 +31,7 @@ func TestRedisHub_NewRoom_SaveRoom_LoadRoom(t *testing.T) {
 	room.ID = "room1"
 
 	// Serialize room for mock return
-	roomBytes, _ := SerializeRoom(room)
+	roomBytes, _ := serialization.SerializeRoom(room)
 	statusCmd := redis.NewStatusCmd(context.Background())
 	statusCmd.SetVal("OK")
 	stringCmd := redis.NewStringCmd(context.Background())
@@ -33,6 +39,8 @@ func TestRedisHub_NewRoom_SaveRoom_LoadRoom(t *testing.T) {
 
 	mockRedis.EXPECT().Set(gomock.Any(), "planning-poker:room:room1", gomock.Any(), time.Duration(24*time.Hour)).Return(statusCmd)
 	mockRedis.EXPECT().Get(gomock.Any(), "planning-poker:room:room1").Return(stringCmd)
+	expectEventsAppended(mockRedis)
+	expectNoEventsAfterSnapshot(mockRedis, "room1")
 
 	hub := &RedisHub{
 		client:           mockRedis,
@@ -55,6 +63,69 @@ func TestRedisHub_NewRoom_SaveRoom_LoadRoom(t *testing.T) {
 	assert.Equal(t, room.ID, gotRoom.ID)
 }
 
+func TestRedisHub_SaveRoom_VersionConflict(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockRedis := NewMockRedisClient(ctrl)
+
+	room := entity.NewRoomWithID("room1", clientcollection.New())
+	room.ClearPendingEvents()
+	room.NewClient("client1")
+
+	conflict := redis.NewCmd(context.Background())
+	conflict.SetVal(int64(0))
+	mockRedis.EXPECT().
+		Eval(gomock.Any(), appendEventsScript, []string{"planning-poker:room-events:room1", "planning-poker:room-fence:room1"}, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, _ []string, args ...any) *redis.Cmd {
+			assert.Equal(t, int64(1), args[0], "expected version")
+			assert.Equal(t, int64(0), args[2], "no fencing token")
+			assert.Len(t, args, 4, "one pending event")
+			return conflict
+		})
+
+	hub := &RedisHub{
+		client:           mockRedis,
+		logger:           log.NewLogger("test"),
+		buses:            make(map[string]domain.Bus),
+		closeCh:          make(chan struct{}),
+		roomClientCounts: make(map[string]int),
+	}
+
+	err := hub.SaveRoom(context.Background(), room)
+	assert.ErrorIs(t, err, domain.ErrVersionConflict)
+	assert.Len(t, room.PendingEvents(), 1)
+}
+
+func TestRedisHub_SaveRoom_StaleFencingToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockRedis := NewMockRedisClient(ctrl)
+
+	room := entity.NewRoomWithID("room1", clientcollection.New())
+	room.ClearPendingEvents()
+	room.NewClient("client1")
+
+	stale := redis.NewCmd(context.Background())
+	stale.SetVal(int64(-1))
+	mockRedis.EXPECT().
+		Eval(gomock.Any(), appendEventsScript, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, _ []string, args ...any) *redis.Cmd {
+			assert.Equal(t, int64(7), args[2], "fencing token of the room lock")
+			return stale
+		})
+
+	hub := &RedisHub{
+		client:           mockRedis,
+		logger:           log.NewLogger("test"),
+		buses:            make(map[string]domain.Bus),
+		closeCh:          make(chan struct{}),
+		roomClientCounts: make(map[string]int),
+	}
+
+	ctx := lock.WithFencingToken(context.Background(), "room1", 7)
+	err := hub.SaveRoom(ctx, room)
+	assert.ErrorIs(t, err, lock.ErrLockExpired)
+	assert.Len(t, room.PendingEvents(), 1)
+}
+
 func TestRedisHub_NewRoomWithID(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	mockRedis := NewMockRedisClient(ctrl)
@@ -64,6 +135,7 @@ func TestRedisHub_NewRoomWithID(t *testing.T) {
 	statusCmd.SetVal("OK")
 
 	mockRedis.EXPECT().Set(gomock.Any(), "planning-poker:room:room-explicit", gomock.Any(), time.Duration(24*time.Hour)).Return(statusCmd)
+	expectEventsAppended(mockRedis)
 
 	hub := &RedisHub{
 		client:           mockRedis,
@@ -92,6 +164,7 @@ func TestRedisHub_AddClient_RemoveRoom(t *testing.T) {
 
 	mockRedis.EXPECT().Set(gomock.Any(), "planning-poker:client:client1", room.ID, time.Duration(24*time.Hour)).Return(redis.NewStatusCmd(context.Background()))
 	mockRedis.EXPECT().Set(gomock.Any(), "planning-poker:room:room2", gomock.Any(), time.Duration(24*time.Hour)).Return(redis.NewStatusCmd(context.Background()))
+	expectEventsAppended(mockRedis)
 
 	hub := &RedisHub{
 		client:           mockRedis,
@@ -103,10 +176,65 @@ func TestRedisHub_AddClient_RemoveRoom(t *testing.T) {
 
 	hub.AddClient(client)
 
-	mockRedis.EXPECT().Del(gomock.Any(), "planning-poker:room:room2").Return(redis.NewIntCmd(context.Background()))
+	mockRedis.EXPECT().Del(gomock.Any(), "planning-poker:room:room2", "planning-poker:room-events:room2", "planning-poker:room-fence:room2", "planning-poker:room-broadcast:room2").Return(redis.NewIntCmd(context.Background()))
 	hub.RemoveRoom(room.ID)
 }
 
+func TestRedisHub_AddClient_ExpiresAfterTheRoomLifecycle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockRedis := NewMockRedisClient(ctrl)
+
+	room := entity.NewRoom(clientcollection.New())
+	room.ID = "room2"
+	client := room.NewClient("client1")
+	client.WithRoom(room)
+	room.Clients.Add(client)
+
+	mockRedis.EXPECT().Set(gomock.Any(), "planning-poker:client:client1", room.ID, 49*time.Hour).Return(redis.NewStatusCmd(context.Background()))
+	mockRedis.EXPECT().Set(gomock.Any(), "planning-poker:room:room2", gomock.Any(), 49*time.Hour).Return(redis.NewStatusCmd(context.Background()))
+	mockRedis.EXPECT().
+		Eval(gomock.Any(), appendEventsScript, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, _ []string, args ...any) *redis.Cmd {
+			assert.Equal(t, int64((49 * time.Hour).Seconds()), args[1])
+			cmd := redis.NewCmd(context.Background())
+			cmd.SetVal(int64(1))
+			return cmd
+		})
+
+	hub := &RedisHub{
+		client:           mockRedis,
+		logger:           log.NewLogger("test"),
+		buses:            make(map[string]domain.Bus),
+		closeCh:          make(chan struct{}),
+		roomClientCounts: make(map[string]int),
+	}
+	hub.SetLifecycle(entity.RoomLifecycle{MaxLifetime: 48 * time.Hour})
+
+	hub.AddClient(client)
+}
+
+func TestRedisHub_KeyTTL(t *testing.T) {
+	tests := []struct {
+		name      string
+		lifecycle entity.RoomLifecycle
+		expected  time.Duration
+	}{
+		{"without lifecycle", entity.RoomLifecycle{}, 24 * time.Hour},
+		{"after the idle timeout", entity.RoomLifecycle{IdleTimeout: 8 * time.Hour}, 9 * time.Hour},
+		{"after the max lifetime", entity.RoomLifecycle{MaxLifetime: 72 * time.Hour}, 73 * time.Hour},
+		{"after the earliest expiry", entity.RoomLifecycle{IdleTimeout: 72 * time.Hour, MaxLifetime: 48 * time.Hour}, 49 * time.Hour},
+		{"never when only empty rooms expire", entity.RoomLifecycle{EmptyRoomTTL: 15 * time.Minute}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hub := &RedisHub{}
+			hub.SetLifecycle(tt.lifecycle)
+			assert.Equal(t, tt.expected, hub.keyTTL())
+		})
+	}
+}
+
 func TestRedisHub_FindClientByID(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	mockRedis := NewMockRedisClient(ctrl)
@@ -119,7 +247,7 @@ func TestRedisHub_FindClientByID(t *testing.T) {
 	room.Clients.Add(client)
 
 	// Serialize room for mock return
-	roomBytes, _ := SerializeRoom(room)
+	roomBytes, _ := serialization.SerializeRoom(room)
 	stringCmdRoom := redis.NewStringCmd(context.Background())
 	stringCmdRoom.SetVal(string(roomBytes))
 	stringCmdClient := redis.NewStringCmd(context.Background())
@@ -127,6 +255,7 @@ func TestRedisHub_FindClientByID(t *testing.T) {
 
 	mockRedis.EXPECT().Get(gomock.Any(), "planning-poker:client:client2").Return(stringCmdClient)
 	mockRedis.EXPECT().Get(gomock.Any(), "planning-poker:room:"+room.ID).Return(stringCmdRoom)
+	expectNoEventsAfterSnapshot(mockRedis, room.ID)
 
 	hub := &RedisHub{
 		client:           mockRedis,
@@ -155,7 +284,7 @@ func TestRedisHub_RemoveClient(t *testing.T) {
 	room.Clients.Add(client)
 
 	// Serialize room for mock return
-	roomBytes, _ := SerializeRoom(room)
+	roomBytes, _ := serialization.SerializeRoom(room)
 	stringCmdRoom := redis.NewStringCmd(context.Background())
 	stringCmdRoom.SetVal(string(roomBytes))
 	intCmd := redis.NewIntCmd(context.Background())
@@ -166,6 +295,8 @@ func TestRedisHub_RemoveClient(t *testing.T) {
 	mockRedis.EXPECT().Del(gomock.Any(), "planning-poker:client:client3").Return(intCmd)
 	mockRedis.EXPECT().Get(gomock.Any(), "planning-poker:room:"+room.ID).Return(stringCmdRoom)
 	mockRedis.EXPECT().Set(gomock.Any(), "planning-poker:room:"+room.ID, gomock.Any(), time.Duration(24*time.Hour)).Return(statusCmd)
+	expectNoEventsAfterSnapshot(mockRedis, room.ID)
+	expectEventsAppended(mockRedis)
 
 	hub := &RedisHub{
 		client:           mockRedis,
@@ -193,6 +324,7 @@ func TestRedisHub_RemoveClient_MissingRoomStillCleansUpAndSucceeds(t *testing.T)
 
 	mockRedis.EXPECT().Del(gomock.Any(), "planning-poker:client:client3").Return(intCmd)
 	mockRedis.EXPECT().Get(gomock.Any(), "planning-poker:room:room4").Return(stringCmd)
+	expectNoEventsAfterSnapshot(mockRedis, "room4")
 
 	hub := &RedisHub{
 		client:           mockRedis,
@@ -224,7 +356,7 @@ func TestRedisHub_RemoveClient_ClientDeleteFailsAndRoomSavesSuccessfully_Returns
 	deleteErr := errors.New("delete failed")
 	intCmd := redis.NewIntCmd(context.Background())
 	intCmd.SetErr(deleteErr)
-	roomBytes, _ := SerializeRoom(room)
+	roomBytes, _ := serialization.SerializeRoom(room)
 	stringCmd := redis.NewStringCmd(context.Background())
 	stringCmd.SetVal(string(roomBytes))
 	statusCmd := redis.NewStatusCmd(context.Background())
@@ -235,6 +367,8 @@ func TestRedisHub_RemoveClient_ClientDeleteFailsAndRoomSavesSuccessfully_Returns
 	mockRedis.EXPECT().Del(gomock.Any(), "planning-poker:client:client3").Return(intCmd)
 	mockRedis.EXPECT().Get(gomock.Any(), "planning-poker:room:room4").Return(stringCmd)
 	mockRedis.EXPECT().Set(gomock.Any(), "planning-poker:room:room4", gomock.Any(), time.Duration(24*time.Hour)).Return(statusCmd)
+	expectNoEventsAfterSnapshot(mockRedis, "room4")
+	expectEventsAppended(mockRedis)
 
 	hub := &RedisHub{
 		client:           mockRedis,
@@ -265,7 +399,7 @@ func TestRedisHub_RemoveClient_SaveRoomFails_PropagatesError(t *testing.T) {
 
 	intCmd := redis.NewIntCmd(context.Background())
 	intCmd.SetVal(1)
-	roomBytes, _ := SerializeRoom(room)
+	roomBytes, _ := serialization.SerializeRoom(room)
 	stringCmd := redis.NewStringCmd(context.Background())
 	stringCmd.SetVal(string(roomBytes))
 	saveErr := errors.New("save failed")
@@ -277,6 +411,8 @@ func TestRedisHub_RemoveClient_SaveRoomFails_PropagatesError(t *testing.T) {
 	mockRedis.EXPECT().Del(gomock.Any(), "planning-poker:client:client3").Return(intCmd)
 	mockRedis.EXPECT().Get(gomock.Any(), "planning-poker:room:room4").Return(stringCmd)
 	mockRedis.EXPECT().Set(gomock.Any(), "planning-poker:room:room4", gomock.Any(), time.Duration(24*time.Hour)).Return(statusCmd)
+	expectNoEventsAfterSnapshot(mockRedis, "room4")
+	expectEventsAppended(mockRedis)
 
 	hub := &RedisHub{
 		client:           mockRedis,
@@ -346,26 +482,91 @@ func TestRedisHub_BroadcastToRoom(t *testing.T) {
 	assert.NoError(t, err)
 }
 
-func TestRedisHub_GetRooms(t *testing.T) {
+func TestRedisHub_BroadcastToRoom_PublishesPatches(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	mockRedis := NewMockRedisClient(ctrl)
-	logger := log.NewLogger("test")
+	hub := &RedisHub{
+		client:           mockRedis,
+		logger:           log.NewLogger("test"),
+		buses:            make(map[string]domain.Bus),
+		closeCh:          make(chan struct{}),
+		roomClientCounts: make(map[string]int),
+	}
+	first := dto.RoomState{Type: dto.RoomStateType, Version: 1}
+	second := dto.RoomState{Type: dto.RoomStateType, Version: 2, Reveal: true}
+
+	published := expectRoomStatePublished(t, mockRedis, "", 1)
+	assert.NoError(t, hub.BroadcastToRoom(context.Background(), "room5", first))
+	assert.Equal(t, dto.RoomStateType, published["type"], "the first state is published whole")
+
+	published = expectRoomStatePublished(t, mockRedis, storedRoomState(first), 1)
+	assert.NoError(t, hub.BroadcastToRoom(context.Background(), "room5", second))
+	assert.Equal(t, dto.RoomPatchType, published["type"])
+	assert.Equal(t, float64(1), published["baseVersion"])
+	assert.Equal(t, float64(2), published["version"])
+}
 
-	validRoom := entity.NewRoom(clientcollection.New())
-	validRoom.ID = "room-valid"
-	validRoomBytes, _ := SerializeRoom(validRoom)
+func TestRedisHub_BroadcastToRoom_StaleRoomStateIsNotPublished(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockRedis := NewMockRedisClient(ctrl)
+	hub := &RedisHub{
+		client:           mockRedis,
+		logger:           log.NewLogger("test"),
+		buses:            make(map[string]domain.Bus),
+		closeCh:          make(chan struct{}),
+		roomClientCounts: make(map[string]int),
+	}
+	stored := storedRoomState(dto.RoomState{Type: dto.RoomStateType, Version: 2})
+
+	mockRedis.EXPECT().Get(gomock.Any(), "planning-poker:room-broadcast:room5").Return(redis.NewStringResult(stored, nil))
+
+	err := hub.BroadcastToRoom(context.Background(), "room5", dto.RoomState{Type: dto.RoomStateType, Version: 1})
+	assert.NoError(t, err)
+}
+
+func TestRedisHub_BroadcastToRoom_PatchesAgainstTheStatePublishedMeanwhile(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockRedis := NewMockRedisClient(ctrl)
+	hub := &RedisHub{
+		client:           mockRedis,
+		logger:           log.NewLogger("test"),
+		buses:            make(map[string]domain.Bus),
+		closeCh:          make(chan struct{}),
+		roomClientCounts: make(map[string]int),
+	}
+	first := dto.RoomState{Type: dto.RoomStateType, Version: 1}
+	second := dto.RoomState{Type: dto.RoomStateType, Version: 2, Reveal: true}
+	third := dto.RoomState{Type: dto.RoomStateType, Version: 3, BacklogMode: true, Reveal: true}
 
-	keysCmd := redis.NewStringSliceCmd(context.Background())
-	keysCmd.SetVal([]string{"planning-poker:room:room-broken", "planning-poker:room:room-valid"})
+	expectRoomStatePublished(t, mockRedis, storedRoomState(first), 0)
+	published := expectRoomStatePublished(t, mockRedis, storedRoomState(second), 1)
 
-	brokenRoomCmd := redis.NewStringCmd(context.Background())
-	brokenRoomCmd.SetErr(errors.New("redis unavailable"))
-	validRoomCmd := redis.NewStringCmd(context.Background())
-	validRoomCmd.SetVal(string(validRoomBytes))
+	assert.NoError(t, hub.BroadcastToRoom(context.Background(), "room5", third))
+	assert.Equal(t, dto.RoomPatchType, published["type"])
+	assert.Equal(t, float64(2), published["baseVersion"])
+}
 
-	mockRedis.EXPECT().Keys(gomock.Any(), "planning-poker:room:*").Return(keysCmd)
-	mockRedis.EXPECT().Get(gomock.Any(), "planning-poker:room:room-broken").Return(brokenRoomCmd)
-	mockRedis.EXPECT().Get(gomock.Any(), "planning-poker:room:room-valid").Return(validRoomCmd)
+func TestRedisHub_ListRooms(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockRedis := NewMockRedisClient(ctrl)
+	logger := log.NewLogger("test")
+
+	validRoom := entity.NewRoom(clientcollection.New())
+	validRoom.ID = "room-valid"
+	validRoomBytes, _ := serialization.SerializeRoom(validRoom)
+
+	expectScan(mockRedis, 0, []string{"planning-poker:room:room-broken", "planning-poker:room:room-valid"}, 17)
+	expectPipelined(mockRedis, func(cmd redis.Cmder) {
+		switch cmd.Args()[1] {
+		case "planning-poker:room:room-broken":
+			cmd.SetErr(errors.New("redis unavailable"))
+		case "planning-poker:room:room-valid":
+			cmd.(*redis.StringCmd).SetVal(string(validRoomBytes))
+		}
+	})
+	expectPipelined(mockRedis, func(cmd redis.Cmder) {
+		assert.Equal(t, "planning-poker:room-events:room-valid", cmd.Args()[1])
+	})
 
 	hub := &RedisHub{
 		client:           mockRedis,
@@ -375,7 +576,204 @@ func TestRedisHub_GetRooms(t *testing.T) {
 		roomClientCounts: make(map[string]int),
 	}
 
-	rooms := hub.GetRooms()
-	assert.Len(t, rooms, 1)
-	assert.Equal(t, validRoom.ID, rooms[0].ID)
+	page, err := hub.ListRooms(context.Background(), domain.RoomQuery{Limit: 1})
+	assert.NoError(t, err)
+	assert.Len(t, page.Rooms, 1)
+	assert.Equal(t, validRoom.ID, page.Rooms[0].ID)
+	assert.Equal(t, "17", page.NextCursor)
+}
+
+func TestRedisHub_ListRooms_ScansUntilThePageIsFull(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockRedis := NewMockRedisClient(ctrl)
+
+	room := entity.NewRoomWithID("room1", clientcollection.New())
+	room.NewClient("client1")
+	roomBytes, _ := serialization.SerializeRoom(room)
+
+	// the first batch has no key, the second one the last key
+	expectScan(mockRedis, 5, nil, 9)
+	expectScan(mockRedis, 9, []string{"planning-poker:room:room1"}, 0)
+	expectPipelined(mockRedis, func(cmd redis.Cmder) {
+		cmd.(*redis.StringCmd).SetVal(string(roomBytes))
+	})
+	expectPipelined(mockRedis, func(cmd redis.Cmder) {
+		cmd.(*redis.XMessageSliceCmd).SetVal([]redis.XMessage{})
+	})
+
+	hub := &RedisHub{client: mockRedis, logger: log.NewLogger("test")}
+
+	page, err := hub.ListRooms(context.Background(), domain.RoomQuery{Cursor: "5", MinParticipants: 1})
+	assert.NoError(t, err)
+	assert.Len(t, page.Rooms, 1)
+	assert.Empty(t, page.NextCursor)
+}
+
+func TestRedisHub_ListRooms_ResumesWithinTheBatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockRedis := NewMockRedisClient(ctrl)
+
+	keys := []string{"planning-poker:room:room-a", "planning-poker:room:room-b", "planning-poker:room:room-c"}
+	snapshots := map[string]string{}
+	for _, key := range keys {
+		data, _ := serialization.SerializeRoom(entity.NewRoomWithID(strings.TrimPrefix(key, "planning-poker:room:"), clientcollection.New()))
+		snapshots[key] = string(data)
+	}
+	for range 2 {
+		expectScan(mockRedis, 4, keys, 0)
+		expectPipelined(mockRedis, func(cmd redis.Cmder) {
+			cmd.(*redis.StringCmd).SetVal(snapshots[cmd.Args()[1].(string)])
+		})
+		expectPipelined(mockRedis, func(cmd redis.Cmder) {})
+	}
+
+	hub := &RedisHub{client: mockRedis, logger: log.NewLogger("test")}
+
+	page, err := hub.ListRooms(context.Background(), domain.RoomQuery{Cursor: "4", Limit: 1})
+	assert.NoError(t, err)
+	assert.Len(t, page.Rooms, 1)
+	assert.Equal(t, "room-a", page.Rooms[0].ID)
+	assert.Equal(t, "4:1", page.NextCursor)
+
+	page, err = hub.ListRooms(context.Background(), domain.RoomQuery{Cursor: page.NextCursor, Limit: 1})
+	assert.NoError(t, err)
+	assert.Len(t, page.Rooms, 1)
+	assert.Equal(t, "room-b", page.Rooms[0].ID)
+	assert.Equal(t, "4:2", page.NextCursor)
+}
+
+func TestRedisHub_ListRooms_SkipsRoomsWithUnreadableEvents(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockRedis := NewMockRedisClient(ctrl)
+
+	snapshots := map[string]string{}
+	for _, id := range []string{"room-broken", "room-valid"} {
+		data, _ := serialization.SerializeRoom(entity.NewRoomWithID(id, clientcollection.New()))
+		snapshots["planning-poker:room:"+id] = string(data)
+	}
+
+	expectScan(mockRedis, 0, []string{"planning-poker:room:room-broken", "planning-poker:room:room-valid"}, 0)
+	expectPipelined(mockRedis, func(cmd redis.Cmder) {
+		cmd.(*redis.StringCmd).SetVal(snapshots[cmd.Args()[1].(string)])
+	})
+	expectPipelined(mockRedis, func(cmd redis.Cmder) {
+		if cmd.Args()[1] == "planning-poker:room-events:room-broken" {
+			cmd.(*redis.XMessageSliceCmd).SetVal([]redis.XMessage{{ID: "2-0", Values: map[string]any{}}})
+		}
+	})
+
+	hub := &RedisHub{client: mockRedis, logger: log.NewLogger("test")}
+
+	page, err := hub.ListRooms(context.Background(), domain.RoomQuery{})
+	assert.NoError(t, err)
+	assert.Len(t, page.Rooms, 1)
+	assert.Equal(t, "room-valid", page.Rooms[0].ID)
+}
+
+func TestRedisHub_ListRooms_Sorted(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockRedis := NewMockRedisClient(ctrl)
+
+	snapshots := map[string]string{}
+	for _, id := range []string{"room-b", "room-a", "room-c"} {
+		data, _ := serialization.SerializeRoom(entity.NewRoomWithID(id, clientcollection.New()))
+		snapshots["planning-poker:room:"+id] = string(data)
+	}
+
+	expectScan(mockRedis, 0, []string{"planning-poker:room:room-b", "planning-poker:room:room-a"}, 3)
+	expectScan(mockRedis, 3, []string{"planning-poker:room:room-c"}, 0)
+	for range 2 {
+		expectPipelined(mockRedis, func(cmd redis.Cmder) {
+			cmd.(*redis.StringCmd).SetVal(snapshots[cmd.Args()[1].(string)])
+		})
+		expectPipelined(mockRedis, func(cmd redis.Cmder) {})
+	}
+
+	hub := &RedisHub{client: mockRedis, logger: log.NewLogger("test")}
+
+	page, err := hub.ListRooms(context.Background(), domain.RoomQuery{SortBy: domain.RoomSortID, Descending: true, Limit: 2})
+	assert.NoError(t, err)
+	assert.Len(t, page.Rooms, 2)
+	assert.Equal(t, "room-c", page.Rooms[0].ID)
+	assert.Equal(t, "room-b", page.Rooms[1].ID)
+	assert.Equal(t, "2", page.NextCursor)
+}
+
+func TestRedisHub_ListRooms_InvalidCursor(t *testing.T) {
+	hub := &RedisHub{client: NewMockRedisClient(gomock.NewController(t)), logger: log.NewLogger("test")}
+
+	for _, cursor := range []string{"abc", "4:abc", "4:-1"} {
+		_, err := hub.ListRooms(context.Background(), domain.RoomQuery{Cursor: cursor})
+		assert.ErrorIs(t, err, domain.ErrInvalidCursor, cursor)
+	}
+}
+
+func expectScan(mockRedis *MockRedisClient, cursor uint64, keys []string, next uint64) {
+	scanCmd := redis.NewScanCmd(context.Background(), nil)
+	scanCmd.SetVal(keys, next)
+	mockRedis.EXPECT().Scan(gomock.Any(), cursor, "planning-poker:room:*", gomock.Any()).Return(scanCmd)
+}
+
+// expectPipelined queues the commands of the next pipeline without a server
+// and lets reply set their results.
+func expectPipelined(mockRedis *MockRedisClient, reply func(cmd redis.Cmder)) {
+	mockRedis.EXPECT().
+		Pipelined(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, fn func(redis.Pipeliner) error) ([]redis.Cmder, error) {
+			pipe := redis.NewClient(&redis.Options{}).Pipeline()
+			if err := fn(pipe); err != nil {
+				return nil, err
+			}
+			cmds := pipe.Cmds()
+			for _, cmd := range cmds {
+				reply(cmd)
+			}
+			return cmds, nil
+		})
+}
+
+func storedRoomState(state dto.RoomState) string {
+	data, _ := json.Marshal(state)
+	return string(data)
+}
+
+// expectRoomStatePublished expects the next room state of room5 to be
+// published against current, with the given result of the script. The map it
+// returns holds what was published once the broadcast is done.
+func expectRoomStatePublished(t *testing.T, mockRedis *MockRedisClient, current string, result int64) map[string]any {
+	t.Helper()
+	key := "planning-poker:room-broadcast:room5"
+	if current == "" {
+		mockRedis.EXPECT().Get(gomock.Any(), key).Return(redis.NewStringResult("", redis.Nil))
+	} else {
+		mockRedis.EXPECT().Get(gomock.Any(), key).Return(redis.NewStringResult(current, nil))
+	}
+
+	published := map[string]any{}
+	mockRedis.EXPECT().
+		Eval(gomock.Any(), publishRoomStateScript, []string{key}, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, _ []string, args ...any) *redis.Cmd {
+			assert.Equal(t, current, args[0], "state the update was computed against")
+			assert.Equal(t, "planning-poker:updates:room5", args[4])
+			var msg struct {
+				Payload map[string]any `json:"payload"`
+			}
+			assert.NoError(t, json.Unmarshal([]byte(args[5].(string)), &msg))
+			maps.Copy(published, msg.Payload)
+
+			cmd := redis.NewCmd(context.Background())
+			cmd.SetVal(result)
+			return cmd
+		})
+	return published
+}
+
+func expectEventsAppended(mockRedis *MockRedisClient) {
+	appended := redis.NewCmd(context.Background())
+	appended.SetVal(int64(1))
+	mockRedis.EXPECT().Eval(gomock.Any(), appendEventsScript, gomock.Any(), gomock.Any()).Return(appended).AnyTimes()
+}
+
+func expectNoEventsAfterSnapshot(mockRedis *MockRedisClient, roomID string) {
+	mockRedis.EXPECT().XRange(gomock.Any(), "planning-poker:room-events:"+roomID, gomock.Any(), "+").Return(redis.NewXMessageSliceCmd(context.Background()))
 }
@@ -85,6 +85,49 @@ func (c *MockRedisClientDelCall) DoAndReturn(f func(context.Context, ...string)
 	return c
 }
 
+// Eval mocks base method.
+func (m *MockRedisClient) Eval(ctx context.Context, script string, keys []string, args ...any) *redis.Cmd {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, script, keys}
+	for _, a := range args {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Eval", varargs...)
+	ret0, _ := ret[0].(*redis.Cmd)
+	return ret0
+}
+
+// Eval indicates an expected call of Eval.
+func (mr *MockRedisClientMockRecorder) Eval(ctx, script, keys any, args ...any) *MockRedisClientEvalCall {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, script, keys}, args...)
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Eval", reflect.TypeOf((*MockRedisClient)(nil).Eval), varargs...)
+	return &MockRedisClientEvalCall{Call: call}
+}
+
+// MockRedisClientEvalCall wrap *gomock.Call
+type MockRedisClientEvalCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockRedisClientEvalCall) Return(arg0 *redis.Cmd) *MockRedisClientEvalCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockRedisClientEvalCall) Do(f func(context.Context, string, []string, ...any) *redis.Cmd) *MockRedisClientEvalCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockRedisClientEvalCall) DoAndReturn(f func(context.Context, string, []string, ...any) *redis.Cmd) *MockRedisClientEvalCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // Get mocks base method.
 func (m *MockRedisClient) Get(ctx context.Context, key string) *redis.StringCmd {
 	m.ctrl.T.Helper()
@@ -123,40 +166,41 @@ func (c *MockRedisClientGetCall) DoAndReturn(f func(context.Context, string) *re
 	return c
 }
 
-// Keys mocks base method.
-func (m *MockRedisClient) Keys(ctx context.Context, pattern string) *redis.StringSliceCmd {
+// Pipelined mocks base method.
+func (m *MockRedisClient) Pipelined(ctx context.Context, fn func(redis.Pipeliner) error) ([]redis.Cmder, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Keys", ctx, pattern)
-	ret0, _ := ret[0].(*redis.StringSliceCmd)
-	return ret0
+	ret := m.ctrl.Call(m, "Pipelined", ctx, fn)
+	ret0, _ := ret[0].([]redis.Cmder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// Keys indicates an expected call of Keys.
-func (mr *MockRedisClientMockRecorder) Keys(ctx, pattern any) *MockRedisClientKeysCall {
+// Pipelined indicates an expected call of Pipelined.
+func (mr *MockRedisClientMockRecorder) Pipelined(ctx, fn any) *MockRedisClientPipelinedCall {
 	mr.mock.ctrl.T.Helper()
-	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Keys", reflect.TypeOf((*MockRedisClient)(nil).Keys), ctx, pattern)
-	return &MockRedisClientKeysCall{Call: call}
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Pipelined", reflect.TypeOf((*MockRedisClient)(nil).Pipelined), ctx, fn)
+	return &MockRedisClientPipelinedCall{Call: call}
 }
 
-// MockRedisClientKeysCall wrap *gomock.Call
-type MockRedisClientKeysCall struct {
+// MockRedisClientPipelinedCall wrap *gomock.Call
+type MockRedisClientPipelinedCall struct {
 	*gomock.Call
 }
 
 // Return rewrite *gomock.Call.Return
-func (c *MockRedisClientKeysCall) Return(arg0 *redis.StringSliceCmd) *MockRedisClientKeysCall {
-	c.Call = c.Call.Return(arg0)
+func (c *MockRedisClientPipelinedCall) Return(arg0 []redis.Cmder, arg1 error) *MockRedisClientPipelinedCall {
+	c.Call = c.Call.Return(arg0, arg1)
 	return c
 }
 
 // Do rewrite *gomock.Call.Do
-func (c *MockRedisClientKeysCall) Do(f func(context.Context, string) *redis.StringSliceCmd) *MockRedisClientKeysCall {
+func (c *MockRedisClientPipelinedCall) Do(f func(context.Context, func(redis.Pipeliner) error) ([]redis.Cmder, error)) *MockRedisClientPipelinedCall {
 	c.Call = c.Call.Do(f)
 	return c
 }
 
 // DoAndReturn rewrite *gomock.Call.DoAndReturn
-func (c *MockRedisClientKeysCall) DoAndReturn(f func(context.Context, string) *redis.StringSliceCmd) *MockRedisClientKeysCall {
+func (c *MockRedisClientPipelinedCall) DoAndReturn(f func(context.Context, func(redis.Pipeliner) error) ([]redis.Cmder, error)) *MockRedisClientPipelinedCall {
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
@@ -200,17 +244,17 @@ func (c *MockRedisClientPublishCall) DoAndReturn(f func(context.Context, string,
 }
 
 // Scan mocks base method.
-func (m *MockRedisClient) Scan(ctx context.Context, count uint64, match string, cursor int64) *redis.ScanCmd {
+func (m *MockRedisClient) Scan(ctx context.Context, cursor uint64, match string, count int64) *redis.ScanCmd {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Scan", ctx, count, match, cursor)
+	ret := m.ctrl.Call(m, "Scan", ctx, cursor, match, count)
 	ret0, _ := ret[0].(*redis.ScanCmd)
 	return ret0
 }
 
 // Scan indicates an expected call of Scan.
-func (mr *MockRedisClientMockRecorder) Scan(ctx, count, match, cursor any) *MockRedisClientScanCall {
+func (mr *MockRedisClientMockRecorder) Scan(ctx, cursor, match, count any) *MockRedisClientScanCall {
 	mr.mock.ctrl.T.Helper()
-	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Scan", reflect.TypeOf((*MockRedisClient)(nil).Scan), ctx, count, match, cursor)
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Scan", reflect.TypeOf((*MockRedisClient)(nil).Scan), ctx, cursor, match, count)
 	return &MockRedisClientScanCall{Call: call}
 }
 
@@ -317,3 +361,41 @@ func (c *MockRedisClientSubscribeCall) DoAndReturn(f func(context.Context, ...st
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
+
+// XRange mocks base method.
+func (m *MockRedisClient) XRange(ctx context.Context, stream, start, stop string) *redis.XMessageSliceCmd {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "XRange", ctx, stream, start, stop)
+	ret0, _ := ret[0].(*redis.XMessageSliceCmd)
+	return ret0
+}
+
+// XRange indicates an expected call of XRange.
+func (mr *MockRedisClientMockRecorder) XRange(ctx, stream, start, stop any) *MockRedisClientXRangeCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "XRange", reflect.TypeOf((*MockRedisClient)(nil).XRange), ctx, stream, start, stop)
+	return &MockRedisClientXRangeCall{Call: call}
+}
+
+// MockRedisClientXRangeCall wrap *gomock.Call
+type MockRedisClientXRangeCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockRedisClientXRangeCall) Return(arg0 *redis.XMessageSliceCmd) *MockRedisClientXRangeCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockRedisClientXRangeCall) Do(f func(context.Context, string, string, string) *redis.XMessageSliceCmd) *MockRedisClientXRangeCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockRedisClientXRangeCall) DoAndReturn(f func(context.Context, string, string, string) *redis.XMessageSliceCmd) *MockRedisClientXRangeCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
@@ -0,0 +1,379 @@
+package serialization
+
+import (
+	"encoding/json"
+	"planning-poker/internal/domain/entity"
+	"time"
+
+	"github.com/bruno303/go-toolkit/pkg/log"
+	"github.com/samber/lo"
+)
+
+type (
+	SerializedStory struct {
+		Name               string                `json:"name"`
+		Result             *float32              `json:"result,omitempty"`
+		MostAppearingVotes []int                 `json:"mostAppearingVotes"`
+		Voted              bool                  `json:"voted"`
+		Statistics         *SerializedStatistics `json:"statistics,omitempty"`
+		Votes              []SerializedStoryVote `json:"votes,omitempty"`
+	}
+	SerializedStoryVote struct {
+		ClientID string `json:"clientId"`
+		Name     string `json:"name"`
+		Vote     string `json:"vote"`
+	}
+	SerializedStatistics struct {
+		Median    float32  `json:"median"`
+		Min       int      `json:"min"`
+		Max       int      `json:"max"`
+		StdDev    float32  `json:"stdDev"`
+		Outliers  []string `json:"outliers"`
+		Consensus bool     `json:"consensus"`
+	}
+	SerializedDeck struct {
+		Name  string   `json:"name"`
+		Cards []string `json:"cards"`
+	}
+	SerializedRoom struct {
+		ID                 string                `json:"id"`
+		Clients            []SerializedClient    `json:"clients"`
+		CurrentStory       string                `json:"currentStory"`
+		Reveal             bool                  `json:"reveal"`
+		Result             *float32              `json:"result,omitempty"`
+		MostAppearingVotes []int                 `json:"mostAppearingVotes"`
+		BacklogMode        bool                  `json:"backlogMode"`
+		Stories            []SerializedStory     `json:"stories,omitempty"`
+		CurrentStoryIndex  int                   `json:"currentStoryIndex"`
+		Deck               *SerializedDeck       `json:"deck,omitempty"`
+		Statistics         *SerializedStatistics `json:"statistics,omitempty"`
+		ConsensusRule      string                `json:"consensusRule,omitempty"`
+		VotingDeadline     *time.Time            `json:"votingDeadline,omitempty"`
+		PasscodeHash       string                `json:"passcodeHash,omitempty"`
+		InviteSecret       []byte                `json:"inviteSecret,omitempty"`
+		InviteOnly         bool                  `json:"inviteOnly,omitempty"`
+		Permissions        map[string][]string   `json:"permissions,omitempty"`
+		Version            int64                 `json:"version,omitempty"`
+		CreatedAt          time.Time             `json:"createdAt,omitzero"`
+		UpdatedAt          time.Time             `json:"updatedAt,omitzero"`
+	}
+	SerializedRoomEvent struct {
+		Sequence     int64           `json:"sequence"`
+		Type         string          `json:"type"`
+		ClientID     string          `json:"clientId,omitempty"`
+		TargetID     string          `json:"targetId,omitempty"`
+		Subject      string          `json:"subject,omitempty"`
+		Name         string          `json:"name,omitempty"`
+		Index        int             `json:"index,omitempty"`
+		Vote         *string         `json:"vote,omitempty"`
+		Deck         *SerializedDeck `json:"deck,omitempty"`
+		Rule         string          `json:"rule,omitempty"`
+		Action       string          `json:"action,omitempty"`
+		Roles        []string        `json:"roles,omitempty"`
+		Deadline     *time.Time      `json:"deadline,omitempty"`
+		PasscodeHash string          `json:"passcodeHash,omitempty"`
+		InviteSecret []byte          `json:"inviteSecret,omitempty"`
+		TokenHash    string          `json:"tokenHash,omitempty"`
+		OccurredAt   time.Time       `json:"occurredAt"`
+	}
+	SerializedClient struct {
+		ID          string  `json:"id"`
+		Name        string  `json:"name"`
+		Subject     string  `json:"subject,omitempty"`
+		CurrentVote *string `json:"currentVote,omitempty"`
+		HasVoted    bool    `json:"hasVoted"`
+		IsSpectator bool    `json:"isSpectator"`
+		IsOwner     bool    `json:"isOwner"`
+		Bot         bool    `json:"bot,omitempty"`
+		TokenHash   string  `json:"tokenHash,omitempty"`
+	}
+)
+
+func (sc SerializedClient) Client(room *entity.Room) *entity.Client {
+	client := &entity.Client{
+		ID:          sc.ID,
+		Name:        sc.Name,
+		Subject:     sc.Subject,
+		CurrentVote: sc.CurrentVote,
+		HasVoted:    sc.HasVoted,
+		IsSpectator: sc.IsSpectator,
+		IsOwner:     sc.IsOwner,
+		Bot:         sc.Bot,
+		TokenHash:   sc.TokenHash,
+	}
+
+	return client.
+		WithRoom(room).
+		WithLogger(log.NewLogger("planningpoker.client"))
+}
+
+func SerializeRoom(room *entity.Room) ([]byte, error) {
+	clients := make([]SerializedClient, 0, room.Clients.Count())
+	room.Clients.ForEach(func(client *entity.Client) {
+		clients = append(clients, SerializedClient{
+			ID:          client.ID,
+			Name:        client.Name,
+			Subject:     client.Subject,
+			CurrentVote: client.CurrentVote,
+			HasVoted:    client.HasVoted,
+			IsSpectator: client.IsSpectator,
+			IsOwner:     client.IsOwner,
+			Bot:         client.Bot,
+			TokenHash:   client.TokenHash,
+		})
+	})
+
+	serialized := SerializedRoom{
+		ID:                 room.ID,
+		Clients:            clients,
+		CurrentStory:       room.CurrentStory,
+		Reveal:             room.Reveal,
+		Result:             room.Result,
+		MostAppearingVotes: room.MostAppearingVotes,
+		BacklogMode:        room.BacklogMode,
+		Stories:            serializeStories(room.Stories),
+		CurrentStoryIndex:  room.CurrentStoryIndex,
+		Deck:               serializeDeck(room.Deck),
+		Statistics:         serializeStatistics(room.Statistics),
+		ConsensusRule:      string(room.ConsensusRule),
+		VotingDeadline:     room.VotingDeadline,
+		PasscodeHash:       room.PasscodeHash,
+		InviteSecret:       room.InviteSecret,
+		InviteOnly:         room.InviteOnly,
+		Permissions:        serializePermissions(room.Policy),
+		Version:            room.Version,
+		CreatedAt:          room.CreatedAt,
+		UpdatedAt:          room.UpdatedAt,
+	}
+
+	return json.Marshal(serialized)
+}
+
+func SerializeRoomEvent(event entity.RoomEvent) ([]byte, error) {
+	serialized := SerializedRoomEvent{
+		Sequence:     event.Sequence,
+		Type:         string(event.Type),
+		ClientID:     event.ClientID,
+		TargetID:     event.TargetID,
+		Subject:      event.Subject,
+		Name:         event.Name,
+		Index:        event.Index,
+		Vote:         event.Vote,
+		Rule:         string(event.Rule),
+		Action:       string(event.Action),
+		Deadline:     event.Deadline,
+		PasscodeHash: event.PasscodeHash,
+		InviteSecret: event.InviteSecret,
+		TokenHash:    event.TokenHash,
+		OccurredAt:   event.OccurredAt,
+	}
+	if event.Deck != nil {
+		serialized.Deck = &SerializedDeck{Name: event.Deck.Name, Cards: event.Deck.Cards}
+	}
+	if event.Roles != nil {
+		serialized.Roles = lo.Map(event.Roles, func(role entity.Role, _ int) string {
+			return string(role)
+		})
+	}
+
+	return json.Marshal(serialized)
+}
+
+func DeserializeRoomEvent(data []byte) (entity.RoomEvent, error) {
+	var serialized SerializedRoomEvent
+	if err := json.Unmarshal(data, &serialized); err != nil {
+		return entity.RoomEvent{}, err
+	}
+
+	event := entity.RoomEvent{
+		Sequence:     serialized.Sequence,
+		Type:         entity.RoomEventType(serialized.Type),
+		ClientID:     serialized.ClientID,
+		TargetID:     serialized.TargetID,
+		Subject:      serialized.Subject,
+		Name:         serialized.Name,
+		Index:        serialized.Index,
+		Vote:         serialized.Vote,
+		Rule:         entity.ConsensusRule(serialized.Rule),
+		Action:       entity.Action(serialized.Action),
+		Deadline:     serialized.Deadline,
+		PasscodeHash: serialized.PasscodeHash,
+		InviteSecret: serialized.InviteSecret,
+		TokenHash:    serialized.TokenHash,
+		OccurredAt:   serialized.OccurredAt,
+	}
+	if serialized.Deck != nil {
+		event.Deck = &entity.Deck{Name: serialized.Deck.Name, Cards: serialized.Deck.Cards}
+	}
+	if serialized.Roles != nil {
+		event.Roles = lo.Map(serialized.Roles, func(role string, _ int) entity.Role {
+			return entity.Role(role)
+		})
+	}
+
+	return event, nil
+}
+
+func serializePermissions(policy entity.Policy) map[string][]string {
+	if len(policy.Overrides) == 0 {
+		return nil
+	}
+	result := make(map[string][]string, len(policy.Overrides))
+	for action, roles := range policy.Overrides {
+		result[string(action)] = lo.Map(roles, func(role entity.Role, _ int) string {
+			return string(role)
+		})
+	}
+	return result
+}
+
+func deserializePermissions(permissions map[string][]string) entity.Policy {
+	if len(permissions) == 0 {
+		return entity.Policy{}
+	}
+	overrides := make(map[entity.Action][]entity.Role, len(permissions))
+	for action, roles := range permissions {
+		overrides[entity.Action(action)] = lo.Map(roles, func(role string, _ int) entity.Role {
+			return entity.Role(role)
+		})
+	}
+	return entity.Policy{Overrides: overrides}
+}
+
+func serializeDeck(deck entity.Deck) *SerializedDeck {
+	if deck.IsEmpty() {
+		return nil
+	}
+	return &SerializedDeck{
+		Name:  deck.Name,
+		Cards: deck.Cards,
+	}
+}
+
+func serializeStories(stories []entity.Story) []SerializedStory {
+	result := make([]SerializedStory, len(stories))
+	for i, s := range stories {
+		result[i] = SerializedStory{
+			Name:               s.Name,
+			Result:             s.Result,
+			MostAppearingVotes: s.MostAppearingVotes,
+			Voted:              s.Voted,
+			Statistics:         serializeStatistics(s.Statistics),
+			Votes:              serializeStoryVotes(s.Votes),
+		}
+	}
+	return result
+}
+
+func serializeStoryVotes(votes []entity.StoryVote) []SerializedStoryVote {
+	if votes == nil {
+		return nil
+	}
+	result := make([]SerializedStoryVote, len(votes))
+	for i, v := range votes {
+		result[i] = SerializedStoryVote{ClientID: v.ClientID, Name: v.Name, Vote: v.Vote}
+	}
+	return result
+}
+
+func serializeStatistics(stats *entity.VoteStatistics) *SerializedStatistics {
+	if stats == nil {
+		return nil
+	}
+	return &SerializedStatistics{
+		Median:    stats.Median,
+		Min:       stats.Min,
+		Max:       stats.Max,
+		StdDev:    stats.StdDev,
+		Outliers:  stats.Outliers,
+		Consensus: stats.Consensus,
+	}
+}
+
+func DeserializeRoom(data []byte, clientCollection entity.ClientCollection) (*entity.Room, error) {
+	var serialized SerializedRoom
+	if err := json.Unmarshal(data, &serialized); err != nil {
+		return nil, err
+	}
+
+	room := &entity.Room{
+		ID:                 serialized.ID,
+		Clients:            clientCollection,
+		CurrentStory:       serialized.CurrentStory,
+		Reveal:             serialized.Reveal,
+		Result:             serialized.Result,
+		MostAppearingVotes: serialized.MostAppearingVotes,
+		BacklogMode:        serialized.BacklogMode,
+		Stories:            deserializeStories(serialized.Stories),
+		CurrentStoryIndex:  serialized.CurrentStoryIndex,
+		Deck:               deserializeDeck(serialized.Deck),
+		Statistics:         deserializeStatistics(serialized.Statistics),
+		ConsensusRule:      entity.ConsensusRule(serialized.ConsensusRule),
+		VotingDeadline:     serialized.VotingDeadline,
+		PasscodeHash:       serialized.PasscodeHash,
+		InviteSecret:       serialized.InviteSecret,
+		InviteOnly:         serialized.InviteOnly,
+		Policy:             deserializePermissions(serialized.Permissions),
+		Version:            serialized.Version,
+		CreatedAt:          serialized.CreatedAt,
+		UpdatedAt:          serialized.UpdatedAt,
+	}
+
+	for _, sc := range serialized.Clients {
+		client := sc.Client(room)
+		room.Clients.Add(client)
+	}
+
+	return room, nil
+}
+
+func deserializeStories(stories []SerializedStory) []entity.Story {
+	result := make([]entity.Story, len(stories))
+	for i, s := range stories {
+		result[i] = entity.Story{
+			Name:               s.Name,
+			Result:             s.Result,
+			MostAppearingVotes: s.MostAppearingVotes,
+			Voted:              s.Voted,
+			Statistics:         deserializeStatistics(s.Statistics),
+			Votes:              deserializeStoryVotes(s.Votes),
+		}
+	}
+	return result
+}
+
+func deserializeStoryVotes(votes []SerializedStoryVote) []entity.StoryVote {
+	if votes == nil {
+		return nil
+	}
+	result := make([]entity.StoryVote, len(votes))
+	for i, v := range votes {
+		result[i] = entity.StoryVote{ClientID: v.ClientID, Name: v.Name, Vote: v.Vote}
+	}
+	return result
+}
+
+func deserializeStatistics(stats *SerializedStatistics) *entity.VoteStatistics {
+	if stats == nil {
+		return nil
+	}
+	return &entity.VoteStatistics{
+		Median:    stats.Median,
+		Min:       stats.Min,
+		Max:       stats.Max,
+		StdDev:    stats.StdDev,
+		Outliers:  stats.Outliers,
+		Consensus: stats.Consensus,
+	}
+}
+
+// rooms saved before decks were introduced have no deck and use the default one
+func deserializeDeck(deck *SerializedDeck) entity.Deck {
+	if deck == nil || len(deck.Cards) == 0 {
+		return entity.DefaultDeck()
+	}
+	return entity.Deck{
+		Name:  deck.Name,
+		Cards: deck.Cards,
+	}
+}
@@ -0,0 +1,265 @@
+package serialization
+
+import (
+	"context"
+	"planning-poker/internal/domain/entity"
+	"planning-poker/internal/infra/boundaries/hub/clientcollection"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+)
+
+func TestSerializeDeserializeRoom(t *testing.T) {
+	// Create a room with some clients
+	originalRoom := entity.NewRoom(clientcollection.New())
+	originalRoom.ID = "test-room-123"
+	originalRoom.CurrentStory = "User Story #42"
+	originalRoom.Reveal = false
+
+	// Add some clients
+	client1 := originalRoom.NewClient("client-1")
+	client1.Name = "Alice"
+	client1.IsOwner = true
+	client1.IsSpectator = false
+
+	client2 := originalRoom.NewClient("client-2")
+	client2.Name = "Bob"
+	client2.IsOwner = false
+	client2.IsSpectator = false
+	vote := "5"
+	client2.CurrentVote = &vote
+	client2.HasVoted = true
+
+	// Serialize the room
+	data, err := SerializeRoom(originalRoom)
+	if err != nil {
+		t.Fatalf("Failed to serialize room: %v", err)
+	}
+
+	// Deserialize the room
+	deserializedRoom, err := DeserializeRoom(data, clientcollection.New())
+	if err != nil {
+		t.Fatalf("Failed to deserialize room: %v", err)
+	}
+
+	// Verify room properties
+	if deserializedRoom.ID != originalRoom.ID {
+		t.Errorf("Expected room ID %s, got %s", originalRoom.ID, deserializedRoom.ID)
+	}
+	if deserializedRoom.CurrentStory != originalRoom.CurrentStory {
+		t.Errorf("Expected story %s, got %s", originalRoom.CurrentStory, deserializedRoom.CurrentStory)
+	}
+	if deserializedRoom.Reveal != originalRoom.Reveal {
+		t.Errorf("Expected reveal %v, got %v", originalRoom.Reveal, deserializedRoom.Reveal)
+	}
+
+	// Verify client count
+	if deserializedRoom.Clients.Count() != originalRoom.Clients.Count() {
+		t.Errorf("Expected %d clients, got %d", originalRoom.Clients.Count(), deserializedRoom.Clients.Count())
+	}
+
+	// Verify client properties
+	deserializedClient1, ok := deserializedRoom.Clients.Filter(func(c *entity.Client) bool {
+		return c.ID == "client-1"
+	}).First()
+	if !ok {
+		t.Fatal("Client 1 not found in deserialized room")
+	}
+	if deserializedClient1.Name != "Alice" {
+		t.Errorf("Expected client name Alice, got %s", deserializedClient1.Name)
+	}
+	if !deserializedClient1.IsOwner {
+		t.Error("Expected client 1 to be owner")
+	}
+
+	deserializedClient2, ok := deserializedRoom.Clients.Filter(func(c *entity.Client) bool {
+		return c.ID == "client-2"
+	}).First()
+	if !ok {
+		t.Fatal("Client 2 not found in deserialized room")
+	}
+	if deserializedClient2.Name != "Bob" {
+		t.Errorf("Expected client name Bob, got %s", deserializedClient2.Name)
+	}
+	if !deserializedClient2.HasVoted {
+		t.Error("Expected client 2 to have voted")
+	}
+	if deserializedClient2.CurrentVote == nil || *deserializedClient2.CurrentVote != "5" {
+		t.Errorf("Expected client 2 vote to be 5, got %v", lo.FromPtr(deserializedClient2.CurrentVote))
+	}
+}
+
+func TestSerializeDeserializeRoom_Deck(t *testing.T) {
+	originalRoom := entity.NewRoom(clientcollection.New())
+	originalRoom.Deck = entity.Deck{Name: entity.DeckCustom, Cards: []string{"small", "big", "?"}}
+
+	data, err := SerializeRoom(originalRoom)
+	if err != nil {
+		t.Fatalf("Failed to serialize room: %v", err)
+	}
+
+	deserializedRoom, err := DeserializeRoom(data, clientcollection.New())
+	if err != nil {
+		t.Fatalf("Failed to deserialize room: %v", err)
+	}
+
+	if deserializedRoom.Deck.Name != entity.DeckCustom {
+		t.Errorf("Expected deck %s, got %s", entity.DeckCustom, deserializedRoom.Deck.Name)
+	}
+	if len(deserializedRoom.Deck.Cards) != 3 || deserializedRoom.Deck.Cards[1] != "big" {
+		t.Errorf("Expected cards to round-trip, got %v", deserializedRoom.Deck.Cards)
+	}
+}
+
+func TestSerializeDeserializeRoom_Statistics(t *testing.T) {
+	originalRoom := entity.NewRoom(clientcollection.New())
+	originalRoom.ConsensusRule = entity.ConsensusAdjacent
+	originalRoom.Statistics = &entity.VoteStatistics{Median: 5, Min: 3, Max: 21, StdDev: 7.3, Outliers: []string{"client4"}}
+	originalRoom.Stories = []entity.Story{{Name: "Story 1", Voted: true, Statistics: &entity.VoteStatistics{Median: 8, Min: 8, Max: 8, Outliers: []string{}, Consensus: true}}}
+
+	data, err := SerializeRoom(originalRoom)
+	if err != nil {
+		t.Fatalf("Failed to serialize room: %v", err)
+	}
+
+	deserializedRoom, err := DeserializeRoom(data, clientcollection.New())
+	if err != nil {
+		t.Fatalf("Failed to deserialize room: %v", err)
+	}
+
+	if deserializedRoom.ConsensusRule != entity.ConsensusAdjacent {
+		t.Errorf("Expected consensus rule %s, got %s", entity.ConsensusAdjacent, deserializedRoom.ConsensusRule)
+	}
+	if !reflect.DeepEqual(deserializedRoom.Statistics, originalRoom.Statistics) {
+		t.Errorf("Expected statistics %+v, got %+v", originalRoom.Statistics, deserializedRoom.Statistics)
+	}
+	if !reflect.DeepEqual(deserializedRoom.Stories[0].Statistics, originalRoom.Stories[0].Statistics) {
+		t.Errorf("Expected story statistics %+v, got %+v", originalRoom.Stories[0].Statistics, deserializedRoom.Stories[0].Statistics)
+	}
+}
+
+func TestSerializeDeserializeRoom_Access(t *testing.T) {
+	originalRoom := entity.NewRoom(clientcollection.New())
+	originalRoom.NewClient("owner")
+	_ = originalRoom.SetPasscode(context.Background(), "owner", "s3cret")
+	invite, _ := originalRoom.CreateInvite(context.Background(), "owner", time.Now(), time.Hour)
+
+	data, err := SerializeRoom(originalRoom)
+	if err != nil {
+		t.Fatalf("Failed to serialize room: %v", err)
+	}
+
+	deserializedRoom, err := DeserializeRoom(data, clientcollection.New())
+	if err != nil {
+		t.Fatalf("Failed to deserialize room: %v", err)
+	}
+
+	if err := deserializedRoom.Authorize(time.Now(), entity.Credentials{Passcode: "s3cret"}); err != nil {
+		t.Errorf("Expected passcode to survive serialization, got %v", err)
+	}
+	if err := deserializedRoom.Authorize(time.Now(), entity.Credentials{InviteToken: invite.Token}); err != nil {
+		t.Errorf("Expected invite to survive serialization, got %v", err)
+	}
+}
+
+func TestSerializeDeserializeRoom_Identity(t *testing.T) {
+	identity := entity.Identity{Issuer: "https://idp.example.com", Subject: "auth0|alice", Name: "Alice"}
+	originalRoom := entity.NewRoom(clientcollection.New())
+	originalRoom.NewClientWithIdentity(identity.ClientID(), identity)
+
+	data, err := SerializeRoom(originalRoom)
+	if err != nil {
+		t.Fatalf("Failed to serialize room: %v", err)
+	}
+	deserializedRoom, err := DeserializeRoom(data, clientcollection.New())
+	if err != nil {
+		t.Fatalf("Failed to deserialize room: %v", err)
+	}
+
+	client, ok := deserializedRoom.FindClient(identity.ClientID())
+	if !ok || client.Subject != identity.Subject || client.Name != identity.Name {
+		t.Fatalf("Expected the identity to survive serialization, got %+v", client)
+	}
+
+	events := originalRoom.PendingEvents()
+	data, err = SerializeRoomEvent(events[len(events)-1])
+	if err != nil {
+		t.Fatalf("Failed to serialize event: %v", err)
+	}
+	deserializedEvent, err := DeserializeRoomEvent(data)
+	if err != nil {
+		t.Fatalf("Failed to deserialize event: %v", err)
+	}
+	if deserializedEvent.Subject != identity.Subject || deserializedEvent.Name != identity.Name {
+		t.Errorf("Expected the identity to survive event serialization, got %+v", deserializedEvent)
+	}
+}
+
+func TestSerializeDeserializeRoom_Bot(t *testing.T) {
+	originalRoom := entity.NewRoom(clientcollection.New())
+	originalRoom.NewClient("owner")
+	credentials, _ := originalRoom.AddBot(context.Background(), "owner", "release-bot")
+
+	data, err := SerializeRoom(originalRoom)
+	if err != nil {
+		t.Fatalf("Failed to serialize room: %v", err)
+	}
+	deserializedRoom, err := DeserializeRoom(data, clientcollection.New())
+	if err != nil {
+		t.Fatalf("Failed to deserialize room: %v", err)
+	}
+	if bot, err := deserializedRoom.AuthenticateBot(credentials.Token); err != nil || !bot.Bot {
+		t.Fatalf("Expected the bot to survive serialization, got %+v, %v", bot, err)
+	}
+
+	events := originalRoom.PendingEvents()
+	data, err = SerializeRoomEvent(events[len(events)-1])
+	if err != nil {
+		t.Fatalf("Failed to serialize event: %v", err)
+	}
+	deserializedEvent, err := DeserializeRoomEvent(data)
+	if err != nil {
+		t.Fatalf("Failed to deserialize event: %v", err)
+	}
+	if deserializedEvent.TokenHash == "" || deserializedEvent.TokenHash != events[len(events)-1].TokenHash {
+		t.Errorf("Expected the token hash to survive event serialization, got %+v", deserializedEvent)
+	}
+}
+
+func TestSerializeDeserializeRoom_Permissions(t *testing.T) {
+	originalRoom := entity.NewRoom(clientcollection.New())
+	originalRoom.NewClient("owner")
+	_ = originalRoom.ChangePermission(context.Background(), "owner", entity.ActionAddStory, []entity.Role{entity.RoleVoter})
+
+	data, err := SerializeRoom(originalRoom)
+	if err != nil {
+		t.Fatalf("Failed to serialize room: %v", err)
+	}
+
+	deserializedRoom, err := DeserializeRoom(data, clientcollection.New())
+	if err != nil {
+		t.Fatalf("Failed to deserialize room: %v", err)
+	}
+
+	if !reflect.DeepEqual(deserializedRoom.Policy, originalRoom.Policy) {
+		t.Errorf("Expected policy %+v, got %+v", originalRoom.Policy, deserializedRoom.Policy)
+	}
+}
+
+func TestDeserializeRoom_WithoutDeckUsesDefault(t *testing.T) {
+	data := []byte(`{"id":"legacy-room","clients":[],"backlogMode":true}`)
+
+	room, err := DeserializeRoom(data, clientcollection.New())
+	if err != nil {
+		t.Fatalf("Failed to deserialize room: %v", err)
+	}
+
+	if room.Deck.Name != entity.DeckFibonacci {
+		t.Errorf("Expected default deck %s, got %s", entity.DeckFibonacci, room.Deck.Name)
+	}
+	if room.EffectiveConsensusRule() != entity.DefaultConsensusRule {
+		t.Errorf("Expected default consensus rule %s, got %s", entity.DefaultConsensusRule, room.EffectiveConsensusRule())
+	}
+}
@@ -0,0 +1,205 @@
+package bus
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+const asyncAPIChannel = "/planning/{roomID}/ws"
+
+var (
+	timeType       = reflect.TypeFor[time.Time]()
+	rawMessageType = reflect.TypeFor[json.RawMessage]()
+)
+
+// AsyncAPI describes a version of the protocol as an AsyncAPI 2.6 document,
+// built from the message types so that it cannot drift from the code.
+// Clients publish the inbound messages and subscribe to the outbound ones.
+func AsyncAPI(version string) ([]byte, error) {
+	protocol, ok := LookupProtocol(version)
+	if !ok {
+		return nil, fmt.Errorf("%w %q", ErrUnsupportedProtocol, version)
+	}
+
+	schemas := schemaSet{}
+	messages := map[string]any{}
+	var publish, subscribe []any
+
+	for _, m := range protocol.Inbound {
+		name := "in." + m.Type
+		payloadType := reflect.TypeOf(m.Payload)
+		payload := map[string]any{"type": []string{"object", "null"}, "additionalProperties": false}
+		required := []string{"type"}
+		if payloadType != reflect.TypeFor[NoPayload]() {
+			payload = schemas.of(payloadType, true)
+		}
+		if len(requiredFields(payloadType)) > 0 {
+			required = append(required, "payload")
+		}
+
+		messages[name] = map[string]any{
+			"name":    m.Type,
+			"summary": m.Summary,
+			"payload": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"type":      map[string]any{"type": "string", "const": m.Type},
+					"payload":   payload,
+					"requestId": map[string]any{"type": "string", "description": "Echoed in the ack or error reply"},
+				},
+				"required":             required,
+				"additionalProperties": false,
+			},
+		}
+		publish = append(publish, messageRef(name))
+	}
+
+	for _, m := range protocol.Outbound {
+		name := "out." + m.Type
+		payload := schemas.inline(reflect.TypeOf(m.Message), false)
+		payload["properties"].(map[string]any)["type"] = map[string]any{"type": "string", "const": m.Type}
+		messages[name] = map[string]any{
+			"name":    m.Type,
+			"summary": m.Summary,
+			"payload": payload,
+		}
+		subscribe = append(subscribe, messageRef(name))
+	}
+
+	document := map[string]any{
+		"asyncapi": "2.6.0",
+		"info": map[string]any{
+			"title":   "Planning Poker websocket protocol",
+			"version": protocol.Version,
+			"description": "Messages exchanged over the room websocket. Pick the version with the protocol query parameter or the " + SubprotocolPrefix + protocol.Version + " subprotocol. " +
+				"Clients that cannot open websockets receive the same messages as Server-Sent Events from /planning/{roomID}/sse and post theirs to /planning/{roomID}/sse/{sessionID}.",
+		},
+		"defaultContentType": "application/json",
+		"channels": map[string]any{
+			asyncAPIChannel: map[string]any{
+				"parameters": map[string]any{
+					"roomID": map[string]any{"schema": map[string]any{"type": "string"}},
+				},
+				"bindings": map[string]any{
+					"ws": map[string]any{
+						"query": map[string]any{
+							"type": "object",
+							"properties": map[string]any{
+								"clientId": map[string]any{"type": "string"},
+								"passcode": map[string]any{"type": "string"},
+								"invite":   map[string]any{"type": "string"},
+								"patches":  map[string]any{"type": "boolean"},
+								"protocol": map[string]any{"type": "string", "const": protocol.Version},
+							},
+						},
+					},
+				},
+				"publish": map[string]any{
+					"operationId": "sendMessage",
+					"message":     map[string]any{"oneOf": publish},
+				},
+				"subscribe": map[string]any{
+					"operationId": "receiveMessage",
+					"message":     map[string]any{"oneOf": subscribe},
+				},
+			},
+		},
+		"components": map[string]any{
+			"messages": messages,
+			"schemas":  schemas,
+		},
+	}
+
+	return json.MarshalIndent(document, "", "  ")
+}
+
+func messageRef(name string) map[string]any {
+	return map[string]any{"$ref": "#/components/messages/" + name}
+}
+
+// schemaSet holds the JSON schemas of the named structs, referenced from the
+// messages.
+type schemaSet map[string]any
+
+// of returns the schema of a type, referencing named structs. Inbound
+// structs require the fields tagged validate:"required", outbound ones every
+// field that is always sent.
+func (s schemaSet) of(t reflect.Type, inbound bool) map[string]any {
+	switch {
+	case t == timeType:
+		return map[string]any{"type": "string", "format": "date-time"}
+	case t == rawMessageType:
+		return map[string]any{}
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		return map[string]any{"anyOf": []any{s.of(t.Elem(), inbound), map[string]any{"type": "null"}}}
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		return map[string]any{"type": "array", "items": s.of(t.Elem(), inbound)}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": s.of(t.Elem(), inbound)}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return s.inline(t, inbound)
+		}
+		if _, ok := s[t.Name()]; !ok {
+			s[t.Name()] = s.inline(t, inbound)
+		}
+		return map[string]any{"$ref": "#/components/schemas/" + t.Name()}
+	default:
+		return map[string]any{}
+	}
+}
+
+func (s schemaSet) inline(t reflect.Type, inbound bool) map[string]any {
+	properties := map[string]any{}
+	required := []string{}
+	for i := range t.NumField() {
+		field := t.Field(i)
+		if !field.IsExported() || field.Tag.Get("json") == "-" {
+			continue
+		}
+
+		name := jsonName(field)
+		properties[name] = s.of(field.Type, inbound)
+		if (inbound && field.Tag.Get("validate") == "required") || (!inbound && !omitted(field)) {
+			required = append(required, name)
+		}
+	}
+
+	schema := map[string]any{
+		"type":       "object",
+		"properties": properties,
+	}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	if inbound {
+		schema["additionalProperties"] = false
+	}
+	return schema
+}
+
+// omitted tells whether the field is left out of the JSON when empty.
+func omitted(field reflect.StructField) bool {
+	_, options, _ := strings.Cut(field.Tag.Get("json"), ",")
+	for option := range strings.SplitSeq(options, ",") {
+		if option == "omitempty" || option == "omitzero" {
+			return true
+		}
+	}
+	return false
+}
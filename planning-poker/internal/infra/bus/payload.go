@@ -0,0 +1,182 @@
+package bus
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"planning-poker/internal/domain"
+	"reflect"
+	"strings"
+)
+
+// Payloads of the inbound messages. Fields tagged validate:"required" must be
+// present, unknown fields are rejected and Validate, when a payload has it,
+// checks the values.
+type (
+	// NoPayload is the payload of messages that carry none, null or {}
+	NoPayload struct{}
+
+	UpdateNamePayload struct {
+		Username string `json:"username" validate:"required"`
+	}
+	ToggleSpectatorPayload struct {
+		TargetClientID string `json:"targetClientId" validate:"required"`
+	}
+	ToggleOwnerPayload struct {
+		TargetClientID string `json:"targetClientId" validate:"required"`
+	}
+	UpdateStoryPayload struct {
+		Story string `json:"story" validate:"required"`
+	}
+	// VotePayload clears the vote with an empty or null vote
+	VotePayload struct {
+		Vote string `json:"vote" validate:"required"`
+	}
+	AddStoryPayload struct {
+		Story string `json:"story" validate:"required"`
+	}
+	RemoveStoryPayload struct {
+		StoryIndex int `json:"storyIndex" validate:"required"`
+	}
+	// ChangeDeckPayload picks a built-in deck by name, the default one without
+	// a name, or the cards of a "custom" deck
+	ChangeDeckPayload struct {
+		Deck  string   `json:"deck"`
+		Cards []string `json:"cards"`
+	}
+	ChangeConsensusRulePayload struct {
+		Rule string `json:"rule" validate:"required"`
+	}
+	StartTimerPayload struct {
+		Seconds int `json:"seconds" validate:"required"`
+	}
+	// ExportReportPayload defaults to JSON without a format
+	ExportReportPayload struct {
+		Format string `json:"format"`
+	}
+	// SetPasscodePayload removes the passcode with an empty one
+	SetPasscodePayload struct {
+		Passcode string `json:"passcode" validate:"required"`
+	}
+	// CreateInvitePayload uses the default lifetime of invites without a TTL
+	CreateInvitePayload struct {
+		TTLSeconds int `json:"ttlSeconds"`
+	}
+	ChangePermissionPayload struct {
+		Action string   `json:"action" validate:"required"`
+		Roles  []string `json:"roles" validate:"required"`
+	}
+)
+
+func (p UpdateNamePayload) Validate() error {
+	return notBlank("username", p.Username)
+}
+
+func (p ToggleSpectatorPayload) Validate() error {
+	return notBlank("targetClientId", p.TargetClientID)
+}
+
+func (p ToggleOwnerPayload) Validate() error {
+	return notBlank("targetClientId", p.TargetClientID)
+}
+
+func (p AddStoryPayload) Validate() error {
+	return notBlank("story", p.Story)
+}
+
+func (p RemoveStoryPayload) Validate() error {
+	if p.StoryIndex < 0 {
+		return fmt.Errorf("%w: storyIndex must not be negative", domain.ErrInvalidPayload)
+	}
+	return nil
+}
+
+func (p ChangeConsensusRulePayload) Validate() error {
+	return notBlank("rule", p.Rule)
+}
+
+func (p StartTimerPayload) Validate() error {
+	if p.Seconds <= 0 {
+		return fmt.Errorf("%w: seconds must be positive", domain.ErrInvalidPayload)
+	}
+	return nil
+}
+
+func (p CreateInvitePayload) Validate() error {
+	if p.TTLSeconds < 0 {
+		return fmt.Errorf("%w: ttlSeconds must not be negative", domain.ErrInvalidPayload)
+	}
+	return nil
+}
+
+func (p ChangePermissionPayload) Validate() error {
+	return notBlank("action", p.Action)
+}
+
+func notBlank(field, value string) error {
+	if strings.TrimSpace(value) == "" {
+		return fmt.Errorf("%w: %s must not be blank", domain.ErrInvalidPayload, field)
+	}
+	return nil
+}
+
+// DecodePayload decodes a payload sent outside of a message, such as the body
+// of a request, with the rules of the protocol. An empty payload is null.
+func DecodePayload(data []byte, out any) error {
+	if len(bytes.TrimSpace(data)) == 0 {
+		data = []byte("null")
+	}
+	return decodePayload(json.RawMessage(data), out)
+}
+
+// decodePayload decodes the payload of a message strictly into out, which
+// points to a payload struct.
+func decodePayload(payload any, out any) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("%w: %w", domain.ErrInvalidPayload, err)
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return fmt.Errorf("%w: payload must be an object", domain.ErrInvalidPayload)
+	}
+	for _, name := range requiredFields(reflect.TypeOf(out).Elem()) {
+		if _, ok := fields[name]; !ok {
+			return fmt.Errorf("%w: %s is required", domain.ErrInvalidPayload, name)
+		}
+	}
+
+	if fields != nil {
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(out); err != nil {
+			return fmt.Errorf("%w: %w", domain.ErrInvalidPayload, err)
+		}
+	}
+
+	if v, ok := reflect.ValueOf(out).Elem().Interface().(interface{ Validate() error }); ok {
+		return v.Validate()
+	}
+	return nil
+}
+
+// requiredFields lists the JSON names of the fields tagged validate:"required".
+func requiredFields(t reflect.Type) []string {
+	var names []string
+	for i := range t.NumField() {
+		field := t.Field(i)
+		if field.Tag.Get("validate") == "required" {
+			names = append(names, jsonName(field))
+		}
+	}
+	return names
+}
+
+func jsonName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "" {
+		return field.Name
+	}
+	return name
+}
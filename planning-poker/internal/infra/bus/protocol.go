@@ -0,0 +1,286 @@
+package bus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"planning-poker/internal/application/planningpoker/usecase"
+	"planning-poker/internal/application/planningpoker/usecase/dto"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/samber/lo"
+)
+
+const (
+	ProtocolV1 = "v1"
+	// LatestProtocol is spoken with clients that do not ask for a version
+	LatestProtocol = ProtocolV1
+	// SubprotocolPrefix names the websocket subprotocols of the versions,
+	// e.g. planning-poker.v1
+	SubprotocolPrefix = "planning-poker."
+)
+
+var ErrUnsupportedProtocol = errors.New("unsupported protocol version")
+
+type (
+	// Protocol is a version of the websocket protocol: the messages clients
+	// may send and the ones they receive.
+	Protocol struct {
+		Version  string
+		Inbound  []InboundMessage
+		Outbound []OutboundMessage
+	}
+	InboundMessage struct {
+		Type    string
+		Summary string
+		// Payload is the zero value of the payload type
+		Payload any
+		handle  func(ctx context.Context, c *session, payload any) error
+	}
+	OutboundMessage struct {
+		Type    string
+		Summary string
+		// Message is the zero value of the message type
+		Message any
+	}
+)
+
+var protocols = map[string]Protocol{
+	ProtocolV1: protocolV1(),
+}
+
+// LookupProtocol returns the protocol of a version.
+func LookupProtocol(version string) (Protocol, bool) {
+	protocol, ok := protocols[version]
+	return protocol, ok
+}
+
+// Subprotocols lists the websocket subprotocols of every version, newest
+// first.
+func Subprotocols() []string {
+	versions := lo.Keys(protocols)
+	slices.Sort(versions)
+	slices.Reverse(versions)
+	return lo.Map(versions, func(version string, _ int) string { return SubprotocolPrefix + version })
+}
+
+// NegotiateProtocol picks the version of a connection from the version the
+// client asked for in the query or, without one, from the subprotocols it
+// offered. Clients asking for neither get the latest version.
+func NegotiateProtocol(queryVersion string, subprotocols []string) (string, error) {
+	if queryVersion != "" {
+		if _, ok := protocols[queryVersion]; !ok {
+			return "", fmt.Errorf("%w %q", ErrUnsupportedProtocol, queryVersion)
+		}
+		return queryVersion, nil
+	}
+	if len(subprotocols) == 0 {
+		return LatestProtocol, nil
+	}
+
+	for _, subprotocol := range subprotocols {
+		version, ok := strings.CutPrefix(subprotocol, SubprotocolPrefix)
+		if _, supported := protocols[version]; ok && supported {
+			return version, nil
+		}
+	}
+	return "", fmt.Errorf("%w, offered %s", ErrUnsupportedProtocol, strings.Join(subprotocols, ", "))
+}
+
+// inbound decodes and validates the payload of the message before handing
+// it to handle.
+func inbound[P any](messageType, summary string, handle func(context.Context, *session, P) error) InboundMessage {
+	var zero P
+	return InboundMessage{
+		Type:    messageType,
+		Summary: summary,
+		Payload: zero,
+		handle: func(ctx context.Context, c *session, payload any) error {
+			var decoded P
+			if err := decodePayload(payload, &decoded); err != nil {
+				return err
+			}
+			return handle(ctx, c, decoded)
+		},
+	}
+}
+
+func protocolV1() Protocol {
+	return Protocol{
+		Version: ProtocolV1,
+		Inbound: []InboundMessage{
+			inbound("update-name", "Renames the sender", func(ctx context.Context, c *session, p UpdateNamePayload) error {
+				return c.usecases.UpdateName.Execute(ctx, usecase.UpdateNameCommand{
+					RoomID:   c.roomID,
+					SenderID: c.ID,
+					Username: p.Username,
+				})
+			}),
+			inbound("vote", "Casts the vote of the sender", func(ctx context.Context, c *session, p VotePayload) error {
+				return c.usecases.Vote.Execute(ctx, usecase.VoteCommand{
+					RoomID:   c.roomID,
+					SenderID: c.ID,
+					Vote:     lo.ToPtr(p.Vote),
+				})
+			}),
+			inbound("reset", "Clears the votes of the room", func(ctx context.Context, c *session, _ NoPayload) error {
+				return c.usecases.Reset.Execute(ctx, usecase.ResetCommand{
+					RoomID:   c.roomID,
+					SenderID: c.ID,
+				})
+			}),
+			inbound("reveal-votes", "Reveals or hides the votes", func(ctx context.Context, c *session, _ NoPayload) error {
+				return c.usecases.Reveal.Execute(ctx, usecase.RevealCommand{
+					RoomID:   c.roomID,
+					SenderID: c.ID,
+				})
+			}),
+			inbound("toggle-spectator", "Turns a participant into a spectator or back", func(ctx context.Context, c *session, p ToggleSpectatorPayload) error {
+				return c.usecases.ToggleSpectator.Execute(ctx, usecase.ToggleSpectatorCommand{
+					RoomID:         c.roomID,
+					SenderID:       c.ID,
+					TargetClientID: p.TargetClientID,
+				})
+			}),
+			inbound("toggle-owner", "Grants or revokes the ownership of the room", func(ctx context.Context, c *session, p ToggleOwnerPayload) error {
+				return c.usecases.ToggleOwner.Execute(ctx, usecase.ToggleOwnerCommand{
+					RoomID:         c.roomID,
+					SenderID:       c.ID,
+					TargetClientID: p.TargetClientID,
+				})
+			}),
+			inbound("update-story", "Renames the current story", func(ctx context.Context, c *session, p UpdateStoryPayload) error {
+				return c.usecases.UpdateStory.Execute(ctx, usecase.UpdateStoryCommand{
+					RoomID:   c.roomID,
+					SenderID: c.ID,
+					Story:    p.Story,
+				})
+			}),
+			inbound("new-voting", "Starts a new voting round", func(ctx context.Context, c *session, _ NoPayload) error {
+				return c.usecases.NewVoting.Execute(ctx, usecase.NewVotingCommand{
+					RoomID:   c.roomID,
+					SenderID: c.ID,
+				})
+			}),
+			inbound("vote-again", "Votes the current story again", func(ctx context.Context, c *session, _ NoPayload) error {
+				return c.usecases.VoteAgain.Execute(ctx, usecase.VoteAgainCommand{
+					RoomID:   c.roomID,
+					SenderID: c.ID,
+				})
+			}),
+			inbound("toggle-backlog-mode", "Switches the backlog mode on or off", func(ctx context.Context, c *session, _ NoPayload) error {
+				return c.usecases.ToggleBacklogMode.Execute(ctx, usecase.ToggleBacklogModeCommand{
+					RoomID:   c.roomID,
+					SenderID: c.ID,
+				})
+			}),
+			inbound("add-story", "Adds a story to the backlog", func(ctx context.Context, c *session, p AddStoryPayload) error {
+				return c.usecases.AddStory.Execute(ctx, usecase.AddStoryCommand{
+					RoomID:    c.roomID,
+					SenderID:  c.ID,
+					StoryName: p.Story,
+				})
+			}),
+			inbound("remove-story", "Removes a story from the backlog", func(ctx context.Context, c *session, p RemoveStoryPayload) error {
+				return c.usecases.RemoveStory.Execute(ctx, usecase.RemoveStoryCommand{
+					RoomID:     c.roomID,
+					SenderID:   c.ID,
+					StoryIndex: p.StoryIndex,
+				})
+			}),
+			inbound("advance-story", "Moves to the next story of the backlog", func(ctx context.Context, c *session, _ NoPayload) error {
+				return c.usecases.AdvanceStory.Execute(ctx, usecase.AdvanceStoryCommand{
+					RoomID:   c.roomID,
+					SenderID: c.ID,
+				})
+			}),
+			inbound("prev-story", "Moves to the previous story of the backlog", func(ctx context.Context, c *session, _ NoPayload) error {
+				return c.usecases.PrevStory.Execute(ctx, usecase.PrevStoryCommand{
+					RoomID:   c.roomID,
+					SenderID: c.ID,
+				})
+			}),
+			inbound("change-deck", "Changes the cards of the room", func(ctx context.Context, c *session, p ChangeDeckPayload) error {
+				return c.usecases.ChangeDeck.Execute(ctx, usecase.ChangeDeckCommand{
+					RoomID:    c.roomID,
+					SenderID:  c.ID,
+					DeckName:  p.Deck,
+					DeckCards: p.Cards,
+				})
+			}),
+			inbound("change-consensus-rule", "Changes how the votes reach a consensus", func(ctx context.Context, c *session, p ChangeConsensusRulePayload) error {
+				return c.usecases.ChangeConsensusRule.Execute(ctx, usecase.ChangeConsensusRuleCommand{
+					RoomID:   c.roomID,
+					SenderID: c.ID,
+					Rule:     p.Rule,
+				})
+			}),
+			inbound("start-timer", "Reveals the votes after the given number of seconds", func(ctx context.Context, c *session, p StartTimerPayload) error {
+				return c.usecases.StartVotingTimer.Execute(ctx, usecase.StartVotingTimerCommand{
+					RoomID:   c.roomID,
+					SenderID: c.ID,
+					Duration: time.Duration(p.Seconds) * time.Second,
+				})
+			}),
+			inbound("cancel-timer", "Cancels the voting timer", func(ctx context.Context, c *session, _ NoPayload) error {
+				return c.usecases.CancelVotingTimer.Execute(ctx, usecase.CancelVotingTimerCommand{
+					RoomID:   c.roomID,
+					SenderID: c.ID,
+				})
+			}),
+			inbound("export-report", "Sends the session report to the sender", func(ctx context.Context, c *session, p ExportReportPayload) error {
+				return c.usecases.ExportReport.Execute(ctx, usecase.ExportReportCommand{
+					RoomID:   c.roomID,
+					SenderID: c.ID,
+					Format:   p.Format,
+				})
+			}),
+			inbound("set-passcode", "Protects the room with a passcode", func(ctx context.Context, c *session, p SetPasscodePayload) error {
+				return c.usecases.SetPasscode.Execute(ctx, usecase.SetPasscodeCommand{
+					RoomID:   c.roomID,
+					SenderID: c.ID,
+					Passcode: p.Passcode,
+				})
+			}),
+			inbound("create-invite", "Creates an invite token, sent to the sender", func(ctx context.Context, c *session, p CreateInvitePayload) error {
+				return c.usecases.CreateInvite.Execute(ctx, usecase.CreateInviteCommand{
+					RoomID:   c.roomID,
+					SenderID: c.ID,
+					TTL:      time.Duration(p.TTLSeconds) * time.Second,
+				})
+			}),
+			inbound("revoke-invites", "Revokes every invite of the room", func(ctx context.Context, c *session, _ NoPayload) error {
+				return c.usecases.RevokeInvites.Execute(ctx, usecase.RevokeInvitesCommand{
+					RoomID:   c.roomID,
+					SenderID: c.ID,
+				})
+			}),
+			inbound("change-permission", "Changes the roles allowed to take an action", func(ctx context.Context, c *session, p ChangePermissionPayload) error {
+				return c.usecases.ChangePermission.Execute(ctx, usecase.ChangePermissionCommand{
+					RoomID:   c.roomID,
+					SenderID: c.ID,
+					Action:   p.Action,
+					Roles:    p.Roles,
+				})
+			}),
+			inbound("resync", "Asks for the full room state", func(ctx context.Context, c *session, _ NoPayload) error {
+				return c.resync(ctx)
+			}),
+		},
+		Outbound: []OutboundMessage{
+			{Type: dto.RoomStateType, Summary: "State of the room, sent on every change", Message: dto.RoomState{}},
+			{Type: dto.RoomPatchType, Summary: "Changes to the room state, sent instead of it to clients connected with patches=true", Message: dto.RoomPatch{}},
+			{Type: "update-client-id", Summary: "ID of the client, to reconnect with", Message: dto.UpdateClientID{}},
+			{Type: "kicked", Summary: "The client was removed from the room", Message: dto.KickNotification{}},
+			{Type: "session-report", Summary: "Report asked for with export-report", Message: dto.SessionReport{}},
+			{Type: "invite-created", Summary: "Invite asked for with create-invite", Message: dto.InviteCreated{}},
+			{Type: "room-expiring", Summary: "The room is going to be closed", Message: dto.RoomExpiring{}},
+			{Type: dto.RoomClosedType, Summary: "The room was closed, the connection is closed after it", Message: dto.RoomClosed{}},
+			{Type: "server-draining", Summary: "The server is shutting down, reconnect with the same client ID", Message: dto.ServerDraining{}},
+			{Type: "ack", Summary: "A message with a request ID was handled", Message: dto.Ack{}},
+			{Type: "error", Summary: "A message failed, sent to its sender only", Message: dto.Error{}},
+		},
+	}
+}
@@ -0,0 +1,105 @@
+package bus
+
+import (
+	"encoding/json"
+	"errors"
+	"planning-poker/internal/domain"
+	"testing"
+)
+
+func TestNegotiateProtocol(t *testing.T) {
+	tests := []struct {
+		name         string
+		query        string
+		subprotocols []string
+		want         string
+		wantErr      bool
+	}{
+		{name: "latest by default", want: LatestProtocol},
+		{name: "query", query: "v1", want: ProtocolV1},
+		{name: "unknown query version", query: "v0", wantErr: true},
+		{name: "query wins over subprotocols", query: "v1", subprotocols: []string{"planning-poker.v9"}, want: ProtocolV1},
+		{name: "first supported subprotocol", subprotocols: []string{"chat", "planning-poker.v9", "planning-poker.v1"}, want: ProtocolV1},
+		{name: "no supported subprotocol", subprotocols: []string{"chat", "planning-poker.v9"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NegotiateProtocol(tt.query, tt.subprotocols)
+			if tt.wantErr {
+				if !errors.Is(err, ErrUnsupportedProtocol) {
+					t.Fatalf("expected ErrUnsupportedProtocol, got %v", err)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("expected %q, got %q (%v)", tt.want, got, err)
+			}
+		})
+	}
+}
+
+func TestDecodePayload(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload string
+		out     any
+		wantErr bool
+	}{
+		{name: "valid", payload: `{"storyIndex": 0}`, out: &RemoveStoryPayload{}},
+		{name: "missing required field", payload: `{}`, out: &RemoveStoryPayload{}, wantErr: true},
+		{name: "wrong type", payload: `{"vote": 5}`, out: &VotePayload{}, wantErr: true},
+		{name: "null vote clears it", payload: `{"vote": null}`, out: &VotePayload{}},
+		{name: "unknown field", payload: `{"vote": "5", "weight": 2}`, out: &VotePayload{}, wantErr: true},
+		{name: "not an object", payload: `"5"`, out: &VotePayload{}, wantErr: true},
+		{name: "invalid value", payload: `{"storyIndex": -1}`, out: &RemoveStoryPayload{}, wantErr: true},
+		{name: "blank value", payload: `{"username": "  "}`, out: &UpdateNamePayload{}, wantErr: true},
+		{name: "optional fields", payload: `null`, out: &ExportReportPayload{}},
+		{name: "no payload", payload: `null`, out: &NoPayload{}},
+		{name: "empty payload", payload: `{}`, out: &NoPayload{}},
+		{name: "unexpected payload", payload: `{"force": true}`, out: &NoPayload{}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// payloads reach the handlers as generic JSON
+			var payload any
+			if err := json.Unmarshal([]byte(tt.payload), &payload); err != nil {
+				t.Fatalf("invalid test payload: %v", err)
+			}
+
+			err := decodePayload(payload, tt.out)
+			if tt.wantErr && !errors.Is(err, domain.ErrInvalidPayload) {
+				t.Errorf("expected ErrInvalidPayload, got %v", err)
+			}
+			if !tt.wantErr && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestDecodeMessage(t *testing.T) {
+	msg, err := decodeMessage([]byte(`{"type": "vote", "payload": {"vote": "5"}, "requestId": "req-1"}`))
+	if err != nil || msg.Type != "vote" || msg.RequestID != "req-1" {
+		t.Fatalf("unexpected message %+v (%v)", msg, err)
+	}
+
+	for _, data := range []string{`not json`, `{"payload": {}}`, `{"type": "vote", "extra": 1}`} {
+		if _, err := decodeMessage([]byte(data)); !errors.Is(err, domain.ErrInvalidMessage) {
+			t.Errorf("expected ErrInvalidMessage for %s, got %v", data, err)
+		}
+	}
+}
+
+func TestProtocols_MessageTypesAreUnique(t *testing.T) {
+	for version, protocol := range protocols {
+		seen := map[string]bool{}
+		for _, m := range protocol.Inbound {
+			if seen[m.Type] {
+				t.Errorf("protocol %s handles %s twice", version, m.Type)
+			}
+			seen[m.Type] = true
+		}
+	}
+}
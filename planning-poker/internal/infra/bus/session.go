@@ -0,0 +1,312 @@
+package bus
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"planning-poker/internal/application/planningpoker/usecase"
+	"planning-poker/internal/application/planningpoker/usecase/dto"
+	"planning-poker/internal/application/ratelimit"
+	"planning-poker/internal/domain"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/bruno303/go-toolkit/pkg/log"
+)
+
+// session is the part of a bus that does not depend on its transport: it
+// runs the use cases of the messages of a client, replies to the client and
+// keeps track of the room state the client has, so that room states and
+// patches reach it in the form it asked for.
+type session struct {
+	ID          string
+	roomID      string
+	usecases    usecase.UseCasesFacade
+	protocol    Protocol
+	rateLimit   RateLimit
+	logger      log.Logger
+	patches     bool
+	skipCleanup atomic.Bool
+	roomClosed  atomic.Bool
+	writeMu     sync.Mutex     // serializes the writes of the transport
+	lastState   *dto.RoomState // last room state sent, guarded by writeMu
+	resyncing   atomic.Bool    // a resync asked by the session is on its way
+	// send is the Send of the bus
+	send func(ctx context.Context, message any) error
+}
+
+func newSession(id, roomID string, usecases usecase.UseCasesFacade, rateLimit RateLimit, logger log.Logger) session {
+	return session{
+		ID:        id,
+		roomID:    roomID,
+		usecases:  usecases,
+		protocol:  protocols[LatestProtocol],
+		rateLimit: rateLimit,
+		logger:    logger,
+	}
+}
+
+// drainAll drains the buses at once, giving up when the context is done.
+func drainAll[B interface{ Drain(context.Context) }](ctx context.Context, buses []B, kind string) error {
+	var wg sync.WaitGroup
+	for _, bus := range buses {
+		wg.Go(func() { bus.Drain(ctx) })
+	}
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("draining %d %s: %w", len(buses), kind, ctx.Err())
+	}
+}
+
+func (c *session) RoomID() string {
+	return c.roomID
+}
+
+func (c *session) Detach() {
+	c.skipCleanup.Store(true)
+}
+
+// isRoomClosed also recognizes notifications that went through the pub/sub
+// of the hub, which arrive as generic JSON.
+func isRoomClosed(message any) bool {
+	switch m := message.(type) {
+	case dto.RoomClosed:
+		return true
+	case map[string]any:
+		return m["type"] == dto.RoomClosedType
+	default:
+		return false
+	}
+}
+
+// prepare turns the room states and patches published by the hub into what
+// the client asked for: patches against the last state sent to it, or whole
+// states for clients without patches. The first state after connecting or a
+// resync is sent whole. Updates older than the last state sent arrived late
+// and are dropped; a patch that skips versions is dropped as well and the
+// state is resynced. Callers hold writeMu.
+func (c *session) prepare(ctx context.Context, message any) (any, bool) {
+	if state, ok := decode[dto.RoomState](message, dto.RoomStateType); ok {
+		return c.prepareState(ctx, state)
+	}
+	if patch, ok := decode[dto.RoomPatch](message, dto.RoomPatchType); ok {
+		return c.preparePatch(ctx, patch)
+	}
+	return message, true
+}
+
+func (c *session) prepareState(ctx context.Context, state dto.RoomState) (any, bool) {
+	previous := c.lastState
+	if previous != nil && state.Version < previous.Version {
+		c.logger.Debug(ctx, "Dropping room state %d older than %d for client %v", state.Version, previous.Version, c.ID)
+		return nil, false
+	}
+	c.lastState = &state
+	c.resyncing.Store(false)
+	if previous == nil || !c.patches {
+		return state, true
+	}
+
+	return dto.NewRoomPatchCommand(*previous, state)
+}
+
+func (c *session) preparePatch(ctx context.Context, patch dto.RoomPatch) (any, bool) {
+	previous := c.lastState
+	if previous == nil {
+		c.logger.Debug(ctx, "Dropping room patch %d for client %v waiting for a room state", patch.Version, c.ID)
+		c.requestResync(ctx)
+		return nil, false
+	}
+	if patch.Version <= previous.Version {
+		c.logger.Debug(ctx, "Dropping room patch %d older than %d for client %v", patch.Version, previous.Version, c.ID)
+		return nil, false
+	}
+	if patch.BaseVersion != previous.Version {
+		c.logger.Warn(ctx, "Room patch %d does not follow %d for client %v, resyncing", patch.Version, previous.Version, c.ID)
+		c.lastState = nil
+		c.requestResync(ctx)
+		return nil, false
+	}
+
+	state, err := patch.Apply(*previous)
+	if err != nil {
+		c.logger.Warn(ctx, "Failed to apply room patch %d for client %v, resyncing: %v", patch.Version, c.ID, err)
+		c.lastState = nil
+		c.requestResync(ctx)
+		return nil, false
+	}
+	c.lastState = &state
+	if !c.patches {
+		return state, true
+	}
+	return patch, true
+}
+
+// requestResync asks for the room state on behalf of the client, once until
+// it arrives. It does not wait for the state, which is sent while holding
+// writeMu.
+func (c *session) requestResync(ctx context.Context) {
+	if !c.resyncing.CompareAndSwap(false, true) {
+		return
+	}
+	go func() {
+		ctx := context.WithoutCancel(ctx)
+		err := c.usecases.Resync.Execute(ctx, usecase.ResyncCommand{
+			RoomID:   c.roomID,
+			SenderID: c.ID,
+		})
+		if err != nil {
+			c.resyncing.Store(false)
+			c.logger.Warn(ctx, "Failed to resync client %v: %v", c.ID, err)
+		}
+	}()
+}
+
+// decode also decodes messages that went through the pub/sub of the hub,
+// which arrive as generic JSON.
+func decode[T any](message any, messageType string) (T, bool) {
+	var value T
+	switch m := message.(type) {
+	case T:
+		return m, true
+	case map[string]any:
+		if m["type"] != messageType {
+			return value, false
+		}
+		data, err := json.Marshal(m)
+		if err != nil {
+			return value, false
+		}
+		if err := json.Unmarshal(data, &value); err != nil {
+			return value, false
+		}
+		return value, true
+	default:
+		return value, false
+	}
+}
+
+// decodeMessage rejects messages with fields the protocol does not know. The
+// payload is decoded by the handler of the message type.
+func decodeMessage(data []byte) (WebSocketMessage, error) {
+	var msg WebSocketMessage
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&msg); err != nil {
+		return WebSocketMessage{}, fmt.Errorf("%w: %w", domain.ErrInvalidMessage, err)
+	}
+	if msg.Type == "" {
+		return msg, fmt.Errorf("%w: type is required", domain.ErrInvalidMessage)
+	}
+	return msg, nil
+}
+
+// dispatch decodes a message received from the client and processes it.
+func (c *session) dispatch(ctx context.Context, data []byte) {
+	msg, err := decodeMessage(data)
+	if err != nil {
+		c.logger.Warn(ctx, "Malformed message from client %v: %v", c.ID, err)
+		c.reply(ctx, dto.NewErrorCommand(msg.RequestID, err))
+		return
+	}
+	c.logger.Info(ctx, "Message received from client %v: %v", c.ID, msg)
+
+	c.process(ctx, msg)
+}
+
+// process runs the use case of the message and replies to the sender alone:
+// errors are always reported, successes only when the message carries a
+// request ID to acknowledge.
+func (c *session) process(ctx context.Context, msg WebSocketMessage) {
+	c.logger.Debug(ctx, "Processing message for event type '%v' with payload: %v", msg.Type, msg)
+	if err := c.throttle(ctx); err != nil {
+		c.logger.Warn(ctx, "Message from client %v throttled: %v", c.ID, err)
+		c.reply(ctx, dto.NewErrorCommand(msg.RequestID, err))
+		return
+	}
+
+	index := slices.IndexFunc(c.protocol.Inbound, func(m InboundMessage) bool { return m.Type == msg.Type })
+	if index < 0 {
+		err := fmt.Errorf("%w '%v'", domain.ErrUnknownMessageType, msg.Type)
+		c.logger.Error(ctx, fmt.Sprintf("Unknown event type '%v' for client %v", msg.Type, c.ID), err)
+		c.reply(ctx, dto.NewErrorCommand(msg.RequestID, err))
+		return
+	}
+
+	err := c.protocol.Inbound[index].handle(ctx, c, msg.Payload)
+	if err != nil {
+		c.logger.Error(ctx, fmt.Sprintf("Error handling event for client %v", c.ID), err)
+		c.reply(ctx, dto.NewErrorCommand(msg.RequestID, err))
+		return
+	}
+	if msg.RequestID != "" {
+		c.reply(ctx, dto.NewAckCommand(msg.RequestID))
+	}
+}
+
+// throttle takes a token from the bucket of the client, then from the one of
+// the room, so that a flooding client does not use up the room's. Messages
+// are let through when the limiter fails.
+func (c *session) throttle(ctx context.Context) error {
+	rateLimit := c.rateLimit
+	if rateLimit.Limiter == nil {
+		return nil
+	}
+
+	buckets := []struct {
+		key   string
+		limit ratelimit.Limit
+	}{
+		{key: "client:" + c.roomID + ":" + c.ID, limit: rateLimit.Client},
+		{key: "room:" + c.roomID, limit: rateLimit.Room},
+	}
+	for _, bucket := range buckets {
+		decision, err := rateLimit.Limiter.Allow(ctx, bucket.key, bucket.limit)
+		if err != nil {
+			c.logger.Warn(ctx, "Failed to rate limit client %v: %v", c.ID, err)
+			return nil
+		}
+		if !decision.Allowed {
+			rateLimit.Metric.IncrementThrottled(ctx)
+			return fmt.Errorf("%w: retry in %v", domain.ErrRateLimited, decision.RetryAfter.Round(time.Millisecond))
+		}
+	}
+	return nil
+}
+
+func (c *session) reply(ctx context.Context, message any) {
+	if err := c.send(ctx, message); err != nil {
+		c.logger.Warn(ctx, "Failed to reply to client %v: %v", c.ID, err)
+	}
+}
+
+// resync forgets the last state sent so that the reply goes out whole.
+func (c *session) resync(ctx context.Context) error {
+	c.writeMu.Lock()
+	c.lastState = nil
+	c.writeMu.Unlock()
+
+	return c.usecases.Resync.Execute(ctx, usecase.ResyncCommand{
+		RoomID:   c.roomID,
+		SenderID: c.ID,
+	})
+}
+
+func (c *session) leaveRoom(ctx context.Context) error {
+	return c.usecases.LeaveRoom.Execute(ctx, usecase.LeaveRoomCommand{
+		RoomID:     c.roomID,
+		SenderID:   c.ID,
+		RoomClosed: c.roomClosed.Load(),
+	})
+}
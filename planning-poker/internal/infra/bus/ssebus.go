@@ -0,0 +1,294 @@
+package bus
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"planning-poker/internal/application/planningpoker/usecase"
+	"planning-poker/internal/application/planningpoker/usecase/dto"
+	"planning-poker/internal/domain"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/bruno303/go-toolkit/pkg/log"
+	"github.com/bruno303/go-toolkit/pkg/trace"
+)
+
+// SSESessionEvent names the first event of a stream, carrying the ID of the
+// session to post messages to. The messages of the protocol are sent as
+// unnamed events.
+const SSESessionEvent = "session"
+
+var (
+	ErrSessionNotFound = errors.New("event stream session not found")
+	ErrMessageTooLarge = errors.New("message too large")
+)
+
+type (
+	// SSEBusFactory creates the buses of clients that cannot open websockets.
+	// They take the same settings: the write timeout, the ping interval for
+	// the keepalives, the size limit of the messages and the rate limits.
+	SSEBusFactory struct {
+		usecases usecase.UseCasesFacade
+		cfg      WebSocketConfig
+		mu       sync.Mutex
+		buses    map[string]*SSEBus // open buses by session ID, drained on shutdown
+	}
+	SSEBusFactoryInput struct {
+		ClientID string
+		RoomID   string
+		Writer   http.ResponseWriter
+		// send room-patch messages instead of the full room-state
+		Patches bool
+		// Protocol is the negotiated protocol version, the latest when empty
+		Protocol string
+	}
+
+	// SSEBus sends the messages of the protocol to the client as
+	// Server-Sent Events on a long-lived response. The client sends its own
+	// with POST requests naming the session of the stream.
+	SSEBus struct {
+		session
+		// SessionID is the secret the client posts its messages with
+		SessionID string
+		w         http.ResponseWriter
+		cfg       WebSocketConfig
+		started   bool // the response headers were written, guarded by writeMu
+		closed    atomic.Bool
+		closeOnce sync.Once
+		stopOnce  sync.Once
+		done      chan struct{}
+		onClose   func()
+	}
+
+	sessionPayload struct {
+		SessionID string `json:"sessionId"`
+	}
+)
+
+var _ domain.Bus = (*SSEBus)(nil)
+
+func NewSSEBusFactory(usecases usecase.UseCasesFacade, cfg WebSocketConfig) *SSEBusFactory {
+	return &SSEBusFactory{
+		usecases: usecases,
+		cfg:      cfg,
+		buses:    make(map[string]*SSEBus),
+	}
+}
+
+func (f *SSEBusFactory) NewBus(input SSEBusFactoryInput) *SSEBus {
+	bus := NewSSEBus(input.ClientID, input.RoomID, input.Writer, f.usecases, f.cfg)
+	bus.patches = input.Patches
+	if protocol, ok := LookupProtocol(input.Protocol); ok {
+		bus.protocol = protocol
+	}
+
+	f.mu.Lock()
+	f.buses[bus.SessionID] = bus
+	f.mu.Unlock()
+	bus.onClose = func() {
+		f.mu.Lock()
+		delete(f.buses, bus.SessionID)
+		f.mu.Unlock()
+	}
+
+	return bus
+}
+
+// Dispatch hands a message posted by a client to the bus of its session,
+// which replies on the stream. Sessions are only known to the instance
+// serving their stream.
+func (f *SSEBusFactory) Dispatch(ctx context.Context, roomID, sessionID string, body io.Reader) error {
+	f.mu.Lock()
+	bus, ok := f.buses[sessionID]
+	f.mu.Unlock()
+	if !ok || bus.roomID != roomID {
+		return ErrSessionNotFound
+	}
+
+	if f.cfg.MaxMessageSize > 0 {
+		body = io.LimitReader(body, f.cfg.MaxMessageSize+1)
+	}
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return fmt.Errorf("failed to read message: %w", err)
+	}
+	if f.cfg.MaxMessageSize > 0 && int64(len(data)) > f.cfg.MaxMessageSize {
+		return fmt.Errorf("%w, the limit is %d bytes", ErrMessageTooLarge, f.cfg.MaxMessageSize)
+	}
+
+	bus.dispatch(ctx, data)
+	return nil
+}
+
+// Drain tells every open stream the server is shutting down and ends them
+// without leaving their rooms. It gives up when the context is done.
+func (f *SSEBusFactory) Drain(ctx context.Context) error {
+	f.mu.Lock()
+	buses := make([]*SSEBus, 0, len(f.buses))
+	for _, bus := range f.buses {
+		buses = append(buses, bus)
+	}
+	f.mu.Unlock()
+
+	return drainAll(ctx, buses, "event streams")
+}
+
+func NewSSEBus(
+	id string,
+	roomID string,
+	w http.ResponseWriter,
+	usecases usecase.UseCasesFacade,
+	cfg WebSocketConfig,
+) *SSEBus {
+	bus := &SSEBus{
+		session:   newSession(id, roomID, usecases, cfg.RateLimit, log.NewLogger("sse.client")),
+		SessionID: rand.Text(),
+		w:         w,
+		cfg:       cfg,
+		done:      make(chan struct{}),
+	}
+	bus.send = bus.Send
+	return bus
+}
+
+// Started tells whether the stream began, after which errors can no longer
+// be answered with a status code.
+func (c *SSEBus) Started() bool {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	return c.started
+}
+
+// Close ends the stream. The response is finished when Listen returns.
+func (c *SSEBus) Close() error {
+	var err error
+	c.closeOnce.Do(func() {
+		// nothing may be written once the handler serving the stream returned
+		c.writeMu.Lock()
+		c.closed.Store(true)
+		c.writeMu.Unlock()
+		c.stop()
+		if !c.skipCleanup.Load() {
+			err = c.leaveRoom(context.Background())
+		}
+		if c.onClose != nil {
+			c.onClose()
+		}
+	})
+	return err
+}
+
+func (c *SSEBus) stop() {
+	c.stopOnce.Do(func() { close(c.done) })
+}
+
+// Drain tells the client the server is shutting down and ends the stream,
+// keeping the client in its room until it reconnects.
+func (c *SSEBus) Drain(ctx context.Context) {
+	c.Detach()
+	if err := c.Send(ctx, dto.NewServerDrainingNotification()); err != nil {
+		c.logger.Warn(ctx, "Failed to tell client %v the server is draining: %v", c.ID, err)
+	}
+	_ = c.Close()
+}
+
+func (c *SSEBus) Send(ctx context.Context, message any) error {
+	_, err := trace.Trace(ctx, trace.NameConfig("SSEBus", "send"), func(ctx context.Context) (any, error) {
+		c.writeMu.Lock()
+		defer c.writeMu.Unlock()
+		if c.closed.Load() {
+			c.logger.Warn(ctx, "Attempted to send message to closed stream for client %v", c.ID)
+			return nil, errors.New("connection closed")
+		}
+		c.logger.Debug(ctx, "Sending message to client: %v", message)
+		var ok bool
+		if message, ok = c.prepare(ctx, message); !ok {
+			return nil, nil
+		}
+		data, err := json.Marshal(message)
+		if err != nil {
+			return nil, err
+		}
+		if err := c.write("data: " + string(data) + "\n\n"); err != nil {
+			c.logger.Error(ctx, fmt.Sprintf("Write error for client %v: %v", c.ID, err), err)
+			return nil, err
+		}
+		// the stream ends once the client was told its room was closed, the
+		// bus leaves the room, which is already gone
+		if isRoomClosed(message) {
+			c.roomClosed.Store(true)
+			c.stop()
+		}
+		return nil, nil
+	})
+	return err
+}
+
+// write starts the stream with the session event on the first write.
+// Callers hold writeMu.
+func (c *SSEBus) write(frame string) error {
+	if !c.started {
+		header := c.w.Header()
+		header.Set("Content-Type", "text/event-stream")
+		header.Set("Cache-Control", "no-cache")
+		// proxies must not buffer the events
+		header.Set("X-Accel-Buffering", "no")
+		c.w.WriteHeader(http.StatusOK)
+		c.started = true
+
+		data, err := json.Marshal(sessionPayload{SessionID: c.SessionID})
+		if err != nil {
+			return err
+		}
+		frame = "event: " + SSESessionEvent + "\ndata: " + string(data) + "\n\n" + frame
+	}
+
+	controller := http.NewResponseController(c.w)
+	if c.cfg.WriteTimeout > 0 {
+		_ = controller.SetWriteDeadline(time.Now().Add(c.cfg.WriteTimeout))
+	}
+	if _, err := io.WriteString(c.w, frame); err != nil {
+		return err
+	}
+	return controller.Flush()
+}
+
+// Listen keeps the stream open until the bus is closed or the client goes
+// away, writing a keepalive comment every ping interval. A failed keepalive
+// means the client is gone.
+func (c *SSEBus) Listen(ctx context.Context) {
+	defer func() { _ = c.Close() }()
+
+	ticker := time.NewTicker(c.cfg.PingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := c.keepalive(); err != nil {
+				c.logger.Warn(ctx, "Keepalive to client %v failed, ending the stream: %v", c.ID, err)
+				return
+			}
+		case <-c.done:
+			return
+		case <-ctx.Done():
+			c.logger.Warn(ctx, "Stream of client %v closed by client or network", c.ID)
+			return
+		}
+	}
+}
+
+func (c *SSEBus) keepalive() error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	if c.closed.Load() {
+		return nil
+	}
+	return c.write(": keepalive\n\n")
+}
@@ -0,0 +1,216 @@
+package bus
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"planning-poker/internal/application/planningpoker/usecase"
+	"planning-poker/internal/application/planningpoker/usecase/dto"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+)
+
+type sseEvent struct {
+	name    string
+	data    map[string]any
+	comment string
+}
+
+// openSSEStream serves the stream of a bus of the factory and returns a
+// reader of its events, which ends when the stream ends.
+func openSSEStream(t *testing.T, factory *SSEBusFactory) (*SSEBus, <-chan sseEvent) {
+	t.Helper()
+
+	busCh := make(chan *SSEBus, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		bus := factory.NewBus(SSEBusFactoryInput{ClientID: "test-client", RoomID: "test-room", Writer: w})
+		busCh <- bus
+		_ = bus.Send(r.Context(), dto.NewUpdateClientIDCommand("test-client"))
+		bus.Listen(r.Context())
+	}))
+	t.Cleanup(srv.Close)
+
+	resp, err := http.Get(srv.URL)
+	if err != nil {
+		t.Fatalf("failed to open stream: %v", err)
+	}
+	t.Cleanup(func() { _ = resp.Body.Close() })
+	if contentType := resp.Header.Get("Content-Type"); contentType != "text/event-stream" {
+		t.Fatalf("Content-Type = %q, want text/event-stream", contentType)
+	}
+
+	events := make(chan sseEvent)
+	go func() {
+		defer close(events)
+		scanner := bufio.NewScanner(resp.Body)
+		var event sseEvent
+		for scanner.Scan() {
+			line := scanner.Text()
+			switch {
+			case line == "":
+				events <- event
+				event = sseEvent{}
+			case strings.HasPrefix(line, ":"):
+				event.comment = strings.TrimSpace(strings.TrimPrefix(line, ":"))
+			case strings.HasPrefix(line, "event: "):
+				event.name = strings.TrimPrefix(line, "event: ")
+			case strings.HasPrefix(line, "data: "):
+				_ = json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &event.data)
+			}
+		}
+	}()
+
+	return <-busCh, events
+}
+
+func readSSEEvent(t *testing.T, events <-chan sseEvent) sseEvent {
+	t.Helper()
+	select {
+	case event, ok := <-events:
+		if !ok {
+			t.Fatal("expected an event, the stream ended")
+		}
+		return event
+	case <-time.After(time.Second):
+		t.Fatal("expected an event")
+		return sseEvent{}
+	}
+}
+
+func expectSSEStreamEnd(t *testing.T, events <-chan sseEvent) {
+	t.Helper()
+	select {
+	case event, ok := <-events:
+		if ok {
+			t.Fatalf("expected the stream to end, got %+v", event)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("expected the stream to end")
+	}
+}
+
+func TestSSEBus_Stream(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+
+	mockReveal := usecase.NewMockUseCase[usecase.RevealCommand](ctrl)
+	mockReveal.EXPECT().
+		Execute(gomock.Any(), usecase.RevealCommand{RoomID: "test-room", SenderID: "test-client"}).
+		Return(nil)
+	left := make(chan struct{})
+	mockLeaveRoom := usecase.NewMockUseCase[usecase.LeaveRoomCommand](ctrl)
+	mockLeaveRoom.EXPECT().
+		Execute(gomock.Any(), usecase.LeaveRoomCommand{RoomID: "test-room", SenderID: "test-client", RoomClosed: true}).
+		DoAndReturn(func(context.Context, usecase.LeaveRoomCommand) error {
+			close(left)
+			return nil
+		})
+
+	factory := NewSSEBusFactory(
+		usecase.UseCasesFacade{Reveal: mockReveal, LeaveRoom: mockLeaveRoom},
+		WebSocketConfig{WriteTimeout: time.Second, PingInterval: time.Minute, MaxMessageSize: 128},
+	)
+	bus, events := openSSEStream(t, factory)
+
+	event := readSSEEvent(t, events)
+	if event.name != SSESessionEvent || event.data["sessionId"] != bus.SessionID {
+		t.Fatalf("expected the session event first, got %+v", event)
+	}
+	if event := readSSEEvent(t, events); event.name != "" || event.data["type"] != "update-client-id" {
+		t.Fatalf("expected the client ID, got %+v", event)
+	}
+
+	// replies to posted messages arrive on the stream
+	err := factory.Dispatch(ctx, "test-room", bus.SessionID, strings.NewReader(`{"type": "reveal-votes", "requestId": "req-1"}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if event := readSSEEvent(t, events); event.data["type"] != "ack" || event.data["requestId"] != "req-1" {
+		t.Fatalf("expected an ack, got %+v", event)
+	}
+
+	err = factory.Dispatch(ctx, "test-room", bus.SessionID, strings.NewReader(`{"type": "dance"}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if event := readSSEEvent(t, events); event.data["type"] != "error" || event.data["code"] != "UNKNOWN_MESSAGE_TYPE" {
+		t.Fatalf("expected an unknown message type error, got %+v", event)
+	}
+
+	oversized := `{"type": "update-story", "payload": {"story": "` + strings.Repeat("a", 200) + `"}}`
+	if err := factory.Dispatch(ctx, "test-room", bus.SessionID, strings.NewReader(oversized)); !errors.Is(err, ErrMessageTooLarge) {
+		t.Errorf("expected ErrMessageTooLarge, got %v", err)
+	}
+	if err := factory.Dispatch(ctx, "other-room", bus.SessionID, strings.NewReader(`{"type": "reset"}`)); !errors.Is(err, ErrSessionNotFound) {
+		t.Errorf("expected ErrSessionNotFound for another room, got %v", err)
+	}
+
+	// notifications published through the hub arrive as generic JSON
+	if err := bus.Send(ctx, map[string]any{"type": dto.RoomClosedType, "reason": "idle"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if event := readSSEEvent(t, events); event.data["type"] != dto.RoomClosedType {
+		t.Fatalf("expected the room-closed notification, got %+v", event)
+	}
+	expectSSEStreamEnd(t, events)
+
+	select {
+	case <-left:
+	case <-time.After(2 * time.Second):
+		t.Fatal("expected the bus to leave the closed room")
+	}
+	if err := factory.Dispatch(ctx, "test-room", bus.SessionID, strings.NewReader(`{"type": "reset"}`)); !errors.Is(err, ErrSessionNotFound) {
+		t.Errorf("expected the session of a closed stream to be forgotten, got %v", err)
+	}
+}
+
+func TestSSEBus_Listen_SendsKeepalives(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLeaveRoom := usecase.NewMockUseCase[usecase.LeaveRoomCommand](ctrl)
+	mockLeaveRoom.EXPECT().Execute(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	factory := NewSSEBusFactory(
+		usecase.UseCasesFacade{LeaveRoom: mockLeaveRoom},
+		WebSocketConfig{WriteTimeout: time.Second, PingInterval: 10 * time.Millisecond},
+	)
+	_, events := openSSEStream(t, factory)
+
+	readSSEEvent(t, events) // session
+	readSSEEvent(t, events) // update-client-id
+	if event := readSSEEvent(t, events); event.comment != "keepalive" {
+		t.Fatalf("expected a keepalive, got %+v", event)
+	}
+}
+
+func TestSSEBusFactory_Drain_KeepsClientsInRooms(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLeaveRoom := usecase.NewMockUseCase[usecase.LeaveRoomCommand](ctrl)
+	mockLeaveRoom.EXPECT().Execute(gomock.Any(), gomock.Any()).Times(0)
+
+	factory := NewSSEBusFactory(
+		usecase.UseCasesFacade{LeaveRoom: mockLeaveRoom},
+		WebSocketConfig{WriteTimeout: time.Second, PingInterval: time.Minute},
+	)
+	_, events := openSSEStream(t, factory)
+	readSSEEvent(t, events) // session
+	readSSEEvent(t, events) // update-client-id
+
+	if err := factory.Drain(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if event := readSSEEvent(t, events); event.data["type"] != "server-draining" {
+		t.Fatalf("expected the server-draining notification, got %+v", event)
+	}
+	expectSSEStreamEnd(t, events)
+}
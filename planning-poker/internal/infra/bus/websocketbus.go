@@ -2,11 +2,13 @@ package bus
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"net"
+	"planning-poker/internal/application/planningpoker/metric"
 	"planning-poker/internal/application/planningpoker/usecase"
+	"planning-poker/internal/application/planningpoker/usecase/dto"
+	"planning-poker/internal/application/ratelimit"
 	"planning-poker/internal/domain"
 	"sync"
 	"sync/atomic"
@@ -15,7 +17,6 @@ import (
 	"github.com/bruno303/go-toolkit/pkg/log"
 	"github.com/bruno303/go-toolkit/pkg/trace"
 	"github.com/gorilla/websocket"
-	"github.com/samber/lo"
 )
 
 type (
@@ -23,59 +24,55 @@ type (
 		hub          domain.Hub
 		usecases     usecase.UseCasesFacade
 		websocketCfg WebSocketConfig
+		mu           sync.Mutex
+		buses        map[*WebsocketBus]struct{} // open buses, drained on shutdown
 	}
 	WebSocketBusFactoryInput struct {
 		ClientID string
 		RoomID   string
 		Socket   *websocket.Conn
+		// send room-patch messages instead of the full room-state
+		Patches bool
+		// Protocol is the negotiated protocol version, the latest when empty
+		Protocol string
 	}
 	WebSocketMessage struct {
 		Type    string `json:"type"`
 		Payload any    `json:"payload"`
+		// RequestID is echoed in the ack or error reply to the message
+		RequestID string `json:"requestId,omitempty"`
 	}
-	UpdateNamePayload struct {
-		Username string `json:"username"`
-	}
-	ToggleSpectatorPayload struct {
-		TargetClientID string `json:"targetClientId"`
-	}
-	ToggleOwnerPayload struct {
-		TargetClientID string `json:"targetClientId"`
-	}
-	UpdateStoryPayload struct {
-		Story string `json:"story"`
-	}
-	VotePayload struct {
-		Vote string `json:"vote"`
-	}
-	AddStoryPayload struct {
-		Story string `json:"story"`
-	}
-	RemoveStoryPayload struct {
-		StoryIndex int `json:"storyIndex"`
-	}
-	useCaseCall func(context.Context, WebSocketMessage) error
 
 	WebsocketBus struct {
-		ID          string
-		conn        *websocket.Conn
-		hub         domain.Hub
-		logger      log.Logger
-		cfg         WebSocketConfig
-		calls       map[string]useCaseCall
-		usecases    usecase.UseCasesFacade
-		roomID      string
-		closed      atomic.Bool
-		closeOnce   sync.Once
-		writeMu     sync.Mutex // Protects writes to conn (required by gorilla/websocket)
-		done        chan struct{}
-		skipCleanup atomic.Bool
+		// the writeMu of the session protects writes to conn (required by
+		// gorilla/websocket)
+		session
+		conn      *websocket.Conn
+		hub       domain.Hub
+		cfg       WebSocketConfig
+		closed    atomic.Bool
+		closeOnce sync.Once
+		done      chan struct{}
+		onClose   func()
 	}
 
 	WebSocketConfig struct {
 		WriteTimeout time.Duration
 		ReadTimeout  time.Duration
 		PingInterval time.Duration
+		// MaxMessageSize closes the connection of clients sending larger
+		// messages, no limit when zero
+		MaxMessageSize int64
+		RateLimit      RateLimit
+	}
+
+	// RateLimit throttles the messages of each client and of each room. It is
+	// disabled without a limiter.
+	RateLimit struct {
+		Limiter ratelimit.Limiter
+		Client  ratelimit.Limit
+		Room    ratelimit.Limit
+		Metric  metric.PlanningPokerMetric
 	}
 )
 
@@ -86,11 +83,12 @@ func NewWebSocketBusFactory(hub domain.Hub, usecases usecase.UseCasesFacade, web
 		hub:          hub,
 		usecases:     usecases,
 		websocketCfg: websocketCfg,
+		buses:        make(map[*WebsocketBus]struct{}),
 	}
 }
 
 func (f *WebSocketBusFactory) NewBus(input WebSocketBusFactoryInput) domain.Bus {
-	return NewWebsocketBus(
+	bus := NewWebsocketBus(
 		input.ClientID,
 		input.RoomID,
 		input.Socket,
@@ -98,6 +96,35 @@ func (f *WebSocketBusFactory) NewBus(input WebSocketBusFactoryInput) domain.Bus
 		f.usecases,
 		f.websocketCfg,
 	)
+	bus.patches = input.Patches
+	if protocol, ok := LookupProtocol(input.Protocol); ok {
+		bus.protocol = protocol
+	}
+
+	f.mu.Lock()
+	f.buses[bus] = struct{}{}
+	f.mu.Unlock()
+	bus.onClose = func() {
+		f.mu.Lock()
+		delete(f.buses, bus)
+		f.mu.Unlock()
+	}
+
+	return bus
+}
+
+// Drain tells every open bus the server is shutting down and closes them
+// without leaving their rooms, so clients can reconnect to another instance
+// and carry on. It gives up when the context is done.
+func (f *WebSocketBusFactory) Drain(ctx context.Context) error {
+	f.mu.Lock()
+	buses := make([]*WebsocketBus, 0, len(f.buses))
+	for bus := range f.buses {
+		buses = append(buses, bus)
+	}
+	f.mu.Unlock()
+
+	return drainAll(ctx, buses, "websocket connections")
 }
 
 func NewWebsocketBus(
@@ -108,25 +135,15 @@ func NewWebsocketBus(
 	usecases usecase.UseCasesFacade,
 	websocketCfg WebSocketConfig,
 ) *WebsocketBus {
-	return &WebsocketBus{
-		ID:       id,
-		conn:     socket,
-		hub:      hub,
-		cfg:      websocketCfg,
-		logger:   log.NewLogger("websocket.client"),
-		usecases: usecases,
-		calls:    mapUsecases(usecases, id, roomID),
-		roomID:   roomID,
-		done:     make(chan struct{}),
+	bus := &WebsocketBus{
+		session: newSession(id, roomID, usecases, websocketCfg.RateLimit, log.NewLogger("websocket.client")),
+		conn:    socket,
+		hub:     hub,
+		cfg:     websocketCfg,
+		done:    make(chan struct{}),
 	}
-}
-
-func (c *WebsocketBus) RoomID() string {
-	return c.roomID
-}
-
-func (c *WebsocketBus) Detach() {
-	c.skipCleanup.Store(true)
+	bus.send = bus.Send
+	return bus
 }
 
 func (c *WebsocketBus) Close() error {
@@ -139,10 +156,30 @@ func (c *WebsocketBus) Close() error {
 		}
 		err2 := c.conn.Close()
 		err = errors.Join(err, err2)
+		if c.onClose != nil {
+			c.onClose()
+		}
 	})
 	return err
 }
 
+// Drain tells the client the server is shutting down and closes the
+// connection, keeping the client in its room until it reconnects.
+func (c *WebsocketBus) Drain(ctx context.Context) {
+	c.Detach()
+	if err := c.Send(ctx, dto.NewServerDrainingNotification()); err != nil {
+		c.logger.Warn(ctx, "Failed to tell client %v the server is draining: %v", c.ID, err)
+	} else {
+		c.writeMu.Lock()
+		closeMessage := websocket.FormatCloseMessage(websocket.CloseServiceRestart, "server draining")
+		if err := c.conn.WriteControl(websocket.CloseMessage, closeMessage, time.Now().Add(c.cfg.WriteTimeout)); err != nil {
+			c.logger.Warn(ctx, "Failed to close connection of client %v: %v", c.ID, err)
+		}
+		c.writeMu.Unlock()
+	}
+	_ = c.Close()
+}
+
 func (c *WebsocketBus) Send(ctx context.Context, message any) error {
 	_, err := trace.Trace(ctx, trace.NameConfig("WebsocketBus", "send"), func(ctx context.Context) (any, error) {
 		if c.closed.Load() {
@@ -152,71 +189,88 @@ func (c *WebsocketBus) Send(ctx context.Context, message any) error {
 		c.logger.Debug(ctx, "Sending message to client: %v", message)
 		c.writeMu.Lock()
 		defer c.writeMu.Unlock()
+		var ok bool
+		if message, ok = c.prepare(ctx, message); !ok {
+			return nil, nil
+		}
 		_ = c.conn.SetWriteDeadline(time.Now().Add(c.cfg.WriteTimeout))
 		err := c.conn.WriteJSON(message)
 		if err != nil {
 			c.logger.Error(ctx, fmt.Sprintf("WriteJSON error for client %v: %v", c.ID, err), err)
+			return nil, err
 		}
-		return nil, err
+		if isRoomClosed(message) {
+			c.endSession(ctx)
+		}
+		return nil, nil
 	})
 	return err
 }
 
-func (c *WebsocketBus) receive(ctx context.Context) (WebSocketMessage, error) {
-	msg, err := trace.Trace(ctx, trace.NameConfig("WebsocketBus", "receive"), func(ctx context.Context) (any, error) {
-		var msg WebSocketMessage
-		err := c.conn.ReadJSON(&msg)
-		return msg, err
+// endSession closes the connection once the client was told its room was
+// closed. Listen stops when the client answers and the bus leaves the room,
+// which is already gone. Callers hold writeMu.
+func (c *WebsocketBus) endSession(ctx context.Context) {
+	c.roomClosed.Store(true)
+	closeMessage := websocket.FormatCloseMessage(websocket.CloseNormalClosure, "room closed")
+	if err := c.conn.WriteControl(websocket.CloseMessage, closeMessage, time.Now().Add(c.cfg.WriteTimeout)); err != nil {
+		c.logger.Warn(ctx, "Failed to close connection of client %v: %v", c.ID, err)
+	}
+}
+
+func (c *WebsocketBus) receive(ctx context.Context) ([]byte, error) {
+	data, err := trace.Trace(ctx, trace.NameConfig("WebsocketBus", "receive"), func(ctx context.Context) (any, error) {
+		_, data, err := c.conn.ReadMessage()
+		return data, err
 	})
 	if err != nil {
-		return WebSocketMessage{}, err
+		return nil, err
 	}
-	return msg.(WebSocketMessage), nil
+	return data.([]byte), nil
 }
 
 func (c *WebsocketBus) Listen(ctx context.Context) {
 	defer func() { _ = c.Close() }()
 
 	_ = c.conn.SetReadDeadline(time.Now().Add(c.cfg.ReadTimeout))
+	if c.cfg.MaxMessageSize > 0 {
+		c.conn.SetReadLimit(c.cfg.MaxMessageSize)
+	}
 	go c.pinger(ctx)
 
 	for {
-		msg, err := c.receive(ctx)
+		data, err := c.receive(ctx)
 		_ = c.conn.SetReadDeadline(time.Now().Add(c.cfg.ReadTimeout))
 
 		if err != nil {
 			c.handleReceiveError(ctx, err)
 			return
 		}
-		c.logger.Info(ctx, "Message received from client %v: %v", c.ID, msg)
-
-		c.process(ctx, msg)
-	}
-}
-
-func (c *WebsocketBus) process(ctx context.Context, msg WebSocketMessage) {
-	c.logger.Debug(ctx, "Processing message for event type '%v' with payload: %v", msg.Type, msg)
-	usecaseCall, exists := c.calls[msg.Type]
-	if !exists {
-		c.logger.Error(ctx, fmt.Sprintf("Unknown event type '%v' for client %v", msg.Type, c.ID), errors.New("unknown event type"))
-		return
-	}
 
-	err := usecaseCall(ctx, msg)
-	if err != nil {
-		c.logger.Error(ctx, fmt.Sprintf("Error handling event for client %v", c.ID), err)
-		return
+		c.dispatch(ctx, data)
 	}
 }
 
 func (c *WebsocketBus) handleReceiveError(ctx context.Context, err error) {
 	_, _ = trace.Trace(ctx, trace.NameConfig("WebsocketBus", "handleReceiveError"), func(ctx context.Context) (any, error) {
+		// closed on our side, e.g. while draining
+		if c.closed.Load() {
+			c.logger.Debug(ctx, "Reader: Connection of client %v closed by the server", c.ID)
+			return nil, nil
+		}
+
 		// closed connection
 		if websocket.IsCloseError(err, websocket.CloseGoingAway, websocket.CloseAbnormalClosure) {
 			c.logger.Warn(ctx, "Reader: Connection explicitly closed by client or network: %v", err.Error())
 			return nil, nil
 		}
 
+		// gorilla already replied with a message-too-big close frame
+		if errors.Is(err, websocket.ErrReadLimit) {
+			c.logger.Warn(ctx, "Reader: Client %v sent a message larger than %d bytes, closing the connection", c.ID, c.cfg.MaxMessageSize)
+			return nil, nil
+		}
+
 		// I/O timeout
 		if netErr, ok := err.(net.Error); ok && netErr.Timeout() {
 			c.logger.Error(ctx, "WebSocket Timeout Detected! Client failed to respond to Ping: %v", netErr)
@@ -265,149 +319,3 @@ func (c *WebsocketBus) pinger(ctx context.Context) {
 		}
 	}
 }
-
-func mapUsecases(usecases usecase.UseCasesFacade, clientID, roomID string) map[string]useCaseCall {
-	decode := func(payload any, out any) error {
-		if v, ok := payload.(map[string]any); ok {
-			b, err := json.Marshal(v)
-			if err != nil {
-				return err
-			}
-			return json.Unmarshal(b, out)
-		}
-		b, err := json.Marshal(payload)
-		if err != nil {
-			return err
-		}
-		return json.Unmarshal(b, out)
-	}
-
-	return map[string]useCaseCall{
-		"update-name": func(ctx context.Context, msg WebSocketMessage) error {
-			var payload UpdateNamePayload
-			if err := decode(msg.Payload, &payload); err != nil {
-				return errors.New("invalid payload")
-			}
-			return usecases.UpdateName.Execute(ctx, usecase.UpdateNameCommand{
-				RoomID:   roomID,
-				SenderID: clientID,
-				Username: payload.Username,
-			})
-		},
-		"vote": func(ctx context.Context, msg WebSocketMessage) error {
-			var payload VotePayload
-			if err := decode(msg.Payload, &payload); err != nil {
-				return errors.New("invalid payload")
-			}
-			return usecases.Vote.Execute(ctx, usecase.VoteCommand{
-				RoomID:   roomID,
-				SenderID: clientID,
-				Vote:     lo.ToPtr(payload.Vote),
-			})
-		},
-		"reset": func(ctx context.Context, msg WebSocketMessage) error {
-			return usecases.Reset.Execute(ctx, usecase.ResetCommand{
-				RoomID:   roomID,
-				SenderID: clientID,
-			})
-		},
-		"reveal-votes": func(ctx context.Context, msg WebSocketMessage) error {
-			return usecases.Reveal.Execute(ctx, usecase.RevealCommand{
-				RoomID:   roomID,
-				SenderID: clientID,
-			})
-		},
-		"toggle-spectator": func(ctx context.Context, msg WebSocketMessage) error {
-			var payload ToggleSpectatorPayload
-			if err := decode(msg.Payload, &payload); err != nil {
-				return errors.New("invalid payload")
-			}
-			return usecases.ToggleSpectator.Execute(ctx, usecase.ToggleSpectatorCommand{
-				RoomID:         roomID,
-				SenderID:       clientID,
-				TargetClientID: payload.TargetClientID,
-			})
-		},
-		"toggle-owner": func(ctx context.Context, msg WebSocketMessage) error {
-			var payload ToggleOwnerPayload
-			if err := decode(msg.Payload, &payload); err != nil {
-				return errors.New("invalid payload")
-			}
-			return usecases.ToggleOwner.Execute(ctx, usecase.ToggleOwnerCommand{
-				RoomID:         roomID,
-				SenderID:       clientID,
-				TargetClientID: payload.TargetClientID,
-			})
-		},
-		"update-story": func(ctx context.Context, msg WebSocketMessage) error {
-			var payload UpdateStoryPayload
-			if err := decode(msg.Payload, &payload); err != nil {
-				return errors.New("invalid payload")
-			}
-			return usecases.UpdateStory.Execute(ctx, usecase.UpdateStoryCommand{
-				RoomID:   roomID,
-				SenderID: clientID,
-				Story:    payload.Story,
-			})
-		},
-		"new-voting": func(ctx context.Context, msg WebSocketMessage) error {
-			return usecases.NewVoting.Execute(ctx, usecase.NewVotingCommand{
-				RoomID:   roomID,
-				SenderID: clientID,
-			})
-		},
-		"vote-again": func(ctx context.Context, msg WebSocketMessage) error {
-			return usecases.VoteAgain.Execute(ctx, usecase.VoteAgainCommand{
-				RoomID:   roomID,
-				SenderID: clientID,
-			})
-		},
-		"toggle-backlog-mode": func(ctx context.Context, msg WebSocketMessage) error {
-			return usecases.ToggleBacklogMode.Execute(ctx, usecase.ToggleBacklogModeCommand{
-				RoomID:   roomID,
-				SenderID: clientID,
-			})
-		},
-		"add-story": func(ctx context.Context, msg WebSocketMessage) error {
-			var payload AddStoryPayload
-			if err := decode(msg.Payload, &payload); err != nil {
-				return errors.New("invalid payload")
-			}
-			return usecases.AddStory.Execute(ctx, usecase.AddStoryCommand{
-				RoomID:    roomID,
-				SenderID:  clientID,
-				StoryName: payload.Story,
-			})
-		},
-		"remove-story": func(ctx context.Context, msg WebSocketMessage) error {
-			var payload RemoveStoryPayload
-			if err := decode(msg.Payload, &payload); err != nil {
-				return errors.New("invalid payload")
-			}
-			return usecases.RemoveStory.Execute(ctx, usecase.RemoveStoryCommand{
-				RoomID:     roomID,
-				SenderID:   clientID,
-				StoryIndex: payload.StoryIndex,
-			})
-		},
-		"advance-story": func(ctx context.Context, msg WebSocketMessage) error {
-			return usecases.AdvanceStory.Execute(ctx, usecase.AdvanceStoryCommand{
-				RoomID:   roomID,
-				SenderID: clientID,
-			})
-		},
-		"prev-story": func(ctx context.Context, msg WebSocketMessage) error {
-			return usecases.PrevStory.Execute(ctx, usecase.PrevStoryCommand{
-				RoomID:   roomID,
-				SenderID: clientID,
-			})
-		},
-	}
-}
-
-func (c *WebsocketBus) leaveRoom(ctx context.Context) error {
-	return c.usecases.LeaveRoom.Execute(ctx, usecase.LeaveRoomCommand{
-		RoomID:   c.roomID,
-		SenderID: c.ID,
-	})
-}
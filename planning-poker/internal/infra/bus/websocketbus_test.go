@@ -1,12 +1,19 @@
 package bus
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"planning-poker/internal/application/planningpoker/metric"
 	"planning-poker/internal/application/planningpoker/usecase"
+	"planning-poker/internal/application/planningpoker/usecase/dto"
+	"planning-poker/internal/application/ratelimit"
 	"planning-poker/internal/domain"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/gorilla/websocket"
 	"go.uber.org/mock/gomock"
@@ -160,3 +167,561 @@ func TestWebsocketBus_Detach_IsIdempotent(t *testing.T) {
 		t.Fatalf("expected no error from Close after double Detach, got %v", err)
 	}
 }
+
+func TestWebsocketBus_Send_Patches(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	serverCh := make(chan *websocket.Conn, 1)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		upgrader := websocket.Upgrader{CheckOrigin: func(*http.Request) bool { return true }}
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		serverCh <- conn
+		<-make(chan struct{})
+	}))
+	defer srv.Close()
+
+	wsURL := "ws://" + strings.TrimPrefix(srv.URL, "http://")
+	clientConn, _, err := websocket.DefaultDialer.Dial(wsURL, nil)
+	if err != nil {
+		t.Fatalf("failed to dial test websocket: %v", err)
+	}
+	defer clientConn.Close()
+
+	serverConn := <-serverCh
+
+	ctx := context.Background()
+	state := func(version int64, reveal bool) dto.RoomState {
+		return dto.RoomState{Type: dto.RoomStateType, Version: version, Reveal: reveal}
+	}
+
+	var bus *WebsocketBus
+	mockResync := usecase.NewMockUseCase[usecase.ResyncCommand](ctrl)
+	mockResync.EXPECT().
+		Execute(gomock.Any(), usecase.ResyncCommand{RoomID: "test-room", SenderID: "test-client"}).
+		DoAndReturn(func(ctx context.Context, _ usecase.ResyncCommand) error {
+			return bus.Send(ctx, state(3, true))
+		})
+
+	factory := NewWebSocketBusFactory(domain.NewMockHub(ctrl), usecase.UseCasesFacade{Resync: mockResync}, WebSocketConfig{WriteTimeout: time.Second})
+	bus = factory.NewBus(WebSocketBusFactoryInput{
+		ClientID: "test-client",
+		RoomID:   "test-room",
+		Socket:   serverConn,
+		Patches:  true,
+	}).(*WebsocketBus)
+	defer func() {
+		bus.Detach()
+		_ = bus.Close()
+	}()
+
+	read := func() map[string]any {
+		t.Helper()
+		var msg map[string]any
+		_ = clientConn.SetReadDeadline(time.Now().Add(time.Second))
+		if err := clientConn.ReadJSON(&msg); err != nil {
+			t.Fatalf("failed to read message: %v", err)
+		}
+		return msg
+	}
+
+	if err := bus.Send(ctx, state(1, false)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if msg := read(); msg["type"] != "room-state" {
+		t.Fatalf("expected the first state to be sent whole, got %v", msg)
+	}
+
+	// room states published through the hub arrive as generic JSON
+	if err := bus.Send(ctx, map[string]any{"type": "room-state", "version": 2, "reveal": true}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	msg := read()
+	if msg["type"] != "room-patch" || msg["baseVersion"] != float64(1) || msg["version"] != float64(2) {
+		t.Fatalf("unexpected patch %v", msg)
+	}
+	if changes := msg["changes"].(map[string]any); changes["reveal"] != true {
+		t.Errorf("expected reveal change, got %v", changes)
+	}
+
+	// late states are dropped, other messages go through untouched
+	if err := bus.Send(ctx, state(1, false)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := bus.Send(ctx, dto.NewKickNotification()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if msg := read(); msg["type"] != "kicked" {
+		t.Fatalf("expected stale state to be dropped, got %v", msg)
+	}
+
+	bus.process(ctx, WebSocketMessage{Type: "resync"})
+	if msg := read(); msg["type"] != "room-state" || msg["version"] != float64(3) {
+		t.Fatalf("expected resync to send the full state, got %v", msg)
+	}
+}
+
+func TestWebsocketBus_Send_PublishedPatches(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	serverCh := make(chan *websocket.Conn, 1)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		upgrader := websocket.Upgrader{CheckOrigin: func(*http.Request) bool { return true }}
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		serverCh <- conn
+		<-make(chan struct{})
+	}))
+	defer srv.Close()
+
+	wsURL := "ws://" + strings.TrimPrefix(srv.URL, "http://")
+	clientConn, _, err := websocket.DefaultDialer.Dial(wsURL, nil)
+	if err != nil {
+		t.Fatalf("failed to dial test websocket: %v", err)
+	}
+	defer clientConn.Close()
+
+	serverConn := <-serverCh
+
+	ctx := context.Background()
+	state := func(version int64, reveal bool) dto.RoomState {
+		return dto.RoomState{Type: dto.RoomStateType, Version: version, Reveal: reveal}
+	}
+	// patches published through the hub arrive as generic JSON
+	published := func(previous, current dto.RoomState) map[string]any {
+		t.Helper()
+		patch, _ := dto.NewRoomPatchCommand(previous, current)
+		data, _ := json.Marshal(patch)
+		var message map[string]any
+		if err := json.Unmarshal(data, &message); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return message
+	}
+
+	var bus *WebsocketBus
+	mockResync := usecase.NewMockUseCase[usecase.ResyncCommand](ctrl)
+	mockResync.EXPECT().
+		Execute(gomock.Any(), usecase.ResyncCommand{RoomID: "test-room", SenderID: "test-client"}).
+		DoAndReturn(func(ctx context.Context, _ usecase.ResyncCommand) error {
+			return bus.Send(ctx, state(4, false))
+		})
+
+	factory := NewWebSocketBusFactory(domain.NewMockHub(ctrl), usecase.UseCasesFacade{Resync: mockResync}, WebSocketConfig{WriteTimeout: time.Second})
+	bus = factory.NewBus(WebSocketBusFactoryInput{
+		ClientID: "test-client",
+		RoomID:   "test-room",
+		Socket:   serverConn,
+	}).(*WebsocketBus)
+	defer func() {
+		bus.Detach()
+		_ = bus.Close()
+	}()
+
+	read := func() map[string]any {
+		t.Helper()
+		var msg map[string]any
+		_ = clientConn.SetReadDeadline(time.Now().Add(time.Second))
+		if err := clientConn.ReadJSON(&msg); err != nil {
+			t.Fatalf("failed to read message: %v", err)
+		}
+		return msg
+	}
+
+	if err := bus.Send(ctx, state(1, false)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if msg := read(); msg["type"] != "room-state" {
+		t.Fatalf("expected the first state to be sent whole, got %v", msg)
+	}
+
+	// clients without patches get the state the patch leads to
+	if err := bus.Send(ctx, published(state(1, false), state(2, true))); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if msg := read(); msg["type"] != "room-state" || msg["version"] != float64(2) || msg["reveal"] != true {
+		t.Fatalf("expected the patched state, got %v", msg)
+	}
+
+	// a patch that skips a version is dropped and the state resynced
+	if err := bus.Send(ctx, published(state(3, false), state(4, false))); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if msg := read(); msg["type"] != "room-state" || msg["version"] != float64(4) {
+		t.Fatalf("expected the state to be resynced, got %v", msg)
+	}
+}
+
+func TestWebsocketBus_Send_RoomClosed_EndsSession(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	serverCh := make(chan *websocket.Conn, 1)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		upgrader := websocket.Upgrader{CheckOrigin: func(*http.Request) bool { return true }}
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		serverCh <- conn
+		<-make(chan struct{})
+	}))
+	defer srv.Close()
+
+	wsURL := "ws://" + strings.TrimPrefix(srv.URL, "http://")
+	clientConn, _, err := websocket.DefaultDialer.Dial(wsURL, nil)
+	if err != nil {
+		t.Fatalf("failed to dial test websocket: %v", err)
+	}
+	defer clientConn.Close()
+
+	serverConn := <-serverCh
+
+	left := make(chan struct{})
+	mockLeaveRoom := usecase.NewMockUseCase[usecase.LeaveRoomCommand](ctrl)
+	mockLeaveRoom.EXPECT().
+		Execute(gomock.Any(), usecase.LeaveRoomCommand{
+			RoomID:     "test-room",
+			SenderID:   "test-client",
+			RoomClosed: true,
+		}).
+		DoAndReturn(func(context.Context, usecase.LeaveRoomCommand) error {
+			close(left)
+			return nil
+		})
+
+	bus := NewWebsocketBus(
+		"test-client",
+		"test-room",
+		serverConn,
+		domain.NewMockHub(ctrl),
+		usecase.UseCasesFacade{LeaveRoom: mockLeaveRoom},
+		WebSocketConfig{WriteTimeout: time.Second, ReadTimeout: 5 * time.Second, PingInterval: time.Minute},
+	)
+	go bus.Listen(context.Background())
+
+	// notifications published through the hub arrive as generic JSON
+	if err := bus.Send(context.Background(), map[string]any{"type": dto.RoomClosedType, "reason": "idle"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var msg map[string]any
+	_ = clientConn.SetReadDeadline(time.Now().Add(time.Second))
+	if err := clientConn.ReadJSON(&msg); err != nil || msg["type"] != dto.RoomClosedType {
+		t.Fatalf("expected the room-closed notification, got %v (%v)", msg, err)
+	}
+	if _, _, err := clientConn.ReadMessage(); !websocket.IsCloseError(err, websocket.CloseNormalClosure) {
+		t.Fatalf("expected the connection to be closed, got %v", err)
+	}
+
+	select {
+	case <-left:
+	case <-time.After(2 * time.Second):
+		t.Fatal("expected the bus to leave the closed room")
+	}
+}
+
+func TestWebSocketBusFactory_Drain_KeepsClientsInRooms(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	serverCh := make(chan *websocket.Conn, 1)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		upgrader := websocket.Upgrader{CheckOrigin: func(*http.Request) bool { return true }}
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		serverCh <- conn
+		<-make(chan struct{})
+	}))
+	defer srv.Close()
+
+	wsURL := "ws://" + strings.TrimPrefix(srv.URL, "http://")
+	clientConn, _, err := websocket.DefaultDialer.Dial(wsURL, nil)
+	if err != nil {
+		t.Fatalf("failed to dial test websocket: %v", err)
+	}
+	defer clientConn.Close()
+
+	serverConn := <-serverCh
+
+	mockLeaveRoom := usecase.NewMockUseCase[usecase.LeaveRoomCommand](ctrl)
+	mockLeaveRoom.EXPECT().Execute(gomock.Any(), gomock.Any()).Times(0)
+
+	factory := NewWebSocketBusFactory(
+		domain.NewMockHub(ctrl),
+		usecase.UseCasesFacade{LeaveRoom: mockLeaveRoom},
+		WebSocketConfig{WriteTimeout: time.Second, ReadTimeout: 5 * time.Second, PingInterval: time.Minute},
+	)
+	bus := factory.NewBus(WebSocketBusFactoryInput{ClientID: "test-client", RoomID: "test-room", Socket: serverConn})
+	listening := make(chan struct{})
+	go func() {
+		bus.Listen(context.Background())
+		close(listening)
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if err := factory.Drain(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var msg map[string]any
+	_ = clientConn.SetReadDeadline(time.Now().Add(time.Second))
+	if err := clientConn.ReadJSON(&msg); err != nil || msg["type"] != "server-draining" {
+		t.Fatalf("expected the server-draining notification, got %v (%v)", msg, err)
+	}
+	if _, _, err := clientConn.ReadMessage(); !websocket.IsCloseError(err, websocket.CloseServiceRestart) {
+		t.Fatalf("expected the connection to be closed for a restart, got %v", err)
+	}
+
+	select {
+	case <-listening:
+	case <-time.After(2 * time.Second):
+		t.Fatal("expected the bus to stop listening")
+	}
+	if len(factory.buses) != 0 {
+		t.Errorf("expected drained buses to be forgotten, got %d", len(factory.buses))
+	}
+}
+
+func TestWebsocketBus_Process_RepliesToSender(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+
+	serverCh := make(chan *websocket.Conn, 1)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		upgrader := websocket.Upgrader{CheckOrigin: func(*http.Request) bool { return true }}
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		serverCh <- conn
+		<-make(chan struct{})
+	}))
+	defer srv.Close()
+
+	wsURL := "ws://" + strings.TrimPrefix(srv.URL, "http://")
+	clientConn, _, err := websocket.DefaultDialer.Dial(wsURL, nil)
+	if err != nil {
+		t.Fatalf("failed to dial test websocket: %v", err)
+	}
+	defer clientConn.Close()
+
+	serverConn := <-serverCh
+
+	mockReveal := usecase.NewMockUseCase[usecase.RevealCommand](ctrl)
+	gomock.InOrder(
+		mockReveal.EXPECT().Execute(gomock.Any(), gomock.Any()).Return(nil),
+		mockReveal.EXPECT().Execute(gomock.Any(), gomock.Any()).
+			Return(&domain.PermissionError{RoomID: "test-room", ClientID: "test-client", Role: "voter", Action: "reveal"}),
+		mockReveal.EXPECT().Execute(gomock.Any(), gomock.Any()).Return(nil),
+	)
+
+	bus := NewWebsocketBus(
+		"test-client",
+		"test-room",
+		serverConn,
+		domain.NewMockHub(ctrl),
+		usecase.UseCasesFacade{Reveal: mockReveal},
+		WebSocketConfig{WriteTimeout: time.Second},
+	)
+	defer func() {
+		bus.Detach()
+		_ = bus.Close()
+	}()
+
+	read := func() map[string]any {
+		t.Helper()
+		var msg map[string]any
+		_ = clientConn.SetReadDeadline(time.Now().Add(time.Second))
+		if err := clientConn.ReadJSON(&msg); err != nil {
+			t.Fatalf("failed to read message: %v", err)
+		}
+		return msg
+	}
+
+	bus.process(ctx, WebSocketMessage{Type: "reveal-votes", RequestID: "req-1"})
+	if msg := read(); msg["type"] != "ack" || msg["requestId"] != "req-1" {
+		t.Fatalf("expected an ack, got %v", msg)
+	}
+
+	bus.process(ctx, WebSocketMessage{Type: "reveal-votes", RequestID: "req-2"})
+	if msg := read(); msg["type"] != "error" || msg["requestId"] != "req-2" || msg["code"] != "PERMISSION_DENIED" {
+		t.Fatalf("expected a permission error, got %v", msg)
+	}
+
+	// without a request ID successes are silent, errors are still reported
+	bus.process(ctx, WebSocketMessage{Type: "reveal-votes"})
+	bus.process(ctx, WebSocketMessage{Type: "dance"})
+	if msg := read(); msg["type"] != "error" || msg["code"] != "UNKNOWN_MESSAGE_TYPE" || msg["requestId"] != nil {
+		t.Fatalf("expected an unknown message type error, got %v", msg)
+	}
+
+	bus.process(ctx, WebSocketMessage{Type: "vote", Payload: "not an object", RequestID: "req-3"})
+	if msg := read(); msg["type"] != "error" || msg["code"] != "INVALID_PAYLOAD" {
+		t.Fatalf("expected an invalid payload error, got %v", msg)
+	}
+}
+
+func TestWebsocketBus_Process_ThrottlesClientAndRoom(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+
+	serverCh := make(chan *websocket.Conn, 1)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		upgrader := websocket.Upgrader{CheckOrigin: func(*http.Request) bool { return true }}
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		serverCh <- conn
+		<-make(chan struct{})
+	}))
+	defer srv.Close()
+
+	wsURL := "ws://" + strings.TrimPrefix(srv.URL, "http://")
+	clientConn, _, err := websocket.DefaultDialer.Dial(wsURL, nil)
+	if err != nil {
+		t.Fatalf("failed to dial test websocket: %v", err)
+	}
+	defer clientConn.Close()
+
+	serverConn := <-serverCh
+
+	clientLimit := ratelimit.Limit{Rate: 60, Burst: 5}
+	roomLimit := ratelimit.Limit{Rate: 600, Burst: 20}
+	limiter := ratelimit.NewMockLimiter(ctrl)
+	allowed := ratelimit.Decision{Allowed: true}
+	throttled := ratelimit.Decision{RetryAfter: time.Second}
+	gomock.InOrder(
+		limiter.EXPECT().Allow(gomock.Any(), "client:test-room:test-client", clientLimit).Return(allowed, nil),
+		limiter.EXPECT().Allow(gomock.Any(), "room:test-room", roomLimit).Return(allowed, nil),
+		limiter.EXPECT().Allow(gomock.Any(), "client:test-room:test-client", clientLimit).Return(throttled, nil),
+		limiter.EXPECT().Allow(gomock.Any(), "client:test-room:test-client", clientLimit).Return(allowed, nil),
+		limiter.EXPECT().Allow(gomock.Any(), "room:test-room", roomLimit).Return(throttled, nil),
+		limiter.EXPECT().Allow(gomock.Any(), "client:test-room:test-client", clientLimit).Return(ratelimit.Decision{}, errors.New("redis down")),
+	)
+
+	mockReveal := usecase.NewMockUseCase[usecase.RevealCommand](ctrl)
+	mockReveal.EXPECT().Execute(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+
+	bus := NewWebsocketBus(
+		"test-client",
+		"test-room",
+		serverConn,
+		domain.NewMockHub(ctrl),
+		usecase.UseCasesFacade{Reveal: mockReveal},
+		WebSocketConfig{
+			WriteTimeout: time.Second,
+			RateLimit:    RateLimit{Limiter: limiter, Client: clientLimit, Room: roomLimit, Metric: metric.NewPlanningPokerMetric()},
+		},
+	)
+	defer func() {
+		bus.Detach()
+		_ = bus.Close()
+	}()
+
+	read := func() map[string]any {
+		t.Helper()
+		var msg map[string]any
+		_ = clientConn.SetReadDeadline(time.Now().Add(time.Second))
+		if err := clientConn.ReadJSON(&msg); err != nil {
+			t.Fatalf("failed to read message: %v", err)
+		}
+		return msg
+	}
+
+	bus.process(ctx, WebSocketMessage{Type: "reveal-votes", RequestID: "req-1"})
+	if msg := read(); msg["type"] != "ack" {
+		t.Fatalf("expected an ack, got %v", msg)
+	}
+
+	for _, requestID := range []string{"req-2", "req-3"} {
+		bus.process(ctx, WebSocketMessage{Type: "reveal-votes", RequestID: requestID})
+		if msg := read(); msg["type"] != "error" || msg["requestId"] != requestID || msg["code"] != "RATE_LIMITED" {
+			t.Fatalf("expected a rate limited error, got %v", msg)
+		}
+	}
+
+	// a failing limiter lets messages through
+	bus.process(ctx, WebSocketMessage{Type: "reveal-votes", RequestID: "req-4"})
+	if msg := read(); msg["type"] != "ack" {
+		t.Fatalf("expected an ack, got %v", msg)
+	}
+}
+
+func TestWebsocketBus_Listen_ClosesOnOversizedMessage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	serverCh := make(chan *websocket.Conn, 1)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		upgrader := websocket.Upgrader{CheckOrigin: func(*http.Request) bool { return true }}
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		serverCh <- conn
+		<-make(chan struct{})
+	}))
+	defer srv.Close()
+
+	wsURL := "ws://" + strings.TrimPrefix(srv.URL, "http://")
+	clientConn, _, err := websocket.DefaultDialer.Dial(wsURL, nil)
+	if err != nil {
+		t.Fatalf("failed to dial test websocket: %v", err)
+	}
+	defer clientConn.Close()
+
+	serverConn := <-serverCh
+
+	mockLeaveRoom := usecase.NewMockUseCase[usecase.LeaveRoomCommand](ctrl)
+	mockLeaveRoom.EXPECT().Execute(gomock.Any(), gomock.Any()).Return(nil)
+
+	bus := NewWebsocketBus(
+		"test-client",
+		"test-room",
+		serverConn,
+		domain.NewMockHub(ctrl),
+		usecase.UseCasesFacade{LeaveRoom: mockLeaveRoom},
+		WebSocketConfig{WriteTimeout: time.Second, ReadTimeout: 5 * time.Second, PingInterval: time.Minute, MaxMessageSize: 64},
+	)
+	listening := make(chan struct{})
+	go func() {
+		bus.Listen(context.Background())
+		close(listening)
+	}()
+
+	message := `{"type": "update-story", "payload": {"story": "` + strings.Repeat("a", 100) + `"}}`
+	if err := clientConn.WriteMessage(websocket.TextMessage, []byte(message)); err != nil {
+		t.Fatalf("failed to write message: %v", err)
+	}
+
+	_ = clientConn.SetReadDeadline(time.Now().Add(time.Second))
+	if _, _, err := clientConn.ReadMessage(); !websocket.IsCloseError(err, websocket.CloseMessageTooBig) {
+		t.Fatalf("expected the connection to be closed for a message too big, got %v", err)
+	}
+
+	select {
+	case <-listening:
+	case <-time.After(2 * time.Second):
+		t.Fatal("expected the bus to stop listening")
+	}
+}
@@ -0,0 +1,143 @@
+package lifecycle
+
+import (
+	"context"
+	"fmt"
+	"planning-poker/internal/application/planningpoker/usecase"
+	"planning-poker/internal/application/timer"
+	"planning-poker/internal/domain"
+	"planning-poker/internal/domain/entity"
+	"time"
+
+	"github.com/bruno303/go-toolkit/pkg/log"
+)
+
+const (
+	defaultReapInterval = time.Minute
+	reapPageSize        = 200
+)
+
+// Reaper walks every room periodically, warns the clients of rooms that are
+// about to expire and closes the expired ones. Every instance runs one; the
+// close use case takes care of closing each room only once, but clients may
+// be warned once per instance.
+type Reaper struct {
+	rooms      domain.AdminHub
+	lifecycle  entity.RoomLifecycle
+	warnBefore time.Duration
+	closeRoom  usecase.UseCase[usecase.CloseExpiredRoomCommand]
+	warnRoom   usecase.UseCase[usecase.WarnRoomExpiringCommand]
+	clock      timer.Clock
+	interval   time.Duration
+	logger     log.Logger
+
+	// warned keeps the expiry each room was last warned about, so that a room
+	// is warned again only when its expiry moves
+	warned map[string]time.Time
+}
+
+func NewReaper(
+	rooms domain.AdminHub,
+	lifecycle entity.RoomLifecycle,
+	warnBefore time.Duration,
+	closeRoom usecase.UseCase[usecase.CloseExpiredRoomCommand],
+	warnRoom usecase.UseCase[usecase.WarnRoomExpiringCommand],
+	clock timer.Clock,
+	interval time.Duration,
+) *Reaper {
+	if interval <= 0 {
+		interval = defaultReapInterval
+	}
+
+	return &Reaper{
+		rooms:      rooms,
+		lifecycle:  lifecycle,
+		warnBefore: warnBefore,
+		closeRoom:  closeRoom,
+		warnRoom:   warnRoom,
+		clock:      clock,
+		interval:   interval,
+		logger:     log.NewLogger("lifecycle.reaper"),
+		warned:     make(map[string]time.Time),
+	}
+}
+
+// Run blocks until the context is cancelled.
+func (r *Reaper) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	r.logger.Info(ctx, "Room reaper started, checking every %s", r.interval)
+	for {
+		select {
+		case <-ctx.Done():
+			r.logger.Info(ctx, "Room reaper stopped")
+			return
+		case <-ticker.C:
+			r.Tick(ctx)
+		}
+	}
+}
+
+// Tick closes the rooms that expired and warns the ones expiring soon.
+func (r *Reaper) Tick(ctx context.Context) {
+	now := r.clock.Now()
+	seen := make(map[string]struct{})
+
+	query := domain.RoomQuery{Limit: reapPageSize}
+	for {
+		page, err := r.rooms.ListRooms(ctx, query)
+		if err != nil {
+			r.logger.Error(ctx, "Error listing rooms to reap", err)
+			return
+		}
+
+		for _, room := range page.Rooms {
+			seen[room.ID] = struct{}{}
+			r.check(ctx, room, now)
+		}
+
+		if page.NextCursor == "" {
+			break
+		}
+		query.Cursor = page.NextCursor
+	}
+
+	for roomID := range r.warned {
+		if _, ok := seen[roomID]; !ok {
+			delete(r.warned, roomID)
+		}
+	}
+}
+
+func (r *Reaper) check(ctx context.Context, room *entity.Room, now time.Time) {
+	expiresAt, reason, ok := room.Expiry(r.lifecycle)
+	if !ok {
+		return
+	}
+
+	if !now.Before(expiresAt) {
+		if err := r.closeRoom.Execute(ctx, usecase.CloseExpiredRoomCommand{RoomID: room.ID}); err != nil {
+			r.logger.Error(ctx, fmt.Sprintf("Error closing expired room %s", room.ID), err)
+		}
+		return
+	}
+
+	if r.warnBefore <= 0 || room.IsEmpty() || now.Before(expiresAt.Add(-r.warnBefore)) {
+		return
+	}
+	if warnedAt, ok := r.warned[room.ID]; ok && warnedAt.Equal(expiresAt) {
+		return
+	}
+
+	err := r.warnRoom.Execute(ctx, usecase.WarnRoomExpiringCommand{
+		RoomID:    room.ID,
+		Reason:    reason,
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		r.logger.Error(ctx, fmt.Sprintf("Error warning room %s about its expiry", room.ID), err)
+		return
+	}
+	r.warned[room.ID] = expiresAt
+}
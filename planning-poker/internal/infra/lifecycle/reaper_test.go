@@ -0,0 +1,192 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"planning-poker/internal/application/planningpoker/metric"
+	"planning-poker/internal/application/planningpoker/usecase"
+	"planning-poker/internal/application/planningpoker/usecase/dto"
+	"planning-poker/internal/application/webhook"
+	"planning-poker/internal/domain"
+	"planning-poker/internal/domain/entity"
+	"planning-poker/internal/infra/boundaries/hub/inmemory"
+	infralock "planning-poker/internal/infra/lock"
+	"sync"
+	"testing"
+	"time"
+)
+
+type (
+	fakeClock struct {
+		mu  sync.Mutex
+		now time.Time
+	}
+	recordingHub struct {
+		*inmemory.InMemoryHub
+		mu       sync.Mutex
+		messages []any
+	}
+)
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func (h *recordingHub) BroadcastToRoom(ctx context.Context, roomID string, message any) error {
+	h.mu.Lock()
+	h.messages = append(h.messages, message)
+	h.mu.Unlock()
+	return h.InMemoryHub.BroadcastToRoom(ctx, roomID, message)
+}
+
+func (h *recordingHub) Messages() []any {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return append([]any(nil), h.messages...)
+}
+
+func newTestHub(clock *fakeClock) *recordingHub {
+	hub := &recordingHub{InMemoryHub: inmemory.NewHub()}
+	hub.SetClock(clock)
+	return hub
+}
+
+func newTestReaper(hub *recordingHub, clock *fakeClock, lifecycle entity.RoomLifecycle, warnBefore time.Duration) *Reaper {
+	closeRoom := usecase.NewCloseExpiredRoomUseCase(hub, infralock.NewInMemoryLockManager(), metric.NewPlanningPokerMetric(), lifecycle, clock, webhook.Discard)
+	warnRoom := usecase.NewWarnRoomExpiringUseCase(hub)
+	return NewReaper(hub, lifecycle, warnBefore, closeRoom, warnRoom, clock, time.Minute)
+}
+
+func TestReaper_WarnsThenClosesIdleRoom(t *testing.T) {
+	ctx := context.Background()
+	clock := &fakeClock{now: time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)}
+	hub := newTestHub(clock)
+	lifecycle := entity.RoomLifecycle{IdleTimeout: time.Hour}
+	reaper := newTestReaper(hub, clock, lifecycle, 5*time.Minute)
+
+	room, _ := hub.NewRoom(ctx)
+	room.NewClient("owner")
+	_ = hub.SaveRoom(ctx, room)
+
+	clock.Advance(50 * time.Minute)
+	reaper.Tick(ctx)
+	if got := len(hub.Messages()); got != 0 {
+		t.Fatalf("expected no message before the warning window, got %d", got)
+	}
+
+	clock.Advance(6 * time.Minute)
+	reaper.Tick(ctx)
+	reaper.Tick(ctx)
+	messages := hub.Messages()
+	if len(messages) != 1 {
+		t.Fatalf("expected a single warning, got %v", messages)
+	}
+	warning, ok := messages[0].(dto.RoomExpiring)
+	if !ok || warning.Reason != string(entity.ExpiryIdle) || !warning.ExpiresAt.Equal(room.UpdatedAt.Add(time.Hour)) {
+		t.Errorf("unexpected warning %+v", messages[0])
+	}
+
+	clock.Advance(4 * time.Minute)
+	reaper.Tick(ctx)
+	messages = hub.Messages()
+	if len(messages) != 2 {
+		t.Fatalf("expected the room to be closed, got %v", messages)
+	}
+	if closed, ok := messages[1].(dto.RoomClosed); !ok || closed.Type != dto.RoomClosedType {
+		t.Errorf("unexpected close message %+v", messages[1])
+	}
+	if _, err := hub.LoadRoom(ctx, room.ID); !errors.Is(err, domain.ErrRoomNotFound) {
+		t.Errorf("expected room to be removed, got %v", err)
+	}
+}
+
+func TestReaper_WarnsAgainWhenExpiryMoves(t *testing.T) {
+	ctx := context.Background()
+	clock := &fakeClock{now: time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)}
+	hub := newTestHub(clock)
+	reaper := newTestReaper(hub, clock, entity.RoomLifecycle{IdleTimeout: time.Hour}, 5*time.Minute)
+
+	room, _ := hub.NewRoom(ctx)
+	room.NewClient("owner")
+	_ = hub.SaveRoom(ctx, room)
+
+	clock.Advance(56 * time.Minute)
+	reaper.Tick(ctx)
+
+	// something happened in the room, which keeps it alive for another hour
+	_ = room.UpdateClientName(ctx, "owner", "Alice")
+	_ = hub.SaveRoom(ctx, room)
+	clock.Advance(56 * time.Minute)
+	reaper.Tick(ctx)
+
+	if got := len(hub.Messages()); got != 2 {
+		t.Errorf("expected two warnings, got %d", got)
+	}
+}
+
+func TestReaper_ClosesEmptyRoomsSilently(t *testing.T) {
+	ctx := context.Background()
+	clock := &fakeClock{now: time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)}
+	hub := newTestHub(clock)
+	lifecycle := entity.RoomLifecycle{IdleTimeout: time.Hour, EmptyRoomTTL: 10 * time.Minute}
+	reaper := newTestReaper(hub, clock, lifecycle, 5*time.Minute)
+
+	empty, _ := hub.NewRoom(ctx)
+	busy, _ := hub.NewRoom(ctx)
+	busy.NewClient("owner")
+	_ = hub.SaveRoom(ctx, busy)
+
+	clock.Advance(10 * time.Minute)
+	reaper.Tick(ctx)
+
+	if got := len(hub.Messages()); got != 0 {
+		t.Errorf("expected no message for an empty room, got %d", got)
+	}
+	if _, err := hub.LoadRoom(ctx, empty.ID); !errors.Is(err, domain.ErrRoomNotFound) {
+		t.Errorf("expected empty room to be removed, got %v", err)
+	}
+	if _, err := hub.LoadRoom(ctx, busy.ID); err != nil {
+		t.Errorf("expected busy room to be kept, got %v", err)
+	}
+}
+
+func TestReaper_ClosesRoomsWithOnlyBotsLeft(t *testing.T) {
+	ctx := context.Background()
+	clock := &fakeClock{now: time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)}
+	hub := newTestHub(clock)
+	hub.KeepEmptyRooms()
+	lifecycle := entity.RoomLifecycle{IdleTimeout: time.Hour, EmptyRoomTTL: 10 * time.Minute}
+	reaper := newTestReaper(hub, clock, lifecycle, 5*time.Minute)
+
+	room, _ := hub.NewRoom(ctx)
+	if _, err := room.NewBot(ctx, ""); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	room.NewClient("owner")
+	_ = hub.SaveRoom(ctx, room)
+
+	if err := hub.RemoveClient(ctx, "owner", room.ID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := hub.LoadRoom(ctx, room.ID); err != nil {
+		t.Fatalf("expected the room to be kept for its ttl, got %v", err)
+	}
+
+	clock.Advance(10 * time.Minute)
+	reaper.Tick(ctx)
+
+	if got := len(hub.Messages()); got != 0 {
+		t.Errorf("expected no message for a room with only bots left, got %d", got)
+	}
+	if _, err := hub.LoadRoom(ctx, room.ID); !errors.Is(err, domain.ErrRoomNotFound) {
+		t.Errorf("expected the room to be removed, got %v", err)
+	}
+}
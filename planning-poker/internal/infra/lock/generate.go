@@ -0,0 +1,3 @@
+package lock
+
+//go:generate go tool mockgen -destination mocks.go -typed -package lock . RedisLockClient
@@ -0,0 +1,91 @@
+package lock
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"planning-poker/internal/application/lock"
+	"planning-poker/internal/domain"
+	"time"
+
+	"github.com/bruno303/go-toolkit/pkg/log"
+	"github.com/bruno303/go-toolkit/pkg/trace"
+)
+
+// OptimisticLockManager does not lock. It relies on the hub rejecting saves of
+// rooms changed since they were loaded and runs the function again, loading
+// the room anew, while it fails with ErrVersionConflict.
+type OptimisticLockManager struct {
+	maxAttempts int
+	retryDelay  time.Duration
+	logger      log.Logger
+}
+
+var _ lock.LockManager = (*OptimisticLockManager)(nil)
+
+const (
+	defaultOptimisticMaxAttempts = 10
+	defaultOptimisticRetryDelay  = 5 * time.Millisecond
+)
+
+func NewOptimisticLockManager() *OptimisticLockManager {
+	return &OptimisticLockManager{
+		maxAttempts: defaultOptimisticMaxAttempts,
+		retryDelay:  defaultOptimisticRetryDelay,
+		logger:      log.NewLogger("optimistic.lockmanager"),
+	}
+}
+
+func (m *OptimisticLockManager) SetRetry(maxAttempts int, retryDelay time.Duration) {
+	m.maxAttempts = maxAttempts
+	m.retryDelay = retryDelay
+}
+
+func (m *OptimisticLockManager) WithLock(
+	ctx context.Context,
+	key string,
+	fn func(ctx context.Context) (any, error),
+) (any, error) {
+	return trace.Trace(ctx, trace.NameConfig("OptimisticLockManager", "WithLock"), func(ctx context.Context) (any, error) {
+		return m.retry(ctx, key, fn)
+	})
+}
+
+func (m *OptimisticLockManager) ExecuteWithLock(
+	ctx context.Context,
+	key string,
+	fn func(context.Context) error,
+) error {
+	_, err := trace.Trace(ctx, trace.NameConfig("OptimisticLockManager", "ExecuteWithLock"), func(ctx context.Context) (any, error) {
+		return m.retry(ctx, key, func(ctx context.Context) (any, error) {
+			return nil, fn(ctx)
+		})
+	})
+
+	return err
+}
+
+func (m *OptimisticLockManager) retry(ctx context.Context, key string, fn func(context.Context) (any, error)) (any, error) {
+	for attempt := 1; ; attempt++ {
+		result, err := fn(ctx)
+		if !errors.Is(err, domain.ErrVersionConflict) || attempt >= m.maxAttempts {
+			return result, err
+		}
+
+		m.logger.Debug(ctx, "Conflict saving '%s', retrying... (attempt %d/%d)", key, attempt, m.maxAttempts)
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(m.backoff(attempt)):
+		}
+	}
+}
+
+// backoff grows with the attempts and is randomized so that the writers
+// that conflicted do not retry in lockstep.
+func (m *OptimisticLockManager) backoff(attempt int) time.Duration {
+	if m.retryDelay <= 0 {
+		return 0
+	}
+	return rand.N(m.retryDelay * time.Duration(attempt))
+}
@@ -0,0 +1,81 @@
+package lock
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"planning-poker/internal/domain"
+	"testing"
+	"time"
+)
+
+func TestOptimisticLockManager_ExecuteWithLock_RetriesConflicts(t *testing.T) {
+	manager := NewOptimisticLockManager()
+	manager.SetRetry(5, time.Millisecond)
+	ctx := context.Background()
+
+	calls := 0
+	err := manager.ExecuteWithLock(ctx, "room-1", func(ctx context.Context) error {
+		calls++
+		if calls < 3 {
+			return fmt.Errorf("save room: %w", domain.ErrVersionConflict)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ExecuteWithLock returned error: %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("expected 3 calls, got %d", calls)
+	}
+}
+
+func TestOptimisticLockManager_ExecuteWithLock_GivesUp(t *testing.T) {
+	manager := NewOptimisticLockManager()
+	manager.SetRetry(3, 0)
+	ctx := context.Background()
+
+	calls := 0
+	err := manager.ExecuteWithLock(ctx, "room-1", func(ctx context.Context) error {
+		calls++
+		return domain.ErrVersionConflict
+	})
+	if !errors.Is(err, domain.ErrVersionConflict) {
+		t.Fatalf("expected ErrVersionConflict, got %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("expected 3 calls, got %d", calls)
+	}
+}
+
+func TestOptimisticLockManager_WithLock_DoesNotRetryOtherErrors(t *testing.T) {
+	manager := NewOptimisticLockManager()
+	ctx := context.Background()
+	expected := errors.New("boom")
+
+	calls := 0
+	result, err := manager.WithLock(ctx, "room-1", func(ctx context.Context) (any, error) {
+		calls++
+		return "partial", expected
+	})
+	if !errors.Is(err, expected) {
+		t.Fatalf("expected %v, got %v", expected, err)
+	}
+	if result != "partial" || calls != 1 {
+		t.Errorf("expected a single call returning its result, got %v after %d calls", result, calls)
+	}
+}
+
+func TestOptimisticLockManager_WithLock_StopsWhenContextIsDone(t *testing.T) {
+	manager := NewOptimisticLockManager()
+	manager.SetRetry(100, time.Second)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	_, err := manager.WithLock(ctx, "room-1", func(ctx context.Context) (any, error) {
+		cancel()
+		return nil, domain.ErrVersionConflict
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+}
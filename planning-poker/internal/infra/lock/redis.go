@@ -2,6 +2,7 @@ package lock
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"planning-poker/internal/application/lock"
 	"time"
@@ -11,8 +12,12 @@ import (
 	"github.com/redis/go-redis/v9"
 )
 
+type RedisLockClient interface {
+	Eval(ctx context.Context, script string, keys []string, args ...any) *redis.Cmd
+}
+
 type RedisLockManager struct {
-	client      *redis.Client
+	client      RedisLockClient
 	lockTimeout time.Duration
 	retryDelay  time.Duration
 	maxRetries  int
@@ -26,9 +31,41 @@ const (
 	defaultRetryDelay  = 50 * time.Millisecond
 	defaultMaxRetries  = 100
 	lockKeyPrefix      = "planning-poker:lock:"
+	tokenKeyPrefix     = "planning-poker:lock-token:"
+	// outlives the fences kept by the hub, so tokens never go backwards for
+	// a room that is still fenced
+	tokenTTL = 48 * time.Hour
 )
 
-func NewRedisLockManager(client *redis.Client) *RedisLockManager {
+// acquireScript takes the lock and hands out the next fencing token of the
+// key. It returns 0 when the lock is held by someone else.
+const acquireScript = `
+if redis.call("exists", KEYS[1]) == 1 then
+	return 0
+end
+local token = redis.call("incr", KEYS[2])
+redis.call("pexpire", KEYS[2], ARGV[2])
+redis.call("set", KEYS[1], token, "px", ARGV[1])
+return token
+`
+
+const extendScript = `
+if redis.call("get", KEYS[1]) == ARGV[1] then
+	return redis.call("pexpire", KEYS[1], ARGV[2])
+else
+	return 0
+end
+`
+
+const releaseScript = `
+if redis.call("get", KEYS[1]) == ARGV[1] then
+	return redis.call("del", KEYS[1])
+else
+	return 0
+end
+`
+
+func NewRedisLockManager(client RedisLockClient) *RedisLockManager {
 	return &RedisLockManager{
 		client:      client,
 		lockTimeout: defaultLockTimeout,
@@ -47,53 +84,51 @@ func (m *RedisLockManager) SetLockTimeout(timeout time.Duration) {
 	m.lockTimeout = timeout
 }
 
-func (m *RedisLockManager) acquireLock(ctx context.Context, key string) (string, error) {
-	lockKey := lockKeyPrefix + key
-	lockValue := fmt.Sprintf("%d", time.Now().UnixNano())
+func (m *RedisLockManager) acquireLock(ctx context.Context, key string) (int64, error) {
+	keys := []string{lockKeyPrefix + key, tokenKeyPrefix + key}
 
 	for i := 0; i < m.maxRetries; i++ {
-		acquired, err := m.client.SetNX(ctx, lockKey, lockValue, m.lockTimeout).Result()
+		token, err := m.client.Eval(ctx, acquireScript, keys, m.lockTimeout.Milliseconds(), tokenTTL.Milliseconds()).Int64()
 		if err != nil {
-			return "", fmt.Errorf("failed to acquire lock: %w", err)
+			return 0, fmt.Errorf("failed to acquire lock: %w", err)
 		}
 
-		if acquired {
-			m.logger.Debug(ctx, "Lock acquired for key '%s'", key)
-			return lockValue, nil
+		if token > 0 {
+			m.logger.Debug(ctx, "Lock acquired for key '%s' with token %d", key, token)
+			return token, nil
 		}
 
 		m.logger.Debug(ctx, "Lock for key '%s' is held by another process, retrying... (attempt %d/%d)", key, i+1, m.maxRetries)
 		select {
 		case <-ctx.Done():
-			return "", ctx.Err()
+			return 0, ctx.Err()
 		case <-time.After(m.retryDelay):
 			// Continue to next iteration
 		}
 	}
 
-	return "", fmt.Errorf("failed to acquire lock for key '%s' after %d retries", key, m.maxRetries)
+	return 0, fmt.Errorf("failed to acquire lock for key '%s' after %d retries", key, m.maxRetries)
 }
 
-func (m *RedisLockManager) releaseLock(ctx context.Context, key string, lockValue string) error {
-	lockKey := lockKeyPrefix + key
-
-	script := `
-		if redis.call("get", KEYS[1]) == ARGV[1] then
-			return redis.call("del", KEYS[1])
-		else
-			return 0
-		end
-	`
+// extendLock reports false when the lock is no longer held with the token.
+func (m *RedisLockManager) extendLock(ctx context.Context, key string, token int64) (bool, error) {
+	extended, err := m.client.Eval(ctx, extendScript, []string{lockKeyPrefix + key}, token, m.lockTimeout.Milliseconds()).Int64()
+	if err != nil {
+		return false, fmt.Errorf("failed to extend lock: %w", err)
+	}
+	return extended == 1, nil
+}
 
+func (m *RedisLockManager) releaseLock(ctx context.Context, key string, token int64) error {
 	opCtx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
 	defer cancel()
 
-	result, err := m.client.Eval(opCtx, script, []string{lockKey}, lockValue).Result()
+	result, err := m.client.Eval(opCtx, releaseScript, []string{lockKeyPrefix + key}, token).Int64()
 	if err != nil {
 		return fmt.Errorf("failed to release lock: %w", err)
 	}
 
-	if result.(int64) == 1 {
+	if result == 1 {
 		m.logger.Debug(ctx, "Lock released for key '%s'", key)
 	} else {
 		m.logger.Warn(ctx, "Lock for key '%s' was already released or expired", key)
@@ -102,24 +137,70 @@ func (m *RedisLockManager) releaseLock(ctx context.Context, key string, lockValu
 	return nil
 }
 
+// watchdog extends the lease while the holder works, a third of the timeout
+// before it runs out. When the lock is lost it cancels the holder with
+// ErrLockExpired.
+func (m *RedisLockManager) watchdog(ctx context.Context, key string, token int64, expire context.CancelCauseFunc) {
+	ticker := time.NewTicker(m.lockTimeout / 3)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			extended, err := m.extendLock(ctx, key, token)
+			if err != nil {
+				// the lease is still valid until the timeout, try again
+				m.logger.Warn(ctx, "Failed to extend lock for key '%s': %v", key, err)
+				continue
+			}
+			if !extended {
+				m.logger.Warn(ctx, "Lock for key '%s' with token %d expired while held", key, token)
+				expire(lock.ErrLockExpired)
+				return
+			}
+		}
+	}
+}
+
+func (m *RedisLockManager) run(ctx context.Context, key string, fn func(context.Context) (any, error)) (any, error) {
+	token, err := m.acquireLock(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	lockCtx, cancel := context.WithCancelCause(lock.WithFencingToken(ctx, key, token))
+	watchdogDone := make(chan struct{})
+	go func() {
+		defer close(watchdogDone)
+		m.watchdog(lockCtx, key, token, cancel)
+	}()
+
+	result, err := fn(lockCtx)
+
+	expired := errors.Is(context.Cause(lockCtx), lock.ErrLockExpired)
+	cancel(nil)
+	<-watchdogDone
+
+	if expired {
+		return result, errors.Join(fmt.Errorf("lock for key '%s' with token %d: %w", key, token, lock.ErrLockExpired), err)
+	}
+
+	if releaseErr := m.releaseLock(ctx, key, token); releaseErr != nil {
+		m.logger.Error(ctx, fmt.Sprintf("Failed to release lock for key '%s'", key), releaseErr)
+	}
+
+	return result, err
+}
+
 func (m *RedisLockManager) WithLock(
 	ctx context.Context,
 	key string,
 	fn func(ctx context.Context) (any, error),
 ) (any, error) {
 	return trace.Trace(ctx, trace.NameConfig("RedisLockManager", "WithLock"), func(ctx context.Context) (any, error) {
-		lockValue, err := m.acquireLock(ctx, key)
-		if err != nil {
-			return nil, err
-		}
-
-		defer func() {
-			if releaseErr := m.releaseLock(ctx, key, lockValue); releaseErr != nil {
-				m.logger.Error(ctx, fmt.Sprintf("Failed to release lock for key '%s'", key), releaseErr)
-			}
-		}()
-
-		return fn(ctx)
+		return m.run(ctx, key, fn)
 	})
 }
 
@@ -129,18 +210,9 @@ func (m *RedisLockManager) ExecuteWithLock(
 	fn func(context.Context) error,
 ) error {
 	_, err := trace.Trace(ctx, trace.NameConfig("RedisLockManager", "ExecuteWithLock"), func(ctx context.Context) (any, error) {
-		lockValue, err := m.acquireLock(ctx, key)
-		if err != nil {
-			return nil, err
-		}
-
-		defer func() {
-			if releaseErr := m.releaseLock(ctx, key, lockValue); releaseErr != nil {
-				m.logger.Error(ctx, fmt.Sprintf("Failed to release lock for key '%s'", key), releaseErr)
-			}
-		}()
-
-		return nil, fn(ctx)
+		return m.run(ctx, key, func(ctx context.Context) (any, error) {
+			return nil, fn(ctx)
+		})
 	})
 
 	return err
@@ -0,0 +1,119 @@
+package lock
+
+import (
+	"context"
+	"errors"
+	"planning-poker/internal/application/lock"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/mock/gomock"
+)
+
+func evalResult(val int64) *redis.Cmd {
+	cmd := redis.NewCmd(context.Background())
+	cmd.SetVal(val)
+	return cmd
+}
+
+func TestRedisLockManager_ExecuteWithLock_FencingToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := NewMockRedisLockClient(ctrl)
+	manager := NewRedisLockManager(client)
+
+	client.EXPECT().
+		Eval(gomock.Any(), acquireScript, []string{"planning-poker:lock:room-1", "planning-poker:lock-token:room-1"}, gomock.Any()).
+		Return(evalResult(42))
+	client.EXPECT().
+		Eval(gomock.Any(), releaseScript, []string{"planning-poker:lock:room-1"}, int64(42)).
+		Return(evalResult(1))
+
+	err := manager.ExecuteWithLock(context.Background(), "room-1", func(ctx context.Context) error {
+		token, ok := lock.FencingToken(ctx, "room-1")
+		if !ok || token != 42 {
+			t.Errorf("expected fencing token 42, got %d (found: %v)", token, ok)
+		}
+		if _, ok := lock.FencingToken(ctx, "room-2"); ok {
+			t.Error("fencing token must not be visible for other keys")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ExecuteWithLock returned error: %v", err)
+	}
+}
+
+func TestRedisLockManager_ExecuteWithLock_RetriesWhileHeld(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := NewMockRedisLockClient(ctrl)
+	manager := NewRedisLockManager(client)
+	manager.SetRetry(5, time.Millisecond)
+
+	gomock.InOrder(
+		client.EXPECT().Eval(gomock.Any(), acquireScript, gomock.Any(), gomock.Any()).Return(evalResult(0)).Times(2),
+		client.EXPECT().Eval(gomock.Any(), acquireScript, gomock.Any(), gomock.Any()).Return(evalResult(3)),
+		client.EXPECT().Eval(gomock.Any(), releaseScript, gomock.Any(), int64(3)).Return(evalResult(1)),
+	)
+
+	calls := 0
+	err := manager.ExecuteWithLock(context.Background(), "room-1", func(ctx context.Context) error {
+		calls++
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ExecuteWithLock returned error: %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("expected 1 call, got %d", calls)
+	}
+}
+
+func TestRedisLockManager_ExecuteWithLock_ExtendsLease(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := NewMockRedisLockClient(ctrl)
+	manager := NewRedisLockManager(client)
+	manager.SetLockTimeout(30 * time.Millisecond)
+
+	client.EXPECT().Eval(gomock.Any(), acquireScript, gomock.Any(), gomock.Any()).Return(evalResult(1))
+	client.EXPECT().
+		Eval(gomock.Any(), extendScript, []string{"planning-poker:lock:room-1"}, int64(1), int64(30)).
+		Return(evalResult(1)).
+		MinTimes(1)
+	client.EXPECT().Eval(gomock.Any(), releaseScript, gomock.Any(), int64(1)).Return(evalResult(1))
+
+	err := manager.ExecuteWithLock(context.Background(), "room-1", func(ctx context.Context) error {
+		time.Sleep(50 * time.Millisecond)
+		return ctx.Err()
+	})
+	if err != nil {
+		t.Fatalf("ExecuteWithLock returned error: %v", err)
+	}
+}
+
+func TestRedisLockManager_ExecuteWithLock_LeaseLost(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := NewMockRedisLockClient(ctrl)
+	manager := NewRedisLockManager(client)
+	manager.SetLockTimeout(30 * time.Millisecond)
+
+	client.EXPECT().Eval(gomock.Any(), acquireScript, gomock.Any(), gomock.Any()).Return(evalResult(1))
+	client.EXPECT().Eval(gomock.Any(), extendScript, gomock.Any(), gomock.Any()).Return(evalResult(0))
+	// the lock is not released, it belongs to someone else by now
+
+	err := manager.ExecuteWithLock(context.Background(), "room-1", func(ctx context.Context) error {
+		select {
+		case <-ctx.Done():
+			if !errors.Is(context.Cause(ctx), lock.ErrLockExpired) {
+				t.Errorf("expected cause ErrLockExpired, got %v", context.Cause(ctx))
+			}
+			return ctx.Err()
+		case <-time.After(time.Second):
+			t.Error("context was not canceled after the lease was lost")
+			return nil
+		}
+	})
+	if !errors.Is(err, lock.ErrLockExpired) {
+		t.Fatalf("expected ErrLockExpired, got %v", err)
+	}
+}
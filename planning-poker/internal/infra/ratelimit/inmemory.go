@@ -0,0 +1,68 @@
+package ratelimit
+
+import (
+	"context"
+	"planning-poker/internal/application/ratelimit"
+	"planning-poker/internal/application/timer"
+	"sync"
+	"time"
+)
+
+// sweepEvery is how many events are let through between removals of the
+// buckets that are full again
+const sweepEvery = 1024
+
+// InMemoryLimiter keeps the buckets of a single instance. Like the Redis
+// one, it implements the token bucket as GCRA: a bucket is the time at which
+// it will be full again.
+type InMemoryLimiter struct {
+	clock   timer.Clock
+	mu      sync.Mutex
+	buckets map[string]time.Time
+	events  int
+}
+
+var _ ratelimit.Limiter = (*InMemoryLimiter)(nil)
+
+func NewInMemoryLimiter(clock timer.Clock) *InMemoryLimiter {
+	return &InMemoryLimiter{
+		clock:   clock,
+		buckets: make(map[string]time.Time),
+	}
+}
+
+func (l *InMemoryLimiter) Allow(_ context.Context, key string, limit ratelimit.Limit) (ratelimit.Decision, error) {
+	if !limit.Enabled() {
+		return ratelimit.Decision{Allowed: true}, nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.clock.Now()
+	full := l.buckets[key]
+	if full.Before(now) {
+		full = now
+	}
+
+	interval := limit.Interval()
+	next := full.Add(interval)
+	if wait := next.Sub(now) - interval*time.Duration(limit.Burst); wait > 0 {
+		return ratelimit.Decision{RetryAfter: wait}, nil
+	}
+
+	l.buckets[key] = next
+	l.events++
+	if l.events%sweepEvery == 0 {
+		l.sweep(now)
+	}
+	return ratelimit.Decision{Allowed: true}, nil
+}
+
+func (l *InMemoryLimiter) sweep(now time.Time) {
+	for key, full := range l.buckets {
+		if !full.After(now) {
+			delete(l.buckets, key)
+		}
+	}
+}
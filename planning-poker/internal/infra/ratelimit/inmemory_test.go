@@ -0,0 +1,74 @@
+package ratelimit
+
+import (
+	"context"
+	"planning-poker/internal/application/ratelimit"
+	"testing"
+	"time"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func TestInMemoryLimiter_Allow_BurstThenRate(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)}
+	limiter := NewInMemoryLimiter(clock)
+	limit := ratelimit.Limit{Rate: 60, Burst: 3}
+	ctx := context.Background()
+
+	for i := range 3 {
+		if decision, _ := limiter.Allow(ctx, "client-1", limit); !decision.Allowed {
+			t.Fatalf("event %d of the burst was rejected", i+1)
+		}
+	}
+
+	decision, err := limiter.Allow(ctx, "client-1", limit)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if decision.Allowed || decision.RetryAfter != time.Second {
+		t.Fatalf("expected a rejection with a retry after 1s, got %+v", decision)
+	}
+
+	if decision, _ := limiter.Allow(ctx, "client-2", limit); !decision.Allowed {
+		t.Error("buckets must not be shared between keys")
+	}
+
+	clock.now = clock.now.Add(time.Second)
+	if decision, _ := limiter.Allow(ctx, "client-1", limit); !decision.Allowed {
+		t.Error("expected a token to be refilled after the interval")
+	}
+	if decision, _ := limiter.Allow(ctx, "client-1", limit); decision.Allowed {
+		t.Error("expected the refilled token to be the only one")
+	}
+}
+
+func TestInMemoryLimiter_Allow_DisabledLimit(t *testing.T) {
+	limiter := NewInMemoryLimiter(&fakeClock{now: time.Now()})
+
+	for range 10 {
+		if decision, _ := limiter.Allow(context.Background(), "client-1", ratelimit.Limit{}); !decision.Allowed {
+			t.Fatal("a disabled limit must let everything through")
+		}
+	}
+}
+
+func TestInMemoryLimiter_Sweep_ForgetsFullBuckets(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)}
+	limiter := NewInMemoryLimiter(clock)
+	limit := ratelimit.Limit{Rate: 60, Burst: 5}
+
+	_, _ = limiter.Allow(context.Background(), "client-1", limit)
+	_, _ = limiter.Allow(context.Background(), "client-2", limit)
+	clock.now = clock.now.Add(time.Minute)
+	limiter.sweep(clock.now)
+
+	if len(limiter.buckets) != 0 {
+		t.Errorf("expected full buckets to be removed, %d left", len(limiter.buckets))
+	}
+}
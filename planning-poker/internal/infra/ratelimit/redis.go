@@ -0,0 +1,67 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"planning-poker/internal/application/ratelimit"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+type RedisLimiterClient interface {
+	Eval(ctx context.Context, script string, keys []string, args ...any) *redis.Cmd
+}
+
+const limitKeyPrefix = "planning-poker:ratelimit:"
+
+// allowScript implements the token bucket as GCRA on the clock of Redis, so
+// that instances agree on time. The key holds the time, in microseconds, at
+// which the bucket is full again and expires then. It returns 0 when a token
+// was taken, otherwise the microseconds until one is available.
+const allowScript = `
+local time = redis.call("time")
+local now = tonumber(time[1]) * 1000000 + tonumber(time[2])
+local interval = tonumber(ARGV[1])
+local tolerance = interval * tonumber(ARGV[2])
+
+local full = tonumber(redis.call("get", KEYS[1]) or now)
+if full < now then
+	full = now
+end
+
+local next = full + interval
+local wait = next - now - tolerance
+if wait > 0 then
+	return math.ceil(wait)
+end
+
+redis.call("set", KEYS[1], next, "px", math.ceil((next - now) / 1000))
+return 0
+`
+
+// RedisLimiter shares the buckets between every instance.
+type RedisLimiter struct {
+	client RedisLimiterClient
+}
+
+var _ ratelimit.Limiter = (*RedisLimiter)(nil)
+
+func NewRedisLimiter(client RedisLimiterClient) *RedisLimiter {
+	return &RedisLimiter{client: client}
+}
+
+func (l *RedisLimiter) Allow(ctx context.Context, key string, limit ratelimit.Limit) (ratelimit.Decision, error) {
+	if !limit.Enabled() {
+		return ratelimit.Decision{Allowed: true}, nil
+	}
+
+	wait, err := l.client.Eval(ctx, allowScript, []string{limitKeyPrefix + key}, limit.Interval().Microseconds(), limit.Burst).Int64()
+	if err != nil {
+		return ratelimit.Decision{}, fmt.Errorf("failed to take a token for key '%s': %w", key, err)
+	}
+	if wait > 0 {
+		return ratelimit.Decision{RetryAfter: time.Duration(wait) * time.Microsecond}, nil
+	}
+	return ratelimit.Decision{Allowed: true}, nil
+}
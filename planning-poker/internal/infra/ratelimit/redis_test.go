@@ -0,0 +1,69 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"planning-poker/internal/application/ratelimit"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/mock/gomock"
+)
+
+func evalResult(val int64) *redis.Cmd {
+	cmd := redis.NewCmd(context.Background())
+	cmd.SetVal(val)
+	return cmd
+}
+
+func TestRedisLimiter_Allow(t *testing.T) {
+	limit := ratelimit.Limit{Rate: 120, Burst: 10}
+
+	tests := []struct {
+		name     string
+		result   *redis.Cmd
+		expected ratelimit.Decision
+		wantErr  bool
+	}{
+		{name: "token taken", result: evalResult(0), expected: ratelimit.Decision{Allowed: true}},
+		{name: "throttled", result: evalResult(250000), expected: ratelimit.Decision{RetryAfter: 250 * time.Millisecond}},
+		{name: "redis error", result: redis.NewCmdResult(nil, errors.New("connection refused")), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			client := NewMockRedisLimiterClient(ctrl)
+			limiter := NewRedisLimiter(client)
+
+			client.EXPECT().
+				Eval(gomock.Any(), allowScript, []string{"planning-poker:ratelimit:room:room-1"}, int64(500000), 10).
+				Return(tt.result)
+
+			decision, err := limiter.Allow(context.Background(), "room:room-1", limit)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if decision != tt.expected {
+				t.Errorf("expected %+v, got %+v", tt.expected, decision)
+			}
+		})
+	}
+}
+
+func TestRedisLimiter_Allow_DisabledLimitSkipsRedis(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	limiter := NewRedisLimiter(NewMockRedisLimiterClient(ctrl))
+
+	decision, err := limiter.Allow(context.Background(), "room:room-1", ratelimit.Limit{Rate: 0, Burst: 10})
+	if err != nil || !decision.Allowed {
+		t.Errorf("expected the event to be allowed, got %+v (%v)", decision, err)
+	}
+}
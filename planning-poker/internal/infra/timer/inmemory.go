@@ -0,0 +1,55 @@
+package timer
+
+import (
+	"context"
+	"planning-poker/internal/application/timer"
+	"sort"
+	"sync"
+	"time"
+)
+
+type InMemoryDeadlineStore struct {
+	deadlines map[string]time.Time
+	mu        sync.Mutex
+}
+
+var _ timer.DeadlineStore = (*InMemoryDeadlineStore)(nil)
+
+func NewInMemoryDeadlineStore() *InMemoryDeadlineStore {
+	return &InMemoryDeadlineStore{
+		deadlines: make(map[string]time.Time),
+	}
+}
+
+func (s *InMemoryDeadlineStore) Schedule(_ context.Context, roomID string, deadline time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.deadlines[roomID] = deadline
+	return nil
+}
+
+func (s *InMemoryDeadlineStore) Cancel(_ context.Context, roomID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.deadlines, roomID)
+	return nil
+}
+
+func (s *InMemoryDeadlineStore) Due(_ context.Context, now time.Time) ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	due := make([]string, 0)
+	for roomID, deadline := range s.deadlines {
+		if !deadline.After(now) {
+			due = append(due, roomID)
+		}
+	}
+	sort.Slice(due, func(i, j int) bool {
+		return s.deadlines[due[i]].Before(s.deadlines[due[j]])
+	})
+
+	return due, nil
+}
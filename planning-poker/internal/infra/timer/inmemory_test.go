@@ -0,0 +1,34 @@
+package timer
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestInMemoryDeadlineStore(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	store := NewInMemoryDeadlineStore()
+
+	_ = store.Schedule(ctx, "room2", now.Add(2*time.Second))
+	_ = store.Schedule(ctx, "room1", now.Add(time.Second))
+	_ = store.Schedule(ctx, "room3", now.Add(time.Minute))
+
+	due, err := store.Due(ctx, now.Add(2*time.Second))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !slices.Equal(due, []string{"room1", "room2"}) {
+		t.Errorf("expected [room1 room2] due, got %v", due)
+	}
+
+	_ = store.Cancel(ctx, "room1")
+	_ = store.Schedule(ctx, "room2", now.Add(time.Hour))
+
+	due, _ = store.Due(ctx, now.Add(2*time.Second))
+	if len(due) != 0 {
+		t.Errorf("expected nothing due after cancel and reschedule, got %v", due)
+	}
+}
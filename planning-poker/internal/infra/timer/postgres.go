@@ -0,0 +1,66 @@
+package timer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"planning-poker/internal/application/timer"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+type PostgresDeadlineStoreClient interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+const (
+	scheduleDeadlineSQL = `
+INSERT INTO voting_deadlines (room_id, deadline) VALUES ($1, $2)
+ON CONFLICT (room_id) DO UPDATE SET deadline = EXCLUDED.deadline`
+
+	dueDeadlinesSQL = `
+SELECT COALESCE(json_agg(room_id ORDER BY deadline), '[]')
+FROM voting_deadlines WHERE deadline <= $1`
+)
+
+// PostgresDeadlineStore keeps the deadlines in a table shared by every
+// instance, for deployments that store rooms in Postgres.
+type PostgresDeadlineStore struct {
+	client PostgresDeadlineStoreClient
+}
+
+var _ timer.DeadlineStore = (*PostgresDeadlineStore)(nil)
+
+func NewPostgresDeadlineStore(client PostgresDeadlineStoreClient) *PostgresDeadlineStore {
+	return &PostgresDeadlineStore{client: client}
+}
+
+func (s *PostgresDeadlineStore) Schedule(ctx context.Context, roomID string, deadline time.Time) error {
+	if _, err := s.client.Exec(ctx, scheduleDeadlineSQL, roomID, deadline); err != nil {
+		return fmt.Errorf("failed to schedule voting deadline: %w", err)
+	}
+	return nil
+}
+
+func (s *PostgresDeadlineStore) Cancel(ctx context.Context, roomID string) error {
+	if _, err := s.client.Exec(ctx, "DELETE FROM voting_deadlines WHERE room_id = $1", roomID); err != nil {
+		return fmt.Errorf("failed to cancel voting deadline: %w", err)
+	}
+	return nil
+}
+
+func (s *PostgresDeadlineStore) Due(ctx context.Context, now time.Time) ([]string, error) {
+	var data []byte
+	if err := s.client.QueryRow(ctx, dueDeadlinesSQL, now).Scan(&data); err != nil {
+		return nil, fmt.Errorf("failed to load due voting deadlines: %w", err)
+	}
+
+	var roomIDs []string
+	if err := json.Unmarshal(data, &roomIDs); err != nil {
+		return nil, fmt.Errorf("failed to load due voting deadlines: %w", err)
+	}
+	return roomIDs, nil
+}
@@ -0,0 +1,51 @@
+package timer
+
+import (
+	"context"
+	"fmt"
+	"planning-poker/internal/application/timer"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const deadlinesKey = "planning-poker:voting-deadlines"
+
+// RedisDeadlineStore keeps the deadlines in a sorted set scored by their unix
+// time in milliseconds, shared by every instance.
+type RedisDeadlineStore struct {
+	client *redis.Client
+}
+
+var _ timer.DeadlineStore = (*RedisDeadlineStore)(nil)
+
+func NewRedisDeadlineStore(client *redis.Client) *RedisDeadlineStore {
+	return &RedisDeadlineStore{client: client}
+}
+
+func (s *RedisDeadlineStore) Schedule(ctx context.Context, roomID string, deadline time.Time) error {
+	err := s.client.ZAdd(ctx, deadlinesKey, redis.Z{Score: float64(deadline.UnixMilli()), Member: roomID}).Err()
+	if err != nil {
+		return fmt.Errorf("failed to schedule voting deadline: %w", err)
+	}
+	return nil
+}
+
+func (s *RedisDeadlineStore) Cancel(ctx context.Context, roomID string) error {
+	if err := s.client.ZRem(ctx, deadlinesKey, roomID).Err(); err != nil {
+		return fmt.Errorf("failed to cancel voting deadline: %w", err)
+	}
+	return nil
+}
+
+func (s *RedisDeadlineStore) Due(ctx context.Context, now time.Time) ([]string, error) {
+	roomIDs, err := s.client.ZRangeByScore(ctx, deadlinesKey, &redis.ZRangeBy{
+		Min: "-inf",
+		Max: strconv.FormatInt(now.UnixMilli(), 10),
+	}).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load due voting deadlines: %w", err)
+	}
+	return roomIDs, nil
+}
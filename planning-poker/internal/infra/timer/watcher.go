@@ -0,0 +1,75 @@
+package timer
+
+import (
+	"context"
+	"fmt"
+	"planning-poker/internal/application/planningpoker/usecase"
+	"planning-poker/internal/application/timer"
+	"time"
+
+	"github.com/bruno303/go-toolkit/pkg/log"
+)
+
+const defaultPollInterval = time.Second
+
+// Watcher polls the deadline store and expires the voting timers that are
+// due. Every instance runs one; the expire use case takes care of revealing
+// each room only once.
+type Watcher struct {
+	deadlines    timer.DeadlineStore
+	expire       usecase.UseCase[usecase.ExpireVotingTimerCommand]
+	clock        timer.Clock
+	pollInterval time.Duration
+	logger       log.Logger
+}
+
+func NewWatcher(
+	deadlines timer.DeadlineStore,
+	expire usecase.UseCase[usecase.ExpireVotingTimerCommand],
+	clock timer.Clock,
+	pollInterval time.Duration,
+) *Watcher {
+	if pollInterval <= 0 {
+		pollInterval = defaultPollInterval
+	}
+
+	return &Watcher{
+		deadlines:    deadlines,
+		expire:       expire,
+		clock:        clock,
+		pollInterval: pollInterval,
+		logger:       log.NewLogger("timer.watcher"),
+	}
+}
+
+// Run blocks until the context is cancelled.
+func (w *Watcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.pollInterval)
+	defer ticker.Stop()
+
+	w.logger.Info(ctx, "Voting timer watcher started, polling every %s", w.pollInterval)
+	for {
+		select {
+		case <-ctx.Done():
+			w.logger.Info(ctx, "Voting timer watcher stopped")
+			return
+		case <-ticker.C:
+			w.Tick(ctx)
+		}
+	}
+}
+
+// Tick expires every deadline that is due at the current time.
+func (w *Watcher) Tick(ctx context.Context) {
+	roomIDs, err := w.deadlines.Due(ctx, w.clock.Now())
+	if err != nil {
+		w.logger.Error(ctx, "Error loading due voting timers", err)
+		return
+	}
+
+	for _, roomID := range roomIDs {
+		if err := w.expire.Execute(ctx, usecase.ExpireVotingTimerCommand{RoomID: roomID}); err != nil {
+			w.logger.Error(ctx, fmt.Sprintf("Error expiring voting timer of room %s", roomID), err)
+		}
+	}
+}
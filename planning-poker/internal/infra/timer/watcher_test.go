@@ -0,0 +1,122 @@
+package timer
+
+import (
+	"context"
+	"planning-poker/internal/application/planningpoker/usecase"
+	"planning-poker/internal/application/webhook"
+	"planning-poker/internal/infra/boundaries/hub/inmemory"
+	infralock "planning-poker/internal/infra/lock"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type (
+	fakeClock struct {
+		mu  sync.Mutex
+		now time.Time
+	}
+	countingHub struct {
+		*inmemory.InMemoryHub
+		broadcasts atomic.Int32
+	}
+)
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func (h *countingHub) BroadcastToRoom(ctx context.Context, roomID string, message any) error {
+	h.broadcasts.Add(1)
+	return h.InMemoryHub.BroadcastToRoom(ctx, roomID, message)
+}
+
+func TestWatcher_RevealsRoomWhenDeadlinePasses(t *testing.T) {
+	ctx := context.Background()
+	clock := &fakeClock{now: time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)}
+	hub := &countingHub{InMemoryHub: inmemory.NewHub()}
+	lockManager := infralock.NewInMemoryLockManager()
+	deadlines := NewInMemoryDeadlineStore()
+
+	room, _ := hub.NewRoom(ctx)
+	room.NewClient("owner")
+	room.NewClient("client1")
+	_ = hub.SaveRoom(ctx, room)
+
+	start := usecase.NewStartVotingTimerUseCase(hub, lockManager, deadlines, clock)
+	if err := start.Execute(ctx, usecase.StartVotingTimerCommand{
+		RoomID:   room.ID,
+		SenderID: "owner",
+		Duration: 30 * time.Second,
+	}); err != nil {
+		t.Fatalf("unexpected error starting timer: %v", err)
+	}
+
+	expire := usecase.NewExpireVotingTimerUseCase(hub, lockManager, deadlines, clock, webhook.Discard)
+	watcher := NewWatcher(deadlines, expire, clock, time.Second)
+
+	clock.Advance(29 * time.Second)
+	watcher.Tick(ctx)
+	if room, _ := hub.LoadRoom(ctx, room.ID); room.Reveal {
+		t.Fatal("expected room to stay hidden before the deadline")
+	}
+
+	clock.Advance(time.Second)
+	watcher.Tick(ctx)
+	if room, _ := hub.LoadRoom(ctx, room.ID); !room.Reveal {
+		t.Fatal("expected room to be revealed at the deadline")
+	}
+	if due, _ := deadlines.Due(ctx, clock.Now()); len(due) != 0 {
+		t.Errorf("expected deadline to be removed, got %v", due)
+	}
+}
+
+func TestWatcher_ConcurrentInstancesRevealOnce(t *testing.T) {
+	ctx := context.Background()
+	clock := &fakeClock{now: time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)}
+	hub := &countingHub{InMemoryHub: inmemory.NewHub()}
+	lockManager := infralock.NewInMemoryLockManager()
+	deadlines := NewInMemoryDeadlineStore()
+
+	room, _ := hub.NewRoom(ctx)
+	room.NewClient("owner")
+	_ = hub.SaveRoom(ctx, room)
+
+	start := usecase.NewStartVotingTimerUseCase(hub, lockManager, deadlines, clock)
+	_ = start.Execute(ctx, usecase.StartVotingTimerCommand{RoomID: room.ID, SenderID: "owner", Duration: 10 * time.Second})
+	hub.broadcasts.Store(0)
+
+	clock.Advance(10 * time.Second)
+
+	// every watcher sees the same due deadline before any of them expires it
+	due, _ := deadlines.Due(ctx, clock.Now())
+	expire := usecase.NewExpireVotingTimerUseCase(hub, lockManager, deadlines, clock, webhook.Discard)
+
+	var wg sync.WaitGroup
+	for range 5 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for _, roomID := range due {
+				_ = expire.Execute(ctx, usecase.ExpireVotingTimerCommand{RoomID: roomID})
+			}
+		}()
+	}
+	wg.Wait()
+
+	if room, _ := hub.LoadRoom(ctx, room.ID); !room.Reveal {
+		t.Fatal("expected room to be revealed")
+	}
+	if got := hub.broadcasts.Load(); got != 1 {
+		t.Errorf("expected a single reveal broadcast, got %d", got)
+	}
+}
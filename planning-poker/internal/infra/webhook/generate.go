@@ -0,0 +1,4 @@
+package webhook
+
+//go:generate go tool mockgen -destination mocks.go -typed -package webhook . RedisStoreClient,PostgresStoreClient
+//go:generate go tool mockgen -destination pgxmocks.go -typed -package webhook github.com/jackc/pgx/v5 Row
@@ -0,0 +1,78 @@
+package webhook
+
+import (
+	"context"
+	"planning-poker/internal/application/webhook"
+	"planning-poker/internal/domain"
+	"slices"
+	"sync"
+)
+
+// InMemoryStore keeps the subscriptions and the latest dead letters of a
+// single instance.
+type InMemoryStore struct {
+	maxDeadLetters int
+	mu             sync.RWMutex
+	subscriptions  []webhook.Subscription
+	deadLetters    []webhook.DeadLetter
+}
+
+var _ webhook.Store = (*InMemoryStore)(nil)
+
+func NewInMemoryStore(maxDeadLetters int) *InMemoryStore {
+	return &InMemoryStore{maxDeadLetters: maxDeadLetters}
+}
+
+func (s *InMemoryStore) AddSubscription(_ context.Context, subscription webhook.Subscription) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.subscriptions = append(s.subscriptions, subscription)
+	return nil
+}
+
+func (s *InMemoryStore) RemoveSubscription(_ context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	index := slices.IndexFunc(s.subscriptions, func(subscription webhook.Subscription) bool {
+		return subscription.ID == id
+	})
+	if index < 0 {
+		return domain.ErrWebhookNotFound
+	}
+	s.subscriptions = slices.Delete(s.subscriptions, index, index+1)
+	return nil
+}
+
+func (s *InMemoryStore) Subscriptions(context.Context) ([]webhook.Subscription, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return slices.Clone(s.subscriptions), nil
+}
+
+func (s *InMemoryStore) AddDeadLetter(_ context.Context, deadLetter webhook.DeadLetter) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.deadLetters = append(s.deadLetters, deadLetter)
+	if s.maxDeadLetters > 0 && len(s.deadLetters) > s.maxDeadLetters {
+		s.deadLetters = s.deadLetters[len(s.deadLetters)-s.maxDeadLetters:]
+	}
+	return nil
+}
+
+func (s *InMemoryStore) DeadLetters(_ context.Context, limit int) ([]webhook.DeadLetter, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var deadLetters []webhook.DeadLetter
+	for i := len(s.deadLetters) - 1; i >= 0; i-- {
+		if limit > 0 && len(deadLetters) == limit {
+			break
+		}
+		deadLetters = append(deadLetters, s.deadLetters[i])
+	}
+	return deadLetters, nil
+}
@@ -0,0 +1,47 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"planning-poker/internal/application/webhook"
+	"planning-poker/internal/domain"
+	"testing"
+)
+
+func TestInMemoryStore_Subscriptions(t *testing.T) {
+	ctx := context.Background()
+	store := NewInMemoryStore(10)
+
+	_ = store.AddSubscription(ctx, webhook.Subscription{ID: "first"})
+	_ = store.AddSubscription(ctx, webhook.Subscription{ID: "second"})
+	if err := store.RemoveSubscription(ctx, "first"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := store.RemoveSubscription(ctx, "first"); !errors.Is(err, domain.ErrWebhookNotFound) {
+		t.Errorf("expected %v, got %v", domain.ErrWebhookNotFound, err)
+	}
+
+	subscriptions, _ := store.Subscriptions(ctx)
+	if len(subscriptions) != 1 || subscriptions[0].ID != "second" {
+		t.Errorf("unexpected subscriptions %+v", subscriptions)
+	}
+}
+
+func TestInMemoryStore_DeadLetters_KeepsTheNewest(t *testing.T) {
+	ctx := context.Background()
+	store := NewInMemoryStore(2)
+
+	for _, id := range []string{"1", "2", "3"} {
+		_ = store.AddDeadLetter(ctx, webhook.DeadLetter{SubscriptionID: id})
+	}
+
+	deadLetters, _ := store.DeadLetters(ctx, 10)
+	if len(deadLetters) != 2 || deadLetters[0].SubscriptionID != "3" || deadLetters[1].SubscriptionID != "2" {
+		t.Errorf("unexpected dead letters %+v", deadLetters)
+	}
+
+	deadLetters, _ = store.DeadLetters(ctx, 1)
+	if len(deadLetters) != 1 || deadLetters[0].SubscriptionID != "3" {
+		t.Errorf("unexpected dead letters %+v", deadLetters)
+	}
+}
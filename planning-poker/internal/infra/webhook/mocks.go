@@ -0,0 +1,398 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: planning-poker/internal/infra/webhook (interfaces: RedisStoreClient,PostgresStoreClient)
+//
+// Generated by this command:
+//
+//	mockgen -destination mocks.go -typed -package webhook . RedisStoreClient,PostgresStoreClient
+//
+
+// Package webhook is a generated GoMock package.
+package webhook
+
+import (
+	context "context"
+	reflect "reflect"
+
+	pgx "github.com/jackc/pgx/v5"
+	pgconn "github.com/jackc/pgx/v5/pgconn"
+	redis "github.com/redis/go-redis/v9"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRedisStoreClient is a mock of RedisStoreClient interface.
+type MockRedisStoreClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockRedisStoreClientMockRecorder
+	isgomock struct{}
+}
+
+// MockRedisStoreClientMockRecorder is the mock recorder for MockRedisStoreClient.
+type MockRedisStoreClientMockRecorder struct {
+	mock *MockRedisStoreClient
+}
+
+// NewMockRedisStoreClient creates a new mock instance.
+func NewMockRedisStoreClient(ctrl *gomock.Controller) *MockRedisStoreClient {
+	mock := &MockRedisStoreClient{ctrl: ctrl}
+	mock.recorder = &MockRedisStoreClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRedisStoreClient) EXPECT() *MockRedisStoreClientMockRecorder {
+	return m.recorder
+}
+
+// HDel mocks base method.
+func (m *MockRedisStoreClient) HDel(ctx context.Context, key string, fields ...string) *redis.IntCmd {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, key}
+	for _, a := range fields {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "HDel", varargs...)
+	ret0, _ := ret[0].(*redis.IntCmd)
+	return ret0
+}
+
+// HDel indicates an expected call of HDel.
+func (mr *MockRedisStoreClientMockRecorder) HDel(ctx, key any, fields ...any) *MockRedisStoreClientHDelCall {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, key}, fields...)
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HDel", reflect.TypeOf((*MockRedisStoreClient)(nil).HDel), varargs...)
+	return &MockRedisStoreClientHDelCall{Call: call}
+}
+
+// MockRedisStoreClientHDelCall wrap *gomock.Call
+type MockRedisStoreClientHDelCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockRedisStoreClientHDelCall) Return(arg0 *redis.IntCmd) *MockRedisStoreClientHDelCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockRedisStoreClientHDelCall) Do(f func(context.Context, string, ...string) *redis.IntCmd) *MockRedisStoreClientHDelCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockRedisStoreClientHDelCall) DoAndReturn(f func(context.Context, string, ...string) *redis.IntCmd) *MockRedisStoreClientHDelCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// HGetAll mocks base method.
+func (m *MockRedisStoreClient) HGetAll(ctx context.Context, key string) *redis.MapStringStringCmd {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HGetAll", ctx, key)
+	ret0, _ := ret[0].(*redis.MapStringStringCmd)
+	return ret0
+}
+
+// HGetAll indicates an expected call of HGetAll.
+func (mr *MockRedisStoreClientMockRecorder) HGetAll(ctx, key any) *MockRedisStoreClientHGetAllCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HGetAll", reflect.TypeOf((*MockRedisStoreClient)(nil).HGetAll), ctx, key)
+	return &MockRedisStoreClientHGetAllCall{Call: call}
+}
+
+// MockRedisStoreClientHGetAllCall wrap *gomock.Call
+type MockRedisStoreClientHGetAllCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockRedisStoreClientHGetAllCall) Return(arg0 *redis.MapStringStringCmd) *MockRedisStoreClientHGetAllCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockRedisStoreClientHGetAllCall) Do(f func(context.Context, string) *redis.MapStringStringCmd) *MockRedisStoreClientHGetAllCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockRedisStoreClientHGetAllCall) DoAndReturn(f func(context.Context, string) *redis.MapStringStringCmd) *MockRedisStoreClientHGetAllCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// HSet mocks base method.
+func (m *MockRedisStoreClient) HSet(ctx context.Context, key string, values ...any) *redis.IntCmd {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, key}
+	for _, a := range values {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "HSet", varargs...)
+	ret0, _ := ret[0].(*redis.IntCmd)
+	return ret0
+}
+
+// HSet indicates an expected call of HSet.
+func (mr *MockRedisStoreClientMockRecorder) HSet(ctx, key any, values ...any) *MockRedisStoreClientHSetCall {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, key}, values...)
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HSet", reflect.TypeOf((*MockRedisStoreClient)(nil).HSet), varargs...)
+	return &MockRedisStoreClientHSetCall{Call: call}
+}
+
+// MockRedisStoreClientHSetCall wrap *gomock.Call
+type MockRedisStoreClientHSetCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockRedisStoreClientHSetCall) Return(arg0 *redis.IntCmd) *MockRedisStoreClientHSetCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockRedisStoreClientHSetCall) Do(f func(context.Context, string, ...any) *redis.IntCmd) *MockRedisStoreClientHSetCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockRedisStoreClientHSetCall) DoAndReturn(f func(context.Context, string, ...any) *redis.IntCmd) *MockRedisStoreClientHSetCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// LPush mocks base method.
+func (m *MockRedisStoreClient) LPush(ctx context.Context, key string, values ...any) *redis.IntCmd {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, key}
+	for _, a := range values {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "LPush", varargs...)
+	ret0, _ := ret[0].(*redis.IntCmd)
+	return ret0
+}
+
+// LPush indicates an expected call of LPush.
+func (mr *MockRedisStoreClientMockRecorder) LPush(ctx, key any, values ...any) *MockRedisStoreClientLPushCall {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, key}, values...)
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LPush", reflect.TypeOf((*MockRedisStoreClient)(nil).LPush), varargs...)
+	return &MockRedisStoreClientLPushCall{Call: call}
+}
+
+// MockRedisStoreClientLPushCall wrap *gomock.Call
+type MockRedisStoreClientLPushCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockRedisStoreClientLPushCall) Return(arg0 *redis.IntCmd) *MockRedisStoreClientLPushCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockRedisStoreClientLPushCall) Do(f func(context.Context, string, ...any) *redis.IntCmd) *MockRedisStoreClientLPushCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockRedisStoreClientLPushCall) DoAndReturn(f func(context.Context, string, ...any) *redis.IntCmd) *MockRedisStoreClientLPushCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// LRange mocks base method.
+func (m *MockRedisStoreClient) LRange(ctx context.Context, key string, start, stop int64) *redis.StringSliceCmd {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LRange", ctx, key, start, stop)
+	ret0, _ := ret[0].(*redis.StringSliceCmd)
+	return ret0
+}
+
+// LRange indicates an expected call of LRange.
+func (mr *MockRedisStoreClientMockRecorder) LRange(ctx, key, start, stop any) *MockRedisStoreClientLRangeCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LRange", reflect.TypeOf((*MockRedisStoreClient)(nil).LRange), ctx, key, start, stop)
+	return &MockRedisStoreClientLRangeCall{Call: call}
+}
+
+// MockRedisStoreClientLRangeCall wrap *gomock.Call
+type MockRedisStoreClientLRangeCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockRedisStoreClientLRangeCall) Return(arg0 *redis.StringSliceCmd) *MockRedisStoreClientLRangeCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockRedisStoreClientLRangeCall) Do(f func(context.Context, string, int64, int64) *redis.StringSliceCmd) *MockRedisStoreClientLRangeCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockRedisStoreClientLRangeCall) DoAndReturn(f func(context.Context, string, int64, int64) *redis.StringSliceCmd) *MockRedisStoreClientLRangeCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// LTrim mocks base method.
+func (m *MockRedisStoreClient) LTrim(ctx context.Context, key string, start, stop int64) *redis.StatusCmd {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LTrim", ctx, key, start, stop)
+	ret0, _ := ret[0].(*redis.StatusCmd)
+	return ret0
+}
+
+// LTrim indicates an expected call of LTrim.
+func (mr *MockRedisStoreClientMockRecorder) LTrim(ctx, key, start, stop any) *MockRedisStoreClientLTrimCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LTrim", reflect.TypeOf((*MockRedisStoreClient)(nil).LTrim), ctx, key, start, stop)
+	return &MockRedisStoreClientLTrimCall{Call: call}
+}
+
+// MockRedisStoreClientLTrimCall wrap *gomock.Call
+type MockRedisStoreClientLTrimCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockRedisStoreClientLTrimCall) Return(arg0 *redis.StatusCmd) *MockRedisStoreClientLTrimCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockRedisStoreClientLTrimCall) Do(f func(context.Context, string, int64, int64) *redis.StatusCmd) *MockRedisStoreClientLTrimCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockRedisStoreClientLTrimCall) DoAndReturn(f func(context.Context, string, int64, int64) *redis.StatusCmd) *MockRedisStoreClientLTrimCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockPostgresStoreClient is a mock of PostgresStoreClient interface.
+type MockPostgresStoreClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockPostgresStoreClientMockRecorder
+	isgomock struct{}
+}
+
+// MockPostgresStoreClientMockRecorder is the mock recorder for MockPostgresStoreClient.
+type MockPostgresStoreClientMockRecorder struct {
+	mock *MockPostgresStoreClient
+}
+
+// NewMockPostgresStoreClient creates a new mock instance.
+func NewMockPostgresStoreClient(ctrl *gomock.Controller) *MockPostgresStoreClient {
+	mock := &MockPostgresStoreClient{ctrl: ctrl}
+	mock.recorder = &MockPostgresStoreClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPostgresStoreClient) EXPECT() *MockPostgresStoreClientMockRecorder {
+	return m.recorder
+}
+
+// Exec mocks base method.
+func (m *MockPostgresStoreClient) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, sql}
+	for _, a := range args {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Exec", varargs...)
+	ret0, _ := ret[0].(pgconn.CommandTag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Exec indicates an expected call of Exec.
+func (mr *MockPostgresStoreClientMockRecorder) Exec(ctx, sql any, args ...any) *MockPostgresStoreClientExecCall {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, sql}, args...)
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Exec", reflect.TypeOf((*MockPostgresStoreClient)(nil).Exec), varargs...)
+	return &MockPostgresStoreClientExecCall{Call: call}
+}
+
+// MockPostgresStoreClientExecCall wrap *gomock.Call
+type MockPostgresStoreClientExecCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockPostgresStoreClientExecCall) Return(arg0 pgconn.CommandTag, arg1 error) *MockPostgresStoreClientExecCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockPostgresStoreClientExecCall) Do(f func(context.Context, string, ...any) (pgconn.CommandTag, error)) *MockPostgresStoreClientExecCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockPostgresStoreClientExecCall) DoAndReturn(f func(context.Context, string, ...any) (pgconn.CommandTag, error)) *MockPostgresStoreClientExecCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// QueryRow mocks base method.
+func (m *MockPostgresStoreClient) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, sql}
+	for _, a := range args {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "QueryRow", varargs...)
+	ret0, _ := ret[0].(pgx.Row)
+	return ret0
+}
+
+// QueryRow indicates an expected call of QueryRow.
+func (mr *MockPostgresStoreClientMockRecorder) QueryRow(ctx, sql any, args ...any) *MockPostgresStoreClientQueryRowCall {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, sql}, args...)
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryRow", reflect.TypeOf((*MockPostgresStoreClient)(nil).QueryRow), varargs...)
+	return &MockPostgresStoreClientQueryRowCall{Call: call}
+}
+
+// MockPostgresStoreClientQueryRowCall wrap *gomock.Call
+type MockPostgresStoreClientQueryRowCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockPostgresStoreClientQueryRowCall) Return(arg0 pgx.Row) *MockPostgresStoreClientQueryRowCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockPostgresStoreClientQueryRowCall) Do(f func(context.Context, string, ...any) pgx.Row) *MockPostgresStoreClientQueryRowCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockPostgresStoreClientQueryRowCall) DoAndReturn(f func(context.Context, string, ...any) pgx.Row) *MockPostgresStoreClientQueryRowCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
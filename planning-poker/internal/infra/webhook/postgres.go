@@ -0,0 +1,137 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"planning-poker/internal/application/webhook"
+	"planning-poker/internal/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+type PostgresStoreClient interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+const (
+	addSubscriptionSQL = `
+INSERT INTO webhook_subscriptions (id, subscription, created_at) VALUES ($1, $2, $3)
+ON CONFLICT (id) DO UPDATE SET subscription = EXCLUDED.subscription, created_at = EXCLUDED.created_at`
+
+	subscriptionsSQL = `
+SELECT COALESCE(json_agg(subscription ORDER BY created_at), '[]') FROM webhook_subscriptions`
+
+	addDeadLetterSQL = `INSERT INTO webhook_dead_letters (dead_letter) VALUES ($1)`
+
+	// addTrimmedDeadLetterSQL also deletes the dead letters beyond the latest
+	// $2, counting the one added
+	addTrimmedDeadLetterSQL = `
+WITH trimmed AS (
+    DELETE FROM webhook_dead_letters
+    WHERE id <= (SELECT id FROM webhook_dead_letters ORDER BY id DESC OFFSET $2::BIGINT - 1 LIMIT 1)
+)
+INSERT INTO webhook_dead_letters (dead_letter) VALUES ($1)`
+
+	deadLettersSQL = `
+SELECT COALESCE(json_agg(d.dead_letter ORDER BY d.id DESC), '[]')
+FROM (SELECT id, dead_letter FROM webhook_dead_letters ORDER BY id DESC LIMIT $1) d`
+)
+
+// PostgresStore keeps the subscriptions and the dead letters in tables shared
+// by every instance, for deployments that store rooms in Postgres. Dead
+// letters are trimmed to the latest maxDeadLetters.
+type PostgresStore struct {
+	client         PostgresStoreClient
+	maxDeadLetters int64
+}
+
+var _ webhook.Store = (*PostgresStore)(nil)
+
+func NewPostgresStore(client PostgresStoreClient, maxDeadLetters int64) *PostgresStore {
+	return &PostgresStore{client: client, maxDeadLetters: maxDeadLetters}
+}
+
+func (s *PostgresStore) AddSubscription(ctx context.Context, subscription webhook.Subscription) error {
+	data, err := json.Marshal(serializeSubscription(subscription))
+	if err != nil {
+		return fmt.Errorf("failed to serialize webhook subscription: %w", err)
+	}
+
+	if _, err := s.client.Exec(ctx, addSubscriptionSQL, subscription.ID, data, subscription.CreatedAt); err != nil {
+		return fmt.Errorf("failed to save webhook subscription: %w", err)
+	}
+	return nil
+}
+
+func (s *PostgresStore) RemoveSubscription(ctx context.Context, id string) error {
+	tag, err := s.client.Exec(ctx, "DELETE FROM webhook_subscriptions WHERE id = $1", id)
+	if err != nil {
+		return fmt.Errorf("failed to remove webhook subscription: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrWebhookNotFound
+	}
+	return nil
+}
+
+func (s *PostgresStore) Subscriptions(ctx context.Context) ([]webhook.Subscription, error) {
+	var data []byte
+	if err := s.client.QueryRow(ctx, subscriptionsSQL).Scan(&data); err != nil {
+		return nil, fmt.Errorf("failed to read webhook subscriptions: %w", err)
+	}
+
+	var serialized []serializedSubscription
+	if err := json.Unmarshal(data, &serialized); err != nil {
+		return nil, fmt.Errorf("failed to deserialize webhook subscriptions: %w", err)
+	}
+	subscriptions := make([]webhook.Subscription, 0, len(serialized))
+	for _, subscription := range serialized {
+		subscriptions = append(subscriptions, subscription.subscription())
+	}
+	return subscriptions, nil
+}
+
+func (s *PostgresStore) AddDeadLetter(ctx context.Context, deadLetter webhook.DeadLetter) error {
+	data, err := json.Marshal(serializedDeadLetter(deadLetter))
+	if err != nil {
+		return fmt.Errorf("failed to serialize webhook dead letter: %w", err)
+	}
+
+	args := []any{data}
+	sql := addDeadLetterSQL
+	if s.maxDeadLetters > 0 {
+		sql = addTrimmedDeadLetterSQL
+		args = append(args, s.maxDeadLetters)
+	}
+	if _, err := s.client.Exec(ctx, sql, args...); err != nil {
+		return fmt.Errorf("failed to save webhook dead letter: %w", err)
+	}
+	return nil
+}
+
+// DeadLetters returns the latest dead letters first, all of them when limit
+// is not positive.
+func (s *PostgresStore) DeadLetters(ctx context.Context, limit int) ([]webhook.DeadLetter, error) {
+	var limitArg any
+	if limit > 0 {
+		limitArg = limit
+	}
+
+	var data []byte
+	if err := s.client.QueryRow(ctx, deadLettersSQL, limitArg).Scan(&data); err != nil {
+		return nil, fmt.Errorf("failed to read webhook dead letters: %w", err)
+	}
+
+	var serialized []serializedDeadLetter
+	if err := json.Unmarshal(data, &serialized); err != nil {
+		return nil, fmt.Errorf("failed to deserialize webhook dead letters: %w", err)
+	}
+	deadLetters := make([]webhook.DeadLetter, 0, len(serialized))
+	for _, deadLetter := range serialized {
+		deadLetters = append(deadLetters, webhook.DeadLetter(deadLetter))
+	}
+	return deadLetters, nil
+}
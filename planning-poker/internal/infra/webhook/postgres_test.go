@@ -0,0 +1,99 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"planning-poker/internal/application/webhook"
+	"planning-poker/internal/domain"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"go.uber.org/mock/gomock"
+)
+
+// scanJSON answers a query with the JSON the database aggregated.
+func scanJSON(ctrl *gomock.Controller, data []byte) *MockRow {
+	row := NewMockRow(ctrl)
+	row.EXPECT().Scan(gomock.Any()).DoAndReturn(func(dest ...any) error {
+		*dest[0].(*[]byte) = data
+		return nil
+	})
+	return row
+}
+
+func TestPostgresStore_Subscriptions_RoundTrip(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := NewMockPostgresStoreClient(ctrl)
+	store := NewPostgresStore(client, 100)
+	subscription := webhook.Subscription{
+		ID:         "subscription-1",
+		RoomID:     "room123",
+		URL:        "https://hooks.example.com/poker",
+		Secret:     "whsec_secret",
+		EventTypes: []webhook.EventType{webhook.EventStoryRevealed},
+		CreatedAt:  time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC),
+	}
+
+	var saved []byte
+	client.EXPECT().Exec(gomock.Any(), addSubscriptionSQL, "subscription-1", gomock.Any(), subscription.CreatedAt).
+		DoAndReturn(func(_ context.Context, _ string, args ...any) (pgconn.CommandTag, error) {
+			saved = args[1].([]byte)
+			return pgconn.NewCommandTag("INSERT 0 1"), nil
+		})
+	if err := store.AddSubscription(context.Background(), subscription); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	client.EXPECT().QueryRow(gomock.Any(), subscriptionsSQL).Return(scanJSON(ctrl, []byte("["+string(saved)+"]")))
+	subscriptions, err := store.Subscriptions(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(subscriptions) != 1 || !reflect.DeepEqual(subscriptions[0], subscription) {
+		t.Errorf("expected %+v, got %+v", subscription, subscriptions)
+	}
+}
+
+func TestPostgresStore_RemoveSubscription_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := NewMockPostgresStoreClient(ctrl)
+	store := NewPostgresStore(client, 100)
+
+	client.EXPECT().Exec(gomock.Any(), gomock.Any(), "missing").Return(pgconn.NewCommandTag("DELETE 0"), nil)
+
+	if err := store.RemoveSubscription(context.Background(), "missing"); !errors.Is(err, domain.ErrWebhookNotFound) {
+		t.Errorf("expected %v, got %v", domain.ErrWebhookNotFound, err)
+	}
+}
+
+func TestPostgresStore_AddDeadLetter_TrimsTheTable(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := NewMockPostgresStoreClient(ctrl)
+	store := NewPostgresStore(client, 100)
+	deadLetter := webhook.DeadLetter{
+		SubscriptionID: "subscription-1",
+		URL:            "https://hooks.example.com/poker",
+		Event:          webhook.Event{ID: "event-1", Type: webhook.EventSessionEnded, RoomID: "room123"},
+		Attempts:       5,
+		Error:          "webhook responded with 500 Internal Server Error",
+		FailedAt:       time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC),
+	}
+
+	client.EXPECT().Exec(gomock.Any(), addTrimmedDeadLetterSQL, gomock.Any(), int64(100)).Return(pgconn.NewCommandTag("INSERT 0 1"), nil)
+	if err := store.AddDeadLetter(context.Background(), deadLetter); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	data, _ := json.Marshal([]serializedDeadLetter{serializedDeadLetter(deadLetter)})
+	client.EXPECT().QueryRow(gomock.Any(), deadLettersSQL, 10).Return(scanJSON(ctrl, data))
+	deadLetters, err := store.DeadLetters(context.Background(), 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(deadLetters) != 1 || deadLetters[0].Event.ID != "event-1" || deadLetters[0].Attempts != 5 {
+		t.Errorf("unexpected dead letters %+v", deadLetters)
+	}
+}
@@ -0,0 +1,167 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"planning-poker/internal/application/webhook"
+	"planning-poker/internal/domain"
+	"slices"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+type RedisStoreClient interface {
+	HSet(ctx context.Context, key string, values ...any) *redis.IntCmd
+	HDel(ctx context.Context, key string, fields ...string) *redis.IntCmd
+	HGetAll(ctx context.Context, key string) *redis.MapStringStringCmd
+	LPush(ctx context.Context, key string, values ...any) *redis.IntCmd
+	LTrim(ctx context.Context, key string, start, stop int64) *redis.StatusCmd
+	LRange(ctx context.Context, key string, start, stop int64) *redis.StringSliceCmd
+}
+
+const (
+	subscriptionsKey = "planning-poker:webhooks:subscriptions"
+	deadLettersKey   = "planning-poker:webhooks:dead-letters"
+)
+
+type (
+	serializedSubscription struct {
+		ID         string    `json:"id"`
+		RoomID     string    `json:"roomId,omitempty"`
+		URL        string    `json:"url"`
+		Secret     string    `json:"secret"`
+		EventTypes []string  `json:"eventTypes,omitempty"`
+		CreatedAt  time.Time `json:"createdAt"`
+	}
+	serializedDeadLetter struct {
+		SubscriptionID string        `json:"subscriptionId"`
+		URL            string        `json:"url"`
+		Event          webhook.Event `json:"event"`
+		Attempts       int           `json:"attempts"`
+		Error          string        `json:"error"`
+		FailedAt       time.Time     `json:"failedAt"`
+	}
+)
+
+// RedisStore keeps the subscriptions in a hash and the dead letters in a
+// list trimmed to maxDeadLetters entries, both shared by every instance.
+type RedisStore struct {
+	client         RedisStoreClient
+	maxDeadLetters int64
+}
+
+var _ webhook.Store = (*RedisStore)(nil)
+
+func NewRedisStore(client RedisStoreClient, maxDeadLetters int64) *RedisStore {
+	return &RedisStore{client: client, maxDeadLetters: maxDeadLetters}
+}
+
+func (s *RedisStore) AddSubscription(ctx context.Context, subscription webhook.Subscription) error {
+	data, err := json.Marshal(serializeSubscription(subscription))
+	if err != nil {
+		return fmt.Errorf("failed to serialize webhook subscription: %w", err)
+	}
+
+	if err := s.client.HSet(ctx, subscriptionsKey, subscription.ID, data).Err(); err != nil {
+		return fmt.Errorf("failed to save webhook subscription: %w", err)
+	}
+	return nil
+}
+
+func (s *RedisStore) RemoveSubscription(ctx context.Context, id string) error {
+	removed, err := s.client.HDel(ctx, subscriptionsKey, id).Result()
+	if err != nil {
+		return fmt.Errorf("failed to remove webhook subscription: %w", err)
+	}
+	if removed == 0 {
+		return domain.ErrWebhookNotFound
+	}
+	return nil
+}
+
+func (s *RedisStore) Subscriptions(ctx context.Context) ([]webhook.Subscription, error) {
+	values, err := s.client.HGetAll(ctx, subscriptionsKey).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read webhook subscriptions: %w", err)
+	}
+
+	subscriptions := make([]webhook.Subscription, 0, len(values))
+	for id, data := range values {
+		var serialized serializedSubscription
+		if err := json.Unmarshal([]byte(data), &serialized); err != nil {
+			return nil, fmt.Errorf("failed to deserialize webhook subscription %s: %w", id, err)
+		}
+		subscriptions = append(subscriptions, serialized.subscription())
+	}
+
+	// hash fields come in no particular order
+	slices.SortFunc(subscriptions, func(a, b webhook.Subscription) int {
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
+	return subscriptions, nil
+}
+
+func (s *RedisStore) AddDeadLetter(ctx context.Context, deadLetter webhook.DeadLetter) error {
+	data, err := json.Marshal(serializedDeadLetter(deadLetter))
+	if err != nil {
+		return fmt.Errorf("failed to serialize webhook dead letter: %w", err)
+	}
+
+	if err := s.client.LPush(ctx, deadLettersKey, data).Err(); err != nil {
+		return fmt.Errorf("failed to save webhook dead letter: %w", err)
+	}
+	if s.maxDeadLetters > 0 {
+		if err := s.client.LTrim(ctx, deadLettersKey, 0, s.maxDeadLetters-1).Err(); err != nil {
+			return fmt.Errorf("failed to trim webhook dead letters: %w", err)
+		}
+	}
+	return nil
+}
+
+func (s *RedisStore) DeadLetters(ctx context.Context, limit int) ([]webhook.DeadLetter, error) {
+	values, err := s.client.LRange(ctx, deadLettersKey, 0, int64(limit)-1).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read webhook dead letters: %w", err)
+	}
+
+	deadLetters := make([]webhook.DeadLetter, 0, len(values))
+	for _, data := range values {
+		var serialized serializedDeadLetter
+		if err := json.Unmarshal([]byte(data), &serialized); err != nil {
+			return nil, fmt.Errorf("failed to deserialize webhook dead letter: %w", err)
+		}
+		deadLetters = append(deadLetters, webhook.DeadLetter(serialized))
+	}
+	return deadLetters, nil
+}
+
+func serializeSubscription(subscription webhook.Subscription) serializedSubscription {
+	eventTypes := make([]string, 0, len(subscription.EventTypes))
+	for _, eventType := range subscription.EventTypes {
+		eventTypes = append(eventTypes, string(eventType))
+	}
+	return serializedSubscription{
+		ID:         subscription.ID,
+		RoomID:     subscription.RoomID,
+		URL:        subscription.URL,
+		Secret:     subscription.Secret,
+		EventTypes: eventTypes,
+		CreatedAt:  subscription.CreatedAt,
+	}
+}
+
+func (s serializedSubscription) subscription() webhook.Subscription {
+	subscription := webhook.Subscription{
+		ID:        s.ID,
+		RoomID:    s.RoomID,
+		URL:       s.URL,
+		Secret:    s.Secret,
+		CreatedAt: s.CreatedAt,
+	}
+	for _, eventType := range s.EventTypes {
+		subscription.EventTypes = append(subscription.EventTypes, webhook.EventType(eventType))
+	}
+	return subscription
+}
@@ -0,0 +1,94 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"planning-poker/internal/application/webhook"
+	"planning-poker/internal/domain"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/mock/gomock"
+)
+
+func TestRedisStore_Subscriptions_RoundTrip(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := NewMockRedisStoreClient(ctrl)
+	store := NewRedisStore(client, 100)
+	subscription := webhook.Subscription{
+		ID:         "subscription-1",
+		RoomID:     "room123",
+		URL:        "https://hooks.example.com/poker",
+		Secret:     "whsec_secret",
+		EventTypes: []webhook.EventType{webhook.EventStoryRevealed},
+		CreatedAt:  time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC),
+	}
+
+	var saved string
+	client.EXPECT().HSet(gomock.Any(), subscriptionsKey, "subscription-1", gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, values ...any) *redis.IntCmd {
+			saved = string(values[1].([]byte))
+			return redis.NewIntResult(1, nil)
+		})
+	if err := store.AddSubscription(context.Background(), subscription); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	client.EXPECT().HGetAll(gomock.Any(), subscriptionsKey).
+		Return(redis.NewMapStringStringResult(map[string]string{"subscription-1": saved}, nil))
+	subscriptions, err := store.Subscriptions(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(subscriptions) != 1 || !reflect.DeepEqual(subscriptions[0], subscription) {
+		t.Errorf("expected %+v, got %+v", subscription, subscriptions)
+	}
+}
+
+func TestRedisStore_RemoveSubscription_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := NewMockRedisStoreClient(ctrl)
+	store := NewRedisStore(client, 100)
+
+	client.EXPECT().HDel(gomock.Any(), subscriptionsKey, "missing").Return(redis.NewIntResult(0, nil))
+
+	if err := store.RemoveSubscription(context.Background(), "missing"); !errors.Is(err, domain.ErrWebhookNotFound) {
+		t.Errorf("expected %v, got %v", domain.ErrWebhookNotFound, err)
+	}
+}
+
+func TestRedisStore_AddDeadLetter_TrimsTheList(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := NewMockRedisStoreClient(ctrl)
+	store := NewRedisStore(client, 100)
+	deadLetter := webhook.DeadLetter{
+		SubscriptionID: "subscription-1",
+		URL:            "https://hooks.example.com/poker",
+		Event:          webhook.Event{ID: "event-1", Type: webhook.EventSessionEnded, RoomID: "room123"},
+		Attempts:       5,
+		Error:          "webhook responded with 500 Internal Server Error",
+		FailedAt:       time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC),
+	}
+
+	gomock.InOrder(
+		client.EXPECT().LPush(gomock.Any(), deadLettersKey, gomock.Any()).Return(redis.NewIntResult(1, nil)),
+		client.EXPECT().LTrim(gomock.Any(), deadLettersKey, int64(0), int64(99)).Return(redis.NewStatusResult("OK", nil)),
+	)
+	if err := store.AddDeadLetter(context.Background(), deadLetter); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	data, _ := json.Marshal(serializedDeadLetter(deadLetter))
+	client.EXPECT().LRange(gomock.Any(), deadLettersKey, int64(0), int64(9)).
+		Return(redis.NewStringSliceResult([]string{string(data)}, nil))
+	deadLetters, err := store.DeadLetters(context.Background(), 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(deadLetters) != 1 || deadLetters[0].Event.ID != "event-1" || deadLetters[0].Attempts != 5 {
+		t.Errorf("unexpected dead letters %+v", deadLetters)
+	}
+}
@@ -0,0 +1,125 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"planning-poker/internal/application/timer"
+	"planning-poker/internal/application/webhook"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+const (
+	HeaderID        = "X-Webhook-ID"
+	HeaderEvent     = "X-Webhook-Event"
+	HeaderTimestamp = "X-Webhook-Timestamp"
+	HeaderSignature = "X-Webhook-Signature"
+
+	signaturePrefix = "sha256="
+	userAgent       = "planning-poker-webhooks/1.0"
+)
+
+var errPrivateAddress = errors.New("webhook address is not public")
+
+// HTTPSender posts the events as JSON, signed with the secret of the
+// subscription. Redirects are not followed and any status but 2xx fails the
+// attempt. Unless private networks are allowed, connections to loopback,
+// private and link-local addresses are refused, so that subscriptions cannot
+// reach the internal services next to the instance.
+type HTTPSender struct {
+	client *http.Client
+	clock  timer.Clock
+}
+
+var _ webhook.Sender = (*HTTPSender)(nil)
+
+func NewHTTPSender(timeout time.Duration, allowPrivateNetworks bool, clock timer.Clock) *HTTPSender {
+	dialer := &net.Dialer{Timeout: timeout}
+	if !allowPrivateNetworks {
+		dialer.Control = refusePrivateAddresses
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	// a proxy would dial the subscriptions in place of the guarded dialer
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &HTTPSender{
+		client: &http.Client{
+			Timeout:   timeout,
+			Transport: transport,
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		clock: clock,
+	}
+}
+
+func (s *HTTPSender) Send(ctx context.Context, subscription webhook.Subscription, event webhook.Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to serialize webhook event: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create webhook request: %w", err)
+	}
+	timestamp := s.clock.Now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set(HeaderID, event.ID)
+	req.Header.Set(HeaderEvent, string(event.Type))
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(HeaderSignature, Sign(subscription.Secret, timestamp, body))
+
+	res, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(res.Body, 64<<10))
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return fmt.Errorf("webhook responded with %s", res.Status)
+	}
+	return nil
+}
+
+// Sign is the signature of a delivery: the hex encoded HMAC-SHA256 of the
+// timestamp and the body joined by a dot, keyed by the secret of the
+// subscription.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// refusePrivateAddresses checks the address once resolved, so that a public
+// name resolving to a private address is refused as well.
+func refusePrivateAddresses(_, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return err
+	}
+	ip := addrPort.Addr().Unmap()
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsMulticast() || ip.IsUnspecified() {
+		return fmt.Errorf("%w: %s", errPrivateAddress, ip)
+	}
+	return nil
+}
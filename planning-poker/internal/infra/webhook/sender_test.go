@@ -0,0 +1,93 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"planning-poker/internal/application/webhook"
+	"strconv"
+	"testing"
+	"time"
+)
+
+type fixedClock struct{ now time.Time }
+
+func (c fixedClock) Now() time.Time { return c.now }
+
+func TestHTTPSender_Send_SignsTheDelivery(t *testing.T) {
+	now := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	event := webhook.Event{ID: "event-1", Type: webhook.EventStoryAdded, RoomID: "room123", OccurredAt: now,
+		Data: webhook.StoryAddedData{Story: "Login page", Index: 0}}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if r.Method != http.MethodPost || r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("unexpected request %s %s", r.Method, r.Header.Get("Content-Type"))
+		}
+		if r.Header.Get(HeaderID) != "event-1" || r.Header.Get(HeaderEvent) != "story.added" {
+			t.Errorf("unexpected headers %v", r.Header)
+		}
+		timestamp, _ := strconv.ParseInt(r.Header.Get(HeaderTimestamp), 10, 64)
+		if timestamp != now.Unix() {
+			t.Errorf("timestamp = %d, want %d", timestamp, now.Unix())
+		}
+		if got, want := r.Header.Get(HeaderSignature), Sign("whsec_secret", timestamp, body); got != want {
+			t.Errorf("signature = %s, want %s", got, want)
+		}
+
+		var received webhook.Event
+		if err := json.Unmarshal(body, &received); err != nil || received.ID != "event-1" || received.RoomID != "room123" {
+			t.Errorf("unexpected body %s", body)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	sender := NewHTTPSender(time.Second, true, fixedClock{now: now})
+	subscription := webhook.Subscription{ID: "subscription-1", URL: server.URL, Secret: "whsec_secret"}
+	if err := sender.Send(context.Background(), subscription, event); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestHTTPSender_Send_FailsOnUnsuccessfulStatus(t *testing.T) {
+	for _, status := range []int{http.StatusFound, http.StatusBadRequest, http.StatusServiceUnavailable} {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if status == http.StatusFound {
+				w.Header().Set("Location", "/elsewhere")
+			}
+			w.WriteHeader(status)
+		}))
+
+		sender := NewHTTPSender(time.Second, true, fixedClock{now: time.Now()})
+		err := sender.Send(context.Background(), webhook.Subscription{URL: server.URL}, webhook.Event{})
+		if err == nil {
+			t.Errorf("expected status %d to fail the delivery", status)
+		}
+		server.Close()
+	}
+}
+
+func TestHTTPSender_Send_RefusesPrivateAddresses(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("the request should not reach the server")
+	}))
+	defer server.Close()
+
+	sender := NewHTTPSender(time.Second, false, fixedClock{now: time.Now()})
+	err := sender.Send(context.Background(), webhook.Subscription{URL: server.URL}, webhook.Event{})
+	if !errors.Is(err, errPrivateAddress) {
+		t.Errorf("expected %v, got %v", errPrivateAddress, err)
+	}
+}
+
+func TestSign(t *testing.T) {
+	// HMAC-SHA256 of "1704103200.{}" keyed by "secret"
+	want := "sha256=0533746a43f00bbcf7e43fd4a14f8c4853acb8acd441038927be633b2090fd9d"
+	if got := Sign("secret", 1704103200, []byte("{}")); got != want {
+		t.Errorf("Sign() = %s, want %s", got, want)
+	}
+}
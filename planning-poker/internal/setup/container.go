@@ -2,19 +2,39 @@ package setup
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"io"
+	nethttp "net/http"
+	"planning-poker/internal/application/audit"
+	"planning-poker/internal/application/auth"
 	"planning-poker/internal/application/lock"
 	"planning-poker/internal/application/planningpoker/metric"
 	"planning-poker/internal/application/planningpoker/usecase"
+	"planning-poker/internal/application/ratelimit"
+	"planning-poker/internal/application/timer"
+	"planning-poker/internal/application/webhook"
 	"planning-poker/internal/config"
 	"planning-poker/internal/domain"
+	"planning-poker/internal/domain/entity"
+	infraaudit "planning-poker/internal/infra/audit"
+	infraauth "planning-poker/internal/infra/auth"
 	"planning-poker/internal/infra/boundaries/http"
 	"planning-poker/internal/infra/boundaries/http/middleware"
+	"planning-poker/internal/infra/boundaries/hub/inmemory"
+	"planning-poker/internal/infra/boundaries/hub/postgres"
 	"planning-poker/internal/infra/boundaries/hub/redis"
 	"planning-poker/internal/infra/bus"
 	"planning-poker/internal/infra/decorators/usecasedecorators"
+	"planning-poker/internal/infra/lifecycle"
 	infralock "planning-poker/internal/infra/lock"
+	infraratelimit "planning-poker/internal/infra/ratelimit"
+	infratimer "planning-poker/internal/infra/timer"
+	infrawebhook "planning-poker/internal/infra/webhook"
+	"time"
 
 	toolkitmetric "github.com/bruno303/go-toolkit/pkg/metric"
+	"github.com/jackc/pgx/v5/pgxpool"
 	redislib "github.com/redis/go-redis/v9"
 )
 
@@ -24,13 +44,24 @@ type (
 	}
 	InfraContainer struct {
 		RedisClient         *redislib.Client
+		PostgresPool        *pgxpool.Pool
 		WebsocketBusFactory *bus.WebSocketBusFactory
+		SSEBusFactory       *bus.SSEBusFactory
 		Hub                 domain.Hub
 		AdminHub            domain.AdminHub
 		LockManager         lock.LockManager
+		DeadlineStore       timer.DeadlineStore
+		RateLimiter         ratelimit.Limiter
+		AuditSink           audit.Sink
+		WebhookStore        webhook.Store
+		VotingTimerWatcher  *infratimer.Watcher
+		// RoomReaper is nil when rooms never expire
+		RoomReaper *lifecycle.Reaper
 	}
 	ApplicationContainer struct {
 		PlanningPokerMetric metric.PlanningPokerMetric
+		Auditor             audit.Auditor
+		Webhooks            *webhook.Dispatcher
 		Usecases            usecase.UseCasesFacade
 	}
 
@@ -45,8 +76,11 @@ func NewContainer(cfg *config.Config) *Container {
 	ctx := context.Background()
 
 	infra := newInfraContainer(ctx, cfg)
-	app := newApplicationContainer(infra)
-	infra.WebsocketBusFactory = newWebsocketBusFactory(cfg, infra, app)
+	app := newApplicationContainer(cfg, infra)
+	infra.WebsocketBusFactory = bus.NewWebSocketBusFactory(infra.Hub, app.Usecases, newBusConfig(cfg, infra, app))
+	infra.SSEBusFactory = bus.NewSSEBusFactory(app.Usecases, newBusConfig(cfg, infra, app))
+	infra.VotingTimerWatcher = newVotingTimerWatcher(cfg, infra, app)
+	infra.RoomReaper = newRoomReaper(cfg, infra, app)
 	api := newAPIContainer(cfg, infra, app)
 
 	return &Container{
@@ -56,7 +90,72 @@ func NewContainer(cfg *config.Config) *Container {
 	}
 }
 
+// Shutdown tells connected clients to reconnect elsewhere, then closes the
+// hub and the connections to Redis and Postgres. Clients stay in their rooms.
+// It gives up on whatever is left when the context is done.
+func (c *Container) Shutdown(ctx context.Context) error {
+	var errs []error
+	if c.Infra.WebsocketBusFactory != nil {
+		errs = append(errs, c.Infra.WebsocketBusFactory.Drain(ctx))
+	}
+	errs = append(errs, c.DrainEventStreams(ctx))
+	if hub, ok := c.Infra.Hub.(io.Closer); ok {
+		errs = append(errs, closeWithin(ctx, "hub", hub.Close))
+	}
+	if c.Infra.RedisClient != nil {
+		errs = append(errs, closeWithin(ctx, "redis client", c.Infra.RedisClient.Close))
+	}
+	if c.Infra.PostgresPool != nil {
+		errs = append(errs, closeWithin(ctx, "postgres pool", func() error {
+			c.Infra.PostgresPool.Close()
+			return nil
+		}))
+	}
+	return errors.Join(errs...)
+}
+
+// DrainEventStreams ends the event streams, which unlike websockets are
+// served by the HTTP server until they end, keeping their clients in their
+// rooms.
+func (c *Container) DrainEventStreams(ctx context.Context) error {
+	if c.Infra.SSEBusFactory == nil {
+		return nil
+	}
+	return c.Infra.SSEBusFactory.Drain(ctx)
+}
+
+func closeWithin(ctx context.Context, name string, closeFunc func() error) error {
+	done := make(chan error, 1)
+	go func() { done <- closeFunc() }()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			return fmt.Errorf("closing %s: %w", name, err)
+		}
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("closing %s: %w", name, ctx.Err())
+	}
+}
+
 func newInfraContainer(ctx context.Context, cfg *config.Config) *InfraContainer {
+	switch cfg.API.PlanningPoker.HubBackend {
+	case "memory":
+		return newSingleNodeInfraContainer(cfg)
+	case "postgres":
+		return newPostgresInfraContainer(ctx, cfg)
+	case "", "redis":
+		return newRedisInfraContainer(ctx, cfg)
+	default:
+		panic("Unknown hub backend: " + cfg.API.PlanningPoker.HubBackend)
+	}
+}
+
+// newRedisInfraContainer shares rooms, locks, voting timers and the rest
+// between the instances through Redis, which keeps rooms for a day after
+// their last change, or until the room lifecycle closes them when enabled.
+func newRedisInfraContainer(ctx context.Context, cfg *config.Config) *InfraContainer {
 	redisClient, err := NewRedisClient(cfg)
 	if err != nil {
 		panic("Failed to initialize Redis client: " + err.Error())
@@ -67,58 +166,198 @@ func newInfraContainer(ctx context.Context, cfg *config.Config) *InfraContainer
 		panic("Failed to initialize Redis hub (ensure Redis is running and accessible): " + err.Error())
 	}
 
-	lockManager := infralock.NewRedisLockManager(redisClient)
+	return &InfraContainer{
+		RedisClient:   redisClient,
+		Hub:           hub,
+		AdminHub:      hub,
+		LockManager:   newLockManager(cfg, redisClient),
+		DeadlineStore: infratimer.NewRedisDeadlineStore(redisClient),
+		RateLimiter:   infraratelimit.NewRedisLimiter(redisClient),
+		AuditSink:     infraaudit.NewRedisSink(redisClient, int64(cfg.API.Audit.MaxRecords)),
+		WebhookStore:  infrawebhook.NewRedisStore(redisClient, int64(cfg.API.Webhooks.MaxDeadLetters)),
+	}
+}
+
+// newPostgresInfraContainer keeps rooms until they are removed, along with
+// voting timers, audit records and webhooks, in Postgres, so Redis is not
+// needed. Changes to rooms are retried on conflicts instead of locked and
+// rate limits apply to each instance on its own.
+func newPostgresInfraContainer(ctx context.Context, cfg *config.Config) *InfraContainer {
+	// the distributed lock is kept in Redis
+	if strategy := cfg.API.PlanningPoker.ConcurrencyStrategy; strategy != "" && strategy != "optimistic" {
+		panic("Concurrency strategy " + strategy + " needs Redis, which the postgres hub backend does not use; use optimistic")
+	}
+
+	pool, err := NewPostgresPool(cfg)
+	if err != nil {
+		panic("Failed to initialize Postgres pool: " + err.Error())
+	}
+	if err := postgres.Migrate(ctx, pool); err != nil {
+		panic("Failed to migrate Postgres schema: " + err.Error())
+	}
+	hub, err := postgres.NewPostgresHub(ctx, pool, postgres.NewPoolListener(pool))
+	if err != nil {
+		panic("Failed to initialize Postgres hub: " + err.Error())
+	}
+
+	return &InfraContainer{
+		PostgresPool:  pool,
+		Hub:           hub,
+		AdminHub:      hub,
+		LockManager:   newOptimisticLockManager(cfg),
+		DeadlineStore: infratimer.NewPostgresDeadlineStore(pool),
+		RateLimiter:   infraratelimit.NewInMemoryLimiter(timer.SystemClock{}),
+		AuditSink:     infraaudit.NewPostgresSink(pool, int64(cfg.API.Audit.MaxRecords)),
+		WebhookStore:  infrawebhook.NewPostgresStore(pool, int64(cfg.API.Webhooks.MaxDeadLetters)),
+	}
+}
+
+// newSingleNodeInfraContainer keeps rooms, locks and voting timers in the
+// process, so nothing but the API is needed. Only one instance may run.
+func newSingleNodeInfraContainer(cfg *config.Config) *InfraContainer {
+	// a single instance only needs locks in the process, the other
+	// strategies are meant for several instances sharing a store
+	if strategy := cfg.API.PlanningPoker.ConcurrencyStrategy; strategy != "" && strategy != "lock" {
+		panic("Concurrency strategy " + strategy + " is not supported by the memory hub backend")
+	}
 
+	hub := inmemory.NewHub()
 	return &InfraContainer{
-		RedisClient: redisClient,
-		Hub:         hub,
-		AdminHub:    hub,
-		LockManager: lockManager,
+		Hub:           hub,
+		AdminHub:      hub,
+		LockManager:   infralock.NewInMemoryLockManager(),
+		DeadlineStore: infratimer.NewInMemoryDeadlineStore(),
+		RateLimiter:   infraratelimit.NewInMemoryLimiter(timer.SystemClock{}),
+		AuditSink:     infraaudit.NewInMemorySink(cfg.API.Audit.MaxRecords),
+		WebhookStore:  infrawebhook.NewInMemoryStore(cfg.API.Webhooks.MaxDeadLetters),
 	}
 }
 
-func newApplicationContainer(infra *InfraContainer) *ApplicationContainer {
+// newLockManager picks how concurrent changes to a room are serialized: a
+// distributed lock around every action or optimistic retries on conflicts.
+func newLockManager(cfg *config.Config, redisClient *redislib.Client) lock.LockManager {
+	planningPokerCfg := cfg.API.PlanningPoker
+	switch planningPokerCfg.ConcurrencyStrategy {
+	case "", "lock":
+		return infralock.NewRedisLockManager(redisClient)
+	case "optimistic":
+		return newOptimisticLockManager(cfg)
+	default:
+		panic("Unknown concurrency strategy: " + planningPokerCfg.ConcurrencyStrategy)
+	}
+}
+
+func newOptimisticLockManager(cfg *config.Config) lock.LockManager {
+	planningPokerCfg := cfg.API.PlanningPoker
+	lockManager := infralock.NewOptimisticLockManager()
+	if planningPokerCfg.OptimisticMaxAttempts > 0 {
+		lockManager.SetRetry(planningPokerCfg.OptimisticMaxAttempts, planningPokerCfg.OptimisticRetryDelay)
+	}
+	return lockManager
+}
+
+func newApplicationContainer(cfg *config.Config, infra *InfraContainer) *ApplicationContainer {
 	planningPokerMetric := metric.NewPlanningPokerMetricWithMeter(toolkitmetric.GetMeter())
-	usecases := newUsecases(infra.Hub, infra.LockManager, planningPokerMetric)
+	auditor := audit.NewSinkAuditor(infra.AuditSink, timer.SystemClock{})
+	webhooks := newWebhookDispatcher(cfg, infra)
+	usecases := newUsecases(
+		infra.Hub,
+		infra.LockManager,
+		infra.DeadlineStore,
+		planningPokerMetric,
+		auditor,
+		infra.WebhookStore,
+		webhooks,
+		cfg.API.PlanningPoker.AutoCreateRoomsOnJoin,
+	)
 
 	return &ApplicationContainer{
 		PlanningPokerMetric: planningPokerMetric,
+		Auditor:             auditor,
+		Webhooks:            webhooks,
 		Usecases:            usecases,
 	}
 }
 
+// newWebhookDispatcher delivers the events of the rooms with the signed HTTP
+// sender.
+func newWebhookDispatcher(cfg *config.Config, infra *InfraContainer) *webhook.Dispatcher {
+	webhooksCfg := cfg.API.Webhooks
+	return webhook.NewDispatcher(
+		infra.WebhookStore,
+		infrawebhook.NewHTTPSender(webhooksCfg.Timeout, webhooksCfg.AllowPrivateNetworks, timer.SystemClock{}),
+		timer.SystemClock{},
+		webhook.DispatcherConfig{
+			Workers:        webhooksCfg.Workers,
+			QueueSize:      webhooksCfg.QueueSize,
+			MaxAttempts:    webhooksCfg.MaxAttempts,
+			InitialBackoff: webhooksCfg.InitialBackoff,
+			MaxBackoff:     webhooksCfg.MaxBackoff,
+		},
+	)
+}
+
 func newAPIContainer(cfg *config.Config, infra *InfraContainer, app *ApplicationContainer) *APIContainer {
-	healthCheckers := []http.HealthChecker{
-		http.NewRedisHealthChecker(infra.RedisClient, "redis"),
+	var healthCheckers []http.HealthChecker
+	if infra.RedisClient != nil {
+		healthCheckers = append(healthCheckers, http.NewRedisHealthChecker(infra.RedisClient, "redis"))
+	}
+	if infra.PostgresPool != nil {
+		healthCheckers = append(healthCheckers, http.NewPostgresHealthChecker(infra.PostgresPool, "postgres"))
 	}
 
-	adminAuthMiddleware := middleware.NewAdminMiddleware(cfg.API.Admin.APIKey)
+	adminAuthMiddleware := middleware.NewAdminKeyringMiddleware(newAdminKeyring(cfg))
+	createRoomRateLimit := newIPRateLimitMiddleware(cfg, infra, app, "create-room")
+	adminRateLimit := newIPRateLimitMiddleware(cfg, infra, app, "admin")
+	roomCommandRateLimit := newIPRateLimitMiddleware(cfg, infra, app, "room-commands")
+	tokenVerifier := newTokenVerifier(cfg)
 	adminRemoveClientUseCase := usecasedecorators.NewTraceableUseCase(
-		usecase.NewAdminRemoveClientUseCase(app.Usecases.LeaveRoom, infra.Hub),
+		usecase.NewAdminRemoveClientUseCase(app.Usecases.LeaveRoom, infra.Hub, app.Auditor),
 		"AdminRemoveClientUseCase",
 		"AdminRemoveClient",
 	)
 	adminKickClientUseCase := usecasedecorators.NewTraceableUseCase(
-		usecase.NewAdminKickClientUseCase(app.Usecases.LeaveRoom, infra.Hub),
+		usecase.NewAdminKickClientUseCase(app.Usecases.LeaveRoom, infra.Hub, app.Auditor),
 		"AdminKickClientUseCase",
 		"AdminKickClient",
 	)
 	adminToggleOwnerUseCase := usecasedecorators.NewTraceableUseCase(
-		usecase.NewAdminToggleOwnerUseCase(infra.Hub, infra.LockManager),
+		usecase.NewAdminToggleOwnerUseCase(infra.Hub, infra.LockManager, app.Auditor),
 		"AdminToggleOwnerUseCase",
 		"AdminToggleOwner",
 	)
 
+	websocketAPI := http.NewWebsocketAPI(app.Usecases, infra.WebsocketBusFactory, app.PlanningPokerMetric, http.WebsocketAPIConfig{
+		AllowedOrigins:      cfg.AllowedOrigins(),
+		MaxConnectionsPerIP: cfg.API.PlanningPoker.WebsocketMaxConnsPerIP,
+		TrustForwardedFor:   cfg.API.TrustForwardedFor,
+		TokenVerifier:       tokenVerifier,
+		RequireAuth:         cfg.API.Auth.Required,
+	})
+
 	apis := []http.API{
-		http.NewWebsocketAPI(app.Usecases, infra.WebsocketBusFactory),
-		http.NewCreateRoomAPI(app.Usecases.CreateRoom),
+		websocketAPI,
+		http.NewSSEAPI(websocketAPI, infra.SSEBusFactory),
+		http.NewSSEMessageAPI(infra.SSEBusFactory),
+		http.WithRateLimit(http.NewCreateRoomAPI(app.Usecases.CreateRoom), createRoomRateLimit),
 		http.NewGetRoomAPI(infra.Hub),
 		http.NewHealthcheckAPI(healthCheckers...),
-		http.NewGetAllRoomsStateAPI(infra.AdminHub, adminAuthMiddleware),
-		http.NewGetRoomStateAPI(infra.Hub, adminAuthMiddleware),
-		http.NewDisconnectClientAPI(adminRemoveClientUseCase, adminAuthMiddleware),
-		http.NewKickClientAPI(adminKickClientUseCase, adminAuthMiddleware),
-		http.NewToggleOwnerAPI(adminToggleOwnerUseCase, adminAuthMiddleware),
+		http.WithRateLimit(http.NewGetAllRoomsStateAPI(infra.AdminHub, adminAuthMiddleware), adminRateLimit),
+		http.WithRateLimit(http.NewGetRoomStateAPI(infra.Hub, adminAuthMiddleware), adminRateLimit),
+		http.WithRateLimit(http.NewExportRoomReportAPI(infra.Hub, adminAuthMiddleware), adminRateLimit),
+		http.WithRateLimit(http.NewGetRoomEventsAPI(infra.AdminHub, adminAuthMiddleware), adminRateLimit),
+		http.WithRateLimit(http.NewDisconnectClientAPI(adminRemoveClientUseCase, adminAuthMiddleware), adminRateLimit),
+		http.WithRateLimit(http.NewKickClientAPI(adminKickClientUseCase, adminAuthMiddleware), adminRateLimit),
+		http.WithRateLimit(http.NewToggleOwnerAPI(adminToggleOwnerUseCase, adminAuthMiddleware), adminRateLimit),
+		http.WithRateLimit(http.NewGetAuditRecordsAPI(infra.AuditSink, adminAuthMiddleware), adminRateLimit),
+		http.WithRateLimit(http.NewGetWebhooksAPI(infra.WebhookStore, adminAuthMiddleware), adminRateLimit),
+		http.WithRateLimit(http.NewCreateWebhookAPI(infra.WebhookStore, timer.SystemClock{}, adminAuthMiddleware), adminRateLimit),
+		http.WithRateLimit(http.NewDeleteWebhookAPI(infra.WebhookStore, adminAuthMiddleware), adminRateLimit),
+		http.WithRateLimit(http.NewGetWebhookDeadLettersAPI(infra.WebhookStore, adminAuthMiddleware), adminRateLimit),
+	}
+	participantMiddleware := middleware.NewParticipantMiddleware(infra.Hub, tokenVerifier)
+	for _, api := range http.NewRoomCommandAPIs(app.Usecases, participantMiddleware) {
+		apis = append(apis, http.WithRateLimit(api, roomCommandRateLimit))
 	}
 	if cfg.Environment != "production" {
 		apis = append(apis, http.NewSwaggerAPI())
@@ -129,52 +368,230 @@ func newAPIContainer(cfg *config.Config, infra *InfraContainer, app *Application
 	}
 }
 
-func newUsecases(hub domain.Hub, lockManager lock.LockManager, metric metric.PlanningPokerMetric) usecase.UseCasesFacade {
-	updateNameUseCase := usecase.NewUpdateNameUseCase(hub)
-	voteUseCase := usecase.NewVoteUseCase(hub, lockManager)
-	revealUseCase := usecase.NewRevealUseCase(hub, lockManager)
+// newAdminKeyring gathers the keys of ADMIN_API_KEY, ADMIN_API_KEYS and
+// ADMIN_API_KEYS_FILE.
+func newAdminKeyring(cfg *config.Config) *middleware.AdminKeyring {
+	adminCfg := cfg.API.Admin
+
+	var keys []middleware.AdminKey
+	if adminCfg.APIKey != "" {
+		keys = append(keys, middleware.AdminKey{
+			Name:   middleware.DefaultAdminKeyName,
+			Key:    adminCfg.APIKey,
+			Scopes: []string{middleware.ScopeAll},
+		})
+	}
+	if adminCfg.Keys != "" {
+		configured, err := middleware.ParseAdminKeys([]byte(adminCfg.Keys))
+		if err != nil {
+			panic(err)
+		}
+		keys = append(keys, configured...)
+	}
+
+	keyring, err := middleware.NewAdminKeyring(keys, adminCfg.KeysFile, timer.SystemClock{})
+	if err != nil {
+		panic(err)
+	}
+	return keyring
+}
+
+// newTokenVerifier returns nil when no issuer is configured, every
+// participant is anonymous then.
+func newTokenVerifier(cfg *config.Config) auth.TokenVerifier {
+	authCfg := cfg.API.Auth
+	if authCfg.Issuer == "" {
+		return nil
+	}
+
+	var keys infraauth.KeySource
+	switch {
+	case authCfg.JWKSFile != "":
+		keySet, err := infraauth.LoadJWKSFile(authCfg.JWKSFile)
+		if err != nil {
+			panic(err)
+		}
+		keys = keySet
+	case authCfg.JWKSURL != "":
+		client := &nethttp.Client{Timeout: 10 * time.Second}
+		keys = infraauth.NewRemoteKeySet(authCfg.JWKSURL, client, timer.SystemClock{}, authCfg.JWKSRefreshInterval)
+	default:
+		panic("API_AUTH_JWKS_FILE or API_AUTH_JWKS_URL is required when API_AUTH_ISSUER is set")
+	}
+
+	return infraauth.NewJWTVerifier(infraauth.JWTConfig{
+		Issuer:    authCfg.Issuer,
+		Audience:  authCfg.Audience,
+		NameClaim: authCfg.NameClaim,
+	}, keys, timer.SystemClock{})
+}
+
+// newIPRateLimitMiddleware throttles the requests of each IP address to the
+// endpoints named name, all of them sharing the same buckets.
+func newIPRateLimitMiddleware(cfg *config.Config, infra *InfraContainer, app *ApplicationContainer, name string) middleware.RateLimitMiddleware {
+	rateLimitCfg := cfg.API.RateLimit
+	return middleware.NewRateLimitMiddleware(
+		name,
+		infra.RateLimiter,
+		ratelimit.Limit{Rate: rateLimitCfg.IPRate, Burst: rateLimitCfg.IPBurst},
+		app.PlanningPokerMetric,
+		cfg.API.TrustForwardedFor,
+	)
+}
+
+func newUsecases(
+	hub domain.Hub,
+	lockManager lock.LockManager,
+	deadlines timer.DeadlineStore,
+	metric metric.PlanningPokerMetric,
+	auditor audit.Auditor,
+	webhookStore webhook.Store,
+	webhooks webhook.Publisher,
+	autoCreateRoomsOnJoin bool,
+) usecase.UseCasesFacade {
+	updateNameUseCase := usecase.NewUpdateNameUseCase(hub, lockManager)
+	voteUseCase := usecase.NewVoteUseCase(hub, lockManager, webhooks)
+	revealUseCase := usecase.NewRevealUseCase(hub, lockManager, webhooks)
 	resetUseCase := usecase.NewResetUseCase(hub, lockManager)
 	toggleSpectatorUseCase := usecase.NewToggleSpectatorUseCase(hub, lockManager)
-	toggleOwnerUseCase := usecase.NewToggleOwnerUseCase(hub, lockManager)
+	toggleOwnerUseCase := usecase.NewToggleOwnerUseCase(hub, lockManager, auditor)
 	updateStoryUseCase := usecase.NewUpdateStoryUseCase(hub, lockManager)
 	newVotingUseCase := usecase.NewNewVotingUseCase(hub, lockManager)
 	voteAgainUseCase := usecase.NewVoteAgainUseCase(hub, lockManager)
-	leaveRoomUseCase := usecase.NewLeaveRoomUseCase(hub, lockManager, metric)
-	joinRoomUseCase := usecase.NewJoinRoomUseCase(hub, lockManager, metric)
+	leaveRoomUseCase := usecase.NewLeaveRoomUseCase(hub, lockManager, metric, auditor, webhooks)
+	joinRoomUseCase := usecase.NewJoinRoomUseCase(hub, lockManager, metric, autoCreateRoomsOnJoin)
 	createClientUseCase := usecase.NewCreateClientUseCase(hub, metric)
 	createRoomUseCase := usecase.NewCreateRoomUseCase(hub, metric)
 	toggleBacklogModeUseCase := usecase.NewToggleBacklogModeUseCase(hub, lockManager)
-	addStoryUseCase := usecase.NewAddStoryUseCase(hub, lockManager)
-	removeStoryUseCase := usecase.NewRemoveStoryUseCase(hub, lockManager)
+	addStoryUseCase := usecase.NewAddStoryUseCase(hub, lockManager, webhooks)
+	removeStoryUseCase := usecase.NewRemoveStoryUseCase(hub, lockManager, auditor)
 	advanceStoryUseCase := usecase.NewAdvanceStoryUseCase(hub, lockManager)
 	prevStoryUseCase := usecase.NewPrevStoryUseCase(hub, lockManager)
+	changeDeckUseCase := usecase.NewChangeDeckUseCase(hub, lockManager)
+	changeConsensusRuleUseCase := usecase.NewChangeConsensusRuleUseCase(hub, lockManager)
+	startVotingTimerUseCase := usecase.NewStartVotingTimerUseCase(hub, lockManager, deadlines, timer.SystemClock{})
+	cancelVotingTimerUseCase := usecase.NewCancelVotingTimerUseCase(hub, lockManager, deadlines)
+	exportReportUseCase := usecase.NewExportReportUseCase(hub)
+	setPasscodeUseCase := usecase.NewSetPasscodeUseCase(hub, lockManager)
+	createInviteUseCase := usecase.NewCreateInviteUseCase(hub, lockManager, timer.SystemClock{})
+	revokeInvitesUseCase := usecase.NewRevokeInvitesUseCase(hub, lockManager)
+	changePermissionUseCase := usecase.NewChangePermissionUseCase(hub, lockManager)
+	resyncUseCase := usecase.NewResyncUseCase(hub)
+	addBotUseCase := usecase.NewAddBotUseCase(hub, lockManager)
+	removeBotUseCase := usecase.NewRemoveBotUseCase(hub, lockManager)
+	createWebhookUseCase := usecase.NewCreateWebhookUseCase(hub, webhookStore, timer.SystemClock{})
+	listWebhooksUseCase := usecase.NewListWebhooksUseCase(hub, webhookStore)
+	deleteWebhookUseCase := usecase.NewDeleteWebhookUseCase(hub, webhookStore)
 
 	return usecase.UseCasesFacade{
-		UpdateName:        usecasedecorators.NewTraceableUseCase(updateNameUseCase, "UpdateNameUseCase", "UpdateName"),
-		Vote:              usecasedecorators.NewTraceableUseCase(voteUseCase, "VoteUseCase", "Vote"),
-		Reveal:            usecasedecorators.NewTraceableUseCase(revealUseCase, "RevealUseCase", "Reveal"),
-		Reset:             usecasedecorators.NewTraceableUseCase(resetUseCase, "ResetUseCase", "Reset"),
-		ToggleSpectator:   usecasedecorators.NewTraceableUseCase(toggleSpectatorUseCase, "ToggleSpectatorUseCase", "ToggleSpectator"),
-		ToggleOwner:       usecasedecorators.NewTraceableUseCase(toggleOwnerUseCase, "ToggleOwnerUseCase", "ToggleOwner"),
-		UpdateStory:       usecasedecorators.NewTraceableUseCase(updateStoryUseCase, "UpdateStoryUseCase", "UpdateStory"),
-		NewVoting:         usecasedecorators.NewTraceableUseCase(newVotingUseCase, "NewVotingUseCase", "NewVoting"),
-		VoteAgain:         usecasedecorators.NewTraceableUseCase(voteAgainUseCase, "VoteAgainUseCase", "VoteAgain"),
-		LeaveRoom:         usecasedecorators.NewTraceableUseCase(leaveRoomUseCase, "LeaveRoomUseCase", "LeaveRoom"),
-		JoinRoom:          usecasedecorators.NewTraceableUseCaseR(joinRoomUseCase, "JoinRoomUseCase", "JoinRoom"),
-		CreateClient:      usecasedecorators.NewTraceableUseCaseO(createClientUseCase, "CreateClientUseCase", "CreateClient"),
-		CreateRoom:        usecasedecorators.NewTraceableUseCaseO(createRoomUseCase, "CreateRoomUseCase", "CreateRoom"),
-		ToggleBacklogMode: usecasedecorators.NewTraceableUseCase(toggleBacklogModeUseCase, "ToggleBacklogModeUseCase", "ToggleBacklogMode"),
-		AddStory:          usecasedecorators.NewTraceableUseCase(addStoryUseCase, "AddStoryUseCase", "AddStory"),
-		RemoveStory:       usecasedecorators.NewTraceableUseCase(removeStoryUseCase, "RemoveStoryUseCase", "RemoveStory"),
-		AdvanceStory:      usecasedecorators.NewTraceableUseCase(advanceStoryUseCase, "AdvanceStoryUseCase", "AdvanceStory"),
-		PrevStory:         usecasedecorators.NewTraceableUseCase(prevStoryUseCase, "PrevStoryUseCase", "PrevStory"),
-	}
-}
-
-func newWebsocketBusFactory(cfg *config.Config, infra *InfraContainer, app *ApplicationContainer) *bus.WebSocketBusFactory {
-	return bus.NewWebSocketBusFactory(infra.Hub, app.Usecases, bus.WebSocketConfig{
-		WriteTimeout: cfg.API.PlanningPoker.WebsocketWriteTimeout,
-		ReadTimeout:  cfg.API.PlanningPoker.WebsocketReadTimeout,
-		PingInterval: cfg.API.PlanningPoker.WebsocketPingInterval,
-	})
+		UpdateName:          usecasedecorators.NewTraceableUseCase(updateNameUseCase, "UpdateNameUseCase", "UpdateName"),
+		Vote:                usecasedecorators.NewTraceableUseCase(voteUseCase, "VoteUseCase", "Vote"),
+		Reveal:              usecasedecorators.NewTraceableUseCase(revealUseCase, "RevealUseCase", "Reveal"),
+		Reset:               usecasedecorators.NewTraceableUseCase(resetUseCase, "ResetUseCase", "Reset"),
+		ToggleSpectator:     usecasedecorators.NewTraceableUseCase(toggleSpectatorUseCase, "ToggleSpectatorUseCase", "ToggleSpectator"),
+		ToggleOwner:         usecasedecorators.NewTraceableUseCase(toggleOwnerUseCase, "ToggleOwnerUseCase", "ToggleOwner"),
+		UpdateStory:         usecasedecorators.NewTraceableUseCase(updateStoryUseCase, "UpdateStoryUseCase", "UpdateStory"),
+		NewVoting:           usecasedecorators.NewTraceableUseCase(newVotingUseCase, "NewVotingUseCase", "NewVoting"),
+		VoteAgain:           usecasedecorators.NewTraceableUseCase(voteAgainUseCase, "VoteAgainUseCase", "VoteAgain"),
+		LeaveRoom:           usecasedecorators.NewTraceableUseCase(leaveRoomUseCase, "LeaveRoomUseCase", "LeaveRoom"),
+		JoinRoom:            usecasedecorators.NewTraceableUseCaseR(joinRoomUseCase, "JoinRoomUseCase", "JoinRoom"),
+		CreateClient:        usecasedecorators.NewTraceableUseCaseO(createClientUseCase, "CreateClientUseCase", "CreateClient"),
+		CreateRoom:          usecasedecorators.NewTraceableUseCaseR(createRoomUseCase, "CreateRoomUseCase", "CreateRoom"),
+		ToggleBacklogMode:   usecasedecorators.NewTraceableUseCase(toggleBacklogModeUseCase, "ToggleBacklogModeUseCase", "ToggleBacklogMode"),
+		AddStory:            usecasedecorators.NewTraceableUseCase(addStoryUseCase, "AddStoryUseCase", "AddStory"),
+		RemoveStory:         usecasedecorators.NewTraceableUseCase(removeStoryUseCase, "RemoveStoryUseCase", "RemoveStory"),
+		AdvanceStory:        usecasedecorators.NewTraceableUseCase(advanceStoryUseCase, "AdvanceStoryUseCase", "AdvanceStory"),
+		PrevStory:           usecasedecorators.NewTraceableUseCase(prevStoryUseCase, "PrevStoryUseCase", "PrevStory"),
+		ChangeDeck:          usecasedecorators.NewTraceableUseCase(changeDeckUseCase, "ChangeDeckUseCase", "ChangeDeck"),
+		ChangeConsensusRule: usecasedecorators.NewTraceableUseCase(changeConsensusRuleUseCase, "ChangeConsensusRuleUseCase", "ChangeConsensusRule"),
+		StartVotingTimer:    usecasedecorators.NewTraceableUseCase(startVotingTimerUseCase, "StartVotingTimerUseCase", "StartVotingTimer"),
+		CancelVotingTimer:   usecasedecorators.NewTraceableUseCase(cancelVotingTimerUseCase, "CancelVotingTimerUseCase", "CancelVotingTimer"),
+		ExportReport:        usecasedecorators.NewTraceableUseCase(exportReportUseCase, "ExportReportUseCase", "ExportReport"),
+		SetPasscode:         usecasedecorators.NewTraceableUseCase(setPasscodeUseCase, "SetPasscodeUseCase", "SetPasscode"),
+		CreateInvite:        usecasedecorators.NewTraceableUseCase(createInviteUseCase, "CreateInviteUseCase", "CreateInvite"),
+		RevokeInvites:       usecasedecorators.NewTraceableUseCase(revokeInvitesUseCase, "RevokeInvitesUseCase", "RevokeInvites"),
+		ChangePermission:    usecasedecorators.NewTraceableUseCase(changePermissionUseCase, "ChangePermissionUseCase", "ChangePermission"),
+		Resync:              usecasedecorators.NewTraceableUseCase(resyncUseCase, "ResyncUseCase", "Resync"),
+		AddBot:              usecasedecorators.NewTraceableUseCaseR(addBotUseCase, "AddBotUseCase", "AddBot"),
+		RemoveBot:           usecasedecorators.NewTraceableUseCase(removeBotUseCase, "RemoveBotUseCase", "RemoveBot"),
+		CreateWebhook:       usecasedecorators.NewTraceableUseCaseR(createWebhookUseCase, "CreateWebhookUseCase", "CreateWebhook"),
+		ListWebhooks:        usecasedecorators.NewTraceableUseCaseR(listWebhooksUseCase, "ListWebhooksUseCase", "ListWebhooks"),
+		DeleteWebhook:       usecasedecorators.NewTraceableUseCase(deleteWebhookUseCase, "DeleteWebhookUseCase", "DeleteWebhook"),
+	}
+}
+
+// newBusConfig configures both transports, the event streams sending their
+// keepalives every ping interval.
+func newBusConfig(cfg *config.Config, infra *InfraContainer, app *ApplicationContainer) bus.WebSocketConfig {
+	return bus.WebSocketConfig{
+		WriteTimeout:   cfg.API.PlanningPoker.WebsocketWriteTimeout,
+		ReadTimeout:    cfg.API.PlanningPoker.WebsocketReadTimeout,
+		PingInterval:   cfg.API.PlanningPoker.WebsocketPingInterval,
+		MaxMessageSize: cfg.API.PlanningPoker.WebsocketMaxMessageSize,
+		RateLimit: bus.RateLimit{
+			Limiter: infra.RateLimiter,
+			Client:  ratelimit.Limit{Rate: cfg.API.RateLimit.ClientRate, Burst: cfg.API.RateLimit.ClientBurst},
+			Room:    ratelimit.Limit{Rate: cfg.API.RateLimit.RoomRate, Burst: cfg.API.RateLimit.RoomBurst},
+			Metric:  app.PlanningPokerMetric,
+		},
+	}
+}
+
+func newVotingTimerWatcher(cfg *config.Config, infra *InfraContainer, app *ApplicationContainer) *infratimer.Watcher {
+	expireVotingTimerUseCase := usecasedecorators.NewTraceableUseCase(
+		usecase.NewExpireVotingTimerUseCase(infra.Hub, infra.LockManager, infra.DeadlineStore, timer.SystemClock{}, app.Webhooks),
+		"ExpireVotingTimerUseCase",
+		"ExpireVotingTimer",
+	)
+
+	return infratimer.NewWatcher(
+		infra.DeadlineStore,
+		expireVotingTimerUseCase,
+		timer.SystemClock{},
+		cfg.API.PlanningPoker.VotingTimerPollInterval,
+	)
+}
+
+func roomLifecycle(cfg *config.Config) entity.RoomLifecycle {
+	return entity.RoomLifecycle{
+		IdleTimeout:  cfg.API.PlanningPoker.RoomIdleTimeout,
+		MaxLifetime:  cfg.API.PlanningPoker.RoomMaxLifetime,
+		EmptyRoomTTL: cfg.API.PlanningPoker.EmptyRoomTTL,
+	}
+}
+
+func newRoomReaper(cfg *config.Config, infra *InfraContainer, app *ApplicationContainer) *lifecycle.Reaper {
+	roomLifecycle := roomLifecycle(cfg)
+	if !roomLifecycle.Enabled() {
+		return nil
+	}
+	if hub, ok := infra.Hub.(interface{ SetLifecycle(entity.RoomLifecycle) }); ok {
+		hub.SetLifecycle(roomLifecycle)
+	}
+	if roomLifecycle.KeepsEmptyRooms() {
+		if hub, ok := infra.Hub.(interface{ KeepEmptyRooms() }); ok {
+			hub.KeepEmptyRooms()
+		}
+	}
+
+	closeExpiredRoomUseCase := usecasedecorators.NewTraceableUseCase(
+		usecase.NewCloseExpiredRoomUseCase(infra.Hub, infra.LockManager, app.PlanningPokerMetric, roomLifecycle, timer.SystemClock{}, app.Webhooks),
+		"CloseExpiredRoomUseCase",
+		"CloseExpiredRoom",
+	)
+	warnRoomExpiringUseCase := usecasedecorators.NewTraceableUseCase(
+		usecase.NewWarnRoomExpiringUseCase(infra.Hub),
+		"WarnRoomExpiringUseCase",
+		"WarnRoomExpiring",
+	)
+
+	return lifecycle.NewReaper(
+		infra.AdminHub,
+		roomLifecycle,
+		cfg.API.PlanningPoker.RoomExpiryWarning,
+		closeExpiredRoomUseCase,
+		warnRoomExpiringUseCase,
+		timer.SystemClock{},
+		cfg.API.PlanningPoker.RoomReapInterval,
+	)
 }
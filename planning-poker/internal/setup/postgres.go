@@ -0,0 +1,42 @@
+package setup
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"planning-poker/internal/config"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+func NewPostgresPool(cfg *config.Config) (*pgxpool.Pool, error) {
+	dsn := url.URL{
+		Scheme: "postgres",
+		User:   url.UserPassword(cfg.Postgres.User, cfg.Postgres.Password),
+		Host:   fmt.Sprintf("%s:%d", cfg.Postgres.Host, cfg.Postgres.Port),
+		Path:   "/" + cfg.Postgres.Database,
+	}
+	if cfg.Postgres.SSLMode != "" {
+		dsn.RawQuery = url.Values{"sslmode": {cfg.Postgres.SSLMode}}.Encode()
+	}
+
+	poolCfg, err := pgxpool.ParseConfig(dsn.String())
+	if err != nil {
+		return nil, fmt.Errorf("invalid Postgres configuration: %w", err)
+	}
+	if cfg.Postgres.MaxConns > 0 {
+		poolCfg.MaxConns = cfg.Postgres.MaxConns
+	}
+
+	ctx := context.Background()
+	pool, err := pgxpool.NewWithConfig(ctx, poolCfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to Postgres: %w", err)
+	}
+	if err := pool.Ping(ctx); err != nil {
+		pool.Close()
+		return nil, fmt.Errorf("failed to connect to Postgres: %w", err)
+	}
+
+	return pool, nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"planning-poker/internal/domain"
+	"planning-poker/internal/domain/entity"
 	redishub "planning-poker/internal/infra/boundaries/hub/redis"
 	"testing"
 	"time"
@@ -273,7 +274,7 @@ func TestIntegration_BroadcastToRoom(t *testing.T) {
 	}
 }
 
-func TestIntegration_GetRooms(t *testing.T) {
+func TestIntegration_ListRooms(t *testing.T) {
 	client := setupRedisClient()
 	defer client.Close()
 
@@ -281,7 +282,7 @@ func TestIntegration_GetRooms(t *testing.T) {
 	assert.NoError(t, err)
 
 	// Initially empty
-	rooms := hub.GetRooms()
+	rooms := listAllRooms(t, hub, domain.RoomQuery{})
 	assert.Empty(t, rooms)
 
 	// Create multiple rooms
@@ -292,8 +293,8 @@ func TestIntegration_GetRooms(t *testing.T) {
 	room3, err := hub.NewRoom(context.Background())
 	assert.NoError(t, err)
 
-	// Get all rooms
-	rooms = hub.GetRooms()
+	// Walk every page
+	rooms = listAllRooms(t, hub, domain.RoomQuery{Limit: 2})
 	assert.Len(t, rooms, 3)
 
 	// Verify all rooms are present
@@ -304,6 +305,25 @@ func TestIntegration_GetRooms(t *testing.T) {
 	assert.True(t, roomIDs[room1.ID])
 	assert.True(t, roomIDs[room2.ID])
 	assert.True(t, roomIDs[room3.ID])
+
+	// Sorted pages
+	page, err := hub.ListRooms(context.Background(), domain.RoomQuery{Limit: 2, SortBy: domain.RoomSortID})
+	assert.NoError(t, err)
+	assert.Len(t, page.Rooms, 2)
+	assert.Equal(t, "2", page.NextCursor)
+}
+
+func listAllRooms(t *testing.T, hub *redishub.RedisHub, query domain.RoomQuery) []*entity.Room {
+	var rooms []*entity.Room
+	for {
+		page, err := hub.ListRooms(context.Background(), query)
+		assert.NoError(t, err)
+		rooms = append(rooms, page.Rooms...)
+		if err != nil || page.NextCursor == "" {
+			return rooms
+		}
+		query.Cursor = page.NextCursor
+	}
 }
 
 func TestIntegration_SaveRoom(t *testing.T) {